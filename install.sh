@@ -1,7 +1,8 @@
 #!/bin/bash
 #
 # OwliaBot Docker installer
-# Checks Docker environment, then runs onboard inside the container
+# Checks the container runtime (Docker, or Podman as a fallback), then runs
+# onboard inside the container
 #
 
 set -euo pipefail
@@ -20,6 +21,47 @@ OWLIABOT_TAG="${OWLIABOT_TAG:-}"
 BUILD_LOCAL=false
 BUILD_BRANCH="main"
 LIST_TAGS=false
+RUNTIME="${OWLIABOT_RUNTIME:-}"  # docker | podman (auto-detected when empty)
+CONTAINER_CLI=""                 # resolved runtime binary
+COMPOSE_CMD=""                   # resolved compose command
+RUN_ARGS=()                      # extra args for one-off `run` containers
+NOTIFY="${OWLIABOT_NOTIFY:-true}"  # bell + desktop notification after slow steps
+NOTIFY_AFTER_SECONDS=20          # only notify when a step took at least this long
+NOTIFY_URL="${OWLIABOT_NOTIFY_URL:-}"    # webhook for onboarding's setup summary, sent once healthy
+NOTIFY_HOST="${OWLIABOT_NOTIFY_HOST:-}"  # host name in that summary (default: the engine's host)
+READY_TIMEOUT="${OWLIABOT_READY_TIMEOUT:-120}"  # seconds to wait for "Gateway ready"
+UNHEALTHY_WINDOW=60              # "unhealthy" this early means the start failed
+CA_BUNDLE="${OWLIABOT_CA_BUNDLE:-}"  # extra PEM CA bundle for outbound HTTPS (corporate proxies)
+CURL_ARGS=()                     # extra args for curl (--cacert)
+SWARM_ACTIVE=false               # engine runs in swarm mode (docker only)
+STACK_MODE=false                 # onboarding wrote docker-stack.yml
+LOGS_TAIL="${OWLIABOT_LOGS_TAIL:-100}"  # log lines shown before following in the log viewer
+SAFE_MODE=""                     # 1: start without write tools, exec and MCP (compose only)
+case "${OWLIABOT_SAFE_MODE:-}" in 1|true|yes) SAFE_MODE=1 ;; esac
+PROFILE="${OWLIABOT_PROFILE:-}"  # named profile: a second bot next to the default one
+CONFIG_DIR_NAME=".owliabot"      # per profile, set by resolve_profile
+CONFIG_DIR="$HOME/.owliabot"
+CONTAINER_NAME="owliabot"
+COMPOSE_FILE="docker-compose.yml"
+DOCKER_TARGET="${OWLIABOT_DOCKER_HOST:-}"  # docker context or ssh://user@host to install on
+REMOTE_DEST=""                   # user@host behind a remote engine (empty: this machine)
+REMOTE_PORT=""                   # its SSH port, when not the default
+OUTPUT_DIR="$(pwd)"              # where onboarding writes the compose file, on the engine's host
+IMAGE_PLATFORM=""                # linux/amd64 when an ARM host runs an amd64-only tag under emulation
+DEMO=""                          # 1: demo provider instead of a real one, no API keys asked
+case "${OWLIABOT_DEMO:-}" in 1|true|yes) DEMO=1 ;; esac
+REGISTRY_USER="${OWLIABOT_REGISTRY_USER:-}"          # login for a private OWLIABOT_IMAGE registry
+REGISTRY_PASSWORD="${OWLIABOT_REGISTRY_PASSWORD:-}"  # its password or token (env only, never a flag)
+VERIFY_SIGNATURE=""              # 1: pull only an image whose cosign signature verifies
+case "${OWLIABOT_VERIFY_SIGNATURE:-}" in 1|true|yes) VERIFY_SIGNATURE=1 ;; esac
+COSIGN_KEY="${OWLIABOT_COSIGN_KEY:-}"  # public key to verify against (default: the release workflow)
+COSIGN_IDENTITY='^https://github\.com/owliabot/owliabot/\.github/workflows/docker\.yml@'
+COSIGN_ISSUER="https://token.actions.githubusercontent.com"
+VERIFIED_DIGEST=""               # digest the signature check covered; pulled by digest, then tagged
+ACCESSIBLE=""                    # 1: plain output (no colors, art or symbols) for screen readers
+case "${OWLIABOT_ACCESSIBLE:-}" in 1|true|yes) ACCESSIBLE=1 ;; esac
+if [ "${TERM:-}" = "dumb" ] && [ -t 1 ]; then ACCESSIBLE=1; fi
+THEME="${OWLIABOT_THEME:-}"      # dark | light | high-contrast | mono (empty: dark, or mono under NO_COLOR)
 
 # Colors
 RED='\033[0;31m'
@@ -29,14 +71,45 @@ BLUE='\033[0;34m'
 CYAN='\033[0;36m'
 NC='\033[0m'
 
-info() { echo -e "${BLUE}i${NC} $1"; }
-success() { echo -e "${GREEN}✓${NC} $1"; }
-warn() { echo -e "${YELLOW}!${NC} $1"; }
-error() { echo -e "${RED}✗${NC} $1"; }
+# No colors at all: the mono theme, and accessible mode (where the helpers
+# below also print words instead of symbols).
+use_plain_output() {
+  RED='' GREEN='' YELLOW='' BLUE='' CYAN='' NC=''
+}
+
+# Swap the colors above for the --theme palette (the same ones as `onboard --theme`).
+apply_theme() {
+  local name="$THEME"
+  if [ -z "$name" ] && { [ -n "${NO_COLOR:-}" ] || [ "${CLICOLOR:-}" = "0" ]; }; then
+    name="mono"
+  fi
+  case "${name:-dark}" in
+    dark) ;;
+    light)
+      YELLOW='\033[0;33m'
+      CYAN='\033[1;34m'
+      ;;
+    high-contrast)
+      RED='\033[1;91m' GREEN='\033[1;92m' YELLOW='\033[1;93m' BLUE='\033[1;94m' CYAN='\033[1;96m'
+      ;;
+    mono) use_plain_output ;;
+    *) die "--theme must be one of: dark, light, high-contrast, mono" ;;
+  esac
+}
+
+info() { if [ -n "$ACCESSIBLE" ]; then echo -e "Note: $1"; else echo -e "${BLUE}i${NC} $1"; fi; }
+success() { if [ -n "$ACCESSIBLE" ]; then echo -e "Done: $1"; else echo -e "${GREEN}✓${NC} $1"; fi; }
+warn() { if [ -n "$ACCESSIBLE" ]; then echo -e "Warning: $1"; else echo -e "${YELLOW}!${NC} $1"; fi; }
+error() { if [ -n "$ACCESSIBLE" ]; then echo -e "Error: $1"; else echo -e "${RED}✗${NC} $1"; fi; }
 die() { error "$1"; exit 1; }
 
 header() {
   echo ""
+  if [ -n "$ACCESSIBLE" ]; then
+    echo "Step: $1"
+    echo ""
+    return 0
+  fi
   echo -e "${CYAN}━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━${NC}"
   echo -e "${CYAN}  $1${NC}"
   echo -e "${CYAN}━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━${NC}"
@@ -55,6 +128,11 @@ big_success_banner() {
   fi
 
   echo ""
+  if [ -n "$ACCESSIBLE" ]; then
+    echo "OwliaBot is running!"
+    echo ""
+    return 0
+  fi
   if [ "${cols}" -lt 74 ]; then
     printf "%b\n" "${CYAN}+----------------------+${NC}"
     printf "%b\n" "${CYAN}| OwliaBot is running! |${NC}"
@@ -73,34 +151,504 @@ big_success_banner() {
   echo ""
 }
 
-check_docker() {
-  header "Checking Docker environment"
-  
-  # Check if docker command exists
-  if ! command -v docker &>/dev/null; then
-    error "Docker is not installed."
-    echo ""
-    info "Please install Docker first:"
-    echo ""
-    echo "  macOS:   brew install --cask docker"
-    echo "           or download from https://docs.docker.com/desktop/mac/install/"
-    echo ""
-    echo "  Ubuntu/Debian:"
-    echo "           curl -fsSL https://get.docker.com | sudo sh"
-    echo "           sudo usermod -aG docker \$USER"
-    echo "           # Log out and back in for group change to take effect"
-    echo ""
-    echo "  Other Linux:"
-    echo "           https://docs.docker.com/engine/install/"
-    echo ""
-    echo "  Windows: https://docs.docker.com/desktop/windows/install/"
+# Ring the terminal bell and show a desktop notification once a slow step
+# (image pull/build, container start) finishes, so users who switched to
+# another window know the installer is waiting for them again.
+# Usage: notify_done <started-at SECONDS> <message>
+notify_done() {
+  local started="$1" message="$2"
+  [ "$NOTIFY" = "true" ] || return 0
+  [ $((SECONDS - started)) -ge "$NOTIFY_AFTER_SECONDS" ] || return 0
+
+  if [ -w /dev/tty ]; then
+    { printf '\a' > /dev/tty; } 2>/dev/null || true
+  fi
+  if [ "$(uname -s)" = "Darwin" ] && command -v osascript &>/dev/null; then
+    local escaped="${message//\\/\\\\}"
+    escaped="${escaped//\"/\\\"}"
+    osascript -e "display notification \"${escaped}\" with title \"OwliaBot\"" &>/dev/null || true
+  elif command -v notify-send &>/dev/null && [ -n "${DISPLAY:-}${WAYLAND_DISPLAY:-}" ]; then
+    notify-send "OwliaBot" "$message" &>/dev/null || true
+  fi
+}
+
+# Mask credential shapes (API keys, bot tokens, AWS key IDs, bearer tokens)
+# in container and compose output before it is printed. Mirrors the
+# patterns in src/utils/redact.ts. Reads stdin.
+redact_secrets() {
+  sed -E \
+    -e 's/sk-[A-Za-z0-9_-]{16,}/[REDACTED]/g' \
+    -e 's/xox[abprs]-[A-Za-z0-9-]{10,}/[REDACTED]/g' \
+    -e 's/xapp-[A-Za-z0-9-]{10,}/[REDACTED]/g' \
+    -e 's/github_pat_[A-Za-z0-9_]{20,}/[REDACTED]/g' \
+    -e 's/gh[pousr]_[A-Za-z0-9]{20,}/[REDACTED]/g' \
+    -e 's/(AKIA|ASIA)[0-9A-Z]{16}/[REDACTED]/g' \
+    -e 's/[0-9]{6,}:[A-Za-z0-9_-]{30,}/[REDACTED]/g' \
+    -e 's#(Bearer|Basic)[[:space:]]+[A-Za-z0-9._~+/=-]{8,}#\1 [REDACTED]#g'
+}
+
+# Show why the bot didn't come up: the last 30 log lines in an error card,
+# sorted into a likely cause (auth, config parse, port bind) with what to do
+# next. Usage: startup_failure_card <title> <logs>
+startup_failure_card() {
+  local title="$1" logs="$2" cause="Unknown" hint=""
+
+  if grep -qiE "EADDRINUSE|address already in use|port is already allocated" <<< "$logs"; then
+    cause="Port bind"
+    hint="Another process holds the gateway port. Stop it, or run this installer again and pick another port."
+  elif grep -qiE "No API key found|unauthorized|invalid.{0,20}(api[ _-]?key|token)|authentication (failed|error)|(status|code|HTTP)[ :]*401" <<< "$logs"; then
+    cause="Auth error"
+    hint="A provider key or channel token was rejected. Fix it in ${CONFIG_DIR}/secrets.yaml (or run this installer again), then: ${COMPOSE_CMD} restart"
+  elif grep -qiE "Invalid config|Failed to (load|parse)|YAMLParseError|ZodError|Unexpected token" <<< "$logs"; then
+    cause="Config parse error"
+    hint="app.yaml or secrets.yaml doesn't load. Check them with: ${CONTAINER_CLI} run --rm -v ${CONFIG_DIR}:/home/owliabot/.owliabot ${OWLIABOT_IMAGE} validate"
+  else
+    hint="Follow the full logs with: ${COMPOSE_CMD} logs -f"
+  fi
+
+  echo ""
+  echo -e "${RED}┌─ ${title}${NC}"
+  echo -e "${RED}│${NC} Likely cause: ${cause}"
+  echo -e "${RED}│${NC}"
+  tail -n 30 <<< "$logs" | redact_secrets | while IFS= read -r line; do printf '%b   %s\n' "${RED}│${NC}" "$line"; done
+  echo -e "${RED}│${NC}"
+  echo -e "${RED}│${NC} Next: ${hint}"
+  echo -e "${RED}└─${NC}"
+}
+
+# Wait until the bot can actually answer, not just until the container runs.
+# Follows the startup log markers (see src/gateway/server.ts) with a one-line
+# progress view. Returns 1 if the container exits, fails its healthcheck in
+# the first minute, or the timeout passes.
+wait_for_ready() {
+  local container="$1" started=$SECONDS stage="Starting" logs="" elapsed=0
+
+  while :; do
+    elapsed=$((SECONDS - started))
+    logs="$("$CONTAINER_CLI" logs "$container" 2>&1 || true)"
+
+    if grep -q "Gateway ready" <<< "$logs"; then
+      printf '\r\033[K'
+      success "Bot is ready (${elapsed}s)"
+      return 0
+    fi
+    if grep -q "Gateway started" <<< "$logs"; then
+      stage="Channels connected, starting scheduler"
+    elif grep -q "Gateway HTTP server listening" <<< "$logs"; then
+      stage="Connecting channels"
+    elif grep -q "Loading config" <<< "$logs"; then
+      stage="Loading config and tools"
+    fi
+
+    if [ "$("$CONTAINER_CLI" inspect -f '{{.State.Running}}' "$container" 2>/dev/null || echo false)" != "true" ]; then
+      printf '\r\033[K'
+      startup_failure_card "The container stopped during startup" "$logs"
+      return 1
+    fi
+    # The compose healthcheck polls /health; failing it right away is a start failure too.
+    if [ "$elapsed" -le "$UNHEALTHY_WINDOW" ] \
+      && [ "$("$CONTAINER_CLI" inspect -f '{{if .State.Health}}{{.State.Health.Status}}{{end}}' "$container" 2>/dev/null || true)" = "unhealthy" ]; then
+      printf '\r\033[K'
+      startup_failure_card "The container turned unhealthy after ${elapsed}s" "$logs"
+      return 1
+    fi
+    if [ "$elapsed" -ge "$READY_TIMEOUT" ]; then
+      printf '\r\033[K'
+      warn "Not ready after ${READY_TIMEOUT}s (last step: ${stage}). It may still be starting."
+      return 1
+    fi
+
+    printf '\r\033[K%b' "${BLUE}i${NC} ${stage}... ${elapsed}s"
+    sleep 2
+  done
+}
+
+# curl on the engine's host, where the gateway port is published
+host_curl() {
+  if is_remote; then
+    remote curl "$@" < /dev/null
+  else
+    curl "$@"
+  fi
+}
+
+# "Gateway ready" in the logs means the bot started inside the container;
+# this checks it can also be reached from the host, through the published
+# port. Polls /health for up to 60s. Skipped when nothing publishes the
+# gateway port (e.g. it sits behind the oauth2-proxy sidecar).
+verify_gateway_health() {
+  local published addr started=$SECONDS
+  published="$(${COMPOSE_CMD} port owliabot 8787 2>/dev/null | head -n1 || true)"
+  [ -n "$published" ] || return 0
+  addr="127.0.0.1:${published##*:}"
+  if is_remote && ! remote "command -v curl" < /dev/null &>/dev/null; then
+    info "No curl on ${REMOTE_DEST}; skipped the ${addr}/health check"
+    return 0
+  fi
+
+  while [ $((SECONDS - started)) -lt 60 ]; do
+    if host_curl -fsS --max-time 3 "http://${addr}/health" &>/dev/null \
+      || host_curl -fsSk --max-time 3 "https://${addr}/health" &>/dev/null; then
+      success "Running & healthy (${addr}/health answers)"
+      return 0
+    fi
+    sleep 2
+  done
+  startup_failure_card "${addr}/health did not answer from the host within 60s" \
+    "$("$CONTAINER_CLI" logs --tail 50 "$CONTAINER_NAME" 2>&1 || true)"
+  return 1
+}
+
+# The IANA zone of the machine the bot runs on, for onboarding's timezone
+# default: inside the container /etc/localtime is the image's. Empty when
+# it can't be told.
+host_timezone() {
+  local zone=""
+  if is_remote; then
+    zone="$(remote "readlink /etc/localtime || cat /etc/timezone" < /dev/null 2>/dev/null | head -n1 || true)"
+  elif [ -n "${TZ:-}" ]; then
+    zone="${TZ#:}"
+  else
+    zone="$(readlink /etc/localtime 2>/dev/null || cat /etc/timezone 2>/dev/null || true)"
+  fi
+  printf '%s' "${zone#*zoneinfo/}"
+}
+
+# POST the setup summary onboarding saved for --notify-url, now that the bot
+# answers. Sent once, without retries, so the inventory counts each install
+# once; only the URL's origin is printed, in case the rest carries a token.
+send_setup_notification() {
+  [ -n "$NOTIFY_URL" ] || return 0
+  local payload origin
+  payload="$(read_config_file setup-notification.json)"
+  [ -n "$payload" ] || return 0
+  origin="$(printf '%s' "$NOTIFY_URL" | sed -E 's|^(https?://[^/?#]+).*|\1|')"
+  if printf '%s' "$payload" | curl -fsS --max-time 10 ${CURL_ARGS[@]+"${CURL_ARGS[@]}"} \
+    -X POST -H "Content-Type: application/json" --data-binary @- "$NOTIFY_URL" &>/dev/null; then
+    success "Sent the setup summary to ${origin}"
+    if is_remote; then
+      remote "rm -f '${CONFIG_DIR}/setup-notification.json'" < /dev/null || true
+    else
+      rm -f "${CONFIG_DIR}/setup-notification.json"
+    fi
+  else
+    warn "Couldn't send the setup summary to ${origin}; it is kept in ${CONFIG_DIR}/setup-notification.json"
+  fi
+}
+
+# Offer to follow the bot's logs right after it started, so users can see it
+# connect without opening another terminal. Paged through `less` when present
+# (scroll with the arrows / PgUp, q quits); Ctrl+C stops following either way
+# and returns to the installer instead of aborting it.
+offer_log_viewer() {
+  [ -r /dev/tty ] || return 0
+  local answer=""
+  printf '%b' "${BLUE}?${NC} View the bot's logs now? [y/N] "
+  read -r answer < /dev/tty || return 0
+  case "$answer" in
+    y|Y|yes|YES) ;;
+    *) return 0 ;;
+  esac
+
+  trap ':' INT
+  if command -v less &>/dev/null; then
+    info "Showing the last ${LOGS_TAIL} lines and following. Ctrl+C stops following, q exits."
+    ${COMPOSE_CMD} logs -f --tail "$LOGS_TAIL" 2>&1 | less -R +F || true
+  else
+    info "Showing the last ${LOGS_TAIL} lines and following. Press Ctrl+C to stop."
+    ${COMPOSE_CMD} logs -f --tail "$LOGS_TAIL" || true
+  fi
+  trap cleanup INT TERM
+  echo ""
+}
+
+# Offer an end-to-end check of the chat side: the bot posts "OwliaBot is
+# online" to an allow-listed Discord channel/user or Telegram chat, which
+# proves the token, the invite/Start and the target ID in one go. Runs
+# `owliabot test-message` inside the container (it asks which target).
+offer_test_message() {
+  [ -r /dev/tty ] || return 0
+  read_config_file app.yaml | grep -qE '^(discord|telegram):' || return 0
+  local answer=""
+  printf '%b' "${BLUE}?${NC} Send a test message to Discord/Telegram now? [y/N] "
+  read -r answer < /dev/tty || return 0
+  case "$answer" in
+    y|Y|yes|YES) ;;
+    *) return 0 ;;
+  esac
+
+  if ! "$CONTAINER_CLI" exec -it "$CONTAINER_NAME" owliabot test-message < /dev/tty; then
+    warn "The test message was not delivered. Fix the cause above, then retry with:"
+    echo "     ${CONTAINER_CLI} exec -it ${CONTAINER_NAME} owliabot test-message"
+  fi
+  echo ""
+}
+
+print_install_help() {
+  info "Please install Docker (or Podman) first:"
+  echo ""
+  echo "  macOS:   brew install --cask docker"
+  echo "           or download from https://docs.docker.com/desktop/mac/install/"
+  echo ""
+  echo "  Ubuntu/Debian:"
+  echo "           curl -fsSL https://get.docker.com | sudo sh"
+  echo "           sudo usermod -aG docker \$USER"
+  echo "           # Log out and back in for group change to take effect"
+  echo ""
+  echo "  Other Linux:"
+  echo "           https://docs.docker.com/engine/install/"
+  echo ""
+  echo "  Windows: https://docs.docker.com/desktop/windows/install/"
+  echo ""
+  echo "  Podman:  https://podman.io/docs/installation"
+  echo "           (plus podman-compose, or Docker Compose for 'podman compose')"
+  echo ""
+}
+
+select_runtime() {
+  if [ -n "$RUNTIME" ]; then
+    case "$RUNTIME" in
+      docker|podman) ;;
+      *) die "Unknown runtime: ${RUNTIME} (expected docker or podman)" ;;
+    esac
+    if ! command -v "$RUNTIME" &>/dev/null; then
+      error "${RUNTIME} is not installed."
+      echo ""
+      print_install_help
+      die "Install ${RUNTIME} and run this script again."
+    fi
+    CONTAINER_CLI="$RUNTIME"
+    return
+  fi
+
+  local has_docker=false has_podman=false
+  command -v docker &>/dev/null && has_docker=true
+  command -v podman &>/dev/null && has_podman=true
+
+  if [ "$has_docker" = "true" ] && [ "$has_podman" = "true" ]; then
+    # `docker` may just be podman-docker's shim; prefer it unless the user picks podman.
+    CONTAINER_CLI="docker"
+    if [ -r /dev/tty ]; then
+      echo "Both Docker and Podman are installed."
+      echo "  1) docker (default)"
+      echo "  2) podman"
+      local choice=""
+      read -r -p "Which runtime should OwliaBot use? [1]: " choice < /dev/tty || true
+      [ "$choice" = "2" ] && CONTAINER_CLI="podman"
+    fi
+  elif [ "$has_docker" = "true" ]; then
+    CONTAINER_CLI="docker"
+  elif [ "$has_podman" = "true" ]; then
+    CONTAINER_CLI="podman"
+    info "Docker not found; using Podman instead."
+  else
+    error "Neither Docker nor Podman is installed."
     echo ""
-    die "Install Docker and run this script again."
+    print_install_help
+    die "Install Docker (or Podman) and run this script again."
   fi
-  success "Docker command found"
-  
-  # Check if docker daemon is running
-  if ! docker info &>/dev/null; then
+}
+
+detect_compose_cmd() {
+  if [ "$CONTAINER_CLI" = "podman" ]; then
+    if command -v podman-compose &>/dev/null; then
+      COMPOSE_CMD="podman-compose"
+    elif podman compose version &>/dev/null; then
+      COMPOSE_CMD="podman compose"
+    fi
+  else
+    if command -v docker-compose &>/dev/null; then
+      COMPOSE_CMD="docker-compose"
+    elif docker compose version &>/dev/null; then
+      COMPOSE_CMD="docker compose"
+    fi
+  fi
+  if [ -n "$COMPOSE_CMD" ] && [ -n "$PROFILE" ]; then
+    COMPOSE_CMD="${COMPOSE_CMD} -f ${COMPOSE_FILE}"
+  fi
+}
+
+# Named profiles keep a second bot apart from the default one: its own config
+# dir (~/.owliabot-<name>), compose file (docker-compose.<name>.yml) and
+# container (owliabot-<name>). Same layout as `owliabot --profile <name>`.
+resolve_profile() {
+  [ "$PROFILE" = "default" ] && PROFILE=""
+  [ -n "$PROFILE" ] || return 0
+  [[ "$PROFILE" =~ ^[a-z][a-z0-9-]{0,30}$ ]] \
+    || die "Invalid profile name: ${PROFILE} (use lowercase letters, digits and dashes)"
+  CONFIG_DIR_NAME=".owliabot-${PROFILE}"
+  CONFIG_DIR="$HOME/${CONFIG_DIR_NAME}"
+  CONTAINER_NAME="owliabot-${PROFILE}"
+  COMPOSE_FILE="docker-compose.${PROFILE}.yml"
+}
+
+# Remote Docker host: docker and compose talk to the engine behind
+# DOCKER_HOST (ssh://) or DOCKER_CONTEXT, and bind mounts name paths on that
+# host. So the config dir and the compose file are written there, and a copy
+# of the compose file is kept here for running compose from this machine.
+is_remote() { [ -n "$REMOTE_DEST" ]; }
+
+# Onboarding runs in a container, where 127.0.0.1 and the engine are not the
+# host's: check the gateway ports here and pass what is taken (and by which
+# container) in. Covers 8787-8836, the default port, the ports offered
+# instead of it and the usual profile ports. Not for a remote engine, whose
+# host this machine can't probe; onboarding then skips the check.
+probe_host_ports() {
+  is_remote && return 0
+  local port busy="" owners="" name
+  for port in $(seq 8787 8836); do
+    if (: <"/dev/tcp/127.0.0.1/${port}") 2>/dev/null; then
+      busy="${busy:+${busy},}${port}"
+      name="$("$CONTAINER_CLI" ps --filter "publish=${port}" --format '{{.Names}}' 2>/dev/null | head -n1 || true)"
+      if [ -n "$name" ]; then owners="${owners:+${owners},}${port}:${name}"; fi
+    fi
+  done
+  RUN_ARGS+=(-e "OWLIABOT_HOST_PORTS_BUSY=${busy}")
+  if [ -n "$owners" ]; then RUN_ARGS+=(-e "OWLIABOT_HOST_PORT_OWNERS=${owners}"); fi
+}
+
+# Onboarding checks that the config dir can be bind-mounted, but in its
+# container it only sees Linux and the container's own mounts. Pass in the
+# host's platform and the filesystem type under the config dir.
+probe_host_config_dir() {
+  local probe facts platform fs_type
+  probe="uname -s; findmnt -n -o FSTYPE -T '${CONFIG_DIR}' 2>/dev/null || df --output=fstype '${CONFIG_DIR}' 2>/dev/null | tail -n 1"
+  if is_remote; then
+    facts="$(remote "$probe" < /dev/null 2>/dev/null || true)"
+  else
+    facts="$(bash -c "$probe" 2>/dev/null || true)"
+  fi
+  platform="$(sed -n 1p <<< "$facts" | tr '[:upper:]' '[:lower:]')"
+  fs_type="$(sed -n 2p <<< "$facts" | tr -d '[:space:]')"
+  RUN_ARGS+=(-e "OWLIABOT_HOST_CONFIG_DIR=${CONFIG_DIR}")
+  if [ -n "$platform" ]; then RUN_ARGS+=(-e "OWLIABOT_HOST_PLATFORM=${platform}"); fi
+  if [ -n "$fs_type" ]; then RUN_ARGS+=(-e "OWLIABOT_HOST_CONFIG_FS=${fs_type}"); fi
+}
+
+remote() {
+  ssh ${REMOTE_PORT:+-p "$REMOTE_PORT"} "$REMOTE_DEST" "$@"
+}
+
+# Offer the other Docker contexts (and an SSH host) at the Welcome stage.
+# Stays quiet on machines with a single context; --docker-host covers those.
+select_docker_host() {
+  [ -z "$DOCKER_TARGET" ] && [ -r /dev/tty ] && [ "$RUNTIME" != "podman" ] || return 0
+  command -v docker &>/dev/null || return 0
+  local current name choice="" i=2
+  local others=()
+  current="$(docker context show 2>/dev/null || true)"
+  [ -n "$current" ] || return 0
+  while IFS= read -r name; do
+    if [ -n "$name" ] && [ "$name" != "$current" ]; then others+=("$name"); fi
+  done < <(docker context ls --format '{{.Name}}' 2>/dev/null || true)
+  [ ${#others[@]} -gt 0 ] || return 0
+
+  echo "Which Docker engine should OwliaBot run on?"
+  echo "  1) ${current} (current context, default)"
+  for name in "${others[@]}"; do
+    echo "  ${i}) ${name}"
+    i=$((i + 1))
+  done
+  echo "  ${i}) Another host over SSH"
+  read -r -p "Pick a number [1]: " choice < /dev/tty || true
+  if [ "$choice" = "$i" ]; then
+    read -r -p "SSH host (ssh://user@host): " DOCKER_TARGET < /dev/tty || true
+    [[ "$DOCKER_TARGET" == ssh://* ]] || DOCKER_TARGET="ssh://${DOCKER_TARGET}"
+  elif [[ "$choice" =~ ^[0-9]+$ ]] && [ "$choice" -ge 2 ] && [ "$choice" -lt "$i" ]; then
+    DOCKER_TARGET="${others[$((choice - 2))]}"
+  fi
+}
+
+# Point docker at DOCKER_TARGET; for a remote engine, find the SSH
+# destination behind it and move CONFIG_DIR / OUTPUT_DIR to that host.
+apply_docker_host() {
+  [ -n "$DOCKER_TARGET" ] || return 0
+  local endpoint="$DOCKER_TARGET" remote_home=""
+  if [[ "$DOCKER_TARGET" == ssh://* ]]; then
+    export DOCKER_HOST="$DOCKER_TARGET"
+  else
+    command -v docker &>/dev/null || die "--docker-host needs the docker CLI on this machine"
+    endpoint="$(docker context inspect "$DOCKER_TARGET" --format '{{.Endpoints.docker.Host}}' 2>/dev/null)" \
+      || die "Unknown Docker context: ${DOCKER_TARGET} (see: docker context ls)"
+    export DOCKER_CONTEXT="$DOCKER_TARGET"
+  fi
+  case "$endpoint" in
+    unix://*|npipe://*) return 0 ;;
+    ssh://*) ;;
+    *) die "${endpoint} is not an SSH endpoint. The config is copied over SSH, so use an ssh:// host or context." ;;
+  esac
+  if [ "$RUNTIME" = "podman" ]; then die "--docker-host works with Docker only"; fi
+  if [ -n "$CA_BUNDLE" ]; then die "--ca-bundle can't be combined with a remote Docker host"; fi
+  RUNTIME="docker"
+
+  REMOTE_DEST="${endpoint#ssh://}"
+  REMOTE_DEST="${REMOTE_DEST%%/*}"
+  if [[ "$REMOTE_DEST" =~ ^(.+):([0-9]+)$ ]]; then
+    REMOTE_DEST="${BASH_REMATCH[1]}"
+    REMOTE_PORT="${BASH_REMATCH[2]}"
+  fi
+  remote_home="$(remote 'printf %s "$HOME"' < /dev/null)" || die "Can't reach ${REMOTE_DEST} over SSH"
+  [ -n "$remote_home" ] || die "Can't find the home directory on ${REMOTE_DEST}"
+  CONFIG_DIR="${remote_home}/${CONFIG_DIR_NAME}"
+  OUTPUT_DIR="${remote_home}/owliabot"
+  info "Installing on ${REMOTE_DEST}: config in ${CONFIG_DIR}, compose file in ${OUTPUT_DIR} (with a copy here)"
+}
+
+# Print a file from the config dir, on whichever host it lives (nothing when missing)
+read_config_file() {
+  if is_remote; then
+    remote "cat '${CONFIG_DIR}/$1'" < /dev/null 2>/dev/null || true
+  else
+    cat "${CONFIG_DIR}/$1" 2>/dev/null || true
+  fi
+}
+
+sed_in_place() {
+  if sed --version 2>/dev/null | grep -q GNU; then
+    sed -i "$1" "$2"
+  else
+    sed -i '' "$1" "$2"
+  fi
+}
+
+# Copy the files onboarding wrote on the remote host here, so compose can run
+# from this machine. Their ~/.owliabot paths would expand to this machine's
+# home, so they are pinned to the remote one.
+fetch_remote_output() {
+  local f
+  for f in "$COMPOSE_FILE" docker-stack.yml .env; do
+    if remote "cat '${OUTPUT_DIR}/${f}'" < /dev/null > "${f}.remote" 2>/dev/null; then
+      mv "${f}.remote" "$f"
+    else
+      rm -f "${f}.remote"
+    fi
+  done
+  for f in "$COMPOSE_FILE" docker-stack.yml; do
+    if [ -f "$f" ]; then sed_in_place "s|~/${CONFIG_DIR_NAME}|${CONFIG_DIR}|g" "$f"; fi
+  done
+  [ ! -f .env ] || chmod 600 .env
+  success "Copied the compose file from ${REMOTE_DEST}:${OUTPUT_DIR}"
+}
+
+check_docker() {
+  header "Checking container runtime"
+
+  select_runtime
+  success "Using ${CONTAINER_CLI} ($(command -v "$CONTAINER_CLI"))"
+
+  # Check that the engine responds
+  if ! "$CONTAINER_CLI" info &>/dev/null; then
+    if [ "$CONTAINER_CLI" = "podman" ]; then
+      error "Podman is not ready."
+      echo ""
+      info "Please start Podman:"
+      echo ""
+      echo "  macOS/Windows: podman machine init && podman machine start"
+      echo ""
+      echo "  Linux:         check 'podman info' for errors (rootless setup, subuid/subgid)"
+      echo ""
+      die "Start Podman and run this script again."
+    fi
     error "Docker daemon is not running."
     echo ""
     info "Please start Docker:"
@@ -112,16 +660,35 @@ check_docker() {
     echo ""
     die "Start Docker and run this script again."
   fi
-  success "Docker daemon is running"
-  
-  # Check docker compose
-  if docker compose version &>/dev/null; then
-    success "Docker Compose v2 found (docker compose)"
-  elif command -v docker-compose &>/dev/null; then
-    success "Docker Compose v1 found (docker-compose)"
+  success "${CONTAINER_CLI} engine is running"
+
+  if [ "$CONTAINER_CLI" = "podman" ]; then
+    # Rootless Podman maps the host user to root in the container; keep-id maps
+    # it to the same uid instead, so the container user can write ~/.owliabot.
+    RUN_ARGS+=(--userns=keep-id)
+    export PODMAN_USERNS="keep-id"
+    export OWLIABOT_CONTAINER_RUNTIME="podman"
+  fi
+
+  # Swarm mode: onboarding (inside a container) can't ask the engine itself.
+  if [ "$CONTAINER_CLI" = "docker" ] && \
+     [ "$(docker info --format '{{.Swarm.LocalNodeState}}' 2>/dev/null || true)" = "active" ]; then
+    SWARM_ACTIVE=true
+    RUN_ARGS+=(-e OWLIABOT_SWARM_ACTIVE=1)
+    info "Swarm mode is active; onboarding will offer a docker stack deploy file"
+  fi
+
+  # Check compose
+  detect_compose_cmd
+  if [ -n "$COMPOSE_CMD" ]; then
+    success "Compose found (${COMPOSE_CMD})"
   else
-    warn "Docker Compose not found. You can still use 'docker run' manually."
-    info "To install Docker Compose: https://docs.docker.com/compose/install/"
+    warn "Compose not found. You can still use '${CONTAINER_CLI} run' manually."
+    if [ "$CONTAINER_CLI" = "podman" ]; then
+      info "To install podman-compose: pip3 install podman-compose"
+    else
+      info "To install Docker Compose: https://docs.docker.com/compose/install/"
+    fi
   fi
 }
 
@@ -150,13 +717,175 @@ resolve_image() {
   fi
 }
 
-list_available_tags() {
-  header "Available prerelease tags"
-  info "Fetching tags from GHCR..."
+# Registry host of an image reference (docker.io when it names none)
+image_registry() {
+  local first="${1%%/*}"
+  if [[ "$1" == */* ]] && { [[ "$first" == *.* ]] || [[ "$first" == *:* ]] || [ "$first" = "localhost" ]; }; then
+    echo "$first"
+  else
+    echo "docker.io"
+  fi
+}
+
+# Repository path of an image reference within its registry (no tag or digest)
+image_repo_path() {
+  local ref="${1%@*}" registry
+  registry="$(image_registry "$1")"
+  [[ "${ref##*/}" == *:* ]] && ref="${ref%:*}"
+  if [ "$registry" = "docker.io" ]; then
+    ref="${ref#docker.io/}"
+    [[ "$ref" == */* ]] || ref="library/${ref}"
+  else
+    ref="${ref#"${registry}"/}"
+  fi
+  echo "$ref"
+}
+
+# "user:password" for a registry: OWLIABOT_REGISTRY_USER/PASSWORD first, then
+# ~/.docker/config.json (an inline auth entry or a credential helper).
+# Prints nothing when there are none.
+registry_credentials() {
+  local registry="$1" config="${DOCKER_CONFIG:-$HOME/.docker}/config.json"
+  if [ -n "$REGISTRY_USER" ] && [ -n "$REGISTRY_PASSWORD" ]; then
+    echo "${REGISTRY_USER}:${REGISTRY_PASSWORD}"
+    return 0
+  fi
+  { [ -f "$config" ] && command -v python3 &>/dev/null; } || return 0
+  python3 - "$config" "$registry" <<'PY' 2>/dev/null || true
+import base64, json, subprocess, sys
+path, registry = sys.argv[1], sys.argv[2]
+cfg = json.load(open(path))
+names = [registry, "https://" + registry]
+if registry == "docker.io":
+    names += ["https://index.docker.io/v1/", "index.docker.io"]
+auths = cfg.get("auths", {})
+for name in names:
+    auth = auths.get(name, {}).get("auth")
+    if auth:
+        print(base64.b64decode(auth).decode())
+        sys.exit(0)
+helpers = cfg.get("credHelpers", {})
+helper = next((helpers[n] for n in names if n in helpers), cfg.get("credsStore"))
+if helper:
+    for name in names:
+        try:
+            out = subprocess.run(["docker-credential-" + helper, "get"], input=name,
+                                 capture_output=True, text=True, timeout=10)
+        except Exception:
+            break
+        if out.returncode == 0:
+            cred = json.loads(out.stdout)
+            print(cred["Username"] + ":" + cred["Secret"])
+            sys.exit(0)
+PY
+}
+
+# curl with basic auth read from a file descriptor, so the password never
+# shows up in `ps`
+curl_with_user() {
+  local creds="$1"; shift
+  creds="${creds//\\/\\\\}"
+  curl -K <(printf 'user = "%s"\n' "${creds//\"/\\\"}") "$@"
+}
+
+# Tags of an image's repository on any v2 registry. Tries anonymously, then
+# answers the 401 challenge (Bearer token or Basic) with registry_credentials.
+registry_tags() {
+  local image="$1" registry repo api url headers challenge realm service creds token
+  local auth_args=()
+  registry="$(image_registry "$image")"
+  repo="$(image_repo_path "$image")"
+  api="$registry"
+  [ "$registry" = "docker.io" ] && api="registry-1.docker.io"
+  url="https://${api}/v2/${repo}/tags/list"
+
+  headers="$(curl -sS -o /dev/null -D - ${CURL_ARGS[@]+"${CURL_ARGS[@]}"} "$url" 2>/dev/null || true)"
+  challenge="$(grep -i '^www-authenticate:' <<< "$headers" | head -n 1 | tr -d '\r' || true)"
+  creds="$(registry_credentials "$registry")"
+  case "$challenge" in
+    *[Bb]earer*)
+      realm="$(sed -nE 's/.*realm="([^"]+)".*/\1/p' <<< "$challenge")"
+      service="$(sed -nE 's/.*service="([^"]+)".*/\1/p' <<< "$challenge")"
+      [ -n "$realm" ] || return 1
+      local token_args=(-fsS ${CURL_ARGS[@]+"${CURL_ARGS[@]}"} -G "$realm"
+        --data-urlencode "scope=repository:${repo}:pull")
+      [ -n "$service" ] && token_args+=(--data-urlencode "service=${service}")
+      if [ -n "$creds" ]; then
+        token="$(curl_with_user "$creds" "${token_args[@]}" 2>/dev/null || true)"
+      else
+        token="$(curl "${token_args[@]}" 2>/dev/null || true)"
+      fi
+      token="$(sed -nE 's/.*"(access_)?token" *: *"([^"]+)".*/\2/p' <<< "$token" | head -n 1)"
+      [ -n "$token" ] || return 1
+      auth_args=(-H "Authorization: Bearer ${token}")
+      ;;
+    *[Bb]asic*)
+      [ -n "$creds" ] || return 1
+      curl_with_user "$creds" -fsS ${CURL_ARGS[@]+"${CURL_ARGS[@]}"} "$url" 2>/dev/null \
+        | sed -nE 's/.*"tags" *: *\[([^]]*)\].*/\1/p' | tr ',' '\n' | tr -d '" '
+      return 0
+      ;;
+  esac
+  curl -fsS ${CURL_ARGS[@]+"${CURL_ARGS[@]}"} ${auth_args[@]+"${auth_args[@]}"} "$url" 2>/dev/null \
+    | sed -nE 's/.*"tags" *: *\[([^]]*)\].*/\1/p' | tr ',' '\n' | tr -d '" '
+}
+
+# Before pulling a custom image from another registry: log the engine in when
+# it has no credentials for it yet and the image can't be read anonymously.
+# ghcr.io/owliabot/owliabot is public and never needs this.
+ensure_registry_login() {
+  case "$OWLIABOT_IMAGE" in "${REGISTRY}:"*|"${REGISTRY}@"*) return 0 ;; esac
+  local registry user="$REGISTRY_USER" password="$REGISTRY_PASSWORD" answer=""
+  registry="$(image_registry "$OWLIABOT_IMAGE")"
 
+  if [ -z "$user" ] || [ -z "$password" ]; then
+    [ -n "$(registry_credentials "$registry")" ] && return 0
+    "$CONTAINER_CLI" manifest inspect "$OWLIABOT_IMAGE" &>/dev/null && return 0
+    [ -r /dev/tty ] || return 0
+    warn "${OWLIABOT_IMAGE} can't be read without logging in to ${registry}"
+    read -r -p "Log in to ${registry} now? [Y/n] " answer < /dev/tty || true
+    case "$answer" in n|N|no|NO) return 0 ;; esac
+    [ -n "$user" ] || read -r -p "Username: " user < /dev/tty || true
+    [ -n "$password" ] || { read -r -s -p "Password or token: " password < /dev/tty || true; echo ""; }
+    [ -n "$user" ] && [ -n "$password" ] || die "A username and password are needed for ${registry}"
+  fi
+
+  printf '%s' "$password" | "$CONTAINER_CLI" login "$registry" -u "$user" --password-stdin >/dev/null \
+    || die "Login to ${registry} failed. Check the credentials, or run: ${CONTAINER_CLI} login ${registry}"
+  success "Logged in to ${registry} as ${user}"
+}
+
+list_available_tags() {
   local tags=""
   local api_response
-  api_response=$(curl -fsSL "https://api.github.com/orgs/owliabot/packages/container/owliabot/versions?per_page=20" \
+
+  # A custom image elsewhere: ask its registry directly
+  case "$OWLIABOT_IMAGE" in
+    "${REGISTRY}:"*|"${REGISTRY}@"*) ;;
+    *)
+      local repo
+      repo="$(image_registry "$OWLIABOT_IMAGE")/$(image_repo_path "$OWLIABOT_IMAGE")"
+      header "Available tags"
+      info "Fetching tags for ${repo}..."
+      tags="$(registry_tags "$OWLIABOT_IMAGE" | sort -r | head -n 20 || true)"
+      if [ -z "$tags" ]; then
+        warn "Could not fetch tags for ${repo}. If it is private, log in first:"
+        echo "  ${CONTAINER_CLI:-docker} login $(image_registry "$OWLIABOT_IMAGE")"
+        echo "  (or set OWLIABOT_REGISTRY_USER and OWLIABOT_REGISTRY_PASSWORD)"
+        return
+      fi
+      echo ""
+      echo "Available tags:"
+      echo "$tags" | while read -r tag; do echo -e "  ${GREEN}• ${tag}${NC}"; done
+      echo ""
+      info "Usage: OWLIABOT_IMAGE=${OWLIABOT_IMAGE%:*}:<tag> $0"
+      return
+      ;;
+  esac
+
+  header "Available prerelease tags"
+  info "Fetching tags from GHCR..."
+  api_response=$(curl -fsSL ${CURL_ARGS[@]+"${CURL_ARGS[@]}"} "https://api.github.com/orgs/owliabot/packages/container/owliabot/versions?per_page=20" \
     -H "Accept: application/vnd.github+json" 2>/dev/null) || true
 
   if [ -n "$api_response" ]; then
@@ -177,7 +906,7 @@ for t in sorted(set(tags), reverse=True)[:20]: print(t)
 
   if [ -z "$tags" ]; then
     warn "Could not fetch tags (auth may be required). Try:"
-    echo "  docker pull ${REGISTRY}:develop"
+    echo "  ${CONTAINER_CLI:-docker} pull ${REGISTRY}:develop"
     return
   fi
 
@@ -195,6 +924,111 @@ for t in sorted(set(tags), reverse=True)[:20]: print(t)
   info "   or: $0 --channel develop"
 }
 
+# Before pulling: does the tag have a build for the engine's platform? An
+# ARM host (e.g. a Raspberry Pi) with an amd64-only tag can still run it
+# under emulation; onboarding then pins `platform:` in docker-compose.yml.
+check_image_platform() {
+  [ "$CONTAINER_CLI" = "docker" ] || return 0
+  local server arch manifest archs answer=""
+  server="$(docker version --format '{{.Server.Os}}/{{.Server.Arch}}' 2>/dev/null || true)"
+  [ -n "$server" ] || return 0
+  manifest="$(docker manifest inspect "$OWLIABOT_IMAGE" 2>/dev/null || true)"
+  # Build attestations are listed as unknown/unknown
+  archs="$(grep -oE '"architecture": *"[^"]+"' <<< "$manifest" | sed -E 's/.*"([^"]+)"$/\1/' \
+    | grep -vx unknown | sort -u | tr '\n' ' ' || true)"
+  [ -n "$archs" ] || return 0
+  arch="${server#*/}"
+  case " ${archs}" in *" ${arch} "*) return 0 ;; esac
+
+  warn "${OWLIABOT_IMAGE} has no ${server} build (only: ${archs% })"
+  if [ "$arch" != "amd64" ] && [[ " ${archs}" == *" amd64 "* ]]; then
+    info "It can run under emulation, more slowly. The engine needs qemu binfmt support:"
+    echo "  docker run --privileged --rm tonistiigi/binfmt --install amd64"
+    if [ -r /dev/tty ]; then
+      read -r -p "Run linux/amd64 under emulation? [y/N] " answer < /dev/tty || true
+    fi
+    case "$answer" in
+      y|Y|yes|YES)
+        IMAGE_PLATFORM="linux/amd64"
+        RUN_ARGS+=(--platform "$IMAGE_PLATFORM" -e OWLIABOT_PLATFORM="$IMAGE_PLATFORM")
+        success "Using ${IMAGE_PLATFORM}; docker-compose.yml will say so"
+        return 0
+        ;;
+    esac
+  fi
+  die "No ${server} build of ${OWLIABOT_IMAGE}. Pick another tag (--list) or build it here (--build)."
+}
+
+# Optional supply-chain check before pulling (--verify-signature): the image
+# must carry a cosign signature from the OwliaBot release workflow (keyless),
+# or one matching OWLIABOT_COSIGN_KEY. On failure nothing is pulled unless
+# the user explicitly accepts the risk.
+verify_image_signature() {
+  [ -n "$VERIFY_SIGNATURE" ] || return 0
+  local args=() out="" answer="" signer="${COSIGN_KEY:-the OwliaBot release workflow}"
+  command -v cosign &>/dev/null \
+    || die "--verify-signature needs cosign: https://docs.sigstore.dev/cosign/system_config/installation/"
+  if [ -n "$COSIGN_KEY" ]; then
+    [ -f "$COSIGN_KEY" ] || die "Cosign key not found: ${COSIGN_KEY}"
+    args=(--key "$COSIGN_KEY")
+  else
+    args=(--certificate-identity-regexp "$COSIGN_IDENTITY" --certificate-oidc-issuer "$COSIGN_ISSUER")
+  fi
+
+  info "Verifying the signature of ${OWLIABOT_IMAGE}..."
+  if out="$(cosign verify "${args[@]}" --output json "$OWLIABOT_IMAGE" 2>&1)"; then
+    VERIFIED_DIGEST="$(grep -oE '"docker-manifest-digest": *"sha256:[0-9a-f]+"' <<< "$out" | head -n 1 | grep -oE 'sha256:[0-9a-f]+' || true)"
+    [ -n "$VERIFIED_DIGEST" ] || die "cosign did not report which digest it verified. Nothing was pulled."
+    success "Signature verified: signed by ${signer} (${VERIFIED_DIGEST})"
+    return 0
+  fi
+
+  echo ""
+  echo -e "${RED}━━━━━━━━━━━━━━━━━━━━━━━ SIGNATURE CHECK FAILED ━━━━━━━━━━━━━━━━━━━━━━━${NC}"
+  error "${OWLIABOT_IMAGE} is not signed by ${signer}"
+  grep -v '^[[:space:]]*$' <<< "$out" | tail -n 3 | sed 's/^/    /' || true
+  warn "It may not come from the OwliaBot publisher, or it was changed after publishing."
+  [ -z "$COSIGN_KEY" ] && [[ "$OWLIABOT_IMAGE" != "${REGISTRY}"[:@]* ]] \
+    && info "For a custom image, set OWLIABOT_COSIGN_KEY to the public key it was signed with."
+  echo -e "${RED}━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━${NC}"
+  if [ -r /dev/tty ]; then
+    read -r -p "Pull the unverified image anyway? Type 'yes' to continue: " answer < /dev/tty || true
+  fi
+  [ "$answer" = "yes" ] || die "Nothing was pulled."
+  warn "Continuing with an unverified image"
+}
+
+# Pull the image. After a signature check, pull the verified digest and tag
+# it, so a tag that moved since the check is never what gets pulled.
+pull_image() {
+  local ref
+  if [ -z "$VERIFIED_DIGEST" ]; then
+    "$CONTAINER_CLI" pull ${IMAGE_PLATFORM:+--platform "$IMAGE_PLATFORM"} "${OWLIABOT_IMAGE}"
+    return
+  fi
+  ref="$(image_registry "$OWLIABOT_IMAGE")/$(image_repo_path "$OWLIABOT_IMAGE")@${VERIFIED_DIGEST}"
+  "$CONTAINER_CLI" pull ${IMAGE_PLATFORM:+--platform "$IMAGE_PLATFORM"} "$ref" || return 1
+  [[ "$OWLIABOT_IMAGE" == *@* ]] || "$CONTAINER_CLI" tag "$ref" "$OWLIABOT_IMAGE"
+}
+
+# Trust an extra CA bundle: curl here, and NODE_EXTRA_CA_CERTS in the
+# onboarding containers. Onboarding copies it to ~/.owliabot/ca-bundle.pem
+# and mounts it into the bot container the same way.
+setup_ca_bundle() {
+  [ -z "$CA_BUNDLE" ] && return 0
+  [ -f "$CA_BUNDLE" ] || die "CA bundle not found: ${CA_BUNDLE}"
+  grep -q -- "-----BEGIN CERTIFICATE-----" "$CA_BUNDLE" || die "${CA_BUNDLE} contains no PEM certificate"
+  CA_BUNDLE="$(cd "$(dirname "$CA_BUNDLE")" && pwd)/$(basename "$CA_BUNDLE")"
+  CURL_ARGS+=(--cacert "$CA_BUNDLE")
+  RUN_ARGS+=(
+    -v "${CA_BUNDLE}:/etc/owliabot/ca-bundle.pem:ro"
+    -e NODE_EXTRA_CA_CERTS=/etc/owliabot/ca-bundle.pem
+    -e OWLIABOT_CA_BUNDLE=/etc/owliabot/ca-bundle.pem
+  )
+  info "Using CA bundle ${CA_BUNDLE}"
+  info "Image pulls use the container engine's own trust store; add the CA there if pulls fail."
+}
+
 parse_args() {
   while [[ $# -gt 0 ]]; do
     case "$1" in
@@ -216,20 +1050,123 @@ parse_args() {
         LIST_TAGS=true
         shift
         ;;
+      --runtime)
+        RUNTIME="${2:-}"
+        [ -z "$RUNTIME" ] && die "--runtime requires a value (docker|podman)"
+        shift 2
+        ;;
+      --no-notify)
+        NOTIFY=false
+        shift
+        ;;
+      --ca-bundle)
+        CA_BUNDLE="${2:-}"
+        [ -z "$CA_BUNDLE" ] && die "--ca-bundle requires a file"
+        shift 2
+        ;;
+      --safe-mode)
+        SAFE_MODE=1
+        shift
+        ;;
+      --demo)
+        DEMO=1
+        shift
+        ;;
+      --profile)
+        PROFILE="${2:-}"
+        [ -z "$PROFILE" ] && die "--profile requires a name"
+        shift 2
+        ;;
+      --docker-host)
+        DOCKER_TARGET="${2:-}"
+        [ -z "$DOCKER_TARGET" ] && die "--docker-host requires a Docker context or ssh://user@host"
+        shift 2
+        ;;
+      --verify-signature)
+        VERIFY_SIGNATURE=1
+        shift
+        ;;
+      --accessible)
+        ACCESSIBLE=1
+        shift
+        ;;
+      --theme)
+        THEME="${2:-}"
+        [ -z "$THEME" ] && die "--theme requires dark, light, high-contrast or mono"
+        shift 2
+        ;;
+      --registry-user)
+        REGISTRY_USER="${2:-}"
+        [ -z "$REGISTRY_USER" ] && die "--registry-user requires a name"
+        shift 2
+        ;;
+      --notify-url)
+        NOTIFY_URL="${2:-}"
+        [[ "$NOTIFY_URL" =~ ^https?:// ]] || die "--notify-url requires an http(s) URL"
+        shift 2
+        ;;
+      --notify-host)
+        NOTIFY_HOST="${2:-}"
+        [ -z "$NOTIFY_HOST" ] && die "--notify-host requires a name"
+        shift 2
+        ;;
+      --logs-tail)
+        LOGS_TAIL="${2:-}"
+        [[ "$LOGS_TAIL" =~ ^[0-9]+$ ]] || die "--logs-tail requires a number of lines"
+        shift 2
+        ;;
       --help|-h)
         echo "Usage: $0 [options]"
         echo ""
         echo "Options:"
         echo "  --channel <name>   Release channel: stable (default) or develop"
         echo "  --tag <tag>        Specific image tag (e.g. 0.2.0-dev.abc1234)"
-        echo "  --list, -l         List available image tags from GHCR"
+        echo "  --list, -l         List available image tags (GHCR, or OWLIABOT_IMAGE's registry)"
         echo "  --build            Build from source instead of pulling"
+        echo "  --runtime <name>   Container runtime: docker or podman (auto-detected)"
+        echo "  --no-notify        No bell/desktop notification when slow steps finish"
+        echo "  --ca-bundle <file> Trust this PEM CA bundle for HTTPS (corporate TLS proxy)"
+        echo "  --logs-tail <n>    Lines of history in the log viewer offered at the end (default: 100)"
+        echo "  --safe-mode        Start with write tools, exec and MCP servers disabled, to check"
+        echo "                     connectivity first (docker compose installs)"
+        echo "  --demo             Skip the AI provider: the bot echoes messages back, so channels"
+        echo "                     and the gateway can be tried before you have an API key"
+        echo "  --profile <name>   Install another bot next to the default one: ~/.owliabot-<name>,"
+        echo "                     docker-compose.<name>.yml and container owliabot-<name>"
+        echo "  --docker-host <h>  Install on another machine: a Docker context or ssh://user@host."
+        echo "                     The config is written there over SSH"
+        echo "  --verify-signature Pull only if the image's cosign signature verifies (needs cosign)"
+        echo "  --accessible       Plain output for screen readers: no colors, banner art or symbols"
+        echo "  --theme <name>     Colors: dark (default), light, high-contrast or mono"
+        echo "  --registry-user <u> Log in to a private OWLIABOT_IMAGE registry as this user"
+        echo "                     (password from OWLIABOT_REGISTRY_PASSWORD, or asked)"
+        echo "  --notify-url <url> POST a setup summary (no secrets) to this webhook once the bot"
+        echo "                     answers /health"
+        echo "  --notify-host <h>  Host name in that summary (default: the machine the bot runs on)"
         echo "  --help, -h         Show this help"
         echo ""
         echo "Environment variables:"
         echo "  OWLIABOT_IMAGE     Override the full image reference"
         echo "  OWLIABOT_TAG       Same as --tag"
         echo "  OWLIABOT_CHANNEL   Same as --channel (stable|develop)"
+        echo "  OWLIABOT_RUNTIME   Same as --runtime (docker|podman)"
+        echo "  OWLIABOT_NOTIFY    Set to false to behave like --no-notify"
+        echo "  OWLIABOT_READY_TIMEOUT  Seconds to wait for the bot to become ready (default: 120)"
+        echo "  OWLIABOT_CA_BUNDLE Same as --ca-bundle"
+        echo "  OWLIABOT_LOGS_TAIL Same as --logs-tail"
+        echo "  OWLIABOT_SAFE_MODE Set to 1 to behave like --safe-mode"
+        echo "  OWLIABOT_DEMO      Set to 1 to behave like --demo"
+        echo "  OWLIABOT_LANG      Onboarding language: en, ja or ko (default: the locale)"
+        echo "  OWLIABOT_ACCESSIBLE  Set to 1 to behave like --accessible (also on for TERM=dumb)"
+        echo "  OWLIABOT_THEME     Same as --theme; NO_COLOR or CLICOLOR=0 mean mono"
+        echo "  OWLIABOT_PROFILE   Same as --profile"
+        echo "  OWLIABOT_DOCKER_HOST  Same as --docker-host"
+        echo "  OWLIABOT_REGISTRY_USER     Same as --registry-user"
+        echo "  OWLIABOT_REGISTRY_PASSWORD Password or token for it; with both set, no prompt"
+        echo "  OWLIABOT_VERIFY_SIGNATURE  Set to 1 to behave like --verify-signature"
+        echo "  OWLIABOT_COSIGN_KEY        Public key to verify against instead of the release workflow"
+        echo "  OWLIABOT_NOTIFY_URL        Same as --notify-url"
+        echo "  OWLIABOT_NOTIFY_HOST       Same as --notify-host"
         exit 0
         ;;
       *)
@@ -239,8 +1176,12 @@ parse_args() {
   done
 }
 
-main() {
-  # Banner
+print_banner() {
+  if [ -n "$ACCESSIBLE" ]; then
+    echo "OwliaBot installer"
+    echo ""
+    return 0
+  fi
   echo ""
   echo -e "${CYAN}"
   echo "   ____          ___       ____        _   "
@@ -251,14 +1192,22 @@ main() {
   echo "  \\____/  \\_/\\_/ |_|_|\\__,_|____/ \\___/ \\__|"
   echo -e "${NC}"
   echo ""
+}
 
+main() {
   # Honor OWLIABOT_BUILD env var
   if [ "${OWLIABOT_BUILD:-}" = "1" ] || [ "${OWLIABOT_BUILD:-}" = "true" ]; then
     BUILD_LOCAL=true
   fi
 
-  # Parse CLI arguments
+  # Parse CLI arguments (first, so --theme and --accessible apply to the banner)
   parse_args "$@"
+  apply_theme
+  if [ -n "$ACCESSIBLE" ]; then use_plain_output; fi
+  print_banner
+  resolve_profile
+
+  setup_ca_bundle
 
   # Sync channel → build branch
   resolve_channel
@@ -281,18 +1230,30 @@ main() {
   fi
   echo ""
 
+  # Which engine to install on (this machine, a context, or an SSH host)
+  select_docker_host
+  apply_docker_host
+
   # Check Docker environment
   check_docker
 
   # Create directories
   header "Preparing directories"
-  mkdir -p ~/.owliabot/auth
-  chmod 700 ~/.owliabot ~/.owliabot/auth 2>/dev/null || true
-  success "Created ~/.owliabot/"
+  if is_remote; then
+    remote "mkdir -p '${CONFIG_DIR}/auth' '${OUTPUT_DIR}' && chmod 700 '${CONFIG_DIR}' '${CONFIG_DIR}/auth'" < /dev/null \
+      || die "Can't create ${CONFIG_DIR} on ${REMOTE_DEST}"
+    success "Created ${CONFIG_DIR}/ on ${REMOTE_DEST}"
+  else
+    mkdir -p "${CONFIG_DIR}/auth"
+    chmod 700 "${CONFIG_DIR}" "${CONFIG_DIR}/auth" 2>/dev/null || true
+    success "Created ~/${CONFIG_DIR_NAME}/"
+  fi
+  [ -n "$PROFILE" ] && info "Profile: ${PROFILE} (container ${CONTAINER_NAME}, ${COMPOSE_FILE})"
 
   # Build or pull image
+  local step_started=$SECONDS
   if [ "$BUILD_LOCAL" = "true" ]; then
-    header "Building Docker image locally"
+    header "Building the image locally"
     SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
     # Only use local Dockerfile if it matches the requested channel
     LOCAL_BRANCH=""
@@ -302,7 +1263,7 @@ main() {
     if [ -f "${SCRIPT_DIR}/Dockerfile" ] && { [ "$CHANNEL" = "stable" ] || [ "$LOCAL_BRANCH" = "$BUILD_BRANCH" ] || [ -z "$LOCAL_BRANCH" ]; }; then
       OWLIABOT_IMAGE="owliabot:local"
       info "Building ${OWLIABOT_IMAGE} from ${SCRIPT_DIR}/Dockerfile (branch: ${LOCAL_BRANCH:-unknown})..."
-      docker build -t "${OWLIABOT_IMAGE}" "${SCRIPT_DIR}" || die "Build failed."
+      "$CONTAINER_CLI" build -t "${OWLIABOT_IMAGE}" "${SCRIPT_DIR}" || die "Build failed."
     else
       # No local Dockerfile — clone and build
       local tmpdir
@@ -311,17 +1272,20 @@ main() {
       git clone --depth 1 --branch "$BUILD_BRANCH" https://github.com/owliabot/owliabot.git "$tmpdir" || die "Clone failed."
       OWLIABOT_IMAGE="owliabot:local"
       info "Building ${OWLIABOT_IMAGE}..."
-      docker build -t "${OWLIABOT_IMAGE}" "$tmpdir" || { rm -rf "$tmpdir"; die "Build failed."; }
+      "$CONTAINER_CLI" build -t "${OWLIABOT_IMAGE}" "$tmpdir" || { rm -rf "$tmpdir"; die "Build failed."; }
       rm -rf "$tmpdir"
     fi
     success "Image built: ${OWLIABOT_IMAGE}"
   else
-    header "Pulling Docker image"
+    header "Pulling the image"
     info "Image: ${OWLIABOT_IMAGE}"
     if [ "$CHANNEL" != "stable" ] || [ -n "$OWLIABOT_TAG" ]; then
       warn "This is a PRERELEASE build — may contain bugs or breaking changes."
     fi
-    if docker pull "${OWLIABOT_IMAGE}"; then
+    ensure_registry_login
+    check_image_platform
+    verify_image_signature
+    if pull_image; then
       success "Image pulled successfully"
     else
       error "Failed to pull ${OWLIABOT_IMAGE}"
@@ -336,59 +1300,91 @@ main() {
     fi
   fi
 
+  notify_done "$step_started" "Image ready. Onboarding is waiting for your answers."
+
   # Run onboard interactively
   header "Starting interactive configuration"
-  info "Running onboard inside Docker container..."
+  info "Running onboard inside a ${CONTAINER_CLI} container..."
   echo ""
   
+  # The wizard picks its language from these (see `onboard --lang`)
+  local lang_var
+  for lang_var in OWLIABOT_LANG LC_ALL LC_MESSAGES LANG; do
+    [ -n "${!lang_var:-}" ] && RUN_ARGS+=(-e "${lang_var}=${!lang_var}")
+  done
+  # ...and its timezone default from TZ, since /etc/localtime in there is the image's
+  local host_tz
+  host_tz="$(host_timezone)"
+  [ -n "$host_tz" ] && RUN_ARGS+=(-e "TZ=${host_tz}")
+  # Onboarding's own engine checks (port owners, demo start) use the same runtime
+  RUN_ARGS+=(-e "OWLIABOT_CONTAINER_RUNTIME=${CONTAINER_CLI}")
+  # The container's own hostname is its id; report the machine the bot runs on
+  if [ -n "$NOTIFY_URL" ]; then
+    if [ -z "$NOTIFY_HOST" ]; then
+      if is_remote; then
+        NOTIFY_HOST="$(remote hostname < /dev/null 2>/dev/null || echo "${REMOTE_DEST#*@}")"
+      else
+        NOTIFY_HOST="$(hostname 2>/dev/null || uname -n)"
+      fi
+    fi
+    RUN_ARGS+=(-e "OWLIABOT_NOTIFY_HOST=${NOTIFY_HOST}")
+  fi
+  probe_host_ports
+  probe_host_config_dir
+  # ...and its colors from these (see `onboard --theme`)
+  local color_var
+  for color_var in NO_COLOR CLICOLOR; do
+    [ -n "${!color_var:-}" ] && RUN_ARGS+=(-e "${color_var}=${!color_var}")
+  done
+
   # Use </dev/tty to ensure interactive input works even when
-  # the script is piped via curl (curl ... | bash steals stdin)
-  docker run --rm -it \
-    -v ~/.owliabot:/home/owliabot/.owliabot \
-    -v "$(pwd):/app/output" \
+  # the script is piped via curl (curl ... | bash steals stdin).
+  # A profile's config dir is mounted under its own name, where
+  # `onboard --profile` looks for it.
+  "$CONTAINER_CLI" run --rm -it ${RUN_ARGS[@]+"${RUN_ARGS[@]}"} \
+    -v "${CONFIG_DIR}:/home/owliabot/${CONFIG_DIR_NAME}" \
+    -v "${OUTPUT_DIR}:/app/output" \
     "${OWLIABOT_IMAGE}" \
-    onboard --docker --output-dir /app/output \
+    onboard --docker --output-dir /app/output ${PROFILE:+--profile "$PROFILE"} ${DEMO:+--demo} \
+    ${ACCESSIBLE:+--accessible} ${THEME:+--theme "$THEME"} ${NOTIFY_URL:+--notify-url "$NOTIFY_URL"} \
     < /dev/tty
+  if is_remote; then fetch_remote_output; fi
 
-  # Verify onboard produced docker-compose.yml
-  if [ ! -f "docker-compose.yml" ]; then
-    die "Onboard did not generate docker-compose.yml. Cannot auto-start."
+  # Verify onboard produced docker-compose.yml (or docker-stack.yml in swarm mode;
+  # never offered for a profile)
+  if [ -z "$PROFILE" ] && [ "$SWARM_ACTIVE" = "true" ] && [ -f "docker-stack.yml" ] && \
+     { [ ! -f "docker-compose.yml" ] || [ "docker-stack.yml" -nt "docker-compose.yml" ]; }; then
+    STACK_MODE=true
+  elif [ ! -f "${COMPOSE_FILE}" ]; then
+    die "Onboard did not generate ${COMPOSE_FILE}. Cannot auto-start."
   fi
 
   # Chromium is bundled in the Docker image — Playwright MCP will use it automatically
   success "Chromium browser bundled in image (Playwright MCP ready)"
 
-  # Detect docker compose command
-  COMPOSE_CMD=""
-  if command -v docker-compose &>/dev/null; then
-    COMPOSE_CMD="docker-compose"
-  elif docker compose version &>/dev/null; then
-    COMPOSE_CMD="docker compose"
-  else
-    die "Docker Compose not found. Please install it and run: docker-compose up -d"
+  # Compose command was detected in check_docker (not needed for docker stack deploy)
+  if [ "$STACK_MODE" = "false" ] && [ -z "$COMPOSE_CMD" ]; then
+    die "Compose not found. Please install it and run: ${CONTAINER_CLI}-compose up -d"
   fi
 
   # --- Auto-trigger OAuth setup if needed (BEFORE starting the container) ---
   OAUTH_OK=true
-  APP_YAML="$HOME/.owliabot/app.yaml"
-  if [ -f "${APP_YAML}" ] && grep -qE 'apiKey: "?oauth"?' "${APP_YAML}" 2>/dev/null; then
+  if read_config_file app.yaml | grep -qE 'apiKey: "?oauth"?'; then
     # If we already have a valid OAuth token on disk, don't force an interactive
     # auth setup again. This avoids redundant re-auth in cases like:
     # - onboard detects an existing valid token and keeps it
     # - install.sh then blindly runs `auth setup` again
     #
     # Token files are stored under ~/.owliabot/auth/ and are mounted into Docker.
-    AUTH_FILE="$HOME/.owliabot/auth/auth-openai-codex.json"
     SKIP_OAUTH_SETUP=false
-    if [ -f "${AUTH_FILE}" ]; then
-      EXPIRES_MS="$(sed -nE 's/.*\"expires\"[[:space:]]*:[[:space:]]*([0-9]+).*/\1/p' "${AUTH_FILE}" | head -n1)"
-      if [ -n "${EXPIRES_MS}" ]; then
-        NOW_S="$(date +%s)"
-        NOW_MS="$((NOW_S * 1000))"
-        if [ "${EXPIRES_MS}" -gt "${NOW_MS}" ] 2>/dev/null; then
-          SKIP_OAUTH_SETUP=true
-          info "Found existing openai-codex OAuth token (not expired). Skipping OAuth setup."
-        fi
+    EXPIRES_MS="$(read_config_file auth/auth-openai-codex.json \
+      | sed -nE 's/.*\"expires\"[[:space:]]*:[[:space:]]*([0-9]+).*/\1/p' | head -n1)"
+    if [ -n "${EXPIRES_MS}" ]; then
+      NOW_S="$(date +%s)"
+      NOW_MS="$((NOW_S * 1000))"
+      if [ "${EXPIRES_MS}" -gt "${NOW_MS}" ] 2>/dev/null; then
+        SKIP_OAUTH_SETUP=true
+        info "Found existing openai-codex OAuth token (not expired). Skipping OAuth setup."
       fi
     fi
 
@@ -401,8 +1397,8 @@ main() {
     echo ""
 
     # Run auth setup in a temporary container (not the long-running one)
-    if docker run --rm -it \
-      -v ~/.owliabot:/home/owliabot/.owliabot \
+    if "$CONTAINER_CLI" run --rm -it ${RUN_ARGS[@]+"${RUN_ARGS[@]}"} \
+      -v "${CONFIG_DIR}:/home/owliabot/.owliabot" \
       "${OWLIABOT_IMAGE}" \
       auth setup < /dev/tty; then
       success "OAuth setup completed successfully"
@@ -414,20 +1410,21 @@ main() {
   fi
 
   # If using a non-default image, update docker-compose.yml BEFORE starting
-  if [ "$OWLIABOT_IMAGE" != "${REGISTRY}:latest" ]; then
-    local SED_PATTERN="s|image:.*ghcr\.io/owliabot/owliabot:.*|image: ${OWLIABOT_IMAGE}|"
-    if sed --version 2>/dev/null | grep -q GNU; then
-      sed -i "$SED_PATTERN" docker-compose.yml
-    else
-      sed -i '' "$SED_PATTERN" docker-compose.yml
-    fi
-    if ! grep -q "${OWLIABOT_IMAGE}" docker-compose.yml 2>/dev/null; then
-      warn "Failed to update image in docker-compose.yml. Please edit manually:"
+  # (docker-stack.yml reads it from OWLIABOT_IMAGE at deploy time instead)
+  if [ "$STACK_MODE" = "false" ] && [ "$OWLIABOT_IMAGE" != "${REGISTRY}:latest" ]; then
+    sed_in_place "s|image:.*ghcr\.io/owliabot/owliabot:.*|image: ${OWLIABOT_IMAGE}|" "${COMPOSE_FILE}"
+    if ! grep -q "${OWLIABOT_IMAGE}" "${COMPOSE_FILE}" 2>/dev/null; then
+      warn "Failed to update image in ${COMPOSE_FILE}. Please edit manually:"
       echo "  image: ${OWLIABOT_IMAGE}"
     else
-      success "Updated docker-compose.yml image to ${OWLIABOT_IMAGE}"
+      success "Updated ${COMPOSE_FILE} image to ${OWLIABOT_IMAGE}"
     fi
   fi
+  # Keep the remote copy the same, for running compose on that host
+  if is_remote && [ "$STACK_MODE" = "false" ]; then
+    remote "cat > '${OUTPUT_DIR}/${COMPOSE_FILE}'" < "${COMPOSE_FILE}" \
+      || warn "Could not update ${OUTPUT_DIR}/${COMPOSE_FILE} on ${REMOTE_DEST}"
+  fi
 
   # --- Start container (or skip if OAuth failed) ---
   if [ "$OAUTH_OK" = "false" ]; then
@@ -437,14 +1434,41 @@ main() {
     info "To complete setup manually:"
     echo ""
     echo "  1. Run OAuth setup in a temporary container:"
-    echo "     docker run --rm -it \\"
-    echo "       -v ~/.owliabot:/home/owliabot/.owliabot \\"
+    echo "     ${CONTAINER_CLI} run --rm -it ${RUN_ARGS[*]+${RUN_ARGS[*]} }\\"
+    echo "       -v ${CONFIG_DIR}:/home/owliabot/.owliabot \\"
     echo "       ${OWLIABOT_IMAGE} \\"
     echo "       auth setup"
     echo ""
     echo "  2. Then start the bot:"
-    echo "     ${COMPOSE_CMD} up -d"
+    if [ "$STACK_MODE" = "true" ]; then
+      echo "     OWLIABOT_IMAGE=${OWLIABOT_IMAGE} docker stack deploy -c docker-stack.yml owliabot"
+    else
+      echo "     ${COMPOSE_CMD} up -d"
+    fi
     echo ""
+  elif [ "$STACK_MODE" = "true" ]; then
+    header "Deploying the OwliaBot stack"
+    step_started=$SECONDS
+    if ! OWLIABOT_IMAGE="${OWLIABOT_IMAGE}" docker stack deploy -c docker-stack.yml owliabot; then
+      die "Failed to deploy. Check docker-stack.yml and try: docker stack deploy -c docker-stack.yml owliabot"
+    fi
+    success "Stack deployed"
+
+    header "Waiting for the bot to be ready"
+    # The task's container appears once the scheduler has placed it.
+    local task_container="" waited=0
+    while [ -z "$task_container" ] && [ "$waited" -lt 60 ]; do
+      task_container="$(docker ps -q --filter "label=com.docker.swarm.service.name=owliabot_owliabot" | head -n1)"
+      [ -n "$task_container" ] || { sleep 2; waited=$((waited + 2)); }
+    done
+    if [ -z "$task_container" ] || ! wait_for_ready "$task_container"; then
+      notify_done "$step_started" "OwliaBot did not become ready. Check the installer output."
+      echo ""
+      info "Check the service with: docker service ps owliabot_owliabot"
+      exit 1
+    fi
+    notify_done "$step_started" "OwliaBot is up and running."
+    send_setup_notification
   else
     header "Starting OwliaBot container"
     info "Using: ${COMPOSE_CMD}"
@@ -452,18 +1476,37 @@ main() {
     # Stop and remove any existing owliabot container (may have been started
     # manually via `docker run` or from an older install).  This prevents
     # name/port conflicts when `compose up -d` tries to create a new one.
-    if docker ps -aq --filter "name=^owliabot$" | grep -q .; then
-      info "Removing existing owliabot container..."
-      docker stop owliabot 2>/dev/null || true
-      docker rm owliabot 2>/dev/null || true
+    if "$CONTAINER_CLI" ps -aq --filter "name=^${CONTAINER_NAME}$" | grep -q .; then
+      info "Removing existing ${CONTAINER_NAME} container..."
+      "$CONTAINER_CLI" stop "$CONTAINER_NAME" 2>/dev/null || true
+      "$CONTAINER_CLI" rm "$CONTAINER_NAME" 2>/dev/null || true
       success "Old container removed"
     fi
 
-    if ! ${COMPOSE_CMD} up -d; then
-      die "Failed to start container. Check docker-compose.yml and try: ${COMPOSE_CMD} up -d"
+    step_started=$SECONDS
+    local up_log
+    up_log="$(mktemp)"
+    # docker-compose.yml passes OWLIABOT_SAFE_MODE through to the container.
+    export OWLIABOT_SAFE_MODE="$SAFE_MODE"
+    [ -n "$SAFE_MODE" ] && info "Safe mode: write tools, exec and MCP servers stay off for this start"
+    if ! ${COMPOSE_CMD} up -d 2>&1 | tee "$up_log"; then
+      startup_failure_card "${COMPOSE_CMD} up failed" "$(cat "$up_log")"
+      rm -f "$up_log"
+      die "Failed to start container. Check ${COMPOSE_FILE} and try: ${COMPOSE_CMD} up -d"
     fi
+    rm -f "$up_log"
     success "Container started"
 
+    header "Waiting for the bot to be ready"
+    if ! wait_for_ready "$CONTAINER_NAME" || ! verify_gateway_health; then
+      notify_done "$step_started" "OwliaBot did not become ready. Check the installer output."
+      echo ""
+      info "Follow the logs with: ${COMPOSE_CMD} logs -f"
+      exit 1
+    fi
+    notify_done "$step_started" "OwliaBot is up and running."
+    send_setup_notification
+
   fi
 
   # --- Final success message ---
@@ -476,12 +1519,41 @@ main() {
   fi
   echo ""
   info "Useful commands:"
+  if [ "$STACK_MODE" = "true" ]; then
+    echo "  docker service logs -f owliabot_owliabot            # Follow logs"
+    echo "  docker stack rm owliabot                            # Stop"
+    echo "  docker stack deploy -c docker-stack.yml owliabot    # Update"
+    echo ""
+    return 0
+  fi
+  if [ -n "$DEMO" ]; then
+    warn "Demo mode: the bot echoes messages back instead of asking a model."
+    info "Set up a real provider with: $0 (without --demo)"
+    echo ""
+  fi
+  if [ -n "$SAFE_MODE" ]; then
+    warn "Running in safe mode: no write tools, exec or MCP servers."
+    info "Once it answers, restart with everything enabled: ${COMPOSE_CMD} up -d"
+    echo ""
+  fi
+  if is_remote; then
+    if [ -n "${DOCKER_CONTEXT:-}" ]; then
+      info "These talk to ${REMOTE_DEST}; first run: export DOCKER_CONTEXT=${DOCKER_CONTEXT}"
+    else
+      info "These talk to ${REMOTE_DEST}; first run: export DOCKER_HOST=${DOCKER_HOST}"
+    fi
+  fi
   echo "  ${COMPOSE_CMD} logs -f                              # Follow logs"
   echo "  ${COMPOSE_CMD} restart                              # Restart"
   echo "  ${COMPOSE_CMD} down                                 # Stop"
   echo "  ${COMPOSE_CMD} pull && ${COMPOSE_CMD} up -d         # Update"
-  echo "  docker exec -it \$(${COMPOSE_CMD} ps -q owliabot) owliabot auth setup  # Re-run OAuth"
+  echo "  ${CONTAINER_CLI} exec -it ${CONTAINER_NAME} owliabot auth setup     # Re-run OAuth"
   echo ""
+
+  if [ "$OAUTH_OK" = "true" ]; then
+    offer_test_message
+    offer_log_viewer
+  fi
 }
 
 main "$@"
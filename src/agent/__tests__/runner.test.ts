@@ -324,6 +324,29 @@ describe("runner", () => {
       expect(result.content).toBe("OAuth works!");
       expect(oauth.loadOAuthCredentials).toHaveBeenCalledWith("openai-codex");
     });
+
+    it("should answer with the demo provider without a key or a call", async () => {
+      delete process.env.ANTHROPIC_API_KEY;
+      delete process.env.OPENAI_API_KEY;
+
+      const messages = [
+        { role: "system" as const, content: "You are a bot", timestamp: Date.now() },
+        { role: "user" as const, content: " hello there ", timestamp: Date.now() },
+      ];
+
+      const result = await runLLM(
+        { provider: "demo", model: "echo" },
+        messages,
+        undefined,
+        { id: "demo", model: "echo", priority: 1 },
+      );
+
+      expect(result.content).toMatch(/^\[demo\] You said: hello there\n/);
+      expect(result.provider).toBe("demo");
+      expect(result.model).toBe("echo");
+      expect(result.toolCalls).toBeUndefined();
+      expect(piAi.completeSimple).not.toHaveBeenCalled();
+    });
   });
 
   describe("callWithFailover", () => {
@@ -0,0 +1,48 @@
+/**
+ * Demo provider: a stand-in LLM that needs no API key.
+ *
+ * `owliabot onboard --demo` configures it so channels, the gateway and
+ * commands can be tried before any provider account exists. Replies echo
+ * the last user message, prefixed so nobody mistakes them for a model.
+ */
+
+import type { Message } from "./session.js";
+import type { LLMResponse } from "./runner.js";
+
+/** Provider id written by `onboard --demo` */
+export const DEMO_PROVIDER_ID = "demo";
+
+/** Model name written by `onboard --demo` (the only one the provider knows) */
+export const DEMO_MODEL = "echo";
+
+/** Prefix on every demo reply */
+export const DEMO_REPLY_PREFIX = "[demo]";
+
+export function isDemoProvider(providerId: string): boolean {
+  return providerId === DEMO_PROVIDER_ID;
+}
+
+/** Rough token count for usage reporting (4 characters per token) */
+function estimateTokens(text: string): number {
+  return Math.ceil(text.length / 4);
+}
+
+/**
+ * Reply to `messages` without calling anything: echo the last user message.
+ */
+export function demoComplete(messages: Message[], model: string = DEMO_MODEL): LLMResponse {
+  const lastUser = [...messages].reverse().find((m) => m.role === "user");
+  const said = lastUser?.content.trim() ?? "";
+  const content = said
+    ? `${DEMO_REPLY_PREFIX} You said: ${said}\n\nThis bot is running in demo mode with no model behind it. Run \`owliabot onboard\` to add a provider.`
+    : `${DEMO_REPLY_PREFIX} This bot is running in demo mode with no model behind it. Run \`owliabot onboard\` to add a provider.`;
+  return {
+    content,
+    usage: {
+      promptTokens: messages.reduce((sum, m) => sum + estimateTokens(m.content), 0),
+      completionTokens: estimateTokens(content),
+    },
+    provider: DEMO_PROVIDER_ID,
+    model,
+  };
+}
@@ -9,6 +9,7 @@ import {
   fromOpenAIResponse,
   openAICompatibleComplete,
   isOpenAICompatible,
+  azureOpenAIChatUrl,
 } from "./openai-compatible.js";
 import type { Message } from "./session.js";
 import type { ToolDefinition } from "./tools/interface.js";
@@ -753,3 +754,38 @@ describe("isOpenAICompatible", () => {
     expect(isOpenAICompatible("google")).toBe(false);
   });
 });
+
+describe("azureOpenAIChatUrl", () => {
+  it("addresses the deployment with the api-version query", () => {
+    expect(azureOpenAIChatUrl("https://res.openai.azure.com/", "gpt-4o-prod", "2024-10-21")).toBe(
+      "https://res.openai.azure.com/openai/deployments/gpt-4o-prod/chat/completions?api-version=2024-10-21",
+    );
+    expect(azureOpenAIChatUrl("https://res.openai.azure.com/openai", "gpt-4o")).toContain(
+      "https://res.openai.azure.com/openai/deployments/gpt-4o/chat/completions?api-version=",
+    );
+  });
+
+  it("is used as-is by openAICompatibleComplete", async () => {
+    mockFetch.mockResolvedValueOnce({
+      ok: true,
+      json: () => Promise.resolve({
+        id: "chatcmpl-1",
+        object: "chat.completion",
+        created: 1,
+        model: "gpt-4o",
+        choices: [{ index: 0, message: { role: "assistant", content: "Hi" }, finish_reason: "stop" }],
+      }),
+    });
+    const url = azureOpenAIChatUrl("https://res.openai.azure.com", "gpt-4o", "2024-10-21");
+
+    await openAICompatibleComplete(
+      { baseUrl: url, model: "gpt-4o", apiKey: "azure-key", authType: "header", authHeader: "api-key" },
+      [{ role: "user", content: "Hi", timestamp: Date.now() }],
+    );
+
+    const [calledUrl, init] = mockFetch.mock.calls.at(-1)!;
+    expect(calledUrl).toBe(url);
+    expect(init.headers["api-key"]).toBe("azure-key");
+    expect(init.headers.Authorization).toBeUndefined();
+  });
+});
@@ -224,7 +224,7 @@ export async function openAICompatibleComplete(
 
   // Normalize baseUrl - remove trailing slash, ensure /chat/completions path
   const normalizedBaseUrl = baseUrl.replace(/\/+$/, "");
-  const url = normalizedBaseUrl.endsWith("/chat/completions")
+  const url = /\/chat\/completions(\?|$)/.test(normalizedBaseUrl)
     ? normalizedBaseUrl
     : `${normalizedBaseUrl}/chat/completions`;
 
@@ -347,3 +347,19 @@ export async function openAICompatibleComplete(
 export function isOpenAICompatible(providerId: string): boolean {
   return providerId === "openai-compatible";
 }
+
+/** Azure OpenAI data-plane API version used when the config doesn't set one */
+export const AZURE_OPENAI_DEFAULT_API_VERSION = "2024-10-21";
+
+/**
+ * Chat completions URL of an Azure OpenAI deployment. Azure routes by
+ * deployment name in the path and needs an api-version query parameter.
+ */
+export function azureOpenAIChatUrl(
+  endpoint: string,
+  deployment: string,
+  apiVersion: string = AZURE_OPENAI_DEFAULT_API_VERSION,
+): string {
+  const base = endpoint.replace(/\/+$/, "").replace(/\/openai$/, "");
+  return `${base}/openai/deployments/${encodeURIComponent(deployment)}/chat/completions?api-version=${encodeURIComponent(apiVersion)}`;
+}
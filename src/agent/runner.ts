@@ -24,8 +24,10 @@ import type { ToolDefinition, ToolCall, ToolResult } from "./tools/interface.js"
 import {
   openAICompatibleComplete,
   isOpenAICompatible,
+  azureOpenAIChatUrl,
   type OpenAICompatibleConfig,
 } from "./openai-compatible.js";
+import { demoComplete, isDemoProvider } from "./demo-provider.js";
 import { resolveModel, getContextWindow, type ModelConfig } from "./models.js";
 import {
   guardContext,
@@ -51,6 +53,10 @@ export interface LLMProvider {
   apiKey?: string;
   priority: number;
   baseUrl?: string;
+  /** Azure OpenAI api-version (azure-openai) */
+  apiVersion?: string;
+  authType?: "bearer" | "api-key" | "header" | "none";
+  authHeader?: string;
 }
 
 export interface RunnerOptions {
@@ -104,6 +110,10 @@ export class TimeoutError extends Error {
  * @param configApiKey - Optional API key from config (loaded from secrets.yaml)
  */
 export async function resolveApiKey(provider: string, configApiKey?: string): Promise<string> {
+  // Bedrock signs requests with AWS credentials from the SDK's default chain
+  // (env, shared profile, instance or task role); there is no API key.
+  if (provider === "amazon-bedrock") return getEnvApiKey(provider) ?? "<authenticated>";
+
   // Use config API key if provided and valid
   if (configApiKey && configApiKey !== "oauth" && configApiKey !== "env" && configApiKey !== "secrets") {
     log.debug(`Using config API key for ${provider}`);
@@ -326,6 +336,28 @@ export async function runLLM(
   provider?: LLMProvider,
   _retryCount: number = 0,
 ): Promise<LLMResponse> {
+  // onboard --demo: canned replies, no key and no network
+  if (isDemoProvider(provider?.id ?? modelConfig.provider ?? "")) {
+    return demoComplete(messages, modelConfig.model);
+  }
+
+  // Azure OpenAI speaks the same chat completions API, addressed by deployment
+  if (provider?.id === "azure-openai") {
+    if (!provider.baseUrl) {
+      throw new Error(
+        "azure-openai provider requires baseUrl (the resource endpoint) to be set. " +
+          "Example: baseUrl: https://my-resource.openai.azure.com"
+      );
+    }
+    return runLLM(modelConfig, messages, options, {
+      ...provider,
+      id: "openai-compatible",
+      baseUrl: azureOpenAIChatUrl(provider.baseUrl, provider.model, provider.apiVersion),
+      authType: "header",
+      authHeader: "api-key",
+    }, _retryCount);
+  }
+
   // Check if this is an openai-compatible provider
   if (provider && isOpenAICompatible(provider.id)) {
     if (!provider.baseUrl) {
@@ -356,6 +388,8 @@ export async function runLLM(
       baseUrl: provider.baseUrl,
       model: modelConfig.model,
       apiKey: provider.apiKey,
+      authType: provider.authType,
+      authHeader: provider.authHeader,
     };
 
     return openAICompatibleComplete(config, guardedMessages, options);
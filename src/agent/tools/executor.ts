@@ -39,6 +39,8 @@ export interface ExecutorOptions {
     writeToolAllowList?: string[];
     writeToolConfirmation?: boolean;
     writeToolConfirmationTimeoutMs?: number;
+    writeToolConfirmationApprover?: string;
+    writeToolConfirmationIn?: "chat" | "dm";
   };
   workspacePath?: string;
   userId?: string;
@@ -0,0 +1,46 @@
+import { describe, it, expect } from "vitest";
+import {
+  setupTokenExpiresAt,
+  parseExpiry,
+  expiryState,
+  setupTokenReminder,
+  oauthSessionReminder,
+  SETUP_TOKEN_LIFETIME_MS,
+} from "../credential-expiry.js";
+
+const DAY = 24 * 60 * 60 * 1000;
+const NOW = Date.parse("2026-03-01T00:00:00.000Z");
+
+describe("credential-expiry", () => {
+  it("setup-token expiry is one year after issue", () => {
+    expect(setupTokenExpiresAt(NOW)).toBe(new Date(NOW + SETUP_TOKEN_LIFETIME_MS).toISOString());
+  });
+
+  it("parses ISO strings and epoch ms, ignores garbage", () => {
+    expect(parseExpiry("2026-03-01T00:00:00.000Z")).toBe(NOW);
+    expect(parseExpiry(NOW)).toBe(NOW);
+    expect(parseExpiry("soon")).toBeUndefined();
+    expect(parseExpiry(undefined)).toBeUndefined();
+  });
+
+  it("classifies expiry state", () => {
+    expect(expiryState(NOW + 30 * DAY, NOW)).toBe("ok");
+    expect(expiryState(NOW + 14 * DAY, NOW)).toBe("due-soon");
+    expect(expiryState(NOW, NOW)).toBe("expired");
+  });
+
+  it("reminds about a setup-token close to expiry", () => {
+    expect(setupTokenReminder(new Date(NOW + 60 * DAY).toISOString(), NOW)).toBeNull();
+    const soon = setupTokenReminder(new Date(NOW + 3 * DAY).toISOString(), NOW);
+    expect(soon).toContain("expires in 3 days (2026-03-04)");
+    expect(soon).toContain("claude setup-token");
+    expect(setupTokenReminder(new Date(NOW - DAY).toISOString(), NOW)).toContain("expired on 2026-02-28");
+    expect(setupTokenReminder(undefined, NOW)).toBeNull();
+  });
+
+  it("only reminds about OAuth sessions that can't refresh", () => {
+    expect(oauthSessionReminder("OpenAI Codex", NOW - DAY, true, NOW)).toBeNull();
+    expect(oauthSessionReminder("OpenAI Codex", NOW - DAY, false, NOW)).toContain("OpenAI Codex sign-in expired");
+    expect(oauthSessionReminder("OpenAI Codex", NOW + DAY, false, NOW)).toContain("expires in 1 day ");
+  });
+});
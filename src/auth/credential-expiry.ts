@@ -0,0 +1,90 @@
+/**
+ * Expiry metadata for credentials with a known lifetime
+ *
+ * - Anthropic setup-tokens (`claude setup-token`) are valid for one year.
+ *   The expiry is recorded next to the token in secrets.yaml
+ *   (anthropic.tokenExpiresAt) when the token is entered.
+ * - OAuth sessions store the access token expiry; it is renewed
+ *   automatically with the refresh token, so a reminder is only due when
+ *   there is no refresh token to renew with.
+ *
+ * Used by `auth status`, `doctor` and the onboarding Welcome stage.
+ */
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+export const SETUP_TOKEN_LIFETIME_MS = 365 * DAY_MS;
+
+/** Start warning this long before a credential expires */
+export const RENEWAL_WARNING_MS = 14 * DAY_MS;
+
+export type ExpiryState = "ok" | "due-soon" | "expired";
+
+/**
+ * Expiry timestamp (ISO) for a setup-token issued now.
+ */
+export function setupTokenExpiresAt(issuedAt: number = Date.now()): string {
+  return new Date(issuedAt + SETUP_TOKEN_LIFETIME_MS).toISOString();
+}
+
+/**
+ * Parse a stored expiry (ISO string or epoch ms); undefined when unusable.
+ */
+export function parseExpiry(value: unknown): number | undefined {
+  if (typeof value === "number") return Number.isFinite(value) ? value : undefined;
+  if (typeof value !== "string") return undefined;
+  const ms = Date.parse(value);
+  return Number.isNaN(ms) ? undefined : ms;
+}
+
+export function expiryState(expiresAt: number, now: number = Date.now()): ExpiryState {
+  if (expiresAt <= now) return "expired";
+  if (expiresAt - now <= RENEWAL_WARNING_MS) return "due-soon";
+  return "ok";
+}
+
+/**
+ * Renewal reminder for a credential, or null when nothing is due yet.
+ * `renew` says how to get a new one.
+ */
+export function renewalReminder(
+  label: string,
+  expiresAt: number,
+  renew: string,
+  now: number = Date.now(),
+): string | null {
+  const state = expiryState(expiresAt, now);
+  if (state === "ok") return null;
+  const date = new Date(expiresAt).toISOString().slice(0, 10);
+  if (state === "expired") return `${label} expired on ${date}. ${renew}`;
+  const days = Math.max(1, Math.ceil((expiresAt - now) / DAY_MS));
+  return `${label} expires in ${days} day${days === 1 ? "" : "s"} (${date}). ${renew}`;
+}
+
+export const SETUP_TOKEN_RENEWAL = "Run `claude setup-token`, then `owliabot onboard` to store the new token.";
+export const OAUTH_RENEWAL = "Run `owliabot auth setup` to sign in again.";
+
+/**
+ * Reminder for a stored setup-token expiry (secrets.anthropic.tokenExpiresAt).
+ */
+export function setupTokenReminder(tokenExpiresAt: unknown, now: number = Date.now()): string | null {
+  const expiresAt = parseExpiry(tokenExpiresAt);
+  if (expiresAt === undefined) return null;
+  return renewalReminder("Anthropic setup-token", expiresAt, SETUP_TOKEN_RENEWAL, now);
+}
+
+/**
+ * Reminder for an OAuth session. Sessions with a refresh token renew
+ * themselves, so only sessions that can't are reported.
+ */
+export function oauthSessionReminder(
+  label: string,
+  expires: unknown,
+  refreshable: boolean,
+  now: number = Date.now(),
+): string | null {
+  if (refreshable) return null;
+  const expiresAt = parseExpiry(expires);
+  if (expiresAt === undefined) return null;
+  return renewalReminder(`${label} sign-in`, expiresAt, OAUTH_RENEWAL, now);
+}
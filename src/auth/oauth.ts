@@ -183,6 +183,8 @@ export async function getOAuthStatus(
 ): Promise<{
   authenticated: boolean;
   expiresAt?: number;
+  /** Has a refresh token, so the session renews itself */
+  refreshable?: boolean;
   email?: string;
 }> {
   const credentials = await loadOAuthCredentials(provider);
@@ -194,6 +196,7 @@ export async function getOAuthStatus(
   return {
     authenticated: true,
     expiresAt: credentials.expires,
+    refreshable: Boolean(credentials.refresh),
     email: credentials.email,
   };
 }
@@ -204,13 +207,13 @@ export async function getOAuthStatus(
 export async function getAllOAuthStatus(): Promise<
   Record<
     SupportedOAuthProvider,
-    { authenticated: boolean; expiresAt?: number; email?: string }
+    { authenticated: boolean; expiresAt?: number; refreshable?: boolean; email?: string }
   >
 > {
   const providers: SupportedOAuthProvider[] = ["openai-codex"];
   const result = {} as Record<
     SupportedOAuthProvider,
-    { authenticated: boolean; expiresAt?: number; email?: string }
+    { authenticated: boolean; expiresAt?: number; refreshable?: boolean; email?: string }
   >;
 
   for (const provider of providers) {
@@ -3,7 +3,7 @@
  * @see design.md Section 5.1
  */
 
-export type ChannelId = "telegram" | "discord" | "http";
+export type ChannelId = "telegram" | "discord" | "slack" | "http" | "webhook";
 
 export interface ChannelPlugin {
   id: ChannelId;
@@ -0,0 +1,120 @@
+import { describe, it, expect, vi } from "vitest";
+import { createSlackPlugin } from "../index.js";
+
+vi.mock("../../../utils/logger.js", () => ({
+  createLogger: () => ({
+    debug: vi.fn(),
+    info: vi.fn(),
+    warn: vi.fn(),
+    error: vi.fn(),
+  }),
+}));
+
+class FakeSocket {
+  listeners: Record<string, ((ev: any) => void)[]> = {};
+  sent: string[] = [];
+  addEventListener(type: string, fn: (ev: any) => void) {
+    (this.listeners[type] ??= []).push(fn);
+  }
+  send(data: string) {
+    this.sent.push(data);
+  }
+  close() {}
+  emit(payload: unknown) {
+    for (const fn of this.listeners.message ?? []) fn({ data: JSON.stringify(payload) });
+  }
+}
+
+function setup(config: { memberAllowList?: string[]; channelAllowList?: string[] } = {}) {
+  const socket = new FakeSocket();
+  const calls: { method: string; args: Record<string, string> }[] = [];
+  const fetchImpl = vi.fn(async (url: string, init: RequestInit) => {
+    const method = url.split("/").pop()!;
+    const args = Object.fromEntries(new URLSearchParams(init.body as URLSearchParams));
+    calls.push({ method, args });
+    const bodies: Record<string, unknown> = {
+      "auth.test": { ok: true, user_id: "UBOT00001", team: "Owls" },
+      "apps.connections.open": { ok: true, url: "wss://example.test/socket" },
+      "users.info": { ok: true, user: { name: "alice", real_name: "Alice" } },
+    };
+    return new Response(JSON.stringify(bodies[method] ?? { ok: true }));
+  });
+  const plugin = createSlackPlugin({
+    botToken: "xoxb-test",
+    appToken: "xapp-test",
+    fetchImpl: fetchImpl as unknown as typeof fetch,
+    createSocket: () => socket as unknown as WebSocket,
+    ...config,
+  });
+  const handler = vi.fn(async () => {});
+  plugin.onMessage(handler);
+  return { plugin, socket, calls, handler };
+}
+
+const event = (envelope_id: string, ev: Record<string, unknown>) => ({
+  type: "events_api",
+  envelope_id,
+  payload: { event: { ts: "1700000000.000100", channel: "C0000001", channel_type: "channel", ...ev } },
+});
+
+const flush = () => new Promise((r) => setTimeout(r, 0));
+
+describe("slack plugin", () => {
+  it("acknowledges envelopes and forwards mentions without the mention tag", async () => {
+    const { plugin, socket, handler } = setup();
+    await plugin.start();
+
+    socket.emit(event("e1", { type: "app_mention", user: "U0000001", text: "<@UBOT00001> hello" }));
+    await flush();
+
+    expect(socket.sent).toContain(JSON.stringify({ envelope_id: "e1" }));
+    expect(handler).toHaveBeenCalledWith(expect.objectContaining({
+      channel: "slack",
+      from: "U0000001",
+      senderName: "Alice",
+      body: "hello",
+      chatType: "group",
+      groupId: "C0000001",
+      mentioned: true,
+    }));
+  });
+
+  it("ignores unmentioned channel chatter, bots and duplicates", async () => {
+    const { plugin, socket, handler } = setup({ channelAllowList: ["C0000002"] });
+    await plugin.start();
+
+    socket.emit(event("e1", { type: "message", user: "U0000001", text: "just chatting" }));
+    socket.emit(event("e2", { type: "message", bot_id: "B1", text: "beep" }));
+    socket.emit(event("e3", { type: "message", user: "U0000001", text: "in allowlisted", channel: "C0000002", ts: "2.0" }));
+    socket.emit(event("e4", { type: "app_mention", user: "U0000001", text: "in allowlisted", channel: "C0000002", ts: "2.0" }));
+    await flush();
+
+    expect(handler).toHaveBeenCalledTimes(1);
+    expect(handler).toHaveBeenCalledWith(expect.objectContaining({ groupId: "C0000002" }));
+  });
+
+  it("drops DMs from members outside the allowlist", async () => {
+    const { plugin, socket, handler } = setup({ memberAllowList: ["U0000009"] });
+    await plugin.start();
+
+    socket.emit(event("e1", { type: "message", user: "U0000001", text: "hi", channel: "D0000001", channel_type: "im" }));
+    socket.emit(event("e2", { type: "message", user: "U0000009", text: "hi", channel: "D0000001", channel_type: "im", ts: "3.0" }));
+    await flush();
+
+    expect(handler).toHaveBeenCalledTimes(1);
+    expect(handler).toHaveBeenCalledWith(expect.objectContaining({ from: "U0000009", chatType: "direct" }));
+  });
+
+  it("posts replies in the thread and maps reactions to emoji names", async () => {
+    const { plugin, socket, calls } = setup();
+    await plugin.start();
+    socket.emit(event("e1", { type: "message", user: "U0000001", text: "hi", channel: "D0000001", channel_type: "im", ts: "4.0" }));
+    await flush();
+
+    await plugin.send("C0000001", { text: "done", replyToId: "1.0" });
+    await plugin.addReaction!("U0000001", "4.0", "👀");
+
+    expect(calls).toContainEqual({ method: "chat.postMessage", args: { channel: "C0000001", text: "done", thread_ts: "1.0" } });
+    expect(calls).toContainEqual({ method: "reactions.add", args: { channel: "D0000001", timestamp: "4.0", name: "eyes" } });
+  });
+});
@@ -0,0 +1,244 @@
+import { createLogger } from "../../utils/logger.js";
+import type {
+  ChannelPlugin,
+  MessageHandler,
+  MsgContext,
+  OutboundMessage,
+  ChannelCapabilities,
+} from "../interface.js";
+
+const log = createLogger("slack");
+
+const SLACK_API_BASE = "https://slack.com/api";
+const RECONNECT_DELAY_MS = 2_000;
+// Remember this many recent messages: Slack sends a mention in an allowlisted
+// channel as both `message` and `app_mention`, and reactions to a DM need its
+// D... channel while the gateway only knows the user ID.
+const RECENT_MESSAGES_LIMIT = 200;
+
+// Slack reactions take emoji names, the gateway passes the characters
+const REACTION_NAMES: Record<string, string> = {
+  "🤔": "thinking_face",
+  "👀": "eyes",
+  "🎉": "tada",
+  "👍": "+1",
+};
+
+export interface SlackConfig {
+  /** Bot token (xoxb-...) for the Web API */
+  botToken: string;
+  /** App-level token (xapp-...) with connections:write, for Socket Mode */
+  appToken: string;
+  /** Allow list of Slack member IDs (U...) */
+  memberAllowList?: string[];
+  /** Channels (C...) where every message reaches the bot, not only mentions */
+  channelAllowList?: string[];
+  /**
+   * Called before channel gating; returning true forwards the message even
+   * without a mention (used by WriteGate for "yes"/"no" replies).
+   */
+  preFilter?: (ctx: MsgContext) => boolean;
+  fetchImpl?: typeof fetch;
+  createSocket?: (url: string) => WebSocket;
+}
+
+interface SlackMessageEvent {
+  type: "message" | "app_mention";
+  subtype?: string;
+  user?: string;
+  bot_id?: string;
+  text?: string;
+  channel: string;
+  channel_type?: "im" | "channel" | "group" | "mpim";
+  ts: string;
+  thread_ts?: string;
+}
+
+interface SocketEnvelope {
+  type: "hello" | "disconnect" | "events_api" | string;
+  envelope_id?: string;
+  payload?: { event?: SlackMessageEvent };
+}
+
+export function createSlackPlugin(config: SlackConfig): ChannelPlugin {
+  const fetchImpl = config.fetchImpl ?? fetch;
+  const createSocket = config.createSocket ?? ((url: string) => new WebSocket(url));
+
+  let messageHandler: MessageHandler | null = null;
+  let socket: WebSocket | null = null;
+  let stopped = false;
+  let botUserId: string | null = null;
+  const recent = new Map<string, string>(); // ts -> channel
+  const userNames = new Map<string, string>();
+
+  const capabilities: ChannelCapabilities = {
+    reactions: true,
+    threads: true,
+    buttons: false,
+    markdown: true,
+    maxMessageLength: 4000,
+  };
+
+  // Form encoding works for every Web API method (JSON bodies don't for reads).
+  async function api<T>(method: string, token: string, args: Record<string, string> = {}): Promise<T> {
+    const res = await fetchImpl(`${SLACK_API_BASE}/${method}`, {
+      method: "POST",
+      headers: { Authorization: `Bearer ${token}` },
+      body: new URLSearchParams(args),
+    });
+    const data = (await res.json()) as { ok: boolean; error?: string } & T;
+    if (!data.ok) throw new Error(`Slack ${method} failed: ${data.error ?? res.status}`);
+    return data;
+  }
+
+  async function displayName(userId: string): Promise<string> {
+    const cached = userNames.get(userId);
+    if (cached) return cached;
+    try {
+      const { user } = await api<{ user: { name: string; real_name?: string } }>(
+        "users.info",
+        config.botToken,
+        { user: userId },
+      );
+      const name = user.real_name || user.name;
+      userNames.set(userId, name);
+      return name;
+    } catch {
+      return userId;
+    }
+  }
+
+  function alreadySeen(event: SlackMessageEvent): boolean {
+    if (recent.get(event.ts) === event.channel) return true;
+    recent.set(event.ts, event.channel);
+    if (recent.size > RECENT_MESSAGES_LIMIT) recent.delete(recent.keys().next().value!);
+    return false;
+  }
+
+  async function handleEvent(event: SlackMessageEvent): Promise<void> {
+    if (!messageHandler) return;
+    // Edits, joins, bot posts (including our own)
+    if (event.subtype || event.bot_id || !event.user || event.user === botUserId) return;
+
+    const isDM = event.channel_type === "im";
+    const mentioned = event.type === "app_mention"
+      || (!!botUserId && (event.text ?? "").includes(`<@${botUserId}>`));
+
+    if (config.memberAllowList && config.memberAllowList.length > 0
+      && !config.memberAllowList.includes(event.user)) {
+      log.warn(`User ${event.user} not in memberAllowList`);
+      return;
+    }
+    if (alreadySeen(event)) return;
+
+    const body = (event.text ?? "").replace(/<@[A-Z0-9]+>\s*/g, "").trim();
+    const msgCtx: MsgContext = {
+      from: event.user,
+      senderName: await displayName(event.user),
+      body: body.length > 0 ? body : event.text ?? "",
+      messageId: event.ts,
+      threadId: event.thread_ts,
+      channel: "slack",
+      chatType: isDM ? "direct" : "group",
+      groupId: isDM ? undefined : event.channel,
+      timestamp: Math.round(Number(event.ts) * 1000),
+      mentioned,
+    };
+
+    if (!isDM && !mentioned && !config.preFilter?.(msgCtx)
+      && !config.channelAllowList?.includes(event.channel)) {
+      return;
+    }
+
+    try {
+      await messageHandler(msgCtx);
+    } catch (err) {
+      log.error("Error handling message", err);
+    }
+  }
+
+  async function connect(): Promise<void> {
+    const { url } = await api<{ url: string }>("apps.connections.open", config.appToken);
+    const ws = createSocket(url);
+    socket = ws;
+
+    ws.addEventListener("message", (msg) => {
+      let envelope: SocketEnvelope;
+      try {
+        envelope = JSON.parse(String(msg.data)) as SocketEnvelope;
+      } catch {
+        return;
+      }
+      // Every envelope must be acknowledged within 3 seconds or Slack retries it.
+      if (envelope.envelope_id) ws.send(JSON.stringify({ envelope_id: envelope.envelope_id }));
+
+      if (envelope.type === "hello") log.info("Slack Socket Mode connected");
+      else if (envelope.type === "disconnect") ws.close();
+      else if (envelope.type === "events_api" && envelope.payload?.event) {
+        const event = envelope.payload.event;
+        if (event.type === "message" || event.type === "app_mention") void handleEvent(event);
+      }
+    });
+
+    ws.addEventListener("close", () => {
+      if (stopped || socket !== ws) return;
+      log.warn("Slack connection closed; reconnecting");
+      setTimeout(() => {
+        if (!stopped) connect().catch((err) => log.error("Slack reconnect failed", err));
+      }, RECONNECT_DELAY_MS);
+    });
+  }
+
+  return {
+    id: "slack",
+    capabilities,
+
+    async start() {
+      log.info("Starting Slack bot...");
+      stopped = false;
+      const auth = await api<{ user_id: string; team?: string }>("auth.test", config.botToken);
+      botUserId = auth.user_id;
+      await connect();
+      log.info(`Slack bot started${auth.team ? ` in ${auth.team}` : ""}`);
+    },
+
+    async stop() {
+      log.info("Stopping Slack bot...");
+      stopped = true;
+      socket?.close();
+      socket = null;
+      log.info("Slack bot stopped");
+    },
+
+    onMessage(handler: MessageHandler) {
+      messageHandler = handler;
+    },
+
+    async send(target: string, message: OutboundMessage) {
+      // target is a channel (C...), a DM channel (D...) or a user ID (U...);
+      // chat.postMessage opens the DM itself for user IDs.
+      try {
+        await api("chat.postMessage", config.botToken, {
+          channel: target,
+          text: message.text,
+          ...(message.replyToId && { thread_ts: message.replyToId }),
+        });
+      } catch (err) {
+        log.error(`Failed to send message to ${target}`, err);
+        throw err;
+      }
+    },
+
+    async addReaction(chatId: string, messageId: string, emoji: string) {
+      const name = REACTION_NAMES[emoji];
+      const channel = recent.get(messageId) ?? chatId;
+      if (name) await api("reactions.add", config.botToken, { channel, timestamp: messageId, name });
+    },
+
+    async removeReaction(chatId: string, messageId: string, emoji: string) {
+      const name = REACTION_NAMES[emoji];
+      const channel = recent.get(messageId) ?? chatId;
+      if (name) await api("reactions.remove", config.botToken, { channel, timestamp: messageId, name });
+    },
+  };
+}
@@ -0,0 +1,99 @@
+import { describe, it, expect, vi } from "vitest";
+import { createWebhookPlugin } from "../index.js";
+import { WriteGate } from "../../../security/write-gate.js";
+import type { MsgContext } from "../../interface.js";
+import { tmpdir } from "node:os";
+import { join } from "node:path";
+
+vi.mock("../../../utils/logger.js", () => ({
+  createLogger: () => ({
+    debug: vi.fn(),
+    info: vi.fn(),
+    warn: vi.fn(),
+    error: vi.fn(),
+  }),
+}));
+
+function setup(outboundUrl?: string) {
+  const fetchImpl = vi.fn(async () => new Response("", { status: 200 }));
+  const plugin = createWebhookPlugin({
+    path: "/webhook",
+    secret: "s3cret",
+    outboundUrl,
+    fetchImpl: fetchImpl as unknown as typeof fetch,
+  });
+  const handler = vi.fn(async () => {});
+  plugin.onMessage(handler);
+  return { plugin, handler, fetchImpl };
+}
+
+describe("webhook plugin", () => {
+  it("rejects requests without the shared secret", async () => {
+    const { plugin, handler } = setup();
+
+    const missing = await plugin.handleRequest(JSON.stringify({ text: "hi" }), {});
+    const wrong = await plugin.handleRequest(JSON.stringify({ text: "hi" }), { "x-webhook-secret": "nope" });
+
+    expect(missing.status).toBe(401);
+    expect(wrong.status).toBe(401);
+    expect(handler).not.toHaveBeenCalled();
+  });
+
+  it("forwards authenticated messages as direct messages", async () => {
+    const { plugin, handler } = setup();
+
+    const res = await plugin.handleRequest(
+      JSON.stringify({ text: "deploy finished", from: "ci", senderName: "CI" }),
+      { authorization: "Bearer s3cret" },
+    );
+
+    expect(res.status).toBe(202);
+    expect(handler).toHaveBeenCalledWith(expect.objectContaining({
+      channel: "webhook",
+      chatType: "direct",
+      from: "webhook:ci",
+      senderName: "CI",
+      body: "deploy finished",
+    }));
+  });
+
+  it("requires a text field", async () => {
+    const { plugin } = setup();
+    const res = await plugin.handleRequest(JSON.stringify({ from: "ci" }), { "x-webhook-secret": "s3cret" });
+    expect(res.status).toBe(400);
+  });
+
+  it("posts replies to the outbound URL", async () => {
+    const { plugin, fetchImpl } = setup("https://example.test/hook");
+
+    await plugin.send("webhook:ci", { text: "ok", replyToId: "m1" });
+
+    expect(fetchImpl).toHaveBeenCalledWith("https://example.test/hook", expect.objectContaining({
+      method: "POST",
+      body: JSON.stringify({ to: "ci", text: "ok", replyToId: "m1" }),
+    }));
+  });
+
+  it("drops replies when no outbound URL is configured", async () => {
+    const { plugin, fetchImpl } = setup();
+    await plugin.send("ci", { text: "ok" });
+    expect(fetchImpl).not.toHaveBeenCalled();
+  });
+
+  it("namespaces senders so a spoofed admin ID can't pass the write gate", async () => {
+    const { plugin, handler } = setup();
+    await plugin.handleRequest(JSON.stringify({ text: "rm -rf notes", from: "123456789" }), { "x-webhook-secret": "s3cret" });
+    const ctx = (handler.mock.calls[0] as unknown as [MsgContext])[0];
+    expect(ctx.from).toBe("webhook:123456789");
+
+    const gate = new WriteGate(
+      { allowList: ["123456789"], confirmationEnabled: false, timeoutMs: 1_000, auditPath: join(tmpdir(), `webhook-gate-${process.pid}.jsonl`) },
+      { sendMessage: vi.fn(async () => {}), waitForReply: vi.fn(async () => "yes") },
+    );
+    const result = await gate.check(
+      { id: "c1", name: "edit_file", arguments: {} },
+      { userId: ctx.from, sessionKey: "webhook:123456789", target: ctx.from },
+    );
+    expect(result).toEqual({ allowed: false, reason: "not_in_allowlist" });
+  });
+});
@@ -0,0 +1,174 @@
+/**
+ * Generic webhook channel
+ *
+ * Lets systems the gateway doesn't natively support talk to the bot over
+ * plain HTTP:
+ * - Inbound: POST JSON to `path` on the Gateway HTTP server, authenticated
+ *   with the shared secret (X-Webhook-Secret or Authorization: Bearer)
+ * - Outbound: replies are POSTed as JSON to `outboundUrl` when configured
+ *
+ * The route is served by startGatewayHttp(); this plugin only validates and
+ * dispatches requests.
+ */
+
+import { randomUUID, timingSafeEqual } from "node:crypto";
+import { createLogger } from "../../utils/logger.js";
+import type {
+  ChannelPlugin,
+  MessageHandler,
+  MsgContext,
+  OutboundMessage,
+  ChannelCapabilities,
+} from "../interface.js";
+
+const log = createLogger("webhook");
+
+/**
+ * Prefix on webhook sender IDs. `from` is chosen by whoever holds the
+ * secret, so it must never match a Telegram, Discord or Slack user ID in
+ * an allowlist (the write-tool allowlist included).
+ */
+export const WEBHOOK_SENDER_PREFIX = "webhook:";
+
+export interface WebhookConfig {
+  /** Path on the Gateway HTTP server, e.g. "/webhook" */
+  path: string;
+  /** Shared secret callers must present */
+  secret: string;
+  /** Optional URL that receives the bot's replies */
+  outboundUrl?: string;
+  fetchImpl?: typeof fetch;
+}
+
+/** Body accepted on the inbound endpoint */
+export interface WebhookInbound {
+  text: string;
+  /** Caller-chosen sender ID, seen by the bot as "webhook:<from>"; also the reply target (default "webhook") */
+  from?: string;
+  senderName?: string;
+  messageId?: string;
+}
+
+export interface WebhookResponse {
+  status: number;
+  body: Record<string, unknown>;
+}
+
+export interface WebhookChannel extends ChannelPlugin {
+  path: string;
+  /** Handle one inbound request; headers use Node's lower-cased names */
+  handleRequest(
+    rawBody: string,
+    headers: Record<string, string | string[] | undefined>,
+  ): Promise<WebhookResponse>;
+}
+
+function header(
+  headers: Record<string, string | string[] | undefined>,
+  name: string,
+): string | undefined {
+  const value = headers[name];
+  return Array.isArray(value) ? value[0] : value;
+}
+
+function safeEqual(a: string, b: string): boolean {
+  const ab = Buffer.from(a, "utf8");
+  const bb = Buffer.from(b, "utf8");
+  return ab.length === bb.length && timingSafeEqual(ab, bb);
+}
+
+function error(status: number, code: string, message: string): WebhookResponse {
+  return { status, body: { ok: false, error: { code, message } } };
+}
+
+export function createWebhookPlugin(config: WebhookConfig): WebhookChannel {
+  const fetchImpl = config.fetchImpl ?? fetch;
+  let messageHandler: MessageHandler | null = null;
+
+  const capabilities: ChannelCapabilities = {
+    reactions: false,
+    threads: false,
+    buttons: false,
+    markdown: true,
+    maxMessageLength: 100_000,
+  };
+
+  function authorized(headers: Record<string, string | string[] | undefined>): boolean {
+    const bearer = header(headers, "authorization")?.replace(/^Bearer\s+/i, "");
+    const provided = header(headers, "x-webhook-secret") ?? bearer;
+    return !!provided && safeEqual(provided, config.secret);
+  }
+
+  return {
+    id: "webhook",
+    path: config.path,
+    capabilities,
+
+    async start() {
+      log.info(`Webhook channel accepting POST ${config.path}`);
+    },
+
+    async stop() {},
+
+    onMessage(handler: MessageHandler) {
+      messageHandler = handler;
+    },
+
+    async handleRequest(rawBody, headers) {
+      if (!authorized(headers)) {
+        return error(401, "ERR_UNAUTHORIZED", "Invalid webhook secret");
+      }
+
+      let body: Partial<WebhookInbound>;
+      try {
+        body = JSON.parse(rawBody || "null") ?? {};
+      } catch {
+        return error(400, "ERR_INVALID_REQUEST", "Invalid JSON");
+      }
+      if (typeof body.text !== "string" || body.text.trim() === "") {
+        return error(400, "ERR_INVALID_REQUEST", "text required");
+      }
+
+      const from = typeof body.from === "string" && body.from ? body.from : "webhook";
+      const messageId = typeof body.messageId === "string" && body.messageId ? body.messageId : randomUUID();
+      const ctx: MsgContext = {
+        from: `${WEBHOOK_SENDER_PREFIX}${from}`,
+        senderName: typeof body.senderName === "string" && body.senderName ? body.senderName : from,
+        body: body.text,
+        messageId,
+        channel: "webhook",
+        chatType: "direct",
+        timestamp: Date.now(),
+      };
+
+      // Answer right away; the reply (if any) goes to outboundUrl.
+      if (messageHandler) {
+        const handler = messageHandler;
+        void handler(ctx).catch((err) => log.error("Error handling webhook message", err));
+      }
+      return { status: 202, body: { ok: true, messageId } };
+    },
+
+    async send(target: string, message: OutboundMessage) {
+      if (!config.outboundUrl) {
+        log.debug(`No outboundUrl configured; dropping reply to ${target}`);
+        return;
+      }
+      const res = await fetchImpl(config.outboundUrl, {
+        method: "POST",
+        headers: {
+          "content-type": "application/json",
+          "x-webhook-secret": config.secret,
+        },
+        body: JSON.stringify({
+          to: target.startsWith(WEBHOOK_SENDER_PREFIX) ? target.slice(WEBHOOK_SENDER_PREFIX.length) : target,
+          text: message.text,
+          ...(message.replyToId && { replyToId: message.replyToId }),
+        }),
+      });
+      if (!res.ok) {
+        throw new Error(`Webhook delivery to ${config.outboundUrl} failed: HTTP ${res.status}`);
+      }
+    },
+  };
+}
@@ -0,0 +1,91 @@
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import os from "node:os";
+import path from "node:path";
+import { mkdtempSync, rmSync, writeFileSync, readFileSync, statSync } from "node:fs";
+
+import { backupFiles, listBackups, restoreBackup, readBackupManifest } from "../backups.js";
+import { recordGeneratedFiles, verifyIntegrityManifest } from "../integrity.js";
+
+describe("config backups", () => {
+  let dir: string;
+  let outDir: string;
+  const now = new Date("2026-02-10T08:30:00.000Z");
+
+  beforeEach(() => {
+    dir = mkdtempSync(path.join(os.tmpdir(), "owliabot-backups-"));
+    outDir = mkdtempSync(path.join(os.tmpdir(), "owliabot-backups-out-"));
+    writeFileSync(path.join(dir, "app.yaml"), "timezone: UTC\n");
+    writeFileSync(path.join(dir, "secrets.yaml"), "discord:\n  token: x\n");
+    writeFileSync(path.join(outDir, "docker-compose.yml"), "services: {}\n");
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+    rmSync(outDir, { recursive: true, force: true });
+  });
+
+  it("copies the existing files into a timestamped dir", () => {
+    const backup = backupFiles(
+      dir,
+      [path.join(dir, "app.yaml"), path.join(dir, "secrets.yaml"), path.join(outDir, "docker-compose.yml"), path.join(dir, "new.yaml")],
+      now,
+    );
+
+    expect(backup).toBe(path.join(dir, "backups", "20260210T083000Z"));
+    expect(statSync(backup!).mode & 0o777).toBe(0o700);
+    expect(readFileSync(path.join(backup!, "secrets.yaml"), "utf-8")).toContain("token: x");
+    expect(readBackupManifest(dir, "20260210T083000Z").files.map((f) => f.name)).toEqual([
+      "app.yaml",
+      "secrets.yaml",
+      "docker-compose.yml",
+    ]);
+  });
+
+  it("returns null when nothing exists yet", () => {
+    expect(backupFiles(dir, [path.join(dir, "missing.yaml")], now)).toBeNull();
+    expect(listBackups(dir)).toEqual([]);
+  });
+
+  it("keeps same-named files and same-second backups apart", () => {
+    const other = path.join(outDir, "app.yaml");
+    writeFileSync(other, "timezone: Europe/Berlin\n");
+    backupFiles(dir, [path.join(dir, "app.yaml"), other], now);
+    backupFiles(dir, [path.join(dir, "app.yaml")], now);
+
+    expect(listBackups(dir)).toEqual(["20260210T083000Z", "20260210T083000Z-2"]);
+    expect(readBackupManifest(dir, "20260210T083000Z").files.map((f) => f.name)).toEqual(["app.yaml", "2-app.yaml"]);
+  });
+
+  it("restores the latest backup and refreshes integrity hashes", () => {
+    const appYaml = path.join(dir, "app.yaml");
+    const compose = path.join(outDir, "docker-compose.yml");
+    backupFiles(dir, [appYaml], new Date("2026-02-09T00:00:00.000Z"));
+    writeFileSync(appYaml, "timezone: Asia/Tokyo\n");
+    backupFiles(dir, [appYaml, compose], now);
+
+    writeFileSync(appYaml, "timezone: America/New_York\n");
+    writeFileSync(compose, "services: { changed: {} }\n");
+    recordGeneratedFiles(dir, [appYaml, compose]);
+
+    const result = restoreBackup(dir);
+    expect(result.backup).toBe("20260210T083000Z");
+    expect(result.restored).toEqual([appYaml, compose]);
+    expect(readFileSync(appYaml, "utf-8")).toBe("timezone: Asia/Tokyo\n");
+    expect(readFileSync(compose, "utf-8")).toBe("services: {}\n");
+    expect(verifyIntegrityManifest(dir)).toEqual([]);
+
+    restoreBackup(dir, "20260209T000000Z");
+    expect(readFileSync(appYaml, "utf-8")).toBe("timezone: UTC\n");
+  });
+
+  it("skips files whose directory is gone", () => {
+    backupFiles(dir, [path.join(outDir, "docker-compose.yml")], now);
+    rmSync(outDir, { recursive: true, force: true });
+    expect(restoreBackup(dir).skipped.map((f) => f.name)).toEqual(["docker-compose.yml"]);
+  });
+
+  it("fails without backups", () => {
+    expect(() => restoreBackup(dir)).toThrow("No backups");
+    expect(() => restoreBackup(dir, "nope")).toThrow("No backup named nope");
+  });
+});
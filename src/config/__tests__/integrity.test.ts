@@ -0,0 +1,77 @@
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import os from "node:os";
+import path from "node:path";
+import { mkdtempSync, rmSync, writeFileSync, unlinkSync, readFileSync } from "node:fs";
+
+import {
+  recordGeneratedFiles,
+  refreshTrackedFile,
+  verifyIntegrityManifest,
+  acceptIntegrityChanges,
+  integrityManifestPath,
+} from "../integrity.js";
+
+describe("integrity manifest", () => {
+  let dir: string;
+  let outDir: string;
+
+  beforeEach(() => {
+    dir = mkdtempSync(path.join(os.tmpdir(), "owliabot-integrity-"));
+    outDir = mkdtempSync(path.join(os.tmpdir(), "owliabot-integrity-out-"));
+    writeFileSync(path.join(dir, "app.yaml"), "timezone: UTC\n");
+    writeFileSync(path.join(dir, "secrets.yaml"), "discord:\n  token: x\n");
+    writeFileSync(path.join(outDir, "docker-compose.yml"), "services: {}\n");
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+    rmSync(outDir, { recursive: true, force: true });
+  });
+
+  it("returns null without a manifest", () => {
+    expect(verifyIntegrityManifest(dir)).toBeNull();
+  });
+
+  it("records files inside and outside the config dir", () => {
+    const compose = path.join(outDir, "docker-compose.yml");
+    recordGeneratedFiles(dir, [path.join(dir, "app.yaml"), path.join(dir, "secrets.yaml"), compose, path.join(dir, "absent.yml")]);
+
+    const manifest = JSON.parse(readFileSync(integrityManifestPath(dir), "utf-8"));
+    expect(Object.keys(manifest.files).sort()).toEqual([compose, "app.yaml", "secrets.yaml"].sort());
+    expect(verifyIntegrityManifest(dir)).toEqual([]);
+  });
+
+  it("flags modified and missing files", () => {
+    const compose = path.join(outDir, "docker-compose.yml");
+    recordGeneratedFiles(dir, [path.join(dir, "app.yaml"), path.join(dir, "secrets.yaml"), compose]);
+
+    writeFileSync(path.join(dir, "app.yaml"), "timezone: Europe/Berlin\n");
+    unlinkSync(compose);
+
+    expect(verifyIntegrityManifest(dir)).toEqual([
+      { file: "app.yaml", kind: "modified" },
+      { file: compose, kind: "missing" },
+    ]);
+  });
+
+  it("refreshes tracked files after the tool edits them", () => {
+    recordGeneratedFiles(dir, [path.join(dir, "app.yaml")]);
+    writeFileSync(path.join(dir, "app.yaml"), "timezone: Asia/Tokyo\n");
+    refreshTrackedFile(dir, path.join(dir, "app.yaml"));
+    expect(verifyIntegrityManifest(dir)).toEqual([]);
+
+    // Untracked files stay untracked
+    refreshTrackedFile(dir, path.join(dir, "secrets.yaml"));
+    const manifest = JSON.parse(readFileSync(integrityManifestPath(dir), "utf-8"));
+    expect(Object.keys(manifest.files)).toEqual(["app.yaml"]);
+  });
+
+  it("accepts the current state", () => {
+    recordGeneratedFiles(dir, [path.join(dir, "app.yaml"), path.join(dir, "secrets.yaml")]);
+    writeFileSync(path.join(dir, "app.yaml"), "timezone: UTC\nworkspace: ./ws\n");
+    unlinkSync(path.join(dir, "secrets.yaml"));
+
+    acceptIntegrityChanges(dir);
+    expect(verifyIntegrityManifest(dir)).toEqual([]);
+  });
+});
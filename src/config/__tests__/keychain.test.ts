@@ -0,0 +1,105 @@
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+
+const execFileSync = vi.fn();
+vi.mock("node:child_process", () => ({
+  execFileSync: (...args: unknown[]) => execFileSync(...args),
+}));
+
+import {
+  detectKeychainBackend,
+  keychainGet,
+  keychainSet,
+  parseKeychainAccount,
+  resolveKeychainRef,
+  securityCommandLine,
+} from "../keychain.js";
+
+describe("keychain", () => {
+  const platform = process.platform;
+
+  function setPlatform(value: NodeJS.Platform) {
+    Object.defineProperty(process, "platform", { value, configurable: true });
+  }
+
+  beforeEach(() => {
+    execFileSync.mockReset();
+  });
+
+  afterEach(() => {
+    setPlatform(platform);
+  });
+
+  it("parses entry names", () => {
+    expect(parseKeychainAccount("Discord")).toBe("discord");
+    expect(() => parseKeychainAccount("slack")).toThrow(/anthropic, openai, discord, telegram/);
+  });
+
+  it("detects backends per platform", () => {
+    expect(detectKeychainBackend("darwin")).toBe("macos");
+    expect(detectKeychainBackend("win32")).toBe("windows");
+
+    execFileSync.mockImplementationOnce(() => "/usr/bin/secret-tool");
+    expect(detectKeychainBackend("linux")).toBe("libsecret");
+
+    execFileSync.mockImplementationOnce(() => {
+      throw new Error("not found");
+    });
+    expect(detectKeychainBackend("linux")).toBeNull();
+  });
+
+  it("reads and writes through the macOS security CLI", () => {
+    setPlatform("darwin");
+    execFileSync.mockReturnValueOnce("sk-ant-api-123\n");
+    expect(keychainGet("anthropic")).toBe("sk-ant-api-123");
+    expect(execFileSync.mock.calls[0][0]).toBe("security");
+    expect(execFileSync.mock.calls[0][1]).toEqual(["find-generic-password", "-s", "owliabot", "-a", "anthropic", "-w"]);
+
+    execFileSync.mockReturnValueOnce("").mockReturnValueOnce("tok\n");
+    keychainSet("discord", "tok");
+    const [, args, opts] = execFileSync.mock.calls[1];
+    expect(args).toEqual(["-i"]);
+    expect(opts.input).toBe(
+      '"add-generic-password" "-U" "-s" "owliabot" "-a" "discord" "-l" "OwliaBot discord" "-w" "tok"\n',
+    );
+    expect(execFileSync.mock.calls[2][1]).toEqual(["find-generic-password", "-s", "owliabot", "-a", "discord", "-w"]);
+  });
+
+  it("quotes security -i command lines and fails when the entry doesn't read back", () => {
+    expect(securityCommandLine(["-w", 'a"b\\c d'])).toBe('"-w" "a\\"b\\\\c d"\n');
+    expect(() => securityCommandLine(["-w", "a\nb"])).toThrow(/line breaks/);
+
+    setPlatform("darwin");
+    execFileSync.mockReturnValueOnce("").mockImplementationOnce(() => {
+      throw new Error("could not be found");
+    });
+    expect(() => keychainSet("telegram", "123:abc")).toThrow(/Could not store telegram/);
+  });
+
+  it("passes the secret to secret-tool on stdin", () => {
+    setPlatform("linux");
+    execFileSync.mockReturnValueOnce("/usr/bin/secret-tool"); // which
+    keychainSet("telegram", "123:abc");
+    const [cmd, args, opts] = execFileSync.mock.calls[1];
+    expect(cmd).toBe("secret-tool");
+    expect(args).not.toContain("123:abc");
+    expect(opts.input).toBe("123:abc");
+  });
+
+  it("keeps the secret out of PowerShell's argv on Windows", () => {
+    setPlatform("win32");
+    keychainSet("openai", "sk-secret");
+    const [cmd, args, opts] = execFileSync.mock.calls[0];
+    expect(cmd).toBe("powershell.exe");
+    expect(args.join(" ")).not.toContain("sk-secret");
+    expect(opts.env.OWLIA_SECRET).toBe("sk-secret");
+    expect(opts.env.OWLIA_TARGET).toBe("owliabot:openai");
+  });
+
+  it("explains missing entries", () => {
+    setPlatform("darwin");
+    execFileSync.mockImplementationOnce(() => {
+      throw new Error("could not be found");
+    });
+    expect(() => resolveKeychainRef("openai")).toThrow(/owliabot secrets set openai/);
+  });
+});
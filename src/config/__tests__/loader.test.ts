@@ -81,6 +81,22 @@ describe("config loader", () => {
     );
   });
 
+  it("falls back to UTC for an unknown timezone instead of failing", async () => {
+    const appConfigPath = join(dir, "app.yaml");
+    await writeFile(
+      appConfigPath,
+      stringify({
+        providers: [{ id: "anthropic", model: "claude-sonnet-4-5", apiKey: "k", priority: 1 }],
+        workspace: "./workspace",
+        timezone: "Mars/Olympus_Mons",
+      }),
+      "utf-8",
+    );
+
+    const config = await loadConfig(appConfigPath);
+    expect(config.timezone).toBe("UTC");
+  });
+
   it("loads provider API keys from secrets when apiKey is 'secrets'", async () => {
     const appConfigPath = join(dir, "app.yaml");
     const secretsPath = join(dir, "secrets.yaml");
@@ -173,4 +189,27 @@ describe("config loader", () => {
 
     expect(config.providers[0]?.apiKey).toBe("sk-env-openai-key");
   });
+
+  it("disables write tools, exec and MCP in safe mode without touching app.yaml", async () => {
+    const appConfigPath = join(dir, "app.yaml");
+    const appConfig = {
+      providers: [{ id: "anthropic", model: "claude-sonnet-4-5", apiKey: "sk-ant-test", priority: 1 }],
+      workspace: "./workspace",
+      tools: { allowWrite: true },
+      system: { exec: { commandAllowList: ["ls"] } },
+      mcp: { presets: ["playwright"] },
+    };
+    await writeFile(appConfigPath, stringify(appConfig, { indent: 2 }), "utf-8");
+
+    const normal = await loadConfig(appConfigPath);
+    expect(normal.tools.allowWrite).toBe(true);
+    expect(normal.system?.exec?.commandAllowList).toEqual(["ls"]);
+
+    process.env.OWLIABOT_SAFE_MODE = "1";
+    const safe = await loadConfig(appConfigPath);
+    expect(safe.tools.allowWrite).toBe(false);
+    expect(safe.system?.exec).toBeUndefined();
+    expect(safe.system?.web).toBeDefined();
+    expect(safe.mcp?.autoStart).toBe(false);
+  });
 });
@@ -0,0 +1,72 @@
+import { describe, it, expect } from "vitest";
+import { auditPermissions, formatPermissionsAudit } from "../permissions.js";
+
+function section(audit: ReturnType<typeof auditPermissions>, title: string): string[] {
+  return audit.sections.find((s) => s.title === title)?.lines ?? [];
+}
+
+describe("auditPermissions", () => {
+  it("applies schema defaults for a minimal config", () => {
+    const audit = auditPermissions({ providers: [], telegram: { allowList: ["42"] } }, "app.yaml");
+    expect(section(audit, "Channels")).toContain("Telegram: direct messages from 42");
+    expect(section(audit, "Admins")).toEqual([
+      "Write gate: on",
+      "Users allowed to run write tools: nobody",
+      "Confirmation before each write: yes, from the requesting user in the same chat, denied after 60s",
+    ]);
+    expect(section(audit, "Shell commands")[0]).toBe("Allowed commands: none (exec is disabled)");
+    expect(section(audit, "Web access")[0]).toBe("Fetch domains: any public domain");
+    expect(audit.warnings).toEqual([]);
+  });
+
+  it("flags risky settings", () => {
+    const audit = auditPermissions(
+      {
+        telegram: {},
+        security: { writeGateEnabled: false },
+        system: { exec: { commandAllowList: ["ls", "bash"] }, web: { allowPrivateNetworks: true } },
+        gateway: { http: { host: "0.0.0.0", port: 8787 } },
+      },
+      "app.yaml",
+    );
+    expect(audit.warnings).toEqual([
+      "Telegram accepts direct messages from anyone who finds the bot.",
+      "security.writeGateEnabled is false: any user who can chat can trigger write tools.",
+      "Exec allowlist includes general-purpose commands: bash.",
+      "system.web.allowPrivateNetworks is true (SSRF exposure).",
+      "Gateway listens on 0.0.0.0 without authentication.",
+    ]);
+  });
+
+  it("reports Slack allowlists and warns when anyone in the workspace can talk", () => {
+    const locked = auditPermissions({ slack: { memberAllowList: ["U1"], channelAllowList: ["C1"] } }, "app.yaml");
+    expect(section(locked, "Channels")).toEqual([
+      "Slack: users U1",
+      "Slack: answers every message in C1 (elsewhere only when mentioned)",
+    ]);
+    expect(locked.warnings).toEqual([]);
+
+    const open = auditPermissions({ slack: {} }, "app.yaml");
+    expect(section(open, "Channels")[0]).toBe("Slack: users any user in the workspace");
+    expect(open.warnings).toEqual(["Slack accepts messages from anyone in the workspace."]);
+  });
+
+  it("marks the webhook as open to anyone holding the secret", () => {
+    const audit = auditPermissions({ webhook: { path: "/hooks/ci" } }, "app.yaml");
+    expect(section(audit, "Channels")).toEqual([
+      "Webhook: POST /hooks/ci, open to anyone holding the shared secret (no user allowlist)",
+      "Webhook: senders appear as webhook:<from> and never match a chat user ID",
+    ]);
+    expect(audit.warnings).toEqual([
+      "Webhook /hooks/ci accepts messages from anyone holding the secret; keep it out of shared repos and logs.",
+    ]);
+  });
+
+  it("renders markdown sections", () => {
+    const lines = formatPermissionsAudit(auditPermissions({ discord: { memberAllowList: ["1"] } }, "/x/app.yaml"));
+    expect(lines[0]).toBe("# OwliaBot permissions: /x/app.yaml");
+    expect(lines).toContain("## Channels");
+    expect(lines).toContain("- Discord: users 1");
+    expect(lines.at(-1)).toBe("- Nothing stands out.");
+  });
+});
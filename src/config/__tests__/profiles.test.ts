@@ -0,0 +1,111 @@
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import os from "node:os";
+import path from "node:path";
+import { mkdirSync, mkdtempSync, rmSync, writeFileSync } from "node:fs";
+
+import {
+  activeProfile,
+  applyProfile,
+  listProfiles,
+  nextGatewayPort,
+  parseProfileName,
+  profileComposeFile,
+  profileContainerName,
+  profileFromArgv,
+  profileGatewayPort,
+  profileHome,
+} from "../profiles.js";
+
+describe("profiles", () => {
+  let home: string;
+  let composeDir: string;
+
+  beforeEach(() => {
+    home = mkdtempSync(path.join(os.tmpdir(), "owliabot-profiles-"));
+    composeDir = mkdtempSync(path.join(os.tmpdir(), "owliabot-profiles-compose-"));
+  });
+
+  afterEach(() => {
+    rmSync(home, { recursive: true, force: true });
+    rmSync(composeDir, { recursive: true, force: true });
+  });
+
+  function addProfile(dirName: string, appYaml?: string) {
+    mkdirSync(path.join(home, dirName), { recursive: true });
+    if (appYaml !== undefined) writeFileSync(path.join(home, dirName, "app.yaml"), appYaml);
+  }
+
+  it("validates profile names", () => {
+    expect(parseProfileName(" Work ")).toBe("work");
+    expect(parseProfileName("team-2")).toBe("team-2");
+    expect(() => parseProfileName("2nd")).toThrow(/Invalid profile name/);
+    expect(() => parseProfileName("../x")).toThrow(/Invalid profile name/);
+  });
+
+  it("names dirs, containers and compose files after the profile", () => {
+    expect(profileHome(undefined, home)).toBe(path.join(home, ".owliabot"));
+    expect(profileHome("default", home)).toBe(path.join(home, ".owliabot"));
+    expect(profileHome("work", home)).toBe(path.join(home, ".owliabot-work"));
+    expect(profileContainerName("work")).toBe("owliabot-work");
+    expect(profileContainerName()).toBe("owliabot");
+    expect(profileComposeFile("work")).toBe("docker-compose.work.yml");
+    expect(profileComposeFile()).toBe("docker-compose.yml");
+  });
+
+  it("reads --profile from argv", () => {
+    expect(profileFromArgv(["--profile", "work", "status"])).toBe("work");
+    expect(profileFromArgv(["status", "--profile=work"])).toBe("work");
+    expect(profileFromArgv(["status", "--", "--profile", "work"])).toBeUndefined();
+  });
+
+  it("points OWLIABOT_HOME at the profile dir", () => {
+    const env: Record<string, string | undefined> = { OWLIABOT_HOME: "/elsewhere" };
+
+    expect(applyProfile("Work", env, home)).toBe("work");
+    expect(env.OWLIABOT_PROFILE).toBe("work");
+    expect(env.OWLIABOT_HOME).toBe(path.join(home, ".owliabot-work"));
+    expect(activeProfile(env)).toBe("work");
+  });
+
+  it("falls back to OWLIABOT_PROFILE and leaves the env alone without one", () => {
+    const env: Record<string, string | undefined> = { OWLIABOT_PROFILE: "lab" };
+    expect(applyProfile(undefined, env, home)).toBe("lab");
+    expect(env.OWLIABOT_HOME).toBe(path.join(home, ".owliabot-lab"));
+
+    const empty: Record<string, string | undefined> = {};
+    expect(applyProfile(undefined, empty, home)).toBeUndefined();
+    expect(empty).toEqual({});
+    expect(activeProfile({ OWLIABOT_PROFILE: "default" })).toBeUndefined();
+  });
+
+  it("lists profiles, default first", () => {
+    addProfile(".owliabot-work", "timezone: UTC\n");
+    addProfile(".owliabot", "timezone: UTC\n");
+    addProfile(".owliabot-alpha");
+    addProfile(".owliabot-Bad_Name");
+    addProfile(".config");
+
+    expect(listProfiles(home)).toEqual([
+      { name: "default", dir: path.join(home, ".owliabot"), configured: true },
+      { name: "alpha", dir: path.join(home, ".owliabot-alpha"), configured: false },
+      { name: "work", dir: path.join(home, ".owliabot-work"), configured: true },
+    ]);
+  });
+
+  it("picks the next free gateway port", () => {
+    expect(nextGatewayPort(new Set([8787, 8788, 8790]))).toBe(8789);
+    expect(profileGatewayPort(undefined, composeDir, home)).toBe(8787);
+
+    addProfile(".owliabot", "gateway:\n  http:\n    host: 127.0.0.1\n    port: 8787\n");
+    writeFileSync(path.join(composeDir, "docker-compose.lab.yml"), 'ports:\n  - "127.0.0.1:8788:8787"\n');
+
+    expect(profileGatewayPort("work", composeDir, home)).toBe(8789);
+  });
+
+  it("keeps the port a profile's compose file already publishes", () => {
+    writeFileSync(path.join(composeDir, "docker-compose.yml"), 'ports:\n  - "127.0.0.1:8787:8787"\n');
+    writeFileSync(path.join(composeDir, "docker-compose.work.yml"), 'ports:\n  - "127.0.0.1:8795:8787"\n');
+
+    expect(profileGatewayPort("work", composeDir, home)).toBe(8795);
+  });
+});
@@ -0,0 +1,60 @@
+import { describe, it, expect } from "vitest";
+
+import { configSchema } from "../schema.js";
+import {
+  CONFIG_KEY_DOCS,
+  explainConfigKey,
+  formatConfigKeyExplanation,
+  listConfigKeys,
+  parseKeyPath,
+  suggestKey,
+} from "../registry.js";
+
+const CONFIG = {
+  providers: [{ id: "anthropic", model: "claude-sonnet-4-5", apiKey: "secrets", priority: 1 }],
+  gateway: { http: { port: 9000 } },
+  telegram: { groups: { "-100": { requireMention: false } } },
+};
+
+describe("config key registry", () => {
+  it("only documents keys the schema has", () => {
+    const keys = new Set(listConfigKeys(configSchema));
+    expect(Object.keys(CONFIG_KEY_DOCS).filter((k) => !keys.has(k))).toEqual([]);
+  });
+
+  it("explains a key with its current value, default and wizard question", () => {
+    const e = explainConfigKey("gateway.http.port", CONFIG);
+    expect(e).toMatchObject({
+      key: "gateway.http.port",
+      type: "integer",
+      current: 9000,
+      isSet: true,
+      default: 8787,
+      hasDefault: true,
+      wizard: 'Gateway HTTP: "Port"',
+    });
+    expect(formatConfigKeyExplanation(e)).toContain("  Current:  9000");
+  });
+
+  it("maps list indexes and map keys to the registry form", () => {
+    expect(parseKeyPath("providers[0].model")).toEqual(["providers", "0", "model"]);
+    const model = explainConfigKey("providers[0].model", CONFIG);
+    expect(model).toMatchObject({ registryKey: "providers.*.model", current: "claude-sonnet-4-5" });
+
+    const mention = explainConfigKey("telegram.groups.-100.requireMention", CONFIG);
+    expect(mention).toMatchObject({ registryKey: "telegram.groups.*.requireMention", current: false, type: "boolean" });
+  });
+
+  it("explains keys that aren't set, with schema-only details", () => {
+    const e = explainConfigKey("group.activation", {});
+    expect(e).toMatchObject({ isSet: false, default: "mention", type: "one of: mention, always" });
+    expect(explainConfigKey("gateway.http").children).toContain("port");
+  });
+
+  it("suggests the closest key for a typo", () => {
+    expect(() => explainConfigKey("gateway.http.prot")).toThrow(/Did you mean gateway\.http\.port\?/);
+    expect(() => explainConfigKey("providers.model")).toThrow(/providers\.0\.model/);
+    expect(suggestKey("apikey", ["token", "apiKey"])).toBe("apiKey");
+    expect(suggestKey("bogus", ["token", "apiKey"])).toBeUndefined();
+  });
+});
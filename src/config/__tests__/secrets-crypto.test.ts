@@ -0,0 +1,71 @@
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import { mkdtempSync, mkdirSync, writeFileSync, rmSync } from "node:fs";
+import { tmpdir } from "node:os";
+import { join } from "node:path";
+
+const execFileSync = vi.fn();
+vi.mock("node:child_process", () => ({
+  execFileSync: (...args: unknown[]) => execFileSync(...args),
+}));
+
+import {
+  AGE_ARMOR_HEADER,
+  decryptSecretsContent,
+  encryptSecretsContent,
+  isEncryptedSecrets,
+  secretsKeyPath,
+} from "../secrets-crypto.js";
+
+const CIPHERTEXT = `${AGE_ARMOR_HEADER}\nYWdlLWVuY3J5cHRpb24ub3Jn\n-----END AGE ENCRYPTED FILE-----\n`;
+
+describe("secrets-crypto", () => {
+  let dir: string;
+
+  beforeEach(() => {
+    dir = mkdtempSync(join(tmpdir(), "owliabot-secrets-crypto-"));
+    execFileSync.mockReset();
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+  });
+
+  it("detects armored age files", () => {
+    expect(isEncryptedSecrets(CIPHERTEXT)).toBe(true);
+    expect(isEncryptedSecrets("discord:\n  token: abc\n")).toBe(false);
+  });
+
+  it("resolves the key from the config dir or OWLIABOT_SECRETS_KEY_FILE", () => {
+    expect(secretsKeyPath("/cfg", {})).toBe("/cfg/auth/secrets.agekey");
+    expect(secretsKeyPath("/cfg", { OWLIABOT_SECRETS_KEY_FILE: "/run/key" })).toBe("/run/key");
+  });
+
+  it("passes plain YAML through without calling age", () => {
+    expect(decryptSecretsContent("a: 1\n", dir)).toBe("a: 1\n");
+    expect(execFileSync).not.toHaveBeenCalled();
+  });
+
+  it("decrypts with the identity in auth/", () => {
+    mkdirSync(join(dir, "auth"));
+    writeFileSync(join(dir, "auth", "secrets.agekey"), "AGE-SECRET-KEY-1TEST\n");
+    execFileSync.mockReturnValue("discord:\n  token: abc\n");
+
+    expect(decryptSecretsContent(CIPHERTEXT, dir)).toContain("token: abc");
+    expect(execFileSync).toHaveBeenCalledWith(
+      "age",
+      ["--decrypt", "-i", join(dir, "auth", "secrets.agekey")],
+      expect.objectContaining({ input: CIPHERTEXT }),
+    );
+  });
+
+  it("explains a missing key", () => {
+    expect(() => decryptSecretsContent(CIPHERTEXT, dir)).toThrow(/key file .* was not found/);
+  });
+
+  it("explains a missing age binary", () => {
+    execFileSync.mockImplementation(() => {
+      throw Object.assign(new Error("spawn age ENOENT"), { code: "ENOENT" });
+    });
+    expect(() => encryptSecretsContent("a: 1\n", "age1xyz")).toThrow(/age is not installed/);
+  });
+});
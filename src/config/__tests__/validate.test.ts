@@ -0,0 +1,143 @@
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import os from "node:os";
+import path from "node:path";
+import { mkdtemp, rm, writeFile } from "node:fs/promises";
+
+import { configSchema } from "../schema.js";
+import {
+  validateConfigFiles,
+  findUnknownKeys,
+  formatConfigValidationReport,
+} from "../validate.js";
+
+const VALID_CONFIG = [
+  "providers:",
+  "  - id: anthropic",
+  "    model: claude-sonnet-4-5",
+  "    apiKey: secrets",
+  "    priority: 1",
+  "workspace: ./workspace",
+  "",
+].join("\n");
+
+describe("validateConfigFiles", () => {
+  let dir: string;
+  let configPath: string;
+
+  beforeEach(async () => {
+    dir = await mkdtemp(path.join(os.tmpdir(), "owliabot-validate-"));
+    configPath = path.join(dir, "app.yaml");
+  });
+
+  afterEach(async () => {
+    await rm(dir, { recursive: true, force: true });
+  });
+
+  it("accepts a minimal valid config", async () => {
+    await writeFile(configPath, VALID_CONFIG, "utf-8");
+    const report = await validateConfigFiles({ configPath, env: {} });
+    expect(report.ok).toBe(true);
+    expect(report.issues).toEqual([]);
+  });
+
+  it("reports a missing config file", async () => {
+    const report = await validateConfigFiles({ configPath, env: {} });
+    expect(report.ok).toBe(false);
+    expect(report.issues[0].id).toBe("config.missing");
+  });
+
+  it("reports YAML syntax errors with a line number", async () => {
+    await writeFile(configPath, "providers:\n  - id: [\n", "utf-8");
+    const report = await validateConfigFiles({ configPath, env: {} });
+    expect(report.ok).toBe(false);
+    expect(report.issues[0].id).toBe("config.parse_error");
+    expect(report.issues[0].line).toBeGreaterThan(0);
+  });
+
+  it("reports an empty provider list", async () => {
+    await writeFile(configPath, "providers: []\n", "utf-8");
+    const report = await validateConfigFiles({ configPath, env: {} });
+    expect(report.ok).toBe(false);
+    const issue = report.issues.find((i) => i.path === "providers");
+    expect(issue?.id).toBe("config.validation_error");
+    expect(issue?.line).toBe(1);
+  });
+
+  it("warns about unknown keys at their line", async () => {
+    await writeFile(configPath, VALID_CONFIG + "discord:\n  memberAlowList: []\n", "utf-8");
+    const report = await validateConfigFiles({ configPath, env: {} });
+    expect(report.ok).toBe(true);
+    const issue = report.issues.find((i) => i.id === "config.unknown_key");
+    expect(issue).toMatchObject({ path: "discord.memberAlowList", line: 8, severity: "warn" });
+    expect(issue?.message).toContain('Did you mean "memberAllowList"?');
+  });
+
+  it("rejects an out-of-range gateway port", async () => {
+    await writeFile(configPath, VALID_CONFIG + "gateway:\n  http:\n    port: 70000\n", "utf-8");
+    const report = await validateConfigFiles({ configPath, env: {} });
+    expect(report.ok).toBe(false);
+    expect(report.issues).toContainEqual(
+      expect.objectContaining({ id: "config.gateway.port.out_of_range", path: "gateway.http.port", line: 9 }),
+    );
+  });
+
+  it("reports an unknown timezone at its line", async () => {
+    await writeFile(configPath, VALID_CONFIG + "timezone: Mars/Olympus_Mons\n", "utf-8");
+    const report = await validateConfigFiles({ configPath, env: {} });
+    expect(report.ok).toBe(false);
+    expect(report.issues).toContainEqual(
+      expect.objectContaining({ id: "config.validation_error", path: "timezone", line: 7 }),
+    );
+  });
+
+  it("rejects malformed allowlist IDs", async () => {
+    await writeFile(
+      configPath,
+      VALID_CONFIG + "telegram:\n  allowList:\n    - \"12345\"\n    - \"@alice\"\n",
+      "utf-8",
+    );
+    const report = await validateConfigFiles({ configPath, env: {} });
+    expect(report.ok).toBe(false);
+    expect(report.issues).toContainEqual(
+      expect.objectContaining({ id: "config.allowlist.invalid_id", path: "telegram.allowList.1", line: 10 }),
+    );
+  });
+
+  it("rejects unknown keys in secrets.yaml", async () => {
+    await writeFile(configPath, VALID_CONFIG, "utf-8");
+    await writeFile(path.join(dir, "secrets.yaml"), "anthropic:\n  apikey: sk-ant-x\n", "utf-8");
+    const report = await validateConfigFiles({ configPath, env: {} });
+    expect(report.ok).toBe(false);
+    expect(report.issues).toContainEqual(
+      expect.objectContaining({ id: "secrets.unknown_key", path: "anthropic.apikey", line: 2 }),
+    );
+  });
+});
+
+describe("findUnknownKeys", () => {
+  it("descends into arrays and records", () => {
+    const unknown = findUnknownKeys(configSchema, {
+      providers: [{ id: "x", model: "m", priority: 1, extra: true }],
+      telegram: { groups: { "-100": { requireMention: true, bogus: 1 } } },
+    });
+    expect(unknown).toEqual([
+      ["providers", 0, "extra"],
+      ["telegram", "groups", "-100", "bogus"],
+    ]);
+  });
+});
+
+describe("formatConfigValidationReport", () => {
+  it("prints file:line locations and a summary", () => {
+    const lines = formatConfigValidationReport({
+      ok: false,
+      configPath: "/c/app.yaml",
+      secretsPath: "/c/secrets.yaml",
+      issues: [
+        { id: "x", severity: "error", file: "/c/app.yaml", path: "providers", line: 1, column: 12, message: "bad" },
+      ],
+    });
+    expect(lines[0]).toBe("✗ /c/app.yaml:1:12  providers  bad");
+    expect(lines[lines.length - 1]).toBe("1 error(s), 0 warning(s)");
+  });
+});
@@ -0,0 +1,105 @@
+/**
+ * Timestamped copies of the files onboarding overwrites, and `rollback`.
+ *
+ * Before re-running onboarding writes anything, every file it is about to
+ * replace (app.yaml, secrets.yaml, docker-compose.yml, ...) is copied to
+ * `<configDir>/backups/<timestamp>/`, together with a backup.json that maps
+ * each copy to where it came from. `owliabot rollback` copies the latest (or
+ * a named) backup back in place, so hand edits lost to a re-run can be
+ * recovered. Backups hold secrets, so the directories are 0700 and nothing
+ * is pruned automatically.
+ */
+
+import { copyFileSync, existsSync, mkdirSync, readFileSync, readdirSync, writeFileSync } from "node:fs";
+import { basename, dirname, join, resolve } from "node:path";
+import { refreshTrackedFile } from "./integrity.js";
+
+export const BACKUPS_DIR = "backups";
+export const BACKUP_MANIFEST_FILE = "backup.json";
+
+export interface BackupManifest {
+  createdAt: string;
+  /** Copy name inside the backup dir -> absolute path it was copied from */
+  files: Array<{ name: string; path: string }>;
+}
+
+export interface RestoreResult {
+  backup: string;
+  restored: string[];
+  /** Originals whose directory no longer exists (e.g. a container path) */
+  skipped: Array<{ name: string; path: string }>;
+}
+
+export function backupsDir(configDir: string): string {
+  return join(configDir, BACKUPS_DIR);
+}
+
+/** 2026-02-10T00:00:00.000Z -> 20260210T000000Z */
+function backupName(now: Date): string {
+  return now.toISOString().replace(/[-:]/g, "").replace(/\.\d{3}Z$/, "Z");
+}
+
+/**
+ * Copy those of `filePaths` that exist into a new backup dir.
+ * Returns the backup dir, or null when there was nothing to copy.
+ */
+export function backupFiles(configDir: string, filePaths: string[], now: Date = new Date()): string | null {
+  const existing = [...new Set(filePaths.map((p) => resolve(p)))].filter((p) => existsSync(p));
+  if (existing.length === 0) return null;
+
+  let dir = join(backupsDir(configDir), backupName(now));
+  for (let n = 2; existsSync(dir); n++) dir = join(backupsDir(configDir), `${backupName(now)}-${n}`);
+  mkdirSync(dir, { recursive: true, mode: 0o700 });
+
+  const manifest: BackupManifest = { createdAt: now.toISOString(), files: [] };
+  const taken = new Set<string>();
+  for (const path of existing) {
+    // Environment variants all have an app.yaml; keep each copy.
+    let name = basename(path);
+    for (let n = 2; taken.has(name); n++) name = `${n}-${basename(path)}`;
+    taken.add(name);
+    copyFileSync(path, join(dir, name));
+    manifest.files.push({ name, path });
+  }
+  writeFileSync(join(dir, BACKUP_MANIFEST_FILE), `${JSON.stringify(manifest, null, 2)}\n`, { mode: 0o600 });
+  return dir;
+}
+
+/** Backup names, oldest first */
+export function listBackups(configDir: string): string[] {
+  const dir = backupsDir(configDir);
+  if (!existsSync(dir)) return [];
+  return readdirSync(dir, { withFileTypes: true })
+    .filter((e) => e.isDirectory() && existsSync(join(dir, e.name, BACKUP_MANIFEST_FILE)))
+    .map((e) => e.name)
+    .sort();
+}
+
+export function readBackupManifest(configDir: string, name: string): BackupManifest {
+  const path = join(backupsDir(configDir), name, BACKUP_MANIFEST_FILE);
+  if (!existsSync(path)) throw new Error(`No backup named ${name} in ${backupsDir(configDir)}`);
+  return JSON.parse(readFileSync(path, "utf-8")) as BackupManifest;
+}
+
+/**
+ * Copy a backup (default: the latest) back over the original files and
+ * refresh their integrity hashes, since this is the tool's own edit.
+ * Throws when there is no such backup.
+ */
+export function restoreBackup(configDir: string, name?: string): RestoreResult {
+  const backup = name ?? listBackups(configDir).at(-1);
+  if (!backup) throw new Error(`No backups in ${backupsDir(configDir)} yet`);
+  const manifest = readBackupManifest(configDir, backup);
+
+  const result: RestoreResult = { backup, restored: [], skipped: [] };
+  for (const file of manifest.files) {
+    if (!existsSync(dirname(file.path))) {
+      result.skipped.push(file);
+      continue;
+    }
+    copyFileSync(join(backupsDir(configDir), backup, file.name), file.path);
+    refreshTrackedFile(configDir, file.path);
+    result.restored.push(file.path);
+  }
+  return result;
+}
@@ -0,0 +1,120 @@
+/**
+ * Hash manifest of the files OwliaBot generated, for drift/tamper detection.
+ *
+ * Onboarding records a SHA-256 of every file it writes (app.yaml,
+ * secrets.yaml, docker-compose.yml, ...) in `<configDir>/integrity.json`.
+ * The tool's own edits (doctor fixes, `token set`, `models set`) refresh the
+ * entry for the file they touch, so `doctor` only flags changes made by hand
+ * or by something else on a shared host. Accepting the changes re-records
+ * the current hashes.
+ *
+ * The manifest lives next to the files it describes, so it detects drift and
+ * casual tampering, not an attacker who can also rewrite the manifest.
+ */
+
+import { createHash } from "node:crypto";
+import { existsSync, readFileSync, writeFileSync, renameSync } from "node:fs";
+import { isAbsolute, join, relative, resolve } from "node:path";
+
+export const INTEGRITY_MANIFEST_FILE = "integrity.json";
+
+export interface IntegrityManifest {
+  version: 1;
+  updatedAt: string;
+  /** Path (relative to the config dir when inside it) -> sha256 hex */
+  files: Record<string, string>;
+}
+
+export type IntegrityProblem = { file: string; kind: "modified" | "missing" };
+
+export function integrityManifestPath(configDir: string): string {
+  return join(configDir, INTEGRITY_MANIFEST_FILE);
+}
+
+export function hashFile(filePath: string): string {
+  return createHash("sha256").update(readFileSync(filePath)).digest("hex");
+}
+
+/** Manifest key for a file: relative inside the config dir, absolute outside it. */
+function manifestKey(configDir: string, filePath: string): string {
+  const rel = relative(resolve(configDir), resolve(filePath));
+  return rel.startsWith("..") || isAbsolute(rel) ? resolve(filePath) : rel;
+}
+
+function keyToPath(configDir: string, key: string): string {
+  return isAbsolute(key) ? key : join(configDir, key);
+}
+
+export function readIntegrityManifest(configDir: string): IntegrityManifest | null {
+  const manifestPath = integrityManifestPath(configDir);
+  if (!existsSync(manifestPath)) return null;
+  try {
+    const parsed = JSON.parse(readFileSync(manifestPath, "utf-8")) as IntegrityManifest;
+    return parsed && typeof parsed.files === "object" ? parsed : null;
+  } catch {
+    return null;
+  }
+}
+
+function writeIntegrityManifest(configDir: string, files: Record<string, string>): void {
+  const manifestPath = integrityManifestPath(configDir);
+  const manifest: IntegrityManifest = { version: 1, updatedAt: new Date().toISOString(), files };
+  const tmp = `${manifestPath}.${process.pid}.tmp`;
+  writeFileSync(tmp, `${JSON.stringify(manifest, null, 2)}\n`, { mode: 0o600 });
+  renameSync(tmp, manifestPath);
+}
+
+/**
+ * Record (or re-record) the hashes of files the tool just wrote.
+ * Files that don't exist are skipped.
+ */
+export function recordGeneratedFiles(configDir: string, filePaths: string[]): void {
+  const files = { ...(readIntegrityManifest(configDir)?.files ?? {}) };
+  for (const filePath of filePaths) {
+    if (existsSync(filePath)) files[manifestKey(configDir, filePath)] = hashFile(filePath);
+  }
+  writeIntegrityManifest(configDir, files);
+}
+
+/**
+ * After the tool edits a file, refresh its hash, but only when the file is
+ * already tracked (no manifest, nothing to keep in sync).
+ */
+export function refreshTrackedFile(configDir: string, filePath: string): void {
+  const manifest = readIntegrityManifest(configDir);
+  if (!manifest) return;
+  const key = manifestKey(configDir, filePath);
+  if (!(key in manifest.files)) return;
+  recordGeneratedFiles(configDir, [filePath]);
+}
+
+/**
+ * Files whose content no longer matches the manifest.
+ * Null when there is no manifest to compare against.
+ */
+export function verifyIntegrityManifest(configDir: string): IntegrityProblem[] | null {
+  const manifest = readIntegrityManifest(configDir);
+  if (!manifest) return null;
+  const problems: IntegrityProblem[] = [];
+  for (const [key, expected] of Object.entries(manifest.files)) {
+    const filePath = keyToPath(configDir, key);
+    if (!existsSync(filePath)) problems.push({ file: key, kind: "missing" });
+    else if (hashFile(filePath) !== expected) problems.push({ file: key, kind: "modified" });
+  }
+  return problems;
+}
+
+/**
+ * Accept the files as they are now: re-hash modified ones and stop tracking
+ * missing ones.
+ */
+export function acceptIntegrityChanges(configDir: string): void {
+  const manifest = readIntegrityManifest(configDir);
+  if (!manifest) return;
+  const files: Record<string, string> = {};
+  for (const key of Object.keys(manifest.files)) {
+    const filePath = keyToPath(configDir, key);
+    if (existsSync(filePath)) files[key] = hashFile(filePath);
+  }
+  writeIntegrityManifest(configDir, files);
+}
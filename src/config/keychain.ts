@@ -0,0 +1,216 @@
+/**
+ * OS keychain storage for channel tokens and provider keys.
+ *
+ * app.yaml refers to an entry with the literal value "keychain"
+ * (providers[].apiKey, discord.token, telegram.token). The loader resolves
+ * these references at startup. Entries live under service "owliabot"
+ * ("owliabot-<profile>" for a named profile), with the account set to the
+ * provider/channel id.
+ *
+ * Backends (all via the platform CLI, nothing to install on macOS/Windows):
+ * - macOS: Keychain via `security`
+ * - Windows: Credential Manager via PowerShell (CredRead/CredWrite)
+ * - Linux: libsecret via `secret-tool`
+ */
+
+import { execFileSync } from "node:child_process";
+import { activeProfile } from "./profiles.js";
+
+export const KEYCHAIN_REF = "keychain";
+
+export const KEYCHAIN_SERVICE = "owliabot";
+
+/** Service name for this process's profile, so profiles keep separate entries */
+export function keychainService(): string {
+  const profile = activeProfile();
+  return profile ? `${KEYCHAIN_SERVICE}-${profile}` : KEYCHAIN_SERVICE;
+}
+
+export const KEYCHAIN_ACCOUNTS = ["anthropic", "openai", "discord", "telegram"] as const;
+
+export type KeychainAccount = (typeof KEYCHAIN_ACCOUNTS)[number];
+
+export type KeychainBackend = "macos" | "windows" | "libsecret";
+
+export function parseKeychainAccount(value: string): KeychainAccount {
+  const account = value.trim().toLowerCase();
+  if ((KEYCHAIN_ACCOUNTS as readonly string[]).includes(account)) return account as KeychainAccount;
+  throw new Error(`Unknown keychain entry "${value}" (expected one of: ${KEYCHAIN_ACCOUNTS.join(", ")})`);
+}
+
+function hasCommand(cmd: string): boolean {
+  try {
+    execFileSync(process.platform === "win32" ? "where" : "which", [cmd], { stdio: "ignore" });
+    return true;
+  } catch {
+    return false;
+  }
+}
+
+/**
+ * Which keychain this machine offers, or null when none is usable.
+ */
+export function detectKeychainBackend(platform: NodeJS.Platform = process.platform): KeychainBackend | null {
+  if (platform === "darwin") return "macos";
+  if (platform === "win32") return "windows";
+  if (platform === "linux" && hasCommand("secret-tool")) return "libsecret";
+  return null;
+}
+
+function requireBackend(): KeychainBackend {
+  const backend = detectKeychainBackend();
+  if (!backend) {
+    throw new Error("No OS keychain available (on Linux install libsecret-tools for secret-tool)");
+  }
+  return backend;
+}
+
+function run(cmd: string, args: string[], opts: { input?: string; env?: NodeJS.ProcessEnv } = {}): string {
+  return execFileSync(cmd, args, {
+    input: opts.input,
+    env: opts.env ? { ...process.env, ...opts.env } : process.env,
+    stdio: "pipe",
+    encoding: "utf-8",
+  });
+}
+
+// Windows has no CLI that can read a generic credential back, so use the
+// Win32 API from PowerShell. The secret travels through an env var, not argv.
+const WINDOWS_CRED_TYPE = `
+Add-Type -TypeDefinition @"
+using System;
+using System.Runtime.InteropServices;
+public static class OwliaCred {
+  [StructLayout(LayoutKind.Sequential, CharSet = CharSet.Unicode)]
+  public struct CREDENTIAL {
+    public int Flags; public int Type; public string TargetName; public string Comment;
+    public System.Runtime.InteropServices.ComTypes.FILETIME LastWritten;
+    public int CredentialBlobSize; public IntPtr CredentialBlob; public int Persist;
+    public int AttributeCount; public IntPtr Attributes; public string TargetAlias; public string UserName;
+  }
+  [DllImport("advapi32.dll", CharSet = CharSet.Unicode, SetLastError = true)]
+  public static extern bool CredRead(string target, int type, int flags, out IntPtr cred);
+  [DllImport("advapi32.dll", CharSet = CharSet.Unicode, SetLastError = true)]
+  public static extern bool CredWrite(ref CREDENTIAL cred, int flags);
+  [DllImport("advapi32.dll", CharSet = CharSet.Unicode, SetLastError = true)]
+  public static extern bool CredDelete(string target, int type, int flags);
+  [DllImport("advapi32.dll")]
+  public static extern void CredFree(IntPtr cred);
+}
+"@
+`;
+
+function windowsTarget(account: KeychainAccount): string {
+  return `${keychainService()}:${account}`;
+}
+
+function powershell(script: string, env: NodeJS.ProcessEnv): string {
+  return run("powershell.exe", ["-NoProfile", "-NonInteractive", "-Command", WINDOWS_CRED_TYPE + script], { env });
+}
+
+/**
+ * Read an entry. Returns null when it does not exist.
+ */
+export function keychainGet(account: KeychainAccount): string | null {
+  const backend = requireBackend();
+  try {
+    let value: string;
+    if (backend === "macos") {
+      value = run("security", ["find-generic-password", "-s", keychainService(), "-a", account, "-w"]);
+    } else if (backend === "libsecret") {
+      value = run("secret-tool", ["lookup", "service", keychainService(), "account", account]);
+    } else {
+      value = powershell(
+        `$p = [IntPtr]::Zero
+if (-not [OwliaCred]::CredRead($env:OWLIA_TARGET, 1, 0, [ref]$p)) { exit 3 }
+$c = [Runtime.InteropServices.Marshal]::PtrToStructure($p, [type][OwliaCred+CREDENTIAL])
+[Console]::Out.Write([Runtime.InteropServices.Marshal]::PtrToStringUni($c.CredentialBlob, $c.CredentialBlobSize / 2))
+[OwliaCred]::CredFree($p)`,
+        { OWLIA_TARGET: windowsTarget(account) },
+      );
+    }
+    const trimmed = value.replace(/\r?\n$/, "");
+    return trimmed.length > 0 ? trimmed : null;
+  } catch {
+    // All three CLIs exit non-zero for "not found".
+    return null;
+  }
+}
+
+/**
+ * One `security -i` command line: every argument double-quoted, with `\`
+ * and `"` escaped. Newlines can't be quoted, so values with one are refused.
+ */
+export function securityCommandLine(args: string[]): string {
+  for (const arg of args) {
+    if (/[\r\n\0]/.test(arg)) throw new Error("Keychain values cannot contain line breaks");
+  }
+  return `${args.map((arg) => `"${arg.replace(/[\\"]/g, "\\$&")}"`).join(" ")}\n`;
+}
+
+/**
+ * Create or replace an entry.
+ */
+export function keychainSet(account: KeychainAccount, value: string): void {
+  const backend = requireBackend();
+  const label = `OwliaBot ${account}`;
+  if (backend === "macos") {
+    // -U updates in place. `security -i` reads the command from stdin, so the
+    // password never shows in ps; a bare -w would prompt on the tty instead.
+    // Interactive mode exits 0 even when the command fails, so read it back.
+    run("security", ["-i"], {
+      input: securityCommandLine(["add-generic-password", "-U", "-s", keychainService(), "-a", account, "-l", label, "-w", value]),
+    });
+    if (keychainGet(account) !== value) throw new Error(`Could not store ${account} in the macOS keychain`);
+  } else if (backend === "libsecret") {
+    run("secret-tool", ["store", `--label=${label}`, "service", keychainService(), "account", account], { input: value });
+  } else {
+    powershell(
+      `$bytes = [Text.Encoding]::Unicode.GetBytes($env:OWLIA_SECRET)
+$c = New-Object OwliaCred+CREDENTIAL
+$c.Type = 1; $c.Persist = 2; $c.TargetName = $env:OWLIA_TARGET; $c.UserName = $env:OWLIA_ACCOUNT
+$c.CredentialBlobSize = $bytes.Length
+$c.CredentialBlob = [Runtime.InteropServices.Marshal]::AllocHGlobal($bytes.Length)
+[Runtime.InteropServices.Marshal]::Copy($bytes, 0, $c.CredentialBlob, $bytes.Length)
+try { if (-not [OwliaCred]::CredWrite([ref]$c, 0)) { exit 1 } }
+finally { [Runtime.InteropServices.Marshal]::FreeHGlobal($c.CredentialBlob) }`,
+      { OWLIA_TARGET: windowsTarget(account), OWLIA_ACCOUNT: account, OWLIA_SECRET: value },
+    );
+  }
+}
+
+/**
+ * Remove an entry. Returns false when there was nothing to remove.
+ */
+export function keychainDelete(account: KeychainAccount): boolean {
+  const backend = requireBackend();
+  try {
+    if (backend === "macos") {
+      run("security", ["delete-generic-password", "-s", keychainService(), "-a", account]);
+    } else if (backend === "libsecret") {
+      if (keychainGet(account) === null) return false;
+      run("secret-tool", ["clear", "service", keychainService(), "account", account]);
+    } else {
+      powershell(`if (-not [OwliaCred]::CredDelete($env:OWLIA_TARGET, 1, 0)) { exit 3 }`, {
+        OWLIA_TARGET: windowsTarget(account),
+      });
+    }
+    return true;
+  } catch {
+    return false;
+  }
+}
+
+/**
+ * Resolve a "keychain" reference, with a clear error when the entry is missing.
+ */
+export function resolveKeychainRef(account: KeychainAccount): string {
+  const value = keychainGet(account);
+  if (value === null) {
+    throw new Error(
+      `app.yaml refers to the OS keychain for ${account}, but no entry was found. ` +
+        `Store one with: owliabot secrets set ${account}`,
+    );
+  }
+  return value;
+}
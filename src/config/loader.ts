@@ -5,14 +5,34 @@
 import { readFile } from "node:fs/promises";
 import { parse } from "yaml";
 import { resolve, dirname, join } from "node:path";
-import { configSchema, type Config } from "./schema.js";
+import { configSchema, isKnownTimezone, type Config } from "./schema.js";
 import { createLogger } from "../utils/logger.js";
 import { ZodError } from "zod";
 import { ensureOwliabotHomeEnv } from "../utils/paths.js";
 import { expandEnvVarsDeep } from "./expand-env.js";
+import { decryptSecretsContent } from "./secrets-crypto.js";
+import { KEYCHAIN_REF, resolveKeychainRef } from "./keychain.js";
+import { applySafeMode, isSafeMode } from "./safe-mode.js";
 
 const log = createLogger("config");
 
+/**
+ * Bedrock has no API key: the AWS SDK reads the region and credentials from
+ * the environment (or the instance role). Hand it the region from app.yaml
+ * and the access keys from secrets.yaml, without overriding what the
+ * environment already sets.
+ */
+function exportBedrockEnv(provider: { region?: string; apiKey?: string }, secrets: any): void {
+  if (provider.region && !process.env.AWS_REGION) process.env.AWS_REGION = provider.region;
+  const keys = secrets?.["amazon-bedrock"];
+  if (keys?.accessKeyId && keys?.secretAccessKey && !process.env.AWS_ACCESS_KEY_ID) {
+    process.env.AWS_ACCESS_KEY_ID = keys.accessKeyId;
+    process.env.AWS_SECRET_ACCESS_KEY = keys.secretAccessKey;
+  }
+  // Nothing for resolveApiKey() to pick up; it knows Bedrock needs no key.
+  provider.apiKey = undefined;
+}
+
 export async function loadConfig(path: string): Promise<Config> {
   // Ensure OWLIABOT_HOME is always defined so ${OWLIABOT_HOME} defaults expand.
   ensureOwliabotHomeEnv();
@@ -46,11 +66,12 @@ export async function loadConfig(path: string): Promise<Config> {
 
   // Load secrets from same directory as the app config (optional): <configDir>/secrets.yaml
   // This allows onboarding to keep tokens out of app.yaml while still satisfying schemas.
+  // The file may be age-encrypted (see secrets-crypto.ts).
   const secretsPath = join(configDir, "secrets.yaml");
   let secrets: any = null;
   try {
     const secretsContent = await readFile(secretsPath, "utf-8");
-    secrets = parse(secretsContent) as any;
+    secrets = parse(decryptSecretsContent(secretsContent, configDir)) as any;
   } catch (err) {
     if ((err as NodeJS.ErrnoException).code !== "ENOENT") {
       throw err;
@@ -58,6 +79,9 @@ export async function loadConfig(path: string): Promise<Config> {
   }
 
   // Merge secrets/env tokens into raw config (before env expansion + schema validation)
+  // token: keychain reads the OS keychain instead.
+  if (raw?.discord?.token === KEYCHAIN_REF) raw.discord.token = resolveKeychainRef("discord");
+  if (raw?.telegram?.token === KEYCHAIN_REF) raw.telegram.token = resolveKeychainRef("telegram");
   if (raw?.discord && !raw.discord.token) {
     raw.discord.token =
       secrets?.discord?.token ?? process.env.DISCORD_BOT_TOKEN ?? undefined;
@@ -66,6 +90,17 @@ export async function loadConfig(path: string): Promise<Config> {
     raw.telegram.token =
       secrets?.telegram?.token ?? process.env.TELEGRAM_BOT_TOKEN ?? undefined;
   }
+  if (raw?.slack) {
+    raw.slack.botToken ||= secrets?.slack?.botToken ?? process.env.SLACK_BOT_TOKEN ?? undefined;
+    raw.slack.appToken ||= secrets?.slack?.appToken ?? process.env.SLACK_APP_TOKEN ?? undefined;
+  }
+  if (Array.isArray(raw?.mcp?.presets) && raw.mcp.presets.includes("github")) {
+    raw.mcp.githubToken ||=
+      secrets?.github?.token ?? process.env.GITHUB_PERSONAL_ACCESS_TOKEN ?? undefined;
+  }
+  if (raw?.webhook) {
+    raw.webhook.secret ||= secrets?.webhook?.secret ?? process.env.OWLIABOT_WEBHOOK_SECRET ?? undefined;
+  }
 
   // Merge Clawlet wallet token from secrets/env
   // Priority: config value > secrets.clawlet.token > env var
@@ -79,10 +114,13 @@ export async function loadConfig(path: string): Promise<Config> {
   }
 
   // Merge provider API keys from secrets/env
-  // Respect user's explicit choice: "secrets" = use secrets.yaml, "env" = use env vars only
+  // Respect user's explicit choice: "secrets" = use secrets.yaml, "env" = use env vars only,
+  // "keychain" = OS keychain entry named after the provider
   if (Array.isArray(raw?.providers)) {
     for (const provider of raw.providers) {
-      if (provider.apiKey === "secrets") {
+      if (provider.apiKey === KEYCHAIN_REF && (provider.id === "openai" || provider.id === "anthropic")) {
+        provider.apiKey = resolveKeychainRef(provider.id);
+      } else if (provider.apiKey === "secrets") {
         // Prefer secrets, fallback to env
         if (provider.id === "openai") {
           provider.apiKey =
@@ -99,6 +137,9 @@ export async function loadConfig(path: string): Promise<Config> {
           // OpenAI-compatible can also use secrets.yaml
           provider.apiKey =
             secrets?.["openai-compatible"]?.apiKey ?? undefined;
+        } else if (provider.id === "azure-openai") {
+          provider.apiKey =
+            secrets?.["azure-openai"]?.apiKey ?? process.env.AZURE_OPENAI_API_KEY ?? undefined;
         }
       } else if (provider.apiKey === "env") {
         // Only use env vars (user explicitly chose env-based auth)
@@ -106,8 +147,13 @@ export async function loadConfig(path: string): Promise<Config> {
           provider.apiKey = process.env.OPENAI_API_KEY ?? undefined;
         } else if (provider.id === "anthropic") {
           provider.apiKey = process.env.ANTHROPIC_API_KEY ?? undefined;
+        } else if (provider.id === "openai-compatible") {
+          provider.apiKey = process.env.OPENAI_COMPATIBLE_API_KEY ?? undefined;
+        } else if (provider.id === "azure-openai") {
+          provider.apiKey = process.env.AZURE_OPENAI_API_KEY ?? undefined;
         }
       }
+      if (provider.id === "amazon-bedrock") exportBedrockEnv(provider, secrets);
     }
   }
 
@@ -116,6 +162,10 @@ export async function loadConfig(path: string): Promise<Config> {
     raw.gateway.http.token =
       secrets?.gateway?.token ?? process.env.OWLIABOT_GATEWAY_TOKEN ?? undefined;
   }
+  if (raw?.gateway?.http?.basicAuth?.password === "secrets") {
+    raw.gateway.http.basicAuth.password =
+      secrets?.gateway?.basicAuthPassword ?? process.env.OWLIABOT_GATEWAY_PASSWORD ?? undefined;
+  }
 
   // Expand environment variables on user-provided values (before schema defaults apply).
   // Note: schema defaults may also contain ${VARS}; we expand again after parse.
@@ -128,6 +178,12 @@ export async function loadConfig(path: string): Promise<Config> {
     expanded.group.activation = expanded.discord.requireMentionInGuild ? "mention" : "always";
   }
 
+  // An unknown timezone shouldn't keep the bot from starting; `validate` still reports it
+  if (typeof expanded?.timezone === "string" && !isKnownTimezone(expanded.timezone)) {
+    log.warn(`Unknown timezone "${expanded.timezone}" (use an IANA name such as Europe/Berlin); using UTC`);
+    expanded.timezone = "UTC";
+  }
+
   // Validate with Zod (applies defaults)
   let config: Config;
   try {
@@ -153,6 +209,19 @@ export async function loadConfig(path: string): Promise<Config> {
   config.workspace = resolve(configDir, config.workspace);
   log.debug(`Resolved workspace path: ${config.workspace}`);
 
+  // Gateway TLS files are resolved relative to the config file, like workspace.
+  const tls = config.gateway?.http?.tls;
+  if (tls) {
+    tls.certPath = resolve(configDir, tls.certPath);
+    tls.keyPath = resolve(configDir, tls.keyPath);
+    if (tls.clientCaPath) tls.clientCaPath = resolve(configDir, tls.clientCaPath);
+  }
+
+  if (isSafeMode()) {
+    const disabled = applySafeMode(config);
+    log.warn(`Safe mode: ${disabled.length > 0 ? `${disabled.join(", ")} disabled` : "nothing risky was enabled"}`);
+  }
+
   log.info("Config loaded successfully");
   return config;
 }
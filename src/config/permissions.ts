@@ -0,0 +1,186 @@
+/**
+ * Permission audit for app.yaml: what the bot may do, in plain language.
+ *
+ * Reads the raw YAML (no secrets, no keychain) and applies the same defaults
+ * as the schema, so the report reflects what the running bot would enforce.
+ * Intended for pasting into a security review.
+ */
+
+import fs from "node:fs";
+import { parse } from "yaml";
+import { expandEnvVarsDeep } from "./expand-env.js";
+
+export interface PermissionsSection {
+  title: string;
+  lines: string[];
+}
+
+export interface PermissionsAudit {
+  configPath: string;
+  sections: PermissionsSection[];
+  /** Settings a reviewer should look at twice */
+  warnings: string[];
+}
+
+function list(values: unknown): string[] {
+  return Array.isArray(values) ? values.map(String) : [];
+}
+
+function joinOr(values: string[], empty: string): string {
+  return values.length > 0 ? values.join(", ") : empty;
+}
+
+function describeConfirmation(security: Record<string, any>): string {
+  const who = security.writeToolConfirmationApprover ? `user ${security.writeToolConfirmationApprover}` : "the requesting user";
+  const where = security.writeToolConfirmationIn === "dm" ? "by direct message" : "in the same chat";
+  const seconds = Math.round((security.writeToolConfirmationTimeoutMs ?? 60_000) / 1000);
+  return `yes, from ${who} ${where}, denied after ${seconds}s`;
+}
+
+/**
+ * Build the audit from a parsed (env-expanded) app.yaml object.
+ */
+export function auditPermissions(raw: Record<string, any>, configPath: string): PermissionsAudit {
+  const warnings: string[] = [];
+  const sections: PermissionsSection[] = [];
+
+  // Channels: who can talk to the bot
+  const channels: string[] = [];
+  if (raw.discord) {
+    const members = list(raw.discord.memberAllowList);
+    const chans = list(raw.discord.channelAllowList);
+    channels.push(`Discord: users ${joinOr(members, "any user")}`);
+    channels.push(`Discord: guild channels ${joinOr(chans, "any channel")}`);
+    channels.push(
+      `Discord: ${raw.discord.requireMentionInGuild === false ? "answers every guild message" : "answers in guilds only when mentioned (or in allowlisted channels)"}`,
+    );
+    if (members.length === 0 && raw.discord.requireMentionInGuild === false) {
+      warnings.push("Discord answers every message from anyone in every guild it joins.");
+    }
+  }
+  if (raw.telegram) {
+    const users = list(raw.telegram.allowList);
+    channels.push(`Telegram: direct messages from ${joinOr(users, "anyone")}`);
+    const groups = Object.keys(raw.telegram.groups ?? {});
+    channels.push(`Telegram: group overrides ${joinOr(groups, "none")}`);
+    if (users.length === 0) warnings.push("Telegram accepts direct messages from anyone who finds the bot.");
+  }
+  if (raw.slack) {
+    const members = list(raw.slack.memberAllowList);
+    const chans = list(raw.slack.channelAllowList);
+    channels.push(`Slack: users ${joinOr(members, "any user in the workspace")}`);
+    channels.push(`Slack: answers every message in ${joinOr(chans, "no channels")} (elsewhere only when mentioned)`);
+    if (members.length === 0) warnings.push("Slack accepts messages from anyone in the workspace.");
+  }
+  if (raw.webhook) {
+    const path = raw.webhook.path ?? "/webhook";
+    channels.push(`Webhook: POST ${path}, open to anyone holding the shared secret (no user allowlist)`);
+    channels.push("Webhook: senders appear as webhook:<from> and never match a chat user ID");
+    warnings.push(`Webhook ${path} accepts messages from anyone holding the secret; keep it out of shared repos and logs.`);
+  }
+  if (channels.length === 0) channels.push("No chat channels configured");
+  sections.push({ title: "Channels", lines: channels });
+
+  // Admins: users allowed to run write-level tools
+  const security = raw.security ?? {};
+  const writeGate = security.writeGateEnabled !== false;
+  const admins = list(security.writeToolAllowList);
+  const adminLines = [
+    `Write gate: ${writeGate ? "on" : "OFF"}`,
+    `Users allowed to run write tools: ${joinOr(admins, writeGate ? "nobody" : "everyone (gate is off)")}`,
+    `Confirmation before each write: ${security.writeToolConfirmation === false ? "no" : describeConfirmation(security)}`,
+  ];
+  if (!writeGate) warnings.push("security.writeGateEnabled is false: any user who can chat can trigger write tools.");
+  sections.push({ title: "Admins", lines: adminLines });
+
+  // Tools
+  const tools = raw.tools ?? {};
+  const policy = tools.policy ?? {};
+  const toolLines = [
+    `File editing (edit_file): ${tools.allowWrite === true ? "enabled" : "disabled"}`,
+  ];
+  if (policy.allowList) toolLines.push(`Only these tools: ${joinOr(list(policy.allowList), "(none)")}`);
+  else if (policy.denyList) toolLines.push(`All tools except: ${joinOr(list(policy.denyList), "(none)")}`);
+  else toolLines.push("Tool policy: all built-in tools available");
+  sections.push({ title: "Tools", lines: toolLines });
+
+  // Exec
+  const exec = raw.system?.exec ?? {};
+  const commands = list(exec.commandAllowList);
+  sections.push({
+    title: "Shell commands",
+    lines: [
+      `Allowed commands: ${joinOr(commands, "none (exec is disabled)")}`,
+      `Environment passed through: ${joinOr(exec.envAllowList ? list(exec.envAllowList) : ["PATH", "LANG"], "none")}`,
+      `Timeout: ${Math.round((exec.timeoutMs ?? 60_000) / 1000)}s`,
+    ],
+  });
+  const risky = commands.filter((c) => ["sh", "bash", "zsh", "python", "python3", "node", "curl", "wget", "sudo"].includes(c));
+  if (risky.length > 0) warnings.push(`Exec allowlist includes general-purpose commands: ${risky.join(", ")}.`);
+
+  // Web
+  const web = raw.system?.web ?? {};
+  const allowDomains = list(web.domainAllowList);
+  const webLines = [
+    `Fetch domains: ${joinOr(allowDomains, "any public domain")}`,
+    `Blocked domains: ${joinOr(list(web.domainDenyList), "none")}`,
+    `Private networks / localhost: ${web.allowPrivateNetworks === true ? "allowed when allowlisted" : "blocked"}`,
+    `Block requests that contain secrets: ${web.blockOnSecret === false ? "no" : "yes"}`,
+  ];
+  if (web.allowPrivateNetworks === true) warnings.push("system.web.allowPrivateNetworks is true (SSRF exposure).");
+  sections.push({ title: "Web access", lines: webLines });
+
+  // Wallet + MCP
+  const clawlet = raw.wallet?.clawlet;
+  const mcpServers = Array.isArray(raw.mcp?.servers) ? raw.mcp.servers.map((s: any) => String(s?.name ?? "?")) : [];
+  sections.push({
+    title: "Integrations",
+    lines: [
+      `Wallet (Clawlet): ${clawlet?.enabled === true ? `enabled (chain ${clawlet.defaultChainId ?? 8453})` : "disabled"}`,
+      `MCP presets: ${joinOr(list(raw.mcp?.presets), "none")}`,
+      `MCP servers: ${joinOr(mcpServers, "none")}`,
+    ],
+  });
+
+  // Gateway
+  const http = raw.gateway?.http;
+  if (http && http.enabled !== false) {
+    const auth = [http.token ? "token" : null, http.basicAuth ? "basic auth" : null, http.tls?.clientCaPath ? "mTLS" : null]
+      .filter(Boolean)
+      .join(" + ");
+    sections.push({
+      title: "Gateway HTTP",
+      lines: [
+        `Listens on ${http.host ?? "127.0.0.1"}:${http.port ?? 8787}${http.tls ? " (HTTPS)" : ""}`,
+        `Authentication: ${auth || "none"}`,
+        `IP allowlist: ${joinOr(list(http.allowlist), "any")}`,
+      ],
+    });
+    if (!auth && http.host !== "127.0.0.1" && http.host !== undefined) {
+      warnings.push(`Gateway listens on ${http.host} without authentication.`);
+    }
+  }
+
+  return { configPath, sections, warnings };
+}
+
+/**
+ * Read app.yaml and audit it.
+ */
+export function auditPermissionsFile(configPath: string): PermissionsAudit {
+  const raw = parse(fs.readFileSync(configPath, "utf-8")) ?? {};
+  return auditPermissions(expandEnvVarsDeep(raw, process.env) as Record<string, any>, configPath);
+}
+
+/**
+ * Markdown rendering (readable in a terminal and in a review document).
+ */
+export function formatPermissionsAudit(audit: PermissionsAudit): string[] {
+  const out = [`# OwliaBot permissions: ${audit.configPath}`, ""];
+  for (const section of audit.sections) {
+    out.push(`## ${section.title}`, ...section.lines.map((l) => `- ${l}`), "");
+  }
+  out.push("## Review notes");
+  out.push(...(audit.warnings.length > 0 ? audit.warnings.map((w) => `- ⚠ ${w}`) : ["- Nothing stands out."]));
+  return out;
+}
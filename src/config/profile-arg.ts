@@ -0,0 +1,16 @@
+/**
+ * Applies `--profile <name>` (or OWLIABOT_PROFILE) as soon as it is imported.
+ *
+ * entry.ts imports this first: several modules resolve OWLIABOT_HOME when
+ * they load, and the commands compute their default config paths when they
+ * are declared, both before commander parses the arguments.
+ */
+
+import { applyProfile, profileFromArgv } from "./profiles.js";
+
+try {
+  applyProfile(profileFromArgv(process.argv.slice(2)));
+} catch (err) {
+  console.error((err as Error).message);
+  process.exit(1);
+}
@@ -0,0 +1,181 @@
+/**
+ * Named profiles: several OwliaBots on one host.
+ *
+ * `owliabot --profile work ...` (or OWLIABOT_PROFILE=work) points the CLI at
+ * ~/.owliabot-work instead of ~/.owliabot. In Docker mode the profile also
+ * gets its own compose file (docker-compose.work.yml), compose project and
+ * container (owliabot-work), and a host port no other profile uses. The
+ * "default" profile is the plain ~/.owliabot install.
+ *
+ * The layout matches `onboard --environments`, so an environment written
+ * that way can be addressed as a profile of the same name.
+ */
+
+import { existsSync, readdirSync, readFileSync } from "node:fs";
+import { join } from "node:path";
+import { parse } from "yaml";
+import { resolveHomeDir } from "../utils/paths.js";
+
+export const PROFILE_ENV = "OWLIABOT_PROFILE";
+export const DEFAULT_PROFILE = "default";
+export const DEFAULT_GATEWAY_PORT = 8787;
+
+const PROFILE_NAME = /^[a-z][a-z0-9-]{0,30}$/;
+
+export function parseProfileName(value: string): string {
+  const name = value.trim().toLowerCase();
+  if (!PROFILE_NAME.test(name)) {
+    throw new Error(`Invalid profile name "${value}" (use lowercase letters, digits and dashes)`);
+  }
+  return name;
+}
+
+function isDefault(profile: string | undefined): boolean {
+  return !profile || profile === DEFAULT_PROFILE;
+}
+
+/** Config dir name under $HOME: .owliabot, or .owliabot-<name> */
+export function profileDirName(profile?: string): string {
+  return isDefault(profile) ? ".owliabot" : `.owliabot-${profile}`;
+}
+
+export function profileHome(profile?: string, homeDir: string = resolveHomeDir()): string {
+  return join(homeDir, profileDirName(profile));
+}
+
+/** container_name and compose project name */
+export function profileContainerName(profile?: string): string {
+  return isDefault(profile) ? "owliabot" : `owliabot-${profile}`;
+}
+
+export function profileComposeFile(profile?: string): string {
+  return isDefault(profile) ? "docker-compose.yml" : `docker-compose.${profile}.yml`;
+}
+
+/** The profile this process runs as (set by applyProfile), undefined for the default */
+export function activeProfile(env: Record<string, string | undefined> = process.env): string | undefined {
+  const name = env[PROFILE_ENV]?.trim();
+  return name && name !== DEFAULT_PROFILE ? name : undefined;
+}
+
+/**
+ * `--profile <name>` / `--profile=<name>` from CLI arguments (before any `--`).
+ */
+export function profileFromArgv(args: string[]): string | undefined {
+  for (let i = 0; i < args.length; i++) {
+    const arg = args[i];
+    if (arg === "--") break;
+    if (arg === "--profile") return args[i + 1];
+    if (arg.startsWith("--profile=")) return arg.slice("--profile=".length);
+  }
+  return undefined;
+}
+
+/**
+ * Point OWLIABOT_HOME at the profile's config dir. A profile (from the
+ * argument, else OWLIABOT_PROFILE) wins over an OWLIABOT_HOME already set.
+ * Returns the profile name, or undefined when none was asked for.
+ */
+export function applyProfile(
+  profile: string | undefined,
+  env: Record<string, string | undefined> = process.env,
+  homeDir: string = resolveHomeDir(),
+): string | undefined {
+  const requested = profile ?? env[PROFILE_ENV];
+  if (requested === undefined || requested.trim() === "") return undefined;
+  const name = parseProfileName(requested);
+  env[PROFILE_ENV] = name;
+  env.OWLIABOT_HOME = profileHome(name, homeDir);
+  return name;
+}
+
+export interface ProfileInfo {
+  name: string;
+  dir: string;
+  /** app.yaml exists */
+  configured: boolean;
+}
+
+/**
+ * Profiles with a config dir under `homeDir`, default first, then by name.
+ */
+export function listProfiles(homeDir: string = resolveHomeDir()): ProfileInfo[] {
+  let entries: string[];
+  try {
+    entries = readdirSync(homeDir);
+  } catch {
+    return [];
+  }
+  const names = entries
+    .filter((entry) => entry === ".owliabot" || entry.startsWith(".owliabot-"))
+    .map((entry) => (entry === ".owliabot" ? DEFAULT_PROFILE : entry.slice(".owliabot-".length)))
+    .filter((name) => PROFILE_NAME.test(name))
+    .sort((a, b) => (a === DEFAULT_PROFILE ? -1 : b === DEFAULT_PROFILE ? 1 : a.localeCompare(b)));
+  return names.map((name) => {
+    const dir = profileHome(name, homeDir);
+    return { name, dir, configured: existsSync(join(dir, "app.yaml")) };
+  });
+}
+
+const PUBLISHED_GATEWAY_PORT = /"(?:[\d.]+:)?(\d+):8787"/g;
+
+function composePorts(path: string): number[] {
+  try {
+    return [...readFileSync(path, "utf-8").matchAll(PUBLISHED_GATEWAY_PORT)].map((m) => Number(m[1]));
+  } catch {
+    return [];
+  }
+}
+
+/**
+ * Host ports other installs already claim: gateway.http.port of native
+ * profiles (Docker configs always say 8787, the port inside the container)
+ * and the ports published by the compose files in `composeDir`, except
+ * `ownComposeFile`.
+ */
+export function usedGatewayPorts(profiles: ProfileInfo[], composeDir?: string, ownComposeFile?: string): Set<number> {
+  const ports = new Set<number>();
+  for (const profile of profiles) {
+    try {
+      const raw = parse(readFileSync(join(profile.dir, "app.yaml"), "utf-8")) as {
+        gateway?: { http?: { host?: string; port?: number } };
+      } | null;
+      const http = raw?.gateway?.http;
+      if (http && http.host !== "0.0.0.0" && typeof http.port === "number") ports.add(http.port);
+    } catch {
+      // no app.yaml (yet) or unreadable
+    }
+  }
+  if (composeDir) {
+    let files: string[] = [];
+    try {
+      files = readdirSync(composeDir).filter((f) => /^docker-compose(\.[\w-]+)?\.ya?ml$/.test(f) && f !== ownComposeFile);
+    } catch {
+      files = [];
+    }
+    for (const file of files) for (const port of composePorts(join(composeDir, file))) ports.add(port);
+  }
+  return ports;
+}
+
+/** First port from `base` up that isn't in `used` */
+export function nextGatewayPort(used: Set<number>, base: number = DEFAULT_GATEWAY_PORT): number {
+  let port = base;
+  while (used.has(port)) port++;
+  return port;
+}
+
+/**
+ * Default gateway port for a profile: 8787 for the default profile, else the
+ * port its compose file already publishes, else the first port the other
+ * profiles (and compose files in `composeDir`) leave free.
+ */
+export function profileGatewayPort(profile: string | undefined, composeDir?: string, homeDir?: string): number {
+  if (isDefault(profile)) return DEFAULT_GATEWAY_PORT;
+  const own = profileComposeFile(profile);
+  const others = listProfiles(homeDir).filter((p) => p.name !== profile);
+  const used = usedGatewayPorts(others, composeDir, own);
+  const current = composeDir ? composePorts(join(composeDir, own))[0] : undefined;
+  if (current !== undefined && !used.has(current)) return current;
+  return nextGatewayPort(used);
+}
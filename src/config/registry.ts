@@ -0,0 +1,448 @@
+/**
+ * Config key registry: what each app.yaml key does and which onboarding
+ * question sets it, next to the type and default read from configSchema.
+ *
+ * `owliabot explain <key>` prints one entry; `owliabot validate` uses the
+ * same schema walk for unknown keys and their "did you mean" suggestions.
+ * Keys are dotted paths; `*` stands for any list index or map key
+ * (`providers.*.model`, `telegram.groups.*.requireMention`).
+ */
+
+import { z, type ZodTypeAny } from "zod";
+import { configSchema } from "./schema.js";
+
+export interface ConfigKeyDoc {
+  /** What the key does, in a sentence or two */
+  summary: string;
+  /** Onboarding stage and question that set it, when one does */
+  wizard?: string;
+}
+
+/**
+ * Curated documentation by key. Keys missing here still explain their type
+ * and default from the schema.
+ */
+export const CONFIG_KEY_DOCS: Record<string, ConfigKeyDoc> = {
+  providers: {
+    summary: "LLM providers, tried in priority order; the next one takes over when a call fails.",
+    wizard: 'AI provider setup: "Choose your AI provider(s):"',
+  },
+  "providers.*.id": {
+    summary: "Which provider this is: anthropic, openai, openai-codex, openai-compatible, amazon-bedrock, azure-openai or demo.",
+    wizard: 'AI provider setup: "Choose your AI provider(s):"',
+  },
+  "providers.*.model": {
+    summary: "Model name or alias sent to the provider (for azure-openai, the deployment name).",
+    wizard: 'AI provider setup: "Model:"',
+  },
+  "providers.*.apiKey": {
+    summary: 'Where the key comes from: "secrets" (secrets.yaml, then env), "env", "oauth", "keychain", or the key itself.',
+    wizard: "AI provider setup: the API key question for each provider",
+  },
+  "providers.*.priority": {
+    summary: "Failover order; 1 is tried first.",
+    wizard: "AI provider setup: the order the providers were picked in",
+  },
+  "providers.*.baseUrl": {
+    summary: "Endpoint for openai-compatible (e.g. http://localhost:11434/v1) and azure-openai (the resource URL).",
+    wizard: 'AI provider setup: "API base URL"',
+  },
+  "telegram.token": {
+    summary: 'Telegram bot token, or "secrets" to read it from secrets.yaml.',
+    wizard: 'Chat: "Where should OwliaBot chat with you?" (Telegram), then the bot token',
+  },
+  "telegram.allowList": {
+    summary: "Telegram user IDs allowed to message the bot directly. Empty or unset: nobody.",
+    wizard: 'Telegram: "Who can talk to me?"',
+  },
+  "telegram.groups": {
+    summary: 'Per-group settings by chat ID; "*" applies to every group without its own entry.',
+  },
+  "telegram.groups.*.enabled": {
+    summary: "Whether the bot answers in this group at all.",
+  },
+  "telegram.groups.*.requireMention": {
+    summary: "Only answer in this group when mentioned.",
+  },
+  "discord.token": {
+    summary: 'Discord bot token, or "secrets" to read it from secrets.yaml.',
+    wizard: 'Chat: "Where should OwliaBot chat with you?" (Discord), then the bot token',
+  },
+  "discord.memberAllowList": {
+    summary: "Discord user IDs allowed to talk to the bot. Empty: any member.",
+    wizard: 'Access: "Discord user IDs allowed to talk to me"',
+  },
+  "discord.channelAllowList": {
+    summary: "Guild channels where the bot answers without being mentioned.",
+    wizard: 'Access: "Discord channel IDs I may answer in"',
+  },
+  "discord.requireMentionInGuild": {
+    summary: "In guild channels outside channelAllowList, only answer when mentioned.",
+  },
+  "slack.memberAllowList": {
+    summary: "Slack member IDs allowed to talk to the bot. Empty: anyone in the workspace.",
+    wizard: 'Access (Slack): "Slack member IDs allowed to talk to me"',
+  },
+  "slack.channelAllowList": {
+    summary: "Slack channels where the bot answers without being mentioned.",
+    wizard: 'Access (Slack): "Slack channel IDs where I answer without a mention"',
+  },
+  "webhook.path": {
+    summary: "Path on the gateway HTTP server that accepts inbound webhook messages.",
+    wizard: 'Webhook: "Inbound path"',
+  },
+  "webhook.outboundUrl": {
+    summary: "Where the bot POSTs its replies to webhook messages; without it replies are dropped.",
+    wizard: 'Webhook: "Send replies to this URL"',
+  },
+  "webhook.secret": {
+    summary: 'Shared secret callers must send, or "secrets" to read it from secrets.yaml.',
+    wizard: 'Webhook: "Shared secret"',
+  },
+  workspace: {
+    summary: "Directory with the bot's persona, memory and files; the file tools can't leave it.",
+    wizard: 'Workspace: "Workspace path"',
+  },
+  timezone: {
+    summary: "IANA timezone used for dates in prompts and for scheduled jobs.",
+    wizard: 'Timezone: "Timezone [...]" (auto-detected)',
+  },
+  "gateway.http.enabled": {
+    summary: "Serve the gateway HTTP API (health, status, webhook, device pairing).",
+    wizard: 'Gateway HTTP: "Enable Gateway HTTP?"',
+  },
+  "gateway.http.host": {
+    summary: "Address the gateway listens on; 0.0.0.0 inside Docker, 127.0.0.1 otherwise.",
+  },
+  "gateway.http.port": {
+    summary: "Port the gateway listens on.",
+    wizard: 'Gateway HTTP: "Port"',
+  },
+  "gateway.http.token": {
+    summary: 'Bearer token for the gateway API, or "secrets" to read it from secrets.yaml.',
+    wizard: 'Docker: "Gateway token"',
+  },
+  "gateway.http.eventTtlMs": {
+    summary: "How long gateway events are kept, in milliseconds.",
+  },
+  "tools.allowWrite": {
+    summary: "Offer the file write/edit tools to the model at all.",
+    wizard: 'Write tools security: "Additional user IDs to allow"',
+  },
+  "tools.policy.allowList": {
+    summary: "Only these tools are offered to the model (wins over denyList).",
+  },
+  "tools.policy.denyList": {
+    summary: "Tools never offered to the model.",
+  },
+  "security.writeGateEnabled": {
+    summary: "Check every write tool call against writeToolAllowList (and ask for confirmation).",
+  },
+  "security.writeToolAllowList": {
+    summary: "User IDs allowed to trigger write tools.",
+    wizard: 'Write tools security: "Additional user IDs to allow"',
+  },
+  "security.writeToolConfirmation": {
+    summary: "Show each file change in the chat and wait for a yes/no before writing.",
+    wizard: 'Write tools security: "Ask for confirmation before each write?"',
+  },
+  "security.writeToolConfirmationApprover": {
+    summary: "User who answers write confirmations; unset: whoever asked for the write.",
+    wizard: 'Write tools security: "User ID who approves writes"',
+  },
+  "security.writeToolConfirmationIn": {
+    summary: "Ask for confirmation in the chat the request came from, or in a DM to the approver.",
+    wizard: 'Write tools security: "Where should the bot ask?"',
+  },
+  "security.writeToolConfirmationTimeoutMs": {
+    summary: "How long to wait for a confirmation before denying the write, in milliseconds.",
+    wizard: 'Write tools security: "Seconds to wait for an answer before denying"',
+  },
+  "system.exec.commandAllowList": {
+    summary: "Commands the exec tool may run. Empty: exec can't run anything.",
+  },
+  "system.exec.envAllowList": {
+    summary: "Environment variables passed through to exec'd commands.",
+  },
+  "system.web.domainAllowList": {
+    summary: "Domains web_fetch may reach. Empty: any public domain.",
+  },
+  "system.web.allowPrivateNetworks": {
+    summary: "Let web_fetch reach private and loopback addresses.",
+  },
+  "system.webSearch.defaultProvider": {
+    summary: "Search engine used by web_search.",
+  },
+  "session.summarizeOnReset": {
+    summary: "Summarize the conversation into memory when a session is reset.",
+  },
+  "session.summaryModel": {
+    summary: "Model for those summaries; unset: the first provider's model.",
+  },
+  "group.activation": {
+    summary: 'In group chats, answer only when mentioned ("mention") or to every message ("always").',
+  },
+  "agents.loop.maxIterations": {
+    summary: "Most model/tool round trips for one message before the bot gives up.",
+  },
+  "agents.loop.timeoutSeconds": {
+    summary: "Longest the bot works on one message.",
+  },
+  "memorySearch.enabled": {
+    summary: "Let the model search the workspace memory files.",
+  },
+  "cron.enabled": {
+    summary: "Run scheduled jobs.",
+  },
+  "infra.eventStore.ttlMs": {
+    summary: "How long channel events are kept, in milliseconds.",
+  },
+  "mcp.presets": {
+    summary: "MCP server presets to start with the bot (e.g. playwright, github).",
+    wizard: 'MCP Servers: "Which presets should I enable?"',
+  },
+  "mcp.servers": {
+    summary: "Custom MCP servers.",
+    wizard: 'MCP Servers: "Add another custom server?"',
+  },
+  "wallet.clawlet.enabled": {
+    summary: "Connect the Clawlet wallet daemon (owliabot wallet connect).",
+  },
+  "messages.unboundUserReply": {
+    summary: "Reply sent to users who aren't on an allow-list.",
+  },
+};
+
+/** Strip optional/default/effects wrappers to reach the structural schema. */
+export function unwrapSchema(schema: ZodTypeAny): ZodTypeAny {
+  let current: ZodTypeAny = schema;
+  for (;;) {
+    if (current instanceof z.ZodOptional || current instanceof z.ZodNullable) {
+      current = current.unwrap();
+    } else if (current instanceof z.ZodDefault) {
+      current = current._def.innerType;
+    } else if (current instanceof z.ZodEffects) {
+      current = current.innerType();
+    } else if (current instanceof z.ZodCatch) {
+      current = current._def.innerType;
+    } else {
+      return current;
+    }
+  }
+}
+
+/** The default of the outermost `.default()` in the wrapper chain, if any */
+function schemaDefault(schema: ZodTypeAny): { value: unknown } | undefined {
+  let current: ZodTypeAny = schema;
+  for (;;) {
+    if (current instanceof z.ZodDefault) return { value: current._def.defaultValue() };
+    if (current instanceof z.ZodOptional || current instanceof z.ZodNullable) {
+      current = current.unwrap();
+    } else if (current instanceof z.ZodEffects) {
+      current = current.innerType();
+    } else {
+      return undefined;
+    }
+  }
+}
+
+/**
+ * The schema at a key path (numbers or "*" for list items and map keys), or
+ * undefined when the schema doesn't have that key.
+ */
+export function schemaAtPath(schema: ZodTypeAny, keyPath: Array<string | number>): ZodTypeAny | undefined {
+  let current: ZodTypeAny = schema;
+  for (const seg of keyPath) {
+    const s = unwrapSchema(current);
+    if (s instanceof z.ZodObject) {
+      const shape = s.shape as Record<string, ZodTypeAny>;
+      if (!(String(seg) in shape)) return undefined;
+      current = shape[String(seg)];
+    } else if (s instanceof z.ZodArray && (typeof seg === "number" || seg === "*" || /^\d+$/.test(seg))) {
+      current = s.element;
+    } else if (s instanceof z.ZodRecord) {
+      current = s.valueSchema;
+    } else {
+      return undefined;
+    }
+  }
+  return current;
+}
+
+/** Keys the schema accepts directly under `keyPath` (empty for leaves) */
+export function childKeys(schema: ZodTypeAny, keyPath: Array<string | number> = []): string[] {
+  const at = schemaAtPath(schema, keyPath);
+  const s = at && unwrapSchema(at);
+  return s instanceof z.ZodObject ? Object.keys(s.shape as Record<string, ZodTypeAny>) : [];
+}
+
+/** Every key path of the config schema, parents before children */
+export function listConfigKeys(schema: ZodTypeAny = configSchema, prefix: string[] = []): string[] {
+  const s = unwrapSchema(schema);
+  const out: string[] = [];
+  if (s instanceof z.ZodObject) {
+    for (const [key, child] of Object.entries(s.shape as Record<string, ZodTypeAny>)) {
+      const path = [...prefix, key];
+      out.push(path.join("."), ...listConfigKeys(child, path));
+    }
+  } else if (s instanceof z.ZodArray) {
+    out.push(...listConfigKeys(s.element, [...prefix, "*"]));
+  } else if (s instanceof z.ZodRecord) {
+    out.push(...listConfigKeys(s.valueSchema, [...prefix, "*"]));
+  }
+  return out;
+}
+
+/** Short type description: "boolean", "list of string", "one of: a, b", ... */
+export function describeSchemaType(schema: ZodTypeAny): string {
+  const s = unwrapSchema(schema);
+  if (s instanceof z.ZodString) return "string";
+  if (s instanceof z.ZodNumber) return s.isInt ? "integer" : "number";
+  if (s instanceof z.ZodBoolean) return "boolean";
+  if (s instanceof z.ZodEnum) return `one of: ${(s.options as string[]).join(", ")}`;
+  if (s instanceof z.ZodLiteral) return JSON.stringify(s.value);
+  if (s instanceof z.ZodArray) return `list of ${describeSchemaType(s.element)}`;
+  if (s instanceof z.ZodRecord) return `map of ${describeSchemaType(s.valueSchema)}`;
+  if (s instanceof z.ZodObject) return "section";
+  if (s instanceof z.ZodUnion) return (s.options as ZodTypeAny[]).map(describeSchemaType).join(" or ");
+  return "value";
+}
+
+function editDistance(a: string, b: string): number {
+  const row = Array.from({ length: b.length + 1 }, (_, j) => j);
+  for (let i = 1; i <= a.length; i++) {
+    let prev = row[0];
+    row[0] = i;
+    for (let j = 1; j <= b.length; j++) {
+      const next = Math.min(row[j] + 1, row[j - 1] + 1, prev + (a[i - 1] === b[j - 1] ? 0 : 1));
+      prev = row[j];
+      row[j] = next;
+    }
+  }
+  return row[b.length];
+}
+
+/** The closest of `candidates` to a misspelt key (case or up to two edits), if any */
+export function suggestKey(key: string, candidates: string[]): string | undefined {
+  let best: { key: string; distance: number } | undefined;
+  for (const candidate of candidates) {
+    const distance = candidate.toLowerCase() === key.toLowerCase() ? 0 : editDistance(key, candidate);
+    if (distance <= 2 && (!best || distance < best.distance)) best = { key: candidate, distance };
+  }
+  return best?.key;
+}
+
+/** "providers[0].model" / "providers.0.model" → ["providers", "0", "model"] */
+export function parseKeyPath(key: string): string[] {
+  return key
+    .trim()
+    .replace(/\[(\w+|\*)\]/g, ".$1")
+    .split(".")
+    .filter(Boolean);
+}
+
+/** The registry key for a concrete path: list indexes and map keys become "*" */
+function registryKey(schema: ZodTypeAny, segments: string[]): string {
+  const out: string[] = [];
+  for (let i = 0; i < segments.length; i++) {
+    const parent = unwrapSchema(schemaAtPath(schema, segments.slice(0, i))!);
+    out.push(parent instanceof z.ZodObject ? segments[i] : "*");
+  }
+  return out.join(".");
+}
+
+function valueAt(value: unknown, segments: string[]): { value: unknown } | undefined {
+  let current: unknown = value;
+  for (const seg of segments) {
+    if (Array.isArray(current) && /^\d+$/.test(seg)) {
+      current = current[Number(seg)];
+    } else if (current && typeof current === "object" && !Array.isArray(current) && seg in current) {
+      current = (current as Record<string, unknown>)[seg];
+    } else {
+      return undefined;
+    }
+    if (current === undefined) return undefined;
+  }
+  return { value: current };
+}
+
+export interface ConfigKeyExplanation {
+  /** The key as given */
+  key: string;
+  /** Registry form, with `*` for list indexes and map keys */
+  registryKey: string;
+  type: string;
+  summary?: string;
+  wizard?: string;
+  /** Present when the schema has a default */
+  default?: unknown;
+  hasDefault: boolean;
+  /** Present when app.yaml sets the key */
+  current?: unknown;
+  isSet: boolean;
+  /** Keys directly under a section */
+  children: string[];
+}
+
+/**
+ * Explain `key` (dotted, `[n]` for list items) against the config schema,
+ * with its current value from `config` (parsed app.yaml, unexpanded).
+ * Throws for keys the schema doesn't have, suggesting the closest one.
+ */
+export function explainConfigKey(key: string, config?: unknown, schema: ZodTypeAny = configSchema): ConfigKeyExplanation {
+  const segments = parseKeyPath(key);
+  if (segments.length === 0) throw new Error("Give a key to explain, e.g. gateway.http.port");
+
+  for (let i = 0; i < segments.length; i++) {
+    if (schemaAtPath(schema, segments.slice(0, i + 1))) continue;
+    const parent = segments.slice(0, i);
+    const siblings = childKeys(schema, parent);
+    const suggestion = suggestKey(segments[i], siblings);
+    const where = parent.length > 0 ? `under ${parent.join(".")}` : "at the top level";
+    const isList = unwrapSchema(schemaAtPath(schema, parent)!) instanceof z.ZodArray;
+    const hint = suggestion
+      ? ` Did you mean ${[...parent, suggestion].join(".")}?`
+      : isList
+        ? ` ${parent.join(".")} is a list; use ${parent.join(".")}.0.${segments.slice(i).join(".")} (or * for any item).`
+        : siblings.length > 0
+          ? ` Keys ${where}: ${siblings.join(", ")}`
+          : "";
+    throw new Error(`app.yaml has no key "${segments.slice(0, i + 1).join(".")}".${hint}`);
+  }
+
+  const at = schemaAtPath(schema, segments)!;
+  const normalized = registryKey(schema, segments);
+  const doc = CONFIG_KEY_DOCS[normalized];
+  const def = schemaDefault(at);
+  const current = valueAt(config, segments);
+  return {
+    key: segments.join("."),
+    registryKey: normalized,
+    type: describeSchemaType(at),
+    ...(doc?.summary && { summary: doc.summary }),
+    ...(doc?.wizard && { wizard: doc.wizard }),
+    ...(def && { default: def.value }),
+    hasDefault: Boolean(def),
+    ...(current && { current: current.value }),
+    isSet: Boolean(current),
+    children: childKeys(schema, segments),
+  };
+}
+
+function formatValue(value: unknown): string {
+  if (typeof value === "string") return value === "" ? '""' : value;
+  return JSON.stringify(value);
+}
+
+/**
+ * Human-readable lines for `owliabot explain`.
+ */
+export function formatConfigKeyExplanation(e: ConfigKeyExplanation): string[] {
+  const lines = [`${e.key} (${e.type})`];
+  lines.push(`  ${e.summary ?? "No description yet."}`);
+  lines.push(`  Current:  ${e.isSet ? formatValue(e.current) : "(not set)"}`);
+  lines.push(`  Default:  ${e.hasDefault ? formatValue(e.default) : "(none)"}`);
+  lines.push(`  Set by:   ${e.wizard ?? "not asked by onboarding; edit app.yaml"}`);
+  if (e.children.length > 0) lines.push(`  Keys:     ${e.children.join(", ")}`);
+  return lines;
+}
@@ -0,0 +1,38 @@
+/**
+ * Safe mode: start with the higher-risk capabilities off, to check that the
+ * bot connects and answers before trusting it with more.
+ *
+ * Set OWLIABOT_SAFE_MODE=1 (or `owliabot start --safe-mode`; in Docker
+ * `OWLIABOT_SAFE_MODE=1 docker compose up -d`). app.yaml is left alone, so
+ * restarting without the variable brings everything back.
+ */
+
+import type { Config } from "./schema.js";
+
+export const SAFE_MODE_ENV = "OWLIABOT_SAFE_MODE";
+
+export function isSafeMode(env: Record<string, string | undefined> = process.env): boolean {
+  const value = env[SAFE_MODE_ENV]?.trim().toLowerCase();
+  return value === "1" || value === "true" || value === "yes";
+}
+
+/**
+ * Turn off file-writing tools, the exec tool and MCP servers. Returns what
+ * was actually switched off, for the startup log.
+ */
+export function applySafeMode(config: Config): string[] {
+  const disabled: string[] = [];
+  if (config.tools.allowWrite) {
+    config.tools.allowWrite = false;
+    disabled.push("write tools");
+  }
+  if (config.system?.exec) {
+    delete (config.system as Partial<NonNullable<Config["system"]>>).exec;
+    disabled.push("exec");
+  }
+  if (config.mcp && config.mcp.autoStart !== false) {
+    config.mcp.autoStart = false;
+    disabled.push("MCP servers");
+  }
+  return disabled;
+}
@@ -14,6 +14,10 @@ export const providerSchema = z
     apiKey: z.string().optional(),
     priority: z.number().int().positive(),
     baseUrl: z.string().url().optional(),
+    /** AWS region (amazon-bedrock) */
+    region: z.string().optional(),
+    /** API version query parameter (azure-openai) */
+    apiVersion: z.string().optional(),
     authType: z
       .enum(["bearer", "api-key", "header", "none"])
       .default("bearer")
@@ -35,6 +39,21 @@ export const providerSchema = z
       path: ["baseUrl"],
     },
   )
+  .refine(
+    (data) => {
+      // Azure OpenAI is addressed by resource endpoint + deployment name
+      if (data.id === "azure-openai" && !data.baseUrl) {
+        return false;
+      }
+      return true;
+    },
+    {
+      message:
+        "baseUrl is required when provider id is 'azure-openai'. " +
+        "Example: https://my-resource.openai.azure.com",
+      path: ["baseUrl"],
+    },
+  )
   .refine(
     (data) => {
       if (data.authType === "header" && !data.authHeader) {
@@ -84,11 +103,36 @@ export const discordConfigSchema = z.object({
   requireMentionInGuild: z.boolean().default(true),
 });
 
+export const slackConfigSchema = z.object({
+  // tokens can be set via onboarding + secrets.yaml (or env) later
+  /** Bot token (xoxb-...) */
+  botToken: z.string().optional(),
+  /** App-level token (xapp-...) for Socket Mode */
+  appToken: z.string().optional(),
+  /** Allow list of Slack member IDs (DMs or channel messages) */
+  memberAllowList: z.array(z.string()).optional(),
+  /** Channels where the bot responds without being mentioned */
+  channelAllowList: z.array(z.string()).optional(),
+});
+
+export const webhookConfigSchema = z.object({
+  /** Path served on the Gateway HTTP server */
+  path: z.string().startsWith("/").default("/webhook"),
+  /** Shared secret; usually set via secrets.yaml (or env) */
+  secret: z.string().optional(),
+  /** Where replies are POSTed; without it replies are dropped */
+  outboundUrl: z.string().url().optional(),
+});
+
 export const securitySchema = z.object({
   writeGateEnabled: z.boolean().default(true),
   writeToolAllowList: z.array(z.string()).default([]),
   writeToolConfirmation: z.boolean().default(true),
   writeToolConfirmationTimeoutMs: z.number().int().default(60_000),
+  /** User who answers confirmations; unset = the user who asked for the write */
+  writeToolConfirmationApprover: z.string().optional(),
+  /** Ask in the chat the write came from, or in a DM to the approver */
+  writeToolConfirmationIn: z.enum(["chat", "dm"]).default("chat"),
 });
 
 // System Capability (exec / web.fetch / web.search)
@@ -225,6 +269,21 @@ const gatewayHttpSchema = z.object({
       max: z.number().int().default(60),
     })
     .default({ windowMs: 60_000, max: 60 }),
+  /** Basic auth on every route except /health (password: "secrets" reads secrets.yaml) */
+  basicAuth: z
+    .object({
+      username: z.string().min(1),
+      password: z.string().min(1),
+    })
+    .optional(),
+  /** Serve HTTPS; clientCaPath additionally requires client certificates (mTLS) */
+  tls: z
+    .object({
+      certPath: z.string().min(1),
+      keyPath: z.string().min(1),
+      clientCaPath: z.string().min(1).optional(),
+    })
+    .optional(),
 });
 
 const sessionSchema = z
@@ -418,8 +477,10 @@ const memorySearchSchema = z
 // MCP (Model Context Protocol) configuration
 export const mcpGatewayConfigSchema = z
   .object({
-    /** Named presets to auto-expand (e.g. ["playwright"]) */
+    /** Named presets to auto-expand (e.g. ["playwright", "github"]) */
     presets: z.array(z.string()).default([]),
+    /** GitHub token for the "github" preset; usually via secrets.yaml (github.token) or env */
+    githubToken: z.string().optional(),
     /** Explicit server definitions (reuses mcpServerConfigSchema with transport validation) */
     servers: z
       .array(mcpServerConfigSchema)
@@ -461,6 +522,16 @@ export const contextGuardSchema = z
 
 export type ContextGuardConfig = z.infer<typeof contextGuardSchema>;
 
+/** Whether the runtime's Intl knows `tz` (IANA names, UTC) */
+export function isKnownTimezone(tz: string): boolean {
+  try {
+    new Intl.DateTimeFormat("en-US", { timeZone: tz });
+    return true;
+  } catch {
+    return false;
+  }
+}
+
 export const configSchema = z.object({
   // AI providers
   providers: z.array(providerSchema).min(1),
@@ -468,6 +539,8 @@ export const configSchema = z.object({
   // Channels
   telegram: telegramConfigSchema.optional(),
   discord: discordConfigSchema.optional(),
+  slack: slackConfigSchema.optional(),
+  webhook: webhookConfigSchema.optional(),
 
   // Session
   session: sessionSchema,
@@ -480,8 +553,12 @@ export const configSchema = z.object({
   // Workspace path
   workspace: z.string().default("${OWLIABOT_HOME}/workspace"),
 
-  // Timezone (used in prompts)
-  timezone: z.string().default("UTC"),
+  // Timezone (used in prompts); must be an IANA zone the runtime knows.
+  // loadConfig() replaces an unknown one with UTC before parsing.
+  timezone: z
+    .string()
+    .refine(isKnownTimezone, { message: "Unknown timezone (use an IANA name such as Europe/Berlin or UTC)" })
+    .default("UTC"),
 
   // Heartbeat (legacy cron scheduling)
   heartbeat: z
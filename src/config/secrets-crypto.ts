@@ -0,0 +1,88 @@
+/**
+ * Optional at-rest encryption for secrets.yaml using age (https://age-encryption.org).
+ *
+ * An encrypted secrets.yaml is ASCII-armored age ciphertext. It is decrypted
+ * with the identity in <configDir>/auth/secrets.agekey, or in the file named
+ * by OWLIABOT_SECRETS_KEY_FILE. Plain YAML files pass through unchanged, so
+ * every reader can call decryptSecretsContent() unconditionally.
+ * Requires the `age` and `age-keygen` CLIs.
+ */
+
+import { execFileSync } from "node:child_process";
+import { existsSync } from "node:fs";
+import { join } from "node:path";
+
+export const AGE_ARMOR_HEADER = "-----BEGIN AGE ENCRYPTED FILE-----";
+
+export const SECRETS_KEY_FILE = "secrets.agekey";
+
+/** True when the content is age-armored ciphertext rather than YAML. */
+export function isEncryptedSecrets(content: string): boolean {
+  return content.trimStart().startsWith(AGE_ARMOR_HEADER);
+}
+
+/**
+ * Where the age identity lives for a config dir.
+ */
+export function secretsKeyPath(
+  configDir: string,
+  env: Record<string, string | undefined> = process.env,
+): string {
+  return env.OWLIABOT_SECRETS_KEY_FILE?.trim() || join(configDir, "auth", SECRETS_KEY_FILE);
+}
+
+function runAge(cmd: string, args: string[], input?: string): string {
+  try {
+    return execFileSync(cmd, args, { input, stdio: "pipe", encoding: "utf-8" });
+  } catch (err) {
+    if ((err as NodeJS.ErrnoException).code === "ENOENT") {
+      throw new Error(`${cmd} is not installed; it is needed for encrypted secrets.yaml (https://age-encryption.org)`);
+    }
+    const stderr = String((err as { stderr?: unknown }).stderr ?? "").trim();
+    throw new Error(`${cmd} failed${stderr ? `: ${stderr}` : ""}`);
+  }
+}
+
+/**
+ * Throw early (with an install hint) when the age CLIs are missing.
+ */
+export function ensureAgeAvailable(): void {
+  runAge("age", ["--version"]);
+  runAge("age-keygen", ["--version"]);
+}
+
+/**
+ * Return plaintext YAML for a secrets.yaml body, decrypting it when needed.
+ */
+export function decryptSecretsContent(content: string, configDir: string): string {
+  if (!isEncryptedSecrets(content)) return content;
+  const keyPath = secretsKeyPath(configDir);
+  if (!existsSync(keyPath)) {
+    throw new Error(`secrets.yaml is encrypted but the key file ${keyPath} was not found`);
+  }
+  return runAge("age", ["--decrypt", "-i", keyPath], content);
+}
+
+/**
+ * Encrypt YAML for the given age recipient (armored output).
+ */
+export function encryptSecretsContent(plaintext: string, recipient: string): string {
+  return runAge("age", ["--encrypt", "--armor", "-r", recipient], plaintext);
+}
+
+/**
+ * Public key (recipient) for the config dir's identity, or null when there is no key.
+ */
+export function readSecretsRecipient(configDir: string): string | null {
+  const keyPath = secretsKeyPath(configDir);
+  if (!existsSync(keyPath)) return null;
+  return runAge("age-keygen", ["-y", keyPath]).trim();
+}
+
+/**
+ * Create a new identity at keyPath (mode 0600, set by age-keygen). Returns its recipient.
+ */
+export function generateSecretsKey(keyPath: string): string {
+  runAge("age-keygen", ["-o", keyPath]);
+  return runAge("age-keygen", ["-y", keyPath]).trim();
+}
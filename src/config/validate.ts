@@ -0,0 +1,360 @@
+/**
+ * Offline config validation for app.yaml + secrets.yaml.
+ *
+ * Unlike loadConfig(), this never throws on the first problem: it collects
+ * every issue (YAML syntax, schema violations, unknown keys, obviously wrong
+ * allowlist entries) and maps each one back to a line in the source file.
+ */
+
+import fs from "node:fs";
+import path from "node:path";
+import { LineCounter, parseDocument, isMap, isSeq, isScalar, type Document } from "yaml";
+import { z, type ZodTypeAny } from "zod";
+
+import { configSchema } from "./schema.js";
+import { childKeys, suggestKey, unwrapSchema } from "./registry.js";
+import { expandEnvVarsDeep } from "./expand-env.js";
+import { decryptSecretsContent, isEncryptedSecrets } from "./secrets-crypto.js";
+
+export type ConfigIssueSeverity = "error" | "warn";
+
+export interface ConfigValidationIssue {
+  id: string;
+  severity: ConfigIssueSeverity;
+  /** File the issue was found in */
+  file: string;
+  /** Dotted key path, e.g. "gateway.http.port" ("(root)" for document-level issues) */
+  path: string;
+  /** 1-based line number, when it could be located */
+  line?: number;
+  /** 1-based column number, when it could be located */
+  column?: number;
+  message: string;
+}
+
+export interface ConfigValidationReport {
+  ok: boolean;
+  configPath: string;
+  secretsPath: string;
+  issues: ConfigValidationIssue[];
+}
+
+export interface ValidateConfigOptions {
+  configPath: string;
+  env?: Record<string, string | undefined>;
+}
+
+type KeyPath = Array<string | number>;
+
+/** Mirrors SecretsConfig (onboarding/secrets.ts); strict so unknown keys are reported. */
+const secretsFileSchema = z
+  .object({
+    discord: z.object({ token: z.string() }).partial().strict(),
+    telegram: z.object({ token: z.string() }).partial().strict(),
+    slack: z.object({ botToken: z.string(), appToken: z.string() }).partial().strict(),
+    webhook: z.object({ secret: z.string() }).partial().strict(),
+    github: z.object({ token: z.string() }).partial().strict(),
+    openai: z.object({ apiKey: z.string() }).partial().strict(),
+    "openai-compatible": z.object({ apiKey: z.string() }).partial().strict(),
+    "azure-openai": z.object({ apiKey: z.string() }).partial().strict(),
+    "amazon-bedrock": z.object({ accessKeyId: z.string(), secretAccessKey: z.string() }).partial().strict(),
+    anthropic: z.object({ token: z.string(), apiKey: z.string(), tokenExpiresAt: z.string() }).partial().strict(),
+    clawlet: z.object({ token: z.string() }).partial().strict(),
+    gateway: z.object({ token: z.string(), basicAuthPassword: z.string() }).partial().strict(),
+  })
+  .partial()
+  .strict();
+
+const DISCORD_SNOWFLAKE = /^\d{17,20}$/;
+const NUMERIC_ID = /^\d+$/;
+const TELEGRAM_CHAT_ID = /^-?\d+$/;
+const SLACK_MEMBER_ID = /^[UW][A-Z0-9]{6,}$/;
+const SLACK_CHANNEL_ID = /^[CG][A-Z0-9]{6,}$/;
+// Write-tool users come from every channel's allowlist
+const ANY_USER_ID = new RegExp(`${NUMERIC_ID.source}|${SLACK_MEMBER_ID.source}`);
+
+function formatPath(p: KeyPath): string {
+  return p.length > 0 ? p.join(".") : "(root)";
+}
+
+/**
+ * Locate the YAML node for a key path. Falls back to the deepest existing
+ * ancestor so issues for missing keys still point somewhere useful.
+ * With preferKey=true, returns the map key node (used for unknown-key reports).
+ */
+function locate(
+  doc: Document,
+  lineCounter: LineCounter,
+  keyPath: KeyPath,
+  preferKey = false,
+): { line: number; column: number } | undefined {
+  let node: unknown = doc.contents;
+  let found: unknown = node;
+
+  for (let i = 0; i < keyPath.length; i++) {
+    const seg = keyPath[i];
+    const isLast = i === keyPath.length - 1;
+
+    if (isMap(node)) {
+      const pair = node.items.find((p) => isScalar(p.key) && String(p.key.value) === String(seg));
+      if (!pair) break;
+      const keyNode = pair.key;
+      node = pair.value;
+      found = isLast && preferKey ? keyNode : (node ?? keyNode);
+    } else if (isSeq(node) && typeof seg === "number") {
+      node = node.items[seg];
+      if (!node) break;
+      found = node;
+    } else {
+      break;
+    }
+  }
+
+  const range = (found as { range?: [number, number, number] } | null)?.range;
+  if (!range) return undefined;
+  const pos = lineCounter.linePos(range[0]);
+  return { line: pos.line, column: pos.col };
+}
+
+/** Walk a value alongside its schema and collect keys the schema doesn't know. */
+export function findUnknownKeys(schema: ZodTypeAny, value: unknown, keyPath: KeyPath = []): KeyPath[] {
+  const s = unwrapSchema(schema);
+  const out: KeyPath[] = [];
+
+  if (s instanceof z.ZodObject && value && typeof value === "object" && !Array.isArray(value)) {
+    const shape = s.shape as Record<string, ZodTypeAny>;
+    for (const [key, child] of Object.entries(value as Record<string, unknown>)) {
+      if (!(key in shape)) {
+        out.push([...keyPath, key]);
+        continue;
+      }
+      out.push(...findUnknownKeys(shape[key], child, [...keyPath, key]));
+    }
+  } else if (s instanceof z.ZodArray && Array.isArray(value)) {
+    value.forEach((item, i) => out.push(...findUnknownKeys(s.element, item, [...keyPath, i])));
+  } else if (s instanceof z.ZodRecord && value && typeof value === "object" && !Array.isArray(value)) {
+    for (const [key, child] of Object.entries(value as Record<string, unknown>)) {
+      out.push(...findUnknownKeys(s.valueSchema, child, [...keyPath, key]));
+    }
+  }
+
+  return out;
+}
+
+/** Semantic checks the schema doesn't express (port range, ID formats). */
+function checkConfigSemantics(raw: any): Array<{ id: string; path: KeyPath; message: string }> {
+  const out: Array<{ id: string; path: KeyPath; message: string }> = [];
+  if (!raw || typeof raw !== "object") return out;
+
+  const port = raw.gateway?.http?.port;
+  if (typeof port === "number" && (port < 1 || port > 65535)) {
+    out.push({
+      id: "config.gateway.port.out_of_range",
+      path: ["gateway", "http", "port"],
+      message: `Port ${port} is out of range (expected 1-65535).`,
+    });
+  }
+
+  const checkIds = (list: unknown, keyPath: KeyPath, pattern: RegExp, label: string) => {
+    if (!Array.isArray(list)) return;
+    list.forEach((v, i) => {
+      const s = typeof v === "number" ? String(v) : v;
+      if (typeof s !== "string" || !pattern.test(s.trim())) {
+        out.push({
+          id: "config.allowlist.invalid_id",
+          path: [...keyPath, i],
+          message: `"${String(v)}" is not a valid ${label}.`,
+        });
+      }
+    });
+  };
+
+  checkIds(raw.discord?.memberAllowList, ["discord", "memberAllowList"], DISCORD_SNOWFLAKE, "Discord user ID (17-20 digits)");
+  checkIds(raw.discord?.channelAllowList, ["discord", "channelAllowList"], DISCORD_SNOWFLAKE, "Discord channel ID (17-20 digits)");
+  checkIds(raw.telegram?.allowList, ["telegram", "allowList"], NUMERIC_ID, "Telegram user ID (digits only)");
+  checkIds(raw.slack?.memberAllowList, ["slack", "memberAllowList"], SLACK_MEMBER_ID, "Slack member ID (U...)");
+  checkIds(raw.slack?.channelAllowList, ["slack", "channelAllowList"], SLACK_CHANNEL_ID, "Slack channel ID (C...)");
+  checkIds(raw.security?.writeToolAllowList, ["security", "writeToolAllowList"], ANY_USER_ID, "user ID (digits, or a Slack member ID)");
+
+  const groups = raw.telegram?.groups;
+  if (groups && typeof groups === "object") {
+    for (const [chatId, group] of Object.entries(groups as Record<string, any>)) {
+      if (chatId !== "*" && !TELEGRAM_CHAT_ID.test(chatId)) {
+        out.push({
+          id: "config.allowlist.invalid_id",
+          path: ["telegram", "groups", chatId],
+          message: `"${chatId}" is not a valid Telegram chat ID (digits, optionally negative, or "*").`,
+        });
+      }
+      checkIds(group?.allowFrom, ["telegram", "groups", chatId, "allowFrom"], NUMERIC_ID, "Telegram user ID (digits only)");
+    }
+  }
+
+  return out;
+}
+
+function readIfExists(filePath: string): string | null {
+  try {
+    return fs.readFileSync(filePath, "utf-8");
+  } catch (err) {
+    if ((err as NodeJS.ErrnoException).code === "ENOENT") return null;
+    throw err;
+  }
+}
+
+function validateDocument(opts: {
+  file: string;
+  source: string;
+  schema: ZodTypeAny;
+  idPrefix: "config" | "secrets";
+  env: Record<string, string | undefined>;
+  semantic?: (raw: any) => Array<{ id: string; path: KeyPath; message: string }>;
+}): ConfigValidationIssue[] {
+  const { file, idPrefix } = opts;
+  const issues: ConfigValidationIssue[] = [];
+  const lineCounter = new LineCounter();
+  const doc = parseDocument(opts.source, { lineCounter });
+
+  if (doc.errors.length > 0) {
+    for (const err of doc.errors) {
+      const pos = err.linePos?.[0];
+      issues.push({
+        id: `${idPrefix}.parse_error`,
+        severity: "error",
+        file,
+        path: "(root)",
+        ...(pos ? { line: pos.line, column: pos.col } : {}),
+        message: err.message.split("\n")[0],
+      });
+    }
+    return issues;
+  }
+
+  const raw = doc.toJS() ?? {};
+  const at = (p: KeyPath, preferKey = false) => locate(doc, lineCounter, p, preferKey);
+
+  for (const p of findUnknownKeys(opts.schema, raw)) {
+    const key = String(p[p.length - 1]);
+    const suggestion = suggestKey(key, childKeys(opts.schema, p.slice(0, -1)));
+    issues.push({
+      id: `${idPrefix}.unknown_key`,
+      severity: idPrefix === "secrets" ? "error" : "warn",
+      file,
+      path: formatPath(p),
+      ...at(p, true),
+      message: `Unknown key "${key}" (it will be ignored).${suggestion ? ` Did you mean "${suggestion}"?` : ""}`,
+    });
+  }
+
+  const parsed = opts.schema.safeParse(expandEnvVarsDeep(raw, opts.env));
+  if (!parsed.success) {
+    for (const issue of parsed.error.issues) {
+      // Unknown keys are reported above with a friendlier message.
+      if (issue.code === "unrecognized_keys") continue;
+      issues.push({
+        id: `${idPrefix}.validation_error`,
+        severity: "error",
+        file,
+        path: formatPath(issue.path),
+        ...at(issue.path),
+        message: issue.message,
+      });
+    }
+  }
+
+  for (const extra of opts.semantic?.(raw) ?? []) {
+    issues.push({
+      id: extra.id,
+      severity: "error",
+      file,
+      path: formatPath(extra.path),
+      ...at(extra.path),
+      message: extra.message,
+    });
+  }
+
+  return issues;
+}
+
+/**
+ * Validate app.yaml and the sibling secrets.yaml without starting the bot.
+ */
+export async function validateConfigFiles(opts: ValidateConfigOptions): Promise<ConfigValidationReport> {
+  const env = opts.env ?? process.env;
+  const configPath = path.resolve(opts.configPath);
+  const secretsPath = path.join(path.dirname(configPath), "secrets.yaml");
+  const issues: ConfigValidationIssue[] = [];
+
+  const configSource = readIfExists(configPath);
+  if (configSource == null) {
+    issues.push({
+      id: "config.missing",
+      severity: "error",
+      file: configPath,
+      path: "(root)",
+      message: `Config file not found: ${configPath}`,
+    });
+  } else {
+    issues.push(
+      ...validateDocument({
+        file: configPath,
+        source: configSource,
+        schema: configSchema,
+        idPrefix: "config",
+        env,
+        semantic: checkConfigSemantics,
+      }),
+    );
+  }
+
+  let secretsSource = readIfExists(secretsPath);
+  if (secretsSource != null && isEncryptedSecrets(secretsSource)) {
+    try {
+      // Line numbers below refer to the decrypted YAML.
+      secretsSource = decryptSecretsContent(secretsSource, path.dirname(secretsPath));
+    } catch (err) {
+      issues.push({
+        id: "secrets.decrypt_failed",
+        severity: "error",
+        file: secretsPath,
+        path: "(root)",
+        message: (err as Error).message,
+      });
+      secretsSource = null;
+    }
+  }
+  if (secretsSource != null) {
+    issues.push(
+      ...validateDocument({
+        file: secretsPath,
+        source: secretsSource,
+        schema: secretsFileSchema,
+        idPrefix: "secrets",
+        env,
+      }),
+    );
+  }
+
+  return { ok: issues.every((i) => i.severity !== "error"), configPath, secretsPath, issues };
+}
+
+/**
+ * Human-readable report lines: `file:line:col  path  message`.
+ */
+export function formatConfigValidationReport(report: ConfigValidationReport): string[] {
+  if (report.issues.length === 0) {
+    return [`✓ ${report.configPath} is valid`];
+  }
+
+  const lines = report.issues.map((issue) => {
+    const icon = issue.severity === "error" ? "✗" : "!";
+    const loc = issue.line != null ? `:${issue.line}${issue.column != null ? `:${issue.column}` : ""}` : "";
+    return `${icon} ${issue.file}${loc}  ${issue.path}  ${issue.message}`;
+  });
+
+  const errors = report.issues.filter((i) => i.severity === "error").length;
+  const warnings = report.issues.length - errors;
+  lines.push("");
+  lines.push(`${errors} error(s), ${warnings} warning(s)`);
+  return lines;
+}
@@ -5,6 +5,7 @@ import { mkdtemp, rm, writeFile, readFile, readdir } from "node:fs/promises";
 import { parse } from "yaml";
 
 import { configSchema } from "../../config/schema.js";
+import { recordGeneratedFiles } from "../../config/integrity.js";
 import {
   diagnoseDoctor,
   resetConfigFile,
@@ -297,4 +298,61 @@ describe("doctor", () => {
       await rm(dir, { recursive: true, force: true });
     }
   });
+
+  it("warns when the recorded setup-token expiry is close", async () => {
+    const dir = await mkdtemp(path.join(os.tmpdir(), "owliabot-doctor-"));
+    try {
+      const configPath = path.join(dir, "app.yaml");
+      await writeFile(
+        configPath,
+        [
+          "providers:",
+          "  - id: anthropic",
+          "    model: claude-sonnet-4-5",
+          "    apiKey: secrets",
+          "    priority: 1",
+          "",
+        ].join("\n"),
+        "utf-8",
+      );
+      await writeFile(
+        path.join(dir, "secrets.yaml"),
+        [
+          "anthropic:",
+          `  token: sk-ant-oat01-${"a".repeat(80)}`,
+          "  tokenExpiresAt: 2026-03-05T00:00:00.000Z",
+          "",
+        ].join("\n"),
+        "utf-8",
+      );
+
+      const now = new Date("2026-03-01T00:00:00.000Z");
+      const report = await diagnoseDoctor({ configPath, env: {}, authDir: dir, now });
+      const issue = report.issues.find((i) => i.id === "credential.anthropic.token.renewal_due");
+      expect(issue?.severity).toBe("warn");
+      expect(issue?.message).toContain("expires in 4 days");
+
+      const later = await diagnoseDoctor({ configPath, env: {}, authDir: dir, now: new Date("2026-01-01T00:00:00.000Z") });
+      expect(later.issues.map((i) => i.id)).not.toContain("credential.anthropic.token.renewal_due");
+    } finally {
+      await rm(dir, { recursive: true, force: true });
+    }
+  });
+
+  it("warns about files changed since onboarding", async () => {
+    const dir = await mkdtemp(path.join(os.tmpdir(), "owliabot-doctor-"));
+    try {
+      const configPath = path.join(dir, "app.yaml");
+      await writeFile(configPath, "workspace: ./workspace\n", "utf-8");
+      recordGeneratedFiles(dir, [configPath]);
+      await writeFile(configPath, "workspace: ./elsewhere\n", "utf-8");
+
+      const report = await diagnoseDoctor({ configPath, env: {}, authDir: dir });
+      const issue = report.issues.find((i) => i.id === "integrity.file_modified");
+      expect(issue?.severity).toBe("warn");
+      expect(issue?.message).toContain("app.yaml");
+    } finally {
+      await rm(dir, { recursive: true, force: true });
+    }
+  });
 });
@@ -0,0 +1,107 @@
+import { describe, it, expect, vi } from "vitest";
+import os from "node:os";
+import path from "node:path";
+import { mkdtemp, rm, writeFile } from "node:fs/promises";
+
+import type { DoctorIO } from "../cli.js";
+import {
+  redactSecrets,
+  dockerContainerStatus,
+  runTroubleshoot,
+  type TroubleshootDeps,
+} from "../troubleshoot.js";
+
+function createTestIO(ynAnswers: boolean[] = []) {
+  const logs: string[] = [];
+  const yn = [...ynAnswers];
+  const io: DoctorIO = {
+    interactive: true,
+    print: (msg) => logs.push(msg),
+    header: (t) => logs.push(`HEADER:${t}`),
+    info: (msg) => logs.push(`INFO:${msg}`),
+    error: (msg) => logs.push(`ERROR:${msg}`),
+    askYN: async () => {
+      const v = yn.shift();
+      if (v === undefined) throw new Error("Unexpected askYN()");
+      return v;
+    },
+  };
+  return { io, logs };
+}
+
+const DISCORD_TOKEN = "MTIzNDU2Nzg5MDEyMzQ1Njc4OQ.GhIjKl.abcdefghijklmnopqrstuvwxyz0123";
+
+async function withConfigDir(fn: (configPath: string) => Promise<void>) {
+  const dir = await mkdtemp(path.join(os.tmpdir(), "owliabot-troubleshoot-"));
+  try {
+    const configPath = path.join(dir, "app.yaml");
+    await writeFile(configPath, "discord: {}\n", "utf-8");
+    await writeFile(path.join(dir, "secrets.yaml"), `discord:\n  token: ${DISCORD_TOKEN}\n`, "utf-8");
+    await fn(configPath);
+  } finally {
+    await rm(dir, { recursive: true, force: true });
+  }
+}
+
+describe("troubleshoot", () => {
+  it("redacts known values and credential shapes", () => {
+    const text = [
+      "login failed with token hunter2-secret",
+      "Authorization: Bearer abcdefgh12345678",
+      "key sk-ant-REDACTED",
+      "telegram 123456789:AAHdqTcvCH1vGWJxfSeofSAs0K5PALDsawX",
+    ].join("\n");
+    const out = redactSecrets(text, ["hunter2-secret"]);
+    expect(out).not.toContain("hunter2-secret");
+    expect(out).toContain("Bearer [REDACTED]");
+    expect(out).not.toContain("sk-ant-api03");
+    expect(out).not.toContain("AAHdqTcv");
+  });
+
+  it("reads the container status from docker ps", () => {
+    expect(dockerContainerStatus("owliabot", () => "Restarting (1) 5 seconds ago\n")).toBe("Restarting (1) 5 seconds ago");
+    expect(dockerContainerStatus("owliabot", () => "")).toBeNull();
+    expect(dockerContainerStatus("owliabot", () => { throw new Error("no docker"); })).toBeNull();
+  });
+
+  it("sends nothing without consent", async () => {
+    await withConfigDir(async (configPath) => {
+      const { io, logs } = createTestIO([false]);
+      const deps: TroubleshootDeps = {
+        readLogs: async () => [`Discord login failed for ${DISCORD_TOKEN}`],
+        containerStatus: () => "Exited (1) 2 minutes ago",
+        ask: vi.fn(),
+      };
+
+      const code = await runTroubleshoot({ configPath, io, deps, env: {} });
+
+      expect(code).toBe(0);
+      expect(deps.ask).not.toHaveBeenCalled();
+      const printed = logs.join("\n");
+      expect(printed).toContain("Exited (1) 2 minutes ago");
+      expect(printed).toContain("Discord login failed for [REDACTED]");
+      expect(printed).not.toContain(DISCORD_TOKEN);
+    });
+  });
+
+  it("sends the redacted report and prints the reply", async () => {
+    await withConfigDir(async (configPath) => {
+      const { io, logs } = createTestIO([true]);
+      const ask = vi.fn(async () => ({ content: "1. Enable Message Content Intent", provider: "anthropic", model: "claude-sonnet-4-5" }));
+
+      const code = await runTroubleshoot({
+        configPath,
+        io,
+        env: {},
+        deps: { readLogs: async () => [`token ${DISCORD_TOKEN} rejected`], containerStatus: () => null, ask },
+      });
+
+      expect(code).toBe(0);
+      const sent = JSON.stringify(ask.mock.calls[0]);
+      expect(sent).toContain("token [REDACTED] rejected");
+      expect(sent).not.toContain(DISCORD_TOKEN);
+      expect(logs).toContain("HEADER:Suggested fix (anthropic/claude-sonnet-4-5)");
+      expect(logs).toContain("1. Enable Message Content Intent");
+    });
+  });
+});
@@ -1,3 +1,4 @@
+import path from "node:path";
 import { createInterface } from "node:readline";
 
 import {
@@ -23,6 +24,7 @@ import {
   deleteProviderApiKeyInConfig,
   type DoctorIssue,
 } from "./index.js";
+import { acceptIntegrityChanges } from "../config/integrity.js";
 
 type FixOutcome = "fixed" | "deleted" | "skipped" | "not_applicable";
 
@@ -88,6 +90,24 @@ function printIssues(io: DoctorIO, issues: DoctorIssue[]): void {
   }
 }
 
+/**
+ * Files edited outside owliabot: offer to accept them as the new baseline.
+ */
+async function offerAcceptIntegrityChanges(opts: {
+  configPath: string;
+  io: DoctorIO;
+  issues: DoctorIssue[];
+}): Promise<void> {
+  const { io } = opts;
+  if (!opts.issues.some((i) => i.id.startsWith("integrity."))) return;
+  const ok = io.askYN
+    ? await io.askYN("Accept the changed files as they are now (updates integrity.json)?", false)
+    : false;
+  if (!ok) return;
+  acceptIntegrityChanges(path.dirname(path.resolve(opts.configPath)));
+  io.success?.("Recorded the current files as the new baseline.");
+}
+
 async function fixConfigIssues(opts: {
   configPath: string;
   io: DoctorIO;
@@ -259,17 +279,25 @@ export async function runDoctorCli(opts: {
   io.header?.("Doctor");
   io.info?.(`Config: ${opts.configPath}`);
 
+  let integrityOffered = false;
+
   // Prevent accidental infinite loops (e.g., user keeps skipping).
   for (let round = 0; round < 10; round++) {
     const report = await diagnoseDoctor({ configPath: opts.configPath, env: opts.env });
 
+    // Warnings (e.g. a credential due for renewal) don't block, but are worth seeing.
+    printIssues(io, report.issues);
+
+    if (io.interactive && !integrityOffered) {
+      integrityOffered = true;
+      await offerAcceptIntegrityChanges({ configPath: opts.configPath, io, issues: report.issues });
+    }
+
     if (report.ok) {
       io.success?.("No blocking issues found.");
       return 0;
     }
 
-    printIssues(io, report.issues);
-
     if (!io.interactive) {
       return 1;
     }
@@ -5,6 +5,11 @@ import { ZodError } from "zod";
 
 import { configSchema } from "../config/schema.js";
 import { expandEnvVarsDeep } from "../config/expand-env.js";
+import { decryptSecretsContent } from "../config/secrets-crypto.js";
+import { KEYCHAIN_REF } from "../config/keychain.js";
+import { setupTokenExpiresAt, setupTokenReminder, oauthSessionReminder } from "../auth/credential-expiry.js";
+import { ensureOwliabotHomeEnv } from "../utils/paths.js";
+import { verifyIntegrityManifest } from "../config/integrity.js";
 import type { SecretsConfig } from "../onboarding/secrets.js";
 import { loadSecrets, saveSecrets } from "../onboarding/secrets.js";
 
@@ -27,6 +32,9 @@ export interface DoctorReport {
 export interface DiagnoseDoctorOptions {
   configPath: string;
   env?: Record<string, string | undefined>;
+  /** Directory holding OAuth session files (default: $OWLIABOT_HOME/auth) */
+  authDir?: string;
+  now?: Date;
 }
 
 function formatTimestampUtcCompact(now: Date): string {
@@ -60,6 +68,7 @@ async function writeAtomic(filePath: string, content: string): Promise<void> {
   } catch {
     // ignore
   }
+  refreshTrackedFile(path.dirname(filePath), filePath);
 }
 
 function formatZodError(error: ZodError): string {
@@ -130,7 +139,8 @@ async function loadYamlFileIfExists(
   try {
     const txt = await fs.promises.readFile(filePath, "utf-8");
     try {
-      return { ok: true, value: parse(txt) as any };
+      // secrets.yaml may be age-encrypted; plain YAML passes through.
+      return { ok: true, value: parse(decryptSecretsContent(txt, path.dirname(filePath))) as any };
     } catch (err) {
       return { ok: false, error: err as Error };
     }
@@ -158,6 +168,7 @@ export async function resetConfigFile(opts: {
 
 export async function diagnoseDoctor(opts: DiagnoseDoctorOptions): Promise<DoctorReport> {
   const env = opts.env ?? process.env;
+  const now = opts.now ?? new Date();
   const configPath = path.resolve(opts.configPath);
   const issues: DoctorIssue[] = [];
 
@@ -227,8 +238,9 @@ export async function diagnoseDoctor(opts: DiagnoseDoctorOptions): Promise<Docto
   const telegramConfigured =
     rawConfig && typeof rawConfig === "object" && (rawConfig as any).telegram != null;
   if (telegramConfigured) {
+    // "keychain" is a reference, not the token; it's resolved (and checked) at startup.
     const tokenFromConfig =
-      typeof (rawConfig as any).telegram?.token === "string"
+      typeof (rawConfig as any).telegram?.token === "string" && (rawConfig as any).telegram.token !== KEYCHAIN_REF
         ? ((rawConfig as any).telegram.token as string)
         : null;
     const tokenFromSecrets =
@@ -262,7 +274,7 @@ export async function diagnoseDoctor(opts: DiagnoseDoctorOptions): Promise<Docto
     rawConfig && typeof rawConfig === "object" && (rawConfig as any).discord != null;
   if (discordConfigured) {
     const tokenFromConfig =
-      typeof (rawConfig as any).discord?.token === "string"
+      typeof (rawConfig as any).discord?.token === "string" && (rawConfig as any).discord.token !== KEYCHAIN_REF
         ? ((rawConfig as any).discord.token as string)
         : null;
     const tokenFromSecrets =
@@ -318,7 +330,7 @@ export async function diagnoseDoctor(opts: DiagnoseDoctorOptions): Promise<Docto
           key = fromEnv;
           source = "env";
         }
-      } else if (apiKeyField && apiKeyField !== "oauth") {
+      } else if (apiKeyField && apiKeyField !== "oauth" && apiKeyField !== KEYCHAIN_REF) {
         key = apiKeyField;
         source = "config";
       }
@@ -373,13 +385,24 @@ export async function diagnoseDoctor(opts: DiagnoseDoctorOptions): Promise<Docto
           kind = looksLikeAnthropicSetupToken(fromEnv) ? "token" : "apiKey";
           source = "env";
         }
-      } else if (apiKeyField && apiKeyField !== "oauth") {
+      } else if (apiKeyField && apiKeyField !== "oauth" && apiKeyField !== KEYCHAIN_REF) {
         value = apiKeyField;
         kind = looksLikeAnthropicSetupToken(apiKeyField) ? "token" : "apiKey";
         source = "config";
       }
 
       if (value) {
+        const reminder = kind === "token" && source === "secrets"
+          ? setupTokenReminder(secrets?.anthropic?.tokenExpiresAt, now.getTime())
+          : null;
+        if (reminder) {
+          issues.push({
+            id: "credential.anthropic.token.renewal_due",
+            severity: "warn",
+            message: reminder,
+            source: "secrets",
+          });
+        }
         if (source === "config") {
           issues.push({
             id: "security.anthropic.key_in_config",
@@ -406,6 +429,37 @@ export async function diagnoseDoctor(opts: DiagnoseDoctorOptions): Promise<Docto
         }
       }
     }
+
+    if (id === "openai-codex" && apiKeyField === "oauth") {
+      // Read the session file directly: loading it would try a refresh.
+      const authDir = opts.authDir ?? path.join(ensureOwliabotHomeEnv(), "auth");
+      let session: any = null;
+      try {
+        session = JSON.parse(await fs.promises.readFile(path.join(authDir, "auth-openai-codex.json"), "utf-8"));
+      } catch {
+        // Missing or unreadable: the provider will report it at startup.
+      }
+      const reminder = session ? oauthSessionReminder("OpenAI Codex", session.expires, Boolean(session.refresh), now.getTime()) : null;
+      if (reminder) {
+        issues.push({
+          id: "credential.openai-codex.oauth.renewal_due",
+          severity: "warn",
+          message: reminder,
+        });
+      }
+    }
+  }
+
+  // Files changed outside the tool since onboarding (integrity.json)
+  for (const problem of verifyIntegrityManifest(path.dirname(configPath)) ?? []) {
+    issues.push({
+      id: problem.kind === "missing" ? "integrity.file_missing" : "integrity.file_modified",
+      severity: "warn",
+      message: problem.kind === "missing"
+        ? `${problem.file} was generated by owliabot but no longer exists.`
+        : `${problem.file} was changed outside owliabot since it was generated.`,
+      source: problem.file.endsWith("secrets.yaml") ? "secrets" : "config",
+    });
   }
 
   return { ok: issues.every((i) => i.severity !== "error"), configPath, issues };
@@ -489,6 +543,10 @@ export async function setProviderSecret(opts: {
       (secrets as any)[opts.provider] = {};
     }
     (secrets as any)[opts.provider][opts.field] = opts.value;
+    // Setup-tokens last a year; record when this one runs out.
+    if (opts.provider === "anthropic" && opts.field === "token") {
+      secrets.anthropic!.tokenExpiresAt = setupTokenExpiresAt();
+    }
   });
 }
 
@@ -501,6 +559,9 @@ export async function deleteProviderSecret(opts: {
   await updateSecretsYaml(configPath, (secrets) => {
     if ((secrets as any)[opts.provider] && typeof (secrets as any)[opts.provider] === "object") {
       delete (secrets as any)[opts.provider][opts.field];
+      if (opts.provider === "anthropic" && opts.field === "token") {
+        delete secrets.anthropic!.tokenExpiresAt;
+      }
       if (Object.keys((secrets as any)[opts.provider]).length === 0) {
         delete (secrets as any)[opts.provider];
       }
@@ -0,0 +1,163 @@
+/**
+ * `owliabot troubleshoot`: ask the configured AI provider about a broken install.
+ *
+ * Collects what a maintainer would ask for first (doctor findings, container
+ * status, the tail of the logs), redacts tokens and keys, shows the result,
+ * and only sends it after the user agrees. The reply is printed as-is.
+ */
+
+import { execFileSync } from "node:child_process";
+import path from "node:path";
+
+import { diagnoseDoctor, type DoctorIssue } from "./index.js";
+import type { DoctorIO } from "./cli.js";
+import { loadSecrets } from "../onboarding/secrets.js";
+import type { Message } from "../agent/session.js";
+import { collectSecretStrings, redactSecrets } from "../utils/redact.js";
+import { containerCli, type ContainerCli } from "../logs/docker.js";
+
+export { redactSecrets };
+
+export interface TroubleshootContext {
+  issues: DoctorIssue[];
+  /** `docker ps` status of the container, null when unknown */
+  containerStatus: string | null;
+  logLines: string[];
+}
+
+export interface TroubleshootDeps {
+  readLogs: (lines: number) => Promise<string[]>;
+  containerStatus: () => string | null;
+  /** Send the conversation to the configured provider; returns the reply and who answered */
+  ask: (messages: Message[]) => Promise<{ content: string; provider: string; model: string }>;
+}
+
+/** Env vars that hold credentials (see onboarding env-file) */
+const SECRET_ENV_VARS = [
+  "ANTHROPIC_API_KEY",
+  "OPENAI_API_KEY",
+  "OPENAI_COMPATIBLE_API_KEY",
+  "AZURE_OPENAI_API_KEY",
+  "DISCORD_BOT_TOKEN",
+  "TELEGRAM_BOT_TOKEN",
+  "SLACK_BOT_TOKEN",
+  "SLACK_APP_TOKEN",
+  "GITHUB_PERSONAL_ACCESS_TOKEN",
+  "OWLIABOT_WEBHOOK_SECRET",
+  "AWS_SECRET_ACCESS_KEY",
+  "CLAWLET_TOKEN",
+  "OWLIABOT_GATEWAY_TOKEN",
+  "OWLIABOT_GATEWAY_PASSWORD",
+];
+
+/**
+ * Every string value in secrets.yaml plus credential env vars.
+ */
+export async function knownSecretValues(
+  configPath: string,
+  env: Record<string, string | undefined> = process.env,
+): Promise<string[]> {
+  return collectSecretStrings(
+    await loadSecrets(configPath).catch(() => null),
+    SECRET_ENV_VARS.map((name) => env[name]),
+  );
+}
+
+/**
+ * `docker ps -a` (or `podman ps -a`) status line for the container, or null
+ * (no container runtime, no container).
+ */
+export function dockerContainerStatus(
+  container: string,
+  exec: (cmd: string, args: string[]) => string = (cmd, args) =>
+    execFileSync(cmd, args, { stdio: "pipe", encoding: "utf-8", timeout: 5_000 }),
+  cli: ContainerCli = containerCli(),
+): string | null {
+  try {
+    const status = exec(cli, ["ps", "-a", "--filter", `name=^${container}$`, "--format", "{{.Status}}"]).trim();
+    return status || null;
+  } catch {
+    return null;
+  }
+}
+
+export function formatTroubleshootContext(context: TroubleshootContext): string {
+  const sections = [
+    "## Doctor findings",
+    ...(context.issues.length > 0
+      ? context.issues.map((i) => `- ${i.severity.toUpperCase()} ${i.id}: ${i.message}`)
+      : ["- none"]),
+    "",
+    "## Container",
+    context.containerStatus ?? "not running in Docker, or docker is unavailable",
+    "",
+    "## Recent logs",
+    ...(context.logLines.length > 0 ? context.logLines : ["(no logs found)"]),
+  ];
+  return sections.join("\n");
+}
+
+export function buildTroubleshootMessages(report: string): Message[] {
+  const now = Date.now();
+  return [
+    {
+      role: "system",
+      content: [
+        "You help users fix their OwliaBot installation (a self-hosted chat bot for Discord/Telegram,",
+        "configured by ~/.owliabot/app.yaml and secrets.yaml, usually run with docker compose).",
+        "Explain the most likely cause in one or two sentences, then give the exact commands or",
+        "config edits to fix it as a short numbered list. Say so if the information is not enough.",
+        "Secrets were replaced with [REDACTED]; never ask the user to paste them.",
+      ].join(" "),
+      timestamp: now,
+    },
+    { role: "user", content: `My OwliaBot install is not working. Diagnostics:\n\n${report}`, timestamp: now },
+  ];
+}
+
+/**
+ * Collect, show, ask for consent, send, print. Returns the exit code.
+ */
+export async function runTroubleshoot(opts: {
+  configPath: string;
+  io: DoctorIO;
+  deps: TroubleshootDeps;
+  /** Skip the consent question (--yes) */
+  assumeYes?: boolean;
+  lines?: number;
+  env?: Record<string, string | undefined>;
+}): Promise<number> {
+  const { io, deps } = opts;
+  io.header?.("Troubleshoot");
+
+  const report = await diagnoseDoctor({ configPath: opts.configPath, env: opts.env });
+  const context: TroubleshootContext = {
+    issues: report.issues,
+    containerStatus: deps.containerStatus(),
+    logLines: await deps.readLogs(opts.lines ?? 80).catch(() => []),
+  };
+  const known = await knownSecretValues(path.resolve(opts.configPath), opts.env);
+  const text = redactSecrets(formatTroubleshootContext(context), known);
+
+  io.info?.("This is what would be sent to your AI provider (secrets redacted):");
+  io.print("");
+  io.print(text);
+  io.print("");
+
+  const consent = opts.assumeYes || (io.askYN ? await io.askYN("Send it to your configured provider?", false) : false);
+  if (!consent) {
+    io.info?.("Nothing was sent. The report above is yours to share wherever you like.");
+    return 0;
+  }
+
+  try {
+    const reply = await deps.ask(buildTroubleshootMessages(text));
+    io.header?.(`Suggested fix (${reply.provider}/${reply.model})`);
+    io.print(reply.content.trim());
+    return 0;
+  } catch (err) {
+    io.error?.(`The provider could not be reached: ${(err as Error).message}`);
+    io.info?.("If the provider itself is the problem, run `owliabot doctor` first.");
+    return 1;
+  }
+}
@@ -3,7 +3,9 @@
  * OwliaBot entry point
  */
 
-import { program } from "commander";
+// First: --profile moves OWLIABOT_HOME before other modules read it.
+import "./config/profile-arg.js";
+import { Option, program } from "commander";
 import { join, dirname } from "node:path";
 import { existsSync, readFileSync } from "node:fs";
 import { fileURLToPath } from "node:url";
@@ -21,9 +23,17 @@ import {
   type SupportedOAuthProvider,
 } from "./auth/oauth.js";
 import { runOnboarding } from "./onboarding/onboard.js";
+import { parseGatewayAuthMode } from "./onboarding/steps/gateway-auth.js";
+import { parseTunnelProvider } from "./onboarding/steps/tunnel.js";
+import { parseReverseProxy } from "./onboarding/steps/reverse-proxy.js";
+import { parseImagePlatform } from "./onboarding/steps/docker.js";
+import { parseEnvironmentNames } from "./onboarding/steps/environments.js";
+import { parseOutputFormat } from "./onboarding/steps/kubernetes.js";
+import { assertCaBundle, relaunchWithCaBundle, resolveCaBundlePath } from "./onboarding/steps/ca-bundle.js";
 import { DEV_APP_CONFIG_PATH } from "./onboarding/storage.js";
 import type { Config } from "./config/schema.js";
-import { defaultConfigPath, ensureOwliabotHomeEnv, resolvePathLike } from "./utils/paths.js";
+import { defaultConfigPath, ensureOwliabotHomeEnv, resolveOwliabotHome, resolvePathLike } from "./utils/paths.js";
+import { activeProfile, profileComposeFile, profileContainerName, profileHome } from "./config/profiles.js";
 import { createDefaultDoctorIO, runDoctorCli } from "./doctor/cli.js";
 import { listConfiguredModelCatalog } from "./models/catalog.js";
 import { parseModelRef } from "./models/ref.js";
@@ -31,6 +41,9 @@ import { updateAppConfigYamlPrimaryModel, updateYamlFileAtomic } from "./models/
 import { parse as parseYaml } from "yaml";
 import { readFile } from "node:fs/promises";
 import { diagnoseDoctor } from "./doctor/index.js";
+import { loadSecrets } from "./onboarding/secrets.js";
+import { acceptIntegrityChanges } from "./config/integrity.js";
+import { setupTokenReminder, oauthSessionReminder } from "./auth/credential-expiry.js";
 
 const log = logger;
 
@@ -51,14 +64,15 @@ if (Number.isNaN(nodeMajor) || nodeMajor < 22) {
 const isDocker = process.env.OWLIABOT_DOCKER === "1" || existsSync("/.dockerenv");
 if (!isDocker) {
   updateNotifier({ pkg }).notify({
-    message: "Update available {currentVersion} → {latestVersion}\nRun `npx owliabot@latest` to update",
+    message: "Update available {currentVersion} → {latestVersion}\nRun `owliabot self-update` (or `npx owliabot@latest`) to update",
   });
 }
 
 program
   .name("owliabot")
   .description("Crypto-native AI agent for Telegram and Discord")
-  .version(pkg.version);
+  .version(pkg.version)
+  .option("--profile <name>", "Use a named profile: config in ~/.owliabot-<name>, its own container and port (env: OWLIABOT_PROFILE)");
 
 /**
  * Check if any OAuth providers are configured but lack credentials.
@@ -116,10 +130,12 @@ program
     "Config file path (default: $OWLIABOT_HOME/app.yaml)",
     process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath()
   )
+  .option("--safe-mode", "Start with write tools, exec and MCP servers disabled (same as OWLIABOT_SAFE_MODE=1)")
   .action(async (options) => {
     try {
       ensureOwliabotHomeEnv();
       log.info("Starting OwliaBot...");
+      if (options.safeMode) process.env.OWLIABOT_SAFE_MODE = "1";
 
       // Make the effective config path available to runtime commands (e.g. /model default).
       // This also keeps behavior consistent across "start -c <path>" and Docker env usage.
@@ -199,9 +215,15 @@ program
   )
   .option("--no-interactive", "Disable interactive prompts (exit non-zero on errors)")
   .option("--json", "Print diagnosis report as JSON and exit (non-interactive)")
+  .option("--accept-changes", "Accept files edited outside owliabot as the new baseline (integrity.json) and exit")
   .action(async (options) => {
     try {
       ensureOwliabotHomeEnv();
+      if (options.acceptChanges) {
+        acceptIntegrityChanges(dirname(resolvePathLike(options.config)));
+        log.info("Recorded the current files as the new baseline.");
+        process.exit(0);
+      }
       if (options.json) {
         const report = await diagnoseDoctor({ configPath: options.config });
         // Print raw JSON for CI/automation usage.
@@ -224,18 +246,215 @@ program
     }
   });
 
+program
+  .command("troubleshoot")
+  .description("Collect doctor findings and recent logs and, with your consent, ask your AI provider for a fix")
+  .option(
+    "-c, --config <path>",
+    "Config file path (default: $OWLIABOT_HOME/app.yaml)",
+    process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath()
+  )
+  .option("--container <name>", "Docker container name", profileContainerName(activeProfile()))
+  .option("-n, --lines <number>", "Number of log lines to include", "80")
+  .option("-y, --yes", "Send without asking (the report is still printed)")
+  .action(async (options) => {
+    try {
+      ensureOwliabotHomeEnv();
+      const { runTroubleshoot, dockerContainerStatus } = await import("./doctor/troubleshoot.js");
+      const { detectLogSource, streamLogs } = await import("./logs/index.js");
+      const { callWithFailover } = await import("./agent/runner.js");
+      const configPath = resolvePathLike(options.config);
+
+      const interactive = Boolean(process.stdin.isTTY && process.stdout.isTTY);
+      const { io, close } = createDefaultDoctorIO({ interactive });
+      try {
+        const code = await runTroubleshoot({
+          configPath,
+          io,
+          assumeYes: Boolean(options.yes),
+          lines: parseInt(options.lines, 10) || 80,
+          deps: {
+            containerStatus: () => dockerContainerStatus(options.container),
+            readLogs: async (lines) => {
+              const { source } = await detectLogSource({ container: options.container });
+              if (!source) return [];
+              const out: string[] = [];
+              for await (const line of streamLogs({ follow: false, lines, source })) out.push(line);
+              return out.slice(-lines);
+            },
+            ask: async (messages) => {
+              const config = await loadConfig(configPath);
+              const reply = await callWithFailover(config.providers, messages, {}, config);
+              return { content: reply.content, provider: reply.provider, model: reply.model };
+            },
+          },
+        });
+        process.exit(code);
+      } finally {
+        close();
+      }
+    } catch (err) {
+      log.error("Troubleshoot failed", err);
+      process.exit(1);
+    }
+  });
+
+program
+  .command("validate")
+  .description("Validate app.yaml and secrets.yaml (unknown keys, schema, allowlists) without starting the bot")
+  .option(
+    "-c, --config <path>",
+    "Config file path (default: $OWLIABOT_HOME/app.yaml)",
+    process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath()
+  )
+  .option("--json", "Print validation report as JSON")
+  .action(async (options) => {
+    try {
+      ensureOwliabotHomeEnv();
+      const { validateConfigFiles, formatConfigValidationReport } = await import("./config/validate.js");
+      const report = await validateConfigFiles({ configPath: resolvePathLike(options.config) });
+      if (options.json) {
+        console.log(JSON.stringify(report, null, 2));
+      } else {
+        for (const line of formatConfigValidationReport(report)) console.log(line);
+      }
+      process.exit(report.ok ? 0 : 1);
+    } catch (err) {
+      log.error("Validation failed", err);
+      process.exit(1);
+    }
+  });
+
+program
+  .command("explain <key>")
+  .description("Explain an app.yaml key: what it does, its current value, its default and which onboarding question sets it")
+  .option(
+    "-c, --config <path>",
+    "Config file path (default: $OWLIABOT_HOME/app.yaml)",
+    process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath()
+  )
+  .option("--json", "Print the explanation as JSON")
+  .action(async (key: string, options) => {
+    try {
+      ensureOwliabotHomeEnv();
+      const { explainConfigKey, formatConfigKeyExplanation } = await import("./config/registry.js");
+      const configPath = resolvePathLike(options.config);
+      const config = existsSync(configPath) ? parseYaml(readFileSync(configPath, "utf-8")) : undefined;
+      const explanation = explainConfigKey(key, config);
+      if (options.json) {
+        console.log(JSON.stringify(explanation, null, 2));
+      } else {
+        for (const line of formatConfigKeyExplanation(explanation)) console.log(line);
+      }
+    } catch (err) {
+      log.error("Explain failed", err);
+      process.exit(1);
+    }
+  });
+
+program
+  .command("permissions")
+  .description("Audit what the bot is allowed to do (channels, admins, tools, exec, web) for a security review")
+  .option(
+    "-c, --config <path>",
+    "Config file path (default: $OWLIABOT_HOME/app.yaml)",
+    process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath()
+  )
+  .option("--json", "Print the audit as JSON")
+  .action(async (options) => {
+    try {
+      ensureOwliabotHomeEnv();
+      const { auditPermissionsFile, formatPermissionsAudit } = await import("./config/permissions.js");
+      const audit = auditPermissionsFile(resolvePathLike(options.config));
+      if (options.json) {
+        console.log(JSON.stringify(audit, null, 2));
+      } else {
+        for (const line of formatPermissionsAudit(audit)) console.log(line);
+      }
+    } catch (err) {
+      log.error("Permission audit failed", err);
+      process.exit(1);
+    }
+  });
+
 program
   .command("onboard")
   .description("Interactive onboarding: configure providers, channels, and generate config files")
   .option("--path <path>", "App config output path (dev mode)", DEV_APP_CONFIG_PATH)
   .option("--docker", "Docker-aware mode: generates docker-compose.yml and full secrets")
   .option("--output-dir <path>", "Output directory for docker-compose.yml", ".")
+  .option("--dry-run", "Print generated files (secrets masked) and a diff against existing ones without writing")
+  .option("--gateway-auth <mode>", "Extra gateway protection: none, basic (basic auth) or mtls (local CA + client cert)", "none")
+  .option("--tunnel <provider>", "Docker mode: add a cloudflared or ngrok sidecar to expose the gateway over HTTPS")
+  .option("--reverse-proxy <proxy>", "Docker mode: expose the gateway publicly behind caddy or traefik, with a Let's Encrypt certificate for your domain")
+  .option("--oidc", "Docker mode: require OIDC login (oauth2-proxy sidecar) in front of the gateway")
+  .option("--environments <names>", "Docker mode: generate per-environment variants, e.g. dev,prod")
+  .option("--output-format <format>", "Docker mode: compose (docker-compose.yml), kubernetes (owliabot-k8s.yaml), swarm (docker-stack.yml) or devcontainer (.devcontainer.json)", "compose")
+  .option("--github-actions", "Docker mode: write a GitHub Actions workflow that validates app.yaml and deploys it over SSH")
+  .option("--nix", "Native mode: also write a Nix flake (nix run / nix develop) for the generated config")
+  .option("--systemd", "Native mode: also write a systemd unit and install script (no Docker needed)")
+  .option("--encrypt-secrets", "Encrypt secrets.yaml with age (key stored in auth/secrets.agekey)")
+  .option("--keychain", "Native mode: store provider keys and channel tokens in the OS keychain")
+  .option("--secrets-env", "Docker mode: write keys and tokens to .env (loaded via env_file:) instead of secrets.yaml")
+  .option("--notify-url <url>", "POST a setup summary (version, host, providers, channels; no secrets) to this webhook once the bot is up")
+  .option("--notify-host <name>", "Host name to report in the setup summary (default: OWLIABOT_NOTIFY_HOST, else the hostname)")
+  .option("--compose-profiles", "Docker mode: put optional services (ollama, watchtower, tunnel, proxy) in compose profiles toggled with --profile")
+  .option("--auto-update", "Docker mode: add Watchtower to docker-compose.yml so the bot picks up new images by itself")
+  .option("--platform <os/arch>", "Docker mode: pin the bot image platform in docker-compose.yml, e.g. linux/amd64 under emulation on ARM (env: OWLIABOT_PLATFORM)")
+  .option("--kiosk", "Lock the bot down for kids or untrusted audiences: one channel and user, read-only tools, no web, 1h retention")
+  .option("--lang [code]", "Wizard language: en, ja or ko (default: OWLIABOT_LANG or the locale); without a code, asks")
+  .option("--theme <name>", "Wizard colors: dark, light, high-contrast or mono (env: OWLIABOT_THEME; NO_COLOR or CLICOLOR=0 mean mono)")
+  .option("--accessible", "Plain output for screen readers and dumb terminals: no colors, banner art or symbols (env: OWLIABOT_ACCESSIBLE)")
+  .option("--demo", "Try the bot without an API key: a demo provider echoes messages back; starts docker-compose.yml")
+  .option("--speedrun", "Answer several prompts in one line, comma-separated (e.g. 2,1,sk-ant-...,1)")
+  .option("--local-run", "Docker mode: also write run-local.sh and app.local.yaml to run the same config with node from a checkout")
+  .option("--ca-bundle <file>", "Trust this PEM CA bundle for outbound HTTPS (corporate proxies) and mount it into the container (env: OWLIABOT_CA_BUNDLE)")
+  .option("--dump-screens <dir>", "Walk every wizard screen with default answers (dry run) and write the plain text of each to <dir>")
+  .addOption(new Option("--debug-flow [file]", "Log wizard stage transitions; write a DOT graph of the flow to file").hideHelp())
   .action(async (options) => {
     try {
+      const caBundle = resolveCaBundlePath(options.caBundle);
+      if (caBundle) {
+        assertCaBundle(caBundle);
+        // Node reads extra CAs only at startup; returns once they are loaded.
+        relaunchWithCaBundle(caBundle);
+      }
       await runOnboarding({
         docker: options.docker,
         appConfigPath: options.path,
         outputDir: options.outputDir,
+        dryRun: options.dryRun,
+        gatewayAuth: parseGatewayAuthMode(options.gatewayAuth),
+        tunnel: parseTunnelProvider(options.tunnel),
+        reverseProxy: parseReverseProxy(options.reverseProxy),
+        oidc: options.oidc,
+        environments: parseEnvironmentNames(options.environments),
+        outputFormat: parseOutputFormat(options.outputFormat),
+        nix: options.nix,
+        githubActions: options.githubActions,
+        systemd: options.systemd,
+        encryptSecrets: options.encryptSecrets,
+        keychain: options.keychain,
+        secretsEnv: options.secretsEnv,
+        composeProfiles: options.composeProfiles,
+        autoUpdate: options.autoUpdate,
+        platform: parseImagePlatform(options.platform ?? process.env.OWLIABOT_PLATFORM),
+        kiosk: options.kiosk,
+        demo: options.demo,
+        lang: options.lang,
+        accessible: options.accessible,
+        theme: options.theme,
+        notifyUrl: options.notifyUrl,
+        notifyHost: options.notifyHost,
+        speedrun: options.speedrun,
+        localRun: options.localRun,
+        caBundle,
+        debugFlow: options.debugFlow,
+        dumpScreens: options.dumpScreens,
+        profile: activeProfile(),
+        // Only for the plain ~/.owliabot setup, not when a profile or home was chosen explicitly
+        pickProfile: Boolean(process.stdin.isTTY) && !process.env.OWLIABOT_PROFILE
+          && resolveOwliabotHome() === profileHome(),
       });
     } catch (err) {
       log.error("Onboarding failed", err);
@@ -243,6 +462,219 @@ program
     }
   });
 
+program
+  .command("rollback")
+  .description("Restore the config files the last onboarding run replaced (from $OWLIABOT_HOME/backups)")
+  .argument("[backup]", "Backup to restore (default: the latest; see --list)")
+  .option(
+    "-c, --config <path>",
+    "Config file path (default: $OWLIABOT_HOME/app.yaml)",
+    process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath()
+  )
+  .option("--list", "List the backups instead of restoring one")
+  .action(async (backup: string | undefined, options) => {
+    try {
+      ensureOwliabotHomeEnv();
+      const { backupsDir, listBackups, restoreBackup } = await import("./config/backups.js");
+      const configDir = dirname(resolvePathLike(options.config));
+      if (options.list) {
+        const names = listBackups(configDir);
+        if (names.length === 0) log.info(`No backups in ${backupsDir(configDir)}`);
+        for (const name of names) console.log(name);
+        return;
+      }
+      const result = restoreBackup(configDir, backup);
+      for (const path of result.restored) log.info(`Restored ${path}`);
+      for (const file of result.skipped) {
+        log.warn(`Skipped ${file.path}: its directory is gone. The copy is ${join(backupsDir(configDir), result.backup, file.name)}`);
+      }
+      log.info(`Rolled back to backup ${result.backup}. Restart OwliaBot to use it.`);
+    } catch (err) {
+      log.error("Rollback failed", err);
+      process.exit(1);
+    }
+  });
+
+program
+  .command("profiles")
+  .description("List the profiles on this machine (separate bots; pick one with --profile <name>)")
+  .action(async () => {
+    try {
+      const { listProfiles, DEFAULT_PROFILE } = await import("./config/profiles.js");
+      const profiles = listProfiles();
+      if (profiles.length === 0) {
+        log.info("No OwliaBot config dirs found. Run: owliabot onboard");
+        return;
+      }
+      const current = activeProfile() ?? DEFAULT_PROFILE;
+      for (const profile of profiles) {
+        const marker = profile.name === current ? "*" : " ";
+        const state = profile.configured ? "" : " (not set up)";
+        console.log(`${marker} ${profile.name.padEnd(16)} ${profile.dir}  container ${profileContainerName(profile.name)}, ${profileComposeFile(profile.name)}${state}`);
+      }
+    } catch (err) {
+      log.error("Listing profiles failed", err);
+      process.exit(1);
+    }
+  });
+
+program
+  .command("status")
+  .description("Show container state, gateway health, configured providers/channels and sign-in expiry")
+  .option(
+    "-c, --config <path>",
+    "Config file path (default: $OWLIABOT_HOME/app.yaml)",
+    process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath()
+  )
+  .option("-f, --file <path>", "Compose file of a Docker install", profileComposeFile(activeProfile()))
+  .action(async (options) => {
+    try {
+      ensureOwliabotHomeEnv();
+      const { collectStatus, formatStatus } = await import("./status/index.js");
+      const report = await collectStatus({ configPath: resolvePathLike(options.config), composeFile: options.file });
+      for (const line of formatStatus(report)) console.log(line);
+    } catch (err) {
+      log.error("Status failed", err);
+      process.exit(1);
+    }
+  });
+
+program
+  .command("upgrade")
+  .description("Pull a newer OwliaBot image and restart docker compose, without re-running onboarding")
+  .option("-f, --file <path>", "Compose file of the install", profileComposeFile(activeProfile()))
+  .option("--check", "Only report whether a newer image is available")
+  .option("--force", "Pull and restart even when the image looks up to date")
+  .option("-y, --yes", "Pull a newer image without showing its release notes and asking")
+  .option(
+    "--verify-signature",
+    "Pull only if the image's cosign signature checks out (needs cosign; OWLIABOT_COSIGN_KEY for a custom key)",
+    ["1", "true", "yes"].includes(process.env.OWLIABOT_VERIFY_SIGNATURE ?? "")
+  )
+  .option(
+    "-c, --config <path>",
+    "Config file path, for the gateway port and token (default: $OWLIABOT_HOME/app.yaml)",
+    process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath()
+  )
+  .action(async (options) => {
+    try {
+      ensureOwliabotHomeEnv();
+      const { dockerExec, imageRepository, runUpgrade } = await import("./upgrade/index.js");
+      const interactive = Boolean(process.stdin.isTTY && process.stdout.isTTY) && !options.yes;
+      const result = await runUpgrade(options.file, {
+        check: options.check,
+        force: options.force,
+        verifySignature: options.verifySignature,
+        cosignKey: process.env.OWLIABOT_COSIGN_KEY || undefined,
+        log: (message) => log.info(message),
+        warn: (message) => log.warn(message),
+        confirm: interactive
+          ? async (image, update) => {
+              const { formatReleaseNotes, releaseNotesFor, showInPager } = await import("./upgrade/release-notes.js");
+              const notes = await releaseNotesFor(image, update.latest, {
+                exec: dockerExec(dirname(resolvePathLike(options.file))),
+              });
+              if (notes.length > 0) showInPager(formatReleaseNotes(notes));
+              else log.info(`No release notes found for ${image}`);
+              const { createInterface } = await import("node:readline");
+              const { askYN } = await import("./onboarding/shared.js");
+              const rl = createInterface({ input: process.stdin, output: process.stdout });
+              try {
+                return await askYN(rl, "Pull the update?", true);
+              } finally {
+                rl.close();
+              }
+            }
+          : undefined,
+      });
+      if (result.signature && result.signature.kind !== "verified") process.exit(1);
+      if (!result.upgraded) return;
+      log.info(`Upgraded ${result.image}${result.current ? ` to ${result.current}` : ""}`);
+      const rollbackHint = result.previous && result.previous !== result.current
+        ? `OWLIABOT_IMAGE=${imageRepository(result.image)}@${result.previous} docker compose up -d`
+        : null;
+
+      log.info("Waiting for the gateway to answer /health...");
+      const { verifyStarted, watchStartup } = await import("./upgrade/verify.js");
+      const verification = await verifyStarted(options.file, { configPath: resolvePathLike(options.config) });
+      if (!verification.healthy) {
+        const { redactSecrets } = await import("./utils/redact.js");
+        log.error(`Not healthy after ${Math.round(verification.waitedMs / 1000)}s: ${verification.health.url}: ${
+          verification.health.kind === "error" ? verification.health.message : "unexpected response"
+        }`);
+        console.log("Recent logs:");
+        for (const line of redactSecrets(verification.logs).trimEnd().split("\n")) console.log(`  ${line}`);
+        if (rollbackHint) log.info(`Go back to the previous version with: ${rollbackHint}`);
+        process.exit(1);
+      }
+      log.info(`Running & healthy (${verification.health.url} answered in ${verification.health.latencyMs} ms)`);
+
+      const { formatChecklist } = await import("./status/checklist.js");
+      let configured: string[] = [];
+      try {
+        configured = Object.keys(parseYaml(readFileSync(resolvePathLike(options.config), "utf-8")) ?? {});
+      } catch {
+        // checklist then shows only what the logs mention
+      }
+      const checklist = await watchStartup(options.file, { configured });
+      if (checklist) {
+        console.log("Startup checklist:");
+        for (const line of formatChecklist(checklist)) console.log(line);
+      }
+      if (rollbackHint) log.info(`If the new version misbehaves, go back with: ${rollbackHint}`);
+    } catch (err) {
+      log.error("Upgrade failed", err);
+      process.exit(1);
+    }
+  });
+
+program
+  .command("self-update")
+  .description("Update this CLI to the latest published version (verifies the download's checksum)")
+  .option("--check", "Only report whether a newer version is available")
+  .action(async (options) => {
+    try {
+      const { runSelfUpdate } = await import("./upgrade/self-update.js");
+      await runSelfUpdate({
+        name: pkg.name,
+        current: pkg.version,
+        packageDir: join(__dirname, ".."),
+        check: options.check,
+        log: (message) => log.info(message),
+      });
+    } catch (err) {
+      log.error("Self-update failed", err);
+      process.exit(1);
+    }
+  });
+
+program
+  .command("test-message")
+  .description("Post an \"OwliaBot is online\" message to an allow-listed Discord channel/user or Telegram chat")
+  .option(
+    "-c, --config <path>",
+    "Config file path (default: $OWLIABOT_HOME/app.yaml)",
+    process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath()
+  )
+  .option("--to <id>", "Channel, user or chat ID to send to (must be in an allow-list)")
+  .action(async (options) => {
+    try {
+      ensureOwliabotHomeEnv();
+      const config = await loadConfig(options.config);
+      const { runTestMessage } = await import("./onboarding/steps/test-message.js");
+      const { createInterface } = await import("node:readline");
+      const rl = process.stdin.isTTY ? createInterface({ input: process.stdin, output: process.stdout }) : null;
+      try {
+        if (!(await runTestMessage(rl, config, options.to))) process.exitCode = 1;
+      } finally {
+        rl?.close();
+      }
+    } catch (err) {
+      log.error("Test message failed", err);
+      process.exit(1);
+    }
+  });
+
 // Token command group (stores tokens to secrets.yaml next to the app config, under $OWLIABOT_HOME by default)
 const token = program.command("token").description("Manage channel tokens (stored on disk)");
 
@@ -283,6 +715,113 @@ token
     }
   });
 
+// Secrets command group (OS keychain entries referenced as "keychain" in app.yaml)
+const secretsCmd = program.command("secrets").description("Manage tokens and keys stored in the OS keychain");
+
+/** Read a secret from a hidden prompt, or from stdin when piped. */
+async function readSecretValue(prompt: string): Promise<string> {
+  if (!process.stdin.isTTY) {
+    const chunks: Buffer[] = [];
+    for await (const chunk of process.stdin) chunks.push(Buffer.from(chunk));
+    return Buffer.concat(chunks).toString("utf-8").trim();
+  }
+  const { createInterface } = await import("node:readline");
+  const { ask } = await import("./onboarding/shared.js");
+  const rl = createInterface({ input: process.stdin, output: process.stdout });
+  try {
+    return (await ask(rl, prompt, true)).trim();
+  } finally {
+    rl.close();
+  }
+}
+
+secretsCmd
+  .command("list")
+  .description("Show which entries exist in the keychain")
+  .action(async () => {
+    try {
+      const { KEYCHAIN_ACCOUNTS, keychainGet } = await import("./config/keychain.js");
+      for (const account of KEYCHAIN_ACCOUNTS) {
+        log.info(`${account.padEnd(10)} ${keychainGet(account) === null ? "not set" : "stored"}`);
+      }
+    } catch (err) {
+      log.error("Failed to list keychain entries", err);
+      process.exit(1);
+    }
+  });
+
+secretsCmd
+  .command("get")
+  .description("Print a keychain entry (masked unless --reveal)")
+  .argument("<name>", "anthropic|openai|discord|telegram")
+  .option("--reveal", "Print the full value")
+  .action(async (name: string, options) => {
+    try {
+      const { parseKeychainAccount, keychainGet } = await import("./config/keychain.js");
+      const value = keychainGet(parseKeychainAccount(name));
+      if (value === null) throw new Error(`No keychain entry for ${name}`);
+      const masked = value.length > 12 ? `${value.slice(0, 6)}…${value.slice(-4)}` : "********";
+      console.log(options.reveal ? value : masked);
+    } catch (err) {
+      log.error("Failed to read keychain entry", err);
+      process.exit(1);
+    }
+  });
+
+secretsCmd
+  .command("set")
+  .description("Store a keychain entry (prompted, or piped on stdin)")
+  .argument("<name>", "anthropic|openai|discord|telegram")
+  .action(async (name: string) => {
+    try {
+      const { parseKeychainAccount, keychainSet } = await import("./config/keychain.js");
+      const account = parseKeychainAccount(name);
+      const value = await readSecretValue(`Value for ${account}: `);
+      if (!value) throw new Error("Empty value; nothing stored");
+      keychainSet(account, value);
+      log.info(`Stored ${account} in the OS keychain. Use "keychain" in app.yaml to refer to it.`);
+    } catch (err) {
+      log.error("Failed to store keychain entry", err);
+      process.exit(1);
+    }
+  });
+
+secretsCmd
+  .command("rotate")
+  .description("Replace an existing keychain entry with a new value")
+  .argument("<name>", "anthropic|openai|discord|telegram")
+  .action(async (name: string) => {
+    try {
+      const { parseKeychainAccount, keychainGet, keychainSet } = await import("./config/keychain.js");
+      const account = parseKeychainAccount(name);
+      if (keychainGet(account) === null) {
+        throw new Error(`No keychain entry for ${account}; use "owliabot secrets set ${account}"`);
+      }
+      const value = await readSecretValue(`New value for ${account}: `);
+      if (!value) throw new Error("Empty value; entry left unchanged");
+      keychainSet(account, value);
+      log.info(`Rotated ${account}. Restart OwliaBot to pick up the new value.`);
+    } catch (err) {
+      log.error("Failed to rotate keychain entry", err);
+      process.exit(1);
+    }
+  });
+
+secretsCmd
+  .command("delete")
+  .description("Remove a keychain entry")
+  .argument("<name>", "anthropic|openai|discord|telegram")
+  .action(async (name: string) => {
+    try {
+      const { parseKeychainAccount, keychainDelete } = await import("./config/keychain.js");
+      const account = parseKeychainAccount(name);
+      log.info(keychainDelete(account) ? `Removed ${account} from the OS keychain` : `No keychain entry for ${account}`);
+    } catch (err) {
+      log.error("Failed to delete keychain entry", err);
+      process.exit(1);
+    }
+  });
+
 // Auth command group
 const auth = program.command("auth").description("Manage authentication");
 
@@ -329,6 +868,19 @@ auth
     }
   });
 
+/**
+ * Print the setup-token expiry recorded in secrets.yaml, if any.
+ */
+async function logSetupTokenExpiry(indent: string): Promise<void> {
+  const appConfigPath = process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath();
+  const secrets = await loadSecrets(appConfigPath).catch(() => null);
+  const expiresAt = secrets?.anthropic?.token ? secrets.anthropic.tokenExpiresAt : undefined;
+  if (!expiresAt) return;
+  log.info(`${indent}Setup-token expires: ${expiresAt}`);
+  const reminder = setupTokenReminder(expiresAt);
+  if (reminder) log.warn(`${indent}${reminder}`);
+}
+
 auth
   .command("status [provider]")
   .description("Check authentication status (openai-codex or all; anthropic uses setup-token)")
@@ -345,15 +897,19 @@ auth
           if (status.email) {
             log.info(`  Account: ${status.email}`);
           }
+          const reminder = oauthSessionReminder(prov, status.expiresAt, status.refreshable !== false);
+          if (reminder) log.warn(`  ${reminder}`);
         } else {
           log.info(`${prov}: Not authenticated`);
         }
       }
       // Note about Anthropic
       log.info(`anthropic: Uses setup-token (stored in secrets.yaml)`);
+      await logSetupTokenExpiry("  ");
     } else if (provider === "anthropic") {
       log.info("Anthropic uses setup-token authentication (stored in secrets.yaml).");
       log.info("Run `claude setup-token` to generate a token, then run `owliabot onboard`.");
+      await logSetupTokenExpiry("");
     } else {
       const validProviders: SupportedOAuthProvider[] = ["openai-codex"];
       const selectedProvider: SupportedOAuthProvider =
@@ -371,6 +927,8 @@ auth
         if (status.email) {
           log.info(`Account: ${status.email}`);
         }
+        const reminder = oauthSessionReminder(selectedProvider, status.expiresAt, status.refreshable !== false);
+        if (reminder) log.warn(reminder);
       } else {
         log.info(`Not authenticated with ${selectedProvider}.`);
         log.info(`Run 'owliabot auth setup ${selectedProvider}' to authenticate.`);
@@ -982,11 +1540,15 @@ program
   .option("-n, --lines <number>", "Number of initial lines to show", "100")
   .option("--level <level>", "Filter by log level (debug|info|warn|error)")
   .option("--grep <pattern>", "Filter by text pattern")
-  .option("--container <name>", "Docker container name", "owliabot")
+  .option("--container <name>", "Docker container name", profileContainerName(activeProfile()))
   .option("--file <path>", "Log file path (overrides auto-detect)")
   .action(async (options) => {
     try {
       const { detectLogSource, streamLogs } = await import("./logs/index.js");
+      const { knownSecretValues } = await import("./doctor/troubleshoot.js");
+      const { redactSecrets } = await import("./utils/redact.js");
+      // The bot's own output can echo tokens (env dumps, auth errors).
+      const known = await knownSecretValues(defaultConfigPath());
 
       const { source, hint } = await detectLogSource({
         file: options.file,
@@ -1007,7 +1569,7 @@ program
       });
 
       for await (const line of stream) {
-        console.log(line);
+        console.log(redactSecrets(line, known));
       }
     } catch (err) {
       if ((err as NodeJS.ErrnoException).code === "ERR_USE_AFTER_CLOSE") {
@@ -1019,8 +1581,109 @@ program
     }
   });
 
+// Memory command group (in Docker: docker exec -it owliabot owliabot memory ...)
+const memory = program.command("memory").description("Maintain the memory search index");
+
+async function loadMemoryTarget(configPath: string) {
+  const config = await loadConfig(configPath);
+  const { resolveMemoryTarget } = await import("./memory/maintenance.js");
+  return resolveMemoryTarget(config, join(ensureOwliabotHomeEnv(), "sessions"));
+}
+
+memory
+  .command("reindex")
+  .description("Index memory files now instead of on the next memory_search")
+  .option(
+    "-c, --config <path>",
+    "Config file path (default: $OWLIABOT_HOME/app.yaml)",
+    process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath()
+  )
+  .option("--full", "Drop the index first and rebuild it from scratch")
+  .action(async (options) => {
+    try {
+      const target = await loadMemoryTarget(options.config);
+      const { reindexMemory } = await import("./memory/maintenance.js");
+      const counts = await reindexMemory(target, { full: options.full });
+      log.info(`Indexed ${counts.files} file(s), ${counts.chunks} chunk(s) into ${target.dbPath}`);
+    } catch (err) {
+      log.error("Memory re-index failed", err);
+      process.exit(1);
+    }
+  });
+
+memory
+  .command("vacuum")
+  .description("Compact the memory index database")
+  .option(
+    "-c, --config <path>",
+    "Config file path (default: $OWLIABOT_HOME/app.yaml)",
+    process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath()
+  )
+  .action(async (options) => {
+    try {
+      const target = await loadMemoryTarget(options.config);
+      const { vacuumMemoryStore } = await import("./memory/maintenance.js");
+      const { formatBytes } = await import("./status/usage.js");
+      const result = vacuumMemoryStore(target.dbPath);
+      if (!result) {
+        log.info(`No memory index at ${target.dbPath} yet`);
+        return;
+      }
+      log.info(`Vacuumed ${target.dbPath}: ${formatBytes(result.before)} → ${formatBytes(result.after)}`);
+    } catch (err) {
+      log.error("Memory vacuum failed", err);
+      process.exit(1);
+    }
+  });
+
+memory
+  .command("clear")
+  .description("Delete the memory index (and with --notes, MEMORY.md and memory/*.md)")
+  .option(
+    "-c, --config <path>",
+    "Config file path (default: $OWLIABOT_HOME/app.yaml)",
+    process.env.OWLIABOT_CONFIG_PATH ?? defaultConfigPath()
+  )
+  .option("--notes", "Also delete the memory notes in the workspace (cannot be undone)")
+  .option("-y, --yes", "Do not ask for confirmation")
+  .action(async (options) => {
+    try {
+      const target = await loadMemoryTarget(options.config);
+      const { clearMemory, memoryClearPlan } = await import("./memory/maintenance.js");
+      const plan = memoryClearPlan(target, { notes: options.notes });
+      if (plan.length === 0) {
+        log.info("Nothing to clear");
+        return;
+      }
+      if (!options.yes) {
+        if (!process.stdin.isTTY) {
+          log.error("Refusing to clear memory without a terminal; pass --yes to confirm");
+          process.exit(1);
+        }
+        console.log("This deletes:");
+        for (const p of plan) console.log(`  ${p}`);
+        const { createInterface } = await import("node:readline");
+        const { askYN } = await import("./onboarding/shared.js");
+        const rl = createInterface({ input: process.stdin, output: process.stdout });
+        try {
+          if (!(await askYN(rl, "Continue?", false))) {
+            log.info("Cancelled");
+            return;
+          }
+        } finally {
+          rl.close();
+        }
+      }
+      const removed = clearMemory(target, { notes: options.notes });
+      log.info(`Removed ${removed.length} file(s); the index is rebuilt on the next memory_search or \`owliabot memory reindex\``);
+    } catch (err) {
+      log.error("Memory clear failed", err);
+      process.exit(1);
+    }
+  });
+
 // Security command group
-const security = program.command("security").description("Manage WriteGate and security configuration");
+const security =program.command("security").description("Manage WriteGate and security configuration");
 
 security
   .command("show")
@@ -0,0 +1,49 @@
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import { mkdtemp, rm } from "node:fs/promises";
+import { join } from "node:path";
+import { tmpdir } from "node:os";
+import {
+  imageHistoryPath,
+  lastKnownGoodImage,
+  readImageHistory,
+  recordImageReady,
+} from "../image-history.js";
+
+describe("image history", () => {
+  let home: string;
+
+  beforeEach(async () => {
+    home = await mkdtemp(join(tmpdir(), "image-history-test-"));
+  });
+
+  afterEach(async () => {
+    await rm(home, { recursive: true, force: true });
+  });
+
+  it("is empty when nothing was recorded", () => {
+    expect(readImageHistory(imageHistoryPath(home))).toEqual([]);
+  });
+
+  it("keeps the most recently ready image first", () => {
+    const path = imageHistoryPath(home);
+    recordImageReady(path, "owliabot:v1", new Date("2026-01-01T00:00:00Z"));
+    recordImageReady(path, "owliabot:v2", new Date("2026-01-02T00:00:00Z"));
+    recordImageReady(path, "owliabot:v1", new Date("2026-01-03T00:00:00Z"));
+
+    const history = readImageHistory(path);
+    expect(history.map((e) => e.image)).toEqual(["owliabot:v1", "owliabot:v2"]);
+    expect(history[0].firstReadyAt).toBe("2026-01-01T00:00:00.000Z");
+    expect(history[0].lastReadyAt).toBe("2026-01-03T00:00:00.000Z");
+  });
+
+  it("skips the image being deployed when picking the last known good one", () => {
+    const path = imageHistoryPath(home);
+    recordImageReady(path, "owliabot:v1");
+    recordImageReady(path, "owliabot:latest");
+
+    const history = readImageHistory(path);
+    expect(lastKnownGoodImage(history)).toBe("owliabot:latest");
+    expect(lastKnownGoodImage(history, "owliabot:latest")).toBe("owliabot:v1");
+    expect(lastKnownGoodImage([], "owliabot:latest")).toBeUndefined();
+  });
+});
@@ -143,12 +143,14 @@ describe("gateway server", () => {
 
   it("starts gateway HTTP when enabled", async () => {
     const stopHttp = vi.fn(async () => {});
+    const markReady = vi.fn();
     vi.mocked(startGatewayHttp).mockResolvedValue({
       baseUrl: "http://127.0.0.1:9999",
       stop: stopHttp,
       store: {} as any,
       channel: { id: "http", capabilities: {}, start: vi.fn(), stop: vi.fn(), onMessage: vi.fn(), send: vi.fn() } as any,
-    });
+      markReady,
+    } as any);
 
     const config = configSchema.parse({
       providers: [{ id: "test", model: "m", apiKey: "k", priority: 1 }],
@@ -180,6 +182,9 @@ describe("gateway server", () => {
       })
     );
 
+    // /ready flips only after channels and cron are up
+    expect(markReady).toHaveBeenCalledTimes(1);
+
     await stopGateway();
     expect(stopHttp).toHaveBeenCalledTimes(1);
   });
@@ -282,4 +282,28 @@ describe("shouldHandleMessage", () => {
 
     expect(shouldHandleMessage(ctx, config)).toBe(true);
   });
+
+  it("applies slack member and channel allowlists", () => {
+    const config = makeConfig({
+      slack: { memberAllowList: ["U0000001"], channelAllowList: ["C0000001"] },
+    });
+    const ctx = (partial: any): any => ({
+      channel: "slack",
+      chatType: "group",
+      from: "U0000001",
+      mentioned: false,
+      ...partial,
+    });
+
+    expect(shouldHandleMessage(ctx({ groupId: "C0000001" }), config)).toBe(true);
+    expect(shouldHandleMessage(ctx({ groupId: "C0000002" }), config)).toBe(false);
+    expect(shouldHandleMessage(ctx({ groupId: "C0000001", from: "U0000002" }), config)).toBe(false);
+  });
+
+  it("handles webhook messages without a user allowlist", () => {
+    const config = makeConfig({ webhook: { path: "/webhook", secret: "s" } });
+    const ctx: any = { channel: "webhook", chatType: "direct", from: "ci" };
+
+    expect(shouldHandleMessage(ctx, config)).toBe(true);
+  });
 });
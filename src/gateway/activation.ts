@@ -10,12 +10,16 @@ import type { MsgContext } from "../channels/interface.js";
  */
 /** Returns true only when an allowlist is configured and the sender is on it. */
 export function passesUserAllowlist(ctx: MsgContext, config: Config): boolean {
+  // Webhook callers are authenticated by the shared secret; `from` is caller-chosen.
+  if (ctx.channel === "webhook") return true;
   const allowList =
     ctx.channel === "discord"
       ? config.discord?.memberAllowList
       : ctx.channel === "telegram"
         ? config.telegram?.allowList
-        : undefined;
+        : ctx.channel === "slack"
+          ? config.slack?.memberAllowList
+          : undefined;
   return !!allowList && allowList.includes(ctx.from);
 }
 
@@ -125,10 +129,10 @@ export function shouldHandleMessage(ctx: MsgContext, config: Config): boolean {
     if (activation === "always") return true;
   }
 
-  const allowlistedDiscordChannel =
+  const allowlistedChannel =
     !!ctx.groupId &&
-    ctx.channel === "discord" &&
-    !!config.discord?.channelAllowList?.includes(ctx.groupId);
+    ((ctx.channel === "discord" && !!config.discord?.channelAllowList?.includes(ctx.groupId)) ||
+      (ctx.channel === "slack" && !!config.slack?.channelAllowList?.includes(ctx.groupId)));
 
-  return !!ctx.mentioned || allowlistedDiscordChannel;
+  return !!ctx.mentioned || allowlistedChannel;
 }
@@ -24,6 +24,7 @@ import type { Config } from "../config/schema.js";
 import type { createSessionTranscriptStore } from "../agent/session-transcript.js";
 import { adaptAllTools } from "../agent/tools/pi-agent-adapter.js";
 import { resolveModel } from "../agent/models.js";
+import { isDemoProvider } from "../agent/demo-provider.js";
 import {
   isCliProvider,
   type ConfigWithCliBackends,
@@ -202,6 +203,12 @@ export async function runAgenticLoop(
     return runLegacyLoop(conversationMessages, context, config);
   }
 
+  // The demo provider has no pi-ai model to resolve
+  if (primaryProvider && isDemoProvider(primaryProvider.id)) {
+    log.info("Using demo provider, falling back to callWithFailover path");
+    return runLegacyLoop(conversationMessages, context, config);
+  }
+
   let iterations = 0;
   let turnCount = 0; // Track turns locally (not from context history)
   let toolCallsCount = 0;
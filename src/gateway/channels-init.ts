@@ -1,13 +1,14 @@
 // src/gateway/channels-init.ts
 /**
  * Channel initialization module.
- * Handles Telegram and Discord channel registration with WriteGate adapters.
+ * Handles Telegram, Discord and Slack channel registration with WriteGate adapters.
  */
 
 import { createLogger } from "../utils/logger.js";
 import { ChannelRegistry } from "../channels/registry.js";
 import { createTelegramPlugin } from "../channels/telegram/index.js";
 import { createDiscordPlugin } from "../channels/discord/index.js";
+import { createSlackPlugin } from "../channels/slack/index.js";
 import {
   WriteGateReplyRouter,
   createWriteGateChannelAdapter,
@@ -26,6 +27,8 @@ export interface ChannelsInitConfig {
   telegram?: Config["telegram"];
   /** Discord channel configuration */
   discord?: Config["discord"];
+  /** Slack channel configuration */
+  slack?: Config["slack"];
 }
 
 /**
@@ -126,6 +129,32 @@ export function initializeChannels(
     log.warn("Discord configured but token missing; skipping Discord channel startup");
   }
 
+  // Register Slack if configured
+  if (config.slack?.botToken && config.slack.appToken) {
+    const slack = createSlackPlugin({
+      botToken: config.slack.botToken,
+      appToken: config.slack.appToken,
+      memberAllowList: config.slack.memberAllowList,
+      channelAllowList: config.slack.channelAllowList,
+      preFilter: (ctx) => replyRouter.hasPendingWaiter(ctx),
+    });
+
+    writeGateChannels.set(
+      "slack",
+      createWriteGateChannelAdapter(slack, replyRouter),
+    );
+
+    slack.onMessage(async (ctx) => {
+      if (replyRouter.tryRoute(ctx)) return;
+      await onMessage(ctx);
+    });
+
+    registry.register(slack);
+    log.info("Slack channel registered");
+  } else if (config.slack) {
+    log.warn("Slack configured but bot or app token missing; skipping Slack channel startup");
+  }
+
   return { registry, writeGateChannels, replyRouter };
 }
 
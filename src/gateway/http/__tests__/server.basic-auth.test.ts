@@ -0,0 +1,91 @@
+import { describe, it, expect, afterEach } from "vitest";
+import { startGatewayHttp } from "../server.js";
+import { testConfig, createMockResources } from "./test-helpers.js";
+
+const basic = (user: string, pass: string) =>
+  `Basic ${Buffer.from(`${user}:${pass}`, "utf8").toString("base64")}`;
+
+describe("basic auth fence", () => {
+  let server: Awaited<ReturnType<typeof startGatewayHttp>> | undefined;
+
+  afterEach(async () => {
+    await server?.stop();
+    server = undefined;
+  });
+
+  async function start() {
+    server = await startGatewayHttp({
+      config: { ...testConfig, basicAuth: { username: "owliabot", password: "s3cret" } },
+      ...createMockResources(),
+    });
+    return server;
+  }
+
+  it("keeps /health public", async () => {
+    const s = await start();
+    const res = await s.request("/health");
+    expect(res.status).toBe(200);
+  });
+
+  it("rejects requests without credentials", async () => {
+    const s = await start();
+    const res = await s.request("/status", { headers: { "x-gateway-token": "gw" } });
+    expect(res.status).toBe(401);
+    expect(res.headers.get("www-authenticate")).toContain("Basic");
+  });
+
+  it("rejects a wrong password", async () => {
+    const s = await start();
+    const res = await s.request("/status", {
+      headers: { "x-gateway-token": "gw", authorization: basic("owliabot", "nope") },
+    });
+    expect(res.status).toBe(401);
+  });
+
+  it("passes valid credentials through to the token check", async () => {
+    const s = await start();
+    const ok = await s.request("/status", {
+      headers: { "x-gateway-token": "gw", authorization: basic("owliabot", "s3cret") },
+    });
+    expect(ok.status).toBe(200);
+
+    const noToken = await s.request("/status", {
+      headers: { authorization: basic("owliabot", "s3cret") },
+    });
+    expect(noToken.status).toBe(401);
+  });
+
+  it("lets clients with a valid API key through to device auth", async () => {
+    const s = await start();
+    const { key } = s.store.createApiKey("fence", { tools: "read", system: false, mcp: false });
+    const res = await s.request("/status", {
+      headers: { "x-gateway-token": "gw", authorization: `Bearer ${key}` },
+    });
+    expect(res.status).toBe(200);
+  });
+
+  it("rejects a forged owk_ bearer, on pairing routes too", async () => {
+    const s = await start();
+    const forged = await s.request("/status", {
+      headers: { "x-gateway-token": "gw", authorization: "Bearer owk_x" },
+    });
+    expect(forged.status).toBe(401);
+    expect(forged.headers.get("www-authenticate")).toContain("Basic");
+
+    const pairing = await s.request("/pair/request", {
+      method: "POST",
+      headers: { "x-device-id": "dev-1", authorization: "Bearer owk_x" },
+    });
+    expect(pairing.status).toBe(401);
+  });
+
+  it("rejects a revoked API key", async () => {
+    const s = await start();
+    const { id, key } = s.store.createApiKey("fence", { tools: "read", system: false, mcp: false });
+    s.store.revokeApiKey(id);
+    const res = await s.request("/status", {
+      headers: { "x-gateway-token": "gw", authorization: `Bearer ${key}` },
+    });
+    expect(res.status).toBe(401);
+  });
+});
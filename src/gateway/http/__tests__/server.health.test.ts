@@ -15,4 +15,21 @@ describe("health", () => {
     expect(json.version).toBe("0.2.0");
     await server.stop();
   });
+
+  it("reports /ready as 503 until markReady()", async () => {
+    const server = await startGatewayHttp({
+      config: testConfig,
+      ...createMockResources(),
+    });
+    const before = await server.request("/ready");
+    expect(before.status).toBe(503);
+
+    server.markReady();
+    const after = await server.request("/ready");
+    const json: any = await after.json();
+    expect(after.status).toBe(200);
+    expect(json.ok).toBe(true);
+    expect(typeof json.readyAt).toBe("number");
+    await server.stop();
+  });
 });
@@ -6,6 +6,8 @@
  *
  * Route organization:
  * - /health — no auth
+ * - /ready — no auth; 503 until the gateway calls markReady() (channels connected)
+ * - webhook channel path (default /webhook) — shared webhook secret
  * - /status — gateway token
  * - /pair/request, /pair/status — device auth
  * - /command/tool, /command/system — device token + scope check
@@ -13,10 +15,17 @@
  * - /mcp — device token + scope check (JSON-RPC 2.0)
  * - /admin/* — gateway token (devices, approve, reject, revoke, scope, token rotate, wallet)
  *
+ * Optional outer fence (config.basicAuth / config.tls.clientCaPath) applies to
+ * every route except /health, /ready and the webhook path, on top of the
+ * per-route auth above.
+ *
  * @see docs/plans/gateway-unification.md Phase 2
  */
 
 import http from "node:http";
+import https from "node:https";
+import type { TLSSocket } from "node:tls";
+import { timingSafeEqual } from "node:crypto";
 import { Readable, Writable } from "node:stream";
 import { createStore, type Store, type ApiKeyRecord } from "./store.js";
 import { executeToolCalls } from "../../agent/tools/executor.js";
@@ -29,7 +38,7 @@ import { hashRequest, hashToken, isIpAllowed } from "./utils.js";
 import { executeSystemRequest } from "../../system/executor.js";
 import type { SystemCapabilityConfig } from "../../system/interface.js";
 import { dirname } from "node:path";
-import { existsSync, mkdirSync } from "node:fs";
+import { existsSync, mkdirSync, readFileSync } from "node:fs";
 import { ensureWorkspaceInitialized } from "../../workspace/init.js";
 import {
   checkToolScope,
@@ -41,6 +50,7 @@ import {
 } from "./scope.js";
 import { createHttpChannel } from "./channel.js";
 import type { ChannelPlugin } from "../../channels/interface.js";
+import type { WebhookChannel } from "../../channels/webhook/index.js";
 
 export interface GatewayHttpConfig {
   enabled?: boolean;
@@ -57,6 +67,10 @@ export interface GatewayHttpConfig {
   maxEventsPerDevice?: number;
   /** Events per poll batch (default: 100) */
   pollBatchSize?: number;
+  /** Optional HTTP basic auth required on every route except /health */
+  basicAuth?: { username: string; password: string };
+  /** Serve HTTPS; with clientCaPath set, also require client certificates (mTLS) */
+  tls?: { certPath: string; keyPath: string; clientCaPath?: string };
 }
 
 export interface GatewayHttpOptions {
@@ -73,6 +87,8 @@ export interface GatewayHttpOptions {
   toolsPolicy?: { allowList?: string[]; denyList?: string[] };
   /** Optional fetch injection for tests (defaults to global fetch) */
   fetchImpl?: typeof fetch;
+  /** Generic webhook channel served on its configured path */
+  webhook?: WebhookChannel;
 }
 
 export interface GatewayHttpResult {
@@ -80,6 +96,8 @@ export interface GatewayHttpResult {
   stop: () => Promise<void>;
   store: Store;
   channel: ChannelPlugin;
+  /** Flip /ready to 200 once the rest of the gateway (channels, cron) is up */
+  markReady: () => void;
   /**
    * In-process request helper for environments that can't bind to network ports.
    * Acts like a minimal `fetch()` against this server instance.
@@ -113,6 +131,7 @@ export async function startGatewayHttp(opts: GatewayHttpOptions): Promise<Gatewa
 
   // Create HTTP channel plugin for message delivery
   const httpChannel = createHttpChannel({ store });
+  let readyAt: number | null = null;
 
   const handler = async (req: http.IncomingMessage, res: http.ServerResponse) => {
     const url = new URL(req.url ?? "/", "http://localhost");
@@ -145,6 +164,57 @@ export async function startGatewayHttp(opts: GatewayHttpOptions): Promise<Gatewa
       return;
     }
 
+    // Readiness: the process can answer messages, not just accept connections.
+    if (req.method === "GET" && url.pathname === "/ready") {
+      res.writeHead(readyAt ? 200 : 503, { "content-type": "application/json" });
+      res.end(JSON.stringify({ ok: readyAt !== null, readyAt }));
+      return;
+    }
+
+    // Webhook callers authenticate with the shared secret instead of the fence:
+    // the systems posting here usually can't do basic auth or client certs.
+    if (opts.webhook && url.pathname === opts.webhook.path && req.method === "POST") {
+      let rawBody: string;
+      try {
+        rawBody = await readBodyString(req);
+      } catch {
+        sendJson(res, 413, {
+          ok: false,
+          error: { code: "ERR_INVALID_REQUEST", message: "Body too large" },
+        });
+        return;
+      }
+      const result = await opts.webhook.handleRequest(rawBody, req.headers);
+      sendJson(res, result.status, result.body);
+      return;
+    }
+
+    // =========================================================================
+    // OUTER FENCE (mTLS / basic auth) — everything below /health
+    // =========================================================================
+
+    if (config.tls?.clientCaPath && !isClientCertAuthorized(req)) {
+      sendJson(res, 401, {
+        ok: false,
+        error: { code: "ERR_UNAUTHORIZED", message: "Valid client certificate required" },
+      });
+      return;
+    }
+
+    if (config.basicAuth && !checkBasicAuth(req, config.basicAuth, store)) {
+      res.writeHead(401, {
+        "content-type": "application/json",
+        "www-authenticate": 'Basic realm="owliabot"',
+      });
+      res.end(
+        JSON.stringify({
+          ok: false,
+          error: { code: "ERR_UNAUTHORIZED", message: "Basic auth required" },
+        })
+      );
+      return;
+    }
+
     // =========================================================================
     // GATEWAY TOKEN ROUTES
     // =========================================================================
@@ -1293,9 +1363,24 @@ export async function startGatewayHttp(opts: GatewayHttpOptions): Promise<Gatewa
     );
   };
 
-  const server = http.createServer((req, res) => {
+  const listener = (req: http.IncomingMessage, res: http.ServerResponse) => {
     void handler(req, res);
-  });
+  };
+  const tls = config.tls;
+  const server = tls
+    ? https.createServer(
+        {
+          cert: readFileSync(tls.certPath),
+          key: readFileSync(tls.keyPath),
+          // Verify client certs against our CA but don't reject at the TLS layer,
+          // so /health stays reachable for container healthchecks.
+          ...(tls.clientCaPath
+            ? { ca: readFileSync(tls.clientCaPath), requestCert: true, rejectUnauthorized: false }
+            : {}),
+        },
+        listener,
+      )
+    : http.createServer(listener);
 
   let listening = true;
   try {
@@ -1380,10 +1465,13 @@ export async function startGatewayHttp(opts: GatewayHttpOptions): Promise<Gatewa
   };
 
   return {
-    baseUrl: listening ? `http://${config.host}:${port}` : "http://in-memory",
+    baseUrl: listening ? `${tls ? "https" : "http"}://${config.host}:${port}` : "http://in-memory",
     stop: () => new Promise<void>((resolve) => server.close(() => resolve())),
     store,
     channel: httpChannel,
+    markReady: () => {
+      readyAt ??= Date.now();
+    },
     request,
   };
 }
@@ -1444,6 +1532,61 @@ function requireGatewayAuth(
   return typeof provided === "string" && provided === token;
 }
 
+function safeEqual(a: string, b: string): boolean {
+  const ab = Buffer.from(a, "utf8");
+  const bb = Buffer.from(b, "utf8");
+  return ab.length === bb.length && timingSafeEqual(ab, bb);
+}
+
+function isClientCertAuthorized(req: http.IncomingMessage): boolean {
+  return (req.socket as Partial<TLSSocket>).authorized === true;
+}
+
+/** Bearer API key in an Authorization header ("Bearer  owk_..." tolerated) */
+function bearerApiKey(authHeader: string | undefined): string | null {
+  return authHeader?.match(/^Bearer\s+(owk_.+)$/i)?.[1] ?? null;
+}
+
+/** The stored key for `apiKey`, or why it can't be used */
+function lookupApiKey(
+  store: Store,
+  apiKey: string
+): { ok: true; record: ApiKeyRecord } | { ok: false; message: string } {
+  const record = store.getApiKeyByHash(hashToken(apiKey));
+  if (!record) return { ok: false, message: "Invalid API key" };
+  if (record.revokedAt) return { ok: false, message: "API key revoked" };
+  if (record.expiresAt && record.expiresAt <= Date.now()) return { ok: false, message: "API key expired" };
+  return { ok: true, record };
+}
+
+/**
+ * Basic auth fence. Clients with a valid API key (Authorization: Bearer
+ * owk_...) are exempt, since a single Authorization header can't carry
+ * both. The key is looked up here; an unknown, revoked or expired one
+ * still has to pass basic auth.
+ */
+function checkBasicAuth(
+  req: http.IncomingMessage,
+  creds: { username: string; password: string },
+  store: Store
+): boolean {
+  const authHeader = getHeader(req, "authorization") ?? "";
+  const apiKey = bearerApiKey(authHeader);
+  if (apiKey) return lookupApiKey(store, apiKey).ok;
+
+  const match = authHeader.match(/^Basic\s+(.+)$/i);
+  if (!match) return false;
+  const decoded = Buffer.from(match[1], "base64").toString("utf8");
+  const sep = decoded.indexOf(":");
+  if (sep < 0) return false;
+  const username = decoded.slice(0, sep);
+  const password = decoded.slice(sep + 1);
+  // Evaluate both comparisons to avoid leaking which half was wrong.
+  const userOk = safeEqual(username, creds.username);
+  const passOk = safeEqual(password, creds.password);
+  return userOk && passOk;
+}
+
 interface DeviceAuthSuccess {
   ok: true;
   device: {
@@ -1468,41 +1611,20 @@ async function requireDeviceAuth(
   // Check for API key auth first (Authorization: Bearer owk_...)
   const authHeader = getHeader(req, "authorization");
   // Tolerate extra whitespace: "Bearer  owk_..." or "Bearer   owk_..."
-  const bearerMatch = authHeader?.match(/^Bearer\s+(owk_.+)$/i);
-  if (bearerMatch) {
-    const apiKey = bearerMatch[1];
-    const keyHash = hashToken(apiKey);
-    const record = store.getApiKeyByHash(keyHash);
-    if (!record) {
-      return {
-        ok: false,
-        status: 401,
-        error: {
-          ok: false,
-          error: { code: "ERR_UNAUTHORIZED", message: "Invalid API key" },
-        },
-      };
-    }
-    if (record.revokedAt) {
-      return {
-        ok: false,
-        status: 401,
-        error: {
-          ok: false,
-          error: { code: "ERR_UNAUTHORIZED", message: "API key revoked" },
-        },
-      };
-    }
-    if (record.expiresAt && record.expiresAt <= Date.now()) {
+  const apiKey = bearerApiKey(authHeader);
+  if (apiKey) {
+    const found = lookupApiKey(store, apiKey);
+    if (!found.ok) {
       return {
         ok: false,
         status: 401,
         error: {
           ok: false,
-          error: { code: "ERR_UNAUTHORIZED", message: "API key expired" },
+          error: { code: "ERR_UNAUTHORIZED", message: found.message },
         },
       };
     }
+    const record = found.record;
     store.touchApiKeyUsed(record.id, Date.now());
     return {
       ok: true,
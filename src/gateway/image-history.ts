@@ -0,0 +1,69 @@
+/**
+ * Which container images reached "Gateway ready" on this host.
+ *
+ * docker-compose.yml passes the image reference into the container as
+ * OWLIABOT_IMAGE_REF. Each ready gateway records it in
+ * <home>/gateway/image-history.json on the bind-mounted config dir, so the
+ * onboarding wizard on the host can label the last known good image and
+ * print a rollback command for it.
+ */
+
+import { mkdirSync, readFileSync, writeFileSync } from "node:fs";
+import { dirname, join } from "node:path";
+import { createLogger } from "../utils/logger.js";
+
+const log = createLogger("image-history");
+
+/** Keep the file small; only the most recent images matter for rollback */
+const MAX_ENTRIES = 20;
+
+/** History file under an OwliaBot home (or a Docker config dir on the host) */
+export function imageHistoryPath(home: string): string {
+  return join(home, "gateway", "image-history.json");
+}
+
+export interface ImageHistoryEntry {
+  image: string;
+  firstReadyAt: string;
+  lastReadyAt: string;
+}
+
+/**
+ * Entries, most recently ready first. Missing or unreadable files are empty.
+ */
+export function readImageHistory(path: string): ImageHistoryEntry[] {
+  try {
+    const data = JSON.parse(readFileSync(path, "utf-8")) as { images?: ImageHistoryEntry[] };
+    return Array.isArray(data.images)
+      ? data.images.filter((e) => typeof e?.image === "string" && typeof e.lastReadyAt === "string")
+      : [];
+  } catch {
+    return [];
+  }
+}
+
+/**
+ * Record that `image` reached ready. Best-effort: a read-only config dir
+ * must not stop the gateway.
+ */
+export function recordImageReady(path: string, image: string, now: Date = new Date()): void {
+  const at = now.toISOString();
+  const history = readImageHistory(path);
+  const previous = history.find((e) => e.image === image);
+  const entry: ImageHistoryEntry = { image, firstReadyAt: previous?.firstReadyAt ?? at, lastReadyAt: at };
+  const images = [entry, ...history.filter((e) => e.image !== image)].slice(0, MAX_ENTRIES);
+  try {
+    mkdirSync(dirname(path), { recursive: true });
+    writeFileSync(path, `${JSON.stringify({ images }, null, 2)}\n`);
+  } catch (err) {
+    log.warn(`Could not record image ${image} in ${path}: ${(err as Error).message}`);
+  }
+}
+
+/**
+ * The most recently ready image, optionally other than `exclude` (the one
+ * about to be deployed), or undefined.
+ */
+export function lastKnownGoodImage(history: ImageHistoryEntry[], exclude?: string): string | undefined {
+  return history.find((e) => e.image !== exclude)?.image;
+}
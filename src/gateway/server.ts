@@ -10,6 +10,8 @@ import type { WorkspaceFiles } from "../workspace/types.js";
 import { ChannelRegistry } from "../channels/registry.js";
 import { createTelegramPlugin } from "../channels/telegram/index.js";
 import { createDiscordPlugin } from "../channels/discord/index.js";
+import { createSlackPlugin } from "../channels/slack/index.js";
+import { createWebhookPlugin, type WebhookChannel } from "../channels/webhook/index.js";
 import type { Message } from "../agent/session.js";
 import { resolveAgentId, resolveSessionKey } from "../agent/session-key.js";
 import { createSessionStore, type SessionKey } from "../agent/session-store.js";
@@ -51,6 +53,7 @@ import { expandMCPPresets, getDefaultSecurityOverrides } from "../mcp/presets.js
 import { applyPlaywrightDefaults } from "../mcp/servers/playwright.js";
 import { startGatewayHttp } from "./http/server.js";
 import { runBootOnce } from "./boot.js";
+import { imageHistoryPath, recordImageReady } from "./image-history.js";
 import { join, dirname, resolve } from "node:path";
 import { homedir } from "node:os";
 import { fileURLToPath } from "node:url";
@@ -277,7 +280,10 @@ export async function startGateway(
   if (config.mcp && config.mcp.autoStart !== false) {
     const mcpConfig = config.mcp;
     // Expand presets into server configs + their default security overrides
-    const expanded = expandMCPPresets(mcpConfig.presets ?? []);
+    const expanded = expandMCPPresets(mcpConfig.presets ?? [], {
+      workspace: config.workspace,
+      githubToken: mcpConfig.githubToken,
+    });
     const allServers = [...expanded.servers, ...(mcpConfig.servers ?? [])].map(
       applyPlaywrightDefaults
     );
@@ -428,6 +434,82 @@ export async function startGateway(
     channels.register(discord);
   }
 
+  // Register Slack if configured (Socket Mode: no public URL needed)
+  if (config.slack?.botToken && config.slack.appToken) {
+    const slack = createSlackPlugin({
+      botToken: config.slack.botToken,
+      appToken: config.slack.appToken,
+      memberAllowList: config.slack.memberAllowList,
+      channelAllowList: config.slack.channelAllowList,
+      // Let WriteGate confirmation replies bypass the mention gate
+      preFilter: (ctx) => replyRouter.hasPendingWaiter(ctx),
+    });
+
+    writeGateChannels.set(
+      "slack",
+      createWriteGateChannelAdapter(slack, replyRouter),
+    );
+
+    slack.onMessage(async (ctx) => {
+      if (replyRouter.tryRoute(ctx)) return; // confirmation reply consumed
+      await handleMessage(
+        ctx,
+        config,
+        workspace,
+        sessionStore,
+        transcripts,
+        channels,
+        tools,
+        writeGateChannels,
+        skillsResult,
+        groupHistory,
+        groupRateLimiter,
+        infraStore,
+        steeringManager,
+        unboundNotifier,
+      );
+    });
+
+    channels.register(slack);
+  }
+
+  // Register the generic webhook channel; its route lives on Gateway HTTP
+  let webhook: WebhookChannel | undefined;
+  if (config.webhook?.secret && config.gateway?.http?.enabled) {
+    webhook = createWebhookPlugin({
+      path: config.webhook.path,
+      secret: config.webhook.secret,
+      outboundUrl: config.webhook.outboundUrl,
+    });
+
+    writeGateChannels.set(
+      "webhook",
+      createWriteGateChannelAdapter(webhook, replyRouter),
+    );
+
+    webhook.onMessage(async (ctx) => {
+      if (replyRouter.tryRoute(ctx)) return; // confirmation reply consumed
+      await handleMessage(
+        ctx,
+        config,
+        workspace,
+        sessionStore,
+        transcripts,
+        channels,
+        tools,
+        writeGateChannels,
+        skillsResult,
+        groupHistory,
+        groupRateLimiter,
+        infraStore,
+        steeringManager,
+        unboundNotifier,
+      );
+    });
+
+    channels.register(webhook);
+  }
+
   if (config.telegram && !config.telegram.token) {
     log.warn(
       "Telegram configured but token missing; skipping Telegram channel startup",
@@ -438,6 +520,21 @@ export async function startGateway(
       "Discord configured but token missing; skipping Discord channel startup",
     );
   }
+  if (config.slack && !(config.slack.botToken && config.slack.appToken)) {
+    log.warn(
+      "Slack configured but bot or app token missing; skipping Slack channel startup",
+    );
+  }
+
+  if (config.webhook && !config.webhook.secret) {
+    log.warn(
+      "Webhook configured but secret missing; skipping webhook channel startup",
+    );
+  } else if (config.webhook && !config.gateway?.http?.enabled) {
+    log.warn(
+      "Webhook configured but Gateway HTTP is disabled; skipping webhook channel startup",
+    );
+  }
 
   // Check if any provider has valid credentials
   if (!await hasAnyValidProvider(config.providers)) {
@@ -512,6 +609,7 @@ export async function startGateway(
   // Start Gateway HTTP if enabled
   // Phase 2 Unification: HTTP API as a Channel Adapter, requiring shared resources
   let stopHttp: (() => Promise<void>) | undefined;
+  let markHttpReady: (() => void) | undefined;
   if (config.gateway?.http?.enabled) {
     const httpGateway = await startGatewayHttp({
       config: config.gateway.http,
@@ -521,8 +619,10 @@ export async function startGateway(
       workspacePath: config.workspace,
       system: config.system,
       toolsPolicy: config.tools?.policy,
+      webhook,
     });
     stopHttp = httpGateway.stop;
+    markHttpReady = httpGateway.markReady;
 
     // Register HTTP channel as a peer of Discord/Telegram
     channels.register(httpGateway.channel);
@@ -542,6 +642,13 @@ export async function startGateway(
   await cronIntegration.start();
   log.info("Cron service started");
 
+  // /ready and the log marker below are what installers wait on before saying "up".
+  markHttpReady?.();
+  log.info("Gateway ready");
+  if (process.env.OWLIABOT_IMAGE_REF) {
+    recordImageReady(imageHistoryPath(ensureOwliabotHomeEnv()), process.env.OWLIABOT_IMAGE_REF);
+  }
+
   // Run BOOT.md once after everything is ready
   runBootOnce({
     workspacePath: config.workspace,
@@ -884,7 +991,9 @@ async function handleMessage(
         ? "Telegram group"
         : ctx.channel === "discord"
           ? "Discord guild"
-          : "Group";
+          : ctx.channel === "slack"
+            ? "Slack channel"
+            : "Group";
     effectiveBody = `[${groupLabel} "${groupTitle}" | ${sender}]\n${effectiveBody}`;
 
     // Inject recent context only when the bot is explicitly invoked.
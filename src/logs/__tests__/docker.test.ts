@@ -0,0 +1,27 @@
+import { describe, it, expect } from "vitest";
+import { containerCli, containerCliCandidates, dockerSource } from "../docker.js";
+
+describe("container runtime selection", () => {
+  it("probes docker before podman by default", () => {
+    expect(containerCliCandidates({})).toEqual(["docker", "podman"]);
+  });
+
+  it("honours OWLIABOT_CONTAINER_RUNTIME", () => {
+    expect(containerCliCandidates({ OWLIABOT_CONTAINER_RUNTIME: "Podman" })).toEqual(["podman"]);
+    expect(containerCliCandidates({ OWLIABOT_CONTAINER_RUNTIME: "docker" })).toEqual(["docker"]);
+    expect(containerCliCandidates({ OWLIABOT_CONTAINER_RUNTIME: "lxc" })).toEqual(["docker", "podman"]);
+  });
+
+  it("picks a runtime synchronously: pinned, else the first that answers", () => {
+    const never = () => { throw new Error("should not probe"); };
+    expect(containerCli({ OWLIABOT_CONTAINER_RUNTIME: "podman" }, never)).toBe("podman");
+    expect(containerCli({}, (cli) => cli === "podman")).toBe("podman");
+    expect(containerCli({}, () => true)).toBe("docker");
+    expect(containerCli({}, () => false)).toBe("docker");
+  });
+
+  it("records the CLI on podman log sources only", () => {
+    expect(dockerSource("owliabot")).toEqual({ kind: "docker", container: "owliabot" });
+    expect(dockerSource("owliabot", "podman")).toEqual({ kind: "docker", container: "owliabot", cli: "podman" });
+  });
+});
@@ -3,7 +3,7 @@
  */
 
 import type { LogSource } from "./reader.js";
-import { isInsideDocker, isDockerAvailable, isContainerRunning, dockerSource } from "./docker.js";
+import { isInsideDocker, detectContainerCli, isContainerRunning, dockerSource } from "./docker.js";
 import { resolveLogFilePath, logFileExists, fileSource } from "./file.js";
 
 export interface DetectResult {
@@ -18,7 +18,7 @@ export interface DetectResult {
  * Priority:
  *  1. Explicit --file flag
  *  2. Inside Docker → tell user logs are already on stdout
- *  3. Docker available + container running → docker logs
+ *  3. Docker/Podman available + container running → docker (podman) logs
  *  4. LOG_FILE env → file source
  *  5. No source found → return hint
  */
@@ -42,10 +42,11 @@ export async function detectLogSource(opts: {
     };
   }
 
-  // 3. Docker available + container running
-  if (await isDockerAvailable()) {
-    if (await isContainerRunning(opts.container)) {
-      return { source: dockerSource(opts.container) };
+  // 3. Docker (or Podman) available + container running
+  const cli = await detectContainerCli();
+  if (cli) {
+    if (await isContainerRunning(opts.container, cli)) {
+      return { source: dockerSource(opts.container, cli) };
     }
   }
 
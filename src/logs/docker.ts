@@ -2,7 +2,7 @@
  * Docker environment helpers — thin adapter over the shared reader.
  */
 
-import { execFile } from "node:child_process";
+import { execFile, spawnSync } from "node:child_process";
 import { existsSync } from "node:fs";
 import type { LogSource } from "./reader.js";
 
@@ -15,28 +15,73 @@ function exec(cmd: string, args: string[]): Promise<string> {
   });
 }
 
-/** Are we running *inside* a Docker container right now? */
+export type ContainerCli = "docker" | "podman";
+
+/** Are we running *inside* a Docker (or Podman) container right now? */
 export function isInsideDocker(): boolean {
-  return existsSync("/.dockerenv") || process.env.OWLIABOT_DOCKER === "1";
+  return existsSync("/.dockerenv") || existsSync("/run/.containerenv") || process.env.OWLIABOT_DOCKER === "1";
 }
 
-/** Is the Docker CLI available on this machine? */
-export async function isDockerAvailable(): Promise<boolean> {
-  try {
-    await exec("docker", ["info", "--format", "{{.ID}}"]);
-    return true;
-  } catch {
-    return false;
+/**
+ * Candidate container CLIs, in preference order.
+ * OWLIABOT_CONTAINER_RUNTIME=docker|podman pins the choice.
+ */
+export function containerCliCandidates(
+  env: Record<string, string | undefined> = process.env,
+): ContainerCli[] {
+  const pinned = env.OWLIABOT_CONTAINER_RUNTIME?.trim().toLowerCase();
+  if (pinned === "docker" || pinned === "podman") return [pinned];
+  return ["docker", "podman"];
+}
+
+/** First container CLI (docker, then podman) whose engine responds, or null. */
+export async function detectContainerCli(): Promise<ContainerCli | null> {
+  for (const cli of containerCliCandidates()) {
+    try {
+      // Plain `info` (no --format): the template fields differ between docker and podman.
+      await exec(cli, ["info"]);
+      return cli;
+    } catch {
+      // try the next one
+    }
   }
+  return null;
+}
+
+let probedCli: ContainerCli | undefined;
+
+/**
+ * Synchronous counterpart of detectContainerCli() for callers that shell out
+ * with execFileSync (upgrade, doctor, onboarding checks): the pinned runtime,
+ * else the first candidate whose engine answers, else "docker" so errors
+ * name the usual tool. Without arguments the probe runs once per process.
+ */
+export function containerCli(
+  env?: Record<string, string | undefined>,
+  probe?: (cli: ContainerCli) => boolean,
+): ContainerCli {
+  const candidates = containerCliCandidates(env ?? process.env);
+  if (candidates.length === 1) return candidates[0];
+  if (!env && !probe && probedCli) return probedCli;
+  const answers = probe ?? ((cli: ContainerCli) => spawnSync(cli, ["info"], { stdio: "ignore", timeout: 5_000 }).status === 0);
+  const cli = candidates.find((c) => answers(c)) ?? "docker";
+  if (!env && !probe) probedCli = cli;
+  return cli;
+}
+
+/** Is a Docker-compatible CLI (docker or podman) available on this machine? */
+export async function isDockerAvailable(): Promise<boolean> {
+  return (await detectContainerCli()) !== null;
 }
 
 /** Check if a container with the given name is running. */
-export async function isContainerRunning(name: string): Promise<boolean> {
+export async function isContainerRunning(name: string, cli: ContainerCli = "docker"): Promise<boolean> {
   try {
-    const out = await exec("docker", [
+    const out = await exec(cli, [
       "ps",
       "--filter",
-      `name=^/${name}$`,
+      // Docker matches against "/name", Podman against "name".
+      `name=^/?${name}$`,
       "--format",
       "{{.ID}}",
     ]);
@@ -46,7 +91,7 @@ export async function isContainerRunning(name: string): Promise<boolean> {
   }
 }
 
-/** Build a LogSource for a running Docker container. */
-export function dockerSource(container: string): LogSource {
-  return { kind: "docker", container };
+/** Build a LogSource for a running Docker (or Podman) container. */
+export function dockerSource(container: string, cli: ContainerCli = "docker"): LogSource {
+  return cli === "docker" ? { kind: "docker", container } : { kind: "docker", container, cli };
 }
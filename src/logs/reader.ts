@@ -22,7 +22,7 @@ import { watch } from "node:fs";
 
 export type LogSource =
   | { kind: "file"; path: string }
-  | { kind: "docker"; container: string }
+  | { kind: "docker"; container: string; cli?: "docker" | "podman" } // cli defaults to "docker"
   | { kind: "process"; command: string[] };
 
 export interface LogReaderOptions {
@@ -92,12 +92,13 @@ async function* streamFromProcess(
 async function* streamFromDocker(
   container: string,
   opts: LogReaderOptions,
+  cli: "docker" | "podman" = "docker",
 ): AsyncGenerator<string> {
   const args = ["logs"];
   if (opts.follow) args.push("-f");
   args.push("--tail", String(opts.lines), container);
 
-  yield* streamFromProcess(["docker", ...args], opts);
+  yield* streamFromProcess([cli, ...args], opts);
 }
 
 async function* streamFromFile(
@@ -179,7 +180,7 @@ export async function* streamLogs(
 
   switch (source.kind) {
     case "docker":
-      yield* streamFromDocker(source.container, options);
+      yield* streamFromDocker(source.container, options, source.cli);
       break;
     case "file":
       yield* streamFromFile(source.path, options);
@@ -1,5 +1,5 @@
 import { describe, it, expect } from "vitest";
-import { expandMCPPresets, getAvailablePresets, getDefaultSecurityOverrides } from "../presets.js";
+import { expandMCPPresets, getAvailablePresets, getDefaultSecurityOverrides, getPresetDescription } from "../presets.js";
 
 describe("expandMCPPresets", () => {
   it("expands playwright preset with server config + security overrides", () => {
@@ -70,6 +70,24 @@ describe("expandMCPPresets", () => {
     expect(result.securityOverrides["playwright__browser_take_screenshot"]).toBeDefined();
   });
 
+  it("points the reference presets at the workspace", () => {
+    const result = expandMCPPresets(["filesystem", "sqlite", "memory"], { workspace: "/ws" });
+    const byName = Object.fromEntries(result.servers.map((s) => [s.name, s]));
+
+    expect(byName.filesystem.args).toEqual(["--yes", "@modelcontextprotocol/server-filesystem", "/ws"]);
+    expect(byName.sqlite.args).toEqual(["mcp-server-sqlite", "--db-path", "/ws/data.sqlite"]);
+    expect(byName.memory.env?.MEMORY_FILE_PATH).toBe("/ws/mcp-memory.jsonl");
+    expect(result.securityOverrides["memory__delete_entities"]).toBeUndefined();
+  });
+
+  it("passes the GitHub token through the environment", () => {
+    const withToken = expandMCPPresets(["github"], { githubToken: "ghp_test" });
+    expect(withToken.servers[0].env).toEqual({ GITHUB_PERSONAL_ACCESS_TOKEN: "ghp_test" });
+    expect(withToken.securityOverrides["github__merge_pull_request"]).toEqual({ level: "write", confirmRequired: true });
+
+    expect(expandMCPPresets(["github"]).servers[0].env).toEqual({});
+  });
+
   it("returns empty for empty input", () => {
     const result = expandMCPPresets([]);
     expect(result.servers).toEqual([]);
@@ -91,7 +109,11 @@ describe("getDefaultSecurityOverrides", () => {
 });
 
 describe("getAvailablePresets", () => {
-  it("includes playwright", () => {
-    expect(getAvailablePresets()).toContain("playwright");
+  it("includes playwright and the reference servers", () => {
+    expect(getAvailablePresets()).toEqual(["playwright", "filesystem", "github", "fetch", "sqlite", "memory"]);
+  });
+
+  it("describes every preset", () => {
+    for (const name of getAvailablePresets()) expect(getPresetDescription(name)).toBeTruthy();
   });
 });
@@ -5,19 +5,61 @@
 
 import { createLogger } from "../utils/logger.js";
 import { createPlaywrightConfig, playwrightSecurityOverrides } from "./servers/playwright.js";
+import {
+  createFilesystemConfig,
+  createGithubConfig,
+  createFetchConfig,
+  createSqliteConfig,
+  createMemoryConfig,
+  filesystemSecurityOverrides,
+  githubSecurityOverrides,
+  fetchSecurityOverrides,
+  sqliteSecurityOverrides,
+  memorySecurityOverrides,
+  type ReferencePresetContext,
+} from "./servers/reference.js";
 import type { MCPServerConfig, MCPSecurityOverride } from "./types.js";
 
 const log = createLogger("mcp:presets");
 
+export type PresetContext = ReferencePresetContext;
+
 /** Registry of known preset names → server config + security overrides */
 const PRESET_REGISTRY: Record<string, {
-  config: () => MCPServerConfig;
+  description: string;
+  config: (ctx: PresetContext) => MCPServerConfig;
   securityOverrides: Record<string, MCPSecurityOverride>;
 }> = {
   playwright: {
+    description: "Browser automation via @playwright/mcp",
     config: () => createPlaywrightConfig({ headless: true }),
     securityOverrides: playwrightSecurityOverrides,
   },
+  filesystem: {
+    description: "Read and edit files in the workspace",
+    config: createFilesystemConfig,
+    securityOverrides: filesystemSecurityOverrides,
+  },
+  github: {
+    description: "Issues, pull requests and code on GitHub (needs a personal access token)",
+    config: createGithubConfig,
+    securityOverrides: githubSecurityOverrides,
+  },
+  fetch: {
+    description: "Fetch web pages as markdown (needs uv)",
+    config: createFetchConfig,
+    securityOverrides: fetchSecurityOverrides,
+  },
+  sqlite: {
+    description: "Query a SQLite database in the workspace (needs uv)",
+    config: createSqliteConfig,
+    securityOverrides: sqliteSecurityOverrides,
+  },
+  memory: {
+    description: "Long-term knowledge graph stored in the workspace",
+    config: createMemoryConfig,
+    securityOverrides: memorySecurityOverrides,
+  },
 };
 
 export interface ExpandedPresets {
@@ -29,7 +71,7 @@ export interface ExpandedPresets {
  * Expand an array of preset names into MCPServerConfig objects + their security overrides.
  * Unknown presets are logged as warnings and skipped.
  */
-export function expandMCPPresets(presets: string[]): ExpandedPresets {
+export function expandMCPPresets(presets: string[], ctx: PresetContext = {}): ExpandedPresets {
   const seen = new Set<string>();
   const servers: MCPServerConfig[] = [];
   const securityOverrides: Record<string, MCPSecurityOverride> = {};
@@ -42,7 +84,10 @@ export function expandMCPPresets(presets: string[]): ExpandedPresets {
         continue;
       }
       seen.add(name);
-      servers.push(preset.config());
+      if (name === "github" && !ctx.githubToken) {
+        log.warn('MCP preset "github" has no token (mcp.githubToken / GITHUB_PERSONAL_ACCESS_TOKEN)');
+      }
+      servers.push(preset.config(ctx));
       Object.assign(securityOverrides, preset.securityOverrides);
       log.info(`Expanded MCP preset: ${name}`);
     } else {
@@ -68,3 +113,13 @@ export function getDefaultSecurityOverrides(serverName: string): Record<string,
 export function getAvailablePresets(): string[] {
   return Object.keys(PRESET_REGISTRY);
 }
+
+/** One-line description of a preset, for onboarding */
+export function getPresetDescription(name: string): string | undefined {
+  return PRESET_REGISTRY[name]?.description;
+}
+
+/** Command a preset runs (npx, uvx, ...), for runtime checks */
+export function getPresetCommand(name: string): string | undefined {
+  return PRESET_REGISTRY[name]?.config({}).command;
+}
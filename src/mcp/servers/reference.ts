@@ -0,0 +1,118 @@
+/**
+ * Reference MCP Server Configurations
+ * Presets for servers from the modelcontextprotocol/servers collection:
+ * filesystem, github, fetch, sqlite and memory.
+ *
+ * fetch and sqlite are Python packages and run through `uvx` (needs uv).
+ *
+ * @see https://github.com/modelcontextprotocol/servers
+ */
+
+import { join } from "node:path";
+import type { MCPServerConfig, MCPSecurityOverride } from "../types.js";
+
+/** Values a preset may need from the gateway config */
+export interface ReferencePresetContext {
+  /** Workspace path; filesystem access and data files stay inside it */
+  workspace?: string;
+  /** GitHub personal access token (mcp.githubToken) */
+  githubToken?: string;
+}
+
+// ============================================================================
+// Configuration
+// ============================================================================
+
+/**
+ * Filesystem server limited to the workspace (or the current directory).
+ */
+export function createFilesystemConfig(ctx: ReferencePresetContext = {}): MCPServerConfig {
+  return {
+    name: "filesystem",
+    command: "npx",
+    args: ["--yes", "@modelcontextprotocol/server-filesystem", ctx.workspace ?? "."],
+    transport: "stdio",
+  };
+}
+
+/**
+ * GitHub server; the token is passed through the environment.
+ */
+export function createGithubConfig(ctx: ReferencePresetContext = {}): MCPServerConfig {
+  return {
+    name: "github",
+    command: "npx",
+    args: ["--yes", "@modelcontextprotocol/server-github"],
+    transport: "stdio",
+    env: ctx.githubToken ? { GITHUB_PERSONAL_ACCESS_TOKEN: ctx.githubToken } : {},
+  };
+}
+
+/**
+ * Fetch server: retrieves web pages as markdown.
+ */
+export function createFetchConfig(): MCPServerConfig {
+  return {
+    name: "fetch",
+    command: "uvx",
+    args: ["mcp-server-fetch"],
+    transport: "stdio",
+  };
+}
+
+/**
+ * SQLite server on a database file in the workspace.
+ */
+export function createSqliteConfig(ctx: ReferencePresetContext = {}): MCPServerConfig {
+  return {
+    name: "sqlite",
+    command: "uvx",
+    args: ["mcp-server-sqlite", "--db-path", join(ctx.workspace ?? ".", "data.sqlite")],
+    transport: "stdio",
+  };
+}
+
+/**
+ * Memory server: a knowledge graph persisted as JSON lines in the workspace.
+ */
+export function createMemoryConfig(ctx: ReferencePresetContext = {}): MCPServerConfig {
+  return {
+    name: "memory",
+    command: "npx",
+    args: ["--yes", "@modelcontextprotocol/server-memory"],
+    transport: "stdio",
+    env: { MEMORY_FILE_PATH: join(ctx.workspace ?? ".", "mcp-memory.jsonl") },
+  };
+}
+
+// ============================================================================
+// Security Overrides
+// ============================================================================
+// Only tools the name heuristic in MCPToolAdapter gets wrong are listed.
+
+export const filesystemSecurityOverrides: Record<string, MCPSecurityOverride> = {
+  "filesystem__directory_tree": { level: "read" },
+  "filesystem__edit_file": { level: "write" },
+  "filesystem__move_file": { level: "write" },
+};
+
+export const githubSecurityOverrides: Record<string, MCPSecurityOverride> = {
+  // These change the remote repository and can't be undone from here
+  "github__push_files": { level: "write", confirmRequired: true },
+  "github__merge_pull_request": { level: "write", confirmRequired: true },
+};
+
+export const fetchSecurityOverrides: Record<string, MCPSecurityOverride> = {};
+
+export const sqliteSecurityOverrides: Record<string, MCPSecurityOverride> = {};
+
+/**
+ * Adding to the graph is treated as read (like Playwright interactions):
+ * it's the bot's own scratch memory. Deleting stays write.
+ */
+export const memorySecurityOverrides: Record<string, MCPSecurityOverride> = {
+  "memory__create_entities": { level: "read" },
+  "memory__create_relations": { level: "read" },
+  "memory__add_observations": { level: "read" },
+  "memory__open_nodes": { level: "read" },
+};
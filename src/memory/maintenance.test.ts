@@ -0,0 +1,69 @@
+import { afterEach, beforeEach, describe, expect, it } from "vitest";
+import { existsSync, mkdirSync, mkdtempSync, rmSync, writeFileSync } from "node:fs";
+import { tmpdir } from "node:os";
+import { join } from "node:path";
+
+import {
+  clearMemory,
+  countIndexed,
+  memoryClearPlan,
+  reindexMemory,
+  vacuumMemoryStore,
+  type MemoryTarget,
+} from "./maintenance.js";
+
+describe("memory maintenance", () => {
+  let dir: string;
+  let target: MemoryTarget;
+
+  beforeEach(() => {
+    dir = mkdtempSync(join(tmpdir(), "owliabot-mem-maint-"));
+    mkdirSync(join(dir, "memory", "2026"), { recursive: true });
+    writeFileSync(join(dir, "MEMORY.md"), "# Memory\nLikes tea\n");
+    writeFileSync(join(dir, "memory", "2026", "01-02.md"), "## Notes\nMet Bob\n");
+    target = {
+      dbPath: join(dir, "memory", "main.sqlite"),
+      workspaceDir: dir,
+      extraPaths: [],
+      sources: ["files"],
+    };
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+  });
+
+  it("re-indexes right away, and from scratch with full", async () => {
+    expect((await reindexMemory(target)).files).toBe(2);
+
+    rmSync(join(dir, "memory", "2026", "01-02.md"));
+    expect((await reindexMemory(target, { full: true })).files).toBe(1);
+  });
+
+  it("vacuums an existing store and skips a missing one", async () => {
+    expect(vacuumMemoryStore(target.dbPath)).toBeNull();
+
+    await reindexMemory(target);
+    const result = vacuumMemoryStore(target.dbPath);
+    expect(result).not.toBeNull();
+    expect(result!.after).toBeGreaterThan(0);
+    expect(result!.after).toBeLessThanOrEqual(result!.before);
+    expect(countIndexed(target.dbPath).files).toBe(2);
+  });
+
+  it("clears the index, keeping notes unless asked", async () => {
+    await reindexMemory(target);
+
+    expect(clearMemory(target)).toContain(target.dbPath);
+    expect(existsSync(target.dbPath)).toBe(false);
+    expect(existsSync(join(dir, "MEMORY.md"))).toBe(true);
+
+    expect(memoryClearPlan(target, { notes: true })).toEqual([
+      join(dir, "MEMORY.md"),
+      join(dir, "memory", "2026", "01-02.md"),
+    ]);
+    clearMemory(target, { notes: true });
+    expect(existsSync(join(dir, "MEMORY.md"))).toBe(false);
+    expect(existsSync(join(dir, "memory", "2026", "01-02.md"))).toBe(false);
+  });
+});
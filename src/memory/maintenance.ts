@@ -0,0 +1,154 @@
+/**
+ * Maintenance for the memory index behind `owliabot memory`.
+ *
+ * The gateway refreshes the index lazily (memorySearch.indexing), at most
+ * every minIntervalMs. These helpers let an operator re-index right away,
+ * compact the sqlite store after large deletions, or start over. In a
+ * Docker install they run inside the container:
+ *
+ *   docker exec -it owliabot owliabot memory reindex
+ */
+
+import { existsSync, lstatSync, readdirSync, rmSync } from "node:fs";
+import path from "node:path";
+
+import type { Config } from "../config/schema.js";
+import { resolveAgentId } from "../agent/session-key.js";
+import { resolveMemorySearchConfig, resolveMemoryStorePath } from "./config.js";
+import { openMemoryIndexDbAtPath } from "./index/db.js";
+import { indexMemory } from "./index/indexer.js";
+import type { MemorySearchSourceId } from "./types.js";
+
+/** Everything the maintenance actions need, resolved from app.yaml */
+export interface MemoryTarget {
+  dbPath: string;
+  workspaceDir: string;
+  extraPaths: string[];
+  sources: MemorySearchSourceId[];
+  sessionsDir?: string;
+}
+
+export interface MemoryIndexCounts {
+  files: number;
+  chunks: number;
+}
+
+const STORE_SUFFIXES = ["", "-wal", "-shm", "-journal"];
+
+export function resolveMemoryTarget(config: Config, sessionsDir?: string): MemoryTarget {
+  const memoryConfig = resolveMemorySearchConfig(config);
+  const dbPath = resolveMemoryStorePath({
+    config: memoryConfig,
+    agentId: resolveAgentId({ config }),
+    workspacePath: config.workspace,
+  });
+  return {
+    dbPath,
+    workspaceDir: path.resolve(config.workspace),
+    extraPaths: memoryConfig.extraPaths ?? [],
+    sources: memoryConfig.indexing?.sources ?? memoryConfig.sources ?? ["files"],
+    sessionsDir,
+  };
+}
+
+/** Size of the store including its WAL / shared-memory files; 0 when absent */
+export function memoryStoreSize(dbPath: string): number {
+  let total = 0;
+  for (const suffix of ["", "-wal", "-shm"]) {
+    try {
+      total += lstatSync(`${dbPath}${suffix}`).size;
+    } catch {
+      // not there
+    }
+  }
+  return total;
+}
+
+export function countIndexed(dbPath: string): MemoryIndexCounts {
+  const db = openMemoryIndexDbAtPath(dbPath);
+  try {
+    const files = (db.prepare("SELECT COUNT(*) AS c FROM files").get() as { c: number }).c;
+    const chunks = (db.prepare("SELECT COUNT(*) AS c FROM chunks").get() as { c: number }).c;
+    return { files, chunks };
+  } finally {
+    db.close();
+  }
+}
+
+/**
+ * Index now instead of waiting for the next memory_search. With `full`, the
+ * store is dropped first so every file is re-chunked, not just changed ones.
+ */
+export async function reindexMemory(
+  target: MemoryTarget,
+  options: { full?: boolean } = {},
+): Promise<MemoryIndexCounts> {
+  if (options.full) removeStoreFiles(target.dbPath);
+  await indexMemory({
+    workspaceDir: target.workspaceDir,
+    extraPaths: target.extraPaths,
+    dbPath: target.dbPath,
+    sources: target.sources,
+    sessionsDir: target.sessionsDir,
+  });
+  return countIndexed(target.dbPath);
+}
+
+/**
+ * Fold the WAL back into the database and VACUUM it. Returns null when
+ * there is no store yet.
+ */
+export function vacuumMemoryStore(dbPath: string): { before: number; after: number } | null {
+  if (!existsSync(dbPath)) return null;
+  const before = memoryStoreSize(dbPath);
+  const db = openMemoryIndexDbAtPath(dbPath);
+  try {
+    db.pragma("wal_checkpoint(TRUNCATE)");
+    db.exec("VACUUM");
+    db.pragma("wal_checkpoint(TRUNCATE)");
+  } finally {
+    db.close();
+  }
+  return { before, after: memoryStoreSize(dbPath) };
+}
+
+/**
+ * Memory notes in the workspace, as the indexer finds them: MEMORY.md and
+ * the markdown files under memory/. The index store itself is not included.
+ */
+export function memoryNoteFiles(workspaceDir: string): string[] {
+  const memoryMd = path.join(workspaceDir, "MEMORY.md");
+  const notes = existsSync(memoryMd) ? [memoryMd] : [];
+  const stack = [path.join(workspaceDir, "memory")];
+  while (stack.length > 0) {
+    const dir = stack.pop()!;
+    if (!existsSync(dir) || !lstatSync(dir).isDirectory()) continue;
+    for (const entry of readdirSync(dir, { withFileTypes: true })) {
+      const p = path.join(dir, entry.name);
+      if (entry.isDirectory()) stack.push(p);
+      else if (entry.isFile() && entry.name.endsWith(".md")) notes.push(p);
+    }
+  }
+  return notes.sort();
+}
+
+/** The files `clearMemory` would delete, for the confirmation prompt */
+export function memoryClearPlan(target: MemoryTarget, options: { notes?: boolean } = {}): string[] {
+  const store = STORE_SUFFIXES.map((s) => `${target.dbPath}${s}`).filter((p) => existsSync(p));
+  return options.notes ? [...store, ...memoryNoteFiles(target.workspaceDir)] : store;
+}
+
+/**
+ * Delete the index store, and with `notes` the memory notes too. The index
+ * is rebuilt on the next memory_search (or `owliabot memory reindex`); the
+ * notes are gone for good.
+ */
+export function clearMemory(target: MemoryTarget, options: { notes?: boolean } = {}): string[] {
+  const removed = memoryClearPlan(target, options);
+  for (const p of removed) rmSync(p, { force: true });
+  return removed;
+}
+
+function removeStoreFiles(dbPath: string): void {
+  for (const suffix of STORE_SUFFIXES) rmSync(`${dbPath}${suffix}`, { force: true });
+}
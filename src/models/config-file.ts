@@ -1,6 +1,7 @@
 import { parse, stringify } from "yaml";
 
 import { applyPrimaryModelRefOverride, type ProviderModelRef } from "./override.js";
+import { refreshTrackedFile } from "../config/integrity.js";
 import { mkdir, open, readFile, rename, stat, unlink, writeFile } from "node:fs/promises";
 import { dirname } from "node:path";
 
@@ -100,6 +101,7 @@ export async function updateYamlFileAtomic(
     const raw = await readFile(filePath, "utf-8");
     const next = await updater(raw);
     await writeFileAtomic(filePath, next);
+    refreshTrackedFile(dirname(filePath), filePath);
   }, opts);
 }
 
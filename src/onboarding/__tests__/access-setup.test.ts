@@ -0,0 +1,69 @@
+/**
+ * Unit tests for onboarding/steps/access-setup.ts
+ */
+
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { parseIdList, configureDiscordAccess } from "../steps/access-setup.js";
+import type { UserAllowLists } from "../steps/types.js";
+
+const CHANNEL = "1234567890123456789";
+const MEMBER = "98765432109876543";
+
+describe("access setup", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  it("splits on commas and spaces and reports malformed IDs", () => {
+    expect(parseIdList(`${CHANNEL}, ${MEMBER} ${CHANNEL}`, "discord")).toEqual({ ids: [CHANNEL, MEMBER], invalid: [] });
+    expect(parseIdList("12345, #general", "discord")).toEqual({ ids: [], invalid: ["12345", "#general"] });
+    expect(parseIdList("539066683, @alice", "telegram")).toEqual({ ids: ["539066683"], invalid: ["@alice"] });
+    expect(parseIdList("", "telegram")).toEqual({ ids: [], invalid: [] });
+  });
+
+  it("keeps the open defaults when both answers are empty", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    const config: any = { discord: { requireMentionInGuild: true, channelAllowList: [] } };
+    const allow: UserAllowLists = { discord: [], telegram: [] };
+    answers = ["", ""];
+
+    await configureDiscordAccess(rl, config, allow);
+
+    expect(config.discord).toEqual({ requireMentionInGuild: true, channelAllowList: [] });
+    expect(allow.discord).toEqual([]);
+  });
+
+  it("re-asks on a malformed ID and feeds members to the write-tool allowlist", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    const config: any = { discord: { requireMentionInGuild: true, channelAllowList: [] } };
+    const allow: UserAllowLists = { discord: [], telegram: [] };
+    answers = ["general", CHANNEL, MEMBER];
+
+    await configureDiscordAccess(rl, config, allow);
+
+    expect(config.discord.requireMentionInGuild).toBe(true);
+    expect(config.discord.channelAllowList).toEqual([CHANNEL]);
+    expect(config.discord.memberAllowList).toEqual([MEMBER]);
+    expect(allow.discord).toEqual([MEMBER]);
+  });
+});
@@ -0,0 +1,63 @@
+/**
+ * Unit tests for accessible mode (onboard --accessible) in onboarding/shared.ts
+ */
+
+import { describe, it, expect, afterEach, vi } from "vitest";
+import { COLORS, header, info, printBanner, setAccessible, success, wantsAccessible } from "../shared.js";
+import { splitScreens } from "../steps/screen-dump.js";
+import { renderMarkdown } from "../steps/stage-help.js";
+
+describe("accessible mode", () => {
+  afterEach(() => {
+    setAccessible(false);
+    vi.restoreAllMocks();
+  });
+
+  function printed(fn: () => void): string[] {
+    const log = vi.spyOn(console, "log").mockImplementation(() => {});
+    fn();
+    return log.mock.calls.map((c) => String(c[0] ?? ""));
+  }
+
+  it("prints plain lines: words instead of symbols, no colors or art", () => {
+    setAccessible(true);
+    expect(COLORS.GREEN).toBe("");
+    const lines = printed(() => {
+      printBanner("(Docker)", ["  ART  "]);
+      header("AI providers");
+      info("Checking Docker");
+      success("Saved");
+      info("");
+    });
+    expect(lines.join("\n")).not.toMatch(/\x1b|ART|━|✓|ℹ/);
+    expect(lines).toContain("Step: AI providers");
+    expect(lines).toContain("Note: Checking Docker");
+    expect(lines).toContain("Done: Saved");
+  });
+
+  it("restores colors when turned off", () => {
+    setAccessible(true);
+    setAccessible(false);
+    expect(COLORS.GREEN).toBe("\x1b[0;32m");
+    expect(printed(() => header("AI providers"))).toContain(`${COLORS.CYAN}━━━ AI providers ━━━${COLORS.NC}`);
+  });
+
+  it("renders help articles without bold or rules", () => {
+    setAccessible(true);
+    expect(renderMarkdown("# Title\n\n- **one** `two`")).toBe("Title\n\n  - one two\n");
+  });
+
+  it("splits plain headers into screens", () => {
+    expect(splitScreens(["Step: AI providers", "Pick one"])).toEqual([
+      { title: "AI providers", lines: ["Step: AI providers", "Pick one"] },
+    ]);
+  });
+
+  it("is on for the flag, OWLIABOT_ACCESSIBLE, or a dumb terminal", () => {
+    expect(wantsAccessible(true, {}, false)).toBe(true);
+    expect(wantsAccessible(undefined, { OWLIABOT_ACCESSIBLE: "1" }, false)).toBe(true);
+    expect(wantsAccessible(undefined, { TERM: "dumb" }, true)).toBe(true);
+    expect(wantsAccessible(undefined, { TERM: "dumb" }, false)).toBe(false);
+    expect(wantsAccessible(undefined, { TERM: "xterm-256color" }, true)).toBe(false);
+  });
+});
@@ -0,0 +1,38 @@
+/**
+ * Unit tests for onboarding/steps/anthropic-login.ts
+ */
+
+import { describe, it, expect, vi } from "vitest";
+import { defaultClaudeLogin, hasClaudeCli, runClaudeSetupToken } from "../steps/anthropic-login.js";
+
+describe("hasClaudeCli", () => {
+  it("is true when claude --version runs", () => {
+    const exec = vi.fn(() => "1.0.0 (Claude Code)\n");
+    expect(hasClaudeCli(exec)).toBe(true);
+    expect(exec).toHaveBeenCalledWith("claude", ["--version"]);
+  });
+
+  it("is false when claude can't be started", () => {
+    expect(hasClaudeCli(() => { throw new Error("spawn claude ENOENT"); })).toBe(false);
+  });
+});
+
+describe("runClaudeSetupToken", () => {
+  it("runs claude setup-token and reports the exit status", () => {
+    const spawn = vi.fn(() => ({ status: 0 }));
+    expect(runClaudeSetupToken(spawn)).toBe(true);
+    expect(spawn).toHaveBeenCalledWith("claude", ["setup-token"]);
+    expect(runClaudeSetupToken(() => ({ status: 1 }))).toBe(false);
+    expect(runClaudeSetupToken(() => ({ status: null }))).toBe(false);
+  });
+});
+
+describe("defaultClaudeLogin", () => {
+  it("is only offered on a terminal with the CLI installed", () => {
+    const available = vi.fn(() => true);
+    expect(defaultClaudeLogin(false, available)).toBeNull();
+    expect(available).not.toHaveBeenCalled();
+    expect(defaultClaudeLogin(true, () => false)).toBeNull();
+    expect(defaultClaudeLogin(true, available)).toBeTypeOf("function");
+  });
+});
@@ -0,0 +1,60 @@
+/**
+ * Unit tests for onboarding/steps/auto-detect.ts
+ */
+
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { detectProviderChoice, detectChannelChoice, tagAutoDetected } from "../steps/auto-detect.js";
+import { askChannels } from "../steps/channel-setup.js";
+
+describe("auto-detected defaults", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  it("picks the provider from exported API keys", () => {
+    expect(detectProviderChoice({ ANTHROPIC_API_KEY: "sk-ant-api03-x" })).toBe(0);
+    expect(detectProviderChoice({ OPENAI_API_KEY: "sk-x" })).toBe(1);
+    expect(detectProviderChoice({ ANTHROPIC_API_KEY: "a", OPENAI_API_KEY: "b" })).toBe(4);
+    expect(detectProviderChoice({})).toBeUndefined();
+  });
+
+  it("picks the channel from env tokens and existing secrets", () => {
+    expect(detectChannelChoice(null, { TELEGRAM_BOT_TOKEN: "1:a" })).toBe(1);
+    expect(detectChannelChoice({ discordToken: "d" }, { TELEGRAM_BOT_TOKEN: "1:a" })).toBe(2);
+    expect(detectChannelChoice({ slackBotToken: "xoxb-1" }, {})).toBe(3);
+    expect(detectChannelChoice(null, {})).toBeUndefined();
+  });
+
+  it("tags only the detected option", () => {
+    expect(tagAutoDetected(["A", "B"], 1)).toEqual(["A", "B (auto-detected)"]);
+    expect(tagAutoDetected(["A", "B"], undefined)).toEqual(["A", "B"]);
+  });
+
+  it("accepts the detected channel on Enter", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["", ""]; // chat platform: Enter (Telegram detected); token: later
+
+    const result = await askChannels(rl, {}, null, false, { TELEGRAM_BOT_TOKEN: "1:a" });
+
+    expect(result.telegramEnabled).toBe(true);
+    expect(result.discordEnabled).toBe(false);
+  });
+});
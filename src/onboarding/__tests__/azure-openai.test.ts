@@ -0,0 +1,128 @@
+/**
+ * Unit tests for onboarding/steps/azure-openai.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import type { DetectedConfig, ProviderSetupState } from "../steps/types.js";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (_q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error("Ran out of answers");
+      cb(next);
+    },
+    close: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import {
+  isAzureApiVersion,
+  isAzureOpenAIHost,
+  maybeConfigureAzureOpenAI,
+  normalizeAzureEndpoint,
+  AZURE_OPENAI_API_VERSION,
+} from "../steps/azure-openai.js";
+import { reuseProvidersFromExisting } from "../steps/provider-setup.js";
+
+function freshState(): ProviderSetupState {
+  return { secrets: {}, providers: [], priority: 1, useAnthropic: false, useOpenaiCodex: false };
+}
+
+describe("azure endpoint helpers", () => {
+  it("normalizes the endpoint and rejects non-https URLs", () => {
+    expect(normalizeAzureEndpoint("https://res.openai.azure.com/")).toBe("https://res.openai.azure.com");
+    expect(normalizeAzureEndpoint("https://res.openai.azure.com/openai/")).toBe("https://res.openai.azure.com");
+    expect(normalizeAzureEndpoint("http://res.openai.azure.com")).toBeNull();
+    expect(normalizeAzureEndpoint("res.openai.azure.com")).toBeNull();
+  });
+
+  it("recognizes Azure OpenAI hosts", () => {
+    expect(isAzureOpenAIHost("https://res.openai.azure.com")).toBe(true);
+    expect(isAzureOpenAIHost("https://res.cognitiveservices.azure.com")).toBe(true);
+    expect(isAzureOpenAIHost("https://proxy.example.com")).toBe(false);
+  });
+
+  it("accepts GA and preview API versions", () => {
+    expect(isAzureApiVersion("2024-10-21")).toBe(true);
+    expect(isAzureApiVersion("2025-01-01-preview")).toBe(true);
+    expect(isAzureApiVersion("latest")).toBe(false);
+  });
+});
+
+describe("maybeConfigureAzureOpenAI", () => {
+  let rl: ReturnType<typeof createInterface>;
+
+  beforeEach(() => {
+    answers = [];
+    rl = createInterface({ input: process.stdin, output: process.stdout });
+    vi.spyOn(console, "log").mockImplementation(() => {});
+  });
+
+  afterEach(() => {
+    vi.restoreAllMocks();
+  });
+
+  it("skips unless Azure OpenAI was picked", async () => {
+    const state = freshState();
+    await maybeConfigureAzureOpenAI(rl, state, 5);
+    expect(state.providers).toHaveLength(0);
+  });
+
+  it("writes the provider block and stores the key in secrets", async () => {
+    const state = freshState();
+    answers = ["ftp://nope", "https://res.openai.azure.com/", "gpt-4o-prod", "v1", "", "azure-key-1234567890"];
+    await maybeConfigureAzureOpenAI(rl, state, 6);
+    expect(state.providers).toEqual([{
+      id: "azure-openai",
+      model: "gpt-4o-prod",
+      baseUrl: "https://res.openai.azure.com",
+      apiVersion: AZURE_OPENAI_API_VERSION,
+      apiKey: "secrets",
+      priority: 1,
+    }]);
+    expect(state.secrets["azure-openai"]).toEqual({ apiKey: "azure-key-1234567890" });
+  });
+
+  it("uses the env var when no key is entered", async () => {
+    const state = freshState();
+    answers = ["https://res.openai.azure.com", "", "2025-01-01-preview", ""];
+    await maybeConfigureAzureOpenAI(rl, state, 6);
+    expect(state.providers[0]).toMatchObject({ model: "gpt-4o", apiVersion: "2025-01-01-preview", apiKey: "env" });
+    expect(state.secrets["azure-openai"]).toBeUndefined();
+  });
+
+  it("adds nothing when the endpoint is skipped", async () => {
+    const state = freshState();
+    answers = [""];
+    await maybeConfigureAzureOpenAI(rl, state, 6);
+    expect(state.providers).toHaveLength(0);
+  });
+});
+
+describe("reuseProvidersFromExisting (azure-openai)", () => {
+  it("rebuilds the provider from the detected endpoint, deployment and key", () => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    const existing: DetectedConfig = {
+      azureOpenai: { apiKey: "azure-key", endpoint: "https://res.openai.azure.com", deployment: "prod", apiVersion: "2024-10-21" },
+    };
+    const result = reuseProvidersFromExisting(existing);
+    expect(result.providers).toEqual([{
+      id: "azure-openai",
+      model: "prod",
+      baseUrl: "https://res.openai.azure.com",
+      apiVersion: "2024-10-21",
+      apiKey: "secrets",
+      priority: 1,
+    }]);
+    expect(result.secrets["azure-openai"]).toEqual({ apiKey: "azure-key" });
+    vi.restoreAllMocks();
+  });
+});
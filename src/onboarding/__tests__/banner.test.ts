@@ -0,0 +1,63 @@
+/**
+ * Unit tests for onboarding/steps/banner.ts
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import { mkdirSync, mkdtempSync, rmSync, writeFileSync } from "node:fs";
+import { join } from "node:path";
+import { tmpdir } from "node:os";
+import { BANNER_ART } from "../shared.js";
+import { chooseBannerLines, readCustomBanner, usesNonLatinScript, TEXT_BANNER, BANNER_ASSET } from "../steps/banner.js";
+
+describe("banner", () => {
+  it("prints the wordmark in wide Latin-script terminals", () => {
+    expect(chooseBannerLines({ columns: 80, locale: "en-US" })).toEqual(BANNER_ART);
+    expect(chooseBannerLines({})).toEqual(BANNER_ART);
+  });
+
+  it("falls back to the text header in narrow terminals", () => {
+    expect(chooseBannerLines({ columns: 40, locale: "en-US" })).toEqual(TEXT_BANNER);
+  });
+
+  it("falls back to the text header for non-Latin locales", () => {
+    expect(chooseBannerLines({ columns: 120, locale: "zh-CN" })).toEqual(TEXT_BANNER);
+    expect(usesNonLatinScript("ru")).toBe(true);
+    expect(usesNonLatinScript("pt-BR")).toBe(false);
+    expect(usesNonLatinScript(undefined)).toBe(false);
+  });
+
+  it("prefers a custom banner", () => {
+    expect(chooseBannerLines({ custom: ["ACME Bot"], columns: 20, locale: "ja-JP" })).toEqual(["ACME Bot"]);
+  });
+
+  describe("custom banner files", () => {
+    let dir: string;
+
+    beforeEach(() => {
+      dir = mkdtempSync(join(tmpdir(), "owliabot-banner-"));
+    });
+
+    afterEach(() => {
+      rmSync(dir, { recursive: true, force: true });
+    });
+
+    it("reads the packaged asset", () => {
+      mkdirSync(join(dir, "branding"));
+      writeFileSync(join(dir, BANNER_ASSET), "ACME\nBot\n\n");
+      expect(readCustomBanner({}, dir)).toEqual(["ACME", "Bot"]);
+    });
+
+    it("lets OWLIABOT_BANNER_FILE override the asset", () => {
+      const file = join(dir, "mine.txt");
+      writeFileSync(file, "Mine\n");
+      expect(readCustomBanner({ OWLIABOT_BANNER_FILE: file }, dir)).toEqual(["Mine"]);
+    });
+
+    it("ignores missing and empty files", () => {
+      expect(readCustomBanner({}, dir)).toBeNull();
+      const empty = join(dir, "empty.txt");
+      writeFileSync(empty, "\n \n");
+      expect(readCustomBanner({ OWLIABOT_BANNER_FILE: empty }, dir)).toBeNull();
+    });
+  });
+});
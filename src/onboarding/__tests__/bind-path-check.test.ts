@@ -0,0 +1,141 @@
+/**
+ * Unit tests for onboarding/steps/bind-path-check.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { AbortError } from "../shared.js";
+import { checkDockerBindPath, confirmDockerBindPath, findMountFsType, hostBindPath } from "../steps/bind-path-check.js";
+
+const MOUNTS = [
+  "/dev/sda1 / ext4 rw,relatime 0 0",
+  "server:/export/home /home nfs4 rw,relatime 0 0",
+  "/dev/sdb1 /home/local ext4 rw,relatime 0 0",
+  "C:\\ /mnt/c 9p rw,relatime 0 0",
+].join("\n");
+
+describe("bind-path-check", () => {
+  describe("findMountFsType", () => {
+    it("picks the longest matching mount point", () => {
+      expect(findMountFsType("/home/alice/.owliabot", MOUNTS)).toBe("nfs4");
+      expect(findMountFsType("/home/local/.owliabot", MOUNTS)).toBe("ext4");
+      expect(findMountFsType("/srv/owliabot", MOUNTS)).toBe("ext4");
+    });
+
+    it("does not treat sibling prefixes as parents", () => {
+      expect(findMountFsType("/home/localother", MOUNTS)).toBe("nfs4");
+    });
+  });
+
+  describe("checkDockerBindPath", () => {
+    it("rejects relative paths", () => {
+      const result = checkDockerBindPath(".owliabot", { platform: "linux", mounts: MOUNTS });
+      expect(result.ok).toBe(false);
+      expect(result.problems[0]).toContain("relative path");
+    });
+
+    it("flags network filesystems on Linux", () => {
+      const result = checkDockerBindPath("/home/alice/.owliabot", { platform: "linux", mounts: MOUNTS });
+      expect(result.ok).toBe(false);
+      expect(result.problems[0]).toContain("network filesystem (nfs4)");
+    });
+
+    it("flags Windows drives under WSL", () => {
+      const result = checkDockerBindPath("/mnt/c/Users/alice/.owliabot", { platform: "linux", mounts: MOUNTS });
+      expect(result.ok).toBe(false);
+      expect(result.problems[0]).toContain("Windows drive");
+    });
+
+    it("accepts local disks", () => {
+      expect(checkDockerBindPath("/home/local/.owliabot", { platform: "linux", mounts: MOUNTS }).ok).toBe(true);
+    });
+
+    it("accepts Linux paths when /proc/mounts is unavailable", () => {
+      expect(checkDockerBindPath("/home/alice/.owliabot", { platform: "linux", mounts: null }).ok).toBe(true);
+    });
+
+    it("flags macOS paths outside Docker Desktop file sharing", () => {
+      expect(checkDockerBindPath("/Users/alice/.owliabot", { platform: "darwin" }).ok).toBe(true);
+      const result = checkDockerBindPath("/opt/owliabot", { platform: "darwin" });
+      expect(result.ok).toBe(false);
+      expect(result.suggestions[0]).toContain("File sharing");
+    });
+  });
+
+  describe("hostBindPath", () => {
+    it("checks the host's config dir, platform and filesystem when install.sh passed them in", () => {
+      const mac = hostBindPath("/home/owliabot/.owliabot", {
+        OWLIABOT_HOST_CONFIG_DIR: "/opt/owliabot/.owliabot",
+        OWLIABOT_HOST_PLATFORM: "Darwin",
+      });
+      expect(mac.configDir).toBe("/opt/owliabot/.owliabot");
+      expect(checkDockerBindPath(mac.configDir, mac.options).suggestions[0]).toContain("File sharing");
+
+      const nfs = hostBindPath("/home/owliabot/.owliabot", {
+        OWLIABOT_HOST_CONFIG_DIR: "/home/alice/.owliabot",
+        OWLIABOT_HOST_PLATFORM: "linux",
+        OWLIABOT_HOST_CONFIG_FS: "nfs4",
+      });
+      expect(checkDockerBindPath(nfs.configDir, nfs.options).problems[0]).toContain("network filesystem (nfs4)");
+
+      // Without the host's filesystem type, the container's own mounts say nothing
+      const noFs = hostBindPath("/home/owliabot/.owliabot", {
+        OWLIABOT_HOST_CONFIG_DIR: "/home/alice/.owliabot",
+        OWLIABOT_HOST_PLATFORM: "linux",
+      });
+      expect(checkDockerBindPath(noFs.configDir, { ...noFs.options, mounts: MOUNTS }).ok).toBe(true);
+    });
+
+    it("falls back to this machine without them", () => {
+      expect(hostBindPath("/home/alice/.owliabot", {})).toEqual({ configDir: "/home/alice/.owliabot", options: {} });
+    });
+  });
+
+  describe("confirmDockerBindPath", () => {
+    let consoleSpy: ReturnType<typeof vi.spyOn>;
+    let rl: ReturnType<typeof createInterface>;
+
+    beforeEach(() => {
+      consoleSpy = vi.spyOn(console, "log").mockImplementation(() => {});
+      answers = [];
+      rl = createInterface({ input: process.stdin, output: process.stdout });
+    });
+
+    afterEach(() => {
+      consoleSpy.mockRestore();
+    });
+
+    it("does not prompt when the path is fine", async () => {
+      await confirmDockerBindPath(rl, "/Users/alice/.owliabot", { platform: "darwin" });
+      expect(answers).toHaveLength(0);
+    });
+
+    it("aborts by default when the path is problematic", async () => {
+      answers.push("");
+      await expect(
+        confirmDockerBindPath(rl, "/opt/owliabot", { platform: "darwin" }),
+      ).rejects.toBeInstanceOf(AbortError);
+    });
+
+    it("continues when the user insists", async () => {
+      answers.push("y");
+      await expect(confirmDockerBindPath(rl, "/opt/owliabot", { platform: "darwin" })).resolves.toBeUndefined();
+    });
+  });
+});
@@ -0,0 +1,58 @@
+/**
+ * Unit tests for onboarding/steps/ca-bundle.ts
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import { mkdtempSync, mkdirSync, readFileSync, rmSync, writeFileSync } from "node:fs";
+import { join } from "node:path";
+import { tmpdir } from "node:os";
+import { assertCaBundle, caBundleLoaded, installCaBundle, resolveCaBundlePath } from "../steps/ca-bundle.js";
+
+const PEM = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n";
+
+describe("ca-bundle", () => {
+  let dir: string;
+
+  beforeEach(() => {
+    dir = mkdtempSync(join(tmpdir(), "owliabot-ca-"));
+    vi.spyOn(console, "log").mockImplementation(() => {});
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+    vi.restoreAllMocks();
+  });
+
+  it("prefers the flag over OWLIABOT_CA_BUNDLE and resolves it", () => {
+    expect(resolveCaBundlePath("/etc/corp.pem", { OWLIABOT_CA_BUNDLE: "/other.pem" })).toBe("/etc/corp.pem");
+    expect(resolveCaBundlePath(undefined, { OWLIABOT_CA_BUNDLE: "/other.pem" })).toBe("/other.pem");
+    expect(resolveCaBundlePath(undefined, {})).toBeUndefined();
+  });
+
+  it("rejects missing files and files without certificates", () => {
+    const empty = join(dir, "empty.pem");
+    writeFileSync(empty, "not a cert");
+    expect(() => assertCaBundle(join(dir, "missing.pem"))).toThrow(/does not exist/);
+    expect(() => assertCaBundle(empty)).toThrow(/no PEM certificate/);
+  });
+
+  it("knows when the bundle was loaded at startup", () => {
+    expect(caBundleLoaded("/etc/corp.pem", { NODE_EXTRA_CA_CERTS: "/etc/corp.pem" })).toBe(true);
+    expect(caBundleLoaded("/etc/corp.pem", { NODE_EXTRA_CA_CERTS: "/etc/other.pem" })).toBe(false);
+    expect(caBundleLoaded("/etc/corp.pem", {})).toBe(false);
+  });
+
+  it("copies the bundle into the config dir", () => {
+    const source = join(dir, "corp.pem");
+    const configDir = join(dir, "config");
+    mkdirSync(configDir);
+    writeFileSync(source, PEM);
+
+    const target = installCaBundle(configDir, source);
+
+    expect(target).toBe(join(configDir, "ca-bundle.pem"));
+    expect(readFileSync(target, "utf-8")).toBe(PEM);
+    // Running again against the copy itself leaves it alone.
+    expect(installCaBundle(configDir, target)).toBe(target);
+  });
+});
@@ -85,6 +85,17 @@ describe("channel-setup step", () => {
       expect(result.telegramToken).toBe("t-tok");
     });
 
+    it("selects Slack (choice 4) and checks the token prefixes", async () => {
+      answers = ["4", "xapp-1-wrong-slot", "xoxb-bot-token", "xapp-1-app-token"];
+      const secrets: any = {};
+      const result = await askChannels(rl, secrets);
+      expect(result.discordEnabled).toBe(false);
+      expect(result.telegramEnabled).toBe(false);
+      expect(result.slackEnabled).toBe(true);
+      expect(result.slackBotToken).toBe("xoxb-bot-token");
+      expect(secrets.slack).toEqual({ botToken: "xoxb-bot-token", appToken: "xapp-1-app-token" });
+    });
+
     it("skips token when empty", async () => {
       answers = ["1", ""];
       const secrets: any = {};
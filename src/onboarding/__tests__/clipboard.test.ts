@@ -0,0 +1,40 @@
+/**
+ * Unit tests for onboarding/steps/clipboard.ts
+ */
+
+import { describe, it, expect, vi } from "vitest";
+import { clipboardCommands, copyToClipboard, osc52Sequence } from "../steps/clipboard.js";
+
+describe("clipboard", () => {
+  it("picks the clipboard tools for the platform and display", () => {
+    expect(clipboardCommands("darwin", {}).map((c) => c.cmd)).toEqual(["pbcopy"]);
+    expect(clipboardCommands("linux", { WAYLAND_DISPLAY: "wayland-0", DISPLAY: ":0" }).map((c) => c.cmd))
+      .toEqual(["wl-copy", "xclip", "xsel"]);
+    expect(clipboardCommands("linux", {})).toEqual([]);
+  });
+
+  it("encodes OSC 52, wrapped for tmux", () => {
+    expect(osc52Sequence("tok", {})).toBe("\x1b]52;c;dG9r\x07");
+    expect(osc52Sequence("tok", { TMUX: "/tmp/tmux-1/default,1,0" })).toBe("\x1bPtmux;\x1b\x1b]52;c;dG9r\x07\x1b\\");
+  });
+
+  it("uses the first clipboard tool that works", () => {
+    const run = vi.fn((cmd: string) => cmd === "xclip");
+    const stdout = { write: vi.fn(), isTTY: true };
+    const method = copyToClipboard("secret", {
+      commands: [{ cmd: "wl-copy", args: [] }, { cmd: "xclip", args: ["-selection", "clipboard"] }],
+      run,
+      stdout,
+    });
+    expect(method).toEqual({ kind: "command", cmd: "xclip" });
+    expect(run).toHaveBeenLastCalledWith("xclip", ["-selection", "clipboard"], "secret");
+    expect(stdout.write).not.toHaveBeenCalled();
+  });
+
+  it("falls back to OSC 52 on a terminal, and to nothing without one", () => {
+    const stdout = { write: vi.fn(), isTTY: true };
+    expect(copyToClipboard("tok", { commands: [], stdout, env: {} })).toEqual({ kind: "osc52" });
+    expect(stdout.write).toHaveBeenCalledWith("\x1b]52;c;dG9r\x07");
+    expect(copyToClipboard("tok", { commands: [], stdout: { write: vi.fn(), isTTY: false } })).toBeNull();
+  });
+});
@@ -26,10 +26,13 @@ vi.mock("node:readline", () => ({
 
 vi.mock("../../auth/oauth.js", () => ({ startOAuthFlow: vi.fn() }));
 
+import { createInterface } from "node:readline";
 import {
   buildDefaultMemorySearchConfig,
   buildDefaultSystemConfig,
+  configureMcpServers,
 } from "../steps/config-building.js";
+import type { SecretsConfig } from "../secrets.js";
 
 describe("config-building step", () => {
   let consoleSpy: ReturnType<typeof vi.spyOn>;
@@ -91,6 +94,72 @@ describe("config-building step", () => {
     });
   });
 
+  // ── configureMcpServers ─────────────────────────────────────────────────
+
+  describe("configureMcpServers", () => {
+    it("enables Playwright on Enter", async () => {
+      const rl = createInterface({ input: process.stdin, output: process.stdout });
+      answers = [""];
+      expect(await configureMcpServers(rl, {})).toEqual({ presets: ["playwright"] });
+    });
+
+    it("returns nothing for none", async () => {
+      const rl = createInterface({ input: process.stdin, output: process.stdout });
+      answers = ["none"];
+      expect(await configureMcpServers(rl, {})).toBeUndefined();
+    });
+
+    it("asks for a GitHub token when the github preset is picked", async () => {
+      const rl = createInterface({ input: process.stdin, output: process.stdout });
+      const secrets: SecretsConfig = {};
+      answers = ["3,6", "ghp_0123456789abcdef"];
+
+      expect(await configureMcpServers(rl, secrets)).toEqual({ presets: ["github", "memory"] });
+      expect(secrets.github).toEqual({ token: "ghp_0123456789abcdef" });
+      expect(promptLog.some((q) => q.includes("GitHub personal access token"))).toBe(true);
+    });
+
+    it("defines a custom stdio server alongside presets", async () => {
+      const rl = createInterface({ input: process.stdin, output: process.stdout });
+      answers = [
+        "1,7",                                          // playwright + custom
+        "notion",                                       // name
+        "1",                                            // stdio
+        "npx",                                          // command
+        "--yes \"@notionhq/notion-mcp-server\"",        // args
+        "NOTION_TOKEN=${NOTION_TOKEN}",                 // env
+        "",                                             // add another: default no
+      ];
+
+      expect(await configureMcpServers(rl, {})).toEqual({
+        presets: ["playwright"],
+        servers: [{
+          name: "notion",
+          transport: "stdio",
+          command: "npx",
+          args: ["--yes", "@notionhq/notion-mcp-server"],
+          env: { NOTION_TOKEN: "${NOTION_TOKEN}" },
+        }],
+      });
+    });
+
+    it("keeps custom servers from app.yaml on re-run", async () => {
+      const rl = createInterface({ input: process.stdin, output: process.stdout });
+      const existing = [{ name: "remote", transport: "sse" as const, url: "http://localhost:9000/sse" }];
+      answers = ["", "none"];                           // keep: default yes; no presets
+
+      expect(await configureMcpServers(rl, {}, existing)).toEqual({ servers: existing });
+    });
+
+    it("drops custom servers from app.yaml when declined", async () => {
+      const rl = createInterface({ input: process.stdin, output: process.stdout });
+      const existing = [{ name: "remote", transport: "sse" as const, url: "http://localhost:9000/sse" }];
+      answers = ["n", ""];
+
+      expect(await configureMcpServers(rl, {}, existing)).toEqual({ presets: ["playwright"] });
+    });
+  });
+
   // ── buildAppConfigFromPrompts ───────────────────────────────────────────
   // These tests require mocking multiple sub-step modules which makes them
   // complex integration tests. We test the two pure functions above thoroughly
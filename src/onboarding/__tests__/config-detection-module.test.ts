@@ -70,15 +70,17 @@ describe("config-detection", () => {
     expect(result?.telegramToken).toBe("123456:ABC-DEF");
   });
 
-  it("should detect gateway token from secrets.yaml", async () => {
+  it("should detect gateway token and basic auth password from secrets.yaml", async () => {
     const secretsPath = join(testDir, "secrets.yaml");
     writeFileSync(secretsPath, `gateway:
-  token: gateway-token-abc123`);
+  token: gateway-token-abc123
+  basicAuthPassword: basic-pw`);
     
     const result = await detectExistingConfig(false, appConfigPath);
     
     expect(result).not.toBeNull();
     expect(result?.gatewayToken).toBe("gateway-token-abc123");
+    expect(result?.gatewayBasicAuthPassword).toBe("basic-pw");
   });
 
   it("should detect telegram allowList from app.yaml", async () => {
@@ -167,4 +169,26 @@ telegram:
     expect(result?.telegramAllowList).toEqual(["12345"]);
     expect(result?.telegramGroups).toBeDefined();
   });
+
+  it("should detect an Azure OpenAI provider from secrets.yaml and app.yaml", async () => {
+    writeFileSync(join(testDir, "secrets.yaml"), `azure-openai:
+  apiKey: azure-key`);
+    writeFileSync(appConfigPath, `workspace: workspace
+providers:
+  - id: azure-openai
+    model: gpt-4o-prod
+    baseUrl: https://res.openai.azure.com
+    apiVersion: "2024-10-21"
+    apiKey: secrets
+    priority: 1`);
+
+    const result = await detectExistingConfig(false, appConfigPath);
+
+    expect(result?.azureOpenai).toEqual({
+      apiKey: "azure-key",
+      endpoint: "https://res.openai.azure.com",
+      deployment: "gpt-4o-prod",
+      apiVersion: "2024-10-21",
+    });
+  });
 });
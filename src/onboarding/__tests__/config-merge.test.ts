@@ -0,0 +1,68 @@
+/**
+ * Unit tests for onboarding/steps/config-merge.ts
+ */
+
+import { describe, it, expect } from "vitest";
+import { parse } from "yaml";
+import { mergeAppConfigYaml } from "../steps/config-merge.js";
+import type { AppConfig } from "../types.js";
+
+const existing = [
+  "# My bot",
+  "workspace: ./workspace",
+  "timezone: UTC # keep in sync with the host",
+  "providers:",
+  "  - id: anthropic",
+  "    model: claude-sonnet-4-5",
+  "    apiKey: secrets",
+  "    priority: 1",
+  "telegram:",
+  "  allowList:",
+  '    - "1"',
+  "gateway:",
+  "  http:",
+  "    host: 0.0.0.0",
+  "    port: 8787",
+  "    rateLimit: 30 # hand-tuned",
+  "customSection:",
+  "  enabled: true",
+  "",
+].join("\n");
+
+const config: AppConfig = {
+  workspace: "./workspace",
+  timezone: "Europe/Berlin",
+  providers: [{ id: "openai", model: "gpt-4o", apiKey: "secrets", priority: 1 }],
+  gateway: { http: { host: "0.0.0.0", port: 9000 } },
+};
+
+describe("mergeAppConfigYaml", () => {
+  it("keeps unknown keys and comments while updating wizard values", () => {
+    const merged = mergeAppConfigYaml(existing, config)!;
+
+    expect(merged).toContain("# My bot");
+    expect(merged).toContain("timezone: Europe/Berlin # keep in sync with the host");
+    expect(merged).toContain("rateLimit: 30 # hand-tuned");
+
+    const parsed = parse(merged);
+    expect(parsed.customSection).toEqual({ enabled: true });
+    expect(parsed.gateway.http).toEqual({ host: "0.0.0.0", port: 9000, rateLimit: 30 });
+    expect(parsed.providers).toEqual(config.providers);
+  });
+
+  it("drops wizard-owned keys the new config no longer has", () => {
+    const parsed = parse(mergeAppConfigYaml(existing, config)!);
+    expect(parsed.telegram).toBeUndefined();
+  });
+
+  it("adds new sections", () => {
+    const parsed = parse(mergeAppConfigYaml(existing, { ...config, tools: { allowWrite: true } })!);
+    expect(parsed.tools).toEqual({ allowWrite: true });
+  });
+
+  it("returns null when the existing file can't be merged into", () => {
+    expect(mergeAppConfigYaml("", config)).toBeNull();
+    expect(mergeAppConfigYaml("- a list\n", config)).toBeNull();
+    expect(mergeAppConfigYaml("key: [unclosed\n", config)).toBeNull();
+  });
+});
@@ -51,12 +51,12 @@ describe("configureTelegramConfig step", () => {
   });
 
   it("configures telegram with user allowlist", async () => {
-    answers = ["user1,user2"];
+    answers = ["111,222"];
     const config: any = {};
     const userAllowLists: any = { discord: [], telegram: [] };
     await configureTelegramConfig(rl, config, userAllowLists);
     expect(config.telegram).toBeDefined();
-    expect(userAllowLists.telegram).toEqual(["user1", "user2"]);
+    expect(userAllowLists.telegram).toEqual(["111", "222"]);
   });
 
   it("sets allowList on config when user IDs provided", async () => {
@@ -78,10 +78,19 @@ describe("configureTelegramConfig step", () => {
   });
 
   it("trims whitespace from user IDs", async () => {
-    answers = [" user1 , user2 "];
+    answers = [" 111 , 222 "];
     const config: any = {};
     const userAllowLists: any = { discord: [], telegram: [] };
     await configureTelegramConfig(rl, config, userAllowLists);
-    expect(userAllowLists.telegram).toEqual(["user1", "user2"]);
+    expect(userAllowLists.telegram).toEqual(["111", "222"]);
+  });
+
+  it("asks again when an entry is not a numeric user ID", async () => {
+    answers = ["111,@alice", "111,222"];
+    const config: any = {};
+    const userAllowLists: any = { discord: [], telegram: [] };
+    await configureTelegramConfig(rl, config, userAllowLists);
+    expect(config.telegram.allowList).toEqual(["111", "222"]);
+    expect(answers).toEqual([]);
   });
 });
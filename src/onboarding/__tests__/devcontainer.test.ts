@@ -0,0 +1,27 @@
+/**
+ * Unit tests for onboarding/steps/devcontainer.ts
+ */
+
+import { describe, it, expect } from "vitest";
+import { buildDevcontainerJson } from "../steps/devcontainer.js";
+
+describe("devcontainer step", () => {
+  it("runs the image's own command with the config dir mounted", () => {
+    const json = JSON.parse(
+      buildDevcontainerJson("~/.owliabot", ["TZ=UTC", "DISCORD_BOT_TOKEN=${DISCORD_BOT_TOKEN}"], "8787", "img:1"),
+    );
+    expect(json.image).toBe("img:1");
+    expect(json.overrideCommand).toBe(false);
+    expect(json.appPort).toEqual(["127.0.0.1:8787:8787"]);
+    expect(json.mounts[0]).toBe("source=${localEnv:HOME}/.owliabot,target=/home/owliabot/.owliabot,type=bind");
+    expect(json.containerEnv).toEqual({ TZ: "UTC", DISCORD_BOT_TOKEN: "${localEnv:DISCORD_BOT_TOKEN}" });
+  });
+
+  it("mounts the secrets key read-only when secrets.yaml is encrypted", () => {
+    const json = JSON.parse(buildDevcontainerJson("~/.owliabot", [], "8787", "img", { secretsKey: true }));
+    expect(json.mounts).toContain(
+      "source=${localEnv:HOME}/.owliabot/auth/secrets.agekey,target=/run/secrets/owliabot-secrets.agekey,type=bind,readonly",
+    );
+    expect(json.containerEnv.OWLIABOT_SECRETS_KEY_FILE).toBe("/run/secrets/owliabot-secrets.agekey");
+  });
+});
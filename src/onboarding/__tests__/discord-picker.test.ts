@@ -0,0 +1,112 @@
+/**
+ * Unit tests for onboarding/steps/discord-picker.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import os from "node:os";
+import path from "node:path";
+import { mkdtemp, readFile, rm } from "node:fs/promises";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { ValidationClient } from "../steps/validation-client.js";
+import {
+  fetchDiscordGuilds,
+  listDiscordGuilds,
+  pickDiscordChannels,
+  DISCORD_CHANNEL_CACHE_FILE,
+  type DiscordGuildListing,
+} from "../steps/discord-picker.js";
+
+function json(status: number, body: unknown): Response {
+  return new Response(JSON.stringify(body), { status, headers: { "content-type": "application/json" } });
+}
+
+const LISTING: DiscordGuildListing = {
+  kind: "ok",
+  guilds: [
+    { id: "1", name: "Owls", channels: [{ id: "11", name: "general" }, { id: "12", name: "bots" }] },
+    { id: "2", name: "Lab", channels: [{ id: "21", name: "alerts" }] },
+  ],
+};
+
+describe("discord channel picker", () => {
+  let dir: string;
+
+  beforeEach(async () => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+    dir = await mkdtemp(path.join(os.tmpdir(), "owliabot-discord-picker-"));
+  });
+
+  afterEach(async () => {
+    await rm(dir, { recursive: true, force: true });
+    vi.restoreAllMocks();
+  });
+
+  it("lists message channels of every guild in position order", async () => {
+    const fetchImpl = vi.fn(async (url: string) => {
+      const p = new URL(url).pathname.replace("/api/v10", "");
+      if (p === "/users/@me/guilds") return json(200, [{ id: "1", name: "Owls" }]);
+      if (p === "/guilds/1/channels") {
+        return json(200, [
+          { id: "12", name: "bots", type: 0, position: 2 },
+          { id: "13", name: "Voice", type: 2, position: 0 },
+          { id: "11", name: "general", type: 0, position: 1 },
+        ]);
+      }
+      throw new Error(`unexpected ${url}`);
+    });
+    const client = new ValidationClient({ fetchImpl: fetchImpl as unknown as typeof fetch, retries: 0 });
+
+    expect(await fetchDiscordGuilds("tok", client)).toEqual({
+      kind: "ok",
+      guilds: [{ id: "1", name: "Owls", channels: [{ id: "11", name: "general" }, { id: "12", name: "bots" }] }],
+    });
+  });
+
+  it("caches a listing per token without storing the token", async () => {
+    const fetchGuilds = vi.fn(async () => LISTING);
+
+    expect(await listDiscordGuilds("tok", dir, fetchGuilds)).toEqual(LISTING);
+    expect(await listDiscordGuilds("tok", dir, fetchGuilds)).toEqual(LISTING);
+    expect(fetchGuilds).toHaveBeenCalledTimes(1);
+
+    await listDiscordGuilds("other-token", dir, fetchGuilds);
+    expect(fetchGuilds).toHaveBeenCalledTimes(2);
+
+    const cache = await readFile(path.join(dir, DISCORD_CHANNEL_CACHE_FILE), "utf-8");
+    expect(cache).not.toContain("other-token");
+  });
+
+  it("turns the picked names into channel IDs", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["1,3"];
+    expect(await pickDiscordChannels(rl, "tok", dir, async () => LISTING)).toEqual(["11", "21"]);
+
+    answers = ["9", "2-3"];
+    expect(await pickDiscordChannels(rl, "tok", dir, async () => LISTING)).toEqual(["12", "21"]);
+  });
+
+  it("returns null when the API can't be used", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    expect(await pickDiscordChannels(rl, "tok", dir, async () => ({ kind: "skipped", reason: "offline" }))).toBeNull();
+    expect(await pickDiscordChannels(rl, "tok", dir, async () => ({ kind: "ok", guilds: [] }))).toBeNull();
+  });
+});
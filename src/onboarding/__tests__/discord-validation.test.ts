@@ -0,0 +1,102 @@
+/**
+ * Unit tests for onboarding/steps/discord-validation.ts
+ */
+
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { ValidationClient } from "../steps/validation-client.js";
+import { checkDiscordToken, promptValidDiscordToken, type DiscordTokenCheck } from "../steps/discord-validation.js";
+
+function clientFor(routes: Record<string, Response>) {
+  const fetchImpl = vi.fn(async (url: string) => {
+    const path = new URL(url).pathname.replace("/api/v10", "");
+    const res = routes[path];
+    if (!res) throw new Error(`unexpected ${url}`);
+    return res;
+  });
+  return { client: new ValidationClient({ fetchImpl: fetchImpl as unknown as typeof fetch, retries: 0 }), fetchImpl };
+}
+
+function json(status: number, body: unknown): Response {
+  return new Response(JSON.stringify(body), { status, headers: { "content-type": "application/json" } });
+}
+
+describe("checkDiscordToken", () => {
+  it("returns the bot name and intent state", async () => {
+    const { client, fetchImpl } = clientFor({
+      "/users/@me": json(200, { username: "owlia", discriminator: "0" }),
+      "/applications/@me": json(200, { flags: 1 << 19 }),
+    });
+    const result = await checkDiscordToken("tok", client);
+    expect(result).toEqual({ kind: "valid", username: "owlia", messageContentIntent: true });
+    expect(fetchImpl.mock.calls[0][1]).toMatchObject({ headers: { Authorization: "Bot tok" } });
+  });
+
+  it("flags a missing Message Content Intent", async () => {
+    const { client } = clientFor({
+      "/users/@me": json(200, { username: "owlia", discriminator: "1234" }),
+      "/applications/@me": json(200, { flags: 0 }),
+    });
+    expect(await checkDiscordToken("tok", client)).toEqual({
+      kind: "valid",
+      username: "owlia#1234",
+      messageContentIntent: false,
+    });
+  });
+
+  it("reports 401 as an invalid token", async () => {
+    const { client } = clientFor({ "/users/@me": json(401, { message: "401: Unauthorized" }) });
+    const result = await checkDiscordToken("bad", client);
+    expect(result.kind).toBe("invalid");
+  });
+});
+
+describe("promptValidDiscordToken", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  it("re-prompts until the token is accepted", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["good"];
+    const check = vi.fn(async (t: string): Promise<DiscordTokenCheck> =>
+      t === "good"
+        ? { kind: "valid", username: "owlia", messageContentIntent: true }
+        : { kind: "invalid", message: "Discord rejected the token (401 Unauthorized)" },
+    );
+    expect(await promptValidDiscordToken(rl, "typo", check)).toBe("good");
+    expect(check).toHaveBeenCalledTimes(2);
+  });
+
+  it("drops the token when the user gives up", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = [""];
+    const check = vi.fn(async (): Promise<DiscordTokenCheck> => ({ kind: "invalid", message: "nope" }));
+    expect(await promptValidDiscordToken(rl, "typo", check)).toBe("");
+  });
+
+  it("keeps the token when the check is skipped", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    const check = vi.fn(async (): Promise<DiscordTokenCheck> => ({ kind: "skipped", reason: "timed out after 8s" }));
+    expect(await promptValidDiscordToken(rl, "tok", check)).toBe("tok");
+  });
+});
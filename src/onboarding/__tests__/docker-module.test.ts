@@ -3,7 +3,18 @@
  */
 
 import { describe, it, expect } from "vitest";
-import { buildDockerEnvLines, buildDockerComposeYaml, initDockerPaths } from "../steps/docker.js";
+import { parse as parseYaml } from "yaml";
+import {
+  buildDockerEnvLines,
+  buildDockerComposeYaml,
+  composeAddOns,
+  composeUpCommand,
+  dockerComposePath,
+  initDockerPaths,
+  parseImagePlatform,
+  promptAutoUpdate,
+  renderComposeServices,
+} from "../steps/docker.js";
 import type { AppConfig } from "../types.js";
 import type { SecretsConfig } from "../secrets.js";
 
@@ -23,6 +34,13 @@ describe("docker", () => {
       
       expect(paths.outputDir).toBe("/custom/output");
     });
+
+    it("should put a profile's compose file next to the default one", () => {
+      const paths = { configDir: "/h/.owliabot-work", dockerConfigPath: "~/.owliabot-work", shellConfigPath: "~/.owliabot-work", outputDir: "/out" };
+
+      expect(dockerComposePath(paths)).toBe("/out/docker-compose.yml");
+      expect(dockerComposePath({ ...paths, composeFile: "docker-compose.work.yml" })).toBe("/out/docker-compose.work.yml");
+    });
   });
 
   describe("buildDockerEnvLines", () => {
@@ -139,6 +157,19 @@ describe("docker", () => {
       const anthropicLine = result.find(line => line.includes("ANTHROPIC_API_KEY"));
       expect(anthropicLine).toBeUndefined();
     });
+
+    it("passes AWS credentials through for Bedrock with env credentials only", () => {
+      const bedrock = (apiKey: string): AppConfig => ({
+        workspace: "workspace",
+        providers: [{ id: "amazon-bedrock", model: "m", region: "us-east-1", apiKey, priority: 1 } as any],
+      });
+
+      expect(buildDockerEnvLines(bedrock("env"), {}, "UTC")).toEqual(expect.arrayContaining([
+        "AWS_ACCESS_KEY_ID=${AWS_ACCESS_KEY_ID}",
+        "AWS_SECRET_ACCESS_KEY=${AWS_SECRET_ACCESS_KEY}",
+      ]));
+      expect(buildDockerEnvLines(bedrock("none"), {}, "UTC").some((l) => l.startsWith("AWS_"))).toBe(false);
+    });
   });
 
   describe("buildDockerComposeYaml", () => {
@@ -195,6 +226,18 @@ describe("docker", () => {
       expect(yaml).toContain("${OWLIABOT_IMAGE:-my-custom-image:v1.0}");
     });
 
+    it("passes the image reference into the container for the image history", () => {
+      const yaml = buildDockerComposeYaml("~/.owliabot", ["TZ=UTC"], "8787", "my-custom-image:v1.0");
+
+      expect(yaml).toContain("- OWLIABOT_IMAGE_REF=${OWLIABOT_IMAGE:-my-custom-image:v1.0}");
+    });
+
+    it("passes OWLIABOT_SAFE_MODE through from the host", () => {
+      const yaml = buildDockerComposeYaml("~/.owliabot", ["TZ=UTC"], "8787", "img");
+
+      expect(yaml).toContain("- OWLIABOT_SAFE_MODE=${OWLIABOT_SAFE_MODE:-}");
+    });
+
     it("should include healthcheck configuration", () => {
       const yaml = buildDockerComposeYaml(
         "~/.owliabot",
@@ -222,5 +265,114 @@ describe("docker", () => {
       expect(yaml).toContain("~/.owliabot:/home/owliabot/.owliabot");
       expect(yaml).toContain("~/.owliabot/workspace:/app/workspace");
     });
+
+    it("should mount the secrets key read-only when secrets are encrypted", () => {
+      const yaml = buildDockerComposeYaml("~/.owliabot", ["TZ=UTC"], "8787", "test:latest", { secretsKey: true });
+
+      expect(yaml).toContain("~/.owliabot/auth/secrets.agekey:/run/secrets/owliabot-secrets.agekey:ro");
+      expect(yaml).toContain("- OWLIABOT_SECRETS_KEY_FILE=/run/secrets/owliabot-secrets.agekey");
+      expect(yaml).toContain("- TZ=UTC");
+    });
+
+    it("should mount the CA bundle read-only and point Node at it", () => {
+      const yaml = buildDockerComposeYaml("~/.owliabot", ["TZ=UTC"], "8787", "test:latest", { caBundle: true });
+
+      expect(yaml).toContain("- ~/.owliabot/ca-bundle.pem:/etc/owliabot/ca-bundle.pem:ro");
+      expect(yaml).toContain("- NODE_EXTRA_CA_CERTS=/etc/owliabot/ca-bundle.pem");
+    });
+
+    it("should not add optional services without profiles", () => {
+      const yaml = buildDockerComposeYaml("~/.owliabot", ["TZ=UTC"], "8787", "test:latest");
+
+      expect(yaml).not.toContain("profiles:");
+      expect(Object.keys(parseYaml(yaml).services)).toEqual(["owliabot"]);
+    });
+
+    it("should put optional services in profiles", () => {
+      const options = {
+        profiles: true,
+        oidcProxy: true,
+        tunnel: { provider: "cloudflared" as const, token: "t" },
+      };
+      const yaml = buildDockerComposeYaml("~/.owliabot", ["TZ=UTC"], "8787", "test:latest", options);
+      const services = parseYaml(yaml).services;
+
+      expect(services.owliabot.profiles).toBeUndefined();
+      expect(services["oauth2-proxy"].profiles).toEqual(["proxy"]);
+      expect(services.tunnel.profiles).toEqual(["tunnel"]);
+      expect(services.ollama.profiles).toEqual(["ollama"]);
+      expect(services.watchtower.profiles).toEqual(["watchtower"]);
+      expect(services.watchtower.command).toEqual(["--cleanup", "owliabot"]);
+      expect(yaml).toContain("# Start with: docker compose --profile tunnel --profile proxy up -d");
+    });
+
+    it("should name the project and sidecars after a profile's container", () => {
+      const options = { profiles: true, containerName: "owliabot-work", projectName: "owliabot-work" };
+      const yaml = buildDockerComposeYaml("~/.owliabot-work", ["TZ=UTC"], "8788", "test:latest", options);
+      const parsed = parseYaml(yaml);
+
+      expect(parsed.name).toBe("owliabot-work");
+      expect(parsed.services.owliabot.container_name).toBe("owliabot-work");
+      expect(parsed.services.owliabot.ports).toContain("127.0.0.1:8788:8787");
+      expect(parsed.services.watchtower.command).toEqual(["--cleanup", "owliabot-work"]);
+      expect(yaml).not.toMatch(/container_name: owliabot-(?!work)/);
+    });
+
+    it("should add Watchtower scoped to the bot container without profiles", () => {
+      const yaml = buildDockerComposeYaml("~/.owliabot", ["TZ=UTC"], "8787", "test:latest", { watchtower: true });
+      const services = parseYaml(yaml).services;
+
+      expect(Object.keys(services)).toEqual(["owliabot", "watchtower"]);
+      expect(services.watchtower.profiles).toBeUndefined();
+      expect(services.watchtower.command).toEqual(["--cleanup", "owliabot"]);
+      expect(services.watchtower.volumes).toEqual(["/var/run/docker.sock:/var/run/docker.sock"]);
+      expect(yaml).toContain("# watchtower pulls new images and restarts the bot");
+    });
+
+    it("should compose add-ons one by one", () => {
+      const names = (options: Parameters<typeof composeAddOns>[2]) =>
+        composeAddOns("~/.owliabot", "8787", options).map((s) => s.name);
+
+      expect(names({})).toEqual([]);
+      expect(names({ watchtower: true })).toEqual(["watchtower"]);
+      expect(names({ oidcProxy: true, profiles: true })).toEqual(["oauth2-proxy", "ollama", "watchtower"]);
+      expect(renderComposeServices(composeAddOns("~/.owliabot", "8787", { watchtower: true }), { profiles: true }))
+        .toContain('profiles: ["watchtower"]');
+    });
+
+    it("should start Watchtower with its profile when it was chosen", () => {
+      expect(composeUpCommand({ profiles: true, watchtower: true })).toBe("docker compose --profile watchtower up -d");
+    });
+
+    it("should only ask about auto-update interactively", async () => {
+      await expect(promptAutoUpdate({} as never, false)).resolves.toBe(false);
+    });
+
+    it("should pin the bot's platform when asked", () => {
+      const services = parseYaml(
+        buildDockerComposeYaml("~/.owliabot", ["TZ=UTC"], "8787", "test:latest", { platform: "linux/amd64", watchtower: true }),
+      ).services;
+
+      expect(services.owliabot.platform).toBe("linux/amd64");
+      expect(services.watchtower.platform).toBeUndefined();
+      expect(parseYaml(buildDockerComposeYaml("~/.owliabot", [], "8787", "test:latest")).services.owliabot.platform)
+        .toBeUndefined();
+      expect(parseImagePlatform(" Linux/ARM/v7 ")).toBe("linux/arm/v7");
+      expect(parseImagePlatform("")).toBeUndefined();
+      expect(() => parseImagePlatform("arm64")).toThrow(/os\/arch/);
+    });
+
+    it("should say at the top when the config is a demo", () => {
+      const yaml = buildDockerComposeYaml("~/.owliabot", ["TZ=UTC"], "8787", "test:latest", { demo: true });
+      expect(yaml.split("\n").slice(0, 5).join("\n")).toContain("# DEMO MODE:");
+      expect(buildDockerComposeYaml("~/.owliabot", ["TZ=UTC"], "8787", "test:latest")).not.toContain("DEMO MODE");
+    });
+
+    it("should start the configured sidecars with their profiles", () => {
+      expect(composeUpCommand({})).toBe("docker compose up -d");
+      expect(composeUpCommand({ tunnel: { provider: "ngrok", token: "t" } })).toBe("docker compose up -d");
+      expect(composeUpCommand({ profiles: true, tunnel: { provider: "ngrok", token: "t" } }))
+        .toBe("docker compose --profile tunnel up -d");
+    });
   });
 });
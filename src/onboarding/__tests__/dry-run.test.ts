@@ -0,0 +1,193 @@
+/**
+ * Unit tests for onboarding/steps/dry-run.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import { mkdtemp, rm, writeFile } from "node:fs/promises";
+import type { createInterface } from "node:readline";
+import { tmpdir } from "node:os";
+import { join } from "node:path";
+import {
+  maskSecretValue,
+  maskSecrets,
+  renderDevFiles,
+  renderDockerFiles,
+  formatLineDiff,
+  printDryRunPreview,
+  diffExistingFiles,
+  confirmOverwrites,
+} from "../steps/dry-run.js";
+import { AbortError } from "../shared.js";
+
+function answering(answer: string): ReturnType<typeof createInterface> {
+  return {
+    question: (_q: string, cb: (ans: string) => void) => cb(answer),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  } as unknown as ReturnType<typeof createInterface>;
+}
+
+function stripAnsi(text: string): string {
+  return text.replace(/\x1b\[[0-9;]*m/g, "");
+}
+
+describe("dry-run", () => {
+  describe("maskSecrets", () => {
+    it("keeps a short prefix of long values", () => {
+      expect(maskSecretValue("sk-ant-api03-abcdef")).toBe("sk-a****");
+    });
+
+    it("fully masks short values", () => {
+      expect(maskSecretValue("abc")).toBe("****");
+    });
+
+    it("masks nested string values without mutating the input", () => {
+      const secrets = { discord: { token: "discord-token-123" }, gateway: { token: "gw" } };
+      const masked = maskSecrets(secrets);
+      expect(masked).toEqual({ discord: { token: "disc****" }, gateway: { token: "****" } });
+      expect(secrets.discord.token).toBe("discord-token-123");
+    });
+  });
+
+  describe("renderDevFiles", () => {
+    it("renders app.yaml with timezone comment and secrets.yaml", () => {
+      const files = renderDevFiles(
+        { workspace: "/w", providers: [], timezone: "UTC" },
+        { anthropic: { apiKey: "sk-ant-api03-xxxxxxxx" } },
+        "/cfg/app.yaml",
+      );
+      expect(files.map((f) => f.path)).toEqual(["/cfg/app.yaml", "/cfg/secrets.yaml"]);
+      expect(files[0].content).toContain("# Timezone was auto-detected");
+      expect(files[1].secret).toBe(true);
+    });
+
+    it("omits secrets.yaml when there are no secrets", () => {
+      const files = renderDevFiles({ workspace: "/w", providers: [] }, {}, "/cfg/app.yaml");
+      expect(files).toHaveLength(1);
+    });
+  });
+
+  describe("renderDockerFiles", () => {
+    it("includes docker-compose.yml in the output dir", () => {
+      const paths = { configDir: "/home/u/.owliabot", dockerConfigPath: "~/.owliabot", shellConfigPath: "~/.owliabot", outputDir: "/out" };
+      const files = renderDockerFiles(paths, { workspace: "/app/workspace", providers: [] }, {}, ["TZ=UTC"], "8787", "img:latest");
+      expect(files.map((f) => f.path)).toEqual(["/home/u/.owliabot/app.yaml", "/out/docker-compose.yml"]);
+      expect(files[1].content).toContain('"127.0.0.1:8787:8787"');
+    });
+  });
+
+  describe("formatLineDiff", () => {
+    it("marks added and removed lines", () => {
+      const diff = formatLineDiff("a\nb\nc", "a\nB\nc");
+      expect(diff).toEqual(["  a", "- b", "+ B", "  c"]);
+    });
+
+    it("collapses long unchanged runs", () => {
+      const before = ["x", "1", "2", "3", "4", "5", "6", "7", "8", "y"].join("\n");
+      const after = ["X", "1", "2", "3", "4", "5", "6", "7", "8", "Y"].join("\n");
+      const diff = formatLineDiff(before, after, 2);
+      expect(diff).toEqual(["- x", "+ X", "  1", "  2", "  …", "  7", "  8", "- y", "+ Y"]);
+    });
+  });
+
+  describe("printDryRunPreview", () => {
+    let dir: string;
+    let output: string[];
+
+    beforeEach(async () => {
+      dir = await mkdtemp(join(tmpdir(), "owliabot-dry-run-"));
+      output = [];
+      vi.spyOn(console, "log").mockImplementation((msg?: unknown) => {
+        output.push(stripAnsi(String(msg ?? "")));
+      });
+    });
+
+    afterEach(async () => {
+      vi.restoreAllMocks();
+      await rm(dir, { recursive: true, force: true });
+    });
+
+    it("prints new files in full", () => {
+      printDryRunPreview([{ path: join(dir, "app.yaml"), content: "workspace: /w\n" }]);
+      expect(output.join("\n")).toContain("New file");
+      expect(output).toContain("workspace: /w");
+    });
+
+    it("prints a diff against existing files", async () => {
+      const path = join(dir, "app.yaml");
+      await writeFile(path, "workspace: /old\n", "utf-8");
+      printDryRunPreview([{ path, content: "workspace: /new\n" }]);
+      expect(output).toContain("- workspace: /old");
+      expect(output).toContain("+ workspace: /new");
+    });
+
+    it("never prints raw secret values", async () => {
+      const path = join(dir, "secrets.yaml");
+      await writeFile(path, "discord:\n  token: old-discord-token\n", "utf-8");
+      printDryRunPreview([{ path, content: "discord:\n  token: new-discord-token\n", secret: true }]);
+      const text = output.join("\n");
+      expect(text).not.toContain("old-discord-token");
+      expect(text).not.toContain("new-discord-token");
+      expect(output).toContain("-   token: old-****");
+      expect(output).toContain("+   token: new-****");
+    });
+  });
+
+  describe("confirmOverwrites", () => {
+    let dir: string;
+    let output: string[];
+
+    beforeEach(async () => {
+      dir = await mkdtemp(join(tmpdir(), "owliabot-overwrite-"));
+      output = [];
+      vi.spyOn(console, "log").mockImplementation((msg?: unknown) => {
+        output.push(stripAnsi(String(msg ?? "")));
+      });
+    });
+
+    afterEach(async () => {
+      vi.restoreAllMocks();
+      await rm(dir, { recursive: true, force: true });
+    });
+
+    it("only lists existing files that change", async () => {
+      const changed = join(dir, "app.yaml");
+      const same = join(dir, "docker-compose.yml");
+      await writeFile(changed, "workspace: /old\n", "utf-8");
+      await writeFile(same, "services: {}\n", "utf-8");
+
+      const diffs = diffExistingFiles([
+        { path: changed, content: "workspace: /new\n" },
+        { path: same, content: "services: {}\n" },
+        { path: join(dir, "new.yaml"), content: "x: 1\n" },
+        { path: changed, content: "masked: ****\n", previewOnly: true },
+      ]);
+      expect(diffs).toEqual([{ path: changed, lines: ["- workspace: /old", "+ workspace: /new"] }]);
+    });
+
+    it("does not compare an encrypted secrets.yaml", async () => {
+      const path = join(dir, "secrets.yaml");
+      await writeFile(path, "-----BEGIN AGE ENCRYPTED FILE-----\nabc\n-----END AGE ENCRYPTED FILE-----\n", "utf-8");
+      expect(diffExistingFiles([{ path, content: "discord:\n  token: t\n", secret: true }])).toEqual([]);
+    });
+
+    it("shows the diff and continues when confirmed", async () => {
+      const path = join(dir, "app.yaml");
+      await writeFile(path, "workspace: /old\n", "utf-8");
+      await confirmOverwrites(answering(""), [{ path, content: "workspace: /new\n" }]);
+      expect(output).toContain("+ workspace: /new");
+    });
+
+    it("aborts when declined", async () => {
+      const path = join(dir, "app.yaml");
+      await writeFile(path, "workspace: /old\n", "utf-8");
+      await expect(confirmOverwrites(answering("n"), [{ path, content: "workspace: /new\n" }]))
+        .rejects.toBeInstanceOf(AbortError);
+    });
+
+    it("asks nothing when no existing file changes", async () => {
+      await confirmOverwrites(answering("n"), [{ path: join(dir, "app.yaml"), content: "x: 1\n" }]);
+      expect(output).toEqual([]);
+    });
+  });
+});
@@ -0,0 +1,63 @@
+/**
+ * Unit tests for onboarding/steps/env-file.ts
+ */
+
+import { describe, it, expect } from "vitest";
+import type { AppConfig } from "../types.js";
+import type { SecretsConfig } from "../secrets.js";
+import { moveSecretsToEnv, withoutEnvFileKeys, buildEnvFile } from "../steps/env-file.js";
+import { buildDockerComposeYaml, buildDockerEnvLines } from "../steps/docker.js";
+
+function baseConfig(): AppConfig {
+  return {
+    workspace: "./workspace",
+    providers: [
+      { id: "anthropic", model: "claude-sonnet-4-5", apiKey: "secrets", priority: 1 },
+      { id: "openai-codex", model: "gpt-5.2", apiKey: "oauth", priority: 2 },
+    ],
+    discord: { requireMentionInGuild: true },
+    gateway: { http: { host: "0.0.0.0", port: 8787, token: "secrets" } },
+  } as AppConfig;
+}
+
+describe("env file step", () => {
+  it("moves every secret into env variables and empties secrets", () => {
+    const config = baseConfig();
+    const secrets: SecretsConfig = {
+      anthropic: { token: "sk-ant-oat01-x" },
+      discord: { token: "a.b.c" },
+      gateway: { token: "gw", basicAuthPassword: "pw" },
+    };
+
+    const vars = moveSecretsToEnv(config, secrets);
+
+    expect(vars).toEqual({
+      ANTHROPIC_API_KEY: "sk-ant-oat01-x",
+      DISCORD_BOT_TOKEN: "a.b.c",
+      OWLIABOT_GATEWAY_TOKEN: "gw",
+      OWLIABOT_GATEWAY_PASSWORD: "pw",
+    });
+    expect(secrets).toEqual({});
+    expect(config.providers[0].apiKey).toBe("env");
+    expect(config.providers[1].apiKey).toBe("oauth");
+    // The loader falls back to env for these, so the references stay as they were.
+    expect(config.discord?.token).toBeUndefined();
+    expect(config.gateway?.http?.token).toBe("secrets");
+  });
+
+  it("renders KEY=value lines", () => {
+    const content = buildEnvFile({ DISCORD_BOT_TOKEN: "a.b.c", OWLIABOT_GATEWAY_TOKEN: "gw" });
+    expect(content).toContain("\nDISCORD_BOT_TOKEN=a.b.c\nOWLIABOT_GATEWAY_TOKEN=gw\n");
+  });
+
+  it("adds env_file to compose and drops duplicated environment lines", () => {
+    const config = baseConfig();
+    const vars = moveSecretsToEnv(config, { anthropic: { apiKey: "k" }, discord: { token: "t" } });
+    const envLines = withoutEnvFileKeys(buildDockerEnvLines(config, {}, "UTC"), vars);
+
+    expect(envLines).toEqual(["TZ=UTC"]);
+
+    const yaml = buildDockerComposeYaml("~/.owliabot", envLines, "8787", "img", { envFile: ".env" });
+    expect(yaml).toContain("    env_file:\n      - .env\n    environment:\n      - TZ=UTC\n");
+  });
+});
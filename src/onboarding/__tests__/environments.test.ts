@@ -0,0 +1,130 @@
+/**
+ * Unit tests for onboarding/steps/environments.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { parse } from "yaml";
+import type { AppConfig } from "../types.js";
+import {
+  parseEnvironmentNames,
+  promptEnvironmentVariants,
+  prepareEnvironments,
+  renderEnvironmentFiles,
+  withImageTag,
+} from "../steps/environments.js";
+
+const BASE_PATHS = {
+  configDir: "/home/alice/.owliabot",
+  dockerConfigPath: "~/.owliabot",
+  shellConfigPath: "~/.owliabot",
+  outputDir: "/srv/bot",
+};
+
+function makeConfig(): AppConfig {
+  return {
+    workspace: "/app/workspace",
+    providers: [{ id: "anthropic", model: "claude-sonnet-4-5", apiKey: "secrets", priority: 1 }],
+    gateway: { http: { host: "0.0.0.0", port: 8787, token: "secrets" } },
+  };
+}
+
+describe("environments step", () => {
+  let consoleSpy: ReturnType<typeof vi.spyOn>;
+  let rl: ReturnType<typeof createInterface>;
+
+  beforeEach(() => {
+    consoleSpy = vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+    rl = createInterface({ input: process.stdin, output: process.stdout });
+  });
+
+  afterEach(() => {
+    consoleSpy.mockRestore();
+  });
+
+  it("parses and validates environment names", () => {
+    expect(parseEnvironmentNames(undefined)).toBeUndefined();
+    expect(parseEnvironmentNames("dev, Prod")).toEqual(["dev", "prod"]);
+    expect(() => parseEnvironmentNames("dev,dev")).toThrow(/duplicate/);
+    expect(() => parseEnvironmentNames("../prod")).toThrow(/Invalid environment name/);
+  });
+
+  it("defaults every override", async () => {
+    answers.push(...Array(10).fill(""));
+    const variants = await promptEnvironmentVariants(rl, ["dev", "prod"], "8787");
+    expect(variants).toEqual([
+      { name: "dev", imageTag: "latest", gatewayPort: "8787", logLevel: "debug", maxIterations: 20, timeoutSeconds: 600 },
+      { name: "prod", imageTag: "latest", gatewayPort: "8788", logLevel: "info", maxIterations: 50, timeoutSeconds: 600 },
+    ]);
+  });
+
+  it("re-asks invalid numbers", async () => {
+    answers.push("v1.2.3", "abc", "9000", "info", "0", "30", "");
+    const [prod] = await promptEnvironmentVariants(rl, ["prod"], "8787");
+    expect(prod).toMatchObject({ imageTag: "v1.2.3", gatewayPort: "9000", maxIterations: 30 });
+  });
+
+  it("replaces image tags", () => {
+    expect(withImageTag("ghcr.io/owliabot/owliabot:latest", "v1")).toBe("ghcr.io/owliabot/owliabot:v1");
+    expect(withImageTag("localhost:5000/owliabot", "dev")).toBe("localhost:5000/owliabot:dev");
+  });
+
+  it("prepares isolated per-environment files", () => {
+    const config = makeConfig();
+    const secrets = { gateway: { token: "tok" } };
+    const prepared = prepareEnvironments(
+      [
+        { name: "dev", imageTag: "edge", gatewayPort: "8787", logLevel: "debug", maxIterations: 20, timeoutSeconds: 300 },
+        { name: "prod", imageTag: "v1", gatewayPort: "8788", logLevel: "info", maxIterations: 50, timeoutSeconds: 600 },
+      ],
+      BASE_PATHS,
+      config,
+      secrets,
+      "UTC",
+      "ghcr.io/owliabot/owliabot:latest",
+      "basic",
+    );
+
+    const [dev, prod] = prepared;
+    expect(dev.paths.configDir).toBe("/home/alice/.owliabot-dev");
+    expect(prod.composePath).toBe("/srv/bot/docker-compose.prod.yml");
+    expect(dev.config.agents?.loop).toEqual({ maxIterations: 20, timeoutSeconds: 300 });
+    expect(config.agents).toBeUndefined();
+    expect(secrets).toEqual({ gateway: { token: "tok" } });
+    expect(dev.secrets.gateway?.basicAuthPassword).not.toBe(prod.secrets.gateway?.basicAuthPassword);
+
+    const compose = parse(prod.compose);
+    expect(compose.services.owliabot.image).toBe("${OWLIABOT_IMAGE:-ghcr.io/owliabot/owliabot:v1}");
+    expect(compose.services.owliabot.container_name).toBe("owliabot-prod");
+    expect(compose.services.owliabot.ports).toEqual(["127.0.0.1:8788:8787"]);
+    expect(compose.services.owliabot.environment).toContain("LOG_LEVEL=info");
+    expect(compose.services.owliabot.volumes[0]).toBe("~/.owliabot-prod:/home/owliabot/.owliabot");
+
+    const paths = renderEnvironmentFiles(prepared).map((f) => f.path);
+    expect(paths).toEqual([
+      "/home/alice/.owliabot-dev/app.yaml",
+      "/home/alice/.owliabot-dev/secrets.yaml",
+      "/srv/bot/docker-compose.dev.yml",
+      "/home/alice/.owliabot-prod/app.yaml",
+      "/home/alice/.owliabot-prod/secrets.yaml",
+      "/srv/bot/docker-compose.prod.yml",
+    ]);
+  });
+});
@@ -0,0 +1,81 @@
+/**
+ * Unit tests for onboarding/steps/flow-trace.ts
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import { mkdtempSync, readFileSync, rmSync } from "node:fs";
+import { join } from "node:path";
+import { tmpdir } from "node:os";
+import { FlowTracer, createFlowTracer, redactAnswer } from "../steps/flow-trace.js";
+
+describe("flow-trace", () => {
+  let lines: string[];
+  const tracer = (known: string[] = [], dotPath?: string) => new FlowTracer(true, dotPath, () => known, (l) => lines.push(l));
+
+  beforeEach(() => {
+    lines = [];
+  });
+
+  it("logs transitions with the answers that changed", () => {
+    const flow = tracer();
+    flow.enter("providers", { reuseExisting: false });
+    flow.enter("channels", { reuseExisting: false, providers: ["anthropic"] });
+
+    expect(flow.events).toEqual([
+      { kind: "enter", from: "start", to: "providers", changes: [{ key: "reuseExisting", before: undefined, after: false }] },
+      { kind: "enter", from: "providers", to: "channels", changes: [{ key: "providers", before: undefined, after: ["anthropic"] }] },
+    ]);
+    expect(lines.join("\n")).toContain("providers → channels");
+    expect(lines.join("\n")).toContain('providers: (unset) → ["anthropic"]');
+  });
+
+  it("redacts secret fields and known secret values but keeps key sources", () => {
+    expect(redactAnswer(
+      { token: "abc", provider: "ngrok", note: "uses hunter2-secret", apiKey: "secrets" },
+      ["hunter2-secret"],
+    )).toEqual({ token: "[REDACTED]", provider: "ngrok", note: "uses [REDACTED]", apiKey: "secrets" });
+  });
+
+  it("renders skips and the outcome in the DOT graph", () => {
+    const flow = tracer();
+    flow.enter("timezone", { timezone: "UTC" });
+    flow.skip("docker", "native mode");
+    flow.enter("config");
+    flow.end("done");
+
+    const dot = flow.toDot();
+    expect(dot).toContain('"start" -> "timezone" [label="1: timezone"];');
+    expect(dot).toContain('"timezone" -> "docker" [style=dashed, label="2: native mode"];');
+    expect(dot).toContain('"config" -> "done" [label="4"];');
+  });
+
+  describe("with a DOT file", () => {
+    let dir: string;
+
+    beforeEach(() => {
+      dir = mkdtempSync(join(tmpdir(), "owliabot-flow-"));
+    });
+
+    afterEach(() => {
+      rmSync(dir, { recursive: true, force: true });
+    });
+
+    it("writes the graph once when the flow ends", () => {
+      const path = join(dir, "flow.dot");
+      const flow = tracer([], path);
+      flow.enter("providers");
+      flow.end("cancelled");
+      flow.end("failed");
+
+      expect(readFileSync(path, "utf-8")).toContain('"providers" -> "cancelled"');
+      expect(flow.events.filter((e) => e.kind === "end")).toHaveLength(1);
+    });
+  });
+
+  it("records nothing when disabled", () => {
+    const flow = createFlowTracer(undefined);
+    flow.enter("providers", { reuseExisting: true });
+    flow.end("done");
+    expect(flow.events).toEqual([]);
+  });
+});
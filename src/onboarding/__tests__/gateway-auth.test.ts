@@ -0,0 +1,128 @@
+/**
+ * Unit tests for onboarding/steps/gateway-auth.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+
+const execFileSync = vi.fn();
+vi.mock("node:child_process", async (importOriginal) => {
+  const original = await importOriginal<typeof import("node:child_process")>();
+  return { ...original, execFileSync: (...args: any[]) => execFileSync(...args) };
+});
+
+import { mkdtemp, rm } from "node:fs/promises";
+import { tmpdir } from "node:os";
+import { join } from "node:path";
+import type { AppConfig } from "../types.js";
+import type { SecretsConfig } from "../secrets.js";
+import { applyGatewayAuth, gatewayAuthConflict, parseGatewayAuthMode } from "../steps/gateway-auth.js";
+import { buildDockerComposeYaml } from "../steps/docker.js";
+import { ensureGatewayToken } from "../steps/ui.js";
+
+function makeConfig(): AppConfig {
+  return {
+    workspace: "./workspace",
+    providers: [],
+    gateway: { http: { host: "0.0.0.0", port: 8787, token: "secrets" } },
+  } as AppConfig;
+}
+
+describe("gateway-auth step", () => {
+  let consoleSpy: ReturnType<typeof vi.spyOn>;
+  let dir: string;
+
+  beforeEach(async () => {
+    consoleSpy = vi.spyOn(console, "log").mockImplementation(() => {});
+    execFileSync.mockReset();
+    dir = await mkdtemp(join(tmpdir(), "owliabot-gw-auth-"));
+  });
+
+  afterEach(async () => {
+    consoleSpy.mockRestore();
+    await rm(dir, { recursive: true, force: true });
+  });
+
+  it("parses modes and rejects unknown ones", () => {
+    expect(parseGatewayAuthMode(undefined)).toBe("none");
+    expect(parseGatewayAuthMode("MTLS")).toBe("mtls");
+    expect(() => parseGatewayAuthMode("oauth")).toThrow(/none, basic, mtls/);
+  });
+
+  it("rejects mtls behind a tunnel, a proxy or in Kubernetes manifests", () => {
+    expect(gatewayAuthConflict("mtls", { tunnel: "cloudflared" })).toMatch(/^--tunnel cannot be combined/);
+    expect(gatewayAuthConflict("mtls", { outputFormat: "kubernetes" })).toMatch(/^--output-format kubernetes cannot/);
+    expect(gatewayAuthConflict("mtls", { oidc: true })).toMatch(/^--oidc cannot/);
+    expect(gatewayAuthConflict("mtls", { reverseProxy: "caddy" })).toMatch(/^--reverse-proxy cannot/);
+    expect(gatewayAuthConflict("mtls", { outputFormat: "compose" })).toBeNull();
+    expect(gatewayAuthConflict("basic", { tunnel: "ngrok", outputFormat: "kubernetes" })).toBeNull();
+  });
+
+  it("rejects an unattended reverse proxy without gateway auth", () => {
+    expect(gatewayAuthConflict("none", { reverseProxy: "caddy", interactive: false })).toMatch(/--gateway-auth basic/);
+    expect(gatewayAuthConflict("none", { reverseProxy: "caddy", interactive: true })).toBeNull();
+    expect(gatewayAuthConflict("basic", { reverseProxy: "caddy", interactive: false })).toBeNull();
+  });
+
+  it("basic: stores the password in secrets and references it from app.yaml", () => {
+    const config = makeConfig();
+    const secrets: SecretsConfig = { gateway: { token: "tok" } };
+
+    const result = applyGatewayAuth("basic", config, secrets, dir);
+
+    expect(result).toEqual({ mode: "basic", username: "owliabot" });
+    expect(config.gateway?.http?.basicAuth).toEqual({ username: "owliabot", password: "secrets" });
+    expect(secrets.gateway?.token).toBe("tok");
+    expect(secrets.gateway?.basicAuthPassword).toMatch(/^[A-Za-z0-9_-]{20,}$/);
+  });
+
+  it("basic: keeps the password from the previous run", () => {
+    const secrets: SecretsConfig = { gateway: { token: "tok" } };
+    ensureGatewayToken(secrets, { gatewayToken: "tok", gatewayBasicAuthPassword: "old-password" }, true);
+
+    applyGatewayAuth("basic", makeConfig(), secrets, dir);
+
+    expect(secrets.gateway).toEqual({ token: "tok", basicAuthPassword: "old-password" });
+  });
+
+  it("mtls: generates certificates and writes relative tls paths", () => {
+    const config = makeConfig();
+
+    const result = applyGatewayAuth("mtls", config, {}, dir);
+
+    expect(result.tlsDir).toBe(join(dir, "tls"));
+    expect(config.gateway?.http?.tls).toEqual({
+      certPath: "tls/server.crt",
+      keyPath: "tls/server.key",
+      clientCaPath: "tls/ca.crt",
+    });
+    const outputs = execFileSync.mock.calls.map((c) => c[1][c[1].indexOf("-out") + 1]);
+    expect(outputs).toEqual(expect.arrayContaining(["ca.crt", "server.crt", "client.crt"]));
+    expect(execFileSync.mock.calls.every((c) => c[0] === "openssl" && c[2].cwd === join(dir, "tls"))).toBe(true);
+  });
+
+  it("mtls: skips generation on dry runs", () => {
+    const config = makeConfig();
+    applyGatewayAuth("mtls", config, {}, dir, { generate: false });
+    expect(execFileSync).not.toHaveBeenCalled();
+    expect(config.gateway?.http?.tls?.clientCaPath).toBe("tls/ca.crt");
+  });
+
+  it("mtls: surfaces openssl failures", () => {
+    execFileSync.mockImplementation(() => {
+      throw new Error("spawn openssl ENOENT");
+    });
+    expect(() => applyGatewayAuth("mtls", makeConfig(), {}, dir)).toThrow(/openssl/);
+  });
+
+  it("skips when gateway HTTP is disabled", () => {
+    const config = { ...makeConfig(), gateway: undefined };
+    expect(applyGatewayAuth("basic", config, {}, dir).mode).toBe("none");
+  });
+
+  it("switches the compose healthcheck to https under TLS", () => {
+    const plain = buildDockerComposeYaml("~/.owliabot", [], "8787", "img");
+    const tls = buildDockerComposeYaml("~/.owliabot", [], "8787", "img", { gatewayTls: true });
+    expect(plain).toContain('"http://localhost:8787/health"');
+    expect(tls).toContain('"--no-check-certificate", "https://localhost:8787/health"');
+  });
+});
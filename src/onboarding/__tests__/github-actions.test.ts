@@ -0,0 +1,37 @@
+/**
+ * Unit tests for onboarding/steps/github-actions.ts
+ */
+
+import { describe, it, expect } from "vitest";
+import { parse } from "yaml";
+import { buildGithubActionsWorkflow } from "../steps/github-actions.js";
+
+describe("github actions step", () => {
+  const setup = { branch: "main", remoteComposeDir: "~/owliabot", remoteConfigDir: "~/.owliabot" };
+
+  it("validates on every change and deploys only from the branch", () => {
+    const workflow = parse(buildGithubActionsWorkflow(setup));
+    expect(workflow.on.push.branches).toEqual(["main"]);
+    expect(workflow.on.pull_request.paths).toContain("app.yaml");
+    expect(workflow.jobs.validate.steps.at(-1).run).toBe("npx --yes owliabot@latest validate -c app.yaml");
+    expect(workflow.jobs.deploy.needs).toBe("validate");
+    expect(workflow.jobs.deploy.if).toContain("github.event_name != 'pull_request'");
+  });
+
+  it("copies the files and restarts compose on the host", () => {
+    const workflow = parse(buildGithubActionsWorkflow(setup));
+    const runs = workflow.jobs.deploy.steps.map((s: { run?: string }) => s.run ?? "").join("\n");
+    expect(runs).toContain(`ssh -i ~/.ssh/id_deploy "$SSH_TARGET" "cat > ~/'.owliabot/app.yaml'" < app.yaml`);
+    expect(runs).toContain(`ssh -i ~/.ssh/id_deploy "$SSH_TARGET" "cat > ~/'owliabot/docker-compose.yml'" < docker-compose.yml`);
+    expect(runs).toContain("cd ~/'owliabot' && docker compose pull && docker compose up -d");
+    expect(workflow.jobs.deploy.env.SSH_TARGET).toBe("${{ secrets.OWLIABOT_SSH_USER }}@${{ secrets.OWLIABOT_SSH_HOST }}");
+  });
+
+  it("copies app.yaml to the profile's config dir, quoted for the remote shell", () => {
+    const workflow = parse(buildGithubActionsWorkflow({ ...setup, remoteConfigDir: "~/.owliabot-work", remoteComposeDir: "/srv/my bots" }));
+    const runs = workflow.jobs.deploy.steps.map((s: { run?: string }) => s.run ?? "").join("\n");
+    expect(runs).toContain(`"cat > ~/'.owliabot-work/app.yaml'" < app.yaml`);
+    expect(runs).toContain(`"cat > '/srv/my bots/docker-compose.yml'" < docker-compose.yml`);
+    expect(runs).not.toContain("scp ");
+  });
+});
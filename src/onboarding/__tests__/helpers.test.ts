@@ -3,7 +3,7 @@
  */
 
 import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
-import { detectTimezone, injectTimezoneComment } from "../steps/helpers.js";
+import { detectTimezone, injectDemoComment, injectTimezoneComment } from "../steps/helpers.js";
 
 describe("helpers", () => {
   describe("detectTimezone", () => {
@@ -81,4 +81,25 @@ providers: []`;
       expect(matches?.length).toBeGreaterThanOrEqual(1);
     });
   });
+
+  describe("injectDemoComment", () => {
+    const demo = `workspace: workspace
+providers:
+  - id: demo
+    model: echo
+    priority: 1`;
+
+    it("should label a config that uses the demo provider, once", () => {
+      const result = injectDemoComment(demo);
+      expect(result).toMatch(/^# DEMO MODE: /);
+      expect(result.endsWith(demo)).toBe(true);
+      expect(injectDemoComment(result)).toBe(result);
+    });
+
+    it("should drop the label once the demo provider is gone", () => {
+      const real = injectDemoComment(demo).replace("id: demo", "id: anthropic");
+      expect(injectDemoComment(real)).toBe(demo.replace("id: demo", "id: anthropic"));
+      expect(injectDemoComment("providers: []")).toBe("providers: []");
+    });
+  });
 });
@@ -0,0 +1,83 @@
+/**
+ * Unit tests for the wizard's message catalog (i18n.ts), and that the
+ * channel stage asks in the chosen language.
+ */
+
+import { describe, it, expect, vi, afterEach } from "vitest";
+
+let answers: string[] = [];
+let promptLog: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      promptLog.push(q);
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { readFileSync } from "node:fs";
+import { dirname, join } from "node:path";
+import { fileURLToPath } from "node:url";
+import { TRANSLATED_MODULES, getLang, missingKeys, parseLang, resolveLang, setLang, t } from "../i18n.js";
+import { askChannels } from "../steps/channel-setup.js";
+
+describe("i18n", () => {
+  afterEach(() => {
+    setLang("en");
+    vi.restoreAllMocks();
+  });
+
+  it("fills placeholders, in English by default", () => {
+    expect(getLang()).toBe("en");
+    expect(t("prompt.pickNumber", { count: 3, onEnter: "" })).toBe("Pick a number [1-3]: ");
+    expect(t("provider.reusing", { name: "OpenAI" }, "ja")).toBe("既存の OpenAI の設定を使います");
+  });
+
+  it("picks the language from the flag, OWLIABOT_LANG, then the locale", () => {
+    expect(parseLang("ko_KR.UTF-8")).toBe("ko");
+    expect(parseLang("fr")).toBeNull();
+    expect(resolveLang("ja", { OWLIABOT_LANG: "ko" })).toBe("ja");
+    expect(resolveLang(undefined, { OWLIABOT_LANG: "ko", LANG: "ja_JP.UTF-8" })).toBe("ko");
+    expect(resolveLang(undefined, { LANG: "ja_JP.UTF-8" })).toBe("ja");
+    expect(resolveLang(undefined, { LANG: "C.UTF-8" })).toBeNull();
+  });
+
+  it("has every wizard string translated except the trilingual language prompt", () => {
+    expect(missingKeys("ja")).toEqual(["wizard.language"]);
+    expect(missingKeys("ko")).toEqual(["wizard.language"]);
+  });
+
+  it("leaves no hardcoded English in the translated modules", () => {
+    const root = join(dirname(fileURLToPath(import.meta.url)), "..");
+    // A prompt, message or header whose text is a literal, not t(...); product names and
+    // logger calls (log.info) are fine
+    const literal = /(?<![\w.])(?:info|warn|success|error|header|ask|askYN|selectOption|console\.log)\(\s*(?:rl,\s*)?(["'`])(?!(?:Discord|Docker|Telegram|Slack)\1)[A-Za-z-]/;
+    const untranslated = TRANSLATED_MODULES.flatMap((file) =>
+      readFileSync(join(root, file), "utf-8")
+        .split("\n")
+        .map((line, i) => ({ where: `${file}:${i + 1}`, line }))
+        .filter(({ line }) => literal.test(line))
+        .map(({ where, line }) => `${where}: ${line.trim()}`),
+    );
+    expect(untranslated).toEqual([]);
+  });
+
+  it("asks the channel question in the chosen language", async () => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    setLang("ko");
+    answers = ["5"];
+    promptLog = [];
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    const result = await askChannels(rl, {}, null, false, {});
+    expect(result.webhookEnabled).toBe(true);
+    expect(promptLog[0]).toBe("번호를 선택하세요 [1-5]: ");
+  });
+});
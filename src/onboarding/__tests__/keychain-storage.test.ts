@@ -0,0 +1,60 @@
+/**
+ * Unit tests for onboarding/steps/keychain-storage.ts
+ */
+
+import { describe, it, expect, vi } from "vitest";
+import type { AppConfig } from "../types.js";
+import type { SecretsConfig } from "../secrets.js";
+import { moveSecretsToKeychain } from "../steps/keychain-storage.js";
+
+function baseConfig(): AppConfig {
+  return {
+    workspace: "./workspace",
+    providers: [
+      { id: "anthropic", model: "claude-sonnet-4-5", apiKey: "secrets", priority: 1 },
+      { id: "openai", model: "gpt-4o", apiKey: "env", priority: 2 },
+    ],
+    discord: { requireMentionInGuild: true },
+    telegram: {},
+  } as AppConfig;
+}
+
+describe("keychain storage step", () => {
+  it("moves secrets-backed keys and channel tokens into the keychain", () => {
+    const config = baseConfig();
+    const secrets: SecretsConfig = {
+      anthropic: { apiKey: "sk-ant-api-1" },
+      discord: { token: "a.b.c" },
+      telegram: { token: "123:abc" },
+      gateway: { token: "gw" },
+    };
+    const set = vi.fn();
+
+    const moved = moveSecretsToKeychain(config, secrets, { set });
+
+    expect(moved).toEqual(["anthropic", "discord", "telegram"]);
+    expect(set).toHaveBeenCalledWith("anthropic", "sk-ant-api-1");
+    expect(set).toHaveBeenCalledWith("discord", "a.b.c");
+    expect(config.providers[0].apiKey).toBe("keychain");
+    expect(config.providers[1].apiKey).toBe("env");
+    expect(config.discord?.token).toBe("keychain");
+    expect(config.telegram?.token).toBe("keychain");
+    // Only what the keychain can't hold stays on disk.
+    expect(secrets).toEqual({ gateway: { token: "gw" } });
+  });
+
+  it("prefers the Anthropic setup-token over an API key", () => {
+    const config = baseConfig();
+    const set = vi.fn();
+    moveSecretsToKeychain(config, { anthropic: { token: "sk-ant-oat01-x", apiKey: "sk-ant-api-1" } }, { set });
+    expect(set).toHaveBeenCalledWith("anthropic", "sk-ant-oat01-x");
+  });
+
+  it("only rewrites references on a dry run", () => {
+    const config = baseConfig();
+    const set = vi.fn();
+    const moved = moveSecretsToKeychain(config, { discord: { token: "a.b.c" } }, { store: false, set });
+    expect(moved).toEqual(["discord"]);
+    expect(set).not.toHaveBeenCalled();
+  });
+});
@@ -0,0 +1,123 @@
+/**
+ * Unit tests for onboarding/steps/kiosk.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { AbortError } from "../shared.js";
+import type { AppConfig } from "../types.js";
+import { buildDefaultMemorySearchConfig, buildDefaultSystemConfig } from "../steps/config-building.js";
+import {
+  applyKioskPreset,
+  KIOSK_COMMANDS,
+  KIOSK_RETENTION_MS,
+  KIOSK_TOOLS,
+  promptKioskAudience,
+  promptKioskPreset,
+} from "../steps/kiosk.js";
+
+function baseConfig(): AppConfig {
+  return {
+    workspace: "/w",
+    providers: [],
+    memorySearch: buildDefaultMemorySearchConfig("/w"),
+    system: buildDefaultSystemConfig(),
+    gateway: { http: { host: "127.0.0.1", port: 8787 } },
+    discord: { memberAllowList: ["111", "222"], channelAllowList: ["900"], requireMentionInGuild: false },
+    telegram: { allowList: ["42"] },
+    webhook: { path: "/webhook" },
+    tools: { allowWrite: true },
+    security: { writeGateEnabled: true, writeToolAllowList: ["111", "222"] },
+    mcp: { presets: ["playwright"] },
+  };
+}
+
+describe("kiosk preset", () => {
+  let rl: ReturnType<typeof createInterface>;
+
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+    rl = createInterface({ input: process.stdin, output: process.stdout });
+  });
+
+  afterEach(() => {
+    vi.restoreAllMocks();
+  });
+
+  it("is only offered interactively", async () => {
+    await expect(promptKioskPreset(rl, false)).resolves.toBe(false);
+    answers.push("y");
+    await expect(promptKioskPreset(rl, true)).resolves.toBe(true);
+  });
+
+  it("narrows to one channel, one user and one channel ID", async () => {
+    answers.push("1", "2");
+    await expect(promptKioskAudience(rl, baseConfig())).resolves.toEqual({
+      channel: "discord",
+      userId: "222",
+      channelId: "900",
+    });
+
+    answers.push("2");
+    await expect(promptKioskAudience(rl, baseConfig())).resolves.toEqual({ channel: "telegram", userId: "42" });
+  });
+
+  it("asks for an ID that isn't allow-listed yet, and gives up after three bad ones", async () => {
+    const config: AppConfig = { workspace: "/w", providers: [], slack: { memberAllowList: ["U1"] } };
+    answers.push("C123");
+    await expect(promptKioskAudience(rl, config)).resolves.toEqual({ channel: "slack", userId: "U1", channelId: "C123" });
+
+    answers.push("", "a b", "#general");
+    await expect(promptKioskAudience(rl, config)).rejects.toBeInstanceOf(AbortError);
+  });
+
+  it("needs a chat channel", async () => {
+    await expect(promptKioskAudience(rl, { workspace: "/w", providers: [] })).rejects.toThrow(/Discord, Telegram or Slack/);
+  });
+
+  it("restricts tools, exec, web, channels and retention", () => {
+    const config = baseConfig();
+    applyKioskPreset(config, { channel: "discord", userId: "222", channelId: "900" });
+
+    expect(config.discord).toEqual({ memberAllowList: ["222"], channelAllowList: ["900"], requireMentionInGuild: true });
+    expect(config.telegram).toBeUndefined();
+    expect(config.webhook).toBeUndefined();
+    expect(config.mcp).toBeUndefined();
+    expect(config.tools).toEqual({ allowWrite: false, policy: { allowList: KIOSK_TOOLS } });
+    expect(config.security?.writeToolAllowList).toEqual([]);
+    expect(config.system?.exec.commandAllowList).toEqual(KIOSK_COMMANDS);
+    expect(config.system?.web).toBeUndefined();
+    expect(config.system?.webSearch).toBeUndefined();
+    expect(config.cron).toEqual({ enabled: false });
+    expect(config.memorySearch?.enabled).toBe(false);
+    expect(config.session).toEqual({ summarizeOnReset: false });
+    expect(config.infra?.eventStore?.ttlMs).toBe(KIOSK_RETENTION_MS);
+    expect(config.gateway?.http?.eventTtlMs).toBe(KIOSK_RETENTION_MS);
+  });
+
+  it("keeps Telegram to direct messages from one user", () => {
+    const config = baseConfig();
+    config.telegram = { token: "keychain", allowList: ["42", "43"], groups: { "-100": { requireMention: false } } };
+    applyKioskPreset(config, { channel: "telegram", userId: "42" });
+
+    expect(config.telegram).toEqual({ token: "keychain", allowList: ["42"], groups: { "*": { enabled: false } } });
+    expect(config.discord).toBeUndefined();
+  });
+});
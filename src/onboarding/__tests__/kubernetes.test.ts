@@ -0,0 +1,60 @@
+/**
+ * Unit tests for onboarding/steps/kubernetes.ts
+ */
+
+import { describe, it, expect } from "vitest";
+import { parseAllDocuments, parse } from "yaml";
+import type { AppConfig } from "../types.js";
+import { buildKubernetesManifests, kubernetesEnvSecretKeys, parseOutputFormat } from "../steps/kubernetes.js";
+
+function makeConfig(): AppConfig {
+  return {
+    workspace: "/app/workspace",
+    providers: [{ id: "anthropic", model: "claude-sonnet-4-5", apiKey: "secrets", priority: 1 }],
+    gateway: { http: { host: "0.0.0.0", port: 8787, token: "secrets" } },
+  };
+}
+
+describe("kubernetes output", () => {
+  it("parses output formats", () => {
+    expect(parseOutputFormat(undefined)).toBe("compose");
+    expect(parseOutputFormat("k8s")).toBe("kubernetes");
+    expect(() => parseOutputFormat("helm")).toThrow(/compose, kubernetes/);
+  });
+
+  it("emits ConfigMap, Secret, PVC, Deployment and Service", () => {
+    const envLines = ["TZ=UTC", "DISCORD_BOT_TOKEN=${DISCORD_BOT_TOKEN}"];
+    const yaml = buildKubernetesManifests(makeConfig(), { gateway: { token: "tok" } }, envLines, {
+      image: "ghcr.io/owliabot/owliabot:latest",
+      namespace: "bots",
+    });
+    const docs = parseAllDocuments(yaml).map((d) => d.toJS());
+
+    expect(docs.map((d) => d.kind)).toEqual(["ConfigMap", "Secret", "PersistentVolumeClaim", "Deployment", "Service"]);
+    expect(docs.every((d) => d.metadata.namespace === "bots")).toBe(true);
+
+    const [configMap, secret, , deployment] = docs;
+    expect(parse(configMap.data["app.yaml"]).gateway.http.port).toBe(8787);
+    expect(parse(secret.stringData["secrets.yaml"])).toEqual({ gateway: { token: "tok" } });
+    expect(secret.stringData.DISCORD_BOT_TOKEN).toBe("");
+
+    const container = deployment.spec.template.spec.containers[0];
+    expect(container.image).toBe("ghcr.io/owliabot/owliabot:latest");
+    expect(container.env).toEqual([
+      { name: "TZ", value: "UTC" },
+      { name: "DISCORD_BOT_TOKEN", valueFrom: { secretKeyRef: { name: "owliabot-secrets", key: "DISCORD_BOT_TOKEN" } } },
+    ]);
+    expect(container.readinessProbe.httpGet).toEqual({ path: "/ready", port: "gateway", scheme: "HTTP" });
+  });
+
+  it("probes over HTTPS when the gateway serves TLS", () => {
+    const config = makeConfig();
+    config.gateway!.http!.tls = { certPath: "tls/server.crt", keyPath: "tls/server.key" };
+    const docs = parseAllDocuments(buildKubernetesManifests(config, {}, [], { image: "img" })).map((d) => d.toJS());
+    expect(docs[3].spec.template.spec.containers[0].livenessProbe.httpGet.scheme).toBe("HTTPS");
+  });
+
+  it("lists env placeholders that must be filled", () => {
+    expect(kubernetesEnvSecretKeys(["TZ=UTC", "OPENAI_API_KEY=${OPENAI_API_KEY}"])).toEqual(["OPENAI_API_KEY"]);
+  });
+});
@@ -0,0 +1,58 @@
+/**
+ * Unit tests for onboarding/steps/local-run.ts
+ */
+
+import { describe, it, expect } from "vitest";
+import { parse as parseYaml } from "yaml";
+import type { AppConfig } from "../types.js";
+import { buildLocalRunConfig, buildLocalRunScript, renderLocalRunFiles } from "../steps/local-run.js";
+import { buildDefaultMemorySearchConfig } from "../steps/config-building.js";
+
+const dockerConfig: AppConfig = {
+  workspace: "/app/workspace",
+  providers: [{ id: "anthropic", model: "claude-opus-4-5", apiKey: "secrets", priority: 1 } as any],
+  memorySearch: buildDefaultMemorySearchConfig("/app/workspace"),
+  gateway: { http: { host: "0.0.0.0", port: 8787, token: "secrets" } },
+};
+
+describe("local-run", () => {
+  it("points the local config at the host workspace and gateway port", () => {
+    const local = buildLocalRunConfig(dockerConfig, "9090");
+
+    expect(local.workspace).toBe("workspace");
+    expect(local.memorySearch?.store.path).toBe("{workspace}/memory/{agentId}.sqlite");
+    expect(local.gateway?.http).toMatchObject({ host: "127.0.0.1", port: 9090, token: "secrets" });
+    expect(local.providers).toEqual(dockerConfig.providers);
+    // The Docker config itself is untouched.
+    expect(dockerConfig.workspace).toBe("/app/workspace");
+  });
+
+  it("exports literal env values and checks pass-throughs", () => {
+    const script = buildLocalRunScript({
+      shellConfigPath: "~/.owliabot",
+      envLines: [
+        "TZ=Europe/Berlin",
+        "ANTHROPIC_API_KEY=${ANTHROPIC_API_KEY}",
+        "OWLIABOT_SECRETS_KEY_FILE=/run/secrets/owliabot-secrets.agekey",
+      ],
+    });
+
+    expect(script).toContain('export OWLIABOT_HOME="${OWLIABOT_HOME:-$HOME/.owliabot}"');
+    expect(script).toContain("export TZ=${TZ:-'Europe/Berlin'}");
+    expect(script).toContain("for var in ANTHROPIC_API_KEY; do");
+    expect(script).not.toContain("OWLIABOT_SECRETS_KEY_FILE");
+    expect(script).toContain('CONFIG="$OWLIABOT_HOME/app.local.yaml"');
+  });
+
+  it("places app.local.yaml next to app.yaml and the script next to the compose file", () => {
+    const files = renderLocalRunFiles(
+      { configDir: "/home/owliabot/.owliabot", dockerConfigPath: "~/.owliabot", shellConfigPath: "~/.owliabot", outputDir: "/app/output" },
+      dockerConfig,
+      ["TZ=UTC"],
+      "8787",
+    );
+
+    expect(files.map((f) => f.path)).toEqual(["/home/owliabot/.owliabot/app.local.yaml", "/app/output/run-local.sh"]);
+    expect(parseYaml(files[0].content).workspace).toBe("workspace");
+  });
+});
@@ -0,0 +1,93 @@
+/**
+ * Unit tests for onboarding/steps/mcp-custom.ts
+ */
+
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import {
+  askCustomMcpServer,
+  isValidMcpServerName,
+  parseEnvPairs,
+  splitArgs,
+} from "../steps/mcp-custom.js";
+
+describe("isValidMcpServerName", () => {
+  it.each(["notion", "my-server", "db_2"])("accepts %s", (name) => {
+    expect(isValidMcpServerName(name)).toBe(true);
+  });
+
+  it.each(["", "my server", "a__b", "-x", "x/y"])("refuses %s", (name) => {
+    expect(isValidMcpServerName(name)).toBe(false);
+  });
+});
+
+describe("splitArgs", () => {
+  it("keeps quoted parts together", () => {
+    expect(splitArgs(`--root "/my docs" -v ''`)).toEqual(["--root", "/my docs", "-v", ""]);
+  });
+
+  it("returns null for an open quote", () => {
+    expect(splitArgs(`"unterminated`)).toBeNull();
+  });
+
+  it("returns an empty list for a blank line", () => {
+    expect(splitArgs("   ")).toEqual([]);
+  });
+});
+
+describe("parseEnvPairs", () => {
+  it("parses KEY=VALUE pairs", () => {
+    expect(parseEnvPairs("A=1 B=${TOKEN} C=")).toEqual({ A: "1", B: "${TOKEN}", C: "" });
+  });
+
+  it("refuses pairs without a key", () => {
+    expect(parseEnvPairs("=1")).toBeNull();
+    expect(parseEnvPairs("JUSTKEY")).toBeNull();
+  });
+});
+
+describe("askCustomMcpServer", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  it("asks again for a taken or invalid name, then an sse URL", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["playwright", "bad name", "remote", "2", "not a url", "https://mcp.example.test/sse"];
+
+    expect(await askCustomMcpServer(rl, new Set(["playwright"]))).toEqual({
+      name: "remote",
+      transport: "sse",
+      url: "https://mcp.example.test/sse",
+    });
+    expect(answers).toEqual([]);
+  });
+
+  it("leaves out empty args and env", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["local", "", "my-mcp-server", "", ""];
+
+    expect(await askCustomMcpServer(rl, new Set())).toEqual({
+      name: "local",
+      transport: "stdio",
+      command: "my-mcp-server",
+    });
+  });
+});
@@ -0,0 +1,90 @@
+/**
+ * Unit tests for onboarding/steps/mcp-runtime-check.ts
+ */
+
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { checkMcpRuntimes, mcpRuntimeCommands, probeRuntime } from "../steps/mcp-runtime-check.js";
+
+describe("mcpRuntimeCommands", () => {
+  it("groups presets and custom stdio servers by command", () => {
+    const commands = mcpRuntimeCommands({
+      presets: ["playwright", "fetch", "sqlite"],
+      servers: [
+        { name: "local", transport: "stdio", command: "npx" },
+        { name: "remote", transport: "sse", url: "http://localhost:9000/sse" },
+      ],
+    });
+    expect([...commands]).toEqual([
+      ["npx", ["playwright", "local"]],
+      ["uvx", ["fetch", "sqlite"]],
+    ]);
+  });
+});
+
+describe("probeRuntime", () => {
+  it("reports the first line of --version", () => {
+    const exec = vi.fn(() => "10.9.2\nextra\n");
+    expect(probeRuntime("npx", exec)).toEqual({ kind: "ok", version: "10.9.2" });
+    expect(exec).toHaveBeenCalledWith("npx", ["--version"]);
+  });
+
+  it("treats a failed start as missing", () => {
+    const exec = vi.fn(() => {
+      throw Object.assign(new Error("spawn uvx ENOENT"), { code: "ENOENT" });
+    });
+    expect(probeRuntime("uvx", exec)).toEqual({ kind: "missing" });
+  });
+});
+
+describe("checkMcpRuntimes", () => {
+  let log: ReturnType<typeof vi.spyOn>;
+
+  beforeEach(() => {
+    log = vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  it("returns the missing runtimes on the host", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = [""];
+    const probe = vi.fn((cmd: string) => (cmd === "npx" ? { kind: "ok" as const, version: "10.9.2" } : { kind: "missing" as const }));
+
+    expect(await checkMcpRuntimes(rl, { presets: ["playwright", "fetch"] }, false, probe)).toEqual(["uvx"]);
+    expect(log.mock.calls.flat().join("\n")).toContain("docs.astral.sh/uv");
+  });
+
+  it("checks against the image in Docker mode without probing", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = [""];
+    const probe = vi.fn();
+
+    expect(await checkMcpRuntimes(rl, { presets: ["memory", "sqlite"] }, true, probe)).toEqual(["uvx"]);
+    expect(probe).not.toHaveBeenCalled();
+  });
+
+  it("skips when declined", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["n"];
+    const probe = vi.fn();
+
+    expect(await checkMcpRuntimes(rl, { presets: ["fetch"] }, false, probe)).toEqual([]);
+    expect(probe).not.toHaveBeenCalled();
+  });
+});
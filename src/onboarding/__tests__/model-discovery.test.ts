@@ -0,0 +1,145 @@
+/**
+ * Unit tests for onboarding/steps/model-discovery.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import os from "node:os";
+import path from "node:path";
+import { mkdtemp, readFile, rm } from "node:fs/promises";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { ValidationClient } from "../steps/validation-client.js";
+import {
+  fetchProviderModels,
+  listProviderModels,
+  promptModel,
+  MODEL_LIST_CACHE_FILE,
+} from "../steps/model-discovery.js";
+
+function json(status: number, body: unknown): Response {
+  return new Response(JSON.stringify(body), { status, headers: { "content-type": "application/json" } });
+}
+
+function clientFor(status: number, body: unknown) {
+  const fetchImpl = vi.fn(async () => json(status, body));
+  return { fetchImpl, client: new ValidationClient({ fetchImpl: fetchImpl as unknown as typeof fetch, retries: 0 }) };
+}
+
+describe("model discovery", () => {
+  let dir: string;
+
+  beforeEach(async () => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+    dir = await mkdtemp(path.join(os.tmpdir(), "owliabot-models-"));
+  });
+
+  afterEach(async () => {
+    await rm(dir, { recursive: true, force: true });
+    vi.restoreAllMocks();
+  });
+
+  it("lists Anthropic models newest first with the API key header", async () => {
+    const { fetchImpl, client } = clientFor(200, {
+      data: [
+        { id: "claude-3-5-haiku-20241022", created_at: "2024-10-22T00:00:00Z" },
+        { id: "claude-opus-4-5-20251101", created_at: "2025-11-01T00:00:00Z" },
+      ],
+    });
+
+    expect(await fetchProviderModels("anthropic", "sk-ant-api03-test", client)).toEqual([
+      "claude-opus-4-5-20251101",
+      "claude-3-5-haiku-20241022",
+    ]);
+    const init = fetchImpl.mock.calls[0][1] as RequestInit;
+    expect((init.headers as Record<string, string>)["x-api-key"]).toBe("sk-ant-api03-test");
+  });
+
+  it("keeps only OpenAI chat models", async () => {
+    const { client } = clientFor(200, {
+      data: [
+        { id: "text-embedding-3-small", created: 3 },
+        { id: "gpt-5.2", created: 2 },
+        { id: "whisper-1", created: 4 },
+        { id: "o3", created: 1 },
+      ],
+    });
+    expect(await fetchProviderModels("openai", "sk-test", client)).toEqual(["gpt-5.2", "o3"]);
+  });
+
+  it("returns null when the key is rejected", async () => {
+    const { client } = clientFor(401, { error: { message: "invalid x-api-key" } });
+    expect(await fetchProviderModels("anthropic", "sk-ant-api03-bad", client)).toBeNull();
+  });
+
+  it("caches a listing per key without storing the key", async () => {
+    const fetchModels = vi.fn(async () => ["gpt-5.2"]);
+
+    expect(await listProviderModels("openai", "sk-one", dir, fetchModels)).toEqual(["gpt-5.2"]);
+    expect(await listProviderModels("openai", "sk-one", dir, fetchModels)).toEqual(["gpt-5.2"]);
+    expect(fetchModels).toHaveBeenCalledTimes(1);
+
+    await listProviderModels("openai", "sk-two", dir, fetchModels);
+    await listProviderModels("anthropic", "sk-two", dir, fetchModels);
+    expect(fetchModels).toHaveBeenCalledTimes(3);
+
+    const cache = await readFile(path.join(dir, MODEL_LIST_CACHE_FILE), "utf-8");
+    expect(cache).not.toContain("sk-two");
+    expect(JSON.parse(cache)).toHaveProperty("anthropic");
+    expect(JSON.parse(cache)).toHaveProperty("openai");
+  });
+
+  it("does not cache a failed listing", async () => {
+    const fetchModels = vi.fn(async () => null);
+    expect(await listProviderModels("openai", "sk-one", dir, fetchModels)).toBeNull();
+    expect(await listProviderModels("openai", "sk-one", dir, fetchModels)).toBeNull();
+    expect(fetchModels).toHaveBeenCalledTimes(2);
+  });
+
+  describe("promptModel", () => {
+    const rl = () => createInterface({ input: process.stdin, output: process.stdout });
+
+    it("keeps the preset default on Enter when the account has it", async () => {
+      answers = [""];
+      expect(await promptModel(rl(), ["claude-sonnet-4-5-20250929", "claude-opus-4-5-20251101"], "claude-opus-4-5"))
+        .toBe("claude-opus-4-5");
+    });
+
+    it("picks a listed model by number", async () => {
+      answers = ["2"];
+      expect(await promptModel(rl(), ["gpt-5.2", "o3"], "gpt-5.2")).toBe("o3");
+    });
+
+    it("defaults to the newest model when the preset isn't listed", async () => {
+      answers = [""];
+      expect(await promptModel(rl(), ["gpt-5.2", "o3"], "gpt-4o")).toBe("gpt-5.2");
+    });
+
+    it("takes a typed model name", async () => {
+      answers = ["3", "gpt-custom"];
+      expect(await promptModel(rl(), ["gpt-5.2", "o3"], "gpt-5.2")).toBe("gpt-custom");
+    });
+
+    it("falls back to the free-text prompt without a list", async () => {
+      answers = [""];
+      expect(await promptModel(rl(), null, "gpt-5.2")).toBe("gpt-5.2");
+    });
+  });
+});
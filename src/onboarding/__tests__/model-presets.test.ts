@@ -0,0 +1,110 @@
+/**
+ * Unit tests for onboarding/steps/model-presets.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import { mkdtempSync, rmSync, writeFileSync } from "node:fs";
+import { tmpdir } from "node:os";
+import { join } from "node:path";
+import {
+  BUILTIN_MODEL_PRESETS,
+  detectLocale,
+  loadModelPresets,
+  resolveModelPresets,
+} from "../steps/model-presets.js";
+import { ValidationClient } from "../steps/validation-client.js";
+
+const deepseek = { name: "DeepSeek", baseUrl: "https://api.deepseek.com/v1", model: "deepseek-chat" };
+
+describe("detectLocale", () => {
+  it("turns POSIX locales into BCP 47 tags", () => {
+    expect(detectLocale({ LANG: "zh_CN.UTF-8" })).toBe("zh-CN");
+    expect(detectLocale({ LC_ALL: "de_DE@euro", LANG: "en_US.UTF-8" })).toBe("de-DE");
+  });
+
+  it("treats C and POSIX as no locale", () => {
+    expect(detectLocale({ LANG: "C.UTF-8" })).toBeUndefined();
+    expect(detectLocale({ LC_ALL: "POSIX" })).toBeUndefined();
+  });
+});
+
+describe("resolveModelPresets", () => {
+  it("returns the built-ins without a catalog", () => {
+    expect(resolveModelPresets(null, "en-US")).toEqual(BUILTIN_MODEL_PRESETS);
+  });
+
+  it("applies default, language and locale layers in order", () => {
+    const catalog = resolveModelPresets(
+      {
+        default: { models: { anthropic: "claude-sonnet-4-5" } },
+        locales: {
+          zh: { models: { openai: "gpt-4.1" } },
+          "zh-cn": { models: { openai: "gpt-4.1-mini" }, compatibleEndpoints: [deepseek] },
+        },
+      },
+      "zh-CN",
+    );
+
+    expect(catalog.models.anthropic).toBe("claude-sonnet-4-5");
+    expect(catalog.models.openai).toBe("gpt-4.1-mini");
+    expect(catalog.compatibleEndpoints.at(-1)).toEqual(deepseek);
+  });
+
+  it("replaces an endpoint with the same name and ignores unknown providers", () => {
+    const catalog = resolveModelPresets(
+      {
+        default: {
+          models: { unknown: "x" } as never,
+          compatibleEndpoints: [{ name: "Ollama", baseUrl: "http://gpu-box:11434/v1" }],
+        },
+      },
+      undefined,
+    );
+
+    expect(catalog.compatibleEndpoints[0]).toEqual({ name: "Ollama", baseUrl: "http://gpu-box:11434/v1" });
+    expect(catalog.compatibleEndpoints).toHaveLength(BUILTIN_MODEL_PRESETS.compatibleEndpoints.length);
+    expect(catalog.models).toEqual(BUILTIN_MODEL_PRESETS.models);
+  });
+
+  it("leaves other locales alone", () => {
+    const catalog = resolveModelPresets({ locales: { "zh-CN": { compatibleEndpoints: [deepseek] } } }, "en-GB");
+    expect(catalog).toEqual(BUILTIN_MODEL_PRESETS);
+  });
+});
+
+describe("loadModelPresets", () => {
+  let dir: string;
+
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    dir = mkdtempSync(join(tmpdir(), "owliabot-presets-"));
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+    vi.restoreAllMocks();
+  });
+
+  it("reads the file named by OWLIABOT_MODEL_PRESETS", async () => {
+    const file = join(dir, "presets.yaml");
+    writeFileSync(file, `locales:\n  zh:\n    compatibleEndpoints:\n      - { name: DeepSeek, baseUrl: "https://api.deepseek.com/v1", model: deepseek-chat }\n`);
+
+    const catalog = await loadModelPresets({ OWLIABOT_MODEL_PRESETS: file, LANG: "zh_TW.UTF-8" });
+    expect(catalog.compatibleEndpoints.at(-1)).toEqual(deepseek);
+  });
+
+  it("falls back to the built-ins for a broken file", async () => {
+    const file = join(dir, "presets.yaml");
+    writeFileSync(file, "just a string\n");
+
+    expect(await loadModelPresets({ OWLIABOT_MODEL_PRESETS: file, LANG: "en_US.UTF-8" })).toEqual(BUILTIN_MODEL_PRESETS);
+  });
+
+  it("fetches a remote catalog", async () => {
+    const fetchImpl = vi.fn(async () => new Response(JSON.stringify({ default: { models: { openai: "gpt-4.1" } } })));
+    const client = new ValidationClient({ fetchImpl: fetchImpl as unknown as typeof fetch, retries: 0 });
+
+    const catalog = await loadModelPresets({ OWLIABOT_MODEL_PRESETS: "https://presets.example.test/catalog.yaml" }, client);
+    expect(catalog.models.openai).toBe("gpt-4.1");
+  });
+});
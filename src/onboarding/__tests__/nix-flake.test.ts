@@ -0,0 +1,27 @@
+/**
+ * Unit tests for onboarding/steps/nix-flake.ts
+ */
+
+import { describe, it, expect } from "vitest";
+import { buildNixFlakeFile } from "../steps/nix-flake.js";
+
+describe("nix flake step", () => {
+  const opts = { configDir: "/home/alice/.owliabot", appConfigPath: "/home/alice/.owliabot/app.yaml" };
+
+  it("lives in its own directory so secrets stay out of the store", () => {
+    expect(buildNixFlakeFile(opts).path).toBe("/home/alice/.owliabot/nix/flake.nix");
+  });
+
+  it("starts owliabot with the generated config", () => {
+    const { content } = buildNixFlakeFile({ ...opts, version: "0.2.0" });
+    expect(content).toContain("exec npx --yes owliabot@0.2.0");
+    expect(content).toContain("OWLIABOT_HOME='/home/alice/.owliabot'");
+    expect(content).toContain("exec ${owliabot}/bin/owliabot start -c '/home/alice/.owliabot/app.yaml'");
+    expect(content).toContain("pkgs = nixpkgs.legacyPackages.${system};");
+  });
+
+  it("escapes Nix interpolation in paths", () => {
+    const { content } = buildNixFlakeFile({ configDir: "/srv/${x}", appConfigPath: "/srv/${x}/app.yaml" });
+    expect(content).toContain("OWLIABOT_HOME='/srv/''${x}'");
+  });
+});
@@ -0,0 +1,132 @@
+/**
+ * Unit tests for onboarding/steps/notify.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import { mkdtempSync, readFileSync, rmSync, statSync } from "node:fs";
+import { tmpdir } from "node:os";
+import { join } from "node:path";
+import type { AppConfig } from "../types.js";
+import {
+  PENDING_NOTIFICATION_FILE,
+  buildOnboardingInventory,
+  notifyHost,
+  owliabotVersion,
+  sendOnboardingNotification,
+  waitForGatewayHealth,
+  writePendingNotification,
+} from "../steps/notify.js";
+
+const config = {
+  workspace: "/ws",
+  providers: [{ id: "anthropic", model: "claude-sonnet-4-5", apiKey: "secrets", priority: 1 }],
+  discord: { requireMentionInGuild: true },
+  webhook: { path: "/webhook" },
+  mcp: { presets: ["playwright"] },
+  gateway: { http: { host: "0.0.0.0", port: 8787, token: "gw-secret-token" } },
+} as unknown as AppConfig;
+
+function fetchReturning(res: Response) {
+  return vi.fn(async (_url: string, _init?: RequestInit) => res);
+}
+
+describe("buildOnboardingInventory", () => {
+  it("summarises the config without secrets", () => {
+    const now = new Date("2026-01-02T03:04:05Z");
+    const inventory = buildOnboardingInventory(config, { dockerMode: true, outputFormat: "compose" }, now, "1.2.3", "box-1");
+
+    expect(inventory).toEqual({
+      event: "owliabot.onboarded",
+      version: "1.2.3",
+      host: "box-1",
+      platform: process.platform,
+      mode: "docker",
+      outputFormat: "compose",
+      providers: [{ id: "anthropic", model: "claude-sonnet-4-5" }],
+      channels: ["discord", "webhook"],
+      mcpPresets: ["playwright"],
+      gatewayHttp: true,
+      timestamp: "2026-01-02T03:04:05.000Z",
+    });
+    expect(JSON.stringify(inventory)).not.toContain("gw-secret-token");
+  });
+
+  it("leaves out the output format in native mode", () => {
+    expect(buildOnboardingInventory(config, { dockerMode: false }).outputFormat).toBeUndefined();
+  });
+
+  it("reads the package version", () => {
+    expect(owliabotVersion()).toMatch(/^\d+\.\d+\.\d+/);
+  });
+
+  it("takes the host from --notify-host, then OWLIABOT_NOTIFY_HOST", () => {
+    expect(notifyHost("edge-7", { OWLIABOT_NOTIFY_HOST: "box-1" })).toBe("edge-7");
+    expect(notifyHost(undefined, { OWLIABOT_NOTIFY_HOST: "box-1" })).toBe("box-1");
+    expect(notifyHost(undefined, {})).not.toBe("");
+  });
+});
+
+describe("sendOnboardingNotification", () => {
+  let log: ReturnType<typeof vi.spyOn>;
+  const inventory = buildOnboardingInventory(config, { dockerMode: false }, new Date(0), "1.0.0", "h");
+
+  beforeEach(() => {
+    log = vi.spyOn(console, "log").mockImplementation(() => {});
+  });
+
+  it("POSTs the inventory as JSON", async () => {
+    const fetchImpl = fetchReturning(new Response(null, { status: 204 }));
+
+    expect(await sendOnboardingNotification("https://inventory.example.test/hook?key=abc", inventory, fetchImpl as unknown as typeof fetch)).toBe(true);
+    const [url, init] = fetchImpl.mock.calls[0];
+    expect(url).toBe("https://inventory.example.test/hook?key=abc");
+    expect(init?.method).toBe("POST");
+    expect(JSON.parse(String(init?.body))).toEqual(inventory);
+    expect(log.mock.calls.flat().join("\n")).not.toContain("key=abc");
+  });
+
+  it("reports a rejected notification without throwing", async () => {
+    const fetchImpl = fetchReturning(new Response("nope", { status: 400 }));
+    expect(await sendOnboardingNotification("https://inventory.example.test/hook", inventory, fetchImpl as unknown as typeof fetch)).toBe(false);
+  });
+
+  it("sends once, without retrying a failed POST", async () => {
+    const fetchImpl = vi.fn(async () => { throw new TypeError("fetch failed"); });
+    expect(await sendOnboardingNotification("https://inventory.example.test/hook", inventory, fetchImpl as unknown as typeof fetch)).toBe(false);
+    expect(fetchImpl).toHaveBeenCalledTimes(1);
+  });
+});
+
+describe("pending setup notification", () => {
+  let dir: string;
+
+  beforeEach(() => {
+    dir = mkdtempSync(join(tmpdir(), "owliabot-notify-"));
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+  });
+
+  it("saves the payload next to app.yaml for install.sh to send", () => {
+    const inventory = buildOnboardingInventory(config, { dockerMode: true }, new Date(0), "1.0.0", "h");
+    const path = writePendingNotification(dir, inventory);
+    expect(path).toBe(join(dir, PENDING_NOTIFICATION_FILE));
+    expect(JSON.parse(readFileSync(path, "utf-8"))).toEqual(inventory);
+    if (process.platform !== "win32") expect(statSync(path).mode & 0o777).toBe(0o600);
+  });
+
+  it("waits for /health to answer, and gives up after the timeout", async () => {
+    const answers = [new Error("ECONNREFUSED"), new Response(null, { status: 503 }), new Response("ok")];
+    const fetchImpl = vi.fn(async () => {
+      const next = answers.shift()!;
+      if (next instanceof Error) throw next;
+      return next;
+    });
+    expect(await waitForGatewayHealth("http://localhost:8787/health", { fetchImpl: fetchImpl as unknown as typeof fetch, intervalMs: 1 })).toBe(true);
+    expect(fetchImpl).toHaveBeenCalledTimes(3);
+
+    const down = vi.fn(async () => { throw new Error("ECONNREFUSED"); });
+    expect(await waitForGatewayHealth("http://localhost:8787/health", { fetchImpl: down as unknown as typeof fetch, timeoutMs: 0 })).toBe(false);
+  });
+});
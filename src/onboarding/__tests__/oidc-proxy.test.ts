@@ -0,0 +1,84 @@
+/**
+ * Unit tests for onboarding/steps/oidc-proxy.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { parse } from "yaml";
+import { AbortError } from "../shared.js";
+import { buildDockerComposeYaml } from "../steps/docker.js";
+import { promptOidcProxySetup, buildOidcProxyEnv } from "../steps/oidc-proxy.js";
+
+describe("oidc-proxy step", () => {
+  let consoleSpy: ReturnType<typeof vi.spyOn>;
+  let rl: ReturnType<typeof createInterface>;
+
+  beforeEach(() => {
+    consoleSpy = vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+    rl = createInterface({ input: process.stdin, output: process.stdout });
+  });
+
+  afterEach(() => {
+    consoleSpy.mockRestore();
+  });
+
+  it("collects issuer, client and domains", async () => {
+    answers.push("https://accounts.google.com/", "client-id", "client-secret", "example.com, example.org", "");
+    const setup = await promptOidcProxySetup(rl, "8787");
+
+    expect(setup).toMatchObject({
+      issuerUrl: "https://accounts.google.com",
+      clientId: "client-id",
+      clientSecret: "client-secret",
+      emailDomains: ["example.com", "example.org"],
+      publicUrl: "http://localhost:8787",
+    });
+    expect(setup.cookieSecret).toHaveLength(32);
+
+    const env = buildOidcProxyEnv(setup);
+    expect(env).toContain("OAUTH2_PROXY_EMAIL_DOMAINS=example.com,example.org\n");
+    expect(env).toContain("OAUTH2_PROXY_REDIRECT_URL=http://localhost:8787/oauth2/callback\n");
+    expect(env).toContain("OAUTH2_PROXY_UPSTREAMS=http://owliabot:8787\n");
+    expect(env).toContain("OAUTH2_PROXY_COOKIE_SECURE=false\n");
+  });
+
+  it("rejects non-https issuers", async () => {
+    answers.push("http://idp.local");
+    await expect(promptOidcProxySetup(rl, "8787")).rejects.toBeInstanceOf(AbortError);
+  });
+
+  it("moves the host port from the gateway to oauth2-proxy", () => {
+    const doc = parse(buildDockerComposeYaml("~/.owliabot", [], "9000", "img", { oidcProxy: true }));
+
+    expect(doc.services.owliabot.ports).toBeUndefined();
+    expect(doc.services["oauth2-proxy"].ports).toEqual(["127.0.0.1:9000:4180"]);
+    expect(doc.services["oauth2-proxy"].env_file).toEqual(["~/.owliabot/oidc.env"]);
+  });
+
+  it("routes the tunnel through the proxy", () => {
+    const doc = parse(
+      buildDockerComposeYaml("~/.owliabot", [], "8787", "img", {
+        oidcProxy: true,
+        tunnel: { provider: "ngrok", token: "ng" },
+      }),
+    );
+    expect(doc.services.tunnel.command).toEqual(["http", "http://oauth2-proxy:4180"]);
+  });
+});
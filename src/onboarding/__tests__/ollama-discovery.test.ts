@@ -0,0 +1,71 @@
+/**
+ * Unit tests for onboarding/steps/ollama-discovery.ts
+ */
+
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { ValidationClient } from "../steps/validation-client.js";
+import {
+  discoverOllamaModels,
+  promptOllamaModel,
+  ollamaApiRoot,
+  formatModelSize,
+} from "../steps/ollama-discovery.js";
+
+function clientReturning(res: Response) {
+  const fetchImpl = vi.fn(async () => res);
+  return { client: new ValidationClient({ fetchImpl: fetchImpl as unknown as typeof fetch, retries: 0 }), fetchImpl };
+}
+
+describe("ollama discovery", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  it("derives the native API root from the /v1 base URL", () => {
+    expect(ollamaApiRoot("http://localhost:11434/v1/")).toBe("http://localhost:11434");
+    expect(formatModelSize(4_661_224_676)).toBe("4.7 GB");
+    expect(formatModelSize(274_302_450)).toBe("274 MB");
+  });
+
+  it("lists installed models from /api/tags", async () => {
+    const body = JSON.stringify({ models: [{ name: "llama3.2:latest", size: 2_019_393_189 }, { name: "qwen2.5:7b", size: 4_683_087_332 }] });
+    const { client, fetchImpl } = clientReturning(new Response(body, { status: 200 }));
+    const models = await discoverOllamaModels("http://localhost:11434/v1", client);
+    expect(fetchImpl.mock.calls[0][0]).toBe("http://localhost:11434/api/tags");
+    expect(models?.map((m) => m.name)).toEqual(["llama3.2:latest", "qwen2.5:7b"]);
+  });
+
+  it("returns null for servers that are not Ollama", async () => {
+    const { client } = clientReturning(new Response("not found", { status: 404 }));
+    expect(await discoverOllamaModels("http://localhost:8000/v1", client)).toBeNull();
+  });
+
+  it("picks an installed model or falls back to a typed name", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    const models = [{ name: "llama3.2:latest", size: 2e9 }];
+    answers = ["1"];
+    expect(await promptOllamaModel(rl, models, "llama3.2")).toBe("llama3.2:latest");
+    answers = ["2", "mistral"];
+    expect(await promptOllamaModel(rl, models, "llama3.2")).toBe("mistral");
+  });
+});
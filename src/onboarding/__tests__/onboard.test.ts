@@ -138,9 +138,15 @@ describe("onboarding", () => {
       "",                  // Workspace path: default
       "",                  // Enable Gateway HTTP: default yes
       "",                  // Gateway port: default 8787
+      "",                  // Discord channelAllowList (empty)
+      "",                  // Discord memberAllowList (empty)
       "539066683",         // Telegram allowList
       "",                  // Enable Playwright MCP: default yes
       "",                  // Additional write-tool user IDs (empty = use only channel users)
+      "",                  // Confirm before each write: default yes
+      "",                  // Approver: empty = whoever asked
+      "",                  // Ask where: default same chat
+      "",                  // Confirmation timeout: default 60s
     ];
 
     await runOnboarding({ appConfigPath });
@@ -167,8 +173,10 @@ describe("onboarding", () => {
     expect(config?.telegram && "token" in config.telegram).toBe(false);
     expect(config?.tools?.allowWrite).toBe(true);
     expect(config?.security?.writeToolAllowList).toEqual(["539066683"]);
-    expect(config?.security?.writeGateEnabled).toBe(false);
-    expect(config?.security?.writeToolConfirmation).toBe(false);
+    expect(config?.security?.writeGateEnabled).toBe(true);
+    expect(config?.security?.writeToolConfirmation).toBe(true);
+    expect(config?.security?.writeToolConfirmationIn).toBe("chat");
+    expect(config?.security?.writeToolConfirmationTimeoutMs).toBe(60_000);
 
     expect(secrets?.discord?.token).toBe("discord-secret");
     expect(secrets?.telegram?.token).toBe("telegram-secret");
@@ -416,8 +424,10 @@ describe("onboarding", () => {
       "",                  // Discord token (skip)
       "",                  // Workspace path: default
       "",                  // Enable Gateway HTTP: default yes
+      "",                  // Gateway port: default 8787
+      "",                  // Discord channelAllowList (empty)
+      "",                  // Discord memberAllowList (empty)
       "",                  // Enable Playwright MCP: default yes
-      "",                  // Additional write-tool user IDs (empty)
     ];
 
     await runOnboarding({ appConfigPath });
@@ -470,6 +480,7 @@ describe("onboarding", () => {
         "",          // Gateway port: default 8787
         "",          // Telegram allowList (empty - will use reused values)
       "",                  // Enable Playwright MCP: default yes
+        "",  // Overwrite the existing files with the changes shown? -> default yes
       ];
 
       await runOnboarding({ appConfigPath });
@@ -542,6 +553,7 @@ describe("onboarding", () => {
         "",  // Gateway port: default 8787
         "",  // Telegram allowList (empty - will use reused values)
       "",                  // Enable Playwright MCP: default yes
+        "",  // Overwrite the existing files with the changes shown? -> default yes
       ];
 
       await runOnboarding({ appConfigPath });
@@ -597,6 +609,7 @@ describe("onboarding", () => {
         "",  // Gateway port: default 8787
         "",  // Telegram allowList (empty - will use reused values)
       "",                  // Enable Playwright MCP: default yes
+        "",  // Overwrite the existing files with the changes shown? -> default yes
       ];
 
       await runOnboarding({ appConfigPath });
@@ -654,6 +667,7 @@ describe("onboarding", () => {
         "",  // "Which port should I use on your machine for Gateway HTTP?" -> default
         "",  // Telegram allowList (empty)
       "",                  // Enable Playwright MCP: default yes
+        "",  // Overwrite the existing files with the changes shown? -> default yes
       ];
 
       await runOnboarding({ docker: true, outputDir: dir });
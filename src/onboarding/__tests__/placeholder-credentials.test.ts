@@ -0,0 +1,121 @@
+/**
+ * Unit tests for onboarding/steps/placeholder-credentials.ts
+ */
+
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  }),
+}));
+
+vi.mock("node:child_process", () => ({
+  spawn: vi.fn(() => ({ on: vi.fn(), unref: vi.fn() })),
+}));
+
+import { createInterface } from "node:readline";
+import { detectPlaceholderCredential, askCredential, CREDENTIAL_DOCS } from "../steps/placeholder-credentials.js";
+import { browserCommand, selectOption } from "../shared.js";
+import { askIdList, ID_DOCS } from "../steps/access-setup.js";
+
+describe("detectPlaceholderCredential", () => {
+  it.each([
+    "changeme",
+    "sk-xxxx",
+    "sk-ant-api03-xxxxxxxx",
+    "sk-ant-oat01-",
+    "sk-ant-...",
+    "<YOUR_API_KEY>",
+    "${ANTHROPIC_API_KEY}",
+    "your-api-key-here",
+    "123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11",
+  ])("refuses %s", (value) => {
+    expect(detectPlaceholderCredential(value)).not.toBeNull();
+  });
+
+  it.each([
+    "",
+    "sk-ant-api03-test",
+    "sk-test-key",
+    "sk-ant-oat01-" + "a".repeat(68),
+    "discord-token-123",
+    "7012345678:AAH3kL9-Vx2qTz",
+  ])("accepts %s", (value) => {
+    expect(detectPlaceholderCredential(value)).toBeNull();
+  });
+});
+
+describe("askCredential", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  it("asks again until the value is not a placeholder", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["sk-xxxx", "changeme", "sk-real-key"];
+    expect(await askCredential(rl, "OpenAI API key: ", "openai")).toBe("sk-real-key");
+    expect(answers).toEqual([]);
+  });
+
+  it("returns an empty answer as-is", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = [""];
+    expect(await askCredential(rl, "OpenAI API key: ", "openai")).toBe("");
+  });
+
+  it("shows the docs on \"d\" and asks again", async () => {
+    const log = vi.spyOn(console, "log").mockImplementation(() => {});
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["d", "123:abc"];
+
+    expect(await askCredential(rl, "Telegram bot token: ", "telegram")).toBe("123:abc");
+    expect(log.mock.calls.flat().join("\n")).toContain(CREDENTIAL_DOCS.telegram);
+  });
+});
+
+describe("docs links on other prompts", () => {
+  it("opens the docs from a numbered choice, then takes the pick", async () => {
+    const log = vi.spyOn(console, "log").mockImplementation(() => {});
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["d", "2"];
+
+    expect(await selectOption(rl, "Where?", ["Discord", "Telegram"], 0, "https://example.test/channels")).toBe(1);
+    expect(log.mock.calls.flat().join("\n")).toContain("https://example.test/channels");
+  });
+
+  it("opens the ID docs from an allowlist question", async () => {
+    const log = vi.spyOn(console, "log").mockImplementation(() => {});
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["d", "123456789"];
+
+    expect(await askIdList(rl, "Telegram user IDs: ", "telegram")).toEqual(["123456789"]);
+    expect(log.mock.calls.flat().join("\n")).toContain(ID_DOCS.telegram);
+  });
+});
+
+describe("browserCommand", () => {
+  const url = "https://example.test/docs";
+
+  it("uses the platform opener on a terminal", () => {
+    expect(browserCommand(url, "darwin", {}, true)).toEqual({ cmd: "open", args: [url] });
+    expect(browserCommand(url, "linux", { DISPLAY: ":0" }, true)).toEqual({ cmd: "xdg-open", args: [url] });
+  });
+
+  it("falls back to printing without a terminal or display", () => {
+    expect(browserCommand(url, "darwin", {}, false)).toBeNull();
+    expect(browserCommand(url, "linux", {}, true)).toBeNull();
+  });
+});
@@ -0,0 +1,121 @@
+/**
+ * Unit tests for onboarding/steps/port-check.ts
+ */
+
+import { describe, it, expect, vi, beforeEach } from "vitest";
+import { createServer, type Server, type AddressInfo } from "node:net";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import {
+  isPortFree,
+  findNextFreePort,
+  containersPublishingPort,
+  checkGatewayPort,
+  defaultPortCheckDeps,
+  hostPortDeps,
+} from "../steps/port-check.js";
+
+function listen(): Promise<Server> {
+  return new Promise((resolve) => {
+    const server = createServer();
+    server.listen(0, "127.0.0.1", () => resolve(server));
+  });
+}
+
+describe("gateway port check", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  it("detects a bound port", async () => {
+    const server = await listen();
+    const { port } = server.address() as AddressInfo;
+    try {
+      expect(await isPortFree(port)).toBe(false);
+    } finally {
+      await new Promise((r) => server.close(r));
+    }
+    expect(await isPortFree(port)).toBe(true);
+  });
+
+  it("finds the next free port", async () => {
+    const taken = new Set([8788, 8789]);
+    expect(await findNextFreePort(8787, async (p) => !taken.has(p))).toBe(8790);
+    expect(await findNextFreePort(8787, async () => false)).toBeNull();
+  });
+
+  it("lists containers publishing the port", () => {
+    const exec = vi.fn(() => "owliabot\nold-bot\n");
+    expect(containersPublishingPort(8787, exec, "docker")).toEqual(["owliabot", "old-bot"]);
+    expect(exec).toHaveBeenCalledWith("docker", ["ps", "--filter", "publish=8787", "--format", "{{.Names}}"]);
+    containersPublishingPort(8787, exec, "podman");
+    expect(exec).toHaveBeenLastCalledWith("podman", ["ps", "--filter", "publish=8787", "--format", "{{.Names}}"]);
+    expect(containersPublishingPort(8787, () => { throw new Error("no docker"); })).toEqual([]);
+  });
+
+  it("keeps a free port without asking", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    const port = await checkGatewayPort(rl, "8787", { isFree: async () => true, containers: () => [] });
+    expect(port).toBe("8787");
+  });
+
+  it("suggests the next free port and names the container", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    const logs: string[] = [];
+    vi.mocked(console.log).mockImplementation((msg?: unknown) => { logs.push(String(msg)); });
+    answers = ["y"];
+
+    const port = await checkGatewayPort(rl, "8787", {
+      isFree: async (p) => p !== 8787,
+      containers: () => ["owliabot"],
+    });
+
+    expect(port).toBe("8788");
+    expect(logs.join("\n")).toContain("docker stop owliabot");
+  });
+
+  it("keeps the port when the suggestion is declined", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["n"];
+    const port = await checkGatewayPort(rl, "8787", { isFree: async (p) => p !== 8787, containers: () => [] });
+    expect(port).toBe("8787");
+  });
+
+  it("uses the host ports install.sh probed when inside the container", async () => {
+    const deps = hostPortDeps({ OWLIABOT_HOST_PORTS_BUSY: "8787,8788", OWLIABOT_HOST_PORT_OWNERS: "8787:owliabot" })!;
+    expect(await deps.isFree(8787)).toBe(false);
+    expect(await deps.isFree(8789)).toBe(true);
+    expect(deps.containers(8787)).toEqual(["owliabot"]);
+    expect(deps.containers(8788)).toEqual([]);
+
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["y"];
+    expect(await checkGatewayPort(rl, "8787", deps)).toBe("8789");
+  });
+
+  it("skips the check in a container install.sh didn't probe for", async () => {
+    expect(defaultPortCheckDeps({}, true)).toBeNull();
+    expect(defaultPortCheckDeps({ OWLIABOT_HOST_PORTS_BUSY: "" }, true)).not.toBeNull();
+    expect(defaultPortCheckDeps({}, false)).not.toBeNull();
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    expect(await checkGatewayPort(rl, "8787", null)).toBe("8787");
+  });
+});
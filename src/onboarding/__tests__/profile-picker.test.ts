@@ -0,0 +1,68 @@
+/**
+ * Unit tests for onboarding/steps/profile-picker.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import type { ProfileInfo } from "../../config/profiles.js";
+import { describeProfile, pickProfile } from "../steps/profile-picker.js";
+
+const HOME = "/home/u";
+const PROFILES: ProfileInfo[] = [
+  { name: "default", dir: "/home/u/.owliabot", configured: true },
+  { name: "work", dir: "/home/u/.owliabot-work", configured: false },
+];
+
+describe("profile picker", () => {
+  const rl = createInterface({ input: process.stdin, output: process.stdout });
+
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  afterEach(() => {
+    vi.restoreAllMocks();
+  });
+
+  it("describes a profile with its dir", () => {
+    expect(describeProfile(PROFILES[0], HOME)).toBe("default (~/.owliabot)");
+    expect(describeProfile(PROFILES[1], HOME)).toBe("work (~/.owliabot-work, not set up yet)");
+  });
+
+  it("doesn't ask on a first install", async () => {
+    await expect(pickProfile(rl, [], HOME)).resolves.toBeUndefined();
+  });
+
+  it("returns undefined for the default profile and the name otherwise", async () => {
+    answers = [""];
+    await expect(pickProfile(rl, PROFILES, HOME)).resolves.toBeUndefined();
+
+    answers = ["2"];
+    await expect(pickProfile(rl, PROFILES, HOME)).resolves.toBe("work");
+  });
+
+  it("asks again for an invalid or taken name", async () => {
+    answers = ["3", "My Bot", "work", "Lab"];
+    await expect(pickProfile(rl, PROFILES, HOME)).resolves.toBe("lab");
+    expect(answers).toEqual([]);
+  });
+});
@@ -0,0 +1,46 @@
+/**
+ * Unit tests for onboarding/steps/provider-priority.ts
+ */
+
+import { describe, it, expect } from "vitest";
+import type { ProviderConfig } from "../types.js";
+import { applyReorder, formatProviderChain, withPriorities } from "../steps/provider-priority.js";
+
+const providers = [
+  { id: "anthropic", model: "claude-opus-4-5", apiKey: "secrets", priority: 1 },
+  { id: "openai", model: "gpt-5.2", apiKey: "env", priority: 2 },
+  { id: "openai-compatible", model: "llama3.2", baseUrl: "http://localhost:11434/v1", apiKey: "none", priority: 3 },
+] as ProviderConfig[];
+const ids = (list: ProviderConfig[] | null) => list?.map((p) => p.id);
+
+describe("provider-priority", () => {
+  it("moves one provider up or down", () => {
+    expect(ids(applyReorder(providers, "3 up"))).toEqual(["anthropic", "openai-compatible", "openai"]);
+    expect(ids(applyReorder(providers, "1 d"))).toEqual(["openai", "anthropic", "openai-compatible"]);
+  });
+
+  it("keeps the order when moving past either end", () => {
+    expect(ids(applyReorder(providers, "1 up"))).toEqual(["anthropic", "openai", "openai-compatible"]);
+    expect(ids(applyReorder(providers, "3 down"))).toEqual(["anthropic", "openai", "openai-compatible"]);
+  });
+
+  it("accepts a complete new order", () => {
+    expect(ids(applyReorder(providers, "3,1,2"))).toEqual(["openai-compatible", "anthropic", "openai"]);
+    expect(ids(applyReorder(providers, "2 3 1"))).toEqual(["openai", "openai-compatible", "anthropic"]);
+  });
+
+  it("rejects answers it can't apply", () => {
+    expect(applyReorder(providers, "4 up")).toBeNull();
+    expect(applyReorder(providers, "1,2")).toBeNull();
+    expect(applyReorder(providers, "1,1,2")).toBeNull();
+    expect(applyReorder(providers, "first")).toBeNull();
+  });
+
+  it("renumbers priorities and prints the chain in priority order", () => {
+    const reordered = withPriorities([providers[2], providers[0], providers[1]]);
+    expect(reordered.map((p) => p.priority)).toEqual([1, 2, 3]);
+    expect(formatProviderChain([...reordered].reverse())).toBe(
+      "openai-compatible (llama3.2) → anthropic (claude-opus-4-5) → openai (gpt-5.2)",
+    );
+  });
+});
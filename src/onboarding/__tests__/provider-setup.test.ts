@@ -151,7 +151,7 @@ describe("provider-setup step", () => {
     });
 
     it("configures setup-token when sk-ant-oat01- prefix", async () => {
-      answers = ["sk-ant-oat01-" + "x".repeat(68), ""];
+      answers = ["sk-ant-oat01-" + "a".repeat(68), ""];
       const state = makeState();
       await maybeConfigureAnthropic(rl, state, 0);
       expect(state.secrets.anthropic?.token).toBeDefined();
@@ -180,6 +180,25 @@ describe("provider-setup step", () => {
       await maybeConfigureAnthropic(rl, state, 0);
       expect(state.providers[0].model).toBe("claude-sonnet-4-20250514");
     });
+
+    it("runs the Claude login before asking for the token", async () => {
+      const login = vi.fn(() => true);
+      answers = ["y", "sk-ant-oat01-" + "a".repeat(68), ""];
+      const state = makeState();
+      await maybeConfigureAnthropic(rl, state, 0, login);
+      expect(login).toHaveBeenCalledOnce();
+      expect(rl.pause).toHaveBeenCalled();
+      expect(state.secrets.anthropic?.token).toBe("sk-ant-oat01-" + "a".repeat(68));
+    });
+
+    it("skips the Claude login when declined", async () => {
+      const login = vi.fn(() => true);
+      answers = ["", "sk-ant-api03-test", ""];
+      const state = makeState();
+      await maybeConfigureAnthropic(rl, state, 0, login);
+      expect(login).not.toHaveBeenCalled();
+      expect(state.secrets.anthropic?.apiKey).toBe("sk-ant-api03-test");
+    });
   });
 
   // ── maybeConfigureOpenAI ────────────────────────────────────────────────
@@ -271,6 +290,44 @@ describe("provider-setup step", () => {
       await maybeConfigureOpenAICompatible(rl, state, 3);
       expect(state.providers[0].apiKey).toBe("none");
     });
+
+    it("picks a catalog endpoint by number and suggests its model", async () => {
+      answers = ["5", "", ""];
+      const state = makeState();
+      state.modelPresets = {
+        models: { anthropic: "a", openai: "o", "openai-codex": "c", "openai-compatible": "llama3.2" },
+        compatibleEndpoints: [
+          { name: "Ollama", baseUrl: "http://localhost:11434/v1" },
+          { name: "vLLM", baseUrl: "http://localhost:8000/v1" },
+          { name: "LM Studio", baseUrl: "http://localhost:1234/v1" },
+          { name: "LocalAI", baseUrl: "http://localhost:8080/v1" },
+          { name: "DeepSeek", baseUrl: "https://api.deepseek.com/v1", model: "deepseek-chat" },
+        ],
+      };
+      await maybeConfigureOpenAICompatible(rl, state, 3);
+      expect((state.providers[0] as any).baseUrl).toBe("https://api.deepseek.com/v1");
+      expect(state.providers[0].model).toBe("deepseek-chat");
+    });
+
+    it("offers the model list of a hosted preset and takes its default on Enter", async () => {
+      answers = ["5", "", "gsk-test-key"];
+      const state = makeState();
+      await maybeConfigureOpenAICompatible(rl, state, 3, false);
+      expect(state.providers[0]).toMatchObject({
+        baseUrl: "https://api.groq.com/openai/v1",
+        model: "llama-3.3-70b-versatile",
+        apiKey: "secrets",
+      });
+      expect(state.secrets["openai-compatible"]?.apiKey).toBe("gsk-test-key");
+    });
+
+    it("picks another model of a hosted preset by number", async () => {
+      answers = ["6", "2", "mistral-key"];
+      const state = makeState();
+      await maybeConfigureOpenAICompatible(rl, state, 3, false);
+      expect((state.providers[0] as any).baseUrl).toBe("https://api.mistral.ai/v1");
+      expect(state.providers[0].model).toBe("mistral-small-latest");
+    });
   });
 
   // ── askProviders ────────────────────────────────────────────────────────
@@ -289,11 +346,23 @@ describe("provider-setup step", () => {
     });
 
     it("configures all on choice 5 (multiple)", async () => {
-      const token = "sk-ant-oat01-" + "x".repeat(68);
-      answers = ["5", token, "", "", "", "n", "http://localhost:11434/v1", "", ""];
+      const token = "sk-ant-oat01-" + "a".repeat(68);
+      answers = ["5", token, "", "", "", "n", "http://localhost:11434/v1", "", "", ""];
       const result = await askProviders(rl, false);
       expect(result.providers).toHaveLength(4);
     });
+
+    it("lets the user reorder the fallback chain on choice 5 (multiple)", async () => {
+      const token = "sk-ant-oat01-" + "a".repeat(68);
+      answers = ["5", token, "", "", "", "n", "http://localhost:11434/v1", "", "", "4,1,2,3", "2 down", ""];
+      const result = await askProviders(rl, false);
+      expect(result.providers.map((p) => [p.id, p.priority])).toEqual([
+        ["openai-compatible", 1],
+        ["openai", 2],
+        ["anthropic", 3],
+        ["openai-codex", 4],
+      ]);
+    });
   });
 
   // ── getProvidersSetup ───────────────────────────────────────────────────
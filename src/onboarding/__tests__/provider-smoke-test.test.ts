@@ -0,0 +1,144 @@
+/**
+ * Unit tests for onboarding/steps/provider-smoke-test.ts
+ */
+
+import { describe, it, expect, vi } from "vitest";
+import type { ProviderConfig } from "../types.js";
+import { ValidationClient } from "../steps/validation-client.js";
+import {
+  smokeTestTarget,
+  runSmokeTest,
+  runFailoverTest,
+  withInvalidKey,
+  FAILOVER_TEST_KEY,
+  type SmokeTestTarget,
+  type SmokeTestResult,
+} from "../steps/provider-smoke-test.js";
+
+function clientReturning(res: Response) {
+  const fetchImpl = vi.fn(async () => res);
+  return { client: new ValidationClient({ fetchImpl: fetchImpl as unknown as typeof fetch, retries: 0 }), fetchImpl };
+}
+
+const anthropic = { id: "anthropic", model: "claude-sonnet-4-5", apiKey: "secrets", priority: 1 } as ProviderConfig;
+
+describe("smokeTestTarget", () => {
+  it("uses x-api-key for Anthropic API keys and Bearer for setup-tokens", () => {
+    const api = smokeTestTarget(anthropic, { anthropic: { apiKey: "sk-ant-api03-x" } }, {});
+    expect(api?.url).toBe("https://api.anthropic.com/v1/models/claude-sonnet-4-5");
+    expect(api?.headers["x-api-key"]).toBe("sk-ant-api03-x");
+
+    const oat = smokeTestTarget(anthropic, { anthropic: { token: "sk-ant-oat01-x" } }, {});
+    expect(oat?.headers.Authorization).toBe("Bearer sk-ant-oat01-x");
+  });
+
+  it("falls back to env keys and skips providers without a key", () => {
+    const openai = { id: "openai", model: "gpt-4o", apiKey: "env", priority: 1 } as ProviderConfig;
+    expect(smokeTestTarget(openai, {}, { OPENAI_API_KEY: "sk-1" })?.headers.Authorization).toBe("Bearer sk-1");
+    expect(smokeTestTarget(openai, {}, {})).toBeNull();
+    expect(smokeTestTarget({ id: "openai-codex", model: "x", apiKey: "oauth", priority: 1 } as ProviderConfig, {}, {})).toBeNull();
+  });
+
+  it("lists models on OpenAI-compatible servers", () => {
+    const target = smokeTestTarget(
+      { id: "openai-compatible", model: "llama3.2", baseUrl: "http://localhost:11434/v1/", apiKey: "none", priority: 1 } as ProviderConfig,
+      {},
+      {},
+    );
+    expect(target?.url).toBe("http://localhost:11434/v1/models");
+    expect(target?.listModels).toBe(true);
+  });
+});
+
+describe("runSmokeTest", () => {
+  const target = { label: "OpenAI", url: "https://api.openai.com/v1/models/gpt-4o", headers: {}, model: "gpt-4o" };
+
+  it("reports latency and availability", async () => {
+    const { client } = clientReturning(new Response("{}", { status: 200 }));
+    const times = [1000, 1240];
+    const result = await runSmokeTest(target, client, () => times.shift()!);
+    expect(result).toEqual({ kind: "ok", latencyMs: 240, modelAvailable: true });
+  });
+
+  it("reports rejected keys and missing models", async () => {
+    expect(await runSmokeTest(target, clientReturning(new Response("{}", { status: 401 })).client)).toEqual({
+      kind: "auth-error",
+      status: 401,
+    });
+    const missing = await runSmokeTest(target, clientReturning(new Response("{}", { status: 404 })).client);
+    expect(missing.kind === "ok" && missing.modelAvailable).toBe(false);
+  });
+
+  it("searches the model list for OpenAI-compatible servers", async () => {
+    const body = JSON.stringify({ data: [{ id: "llama3.2" }, { id: "qwen2.5" }] });
+    const { client } = clientReturning(new Response(body, { status: 200 }));
+    const result = await runSmokeTest({ ...target, model: "qwen2.5", listModels: true }, client);
+    expect(result.kind === "ok" && result.modelAvailable).toBe(true);
+  });
+});
+
+describe("runFailoverTest", () => {
+  const openai = { id: "openai", model: "gpt-4o", apiKey: "secrets", priority: 2 } as ProviderConfig;
+  const secrets = { anthropic: { apiKey: "sk-ant-api03-x" }, openai: { apiKey: "sk-1" } };
+
+  function runner(results: Record<string, SmokeTestResult>) {
+    const calls: SmokeTestTarget[] = [];
+    const run = async (target: SmokeTestTarget) => {
+      calls.push(target);
+      return results[target.label];
+    };
+    return { run, calls };
+  }
+
+  it("swaps the primary's key for an invalid one", () => {
+    const target = smokeTestTarget(anthropic, secrets, {})!;
+    expect(withInvalidKey(target)?.headers["x-api-key"]).toBe(FAILOVER_TEST_KEY);
+    expect(withInvalidKey({ ...target, headers: {} })).toBeNull();
+  });
+
+  it("confirms the secondary answers when the primary fails", async () => {
+    const { run, calls } = runner({
+      Anthropic: { kind: "auth-error", status: 401 },
+      OpenAI: { kind: "ok", latencyMs: 120, modelAvailable: true },
+    });
+    const result = await runFailoverTest([openai, anthropic], secrets, run, {});
+    expect(calls.map((c) => c.label)).toEqual(["Anthropic", "OpenAI"]);
+    expect(calls[0].headers["x-api-key"]).toBe(FAILOVER_TEST_KEY);
+    expect(result).toMatchObject({ kind: "ok", primary: "Anthropic", servedBy: "OpenAI", latencyMs: 120 });
+  });
+
+  it("reports when no fallback answers", async () => {
+    const { run } = runner({
+      Anthropic: { kind: "auth-error", status: 401 },
+      OpenAI: { kind: "ok", latencyMs: 80, modelAvailable: false },
+    });
+    const result = await runFailoverTest([anthropic, openai], secrets, run, {});
+    expect(result).toMatchObject({ kind: "no-fallback", tried: [{ label: "OpenAI" }] });
+  });
+
+  it("can't simulate a failure when the primary accepts the invalid key", async () => {
+    const { run } = runner({ Anthropic: { kind: "ok", latencyMs: 10, modelAvailable: true } });
+    expect(await runFailoverTest([anthropic, openai], secrets, run, {})).toEqual({
+      kind: "primary-accepted",
+      primary: "Anthropic",
+    });
+  });
+
+  it("leaves out an OAuth primary and marks untestable fallbacks", async () => {
+    const codex = { id: "openai-codex", model: "gpt-5", apiKey: "oauth", priority: 1 } as ProviderConfig;
+    const codexSecond = { ...codex, priority: 2 } as ProviderConfig;
+    const third = { ...openai, priority: 3 } as ProviderConfig;
+    const { run, calls } = runner({
+      Anthropic: { kind: "auth-error", status: 401 },
+      OpenAI: { kind: "ok", latencyMs: 50, modelAvailable: true },
+    });
+
+    const result = await runFailoverTest([anthropic, codexSecond, third], secrets, run, {});
+    expect(result).toMatchObject({ kind: "ok", servedBy: "OpenAI" });
+    expect(result.kind === "ok" && result.tried[0]).toEqual({ label: "openai-codex", result: { kind: "untested" } });
+
+    calls.length = 0;
+    await runFailoverTest([codex, openai], secrets, run, {});
+    expect(calls.map((c) => c.label)).toEqual(["OpenAI"]);
+  });
+});
@@ -0,0 +1,152 @@
+/**
+ * Unit tests for onboarding/steps/reverse-proxy.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import os from "node:os";
+import path from "node:path";
+import { existsSync, mkdtempSync, readFileSync, rmSync } from "node:fs";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { parse } from "yaml";
+import { AbortError } from "../shared.js";
+import { buildDockerComposeYaml, composeUpCommand } from "../steps/docker.js";
+import {
+  buildCaddyfile,
+  buildTraefikDynamicConfig,
+  confirmReverseProxyAuth,
+  normalizeDomain,
+  parseReverseProxy,
+  promptExposeGateway,
+  promptReverseProxySetup,
+  writeReverseProxyConfig,
+  type ReverseProxySetup,
+} from "../steps/reverse-proxy.js";
+
+const CADDY: ReverseProxySetup = { proxy: "caddy", domain: "bot.example.com", email: "ops@example.com" };
+const TRAEFIK: ReverseProxySetup = { proxy: "traefik", domain: "bot.example.com" };
+
+describe("reverse proxy step", () => {
+  let rl: ReturnType<typeof createInterface>;
+
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+    rl = createInterface({ input: process.stdin, output: process.stdout });
+  });
+
+  afterEach(() => {
+    vi.restoreAllMocks();
+  });
+
+  it("parses proxies and domains", () => {
+    expect(parseReverseProxy(undefined)).toBeUndefined();
+    expect(parseReverseProxy("Traefik")).toBe("traefik");
+    expect(() => parseReverseProxy("nginx")).toThrow(/caddy, traefik/);
+    expect(normalizeDomain("https://Bot.Example.com/")).toBe("bot.example.com");
+    expect(normalizeDomain("localhost")).toBeUndefined();
+    expect(normalizeDomain("10.0.0.1")).toBeUndefined();
+  });
+
+  it("asks for the domain until it is one, then the email", async () => {
+    answers.push("not a domain", "bot.example.com", "");
+    await expect(promptReverseProxySetup(rl, "caddy")).resolves.toEqual({
+      proxy: "caddy",
+      domain: "bot.example.com",
+      email: undefined,
+    });
+  });
+
+  it("gives up after three bad domains", async () => {
+    answers.push("a", "b", "c");
+    await expect(promptReverseProxySetup(rl, "traefik")).rejects.toBeInstanceOf(AbortError);
+  });
+
+  it("requires gateway auth before exposing the gateway", async () => {
+    await expect(confirmReverseProxyAuth(rl, "basic", false)).resolves.toBe("basic");
+    await expect(confirmReverseProxyAuth(rl, "none", false)).rejects.toThrow(/--gateway-auth basic/);
+
+    answers.push("");
+    await expect(confirmReverseProxyAuth(rl, "none", true)).resolves.toBe("basic");
+    answers.push("n", "y");
+    await expect(confirmReverseProxyAuth(rl, "none", true)).resolves.toBe("none");
+    answers.push("n", "");
+    await expect(confirmReverseProxyAuth(rl, "none", true)).resolves.toBeUndefined();
+  });
+
+  it("only offers to expose the gateway interactively", async () => {
+    await expect(promptExposeGateway(rl, false)).resolves.toBeUndefined();
+    answers.push("y", "2");
+    await expect(promptExposeGateway(rl, true)).resolves.toBe("traefik");
+  });
+
+  it("builds a Caddyfile", () => {
+    expect(buildCaddyfile(CADDY)).toBe(
+      "{\n\temail ops@example.com\n}\n\nbot.example.com {\n\treverse_proxy http://owliabot:8787\n}\n",
+    );
+    expect(buildCaddyfile({ ...CADDY, email: undefined }, true)).toContain(
+      "reverse_proxy https://owliabot:8787 {\n\t\ttransport http {\n\t\t\ttls_insecure_skip_verify",
+    );
+  });
+
+  it("builds Traefik's dynamic config", () => {
+    const config = parse(buildTraefikDynamicConfig(TRAEFIK, true));
+
+    expect(config.http.routers.owliabot).toEqual({
+      rule: "Host(`bot.example.com`)",
+      entryPoints: ["websecure"],
+      service: "owliabot",
+      tls: { certResolver: "letsencrypt" },
+    });
+    expect(config.http.services.owliabot.loadBalancer.servers).toEqual([{ url: "https://owliabot:8787" }]);
+    expect(config.http.serversTransports.owliabot.insecureSkipVerify).toBe(true);
+    expect(parse(buildTraefikDynamicConfig(TRAEFIK)).http.serversTransports).toBeUndefined();
+  });
+
+  it("puts the proxy on 80/443 and stops publishing the gateway", () => {
+    const services = parse(
+      buildDockerComposeYaml("~/.owliabot", ["TZ=UTC"], "8787", "test:latest", { reverseProxy: CADDY }),
+    ).services;
+
+    expect(services.owliabot.ports).toBeUndefined();
+    expect(services.caddy.ports).toEqual(["80:80", "443:443", "443:443/udp"]);
+    expect(services.caddy.volumes).toContain("~/.owliabot/Caddyfile:/etc/caddy/Caddyfile:ro");
+
+    const traefik = parse(
+      buildDockerComposeYaml("~/.owliabot", ["TZ=UTC"], "8787", "test:latest", { reverseProxy: TRAEFIK }),
+    ).services.traefik;
+    expect(traefik.command).toContain("--providers.file.filename=/etc/traefik/traefik-dynamic.yml");
+    expect(traefik.command.some((c: string) => c.includes("acme.email"))).toBe(false);
+    expect(composeUpCommand({ profiles: true, reverseProxy: TRAEFIK })).toBe("docker compose --profile proxy up -d");
+  });
+
+  it("writes the config and the certificate dir", () => {
+    const dir = mkdtempSync(path.join(os.tmpdir(), "owliabot-reverse-proxy-"));
+    try {
+      writeReverseProxyConfig(dir, CADDY);
+      expect(readFileSync(path.join(dir, "Caddyfile"), "utf-8")).toContain("bot.example.com {");
+      expect(existsSync(path.join(dir, "caddy", "data"))).toBe(true);
+
+      writeReverseProxyConfig(dir, TRAEFIK);
+      expect(readFileSync(path.join(dir, "traefik-dynamic.yml"), "utf-8")).toContain("Host(`bot.example.com`)");
+      expect(existsSync(path.join(dir, "traefik"))).toBe(true);
+    } finally {
+      rmSync(dir, { recursive: true, force: true });
+    }
+  });
+});
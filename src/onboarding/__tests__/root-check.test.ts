@@ -0,0 +1,132 @@
+/**
+ * Unit tests for root-check step:
+ * - detectRootInvocation
+ * - confirmRootInvocation
+ * - chownTree
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+
+let answers: string[] = [];
+let promptLog: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      promptLog.push(q);
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  }),
+}));
+
+const chownSync = vi.fn();
+vi.mock("node:fs", async (importOriginal) => {
+  const original = await importOriginal<typeof import("node:fs")>();
+  return { ...original, chownSync: (...args: any[]) => chownSync(...args) };
+});
+
+import { createInterface } from "node:readline";
+import { mkdtemp, mkdir, rm, writeFile } from "node:fs/promises";
+import { tmpdir } from "node:os";
+import { join } from "node:path";
+import { AbortError } from "../shared.js";
+import {
+  detectRootInvocation,
+  confirmRootInvocation,
+  chownTree,
+} from "../steps/root-check.js";
+
+const SUDO_ENV = { SUDO_USER: "alice", SUDO_UID: "1000", SUDO_GID: "1000" };
+
+describe("root-check step", () => {
+  let consoleSpy: ReturnType<typeof vi.spyOn>;
+  let rl: ReturnType<typeof createInterface>;
+
+  beforeEach(() => {
+    consoleSpy = vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+    promptLog = [];
+    chownSync.mockClear();
+    rl = createInterface({ input: process.stdin, output: process.stdout });
+  });
+
+  afterEach(() => {
+    consoleSpy.mockRestore();
+  });
+
+  describe("detectRootInvocation", () => {
+    it("is a no-op for regular users", () => {
+      expect(detectRootInvocation(1000, SUDO_ENV)).toEqual({ isRoot: false, sudoTarget: null });
+    });
+
+    it("returns the sudo invoker when run via sudo", () => {
+      expect(detectRootInvocation(0, SUDO_ENV)).toEqual({
+        isRoot: true,
+        sudoTarget: { user: "alice", uid: 1000, gid: 1000 },
+      });
+    });
+
+    it("has no target for a plain root login", () => {
+      expect(detectRootInvocation(0, {})).toEqual({ isRoot: true, sudoTarget: null });
+      expect(detectRootInvocation(0, { SUDO_USER: "root", SUDO_UID: "0", SUDO_GID: "0" }).sudoTarget).toBeNull();
+    });
+  });
+
+  describe("confirmRootInvocation", () => {
+    it("does not prompt for non-root runs", async () => {
+      const target = await confirmRootInvocation(rl, { isRoot: false, sudoTarget: null }, "/cfg", true);
+      expect(target).toBeNull();
+      expect(promptLog).toHaveLength(0);
+    });
+
+    it("offers to hand files to the sudo user", async () => {
+      answers.push("");
+      const target = await confirmRootInvocation(rl, detectRootInvocation(0, SUDO_ENV), "/cfg", true);
+      expect(target).toEqual({ user: "alice", uid: 1000, gid: 1000 });
+      expect(promptLog[0]).toContain("alice");
+    });
+
+    it("aborts when the user declines to continue as root", async () => {
+      answers.push("n", "");
+      await expect(
+        confirmRootInvocation(rl, detectRootInvocation(0, SUDO_ENV), "/cfg", true),
+      ).rejects.toBeInstanceOf(AbortError);
+    });
+
+    it("keeps root ownership when explicitly confirmed", async () => {
+      answers.push("y");
+      const target = await confirmRootInvocation(rl, detectRootInvocation(0, {}), "/cfg", true);
+      expect(target).toBeNull();
+    });
+
+    it("only warns without a TTY", async () => {
+      const target = await confirmRootInvocation(rl, detectRootInvocation(0, SUDO_ENV), "/cfg", false);
+      expect(target).toEqual({ user: "alice", uid: 1000, gid: 1000 });
+      expect(promptLog).toHaveLength(0);
+    });
+  });
+
+  describe("chownTree", () => {
+    it("chowns every file and directory in the tree", async () => {
+      const dir = await mkdtemp(join(tmpdir(), "owliabot-chown-"));
+      try {
+        await mkdir(join(dir, "auth"));
+        await writeFile(join(dir, "app.yaml"), "x", "utf-8");
+        await writeFile(join(dir, "auth", "a.json"), "{}", "utf-8");
+
+        chownTree(dir, { user: "alice", uid: 1000, gid: 1001 });
+
+        const paths = chownSync.mock.calls.map((c) => c[0]).sort();
+        expect(paths).toEqual([dir, join(dir, "app.yaml"), join(dir, "auth"), join(dir, "auth", "a.json")].sort());
+        expect(chownSync).toHaveBeenCalledWith(dir, 1000, 1001);
+      } finally {
+        await rm(dir, { recursive: true, force: true });
+      }
+    });
+  });
+});
@@ -0,0 +1,71 @@
+/**
+ * Unit tests for onboarding/steps/screen-dump.ts
+ */
+
+import { describe, it, expect, afterEach } from "vitest";
+import { mkdtempSync, readFileSync, readdirSync, rmSync } from "node:fs";
+import { join } from "node:path";
+import { tmpdir } from "node:os";
+import { COLORS, header } from "../shared.js";
+import {
+  defaultAnswers,
+  splitScreens,
+  startScreenDump,
+  stripAnsi,
+  writeScreens,
+} from "../steps/screen-dump.js";
+
+describe("screen-dump", () => {
+  let dir: string | undefined;
+
+  afterEach(() => {
+    if (dir) rmSync(dir, { recursive: true, force: true });
+    dir = undefined;
+  });
+
+  it("strips colors", () => {
+    expect(stripAnsi(`${COLORS.GREEN}✓${COLORS.NC} Saved`)).toBe("✓ Saved");
+  });
+
+  it("takes the default first and escalates when a prompt repeats", () => {
+    const answer = defaultAnswers();
+    expect(answer("Port [8787]: ")).toBe("");
+    expect(answer("API key: ")).toBe("");
+    expect(answer("API key: ")).toBe("1");
+    expect(answer("API key: ")).toBe("y");
+    expect(() => answer("API key: ")).toThrow(/API key:/);
+  });
+
+  it("splits output into screens at headers, dropping blank edges", () => {
+    const screens = splitScreens(["", "banner", "", "━━━ AI providers ━━━", "", "Pick one", ""]);
+    expect(screens).toEqual([
+      { title: "start", lines: ["banner"] },
+      { title: "AI providers", lines: ["━━━ AI providers ━━━", "", "Pick one"] },
+    ]);
+  });
+
+  it("writes numbered files named after each screen", () => {
+    dir = mkdtempSync(join(tmpdir(), "owliabot-screens-"));
+    const paths = writeScreens(dir, [
+      { title: "start", lines: ["banner"] },
+      { title: "Chat platforms (Discord/Telegram)", lines: ["x"] },
+    ]);
+    expect(paths.map((p) => p.slice(dir!.length + 1))).toEqual(["01-start.txt", "02-chat-platforms-discord-telegram.txt"]);
+    expect(readFileSync(paths[1], "utf-8")).toBe("x\n");
+  });
+
+  it("records console output until finished, once", () => {
+    dir = mkdtempSync(join(tmpdir(), "owliabot-screens-"));
+    const out = join(dir, "screens");
+    const dump = startScreenDump(out);
+    console.log("intro");
+    header("Gateway");
+    console.log(`${COLORS.CYAN}port 8787${COLORS.NC}`);
+    dump.finish();
+    dump.finish();
+    console.log("after");
+
+    expect(readdirSync(out).sort()).toEqual(["01-start.txt", "02-gateway.txt"]);
+    expect(readFileSync(join(out, "02-gateway.txt"), "utf-8")).toBe("━━━ Gateway ━━━\n\nport 8787\n");
+  });
+});
@@ -0,0 +1,106 @@
+/**
+ * Unit tests for masked secret input (readMaskedSecret in onboarding/shared.ts)
+ */
+
+import { describe, it, expect, vi, afterEach } from "vitest";
+import { EventEmitter } from "node:events";
+import { AbortError, readMaskedSecret, setAccessible, type SecretInput } from "../shared.js";
+
+function fakeTerminal() {
+  const stdin = Object.assign(new EventEmitter(), {
+    isRaw: false,
+    setRawMode: vi.fn(function (this: { isRaw: boolean }, mode: boolean) {
+      this.isRaw = mode;
+      return this;
+    }),
+  });
+  let output = "";
+  const stdout = { write: (text: string) => { output += text; return true; } };
+  const type = (...keys: string[]) => keys.forEach((k) => stdin.emit("data", Buffer.from(k)));
+  return { stdin, stdout, type, output: () => output };
+}
+
+describe("readMaskedSecret", () => {
+  afterEach(() => {
+    setAccessible(false);
+    vi.useRealTimers();
+  });
+
+  it("draws a mask per character, never the secret, and restores the mode", async () => {
+    const term = fakeTerminal();
+    const answer = readMaskedSecret({} as any, "API key: ", { stdin: term.stdin as unknown as SecretInput, stdout: term.stdout });
+    term.type("s", "k", "x", "\x7f", "-", "1", "\r");
+    await expect(answer).resolves.toBe("sk-1");
+    expect(term.output()).toContain("API key: •••\b \b••");
+    expect(term.output()).not.toContain("sk");
+    expect(term.stdin.isRaw).toBe(false);
+    expect(term.stdin.listenerCount("data")).toBe(0);
+  });
+
+  it("shows the text on Ctrl+R until the timeout, then masks it again", async () => {
+    vi.useFakeTimers();
+    const term = fakeTerminal();
+    const answer = readMaskedSecret({} as any, "Setup\nToken: ", {
+      stdin: term.stdin as unknown as SecretInput,
+      stdout: term.stdout,
+      revealMs: 500,
+    });
+    term.type("a", "b", "\x12");
+    expect(term.output().endsWith("\r\x1b[KToken: ab")).toBe(true);
+    vi.advanceTimersByTime(500);
+    expect(term.output().endsWith("\r\x1b[KToken: ••")).toBe(true);
+    term.type("\r");
+    await expect(answer).resolves.toBe("ab");
+  });
+
+  it("uses ASCII masks in accessible mode", async () => {
+    setAccessible(true);
+    const term = fakeTerminal();
+    const answer = readMaskedSecret({} as any, "Token: ", { stdin: term.stdin as unknown as SecretInput, stdout: term.stdout });
+    term.type("a", "b", "\r");
+    await answer;
+    expect(term.output()).toContain("Token: **");
+  });
+
+  it("rejects with AbortError on Ctrl+C", async () => {
+    const term = fakeTerminal();
+    const answer = readMaskedSecret({} as any, "Token: ", { stdin: term.stdin as unknown as SecretInput, stdout: term.stdout });
+    term.type("a", "\x03");
+    await expect(answer).rejects.toThrow(AbortError);
+    expect(term.stdin.isRaw).toBe(false);
+  });
+});
+
+describe("readMaskedSecret bracketed paste", () => {
+  function start() {
+    const term = fakeTerminal();
+    const answer = readMaskedSecret({} as any, "Token: ", { stdin: term.stdin as unknown as SecretInput, stdout: term.stdout });
+    return { term, answer };
+  }
+
+  it("turns bracketed paste on while reading and off after", async () => {
+    const { term, answer } = start();
+    term.type("\r");
+    await answer;
+    expect(term.output()).toMatch(/^[^]*\x1b\[\?2004hToken: [^]*\x1b\[\?2004l\n$/);
+  });
+
+  it("takes a paste whole, without submitting on its newline", async () => {
+    const { term, answer } = start();
+    term.type("x", "\x1b[200~MTA4.abc\r\n\x1b[201~", "\r");
+    await expect(answer).resolves.toBe("xMTA4.abc");
+    expect(term.output()).toContain("Token: •••••••••");
+  });
+
+  it("joins a paste split across chunks, markers included", async () => {
+    const { term, answer } = start();
+    term.type("\x1b[20", "0~abc\x1b[2", "01~def\x1b[201", "~", "\r");
+    await expect(answer).resolves.toBe("abcdef");
+  });
+
+  it("handles several keys in one chunk and skips escape sequences", async () => {
+    const { term, answer } = start();
+    term.type("ab\x1b[Dc\x1bOPd\rignored");
+    await expect(answer).resolves.toBe("abcd");
+  });
+});
@@ -59,18 +59,54 @@ describe("security-setup step", () => {
     });
 
     it("merges discord and telegram IDs", async () => {
-      answers = [""];
+      answers = ["", "", "", "", ""];
       const config = {} as any;
       const userAllowLists = { discord: ["111"], telegram: ["222"] };
       const result = await configureWriteToolsSecurity(rl, config, userAllowLists);
       expect(result).toEqual(["111", "222"]);
       expect(config.security?.writeToolAllowList).toEqual(["111", "222"]);
-      expect(config.security?.writeGateEnabled).toBe(false);
+      expect(config.security?.writeGateEnabled).toBe(true);
       expect(config.tools?.allowWrite).toBe(true);
     });
 
+    it("asks for confirmation in the same chat from the requester by default", async () => {
+      answers = ["", "", "", "", ""];
+      const config = {} as any;
+      await configureWriteToolsSecurity(rl, config, { discord: ["111"], telegram: [] });
+      expect(config.security).toEqual({
+        writeGateEnabled: true,
+        writeToolAllowList: ["111"],
+        writeToolConfirmation: true,
+        writeToolConfirmationIn: "chat",
+        writeToolConfirmationTimeoutMs: 60_000,
+      });
+    });
+
+    it("stores the approver, DM and timeout answers", async () => {
+      answers = ["", "y", "111", "2", "120"];
+      const config = {} as any;
+      await configureWriteToolsSecurity(rl, config, { discord: ["111", "222"], telegram: [] });
+      expect(config.security).toMatchObject({
+        writeToolConfirmation: true,
+        writeToolConfirmationApprover: "111",
+        writeToolConfirmationIn: "dm",
+        writeToolConfirmationTimeoutMs: 120_000,
+      });
+    });
+
+    it("keeps the gate on when confirmation is turned off", async () => {
+      answers = ["", "n"];
+      const config = {} as any;
+      await configureWriteToolsSecurity(rl, config, { discord: ["111"], telegram: [] });
+      expect(config.security).toEqual({
+        writeGateEnabled: true,
+        writeToolAllowList: ["111"],
+        writeToolConfirmation: false,
+      });
+    });
+
     it("adds additional user IDs", async () => {
-      answers = ["333,444"];
+      answers = ["333,444", "n"];
       const config = {} as any;
       const userAllowLists = { discord: ["111"], telegram: [] as string[] };
       const result = await configureWriteToolsSecurity(rl, config, userAllowLists);
@@ -78,7 +114,7 @@ describe("security-setup step", () => {
     });
 
     it("deduplicates IDs", async () => {
-      answers = ["111"];
+      answers = ["111", "n"];
       const config = {} as any;
       const userAllowLists = { discord: ["111"], telegram: [] as string[] };
       const result = await configureWriteToolsSecurity(rl, config, userAllowLists);
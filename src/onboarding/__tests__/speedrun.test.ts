@@ -0,0 +1,51 @@
+/**
+ * Tests for speedrun mode in ask() (one line answering several prompts).
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import { EventEmitter } from "node:events";
+import { ask, askYN, setSpeedrun } from "../shared.js";
+
+function createMockRl(lines: string[]) {
+  const emitter = new EventEmitter();
+  const rl = Object.assign(emitter, {
+    question: vi.fn((_q: string, cb: (ans: string) => void) => {
+      const next = lines.shift();
+      if (next === undefined) throw new Error("Ran out of lines");
+      cb(next);
+    }),
+    close: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  });
+  return rl;
+}
+
+describe("speedrun mode", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+  });
+
+  afterEach(() => {
+    setSpeedrun(false);
+    vi.restoreAllMocks();
+  });
+
+  it("spreads one comma-separated line over the following prompts", async () => {
+    setSpeedrun(true);
+    const rl = createMockRl(["2, sk-test ,,y", "last"]);
+
+    expect(await ask(rl as any, "Provider: ")).toBe("2");
+    expect(await ask(rl as any, "Key: ", true)).toBe("sk-test");
+    expect(await ask(rl as any, "Model: ")).toBe("");
+    expect(await askYN(rl as any, "Continue?", false)).toBe(true);
+    expect(await ask(rl as any, "Next: ")).toBe("last");
+    expect(rl.question).toHaveBeenCalledTimes(2);
+    expect(console.log).toHaveBeenCalledWith("Key: ********");
+  });
+
+  it("keeps commas as typed when off", async () => {
+    const rl = createMockRl(["111,222"]);
+    expect(await ask(rl as any, "IDs: ")).toBe("111,222");
+  });
+});
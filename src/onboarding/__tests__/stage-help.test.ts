@@ -0,0 +1,91 @@
+/**
+ * Unit tests for onboarding/steps/stage-help.ts and the F1 / "h" hook in shared.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import { EventEmitter } from "node:events";
+import { askYN, selectOption, setStageHelp } from "../shared.js";
+import { hasStageHelp, pagerCommand, renderMarkdown, showStageHelp, STAGE_HELP } from "../steps/stage-help.js";
+
+function createMockRl(lines: string[]) {
+  const emitter = new EventEmitter();
+  return Object.assign(emitter, {
+    question: vi.fn((_q: string, cb: (ans: string) => void) => {
+      const next = lines.shift();
+      if (next === undefined) throw new Error("Ran out of lines");
+      cb(next);
+    }),
+    close: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  });
+}
+
+const strip = (text: string) => text.replace(/\x1b\[[0-9;]*m/g, "");
+
+describe("stage help", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+  });
+
+  afterEach(() => {
+    setStageHelp(null);
+    vi.restoreAllMocks();
+  });
+
+  it("has articles for the stages that ask about providers, channels, MCP and write gates", () => {
+    expect(hasStageHelp("providers")).toBe(true);
+    expect(hasStageHelp("channels")).toBe(true);
+    expect(STAGE_HELP.channels).toContain("Message Content Intent");
+    expect(STAGE_HELP.config).toContain("MCP");
+    expect(STAGE_HELP.config).toContain("Write gates");
+    expect(hasStageHelp("preflight")).toBe(false);
+  });
+
+  it("renders headings, lists and inline markup to ANSI", () => {
+    const text = renderMarkdown("# Title\n\nSome `code` and **bold**.\n\n- one\n- two", 80);
+
+    expect(text).toContain("\x1b[1mTitle");
+    expect(text).toContain("\x1b[1;33mcode\x1b[0m");
+    expect(text).toContain("\x1b[1mbold\x1b[22m");
+    expect(strip(text)).toBe("Title\n━━━━━\n\nSome code and bold.\n\n  • one\n  • two\n");
+  });
+
+  it("wraps to the width, counting visible characters", () => {
+    const text = strip(renderMarkdown("- alpha beta `gamma` delta epsilon", 20));
+
+    expect(text).toBe("  • alpha beta gamma\n    delta epsilon\n");
+    for (const article of Object.values(STAGE_HELP)) {
+      for (const line of strip(renderMarkdown(article, 60)).split("\n")) {
+        if (!line.includes("https://")) expect(line.length).toBeLessThanOrEqual(60);
+      }
+    }
+  });
+
+  it("uses $PAGER, else less, and no pager off a terminal", () => {
+    expect(pagerCommand({}, true)).toEqual({ cmd: "less", args: ["-R"] });
+    expect(pagerCommand({ PAGER: "more" }, true)).toEqual({ cmd: "sh", args: ["-c", "more"] });
+    expect(pagerCommand({}, false)).toBeNull();
+  });
+
+  it("prints the article without a pager", () => {
+    expect(showStageHelp("timezone", null, 80)).toBe(true);
+    expect(console.log).toHaveBeenCalledWith(expect.stringContaining("IANA"));
+    expect(showStageHelp("preflight", null, 80)).toBe(false);
+  });
+
+  it("shows the help for h at yes/no and numbered questions, then asks again", async () => {
+    const show = vi.fn();
+    setStageHelp(show);
+    const rl = createMockRl(["h", "y", "H", "2"]);
+
+    expect(await askYN(rl as any, "Continue?", false)).toBe(true);
+    expect(await selectOption(rl as any, "Pick one", ["a", "b"])).toBe(1);
+    expect(show).toHaveBeenCalledTimes(2);
+  });
+
+  it("leaves h alone without stage help", async () => {
+    const rl = createMockRl(["h"]);
+    expect(await askYN(rl as any, "Continue?", true)).toBe(false);
+  });
+});
@@ -0,0 +1,107 @@
+/**
+ * Unit tests for onboarding/steps/stage-timing.ts
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import { existsSync, mkdtempSync, readFileSync, rmSync } from "node:fs";
+import { join } from "node:path";
+import { tmpdir } from "node:os";
+import {
+  StageTimer,
+  formatDuration,
+  describeSetupTiming,
+  appendSetupHistory,
+  ONBOARDING_HISTORY_FILE,
+} from "../steps/stage-timing.js";
+
+describe("stage-timing", () => {
+  let clock: number;
+  const timer = () => new StageTimer(() => clock);
+
+  beforeEach(() => {
+    clock = 0;
+  });
+
+  it("measures each stage and the running one", () => {
+    const t = timer();
+    clock = 1_000;
+    t.enter("providers");
+    clock = 126_000;
+    t.enter("channels");
+    clock = 196_000;
+
+    expect(t.summary("done")).toEqual({
+      outcome: "done",
+      lastStage: "channels",
+      totalMs: 196_000,
+      stages: [
+        { stage: "providers", ms: 125_000 },
+        { stage: "channels", ms: 70_000 },
+      ],
+    });
+  });
+
+  it("adds up a stage entered twice", () => {
+    const t = timer();
+    t.enter("config");
+    clock = 2_000;
+    t.enter("write");
+    clock = 3_000;
+    t.enter("config");
+    clock = 6_000;
+    expect(t.summary("done").stages).toEqual([
+      { stage: "config", ms: 5_000 },
+      { stage: "write", ms: 1_000 },
+    ]);
+  });
+
+  it("formats durations", () => {
+    expect(formatDuration(252_000)).toBe("4m12s");
+    expect(formatDuration(65_400)).toBe("1m05s");
+    expect(formatDuration(38_000)).toBe("38s");
+    expect(formatDuration(200)).toBe("0s");
+  });
+
+  it("names the three slowest stages", () => {
+    expect(describeSetupTiming({
+      outcome: "done",
+      lastStage: "write",
+      totalMs: 252_000,
+      stages: [
+        { stage: "preflight", ms: 400 },
+        { stage: "providers", ms: 125_000 },
+        { stage: "channels", ms: 70_000 },
+        { stage: "timezone", ms: 5_000 },
+        { stage: "config", ms: 40_000 },
+      ],
+    })).toBe("Setup took 4m12s (providers 2m05s, channels 1m10s, config 40s)");
+  });
+
+  describe("history", () => {
+    let dir: string;
+
+    beforeEach(() => {
+      dir = mkdtempSync(join(tmpdir(), "owliabot-timing-"));
+    });
+
+    afterEach(() => {
+      rmSync(dir, { recursive: true, force: true });
+    });
+
+    it("appends one line per run", () => {
+      const timing = { outcome: "cancelled" as const, lastStage: "channels", totalMs: 5_000, stages: [] };
+      appendSetupHistory(dir, timing, new Date("2026-01-02T03:04:05Z"));
+      appendSetupHistory(dir, { ...timing, outcome: "done" });
+
+      const lines = readFileSync(join(dir, ONBOARDING_HISTORY_FILE), "utf-8").trim().split("\n");
+      expect(lines).toHaveLength(2);
+      expect(JSON.parse(lines[0])).toEqual({ timestamp: "2026-01-02T03:04:05.000Z", ...timing });
+    });
+
+    it("does not create a missing config dir", () => {
+      const missing = join(dir, "nope");
+      appendSetupHistory(missing, { outcome: "cancelled", lastStage: "start", totalMs: 0, stages: [] });
+      expect(existsSync(missing)).toBe(false);
+    });
+  });
+});
@@ -0,0 +1,51 @@
+/**
+ * Unit tests for onboarding/steps/swarm.ts
+ */
+
+import { describe, it, expect, vi } from "vitest";
+import { parse } from "yaml";
+import { buildDockerStackYaml, detectSwarmActive, promptSwarmOutput } from "../steps/swarm.js";
+import { parseOutputFormat } from "../steps/kubernetes.js";
+
+describe("swarm step", () => {
+  it("parses the swarm output format", () => {
+    expect(parseOutputFormat("swarm")).toBe("swarm");
+    expect(parseOutputFormat("stack")).toBe("swarm");
+  });
+
+  it("reshapes the service for docker stack deploy", () => {
+    const doc = parse(buildDockerStackYaml("~/.owliabot", ["TZ=UTC"], "8787", "img:latest"));
+    const svc = doc.services.owliabot;
+
+    expect(svc.container_name).toBeUndefined();
+    expect(svc.restart).toBeUndefined();
+    expect(svc.deploy.replicas).toBe(1);
+    expect(svc.deploy.restart_policy.condition).toBe("on-failure");
+    expect(svc.deploy.placement.constraints).toEqual(["node.role == manager"]);
+    expect(svc.ports).toEqual([{ target: 8787, published: 8787, protocol: "tcp", mode: "host" }]);
+    // stack deploy does not expand ~
+    expect(svc.volumes[0]).toBe("${HOME}/.owliabot:/home/owliabot/.owliabot");
+  });
+
+  it("keeps the TLS healthcheck and secrets key mount", () => {
+    const yaml = buildDockerStackYaml("~/.owliabot", [], "9000", "img", { gatewayTls: true, secretsKey: true });
+    expect(yaml).toContain("https://localhost:8787/health");
+    expect(yaml).toContain("${HOME}/.owliabot/auth/secrets.agekey:/run/secrets/owliabot-secrets.agekey:ro");
+    expect(yaml).toContain("OWLIABOT_SECRETS_KEY_FILE=");
+  });
+
+  it("detects swarm from the env hint or docker info", () => {
+    expect(detectSwarmActive({ OWLIABOT_SWARM_ACTIVE: "1" })).toBe(true);
+    expect(detectSwarmActive({ OWLIABOT_SWARM_ACTIVE: "0" })).toBe(false);
+    expect(detectSwarmActive({}, () => "active\n")).toBe(true);
+    expect(detectSwarmActive({}, () => "inactive\n")).toBe(false);
+    expect(detectSwarmActive({}, () => { throw new Error("no docker"); })).toBe(false);
+    expect(detectSwarmActive({ OWLIABOT_CONTAINER_RUNTIME: "podman" }, () => "active\n")).toBe(false);
+  });
+
+  it("does not probe the engine when not interactive", async () => {
+    const detect = vi.fn(() => true);
+    await expect(promptSwarmOutput({} as any, detect, false)).resolves.toBe(false);
+    expect(detect).not.toHaveBeenCalled();
+  });
+});
@@ -0,0 +1,52 @@
+/**
+ * Unit tests for onboarding/steps/systemd.ts
+ */
+
+import { describe, it, expect } from "vitest";
+import { buildSystemdFiles, buildSystemdUnit, defaultServiceUser } from "../steps/systemd.js";
+
+describe("systemd step", () => {
+  const opts = {
+    configDir: "/home/alice/.owliabot",
+    appConfigPath: "/home/alice/.owliabot/app.yaml",
+    user: "alice",
+    nodePath: "/usr/bin/node",
+    entryPath: "/opt/owliabot/dist/entry.js",
+  };
+
+  it("runs the entrypoint with OWLIABOT_HOME set to the config dir", () => {
+    const unit = buildSystemdUnit(opts);
+    expect(unit).toContain("User=alice");
+    expect(unit).toContain("Environment=OWLIABOT_HOME=/home/alice/.owliabot");
+    expect(unit).toContain(
+      "ExecStart=/usr/bin/node /opt/owliabot/dist/entry.js start -c /home/alice/.owliabot/app.yaml",
+    );
+    expect(unit).toContain("WantedBy=multi-user.target");
+  });
+
+  it("quotes paths with spaces", () => {
+    const unit = buildSystemdUnit({ ...opts, configDir: "/srv/owlia bot", appConfigPath: "/srv/owlia bot/app.yaml" });
+    expect(unit).toContain('WorkingDirectory="/srv/owlia bot"');
+    expect(unit).toContain('Environment="OWLIABOT_HOME=/srv/owlia bot"');
+    expect(unit).toContain('-c "/srv/owlia bot/app.yaml"');
+  });
+
+  it("passes a CA bundle to Node only when one is set", () => {
+    expect(buildSystemdUnit(opts)).not.toContain("NODE_EXTRA_CA_CERTS");
+    expect(buildSystemdUnit({ ...opts, caBundle: "/home/alice/.owliabot/ca-bundle.pem" }))
+      .toContain("Environment=NODE_EXTRA_CA_CERTS=/home/alice/.owliabot/ca-bundle.pem\nExecStart=");
+  });
+
+  it("places the unit and install script next to app.yaml", () => {
+    const files = buildSystemdFiles(opts);
+    expect(files.unitPath).toBe("/home/alice/.owliabot/owliabot.service");
+    expect(files.scriptPath).toBe("/home/alice/.owliabot/install-systemd.sh");
+    expect(files.script).toContain("install -m 0644 '/home/alice/.owliabot/owliabot.service' /etc/systemd/system/owliabot.service");
+    expect(files.script).toContain("systemctl enable --now owliabot.service");
+  });
+
+  it("prefers the sudo invoker as service user", () => {
+    expect(defaultServiceUser("bob")).toBe("bob");
+    expect(defaultServiceUser(null)).toBeTruthy();
+  });
+});
@@ -0,0 +1,99 @@
+/**
+ * Unit tests for onboarding/steps/telegram-discovery.ts
+ */
+
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { ValidationClient } from "../steps/validation-client.js";
+import { pollTelegramSenders, discoverTelegramUserIds } from "../steps/telegram-discovery.js";
+import { configureTelegramConfig } from "../steps/configure-telegram.js";
+
+function json(status: number, body: unknown): Response {
+  return new Response(JSON.stringify(body), { status, headers: { "content-type": "application/json" } });
+}
+
+function clientFor(responses: Response[]) {
+  const fetchImpl = vi.fn(async () => {
+    const res = responses.shift();
+    if (!res) throw new Error("unexpected getUpdates call");
+    return res;
+  });
+  return { client: new ValidationClient({ fetchImpl: fetchImpl as unknown as typeof fetch, retries: 0 }), fetchImpl };
+}
+
+const message = (update_id: number, from: Record<string, unknown>) => ({ update_id, message: { from } });
+
+describe("telegram ID discovery", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  it("skips the backlog and returns people who write afterwards", async () => {
+    const { client, fetchImpl } = clientFor([
+      json(200, { ok: true, result: [message(7, { id: 1, first_name: "Old" })] }),
+      json(200, { ok: true, result: [] }),
+      json(200, {
+        ok: true,
+        result: [
+          message(8, { id: 539066683, username: "alice" }),
+          message(9, { id: 42, is_bot: true, username: "otherbot" }),
+          message(10, { id: 539066683, username: "alice" }),
+        ],
+      }),
+    ]);
+
+    const result = await pollTelegramSenders("123:abc", { client });
+
+    expect(result).toEqual({ kind: "ok", senders: [{ id: "539066683", name: "@alice" }] });
+    expect(String(fetchImpl.mock.calls[0][0])).toContain("offset=-1&timeout=0");
+    expect(String(fetchImpl.mock.calls[1][0])).toContain("offset=8&timeout=5");
+  });
+
+  it("gives up when another poller holds the bot", async () => {
+    const { client } = clientFor([json(409, { ok: false })]);
+    const result = await pollTelegramSenders("123:abc", { client });
+    expect(result.kind).toBe("skipped");
+  });
+
+  it("stops at the deadline", async () => {
+    let t = 0;
+    const { client } = clientFor([json(200, { ok: true, result: [] }), json(200, { ok: true, result: [] })]);
+    const result = await pollTelegramSenders("123:abc", { client, timeoutMs: 10, now: () => (t += 6) });
+    expect(result).toEqual({ kind: "ok", senders: [] });
+  });
+
+  it("adds accepted senders to the Telegram allowlist", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    const poll = async () => ({
+      kind: "ok" as const,
+      senders: [{ id: "111", name: "@alice" }, { id: "222", name: "Bob" }],
+    });
+    const config: any = {};
+    const userAllowLists = { discord: [], telegram: [] as string[] };
+    answers = ["", "", "n", "333"]; // find by message: yes; allow alice; not Bob; one more typed
+
+    await configureTelegramConfig(rl, config, userAllowLists, (r) => discoverTelegramUserIds(r, "123:abc", poll));
+
+    expect(config.telegram.allowList).toEqual(["111", "333"]);
+    expect(userAllowLists.telegram).toEqual(["111", "333"]);
+  });
+});
@@ -0,0 +1,76 @@
+/**
+ * Unit tests for onboarding/steps/telegram-validation.ts
+ */
+
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { ValidationClient } from "../steps/validation-client.js";
+import { checkTelegramToken, promptValidTelegramToken, type TelegramTokenCheck } from "../steps/telegram-validation.js";
+
+function clientReturning(res: Response) {
+  const fetchImpl = vi.fn(async () => res);
+  return { client: new ValidationClient({ fetchImpl: fetchImpl as unknown as typeof fetch, retries: 0 }), fetchImpl };
+}
+
+function json(status: number, body: unknown): Response {
+  return new Response(JSON.stringify(body), { status, headers: { "content-type": "application/json" } });
+}
+
+describe("checkTelegramToken", () => {
+  it("returns the bot username from getMe", async () => {
+    const { client, fetchImpl } = clientReturning(json(200, { ok: true, result: { id: 1, username: "owlia_bot" } }));
+    expect(await checkTelegramToken("123:abc", client)).toEqual({ kind: "valid", username: "owlia_bot" });
+    expect(fetchImpl.mock.calls[0][0]).toBe("https://api.telegram.org/bot123:abc/getMe");
+  });
+
+  it("reports 401 as an invalid token", async () => {
+    const { client } = clientReturning(json(401, { ok: false, error_code: 401, description: "Unauthorized" }));
+    expect((await checkTelegramToken("123:bad", client)).kind).toBe("invalid");
+  });
+
+  it("rejects malformed tokens without calling the API", async () => {
+    const { client, fetchImpl } = clientReturning(json(200, {}));
+    expect((await checkTelegramToken("not a token", client)).kind).toBe("invalid");
+    expect(fetchImpl).not.toHaveBeenCalled();
+  });
+});
+
+describe("promptValidTelegramToken", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  it("retries after a rejected token", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["123:good"];
+    const check = vi.fn(async (t: string): Promise<TelegramTokenCheck> =>
+      t === "123:good" ? { kind: "valid", username: "owlia_bot" } : { kind: "invalid", message: "Telegram rejected the token (HTTP 401)" },
+    );
+    expect(await promptValidTelegramToken(rl, "123:typo", check)).toBe("123:good");
+  });
+
+  it("keeps the token when Telegram can't be reached", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    const check = vi.fn(async (): Promise<TelegramTokenCheck> => ({ kind: "skipped", reason: "timed out after 8s" }));
+    expect(await promptValidTelegramToken(rl, "123:abc", check)).toBe("123:abc");
+  });
+});
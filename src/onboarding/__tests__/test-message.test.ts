@@ -0,0 +1,69 @@
+/**
+ * Unit tests for onboarding/steps/test-message.ts
+ */
+
+import { describe, it, expect, vi } from "vitest";
+import { ValidationClient } from "../steps/validation-client.js";
+import {
+  runTestMessage,
+  sendDiscordTestMessage,
+  sendTelegramTestMessage,
+  testMessageTargets,
+} from "../steps/test-message.js";
+
+function json(status: number, body: unknown): Response {
+  return new Response(JSON.stringify(body), { status, headers: { "content-type": "application/json" } });
+}
+
+function clientReturning(...responses: Response[]) {
+  const fetchImpl = vi.fn(async () => responses.shift()!);
+  return { client: new ValidationClient({ fetchImpl: fetchImpl as unknown as typeof fetch, retries: 0 }), fetchImpl };
+}
+
+const config = {
+  discord: { token: "discord-token", channelAllowList: ["111"], memberAllowList: ["222"], requireMentionInGuild: true },
+  telegram: { token: "123:abc", allowList: ["333"], groups: { "*": { requireMention: true }, "-100444": {} } },
+};
+
+describe("test message", () => {
+  it("offers the allow-listed channels, users and groups of channels with a token", () => {
+    expect(testMessageTargets(config).map((t) => `${t.channel}:${t.kind}:${t.id}`)).toEqual([
+      "discord:channel:111",
+      "discord:user:222",
+      "telegram:group:-100444",
+      "telegram:user:333",
+    ]);
+    expect(testMessageTargets({ discord: { ...config.discord, token: undefined } })).toEqual([]);
+  });
+
+  it("opens a DM channel before messaging a Discord user", async () => {
+    const { client, fetchImpl } = clientReturning(json(200, { id: "dm-1" }), json(200, { id: "msg" }));
+    const result = await sendDiscordTestMessage("tok", { channel: "discord", kind: "user", id: "222" }, "hi", client);
+    expect(result).toEqual({ kind: "sent" });
+    expect(fetchImpl.mock.calls.map((c) => (c as unknown[])[0])).toEqual([
+      "https://discord.com/api/v10/users/@me/channels",
+      "https://discord.com/api/v10/channels/dm-1/messages",
+    ]);
+  });
+
+  it("explains a Discord channel the bot can't post in", async () => {
+    const { client } = clientReturning(json(403, { message: "Missing Access" }));
+    const result = await sendDiscordTestMessage("tok", { channel: "discord", kind: "channel", id: "111" }, "hi", client);
+    expect(result).toMatchObject({ kind: "failed", message: "Missing Access" });
+    expect(result.kind === "failed" && result.hint).toMatch(/Invite the bot/);
+  });
+
+  it("tells Telegram users to press Start when the bot can't message them", async () => {
+    const { client } = clientReturning(json(403, { ok: false, description: "Forbidden: bot can't initiate conversation with a user" }));
+    const result = await sendTelegramTestMessage("123:abc", { channel: "telegram", kind: "user", id: "333" }, "hi", client);
+    expect(result.kind === "failed" && result.hint).toMatch(/press Start/);
+  });
+
+  it("sends to the --to target only when it is allow-listed", async () => {
+    const send = vi.fn(async () => ({ kind: "sent" as const }));
+    expect(await runTestMessage(null, config, "333", send)).toBe(true);
+    expect(send).toHaveBeenCalledWith({ channel: "telegram", kind: "user", id: "333" });
+    expect(await runTestMessage(null, config, "999", send)).toBe(false);
+    expect(send).toHaveBeenCalledTimes(1);
+  });
+});
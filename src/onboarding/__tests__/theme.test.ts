@@ -0,0 +1,54 @@
+/**
+ * Unit tests for onboarding/theme.ts
+ */
+
+import { describe, it, expect, afterEach } from "vitest";
+import { THEMES, parseTheme, resolveTheme } from "../theme.js";
+import { COLORS, setAccessible, setTheme } from "../shared.js";
+import { renderMarkdown } from "../steps/stage-help.js";
+
+describe("theme", () => {
+  afterEach(() => {
+    setAccessible(false);
+    setTheme("dark");
+  });
+
+  it("parses theme names", () => {
+    expect(parseTheme("Light")).toBe("light");
+    expect(parseTheme("high-contrast")).toBe("high-contrast");
+    expect(parseTheme("solarized")).toBeUndefined();
+    expect(parseTheme(undefined)).toBeUndefined();
+  });
+
+  it("resolves the flag, then OWLIABOT_THEME, then NO_COLOR / CLICOLOR=0", () => {
+    expect(resolveTheme(undefined, {})).toBe("dark");
+    expect(resolveTheme("light", { OWLIABOT_THEME: "high-contrast" })).toBe("light");
+    expect(resolveTheme(undefined, { OWLIABOT_THEME: "high-contrast" })).toBe("high-contrast");
+    expect(resolveTheme(undefined, { NO_COLOR: "1" })).toBe("mono");
+    expect(resolveTheme(undefined, { CLICOLOR: "0" })).toBe("mono");
+    expect(resolveTheme(undefined, { NO_COLOR: "" })).toBe("dark");
+    expect(resolveTheme("light", { NO_COLOR: "1" })).toBe("light");
+  });
+
+  it("keeps dim text and bright yellow out of the light theme", () => {
+    expect(THEMES.light.DIM).not.toBe(THEMES.dark.DIM);
+    expect(THEMES.light.YELLOW).not.toBe(THEMES.dark.YELLOW);
+    expect(THEMES["high-contrast"].DIM).toBe("");
+  });
+
+  it("switches COLORS, and mono prints no escapes", () => {
+    setTheme("light");
+    expect(COLORS.CYAN).toBe(THEMES.light.CYAN);
+    setTheme("mono");
+    expect(Object.values(COLORS).join("")).toBe("");
+    expect(renderMarkdown("# Title\n\n**bold**")).not.toMatch(/\x1b/);
+  });
+
+  it("stays blank under accessible mode and comes back after it", () => {
+    setTheme("high-contrast");
+    setAccessible(true);
+    expect(COLORS.RED).toBe("");
+    setAccessible(false);
+    expect(COLORS.RED).toBe(THEMES["high-contrast"].RED);
+  });
+});
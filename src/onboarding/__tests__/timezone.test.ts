@@ -0,0 +1,86 @@
+/**
+ * Unit tests for onboarding/steps/timezone.ts
+ */
+
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import {
+  isValidTimezone,
+  listTimezones,
+  detectHostTimezone,
+  searchTimezones,
+  promptTimezone,
+} from "../steps/timezone.js";
+
+const ZONES = ["America/New_York", "America/Newport_News", "Europe/Berlin", "Europe/London", "UTC"];
+
+describe("timezone picker", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  it("validates zones and lists them with UTC", () => {
+    expect(isValidTimezone("Europe/Berlin")).toBe(true);
+    expect(isValidTimezone("Mars/Olympus_Mons")).toBe(false);
+    expect(listTimezones()).toContain("UTC");
+  });
+
+  it("reads the host zone from /etc/localtime", () => {
+    expect(detectHostTimezone(() => "/usr/share/zoneinfo/Europe/Berlin", {})).toBe("Europe/Berlin");
+    expect(detectHostTimezone(() => "../usr/share/zoneinfo/Asia/Tokyo", {})).toBe("Asia/Tokyo");
+    const fallback = detectHostTimezone(() => { throw new Error("EINVAL"); }, {});
+    expect(isValidTimezone(fallback)).toBe(true);
+  });
+
+  it("prefers TZ, which install.sh sets from the host", () => {
+    const containerLocaltime = () => "/usr/share/zoneinfo/Etc/UTC";
+    expect(detectHostTimezone(containerLocaltime, { TZ: "America/New_York" })).toBe("America/New_York");
+    expect(detectHostTimezone(containerLocaltime, { TZ: ":Europe/Berlin" })).toBe("Europe/Berlin");
+    expect(detectHostTimezone(containerLocaltime, { TZ: "Mars/Olympus_Mons" })).toBe("Etc/UTC");
+  });
+
+  it("searches case-insensitively, spaces matching underscores", () => {
+    expect(searchTimezones("new york", ZONES)).toEqual(["America/New_York"]);
+    expect(searchTimezones("europe", ZONES)).toEqual(["Europe/Berlin", "Europe/London"]);
+    expect(searchTimezones("", ZONES)).toEqual([]);
+  });
+
+  it("keeps the detected zone on Enter", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = [""];
+    expect(await promptTimezone(rl, "Europe/Berlin", ZONES)).toBe("Europe/Berlin");
+  });
+
+  it("accepts an exact name and a unique search hit", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["europe/london"];
+    expect(await promptTimezone(rl, "UTC", ZONES)).toBe("Europe/London");
+    answers = ["berl"];
+    expect(await promptTimezone(rl, "UTC", ZONES)).toBe("Europe/Berlin");
+  });
+
+  it("lists several matches and re-asks when nothing matches", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    answers = ["atlantis", "new", "2"];
+    expect(await promptTimezone(rl, "UTC", ZONES)).toBe("America/Newport_News");
+  });
+});
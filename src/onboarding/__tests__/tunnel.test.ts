@@ -0,0 +1,83 @@
+/**
+ * Unit tests for onboarding/steps/tunnel.ts
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { parse } from "yaml";
+import { AbortError } from "../shared.js";
+import { buildDockerComposeYaml } from "../steps/docker.js";
+import {
+  parseTunnelProvider,
+  promptTunnelSetup,
+  buildTunnelEnv,
+  describeTunnelUrl,
+} from "../steps/tunnel.js";
+
+describe("tunnel step", () => {
+  let consoleSpy: ReturnType<typeof vi.spyOn>;
+  let rl: ReturnType<typeof createInterface>;
+
+  beforeEach(() => {
+    consoleSpy = vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+    rl = createInterface({ input: process.stdin, output: process.stdout });
+  });
+
+  afterEach(() => {
+    consoleSpy.mockRestore();
+  });
+
+  it("parses providers", () => {
+    expect(parseTunnelProvider(undefined)).toBeUndefined();
+    expect(parseTunnelProvider("Cloudflared")).toBe("cloudflared");
+    expect(() => parseTunnelProvider("frp")).toThrow(/cloudflared, ngrok/);
+  });
+
+  it("prompts for token and hostname", async () => {
+    answers.push("cf-token", "https://bot.example.com/");
+    const setup = await promptTunnelSetup(rl, "cloudflared");
+    expect(setup).toEqual({ provider: "cloudflared", token: "cf-token", hostname: "bot.example.com" });
+    expect(describeTunnelUrl(setup)).toBe("https://bot.example.com");
+    expect(buildTunnelEnv(setup)).toBe("TUNNEL_TOKEN=cf-token\n");
+  });
+
+  it("requires a token", async () => {
+    answers.push("");
+    await expect(promptTunnelSetup(rl, "ngrok")).rejects.toBeInstanceOf(AbortError);
+  });
+
+  it("adds a cloudflared sidecar without putting the token in compose", () => {
+    const setup = { provider: "cloudflared" as const, token: "cf-token", hostname: "bot.example.com" };
+    const yaml = buildDockerComposeYaml("~/.owliabot", [], "8787", "img", { tunnel: setup });
+    const doc = parse(yaml);
+
+    expect(Object.keys(doc.services)).toEqual(["owliabot", "tunnel"]);
+    expect(doc.services.tunnel.image).toBe("cloudflare/cloudflared:latest");
+    expect(doc.services.tunnel.env_file).toEqual(["~/.owliabot/tunnel.env"]);
+    expect(yaml).not.toContain("cf-token");
+  });
+
+  it("points ngrok at the gateway, honouring TLS and reserved domains", () => {
+    const setup = { provider: "ngrok" as const, token: "ng", hostname: "owlia.ngrok.app" };
+    const doc = parse(buildDockerComposeYaml("~/.owliabot", [], "8787", "img", { tunnel: setup, gatewayTls: true }));
+    expect(doc.services.tunnel.command).toEqual(["http", "https://owliabot:8787", "--url=owlia.ngrok.app"]);
+    expect(describeTunnelUrl({ provider: "ngrok", token: "ng" })).toContain("localhost:4040");
+  });
+});
@@ -0,0 +1,83 @@
+/**
+ * Unit tests for onboarding/steps/validation-client.ts
+ */
+
+import { describe, it, expect, vi } from "vitest";
+import { ValidationClient, parseRetryAfter } from "../steps/validation-client.js";
+
+function res(status: number, headers: Record<string, string> = {}): Response {
+  return new Response(status === 204 ? null : "{}", { status, headers });
+}
+
+function makeClient(responses: Array<Response | Error>, opts: { retries?: number; breakerThreshold?: number } = {}) {
+  const fetchImpl = vi.fn(async () => {
+    const next = responses.shift();
+    if (!next) throw new Error("no more responses");
+    if (next instanceof Error) throw next;
+    return next;
+  });
+  const sleep = vi.fn(async (_ms: number) => {});
+  const client = new ValidationClient({ fetchImpl: fetchImpl as unknown as typeof fetch, sleep, ...opts });
+  return { client, fetchImpl, sleep };
+}
+
+describe("ValidationClient", () => {
+  it("returns definitive answers without retrying", async () => {
+    const { client, fetchImpl } = makeClient([res(401)]);
+    const result = await client.fetch("https://example.test");
+    expect(result.kind).toBe("response");
+    expect(result.kind === "response" && result.response.status).toBe(401);
+    expect(fetchImpl).toHaveBeenCalledTimes(1);
+  });
+
+  it("retries 429 honouring Retry-After", async () => {
+    const { client, sleep } = makeClient([res(429, { "retry-after": "2" }), res(200)]);
+    const result = await client.fetch("https://example.test");
+    expect(result.kind).toBe("response");
+    expect(sleep).toHaveBeenCalledWith(2000);
+  });
+
+  it("skips instead of waiting out a long Retry-After", async () => {
+    const { client, fetchImpl, sleep } = makeClient([res(429, { "retry-after": "120" })]);
+    const result = await client.fetch("https://example.test");
+    expect(result).toEqual({ kind: "skipped", reason: "rate-limited by the provider" });
+    expect(fetchImpl).toHaveBeenCalledTimes(1);
+    expect(sleep).not.toHaveBeenCalled();
+  });
+
+  it("backs off exponentially on network errors", async () => {
+    const { client, sleep } = makeClient([new Error("ECONNRESET"), new Error("ECONNRESET"), new Error("ECONNRESET")]);
+    const result = await client.fetch("https://example.test");
+    expect(result.kind).toBe("skipped");
+    expect(result.kind === "skipped" && result.reason).toContain("ECONNRESET");
+    expect(sleep.mock.calls.map((c) => c[0])).toEqual([500, 1000]);
+  });
+
+  it("reports timeouts", async () => {
+    const timeout = Object.assign(new Error("timed out"), { name: "TimeoutError" });
+    const { client } = makeClient([timeout], { retries: 0 });
+    const result = await client.fetch("https://example.test");
+    expect(result.kind === "skipped" && result.reason).toMatch(/timed out/);
+  });
+
+  it("opens the breaker after repeated failures", async () => {
+    const { client, fetchImpl } = makeClient([res(503), res(503), res(200)], { retries: 0, breakerThreshold: 2 });
+    await client.fetch("https://a.test");
+    await client.fetch("https://b.test");
+    expect(client.isOpen).toBe(true);
+
+    const result = await client.fetch("https://c.test");
+    expect(result.kind).toBe("skipped");
+    expect(fetchImpl).toHaveBeenCalledTimes(2);
+
+    client.reset();
+    expect((await client.fetch("https://c.test")).kind).toBe("response");
+  });
+
+  it("parses Retry-After dates", () => {
+    const now = Date.parse("2026-01-01T00:00:00Z");
+    expect(parseRetryAfter("Thu, 01 Jan 2026 00:00:05 GMT", now)).toBe(5000);
+    expect(parseRetryAfter("garbage", now)).toBeNull();
+    expect(parseRetryAfter(null, now)).toBeNull();
+  });
+});
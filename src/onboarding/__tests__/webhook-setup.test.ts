@@ -0,0 +1,66 @@
+/**
+ * Unit tests for onboarding/steps/webhook-setup.ts
+ */
+
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+let answers: string[] = [];
+
+vi.mock("node:readline", () => ({
+  createInterface: () => ({
+    question: (q: string, cb: (ans: string) => void) => {
+      const next = answers.shift();
+      if (next === undefined) throw new Error(`Ran out of answers at: "${q}"`);
+      cb(next);
+    },
+    close: vi.fn(),
+    once: vi.fn(),
+    removeListener: vi.fn(),
+    pause: vi.fn(),
+    resume: vi.fn(),
+  }),
+}));
+
+import { createInterface } from "node:readline";
+import { configureWebhookConfig, isWebhookUrl } from "../steps/webhook-setup.js";
+import type { SecretsConfig } from "../secrets.js";
+
+describe("webhook setup", () => {
+  beforeEach(() => {
+    vi.spyOn(console, "log").mockImplementation(() => {});
+    answers = [];
+  });
+
+  it("uses defaults, generates a secret and turns on Gateway HTTP", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    const config: any = { workspace: "./workspace", providers: [] };
+    const secrets: SecretsConfig = {};
+    answers = ["", "", ""];
+
+    await configureWebhookConfig(rl, config, secrets);
+
+    expect(config.webhook).toEqual({ path: "/webhook" });
+    expect(secrets.webhook?.secret).toMatch(/^[0-9a-f]{48}$/);
+    expect(config.gateway.http).toMatchObject({ host: "127.0.0.1", port: 8787 });
+  });
+
+  it("re-asks for invalid paths and URLs and keeps an existing gateway", async () => {
+    const rl = createInterface({ input: process.stdin, output: process.stdout });
+    const gateway = { http: { host: "0.0.0.0", port: 8787, token: "secrets" } };
+    const config: any = { workspace: "./workspace", providers: [], gateway };
+    const secrets: SecretsConfig = {};
+    answers = ["hooks", "/hooks/ci", "s3cret", "not a url", "https://example.test/replies"];
+
+    await configureWebhookConfig(rl, config, secrets);
+
+    expect(config.webhook).toEqual({ path: "/hooks/ci", outboundUrl: "https://example.test/replies" });
+    expect(secrets.webhook).toEqual({ secret: "s3cret" });
+    expect(config.gateway).toBe(gateway);
+  });
+
+  it("accepts only http(s) reply URLs", () => {
+    expect(isWebhookUrl("https://example.test/x")).toBe(true);
+    expect(isWebhookUrl("ftp://example.test/x")).toBe(false);
+    expect(isWebhookUrl("example.test")).toBe(false);
+  });
+});
@@ -0,0 +1,126 @@
+/**
+ * Message catalog for the onboarding wizard.
+ *
+ * English is the source: every key exists there, and a translation that
+ * lacks a key falls back to it. Placeholders are `{name}`. The language
+ * comes from `onboard --lang`, else OWLIABOT_LANG, else the locale
+ * (LC_ALL / LC_MESSAGES / LANG); `--lang` on its own asks.
+ *
+ * Every wizard message goes through the catalog; TRANSLATED_MODULES lists
+ * the modules that show them. Thrown errors, log lines, the F1 help
+ * articles (steps/stage-help.ts) and the files the wizard writes stay in
+ * English.
+ */
+
+import { en, type Catalog, type MessageKey } from "./locales/en.js";
+import { ja } from "./locales/ja.js";
+import { ko } from "./locales/ko.js";
+
+export type { MessageKey };
+
+/** Modules (relative to src/onboarding) whose user-facing text all comes from the catalog */
+export const TRANSLATED_MODULES = [
+  "shared.ts",
+  "onboard.ts",
+  "steps/access-setup.ts",
+  "steps/azure-openai.ts",
+  "steps/bedrock.ts",
+  "steps/bind-path-check.ts",
+  "steps/ca-bundle.ts",
+  "steps/channel-setup.ts",
+  "steps/clipboard.ts",
+  "steps/config-building.ts",
+  "steps/configure-discord.ts",
+  "steps/configure-telegram.ts",
+  "steps/demo.ts",
+  "steps/detect-existing.ts",
+  "steps/devcontainer.ts",
+  "steps/discord-picker.ts",
+  "steps/discord-validation.ts",
+  "steps/docker.ts",
+  "steps/dry-run.ts",
+  "steps/env-file.ts",
+  "steps/environments.ts",
+  "steps/gateway-auth.ts",
+  "steps/gateway-setup.ts",
+  "steps/github-actions.ts",
+  "steps/init-dev-workspace.ts",
+  "steps/keychain-storage.ts",
+  "steps/kiosk.ts",
+  "steps/kubernetes.ts",
+  "steps/local-run.ts",
+  "steps/mcp-custom.ts",
+  "steps/mcp-runtime-check.ts",
+  "steps/model-discovery.ts",
+  "steps/model-presets.ts",
+  "steps/nix-flake.ts",
+  "steps/notify.ts",
+  "steps/oidc-proxy.ts",
+  "steps/ollama-discovery.ts",
+  "steps/placeholder-credentials.ts",
+  "steps/policy-allowed-users.ts",
+  "steps/port-check.ts",
+  "steps/profile-picker.ts",
+  "steps/provider-priority.ts",
+  "steps/provider-setup.ts",
+  "steps/provider-smoke-test.ts",
+  "steps/reverse-proxy.ts",
+  "steps/root-check.ts",
+  "steps/screen-dump.ts",
+  "steps/secrets-encryption.ts",
+  "steps/security-setup.ts",
+  "steps/slack-setup.ts",
+  "steps/stage-timing.ts",
+  "steps/swarm.ts",
+  "steps/systemd.ts",
+  "steps/telegram-discovery.ts",
+  "steps/telegram-validation.ts",
+  "steps/test-message.ts",
+  "steps/timezone.ts",
+  "steps/tunnel.ts",
+  "steps/ui.ts",
+  "steps/validation-client.ts",
+  "steps/webhook-setup.ts",
+  "steps/workspace-setup.ts",
+  "steps/writers.ts",
+];
+
+export const LANGUAGES = { en: "English", ja: "日本語", ko: "한국어" } as const;
+export type Lang = keyof typeof LANGUAGES;
+
+const CATALOGS: Record<Lang, Catalog> = { en, ja, ko };
+
+let current: Lang = "en";
+
+export function setLang(lang: Lang): void {
+  current = lang;
+}
+
+export function getLang(): Lang {
+  return current;
+}
+
+/** "ja", "ja_JP.UTF-8", "ko-KR" -> a supported language, else null */
+export function parseLang(value: string | undefined): Lang | null {
+  const code = value?.trim().split(/[-_.@]/)[0]?.toLowerCase();
+  return code && code in LANGUAGES ? (code as Lang) : null;
+}
+
+/**
+ * The language for this run: `flag` (a code), else OWLIABOT_LANG, else the
+ * locale variables. Null when none names a supported language.
+ */
+export function resolveLang(flag: string | undefined, env: NodeJS.ProcessEnv = process.env): Lang | null {
+  return parseLang(flag) ?? parseLang(env.OWLIABOT_LANG) ?? parseLang(env.LC_ALL || env.LC_MESSAGES || env.LANG);
+}
+
+/** The message for `key` in the current language, with `{name}` placeholders filled in */
+export function t(key: MessageKey, vars: Record<string, string | number> = {}, lang: Lang = current): string {
+  const template = CATALOGS[lang][key] ?? en[key];
+  return template.replace(/\{(\w+)\}/g, (match, name: string) => (name in vars ? String(vars[name]) : match));
+}
+
+/** Keys a translation doesn't have yet (English is shown for those) */
+export function missingKeys(lang: Lang): MessageKey[] {
+  return (Object.keys(en) as MessageKey[]).filter((key) => !(key in CATALOGS[lang]));
+}
@@ -0,0 +1,676 @@
+/**
+ * English wizard messages: the source catalog. Every key exists here, and
+ * keys are grouped by the step that shows them.
+ */
+
+export const en = {
+  // shared prompts
+  "prompt.pickNumber": "Pick a number [1-{count}]{onEnter}: ",
+  "prompt.enterFor": " (Enter for {n})",
+  "prompt.numberRange": "Please type a number between 1 and {count}.",
+  "prompt.pickNumbers": "Pick numbers (e.g. 1,3 or 2-4; \"all\"; {onEnter}): ",
+  "prompt.enterForList": "Enter for {list}, \"none\" to skip",
+  "prompt.enterForNone": "Enter for none",
+  "prompt.numbersRange": "Please use numbers between 1 and {count}.",
+  "prompt.docsHint": "(type d and press Enter to open the docs)",
+  "prompt.opening": "Opening {url}",
+  "prompt.docs": "Docs: {url}",
+  "prompt.secretHint": "(hidden as you type; Ctrl+R shows it for a moment)",
+  "banner.setUp": "Let's set up OwliaBot{subtitle}",
+
+  // shared by several steps
+  "common.saved": "Saved {path}",
+  "common.savedSettings": "Saved your settings in {path}",
+  "common.savedSecrets": "Saved your tokens and keys in {path}",
+
+  // wizard
+  "wizard.helpHint": "(F1, or h at a numbered or yes/no question: help on the current step)",
+  "wizard.language": "Language / 言語 / 언어:",
+  "wizard.saving": "Saving your settings",
+  "wizard.allSet": "All set!",
+  "wizard.cancelled": "Setup cancelled. No changes were made.",
+  "wizard.dryRun": "Dry run: no files were written.",
+  "wizard.backup": "Copied the files about to be replaced to {dir} (undo with: owliabot rollback)",
+  "wizard.envWouldSet": "{file} would set: {vars}",
+  "wizard.nothing": "(nothing)",
+  "wizard.sidecarsIgnored": "--tunnel and --oidc add docker-compose sidecars and only apply with --docker; ignoring them.",
+  "wizard.notifyByInstaller": "install.sh sends the setup summary once the bot answers /health.",
+  "wizard.notifySaved": "Saved the setup summary to {path}. Once the bot is up, send it with:",
+  "wizard.reverseProxyOnly": "The gateway is only reachable at {url} (port {port} is not published).",
+  "wizard.reverseProxyFirstStart": "The first start takes a few seconds longer while {proxy} gets the certificate.",
+  "wizard.reverseProxyKept": "Keeping the gateway on 127.0.0.1.",
+  "wizard.reverseProxyDeclined": "--reverse-proxy needs basic auth, or your go-ahead to expose the gateway without it.",
+  "wizard.profileStart": "Start this profile with: {command}",
+  "wizard.profileCommands": "Other commands take the profile too, e.g. {command}",
+  "wizard.composeProfiles": "Optional services are in compose profiles. Start with: {command}",
+  "wizard.composeProfilesHint": "Add --profile ollama or --profile watchtower to turn those on.",
+  "wizard.runAgain": "You can run this again anytime with: {command}",
+
+  // providers
+  "provider.header": "AI provider setup",
+  "provider.choose": "Choose your AI provider(s):",
+  "provider.option.anthropic": "Anthropic (Claude) - API Key or setup-token",
+  "provider.option.openai": "OpenAI (API key)",
+  "provider.option.codex": "OpenAI Codex (ChatGPT Plus/Pro OAuth)",
+  "provider.option.compatible": "OpenAI-compatible (Ollama / vLLM / LM Studio / etc.)",
+  "provider.option.multiple": "Multiple providers (fallback chain)",
+  "provider.option.bedrock": "AWS Bedrock (region, model ID, AWS credentials)",
+  "provider.option.azure": "Azure OpenAI (endpoint, deployment, API version)",
+  "provider.none": "No provider configured. Add one later in the config file.",
+  "provider.chain": "Provider fallback chain: {chain}",
+  "provider.orderIntro": "Fallback order: OwliaBot tries the first provider, then the next one if a call fails.",
+  "provider.orderAsk": "Move one (\"2 up\", \"1 down\"), type a new order (\"2,1,3\"), or press Enter to keep it: ",
+  "provider.orderInvalid": "Use \"<number> up\", \"<number> down\", or all numbers 1-{count} in the new order.",
+  "provider.orderDone": "Fallback chain: {chain}",
+  "provider.reusing": "Reusing {name} configuration",
+  "provider.model": "Model:",
+  "provider.otherModel": "Another model (type its name)",
+  "provider.modelDefault": "Model [{model}]: ",
+  "provider.anthropic.header": "Anthropic Authentication",
+  "provider.anthropic.methods": "Supports two authentication methods:",
+  "provider.anthropic.setupToken": "  • Setup-token (Claude Pro/Max subscription)",
+  "provider.anthropic.setupTokenHow": "    Run `claude setup-token` to generate one",
+  "provider.anthropic.apiKey": "  • API Key (pay-as-you-go)",
+  "provider.anthropic.apiKeyWhere": "    Get from console.anthropic.com",
+  "provider.anthropic.format": "    Format: {format}",
+  "provider.anthropic.loginNow": "Log in with your Claude account in the browser now (runs `claude setup-token`)?",
+  "provider.anthropic.loginStarting": "Starting Claude login...",
+  "provider.anthropic.loginCopy": "Copy the sk-ant-oat01-... token it printed and paste it below.",
+  "provider.anthropic.loginFailed": "The Claude login didn't finish. You can still paste a setup-token or API key.",
+  "provider.anthropic.paste": "Paste setup-token or API key (leave empty for env var): ",
+  "provider.anthropic.tokenWarning": "Setup-token validation warning: {error}",
+  "provider.anthropic.tokenSaved": "Setup-token saved (Claude Pro/Max)",
+  "provider.anthropic.keySaved": "API key saved",
+  "provider.openai.keys": "OpenAI API keys: {url}",
+  "provider.openai.ask": "OpenAI API key (leave empty for env var): ",
+  "provider.openai.saved": "OpenAI API key saved",
+  "provider.codex.intro": "OpenAI Codex uses your ChatGPT Plus/Pro subscription via OAuth.",
+  "provider.codex.startNow": "Start OAuth flow now?",
+  "provider.codex.starting": "Starting OpenAI Codex OAuth flow...",
+  "provider.codex.done": "OAuth completed",
+  "provider.codex.laterDocker": "Run after container starts: {command}",
+  "provider.codex.later": "Run `{command}` later to authenticate.",
+  "provider.compatible.intro": "OpenAI-compatible supports any server with the OpenAI v1 API:",
+  "provider.compatible.baseUrl": "API base URL (or 1-{count} for one above): ",
+  "provider.compatible.keyAt": "Get a {name} API key at {url}",
+  "provider.compatible.key": "API key: ",
+  "provider.compatible.keyOptional": "API key (optional, leave empty if not required): ",
+  "provider.compatible.configured": "OpenAI-compatible configured: {url}",
+
+  // channels
+  "channel.header": "Chat",
+  "channel.choose": "Where should OwliaBot chat with you?",
+  "channel.option.both": "Both (Discord + Telegram)",
+  "channel.option.webhook": "Webhook (HTTP, for other systems)",
+  "channel.existing": "Using your existing chat setup:",
+  "channel.noToken": "No chat token yet. You can add it later.",
+  "channel.noAllowList": "No allowList configured. The bot won't respond to anyone without one.",
+  "channel.discord.portal": "You'll find your bot token in the Discord developer portal: {url}",
+  "channel.guide": "Guide: {url}",
+  "channel.discord.intent": "Quick reminder: enable MESSAGE CONTENT INTENT, otherwise I won't receive messages.",
+  "channel.discord.paste": "Paste your Discord bot token (or press Enter to do this later): ",
+  "channel.discord.saved": "Got it. I'll use that Discord token.",
+  "channel.discord.checklist": "Quick checklist: View Channels, Send Messages, Send Messages in Threads, Read Message History",
+  "channel.telegram.found": "I found existing Telegram settings (allowed users: {users}, groups: {groups}).",
+  "channel.telegram.reuse": "Reuse your existing Telegram setup?",
+  "channel.telegram.reused": "Got it. I'll reuse your existing Telegram configuration.",
+  "channel.telegram.botfather": "Create a bot with BotFather: {url}",
+  "channel.telegram.paste": "Paste your Telegram bot token (or press Enter to do this later): ",
+  "channel.telegram.saved": "Got it. I'll use that Telegram token.",
+  "channel.telegram.who": "Who can talk to me? (comma-separated Telegram user IDs; press Enter to skip): ",
+  "channel.telegram.allowed": "I'll only respond to these Telegram user IDs: {ids}",
+  "channel.telegram.ids": "Enter your Telegram user IDs (comma-separated, or press Enter to skip): ",
+  "channel.telegram.allowList": "Telegram allowList: {ids}",
+  // access
+  "access.hint.discord": "Discord IDs are 17-20 digits (Developer Mode > right-click > Copy ID)",
+  "access.hint.telegram": "Telegram user IDs are numbers (ask @userinfobot), not @usernames",
+  "access.hint.slackMember": "Slack member IDs start with U (profile > ⋮ > Copy member ID)",
+  "access.hint.slackChannel": "Slack channel IDs start with C (channel details, at the bottom)",
+  "access.invalid": "Not valid: {ids}. {hint}.",
+  "access.header": "Access",
+  "access.intro": "Limit where and with whom I talk. Press Enter to skip a question.",
+  "access.discord.channels": "Discord channel IDs I may answer in (comma-separated): ",
+  "access.discord.members": "Discord user IDs allowed to talk to me (comma-separated): ",
+  "access.discord.channelsSet": "Discord channels: {ids}",
+  "access.discord.membersSet": "Discord users: {ids}",
+  "access.slack.header": "Access (Slack)",
+  "access.slack.intro": "Press Enter to skip a question.",
+  "access.slack.channels": "Slack channel IDs where I answer without a mention (comma-separated): ",
+  "access.slack.members": "Slack member IDs allowed to talk to me (comma-separated): ",
+  "access.slack.channelsSet": "Slack channels: {ids}",
+  "access.slack.membersSet": "Slack members: {ids}",
+  "access.slack.noMembers": "Without member IDs I won't answer anyone on Slack. Add slack.memberAllowList to app.yaml later.",
+  // Azure OpenAI
+  "provider.azure.endpoint": "Azure OpenAI endpoint (https://<resource>.openai.azure.com, empty to skip): ",
+  "provider.azure.httpsOnly": "The endpoint must be an https:// URL.",
+  "provider.azure.notAzure": "That isn't an Azure OpenAI host; using it as-is (a proxy, for example).",
+  "provider.azure.whereKeys": "Find the endpoint and keys under Resource Management > Keys and Endpoint in the Azure portal,",
+  "provider.azure.whereDeployment": "and the deployment name under Deployments in Azure AI Foundry.",
+  "provider.azure.deployment": "Deployment name [{deployment}]: ",
+  "provider.azure.apiVersion": "API version [{version}]: ",
+  "provider.azure.apiVersionFormat": "API versions look like 2024-10-21 or 2025-01-01-preview.",
+  "provider.azure.key": "Azure OpenAI API key (leave empty for env var): ",
+  "provider.azure.keySaved": "Azure OpenAI API key saved",
+  "provider.azure.configured": "Azure OpenAI configured: {deployment} at {endpoint}",
+  // AWS Bedrock
+  "provider.bedrock.region": "AWS region [{region}]: ",
+  "provider.bedrock.badRegion": "That doesn't look like an AWS region (e.g. us-east-1, eu-central-1).",
+  "provider.bedrock.intro": "AWS Bedrock uses your AWS account; enable model access in the Bedrock console first.",
+  "provider.bedrock.model": "Model ID [{model}]: ",
+  "provider.bedrock.credentials": "AWS credentials:",
+  "provider.bedrock.option.env": "Environment (AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY or AWS_PROFILE)",
+  "provider.bedrock.option.instance": "Instance profile / task role (EC2, ECS, EKS)",
+  "provider.bedrock.option.keys": "Access keys (stored in secrets.yaml)",
+  "provider.bedrock.accessKeyId": "AWS access key ID: ",
+  "provider.bedrock.secretAccessKey": "AWS secret access key: ",
+  "provider.bedrock.keysSaved": "AWS access keys saved",
+  "provider.bedrock.noKeys": "No keys entered; set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY in the environment instead.",
+  "provider.bedrock.instanceRole": "The AWS SDK picks up the role on its own. In Docker on EC2, the instance metadata hop limit must be at least 2.",
+  "provider.bedrock.configured": "AWS Bedrock configured: {model} in {region}",
+  // Docker bind mounts
+  "bindPath.relative": "The config folder \"{dir}\" is a relative path; Docker needs an absolute path to bind-mount it.",
+  "bindPath.useAbsolute": "Use the absolute path instead: {path}",
+  "bindPath.notShared": "{dir} is outside the folders Docker Desktop shares by default ({roots}).",
+  "bindPath.addSharing": "Add it in Docker Desktop → Settings → Resources → File sharing, or keep your config under /Users.",
+  "bindPath.networkFs": "{dir} is on a network filesystem ({fsType}). Ownership and file locking often break inside the container.",
+  "bindPath.localDisk": "Keep the config folder on a local disk (set HOME to a local path, or symlink ~/.owliabot to local storage).",
+  "bindPath.wslDrive": "{dir} is on a Windows drive mounted into WSL ({fsType}). It's slow and Linux permissions don't apply there.",
+  "bindPath.wslHome": "Keep the config folder inside the WSL filesystem (e.g. /home/<you>/.owliabot).",
+  "bindPath.warning": "Docker may not be able to mount your config folder:",
+  "bindPath.continue": "Continue with this folder anyway?",
+  // CA bundle
+  "caBundle.saved": "CA bundle saved to {path}",
+  "caBundle.start": "Start OwliaBot with the CA bundle: {command}",
+  // clipboard
+  "clipboard.copyItem": "Copy the {label}",
+  "clipboard.done": "Done",
+  "clipboard.ask": "Copy to the clipboard?",
+  "clipboard.unavailable": "No clipboard available here; copy the {label} from above instead.",
+  "clipboard.osc52": "Sent the {label} to your terminal's clipboard (needs OSC 52 support, e.g. iTerm2, kitty, WezTerm, Windows Terminal).",
+  "clipboard.copied": "Copied the {label} to the clipboard.",
+  "clipboard.startCommand": "start command",
+  "clipboard.gatewayUrl": "gateway URL",
+  "clipboard.gatewayToken": "gateway token",
+  // MCP servers
+  "mcp.header": "MCP Servers",
+  "mcp.intro": "MCP (Model Context Protocol) lets your bot use external tool servers.",
+  "mcp.existing": "Custom servers in app.yaml: {names}",
+  "mcp.keepExisting": "Keep the {count} custom MCP server(s) from app.yaml?",
+  "mcp.pickPresets": "Which presets should I enable?",
+  "mcp.customDetail": "Define your own server: name, command, args, env and transport",
+  "mcp.added": "Added MCP server \"{name}\"",
+  "mcp.addAnother": "Add another custom server?",
+  "mcp.githubTokenAt": "Create a token at {url} (repository access as needed).",
+  "mcp.githubToken": "GitHub personal access token (or press Enter to set GITHUB_PERSONAL_ACCESS_TOKEN later): ",
+  "mcp.presets": "MCP presets: {names}",
+  "mcp.servers": "Custom MCP servers: {names}",
+  // Discord configuration
+  "discord.header": "Discord configuration",
+  "discord.permissions": "Ensure your bot has these permissions: View Channels, Send Messages, Send Messages in Threads, Read Message History",
+  "discord.see": "See: {url}",
+  // Telegram configuration
+  "telegram.header": "Telegram configuration",
+  "telegram.discover": "Find your user ID by sending the bot a message?",
+  "telegram.otherIds": "Other user IDs allowed to interact (comma-separated, Enter for none): ",
+  "telegram.ids": "User allowlist - user IDs allowed to interact (comma-separated): ",
+  "telegram.allowList": "Telegram user allowlist: {ids}",
+  // demo mode
+  "demo.echoes": "Demo mode: the bot echoes messages back instead of asking a model.",
+  "demo.noKey": "No API key is needed. Run onboarding again without --demo to add one.",
+  "demo.starting": "Starting the demo",
+  "demo.startFailed": "Could not start it: {error}",
+  "demo.startYourself": "Start it yourself with: {command}",
+  "demo.running": "The demo bot is running",
+  "demo.isDemo": "This is a demo: replies are echoes from the demo provider, not a model.",
+  "demo.startWith": "Start it with: {command}",
+  "demo.tryIt": "Send it a message on your chat channel (commands like /status work too), or try the gateway's /health.",
+  "demo.addKeyLater": "When you have an API key, run onboarding again without --demo to add it.",
+  // existing configuration
+  "existing.header": "Existing configuration found",
+  "existing.at": "Found existing config at: {dir}",
+  "existing.anthropicKey": "Found Anthropic API key: {key}...",
+  "existing.anthropicToken": "Found Anthropic setup-token",
+  "existing.anthropicOAuth": "Found Anthropic OAuth token",
+  "existing.openaiKey": "Found OpenAI API key: {key}...",
+  "existing.codexOAuth": "Found OpenAI OAuth token (openai-codex)",
+  "existing.discordToken": "Found Discord token: {token}...",
+  "existing.telegramToken": "Found Telegram token: {token}...",
+  "existing.gatewayToken": "Found Gateway token: {token}...",
+  "existing.reuse": "Do you want to reuse existing configuration?",
+  "existing.reusing": "Will reuse existing configuration",
+  "existing.new": "Will configure new credentials",
+  // validation calls (the labels name the skipped check)
+  "validation.paused": "validation paused after repeated network failures",
+  "validation.requestFailed": "request failed",
+  "validation.rateLimited": "rate-limited by the provider",
+  "validation.unavailable": "provider unavailable (HTTP {status})",
+  "validation.timedOut": "timed out after {seconds}s",
+  "validation.networkError": "network error ({error})",
+  "validation.skipped": "Skipped {label} check: {reason}. Continuing without it.",
+  // devcontainer
+  "devcontainer.saved": "Saved {file} in {path}",
+  "devcontainer.header": "Run in a devcontainer",
+  "devcontainer.vscode": "VS Code: open {dir} and pick \"Reopen in Container\"",
+  "devcontainer.cli": "CLI:     {command}",
+  "devcontainer.gateway": "Gateway: {url}",
+  // Discord channel picker
+  "discordPicker.check": "Discord channel list",
+  "discordPicker.noChannels": "The bot isn't in any server with text channels yet. Invite it first, or type channel IDs.",
+  "discordPicker.pick": "Channels I may answer in (none = any channel where I'm mentioned):",
+  // Discord token check
+  "discordCheck.rejected": "Discord rejected the token (401 Unauthorized)",
+  "discordCheck.unexpected": "unexpected HTTP {status} from Discord",
+  "discordCheck.check": "Discord token",
+  "discordCheck.bot": "Discord bot: {name}",
+  "discordCheck.intentOff": "Message Content Intent is off. Enable it under Bot > Privileged Gateway Intents, or I won't see messages.",
+  "discordCheck.intentUnknown": "Couldn't read the intent settings; make sure Message Content Intent is enabled.",
+  "discordCheck.invalid": "{message}. Check for a typo or reset the token in the developer portal.",
+  "discordCheck.again": "Paste your Discord bot token again (or press Enter to do this later): ",
+  // Docker
+  "docker.defaultPort": "Using default Gateway port: {port}",
+  "docker.watchtower": "Watchtower checks for a new OwliaBot image once a day and restarts the bot on it.",
+  "docker.watchtowerSocket": "It only touches the bot container, but needs access to the Docker socket.",
+  "docker.autoUpdate": "Update the bot automatically (adds a watchtower service)?",
+  "docker.saved": "Saved {file} in {path}",
+  "docker.lastGood": "Last known good image: {image}",
+  "docker.rollback": "If {image} fails its health check, roll back with: {command}",
+  "docker.summary.config": "Config",
+  "docker.summary.secrets": "Secrets",
+  "docker.summary.auth": "Auth",
+  "docker.summary.workspace": "Workspace",
+  "docker.summary.compose": "Compose",
+  "docker.summary.gateway": "Gateway",
+  "docker.summary.url": "URL",
+  "docker.summary.token": "Token",
+  "docker.summary.public": "Public",
+  "docker.summary.title": "Setup Complete",
+  "docker.summary.plainTitle": "Setup complete.",
+  // dry run and overwrite check
+  "dryRun.header": "Dry run: {path}",
+  "dryRun.newFile": "New file (does not exist yet):",
+  "dryRun.noChanges": "No changes.",
+  "dryRun.changes": "Changes compared to the existing file:",
+  "dryRun.changesTo": "Changes to {path}",
+  "dryRun.overwriteOne": "Overwrite this file with the changes above?",
+  "dryRun.overwriteMany": "Overwrite these {count} files with the changes above?",
+  // .env secrets
+  "envFile.header": "Secrets in .env",
+  "envFile.none": "(none)",
+  "envFile.orchestrator": "To inject them from your orchestrator instead, drop the file and the env_file: entry.",
+  "envFile.stale": "{path} is left over from an earlier setup and wins over .env; remove it.",
+  // environments
+  "environments.positiveInt": "Please enter a positive whole number.",
+  "environments.header": "Environment: {name}",
+  "environments.lastGood": "Last known good image for {name}: {image}",
+  "environments.imageTag": "Image tag [{tag}]: ",
+  "environments.port": "Host port for the gateway",
+  "environments.logLevel": "Log level (info/debug) [{level}]: ",
+  "environments.maxIterations": "Max agent iterations per message",
+  "environments.timeout": "Agent timeout in seconds",
+  "environments.summary": "{name}: image tag {tag}, port {port}, log level {level}",
+  "environments.saving": "Saving environment: {name}",
+  "environments.listHeader": "Environments",
+  "environments.where": "gateway: {url}  config: {dir}",
+  // gateway protection
+  "gatewayAuth.noHttp": "Gateway HTTP is disabled, so there is nothing to protect. Skipping gateway auth.",
+  "gatewayAuth.basicEnabled": "Gateway basic auth enabled (user: {user}, password saved to secrets.yaml)",
+  "gatewayAuth.tlsGenerated": "Generated a local CA and client certificate in {dir}",
+  "gatewayAuth.header": "Gateway protection",
+  "gatewayAuth.basicRoutes": "Every gateway route except /health now needs basic auth:",
+  "gatewayAuth.basicPassword": "The password is gateway.basicAuthPassword in secrets.yaml.",
+  "gatewayAuth.mtls": "The gateway now serves HTTPS and requires a client certificate:",
+  "gatewayAuth.caKey": "Keep ca.key private; use it to sign more client certificates.",
+  // gateway HTTP
+  "gateway.optionalHeader": "Gateway HTTP (optional)",
+  "gateway.intro": "Gateway HTTP provides a REST API for health checks and integrations.",
+  "gateway.enable": "Enable Gateway HTTP?",
+  "gateway.port": "Port [{port}]: ",
+  "gateway.tokenGenerated": "Generated gateway token: {token}...",
+  "gateway.enabled": "Gateway HTTP enabled on port {port}",
+  "gateway.header": "Gateway HTTP",
+  "gateway.dockerIntro": "Gateway HTTP is used for health checks and REST API access.",
+  "gateway.hostPort": "Host port to expose the gateway [8787]: ",
+  "gateway.randomToken": "Generated a random gateway token.",
+  "gateway.reusingToken": "Reusing existing Gateway token",
+  "gateway.token": "Gateway token [{token}...]: ",
+  "gateway.tokenSet": "Gateway token set",
+  "gateway.otherHeader": "Other settings",
+  "gateway.timezone": "Timezone [UTC]: ",
+  "gateway.timezoneSet": "Timezone: {tz}",
+  // GitHub Actions
+  "githubActions.header": "GitHub Actions deploy",
+  "githubActions.commit": "Commit app.yaml and docker-compose.yml to the repo root. Keep secrets.yaml on the host only.",
+  "githubActions.copies": "Deploys copy app.yaml to {dir} on the host, the directory docker-compose.yml mounts.",
+  "githubActions.branch": "Branch that deploys [main]: ",
+  "githubActions.composeDir": "docker-compose.yml directory on the host [~/owliabot]: ",
+  "githubActions.nextHeader": "GitOps with GitHub Actions",
+  "githubActions.step1": "1. Copy {path} into the repo root next to docker-compose.yml",
+  "githubActions.step2": "2. Commit both files and {path} (not secrets.yaml)",
+  "githubActions.step3": "3. Add repository secrets: {names}",
+  "githubActions.knownHosts": "   (known hosts: {command})",
+  // workspace files
+  "devWorkspace.bootstrap": "Created BOOTSTRAP.md for first-run setup",
+  "devWorkspace.skills": "Copied bundled skills to: {dir}",
+  // OS keychain
+  "keychain.header": "OS keychain",
+  "keychain.nothing": "Nothing to store in the keychain (no keys or tokens were entered).",
+  "keychain.stored": "Stored {names} in the {backend}",
+  "keychain.manage": "Manage them with: {command}",
+  // kiosk preset
+  "kiosk.offer": "Lock the bot down for kids or an untrusted audience (kiosk preset)?",
+  "kiosk.userId": "{channel} user ID",
+  "kiosk.channelId": "{channel} channel ID",
+  "kiosk.whichOne": "Which {what} may the bot answer?",
+  "kiosk.theOne": "The one {what} the bot answers: ",
+  "kiosk.notAnId": "\"{id}\" is not an ID.",
+  "kiosk.header": "Kiosk preset",
+  "kiosk.intro": "The bot will answer one user, in one place, and nobody else.",
+  "kiosk.whichChannel": "Which chat channel should the bot keep?",
+  "kiosk.inChannel": "in {channel} channel {id}",
+  "kiosk.inDirect": "in {channel} direct messages",
+  "kiosk.answersOnly": "Answers only user {user}, {where}",
+  "kiosk.tools": "Tools: {tools}",
+  "kiosk.commands": "Shell commands: {commands}",
+  "kiosk.noAccess": "No web access, no file writes, no MCP servers, no wallet, no scheduled jobs",
+  "kiosk.history": "History kept for {hours}h; no memory search or session summaries",
+  // Kubernetes
+  "kubernetes.saved": "Saved Kubernetes manifests in {path}",
+  "kubernetes.header": "Deploy to Kubernetes",
+  "kubernetes.fillIn": "Fill in {keys} under the Secret's stringData first.",
+  "kubernetes.portForward": "# reach the gateway locally",
+  // running without Docker
+  "localRun.saved": "Saved {script} and {config} for running without Docker",
+  "localRun.header": "Run without Docker",
+  "localRun.where": "From the directory with docker-compose.yml, with the container stopped:",
+  "localRun.npmInstall": "(run npm install there first)",
+  "localRun.shared": "It uses the same secrets.yaml and workspace as the container.",
+  // custom MCP servers
+  "mcp.custom.name": "Server name (e.g. notion): ",
+  "mcp.custom.badName": "Use letters, digits, - or _ (tools show up as <name>__<tool>).",
+  "mcp.custom.taken": "There is already a server called \"{name}\".",
+  "mcp.custom.transport": "Transport:",
+  "mcp.custom.stdio": "stdio (the gateway runs a local command)",
+  "mcp.custom.sse": "sse (connect to a running server by URL)",
+  "mcp.custom.url": "Server URL: ",
+  "mcp.custom.badUrl": "Please enter an http(s) URL.",
+  "mcp.custom.command": "Command (e.g. npx): ",
+  "mcp.custom.badCommand": "Enter just the executable; arguments come next.",
+  "mcp.custom.args": "Arguments (space-separated, quotes allowed; Enter for none): ",
+  "mcp.custom.openQuote": "A quote was left open.",
+  "mcp.custom.envIntro": "Environment variables are KEY=VALUE pairs. For secrets, write ${VAR} and set VAR in the environment.",
+  "mcp.custom.env": "Environment (e.g. API_KEY=${NOTION_TOKEN}; Enter for none): ",
+  "mcp.custom.badEnv": "Use KEY=VALUE pairs separated by spaces.",
+  // MCP runtime check
+  "mcpRuntime.install": "Install {tool}: {url}",
+  "mcpRuntime.ask": "Check that the MCP server commands are installed?",
+  "mcpRuntime.header": "MCP runtime check",
+  "mcpRuntime.inImage": "The servers run inside the owliabot image, so I'm checking against what it ships.",
+  "mcpRuntime.included": "{command}: included in the image ({servers})",
+  "mcpRuntime.notInImage": "{command}: not in the owliabot image; {servers} will fail to start unless you add it to the image.",
+  "mcpRuntime.found": "found",
+  "mcpRuntime.missing": "{command}: not found; {servers} will fail to start.",
+  // model lists
+  "models.check": "model list",
+  "models.notListed": "{model} isn't in your account's model list; pick one of these instead.",
+  "models.available": "Models available to your key:",
+  // model preset catalog
+  "modelPresets.check": "model preset catalog",
+  "modelPresets.httpError": "Couldn't load the model preset catalog (HTTP {status}); using the built-in presets.",
+  "modelPresets.remote": "the remote model preset catalog",
+  "modelPresets.file": "model preset catalog {path}",
+  "modelPresets.notMapping": "Ignoring {source}: expected a mapping.",
+  "modelPresets.ignoring": "Ignoring {source}: {error}",
+  // Nix
+  "nix.header": "Run with Nix",
+  "nix.run": "# starts OwliaBot with this config",
+  "nix.develop": "# shell with the owliabot command",
+  // setup notification
+  "notify.failed": "Couldn't notify {origin}: {error}.",
+  "notify.rejected": "{origin} rejected the setup notification (HTTP {status}).",
+  "notify.sent": "Sent the setup summary to {origin}",
+  // OIDC login
+  "oidc.header": "OIDC login (oauth2-proxy)",
+  "oidc.register": "Register an OAuth/OIDC application with your identity provider",
+  "oidc.copy": "(Google Workspace, Okta, Azure AD, Keycloak, ...) and copy its client ID and secret.",
+  "oidc.issuer": "Issuer URL (e.g. https://accounts.google.com): ",
+  "oidc.clientId": "Client ID: ",
+  "oidc.clientSecret": "Client secret: ",
+  "oidc.domains": "Allowed email domains, comma-separated [*]: ",
+  "oidc.publicUrl": "Public URL of the gateway [{url}]: ",
+  "oidc.redirect": "Set the redirect URI in your identity provider to: {url}",
+  "oidc.added": "oauth2-proxy will be added to docker-compose.yml",
+  "oidc.saved": "Saved oauth2-proxy settings to {path}",
+  // Ollama
+  "ollama.check": "Ollama model list",
+  "ollama.installed": "Models installed on this Ollama server:",
+  // placeholder credentials
+  "credential.where.anthropic": "Create a key at console.anthropic.com, or run `claude setup-token`.",
+  "credential.where.openai": "Create a key at https://platform.openai.com/api-keys.",
+  "credential.where.openaiCompatible": "Use the key your server was started with, or leave it empty if it needs none.",
+  "credential.where.azureOpenai": "Copy KEY 1 from Keys and Endpoint on your Azure OpenAI resource in the Azure portal.",
+  "credential.where.bedrock": "Create an access key for an IAM user with Bedrock access in the AWS console.",
+  "credential.where.discord": "Copy it from Bot > Reset Token in the Discord developer portal.",
+  "credential.where.telegram": "Copy it from BotFather (/mybots > API Token).",
+  "credential.where.slack": "Copy it from your app at https://api.slack.com/apps (OAuth & Permissions, or Basic Information > App-Level Tokens).",
+  "credential.where.github": "Create one at https://github.com/settings/personal-access-tokens.",
+  "credential.docExample": "that's the example token from the documentation",
+  "credential.placeholderWord": "\"{value}\" is a placeholder",
+  "credential.template": "that's a template placeholder, not the value itself",
+  "credential.shortened": "it looks shortened (contains ...); paste the full value",
+  "credential.exampleText": "it looks like placeholder text from an example",
+  "credential.prefixOnly": "only the key prefix was pasted",
+  "credential.masked": "it looks masked (only x or * after the prefix)",
+  "credential.wontWork": "That won't work: {problem}. {where}",
+  // policy.yml
+  "policy.updateFailed": "Failed to update policy.yml allowedUsers: {error}",
+  // gateway port check
+  "port.inUse": "Port {port} on 127.0.0.1 is already in use.",
+  "port.publishedBy": "It's published by container {containers}.",
+  "port.stopOld": "If that's an older OwliaBot, stop it first: {command}",
+  "port.noneFree": "No free port found between {from} and {to}; keeping {port}.",
+  "port.useNext": "Use port {port} for the gateway instead?",
+  "port.chosen": "Gateway port: {port}",
+  "port.keeping": "Keeping port {port}. Free it before starting the bot.",
+  // profile picker
+  "profile.notSetUp": "{name} ({dir}, not set up yet)",
+  "profile.header": "Profile",
+  "profile.intro": "Each profile is a separate bot with its own settings, container and port.",
+  "profile.new": "New profile...",
+  "profile.which": "Which one do you want to set up?",
+  "profile.name": "Name for the new profile (e.g. work): ",
+  "profile.exists": "Profile {name} already exists; pick another name.",
+  // provider connection and failover tests
+  "smokeTest.offer": "Test the provider connection now?",
+  "smokeTest.header": "Connection test",
+  "smokeTest.testing": "Testing {label}...",
+  "smokeTest.label": "{label} connection",
+  "smokeTest.rejected": "{label}: the key was rejected (HTTP {status}). Check it before starting the bot.",
+  "smokeTest.unexpected": "{label}: unexpected HTTP {status}.",
+  "smokeTest.ok": "{label}: OK in {latency} ms, model {model} is available",
+  "smokeTest.noModel": "{label}: connected in {latency} ms, but model {model} was not found",
+  "failover.untested": "{label}: can't be tested here (OAuth or no key)",
+  "failover.rejected": "{label}: key rejected (HTTP {status})",
+  "failover.noModel": "{label}: model not found",
+  "failover.offer": "Test failover (simulate the first provider failing)?",
+  "failover.header": "Failover test",
+  "failover.accepted": "{primary} accepted an invalid key, so a failure couldn't be simulated.",
+  "failover.works": "Failover works: with {primary} failing, {servedBy} answered in {latency} ms",
+  "failover.none": "No fallback answered with {primary} failing. Check the other providers' keys and models.",
+  // reverse proxy
+  "proxy.offer": "Expose the gateway publicly over HTTPS (Caddy or Traefik with Let's Encrypt)?",
+  "proxy.which": "Which reverse proxy?",
+  "proxy.caddy": "Caddy (one small config file)",
+  "proxy.publicWarning": "Behind the proxy anyone on the internet reaches the gateway; its token only guards /command and /admin.",
+  "proxy.addBasicAuth": "Add basic auth in front of the gateway (recommended)?",
+  "proxy.exposeAnyway": "Expose the gateway without basic auth anyway?",
+  "proxy.header": "Expose the gateway ({name})",
+  "proxy.dns": "Point a DNS A/AAAA record for your domain at this host, and open ports 80 and 443.",
+  "proxy.port80": "Let's Encrypt checks the domain over port 80 before it issues the certificate.",
+  "proxy.domain": "Domain (e.g. bot.example.com): ",
+  "proxy.notDomain": "\"{answer}\" is not a domain name.",
+  "proxy.email": "Email for Let's Encrypt expiry notices (optional): ",
+  "proxy.added": "{proxy} will be added to docker-compose.yml; the gateway port is no longer published on the host",
+  "proxy.saved": "Saved {proxy} config to {path}",
+  // running as root
+  "root.warning": "You're running onboarding as root.",
+  "root.ownedByRoot": "Files under {dir} would be owned by root, so you couldn't edit them later",
+  "root.ownedByRootWhy": "without sudo, and the container (which runs as a non-root user) may not be able to write to them.",
+  "root.handOver": "Give ownership of the generated files to {user}?",
+  "root.willHandOver": "I'll hand the files over to {user} after saving.",
+  "root.tip": "Tip: run onboarding as your normal user instead (without sudo).",
+  "root.continue": "Continue as root anyway?",
+  "root.handedOver": "Handed ownership of the generated files to {user}",
+  "screens.written": "Wrote {count} screen(s) to {dir}",
+  // secrets encryption
+  "secretsKey.reusing": "Reusing the secrets key in {path}",
+  "secretsKey.created": "Created secrets key in {path}",
+  "secretsKey.dryRun": "secrets.yaml would be encrypted with age (key: {path})",
+  "secretsKey.header": "Encrypted secrets",
+  "secretsKey.encrypted": "secrets.yaml is encrypted with age. Key: {path}",
+  "secretsKey.view": "View or edit it with: {command}",
+  "secretsKey.backup": "Back up the key file: without it the secrets cannot be recovered.",
+  // write tools security
+  "security.header": "Write tools security",
+  "security.intro": "Users in the write-tool allowlist can use file write/edit tools.",
+  "security.autoIncluded": "Auto-included from channel allowlists: {ids}",
+  "security.additional": "Additional user IDs to allow (comma-separated, leave empty to use only channel users): ",
+  "security.enabled": "Filesystem write tools enabled (write_file/edit_file/apply_patch)",
+  "security.allowList": "Write-tool allowlist: {ids}",
+  "security.confirmIntro": "With confirmation on, the bot shows each file change and waits for a yes/no reply.",
+  "security.confirmAsk": "Ask for confirmation before each write?",
+  "security.approver": "User ID who approves writes (leave empty for whoever asked for the write): ",
+  "security.approverIgnored": "{id} is not in the channel allow-lists; the bot ignores their messages, including the reply.",
+  "security.where": "Where should the bot ask?",
+  "security.whereChat": "In the chat the request came from",
+  "security.whereDm": "In a direct message to {who}",
+  "security.requester": "the requester",
+  "security.timeout": "Seconds to wait for an answer before denying [60]: ",
+  "security.badTimeout": "\"{answer}\" is not a whole number of seconds; using 60.",
+  "security.confirmOff": "Write-tool confirmation disabled (allowlisted users can write directly)",
+  "security.requestingUser": "the requesting user",
+  "security.confirmChat": "Write-tool confirmation: {who} answers in the same chat, auto-deny after {seconds}s",
+  "security.confirmDm": "Write-tool confirmation: {who} answers by direct message, auto-deny after {seconds}s",
+  // Slack tokens
+  "slack.wrongPrefix": "That doesn't look right: this token starts with {prefix}.",
+  "slack.createApp": "Create a Slack app at https://api.slack.com/apps and turn on Socket Mode.",
+  "slack.scopes": "Bot scopes: {scopes}",
+  "slack.guide": "Guide: {url}",
+  "slack.botToken": "Paste your Slack bot token, xoxb-... (or press Enter to do this later): ",
+  "slack.appToken": "Paste your Slack app-level token, xapp-... (or press Enter to do this later): ",
+  "slack.saved": "Got it. I'll use those Slack tokens.",
+  // swarm mode
+  "swarm.detected": "This Docker engine is in swarm mode.",
+  "swarm.offer": "Generate {file} for `docker stack deploy` instead of docker-compose.yml?",
+  "swarm.saved": "Saved {file} in {path}",
+  "swarm.header": "Deploy to the swarm",
+  "swarm.gateway": "Gateway: {url}",
+  // Telegram user ID lookup
+  "telegramIds.busy": "another process is receiving this bot's updates (stop OwliaBot or remove the webhook)",
+  "telegramIds.waiting": "Open Telegram and send your bot any message (e.g. \"hi\"). Waiting up to 90 seconds...",
+  "telegramIds.check": "Telegram ID lookup",
+  "telegramIds.none": "No message arrived. You can type the ID instead.",
+  "telegramIds.allow": "Allow {name} (ID {id})?",
+  "telegramIds.found": "Found Telegram user IDs: {ids}",
+  // Telegram token check
+  "telegramCheck.format": "That doesn't look like a bot token (expected 123456:ABC...)",
+  "telegramCheck.rejected": "Telegram rejected the token (HTTP {status})",
+  "telegramCheck.unexpected": "unexpected HTTP {status} from Telegram",
+  "telegramCheck.badResponse": "unexpected getMe response",
+  "telegramCheck.check": "Telegram token",
+  "telegramCheck.bot": "Telegram bot: @{username}",
+  "telegramCheck.sayHi": "Say hi once it's running: {url}",
+  "telegramCheck.invalid": "{message}. Copy the token from BotFather again (/mybots > API Token).",
+  "telegramCheck.again": "Paste your Telegram bot token again (or press Enter to do this later): ",
+  // test message
+  "testMessage.channel": "{service} channel {id}",
+  "testMessage.group": "{service} group {id}",
+  "testMessage.user": "{service} DM to user {id}",
+  "testMessage.discordRejected": "The bot token was rejected. Reset it in the Developer Portal.",
+  "testMessage.discordDm": "Discord only allows DMs from a bot that shares a server with the user.",
+  "testMessage.discordInvite": "Invite the bot to this channel's server and give it Send Messages there.",
+  "testMessage.discordNoChannel": "No such channel; check the ID in discord.channelAllowList.",
+  "testMessage.telegramRejected": "The bot token was rejected. Copy it from BotFather again.",
+  "testMessage.telegramStart": "Bots can't start a chat. Open the bot in Telegram and press Start, then try again.",
+  "testMessage.telegramGroup": "Add the bot to the group first.",
+  "testMessage.telegramChatId": "Check the chat ID in the allow-list.",
+  "testMessage.notAllowed": "{to} is not in the Discord or Telegram allow-lists.",
+  "testMessage.noTargets": "No Discord channel/user or Telegram user/group in the allow-lists to send a test message to.",
+  "testMessage.pick": "Send the test message to:",
+  "testMessage.sent": "Test message sent to {target}",
+  "testMessage.reply": "Reply to it: an answer from the bot confirms messages get in as well as out.",
+  "testMessage.unreachable": "Could not reach {service}: {reason}",
+  "testMessage.failed": "Sending to {target} failed: {message}",
+  // timezone
+  "timezone.header": "Timezone",
+  "timezone.ask": "Timezone [{detected}] (auto-detected; Enter to keep, or type part of a city/region to search): ",
+  "timezone.noMatch": "No timezone matches \"{answer}\". Try a city such as Berlin or New York.",
+  "timezone.chosen": "Timezone: {zone}",
+  "timezone.tooMany": "{count} timezones match \"{answer}\". Type a bit more.",
+  "timezone.matching": "Matching timezones:",
+  "timezone.searchAgain": "Search again",
+  // tunnels
+  "tunnel.ngrokHeader": "ngrok tunnel",
+  "tunnel.cloudflareCreate": "Create a tunnel in Cloudflare Zero Trust → Networks → Tunnels and copy its token.",
+  "tunnel.cloudflareHostname": "Add a public hostname that points to the gateway service (http://owliabot:8787,",
+  "tunnel.cloudflareOidc": "or http://oauth2-proxy:4180 when OIDC login is enabled).",
+  "tunnel.ngrokToken": "Copy your authtoken from {url}",
+  "tunnel.token": "Tunnel token: ",
+  "tunnel.hostname": "Public hostname (e.g. bot.example.com): ",
+  "tunnel.ngrokDomain": "Reserved ngrok domain (leave empty for a random URL): ",
+  "tunnel.noHostname": "No hostname given; you'll find it under the tunnel's Public Hostnames in Cloudflare.",
+  "tunnel.added": "{provider} tunnel will be added to docker-compose.yml",
+  "tunnel.randomUrl": "random URL, shown at {url}",
+  "tunnel.seeCloudflare": "see the tunnel's Public Hostnames in Cloudflare",
+  "tunnel.saved": "Saved tunnel token to {path}",
+  // webhook
+  "webhook.path": "Inbound path [{path}]: ",
+  "webhook.badPath": "The path should start with / and contain no spaces or query string.",
+  "webhook.replyUrl": "Send replies to this URL (press Enter to skip): ",
+  "webhook.badUrl": "That doesn't look like an http(s) URL.",
+  "webhook.header": "Webhook",
+  "webhook.intro": "Other systems POST JSON like {example} to this path on Gateway HTTP.",
+  "webhook.auth": "They authenticate with the shared secret in an X-Webhook-Secret header.",
+  "webhook.secret": "Shared secret (press Enter to generate one): ",
+  "webhook.generated": "Generated webhook secret: {secret}...",
+  "webhook.noReplyUrl": "Without a reply URL, I'll read webhook messages but my answers are dropped.",
+  "webhook.gatewayOn": "The webhook is served by Gateway HTTP, so I turned it on (port {port}).",
+  "webhook.ready": "Webhook ready: POST {url}",
+  // existing setup summary
+  "setup.devModeSubtitle": "(dev mode)",
+  "setup.devMode": "Dev mode is on (OWLIABOT_DEV=1). I'll save settings to ~/.owlia_dev/.",
+  "setup.found": "I found an existing setup",
+  "setup.folder": "Settings folder: {dir}",
+  "setup.apiKeySet": "{service}: API key is set ({key}...)",
+  "setup.invalidFormat": "⚠️ invalid format",
+  "setup.setupTokenSet": "Anthropic: setup-token is set {status}",
+  "setup.anthropicOAuth": "Anthropic: OAuth token is present",
+  "setup.azure": "Azure OpenAI: {deployment} at {endpoint}",
+  "setup.codexValid": "OpenAI Codex: ✅ OAuth token is valid",
+  "setup.codexValidUntil": "OpenAI Codex: ✅ OAuth token is valid (expires: {expires})",
+  "setup.tokenSet": "{service}: token is set ({token}...)",
+  "setup.otherProfiles": "Other profiles on this machine: {profiles} (owliabot --profile <name> onboard to change one)",
+  "setup.keep": "Want to keep using these settings?",
+  "setup.keeping": "Great. I'll keep your existing settings.",
+  "setup.fresh": "Okay. We'll set things up fresh.",
+  // workspace and next steps
+  "workspace.header": "Workspace",
+  "workspace.docker": "Docker mode uses the default workspace path inside the container.",
+  "workspace.chosen": "Workspace: {path}",
+  "workspace.ask": "Workspace path [{path}]: ",
+  "nextSteps.header": "Next steps",
+  "nextSteps.intro": "You're almost there:",
+  "nextSteps.discordToken": "Add your Discord token later: {command}",
+  "nextSteps.telegramToken": "Add your Telegram token later: {command}",
+  "nextSteps.envVars": "If you're using environment variables, set ANTHROPIC_API_KEY or OPENAI_API_KEY",
+  "nextSteps.signIn": "Finish sign-in: {command}",
+  "nextSteps.gateway": "Gateway endpoint: {url} (token: {token}...)",
+  "nextSteps.start": "Start OwliaBot: {command}",
+  // systemd service
+  "systemd.header": "Run as a systemd service",
+  "systemd.installs": "installs and starts {unit}",
+  "systemd.afterEdit": "after editing app.yaml",
+  "timing.took": "Setup took {duration}{breakdown}",
+};
+
+export type MessageKey = keyof typeof en;
+export type Catalog = Partial<Record<MessageKey, string>>;
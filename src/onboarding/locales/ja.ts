@@ -0,0 +1,611 @@
+/** Japanese wizard messages (see en.ts for the keys) */
+
+import type { Catalog } from "./en.js";
+
+export const ja: Catalog = {
+  "prompt.pickNumber": "番号を選んでください [1-{count}]{onEnter}: ",
+  "prompt.enterFor": " (Enter で {n})",
+  "prompt.numberRange": "1 から {count} までの番号を入力してください。",
+  "prompt.pickNumbers": "番号を選んでください (例: 1,3 や 2-4、\"all\"、{onEnter}): ",
+  "prompt.enterForList": "Enter で {list}、\"none\" でスキップ",
+  "prompt.enterForNone": "Enter で選択なし",
+  "prompt.numbersRange": "1 から {count} までの番号を使ってください。",
+  "prompt.docsHint": "(d を入力して Enter でドキュメントを開きます)",
+  "prompt.opening": "{url} を開いています",
+  "prompt.docs": "ドキュメント: {url}",
+  "prompt.secretHint": "(入力は伏せ字で表示されます。Ctrl+R で一時的に表示)",
+  "banner.setUp": "OwliaBot をセットアップしましょう{subtitle}",
+
+  "common.saved": "{path} を保存しました",
+  "common.savedSettings": "設定を {path} に保存しました",
+  "common.savedSecrets": "トークンとキーを {path} に保存しました",
+
+  "wizard.helpHint": "(F1、または番号・はい/いいえの質問で h: 現在のステップのヘルプ)",
+  "wizard.saving": "設定を保存しています",
+  "wizard.allSet": "準備完了です！",
+  "wizard.cancelled": "セットアップを中止しました。変更はありません。",
+  "wizard.dryRun": "ドライラン: ファイルは書き込まれていません。",
+  "wizard.backup": "置き換えるファイルを {dir} にコピーしました (元に戻すには: owliabot rollback)",
+  "wizard.envWouldSet": "{file} に設定される変数: {vars}",
+  "wizard.nothing": "(なし)",
+  "wizard.sidecarsIgnored": "--tunnel と --oidc は docker-compose のサイドカーを追加するもので、--docker と一緒にしか使えません。無視します。",
+  "wizard.notifyByInstaller": "ボットが /health に応答したら、install.sh がセットアップの概要を送信します。",
+  "wizard.notifySaved": "セットアップの概要を {path} に保存しました。ボットの起動後、次のコマンドで送信してください:",
+  "wizard.reverseProxyOnly": "ゲートウェイには {url} からのみアクセスできます (ポート {port} は公開されません)。",
+  "wizard.reverseProxyFirstStart": "初回の起動は、{proxy} が証明書を取得するため数秒長くかかります。",
+  "wizard.reverseProxyKept": "ゲートウェイは 127.0.0.1 のままにします。",
+  "wizard.reverseProxyDeclined": "--reverse-proxy には basic 認証、または認証なしで公開することへの同意が必要です。",
+  "wizard.profileStart": "このプロファイルの起動: {command}",
+  "wizard.profileCommands": "他のコマンドにもプロファイルを指定します。例: {command}",
+  "wizard.composeProfiles": "オプションのサービスは compose のプロファイルに入っています。起動: {command}",
+  "wizard.composeProfilesHint": "有効にするには --profile ollama または --profile watchtower を追加してください。",
+  "wizard.runAgain": "いつでも次のコマンドでやり直せます: {command}",
+
+  "provider.header": "AI プロバイダーの設定",
+  "provider.choose": "AI プロバイダーを選んでください:",
+  "provider.option.anthropic": "Anthropic (Claude) - API キーまたは setup-token",
+  "provider.option.openai": "OpenAI (API キー)",
+  "provider.option.codex": "OpenAI Codex (ChatGPT Plus/Pro の OAuth)",
+  "provider.option.compatible": "OpenAI 互換 (Ollama / vLLM / LM Studio など)",
+  "provider.option.multiple": "複数のプロバイダー (フォールバックチェーン)",
+  "provider.option.bedrock": "AWS Bedrock (リージョン、モデル ID、AWS 認証情報)",
+  "provider.option.azure": "Azure OpenAI (エンドポイント、デプロイメント、API バージョン)",
+  "provider.none": "プロバイダーが設定されていません。あとで設定ファイルに追加してください。",
+  "provider.chain": "プロバイダーのフォールバック順: {chain}",
+  "provider.orderIntro": "フォールバック順: OwliaBot は最初のプロバイダーを使い、呼び出しに失敗したら次を試します。",
+  "provider.orderAsk": "移動（\"2 up\"、\"1 down\"）、新しい順序（\"2,1,3\"）を入力するか、Enter でそのままにします: ",
+  "provider.orderInvalid": "\"<番号> up\"、\"<番号> down\"、または 1-{count} のすべての番号を新しい順で入力してください。",
+  "provider.orderDone": "フォールバックの順序: {chain}",
+  "provider.reusing": "既存の {name} の設定を使います",
+  "provider.model": "モデル:",
+  "provider.otherModel": "その他のモデル (名前を入力)",
+  "provider.modelDefault": "モデル [{model}]: ",
+  "provider.anthropic.header": "Anthropic の認証",
+  "provider.anthropic.methods": "2 つの認証方法に対応しています:",
+  "provider.anthropic.setupToken": "  • Setup-token (Claude Pro/Max サブスクリプション)",
+  "provider.anthropic.setupTokenHow": "    `claude setup-token` を実行して発行します",
+  "provider.anthropic.apiKey": "  • API キー (従量課金)",
+  "provider.anthropic.apiKeyWhere": "    console.anthropic.com で取得します",
+  "provider.anthropic.format": "    形式: {format}",
+  "provider.anthropic.loginNow": "ブラウザで Claude アカウントにログインしますか (`claude setup-token` を実行)?",
+  "provider.anthropic.loginStarting": "Claude のログインを開始しています...",
+  "provider.anthropic.loginCopy": "表示された sk-ant-oat01-... のトークンをコピーして、下に貼り付けてください。",
+  "provider.anthropic.loginFailed": "Claude のログインが完了しませんでした。setup-token か API キーを貼り付けることもできます。",
+  "provider.anthropic.paste": "setup-token または API キーを貼り付け (空欄で環境変数を使用): ",
+  "provider.anthropic.tokenWarning": "Setup-token の検証で警告: {error}",
+  "provider.anthropic.tokenSaved": "Setup-token を保存しました (Claude Pro/Max)",
+  "provider.anthropic.keySaved": "API キーを保存しました",
+  "provider.openai.keys": "OpenAI の API キー: {url}",
+  "provider.openai.ask": "OpenAI の API キー (空欄で環境変数を使用): ",
+  "provider.openai.saved": "OpenAI の API キーを保存しました",
+  "provider.codex.intro": "OpenAI Codex は ChatGPT Plus/Pro のサブスクリプションを OAuth で使います。",
+  "provider.codex.startNow": "今すぐ OAuth を開始しますか?",
+  "provider.codex.starting": "OpenAI Codex の OAuth を開始しています...",
+  "provider.codex.done": "OAuth が完了しました",
+  "provider.codex.laterDocker": "コンテナの起動後に実行してください: {command}",
+  "provider.codex.later": "あとで `{command}` を実行して認証してください。",
+  "provider.compatible.intro": "OpenAI 互換は OpenAI v1 API を持つ任意のサーバーに対応します:",
+  "provider.compatible.baseUrl": "API のベース URL (上の候補なら 1-{count}): ",
+  "provider.compatible.keyAt": "{name} の API キーは {url} で取得できます",
+  "provider.compatible.key": "API キー: ",
+  "provider.compatible.keyOptional": "API キー (任意、不要なら空欄): ",
+  "provider.compatible.configured": "OpenAI 互換を設定しました: {url}",
+
+  "channel.header": "チャット",
+  "channel.choose": "OwliaBot とどこでチャットしますか?",
+  "channel.option.both": "両方 (Discord + Telegram)",
+  "channel.option.webhook": "Webhook (HTTP、他のシステム向け)",
+  "channel.existing": "既存のチャット設定を使います:",
+  "channel.noToken": "チャットのトークンはまだありません。あとで追加できます。",
+  "channel.noAllowList": "allowList が設定されていません。設定しないとボットは誰にも応答しません。",
+  "channel.discord.portal": "ボットのトークンは Discord Developer Portal にあります: {url}",
+  "channel.guide": "ガイド: {url}",
+  "channel.discord.intent": "MESSAGE CONTENT INTENT を有効にしてください。無効だとメッセージを受け取れません。",
+  "channel.discord.paste": "Discord ボットのトークンを貼り付け (Enter であとで設定): ",
+  "channel.discord.saved": "Discord のトークンを使います。",
+  "channel.discord.checklist": "必要な権限: View Channels、Send Messages、Send Messages in Threads、Read Message History",
+  "channel.telegram.found": "既存の Telegram 設定が見つかりました (許可ユーザー: {users}、グループ: {groups})。",
+  "channel.telegram.reuse": "既存の Telegram 設定を使いますか?",
+  "channel.telegram.reused": "既存の Telegram 設定を使います。",
+  "channel.telegram.botfather": "BotFather でボットを作成してください: {url}",
+  "channel.telegram.paste": "Telegram ボットのトークンを貼り付け (Enter であとで設定): ",
+  "channel.telegram.saved": "Telegram のトークンを使います。",
+  "channel.telegram.who": "誰と話しますか? (Telegram のユーザー ID をカンマ区切り、Enter でスキップ): ",
+  "channel.telegram.allowed": "次の Telegram ユーザー ID にだけ応答します: {ids}",
+  "channel.telegram.ids": "Telegram のユーザー ID を入力 (カンマ区切り、Enter でスキップ): ",
+  "channel.telegram.allowList": "Telegram の allowList: {ids}",
+  "access.hint.discord": "Discord の ID は 17〜20 桁の数字です (開発者モード > 右クリック > ID をコピー)",
+  "access.hint.telegram": "Telegram のユーザー ID は数字です (@userinfobot で確認できます)。@ユーザー名ではありません",
+  "access.hint.slackMember": "Slack のメンバー ID は U で始まります (プロフィール > ⋮ > メンバー ID をコピー)",
+  "access.hint.slackChannel": "Slack のチャンネル ID は C で始まります (チャンネル詳細の一番下)",
+  "access.invalid": "無効な値: {ids}。{hint}。",
+  "access.header": "アクセス",
+  "access.intro": "どこで、誰と話すかを制限します。質問をスキップするには Enter を押してください。",
+  "access.discord.channels": "応答してよい Discord チャンネル ID (カンマ区切り): ",
+  "access.discord.members": "話しかけてよい Discord ユーザー ID (カンマ区切り): ",
+  "access.discord.channelsSet": "Discord チャンネル: {ids}",
+  "access.discord.membersSet": "Discord ユーザー: {ids}",
+  "access.slack.header": "アクセス (Slack)",
+  "access.slack.intro": "質問をスキップするには Enter を押してください。",
+  "access.slack.channels": "メンションなしで応答する Slack チャンネル ID (カンマ区切り): ",
+  "access.slack.members": "話しかけてよい Slack メンバー ID (カンマ区切り): ",
+  "access.slack.channelsSet": "Slack チャンネル: {ids}",
+  "access.slack.membersSet": "Slack メンバー: {ids}",
+  "access.slack.noMembers": "メンバー ID がないと Slack では誰にも応答しません。後で app.yaml に slack.memberAllowList を追加してください。",
+  "provider.azure.endpoint": "Azure OpenAI のエンドポイント (https://<resource>.openai.azure.com、空欄でスキップ): ",
+  "provider.azure.httpsOnly": "エンドポイントは https:// の URL にしてください。",
+  "provider.azure.notAzure": "Azure OpenAI のホストではありません。そのまま使います (プロキシなど)。",
+  "provider.azure.whereKeys": "エンドポイントとキーは Azure ポータルの「リソース管理 > キーとエンドポイント」に、",
+  "provider.azure.whereDeployment": "デプロイ名は Azure AI Foundry の「デプロイ」にあります。",
+  "provider.azure.deployment": "デプロイ名 [{deployment}]: ",
+  "provider.azure.apiVersion": "API バージョン [{version}]: ",
+  "provider.azure.apiVersionFormat": "API バージョンは 2024-10-21 や 2025-01-01-preview の形式です。",
+  "provider.azure.key": "Azure OpenAI の API キー (環境変数を使う場合は空欄): ",
+  "provider.azure.keySaved": "Azure OpenAI の API キーを保存しました",
+  "provider.azure.configured": "Azure OpenAI を設定しました: {endpoint} の {deployment}",
+  "provider.bedrock.region": "AWS リージョン [{region}]: ",
+  "provider.bedrock.badRegion": "AWS のリージョンではないようです (例: us-east-1、eu-central-1)。",
+  "provider.bedrock.intro": "AWS Bedrock は AWS アカウントを使います。先に Bedrock コンソールでモデルへのアクセスを有効にしてください。",
+  "provider.bedrock.model": "モデル ID [{model}]: ",
+  "provider.bedrock.credentials": "AWS 認証情報:",
+  "provider.bedrock.option.env": "環境変数 (AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY または AWS_PROFILE)",
+  "provider.bedrock.option.instance": "インスタンスプロファイル / タスクロール (EC2、ECS、EKS)",
+  "provider.bedrock.option.keys": "アクセスキー (secrets.yaml に保存)",
+  "provider.bedrock.accessKeyId": "AWS アクセスキー ID: ",
+  "provider.bedrock.secretAccessKey": "AWS シークレットアクセスキー: ",
+  "provider.bedrock.keysSaved": "AWS アクセスキーを保存しました",
+  "provider.bedrock.noKeys": "キーが入力されませんでした。代わりに環境変数 AWS_ACCESS_KEY_ID と AWS_SECRET_ACCESS_KEY を設定してください。",
+  "provider.bedrock.instanceRole": "ロールは AWS SDK が自動で取得します。EC2 上の Docker では、インスタンスメタデータのホップ制限を 2 以上にしてください。",
+  "provider.bedrock.configured": "AWS Bedrock を設定しました: {region} の {model}",
+  "bindPath.relative": "設定フォルダー \"{dir}\" は相対パスです。Docker でバインドマウントするには絶対パスが必要です。",
+  "bindPath.useAbsolute": "代わりに絶対パスを使ってください: {path}",
+  "bindPath.notShared": "{dir} は Docker Desktop が既定で共有するフォルダー ({roots}) の外にあります。",
+  "bindPath.addSharing": "Docker Desktop → Settings → Resources → File sharing で追加するか、設定を /Users の下に置いてください。",
+  "bindPath.networkFs": "{dir} はネットワークファイルシステム ({fsType}) 上にあります。コンテナ内では所有権やファイルロックがよく壊れます。",
+  "bindPath.localDisk": "設定フォルダーはローカルディスクに置いてください (HOME をローカルのパスにするか、~/.owliabot をローカルストレージへのシンボリックリンクにします)。",
+  "bindPath.wslDrive": "{dir} は WSL にマウントされた Windows ドライブ ({fsType}) 上にあります。遅いうえ、Linux のパーミッションが効きません。",
+  "bindPath.wslHome": "設定フォルダーは WSL のファイルシステム内に置いてください (例: /home/<you>/.owliabot)。",
+  "bindPath.warning": "Docker が設定フォルダーをマウントできない可能性があります:",
+  "bindPath.continue": "このフォルダーのまま続けますか？",
+  "caBundle.saved": "CA バンドルを {path} に保存しました",
+  "caBundle.start": "CA バンドルを指定して OwliaBot を起動してください: {command}",
+  "clipboard.copyItem": "{label}をコピー",
+  "clipboard.done": "完了",
+  "clipboard.ask": "クリップボードにコピーしますか？",
+  "clipboard.unavailable": "ここではクリップボードを使えません。上に表示された{label}をコピーしてください。",
+  "clipboard.osc52": "{label}をターミナルのクリップボードに送りました (OSC 52 対応が必要です。例: iTerm2、kitty、WezTerm、Windows Terminal)。",
+  "clipboard.copied": "{label}をクリップボードにコピーしました。",
+  "clipboard.startCommand": "起動コマンド",
+  "clipboard.gatewayUrl": "ゲートウェイ URL",
+  "clipboard.gatewayToken": "ゲートウェイトークン",
+  "mcp.header": "MCP サーバー",
+  "mcp.intro": "MCP (Model Context Protocol) を使うと、ボットが外部のツールサーバーを利用できます。",
+  "mcp.existing": "app.yaml のカスタムサーバー: {names}",
+  "mcp.keepExisting": "app.yaml のカスタム MCP サーバー {count} 件を残しますか？",
+  "mcp.pickPresets": "どのプリセットを有効にしますか？",
+  "mcp.customDetail": "独自のサーバーを定義します: 名前、コマンド、引数、環境変数、トランスポート",
+  "mcp.added": "MCP サーバー \"{name}\" を追加しました",
+  "mcp.addAnother": "別のカスタムサーバーを追加しますか？",
+  "mcp.githubTokenAt": "{url} でトークンを作成してください (リポジトリへのアクセスは必要に応じて)。",
+  "mcp.githubToken": "GitHub の個人アクセストークン (後で GITHUB_PERSONAL_ACCESS_TOKEN を設定する場合は Enter): ",
+  "mcp.presets": "MCP プリセット: {names}",
+  "mcp.servers": "カスタム MCP サーバー: {names}",
+  "discord.header": "Discord の設定",
+  "discord.permissions": "ボットに次の権限があることを確認してください: View Channels、Send Messages、Send Messages in Threads、Read Message History",
+  "discord.see": "参照: {url}",
+  "telegram.header": "Telegram の設定",
+  "telegram.discover": "ボットにメッセージを送ってユーザー ID を調べますか？",
+  "telegram.otherIds": "やり取りを許可する他のユーザー ID (カンマ区切り、なければ Enter): ",
+  "telegram.ids": "ユーザー許可リスト - やり取りを許可するユーザー ID (カンマ区切り): ",
+  "telegram.allowList": "Telegram ユーザー許可リスト: {ids}",
+  "demo.echoes": "デモモード: ボットはモデルに問い合わせず、メッセージをそのまま返します。",
+  "demo.noKey": "API キーは不要です。追加するには --demo なしでオンボーディングをもう一度実行してください。",
+  "demo.starting": "デモを起動しています",
+  "demo.startFailed": "起動できませんでした: {error}",
+  "demo.startYourself": "次のコマンドで手動で起動してください: {command}",
+  "demo.running": "デモボットが動いています",
+  "demo.isDemo": "これはデモです: 返信はモデルではなく、デモプロバイダーのエコーです。",
+  "demo.startWith": "起動コマンド: {command}",
+  "demo.tryIt": "チャットチャンネルでメッセージを送るか (/status などのコマンドも使えます)、ゲートウェイの /health を試してください。",
+  "demo.addKeyLater": "API キーを用意できたら、--demo なしでオンボーディングをもう一度実行して追加してください。",
+  "existing.header": "既存の設定が見つかりました",
+  "existing.at": "既存の設定の場所: {dir}",
+  "existing.anthropicKey": "Anthropic の API キーが見つかりました: {key}...",
+  "existing.anthropicToken": "Anthropic の setup-token が見つかりました",
+  "existing.anthropicOAuth": "Anthropic の OAuth トークンが見つかりました",
+  "existing.openaiKey": "OpenAI の API キーが見つかりました: {key}...",
+  "existing.codexOAuth": "OpenAI の OAuth トークン (openai-codex) が見つかりました",
+  "existing.discordToken": "Discord のトークンが見つかりました: {token}...",
+  "existing.telegramToken": "Telegram のトークンが見つかりました: {token}...",
+  "existing.gatewayToken": "ゲートウェイトークンが見つかりました: {token}...",
+  "existing.reuse": "既存の設定を再利用しますか？",
+  "existing.reusing": "既存の設定を再利用します",
+  "existing.new": "新しい認証情報を設定します",
+  "validation.paused": "ネットワークエラーが続いたため確認を一時停止しました",
+  "validation.requestFailed": "リクエストに失敗しました",
+  "validation.rateLimited": "プロバイダーのレート制限にかかりました",
+  "validation.unavailable": "プロバイダーが利用できません (HTTP {status})",
+  "validation.timedOut": "{seconds} 秒でタイムアウトしました",
+  "validation.networkError": "ネットワークエラー ({error})",
+  "validation.skipped": "{label}の確認をスキップしました: {reason}。確認なしで続けます。",
+  "devcontainer.saved": "{file} を {path} に保存しました",
+  "devcontainer.header": "devcontainer で実行",
+  "devcontainer.vscode": "VS Code: {dir} を開いて「Reopen in Container」を選びます",
+  "devcontainer.cli": "CLI:     {command}",
+  "devcontainer.gateway": "ゲートウェイ: {url}",
+  "discordPicker.check": "Discord チャンネル一覧",
+  "discordPicker.noChannels": "ボットはまだテキストチャンネルのあるサーバーに参加していません。先に招待するか、チャンネル ID を入力してください。",
+  "discordPicker.pick": "応答してよいチャンネル (なし = メンションされたすべてのチャンネル):",
+  "discordCheck.rejected": "Discord がトークンを拒否しました (401 Unauthorized)",
+  "discordCheck.unexpected": "Discord から想定外の HTTP {status} が返りました",
+  "discordCheck.check": "Discord トークン",
+  "discordCheck.bot": "Discord ボット: {name}",
+  "discordCheck.intentOff": "Message Content Intent がオフです。Bot > Privileged Gateway Intents で有効にしないとメッセージを受け取れません。",
+  "discordCheck.intentUnknown": "インテントの設定を読めませんでした。Message Content Intent が有効か確認してください。",
+  "discordCheck.invalid": "{message}。入力ミスがないか確認するか、開発者ポータルでトークンをリセットしてください。",
+  "discordCheck.again": "Discord ボットのトークンをもう一度貼り付けてください (後で設定する場合は Enter): ",
+  "docker.defaultPort": "既定のゲートウェイポートを使います: {port}",
+  "docker.watchtower": "Watchtower は 1 日 1 回 OwliaBot の新しいイメージを確認し、そのイメージでボットを再起動します。",
+  "docker.watchtowerSocket": "触るのはボットのコンテナだけですが、Docker ソケットへのアクセスが必要です。",
+  "docker.autoUpdate": "ボットを自動で更新しますか (watchtower サービスを追加します)？",
+  "docker.saved": "{file} を {path} に保存しました",
+  "docker.lastGood": "最後に正常に動いたイメージ: {image}",
+  "docker.rollback": "{image} のヘルスチェックが失敗したら、次のコマンドで戻せます: {command}",
+  "docker.summary.config": "設定",
+  "docker.summary.secrets": "シークレット",
+  "docker.summary.auth": "認証",
+  "docker.summary.workspace": "ワークスペース",
+  "docker.summary.compose": "Compose",
+  "docker.summary.gateway": "ゲートウェイ",
+  "docker.summary.url": "URL",
+  "docker.summary.token": "トークン",
+  "docker.summary.public": "公開 URL",
+  "docker.summary.title": "セットアップ完了",
+  "docker.summary.plainTitle": "セットアップが完了しました。",
+  "dryRun.header": "ドライラン: {path}",
+  "dryRun.newFile": "新しいファイル (まだ存在しません):",
+  "dryRun.noChanges": "変更はありません。",
+  "dryRun.changes": "既存のファイルとの差分:",
+  "dryRun.changesTo": "{path} の変更",
+  "dryRun.overwriteOne": "上の変更でこのファイルを上書きしますか？",
+  "dryRun.overwriteMany": "上の変更でこれら {count} 個のファイルを上書きしますか？",
+  "envFile.header": ".env のシークレット",
+  "envFile.none": "(なし)",
+  "envFile.orchestrator": "オーケストレーターから注入する場合は、このファイルと env_file: の行を削除してください。",
+  "envFile.stale": "{path} は以前のセットアップの残りで、.env より優先されます。削除してください。",
+  "environments.positiveInt": "正の整数を入力してください。",
+  "environments.header": "環境: {name}",
+  "environments.lastGood": "{name} で最後に正常に動いたイメージ: {image}",
+  "environments.imageTag": "イメージタグ [{tag}]: ",
+  "environments.port": "ゲートウェイのホストポート",
+  "environments.logLevel": "ログレベル (info/debug) [{level}]: ",
+  "environments.maxIterations": "メッセージごとのエージェント反復回数の上限",
+  "environments.timeout": "エージェントのタイムアウト (秒)",
+  "environments.summary": "{name}: イメージタグ {tag}、ポート {port}、ログレベル {level}",
+  "environments.saving": "環境を保存しています: {name}",
+  "environments.listHeader": "環境",
+  "environments.where": "ゲートウェイ: {url}  設定: {dir}",
+  "gatewayAuth.noHttp": "ゲートウェイ HTTP が無効なので、保護するものがありません。ゲートウェイ認証をスキップします。",
+  "gatewayAuth.basicEnabled": "ゲートウェイの Basic 認証を有効にしました (ユーザー: {user}、パスワードは secrets.yaml に保存)",
+  "gatewayAuth.tlsGenerated": "ローカル CA とクライアント証明書を {dir} に生成しました",
+  "gatewayAuth.header": "ゲートウェイの保護",
+  "gatewayAuth.basicRoutes": "/health 以外のすべてのゲートウェイのルートで Basic 認証が必要になりました:",
+  "gatewayAuth.basicPassword": "パスワードは secrets.yaml の gateway.basicAuthPassword です。",
+  "gatewayAuth.mtls": "ゲートウェイは HTTPS で応答し、クライアント証明書が必要になりました:",
+  "gatewayAuth.caKey": "ca.key は外部に出さないでください。追加のクライアント証明書の署名に使います。",
+  "gateway.optionalHeader": "ゲートウェイ HTTP (任意)",
+  "gateway.intro": "ゲートウェイ HTTP は、ヘルスチェックや連携のための REST API を提供します。",
+  "gateway.enable": "ゲートウェイ HTTP を有効にしますか？",
+  "gateway.port": "ポート [{port}]: ",
+  "gateway.tokenGenerated": "ゲートウェイトークンを生成しました: {token}...",
+  "gateway.enabled": "ポート {port} でゲートウェイ HTTP を有効にしました",
+  "gateway.header": "ゲートウェイ HTTP",
+  "gateway.dockerIntro": "ゲートウェイ HTTP はヘルスチェックと REST API のアクセスに使います。",
+  "gateway.hostPort": "ゲートウェイを公開するホストポート [8787]: ",
+  "gateway.randomToken": "ランダムなゲートウェイトークンを生成しました。",
+  "gateway.reusingToken": "既存のゲートウェイトークンを再利用します",
+  "gateway.token": "ゲートウェイトークン [{token}...]: ",
+  "gateway.tokenSet": "ゲートウェイトークンを設定しました",
+  "gateway.otherHeader": "その他の設定",
+  "gateway.timezone": "タイムゾーン [UTC]: ",
+  "gateway.timezoneSet": "タイムゾーン: {tz}",
+  "githubActions.header": "GitHub Actions でのデプロイ",
+  "githubActions.commit": "app.yaml と docker-compose.yml をリポジトリのルートにコミットします。secrets.yaml はホストにだけ置いてください。",
+  "githubActions.copies": "デプロイ時、app.yaml はホストの {dir} (docker-compose.yml がマウントするディレクトリ) にコピーされます。",
+  "githubActions.branch": "デプロイするブランチ [main]: ",
+  "githubActions.composeDir": "ホスト上の docker-compose.yml のディレクトリ [~/owliabot]: ",
+  "githubActions.nextHeader": "GitHub Actions による GitOps",
+  "githubActions.step1": "1. {path} をリポジトリのルート (docker-compose.yml の隣) にコピーします",
+  "githubActions.step2": "2. 両方のファイルと {path} をコミットします (secrets.yaml は除く)",
+  "githubActions.step3": "3. リポジトリのシークレットを追加します: {names}",
+  "githubActions.knownHosts": "   (known hosts は {command} で取得できます)",
+  "devWorkspace.bootstrap": "初回セットアップ用に BOOTSTRAP.md を作成しました",
+  "devWorkspace.skills": "同梱のスキルをコピーしました: {dir}",
+  "keychain.header": "OS のキーチェーン",
+  "keychain.nothing": "キーチェーンに保存するものはありません (キーやトークンが入力されていません)。",
+  "keychain.stored": "{names} を {backend} に保存しました",
+  "keychain.manage": "管理コマンド: {command}",
+  "kiosk.offer": "子どもや信頼できない相手向けにボットを制限しますか (キオスクプリセット)？",
+  "kiosk.userId": "{channel} のユーザー ID",
+  "kiosk.channelId": "{channel} のチャンネル ID",
+  "kiosk.whichOne": "ボットが応答してよい {what} はどれですか？",
+  "kiosk.theOne": "ボットが応答する唯一の {what}: ",
+  "kiosk.notAnId": "\"{id}\" は ID ではありません。",
+  "kiosk.header": "キオスクプリセット",
+  "kiosk.intro": "ボットは 1 か所で 1 人のユーザーにだけ応答し、ほかの誰にも応答しません。",
+  "kiosk.whichChannel": "どのチャットチャンネルを残しますか？",
+  "kiosk.inChannel": "{channel} のチャンネル {id} で",
+  "kiosk.inDirect": "{channel} のダイレクトメッセージで",
+  "kiosk.answersOnly": "ユーザー {user} にだけ応答します ({where})",
+  "kiosk.tools": "ツール: {tools}",
+  "kiosk.commands": "シェルコマンド: {commands}",
+  "kiosk.noAccess": "Web アクセス、ファイル書き込み、MCP サーバー、ウォレット、定期ジョブはすべて無効",
+  "kiosk.history": "履歴は {hours} 時間だけ保持。メモリ検索とセッション要約はなし",
+  "kubernetes.saved": "Kubernetes マニフェストを {path} に保存しました",
+  "kubernetes.header": "Kubernetes へのデプロイ",
+  "kubernetes.fillIn": "先に Secret の stringData に {keys} を記入してください。",
+  "kubernetes.portForward": "# ローカルからゲートウェイに接続",
+  "localRun.saved": "Docker なしで動かすための {script} と {config} を保存しました",
+  "localRun.header": "Docker なしで実行",
+  "localRun.where": "コンテナを止めた状態で、docker-compose.yml のあるディレクトリから:",
+  "localRun.npmInstall": "(先にそこで npm install を実行)",
+  "localRun.shared": "コンテナと同じ secrets.yaml とワークスペースを使います。",
+  "mcp.custom.name": "サーバー名 (例: notion): ",
+  "mcp.custom.badName": "英字、数字、- または _ を使ってください (ツールは <name>__<tool> として表示されます)。",
+  "mcp.custom.taken": "\"{name}\" という名前のサーバーはすでにあります。",
+  "mcp.custom.transport": "トランスポート:",
+  "mcp.custom.stdio": "stdio (ゲートウェイがローカルのコマンドを実行)",
+  "mcp.custom.sse": "sse (起動中のサーバーに URL で接続)",
+  "mcp.custom.url": "サーバー URL: ",
+  "mcp.custom.badUrl": "http(s) の URL を入力してください。",
+  "mcp.custom.command": "コマンド (例: npx): ",
+  "mcp.custom.badCommand": "実行ファイルだけを入力してください。引数は次に聞きます。",
+  "mcp.custom.args": "引数 (スペース区切り、引用符可。なければ Enter): ",
+  "mcp.custom.openQuote": "引用符が閉じられていません。",
+  "mcp.custom.envIntro": "環境変数は KEY=VALUE の形式です。シークレットは ${VAR} と書き、VAR を環境で設定してください。",
+  "mcp.custom.env": "環境変数 (例: API_KEY=${NOTION_TOKEN}。なければ Enter): ",
+  "mcp.custom.badEnv": "スペース区切りの KEY=VALUE の形式にしてください。",
+  "mcpRuntime.install": "{tool} をインストールしてください: {url}",
+  "mcpRuntime.ask": "MCP サーバーのコマンドがインストールされているか確認しますか？",
+  "mcpRuntime.header": "MCP ランタイムの確認",
+  "mcpRuntime.inImage": "サーバーは owliabot イメージの中で動くので、イメージに含まれるものと照合します。",
+  "mcpRuntime.included": "{command}: イメージに含まれています ({servers})",
+  "mcpRuntime.notInImage": "{command}: owliabot イメージにありません。イメージに追加しない限り {servers} は起動できません。",
+  "mcpRuntime.found": "見つかりました",
+  "mcpRuntime.missing": "{command}: 見つかりません。{servers} は起動できません。",
+  "models.check": "モデル一覧",
+  "models.notListed": "{model} はアカウントのモデル一覧にありません。代わりに次から選んでください。",
+  "models.available": "このキーで使えるモデル:",
+  "modelPresets.check": "モデルプリセットのカタログ",
+  "modelPresets.httpError": "モデルプリセットのカタログを読み込めませんでした (HTTP {status})。組み込みのプリセットを使います。",
+  "modelPresets.remote": "リモートのモデルプリセットのカタログ",
+  "modelPresets.file": "モデルプリセットのカタログ {path}",
+  "modelPresets.notMapping": "{source} を無視します: マッピングではありません。",
+  "modelPresets.ignoring": "{source} を無視します: {error}",
+  "nix.header": "Nix で実行",
+  "nix.run": "# この設定で OwliaBot を起動",
+  "nix.develop": "# owliabot コマンドが使えるシェル",
+  "notify.failed": "{origin} に通知できませんでした: {error}。",
+  "notify.rejected": "{origin} がセットアップの通知を拒否しました (HTTP {status})。",
+  "notify.sent": "セットアップの概要を {origin} に送りました",
+  "oidc.header": "OIDC ログイン (oauth2-proxy)",
+  "oidc.register": "ID プロバイダーに OAuth/OIDC アプリケーションを登録し",
+  "oidc.copy": "(Google Workspace、Okta、Azure AD、Keycloak など)、クライアント ID とシークレットをコピーしてください。",
+  "oidc.issuer": "発行者 (Issuer) の URL (例: https://accounts.google.com): ",
+  "oidc.clientId": "クライアント ID: ",
+  "oidc.clientSecret": "クライアントシークレット: ",
+  "oidc.domains": "許可するメールドメイン (カンマ区切り) [*]: ",
+  "oidc.publicUrl": "ゲートウェイの公開 URL [{url}]: ",
+  "oidc.redirect": "ID プロバイダーのリダイレクト URI を次に設定してください: {url}",
+  "oidc.added": "oauth2-proxy を docker-compose.yml に追加します",
+  "oidc.saved": "oauth2-proxy の設定を {path} に保存しました",
+  "ollama.check": "Ollama のモデル一覧",
+  "ollama.installed": "この Ollama サーバーにインストール済みのモデル:",
+  "credential.where.anthropic": "console.anthropic.com でキーを作成するか、`claude setup-token` を実行してください。",
+  "credential.where.openai": "https://platform.openai.com/api-keys でキーを作成してください。",
+  "credential.where.openaiCompatible": "サーバーの起動時に指定したキーを使ってください。不要なら空欄のままにします。",
+  "credential.where.azureOpenai": "Azure ポータルで Azure OpenAI リソースの「キーとエンドポイント」から KEY 1 をコピーしてください。",
+  "credential.where.bedrock": "AWS コンソールで、Bedrock にアクセスできる IAM ユーザーのアクセスキーを作成してください。",
+  "credential.where.discord": "Discord 開発者ポータルの Bot > Reset Token からコピーしてください。",
+  "credential.where.telegram": "BotFather (/mybots > API Token) からコピーしてください。",
+  "credential.where.slack": "https://api.slack.com/apps のアプリ (OAuth & Permissions、または Basic Information > App-Level Tokens) からコピーしてください。",
+  "credential.where.github": "https://github.com/settings/personal-access-tokens で作成してください。",
+  "credential.docExample": "ドキュメントに載っている例のトークンです",
+  "credential.placeholderWord": "\"{value}\" はプレースホルダーです",
+  "credential.template": "値そのものではなく、テンプレートのプレースホルダーです",
+  "credential.shortened": "省略されているようです (... を含みます)。値をすべて貼り付けてください",
+  "credential.exampleText": "例にあるプレースホルダーの文字列のようです",
+  "credential.prefixOnly": "キーの接頭辞しか貼り付けられていません",
+  "credential.masked": "伏せ字のようです (接頭辞のあとが x や * だけ)",
+  "credential.wontWork": "その値は使えません: {problem}。{where}",
+  "policy.updateFailed": "policy.yml の allowedUsers を更新できませんでした: {error}",
+  "port.inUse": "127.0.0.1 のポート {port} はすでに使われています。",
+  "port.publishedBy": "コンテナ {containers} が公開しています。",
+  "port.stopOld": "古い OwliaBot なら、先に止めてください: {command}",
+  "port.noneFree": "{from} から {to} の間に空いているポートがありません。{port} のままにします。",
+  "port.useNext": "代わりにポート {port} をゲートウェイに使いますか？",
+  "port.chosen": "ゲートウェイのポート: {port}",
+  "port.keeping": "ポート {port} のままにします。ボットを起動する前に空けてください。",
+  "profile.notSetUp": "{name}（{dir}、未設定）",
+  "profile.header": "プロファイル",
+  "profile.intro": "プロファイルごとに別のボットになり、設定・コンテナ・ポートもそれぞれ別です。",
+  "profile.new": "新しいプロファイル...",
+  "profile.which": "どれを設定しますか？",
+  "profile.name": "新しいプロファイルの名前（例: work）: ",
+  "profile.exists": "プロファイル {name} はすでにあります。別の名前にしてください。",
+  "smokeTest.offer": "今すぐプロバイダーへの接続をテストしますか？",
+  "smokeTest.header": "接続テスト",
+  "smokeTest.testing": "{label} をテストしています...",
+  "smokeTest.label": "{label} への接続",
+  "smokeTest.rejected": "{label}: キーが拒否されました（HTTP {status}）。ボットを起動する前に確認してください。",
+  "smokeTest.unexpected": "{label}: 予期しない HTTP {status} です。",
+  "smokeTest.ok": "{label}: OK（{latency} ms）、モデル {model} は利用できます",
+  "smokeTest.noModel": "{label}: {latency} ms で接続しましたが、モデル {model} が見つかりませんでした",
+  "failover.untested": "{label}: ここではテストできません（OAuth またはキーなし）",
+  "failover.rejected": "{label}: キーが拒否されました（HTTP {status}）",
+  "failover.noModel": "{label}: モデルが見つかりません",
+  "failover.offer": "フェイルオーバーをテストしますか（最初のプロバイダーの失敗を再現します）？",
+  "failover.header": "フェイルオーバーテスト",
+  "failover.accepted": "{primary} が無効なキーを受け付けたため、失敗を再現できませんでした。",
+  "failover.works": "フェイルオーバーは動作しています: {primary} が失敗しても {servedBy} が {latency} ms で応答しました",
+  "failover.none": "{primary} が失敗したとき、応答したフォールバックはありませんでした。ほかのプロバイダーのキーとモデルを確認してください。",
+  "proxy.offer": "ゲートウェイを HTTPS で公開しますか（Caddy または Traefik と Let's Encrypt）？",
+  "proxy.which": "どのリバースプロキシを使いますか？",
+  "proxy.caddy": "Caddy（小さな設定ファイル 1 つ）",
+  "proxy.publicWarning": "プロキシ経由ではインターネット上の誰でもゲートウェイに届きます。トークンが守るのは /command と /admin だけです。",
+  "proxy.addBasicAuth": "ゲートウェイの前に Basic 認証を追加しますか（推奨）？",
+  "proxy.exposeAnyway": "Basic 認証なしでゲートウェイを公開しますか？",
+  "proxy.header": "ゲートウェイの公開（{name}）",
+  "proxy.dns": "ドメインの DNS A/AAAA レコードをこのホストに向け、ポート 80 と 443 を開けてください。",
+  "proxy.port80": "Let's Encrypt は証明書を発行する前に、ポート 80 でドメインを確認します。",
+  "proxy.domain": "ドメイン（例: bot.example.com）: ",
+  "proxy.notDomain": "\"{answer}\" はドメイン名ではありません。",
+  "proxy.email": "Let's Encrypt の期限切れ通知用メールアドレス（任意）: ",
+  "proxy.added": "{proxy} を docker-compose.yml に追加します。ゲートウェイのポートはホストに公開されなくなります",
+  "proxy.saved": "{proxy} の設定を {path} に保存しました",
+  "root.warning": "root としてオンボーディングを実行しています。",
+  "root.ownedByRoot": "{dir} 以下のファイルの所有者が root になり、あとから編集するには",
+  "root.ownedByRootWhy": "sudo が必要になります。また、root 以外のユーザーで動くコンテナが書き込めないことがあります。",
+  "root.handOver": "生成したファイルの所有者を {user} にしますか？",
+  "root.willHandOver": "保存後にファイルの所有者を {user} にします。",
+  "root.tip": "ヒント: オンボーディングは普段のユーザーで（sudo なしで）実行してください。",
+  "root.continue": "このまま root で続けますか？",
+  "root.handedOver": "生成したファイルの所有者を {user} にしました",
+  "screens.written": "{count} 画面を {dir} に書き出しました",
+  "secretsKey.reusing": "{path} のシークレット鍵を使います",
+  "secretsKey.created": "シークレット鍵を {path} に作成しました",
+  "secretsKey.dryRun": "secrets.yaml は age で暗号化されます（鍵: {path}）",
+  "secretsKey.header": "暗号化されたシークレット",
+  "secretsKey.encrypted": "secrets.yaml は age で暗号化されています。鍵: {path}",
+  "secretsKey.view": "表示・編集するには: {command}",
+  "secretsKey.backup": "鍵ファイルをバックアップしてください。鍵がないとシークレットを復元できません。",
+  "security.header": "書き込みツールのセキュリティ",
+  "security.intro": "書き込みツールの許可リストにいるユーザーは、ファイルの書き込み・編集ツールを使えます。",
+  "security.autoIncluded": "チャンネルの許可リストから自動で追加: {ids}",
+  "security.additional": "追加で許可するユーザー ID（カンマ区切り。チャンネルのユーザーだけなら空のまま）: ",
+  "security.enabled": "ファイル書き込みツールを有効にしました（write_file/edit_file/apply_patch）",
+  "security.allowList": "書き込みツールの許可リスト: {ids}",
+  "security.confirmIntro": "確認をオンにすると、ボットはファイルの変更ごとに内容を示し、yes/no の返事を待ちます。",
+  "security.confirmAsk": "書き込みのたびに確認しますか？",
+  "security.approver": "書き込みを承認するユーザー ID（依頼した本人にするなら空のまま）: ",
+  "security.approverIgnored": "{id} はチャンネルの許可リストにありません。ボットはこのユーザーのメッセージを（返事も含めて）無視します。",
+  "security.where": "ボットはどこで確認しますか？",
+  "security.whereChat": "依頼があったチャットで",
+  "security.whereDm": "{who} へのダイレクトメッセージで",
+  "security.requester": "依頼した人",
+  "security.timeout": "拒否するまで返事を待つ秒数 [60]: ",
+  "security.badTimeout": "\"{answer}\" は整数の秒数ではありません。60 を使います。",
+  "security.confirmOff": "書き込みツールの確認はオフです（許可リストのユーザーは直接書き込めます）",
+  "security.requestingUser": "依頼したユーザー",
+  "security.confirmChat": "書き込みツールの確認: {who} が同じチャットで返事します。{seconds} 秒で自動的に拒否します",
+  "security.confirmDm": "書き込みツールの確認: {who} がダイレクトメッセージで返事します。{seconds} 秒で自動的に拒否します",
+  "slack.wrongPrefix": "正しくないようです。このトークンは {prefix} で始まります。",
+  "slack.createApp": "https://api.slack.com/apps で Slack アプリを作成し、Socket Mode をオンにしてください。",
+  "slack.scopes": "ボットのスコープ: {scopes}",
+  "slack.guide": "ガイド: {url}",
+  "slack.botToken": "Slack のボットトークン（xoxb-...）を貼り付けてください（あとで設定するなら Enter）: ",
+  "slack.appToken": "Slack のアプリレベルトークン（xapp-...）を貼り付けてください（あとで設定するなら Enter）: ",
+  "slack.saved": "了解しました。その Slack トークンを使います。",
+  "swarm.detected": "この Docker エンジンは swarm モードです。",
+  "swarm.offer": "docker-compose.yml の代わりに `docker stack deploy` 用の {file} を生成しますか？",
+  "swarm.saved": "{file} を {path} に保存しました",
+  "swarm.header": "swarm にデプロイ",
+  "swarm.gateway": "ゲートウェイ: {url}",
+  "telegramIds.busy": "別のプロセスがこのボットの更新を受信しています（OwliaBot を止めるか Webhook を削除してください）",
+  "telegramIds.waiting": "Telegram を開いて、ボットに何かメッセージ（例: \"hi\"）を送ってください。最大 90 秒待ちます...",
+  "telegramIds.check": "Telegram ID の確認",
+  "telegramIds.none": "メッセージが届きませんでした。代わりに ID を入力できます。",
+  "telegramIds.allow": "{name}（ID {id}）を許可しますか？",
+  "telegramIds.found": "Telegram のユーザー ID が見つかりました: {ids}",
+  "telegramCheck.format": "ボットトークンではないようです（123456:ABC... の形式です）",
+  "telegramCheck.rejected": "Telegram がトークンを拒否しました（HTTP {status}）",
+  "telegramCheck.unexpected": "Telegram から予期しない HTTP {status} が返りました",
+  "telegramCheck.badResponse": "getMe の応答が想定外です",
+  "telegramCheck.check": "Telegram トークン",
+  "telegramCheck.bot": "Telegram ボット: @{username}",
+  "telegramCheck.sayHi": "起動したら話しかけてみてください: {url}",
+  "telegramCheck.invalid": "{message}。BotFather からトークンをもう一度コピーしてください（/mybots > API Token）。",
+  "telegramCheck.again": "Telegram のボットトークンをもう一度貼り付けてください（あとで設定するなら Enter）: ",
+  "testMessage.channel": "{service} チャンネル {id}",
+  "testMessage.group": "{service} グループ {id}",
+  "testMessage.user": "{service} ユーザー {id} への DM",
+  "testMessage.discordRejected": "ボットトークンが拒否されました。Developer Portal でリセットしてください。",
+  "testMessage.discordDm": "Discord では、ユーザーと同じサーバーにいるボットしか DM を送れません。",
+  "testMessage.discordInvite": "このチャンネルのサーバーにボットを招待し、そこでメッセージ送信の権限を与えてください。",
+  "testMessage.discordNoChannel": "そのチャンネルはありません。discord.channelAllowList の ID を確認してください。",
+  "testMessage.telegramRejected": "ボットトークンが拒否されました。BotFather からもう一度コピーしてください。",
+  "testMessage.telegramStart": "ボットからはチャットを始められません。Telegram でボットを開いて Start を押してから、もう一度試してください。",
+  "testMessage.telegramGroup": "先にボットをグループに追加してください。",
+  "testMessage.telegramChatId": "許可リストのチャット ID を確認してください。",
+  "testMessage.notAllowed": "{to} は Discord と Telegram のどちらの許可リストにもありません。",
+  "testMessage.noTargets": "テストメッセージを送れる Discord のチャンネル・ユーザーや Telegram のユーザー・グループが許可リストにありません。",
+  "testMessage.pick": "テストメッセージの送信先:",
+  "testMessage.sent": "{target} にテストメッセージを送りました",
+  "testMessage.reply": "返信してみてください。ボットが答えれば、送信だけでなく受信もできています。",
+  "testMessage.unreachable": "{service} に接続できませんでした: {reason}",
+  "testMessage.failed": "{target} への送信に失敗しました: {message}",
+  "timezone.header": "タイムゾーン",
+  "timezone.ask": "タイムゾーン [{detected}]（自動検出。Enter でそのまま、都市や地域名の一部を入力すると検索します）: ",
+  "timezone.noMatch": "\"{answer}\" に一致するタイムゾーンはありません。Tokyo や New York のような都市名で試してください。",
+  "timezone.chosen": "タイムゾーン: {zone}",
+  "timezone.tooMany": "\"{answer}\" に一致するタイムゾーンが {count} 件あります。もう少し入力してください。",
+  "timezone.matching": "一致するタイムゾーン:",
+  "timezone.searchAgain": "もう一度検索",
+  "tunnel.ngrokHeader": "ngrok トンネル",
+  "tunnel.cloudflareCreate": "Cloudflare Zero Trust → Networks → Tunnels でトンネルを作成し、トークンをコピーしてください。",
+  "tunnel.cloudflareHostname": "ゲートウェイのサービスを指す公開ホスト名を追加してください（http://owliabot:8787、",
+  "tunnel.cloudflareOidc": "OIDC ログインが有効なら http://oauth2-proxy:4180）。",
+  "tunnel.ngrokToken": "{url} から authtoken をコピーしてください",
+  "tunnel.token": "トンネルのトークン: ",
+  "tunnel.hostname": "公開ホスト名（例: bot.example.com）: ",
+  "tunnel.ngrokDomain": "予約済みの ngrok ドメイン（ランダムな URL にするなら空のまま）: ",
+  "tunnel.noHostname": "ホスト名が未入力です。Cloudflare のトンネルの Public Hostnames で確認できます。",
+  "tunnel.added": "{provider} のトンネルを docker-compose.yml に追加します",
+  "tunnel.randomUrl": "ランダムな URL（{url} に表示されます）",
+  "tunnel.seeCloudflare": "Cloudflare のトンネルの Public Hostnames を参照",
+  "tunnel.saved": "トンネルのトークンを {path} に保存しました",
+  "webhook.path": "受信パス [{path}]: ",
+  "webhook.badPath": "パスは / で始め、空白やクエリ文字列を含めないでください。",
+  "webhook.replyUrl": "返信を送る URL（スキップするなら Enter）: ",
+  "webhook.badUrl": "http(s) の URL ではないようです。",
+  "webhook.header": "Webhook",
+  "webhook.intro": "ほかのシステムは Gateway HTTP のこのパスに {example} のような JSON を POST します。",
+  "webhook.auth": "認証には X-Webhook-Secret ヘッダーの共有シークレットを使います。",
+  "webhook.secret": "共有シークレット（Enter で自動生成）: ",
+  "webhook.generated": "Webhook のシークレットを生成しました: {secret}...",
+  "webhook.noReplyUrl": "返信 URL がないと、Webhook のメッセージは読みますが、返事は捨てられます。",
+  "webhook.gatewayOn": "Webhook は Gateway HTTP で受けるため、これをオンにしました（ポート {port}）。",
+  "webhook.ready": "Webhook の準備ができました: POST {url}",
+  "setup.devModeSubtitle": "（開発モード）",
+  "setup.devMode": "開発モードがオンです（OWLIABOT_DEV=1）。設定は ~/.owlia_dev/ に保存します。",
+  "setup.found": "既存の設定が見つかりました",
+  "setup.folder": "設定フォルダー: {dir}",
+  "setup.apiKeySet": "{service}: API キーは設定済みです（{key}...）",
+  "setup.invalidFormat": "⚠️ 形式が正しくありません",
+  "setup.setupTokenSet": "Anthropic: setup-token は設定済みです {status}",
+  "setup.anthropicOAuth": "Anthropic: OAuth トークンがあります",
+  "setup.azure": "Azure OpenAI: {endpoint} の {deployment}",
+  "setup.codexValid": "OpenAI Codex: ✅ OAuth トークンは有効です",
+  "setup.codexValidUntil": "OpenAI Codex: ✅ OAuth トークンは有効です（有効期限: {expires}）",
+  "setup.tokenSet": "{service}: トークンは設定済みです（{token}...）",
+  "setup.otherProfiles": "このマシンのほかのプロファイル: {profiles}（変更するには owliabot --profile <name> onboard）",
+  "setup.keep": "この設定をそのまま使いますか？",
+  "setup.keeping": "わかりました。既存の設定をそのまま使います。",
+  "setup.fresh": "わかりました。最初から設定します。",
+  "workspace.header": "ワークスペース",
+  "workspace.docker": "Docker モードではコンテナ内の既定のワークスペースパスを使います。",
+  "workspace.chosen": "ワークスペース: {path}",
+  "workspace.ask": "ワークスペースのパス [{path}]: ",
+  "nextSteps.header": "次のステップ",
+  "nextSteps.intro": "あと少しです:",
+  "nextSteps.discordToken": "Discord のトークンはあとで追加: {command}",
+  "nextSteps.telegramToken": "Telegram のトークンはあとで追加: {command}",
+  "nextSteps.envVars": "環境変数を使う場合は ANTHROPIC_API_KEY または OPENAI_API_KEY を設定してください",
+  "nextSteps.signIn": "サインインを完了: {command}",
+  "nextSteps.gateway": "ゲートウェイのエンドポイント: {url}（トークン: {token}...）",
+  "nextSteps.start": "OwliaBot を起動: {command}",
+  "systemd.header": "systemd サービスとして実行",
+  "systemd.installs": "{unit} をインストールして起動",
+  "systemd.afterEdit": "app.yaml を編集したあと",
+  "timing.took": "セットアップにかかった時間: {duration}{breakdown}",
+};
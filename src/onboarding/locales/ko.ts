@@ -0,0 +1,611 @@
+/** Korean wizard messages (see en.ts for the keys) */
+
+import type { Catalog } from "./en.js";
+
+export const ko: Catalog = {
+  "prompt.pickNumber": "번호를 선택하세요 [1-{count}]{onEnter}: ",
+  "prompt.enterFor": " (Enter: {n})",
+  "prompt.numberRange": "1부터 {count}까지의 번호를 입력하세요.",
+  "prompt.pickNumbers": "번호를 선택하세요 (예: 1,3 또는 2-4, \"all\", {onEnter}): ",
+  "prompt.enterForList": "Enter: {list}, \"none\": 건너뛰기",
+  "prompt.enterForNone": "Enter: 선택 안 함",
+  "prompt.numbersRange": "1부터 {count}까지의 번호를 사용하세요.",
+  "prompt.docsHint": "(d를 입력하고 Enter를 누르면 문서가 열립니다)",
+  "prompt.opening": "{url} 여는 중",
+  "prompt.docs": "문서: {url}",
+  "prompt.secretHint": "(입력은 가려서 표시됩니다. Ctrl+R: 잠시 보기)",
+  "banner.setUp": "OwliaBot 설정을 시작합니다{subtitle}",
+
+  "common.saved": "{path}을(를) 저장했습니다",
+  "common.savedSettings": "설정을 {path}에 저장했습니다",
+  "common.savedSecrets": "토큰과 키를 {path}에 저장했습니다",
+
+  "wizard.helpHint": "(F1, 또는 번호·예/아니요 질문에서 h: 현재 단계 도움말)",
+  "wizard.saving": "설정을 저장하는 중",
+  "wizard.allSet": "모두 준비되었습니다!",
+  "wizard.cancelled": "설정을 취소했습니다. 변경된 내용은 없습니다.",
+  "wizard.dryRun": "시험 실행: 파일을 쓰지 않았습니다.",
+  "wizard.backup": "교체될 파일을 {dir}에 복사했습니다 (되돌리기: owliabot rollback)",
+  "wizard.envWouldSet": "{file}에 설정될 변수: {vars}",
+  "wizard.nothing": "(없음)",
+  "wizard.sidecarsIgnored": "--tunnel과 --oidc는 docker-compose 사이드카를 추가하며 --docker와 함께일 때만 적용됩니다. 무시합니다.",
+  "wizard.notifyByInstaller": "봇이 /health에 응답하면 install.sh가 설정 요약을 보냅니다.",
+  "wizard.notifySaved": "설정 요약을 {path}에 저장했습니다. 봇을 시작한 뒤 다음 명령으로 보내세요:",
+  "wizard.reverseProxyOnly": "게이트웨이는 {url}에서만 접근할 수 있습니다 (포트 {port}는 공개되지 않습니다).",
+  "wizard.reverseProxyFirstStart": "{proxy}가 인증서를 받는 동안 첫 시작은 몇 초 더 걸립니다.",
+  "wizard.reverseProxyKept": "게이트웨이를 127.0.0.1에 그대로 둡니다.",
+  "wizard.reverseProxyDeclined": "--reverse-proxy에는 basic 인증 또는 인증 없이 공개하겠다는 동의가 필요합니다.",
+  "wizard.profileStart": "이 프로필 시작: {command}",
+  "wizard.profileCommands": "다른 명령에도 프로필을 지정합니다. 예: {command}",
+  "wizard.composeProfiles": "선택 서비스는 compose 프로필에 있습니다. 시작: {command}",
+  "wizard.composeProfilesHint": "켜려면 --profile ollama 또는 --profile watchtower를 추가하세요.",
+  "wizard.runAgain": "언제든지 다음 명령으로 다시 실행할 수 있습니다: {command}",
+
+  "provider.header": "AI 제공자 설정",
+  "provider.choose": "AI 제공자를 선택하세요:",
+  "provider.option.anthropic": "Anthropic (Claude) - API 키 또는 setup-token",
+  "provider.option.openai": "OpenAI (API 키)",
+  "provider.option.codex": "OpenAI Codex (ChatGPT Plus/Pro OAuth)",
+  "provider.option.compatible": "OpenAI 호환 (Ollama / vLLM / LM Studio 등)",
+  "provider.option.multiple": "여러 제공자 (대체 체인)",
+  "provider.option.bedrock": "AWS Bedrock (리전, 모델 ID, AWS 자격 증명)",
+  "provider.option.azure": "Azure OpenAI (엔드포인트, 배포, API 버전)",
+  "provider.none": "설정된 제공자가 없습니다. 나중에 설정 파일에 추가하세요.",
+  "provider.chain": "제공자 대체 순서: {chain}",
+  "provider.orderIntro": "폴백 순서: OwliaBot은 첫 번째 제공자를 쓰고, 호출이 실패하면 다음 것을 시도합니다.",
+  "provider.orderAsk": "하나를 옮기거나(\"2 up\", \"1 down\") 새 순서(\"2,1,3\")를 입력하고, 그대로 두려면 Enter를 누르세요: ",
+  "provider.orderInvalid": "\"<번호> up\", \"<번호> down\" 또는 1-{count}의 모든 번호를 새 순서로 입력하세요.",
+  "provider.orderDone": "폴백 순서: {chain}",
+  "provider.reusing": "기존 {name} 설정을 사용합니다",
+  "provider.model": "모델:",
+  "provider.otherModel": "다른 모델 (이름 입력)",
+  "provider.modelDefault": "모델 [{model}]: ",
+  "provider.anthropic.header": "Anthropic 인증",
+  "provider.anthropic.methods": "두 가지 인증 방법을 지원합니다:",
+  "provider.anthropic.setupToken": "  • Setup-token (Claude Pro/Max 구독)",
+  "provider.anthropic.setupTokenHow": "    `claude setup-token`을 실행해 발급합니다",
+  "provider.anthropic.apiKey": "  • API 키 (종량제)",
+  "provider.anthropic.apiKeyWhere": "    console.anthropic.com에서 발급합니다",
+  "provider.anthropic.format": "    형식: {format}",
+  "provider.anthropic.loginNow": "지금 브라우저에서 Claude 계정으로 로그인할까요 (`claude setup-token` 실행)?",
+  "provider.anthropic.loginStarting": "Claude 로그인을 시작하는 중...",
+  "provider.anthropic.loginCopy": "출력된 sk-ant-oat01-... 토큰을 복사해 아래에 붙여 넣으세요.",
+  "provider.anthropic.loginFailed": "Claude 로그인이 끝나지 않았습니다. setup-token이나 API 키를 붙여 넣을 수도 있습니다.",
+  "provider.anthropic.paste": "setup-token 또는 API 키를 붙여 넣으세요 (비워 두면 환경 변수 사용): ",
+  "provider.anthropic.tokenWarning": "Setup-token 검증 경고: {error}",
+  "provider.anthropic.tokenSaved": "Setup-token을 저장했습니다 (Claude Pro/Max)",
+  "provider.anthropic.keySaved": "API 키를 저장했습니다",
+  "provider.openai.keys": "OpenAI API 키: {url}",
+  "provider.openai.ask": "OpenAI API 키 (비워 두면 환경 변수 사용): ",
+  "provider.openai.saved": "OpenAI API 키를 저장했습니다",
+  "provider.codex.intro": "OpenAI Codex는 ChatGPT Plus/Pro 구독을 OAuth로 사용합니다.",
+  "provider.codex.startNow": "지금 OAuth를 시작할까요?",
+  "provider.codex.starting": "OpenAI Codex OAuth를 시작하는 중...",
+  "provider.codex.done": "OAuth가 완료되었습니다",
+  "provider.codex.laterDocker": "컨테이너가 시작된 뒤 실행하세요: {command}",
+  "provider.codex.later": "나중에 `{command}`를 실행해 인증하세요.",
+  "provider.compatible.intro": "OpenAI 호환은 OpenAI v1 API를 제공하는 모든 서버를 지원합니다:",
+  "provider.compatible.baseUrl": "API 기본 URL (위 항목은 1-{count}): ",
+  "provider.compatible.keyAt": "{name} API 키는 {url}에서 발급합니다",
+  "provider.compatible.key": "API 키: ",
+  "provider.compatible.keyOptional": "API 키 (선택, 필요 없으면 비워 두세요): ",
+  "provider.compatible.configured": "OpenAI 호환을 설정했습니다: {url}",
+
+  "channel.header": "채팅",
+  "channel.choose": "OwliaBot과 어디에서 채팅할까요?",
+  "channel.option.both": "둘 다 (Discord + Telegram)",
+  "channel.option.webhook": "Webhook (HTTP, 다른 시스템용)",
+  "channel.existing": "기존 채팅 설정을 사용합니다:",
+  "channel.noToken": "아직 채팅 토큰이 없습니다. 나중에 추가할 수 있습니다.",
+  "channel.noAllowList": "allowList가 설정되지 않았습니다. 설정하지 않으면 봇이 아무에게도 응답하지 않습니다.",
+  "channel.discord.portal": "봇 토큰은 Discord 개발자 포털에 있습니다: {url}",
+  "channel.guide": "가이드: {url}",
+  "channel.discord.intent": "MESSAGE CONTENT INTENT를 켜 주세요. 꺼져 있으면 메시지를 받을 수 없습니다.",
+  "channel.discord.paste": "Discord 봇 토큰을 붙여 넣으세요 (Enter: 나중에 설정): ",
+  "channel.discord.saved": "Discord 토큰을 사용합니다.",
+  "channel.discord.checklist": "필요한 권한: View Channels, Send Messages, Send Messages in Threads, Read Message History",
+  "channel.telegram.found": "기존 Telegram 설정을 찾았습니다 (허용 사용자: {users}, 그룹: {groups}).",
+  "channel.telegram.reuse": "기존 Telegram 설정을 사용할까요?",
+  "channel.telegram.reused": "기존 Telegram 설정을 사용합니다.",
+  "channel.telegram.botfather": "BotFather에서 봇을 만드세요: {url}",
+  "channel.telegram.paste": "Telegram 봇 토큰을 붙여 넣으세요 (Enter: 나중에 설정): ",
+  "channel.telegram.saved": "Telegram 토큰을 사용합니다.",
+  "channel.telegram.who": "누구와 대화할까요? (Telegram 사용자 ID, 쉼표로 구분, Enter: 건너뛰기): ",
+  "channel.telegram.allowed": "다음 Telegram 사용자 ID에만 응답합니다: {ids}",
+  "channel.telegram.ids": "Telegram 사용자 ID를 입력하세요 (쉼표로 구분, Enter: 건너뛰기): ",
+  "channel.telegram.allowList": "Telegram allowList: {ids}",
+  "access.hint.discord": "Discord ID는 17~20자리 숫자입니다 (개발자 모드 > 우클릭 > ID 복사)",
+  "access.hint.telegram": "Telegram 사용자 ID는 숫자입니다 (@userinfobot에서 확인). @사용자명이 아닙니다",
+  "access.hint.slackMember": "Slack 멤버 ID는 U로 시작합니다 (프로필 > ⋮ > 멤버 ID 복사)",
+  "access.hint.slackChannel": "Slack 채널 ID는 C로 시작합니다 (채널 세부정보 맨 아래)",
+  "access.invalid": "올바르지 않은 값: {ids}. {hint}.",
+  "access.header": "접근",
+  "access.intro": "어디에서 누구와 대화할지 제한합니다. 질문을 건너뛰려면 Enter를 누르세요.",
+  "access.discord.channels": "응답해도 되는 Discord 채널 ID (쉼표로 구분): ",
+  "access.discord.members": "대화할 수 있는 Discord 사용자 ID (쉼표로 구분): ",
+  "access.discord.channelsSet": "Discord 채널: {ids}",
+  "access.discord.membersSet": "Discord 사용자: {ids}",
+  "access.slack.header": "접근 (Slack)",
+  "access.slack.intro": "질문을 건너뛰려면 Enter를 누르세요.",
+  "access.slack.channels": "멘션 없이 응답할 Slack 채널 ID (쉼표로 구분): ",
+  "access.slack.members": "대화할 수 있는 Slack 멤버 ID (쉼표로 구분): ",
+  "access.slack.channelsSet": "Slack 채널: {ids}",
+  "access.slack.membersSet": "Slack 멤버: {ids}",
+  "access.slack.noMembers": "멤버 ID가 없으면 Slack에서 아무에게도 응답하지 않습니다. 나중에 app.yaml에 slack.memberAllowList를 추가하세요.",
+  "provider.azure.endpoint": "Azure OpenAI 엔드포인트 (https://<resource>.openai.azure.com, 비워 두면 건너뜀): ",
+  "provider.azure.httpsOnly": "엔드포인트는 https:// URL이어야 합니다.",
+  "provider.azure.notAzure": "Azure OpenAI 호스트가 아닙니다. 그대로 사용합니다 (예: 프록시).",
+  "provider.azure.whereKeys": "엔드포인트와 키는 Azure 포털의 리소스 관리 > 키 및 엔드포인트에서,",
+  "provider.azure.whereDeployment": "배포 이름은 Azure AI Foundry의 배포에서 확인하세요.",
+  "provider.azure.deployment": "배포 이름 [{deployment}]: ",
+  "provider.azure.apiVersion": "API 버전 [{version}]: ",
+  "provider.azure.apiVersionFormat": "API 버전은 2024-10-21 또는 2025-01-01-preview 형식입니다.",
+  "provider.azure.key": "Azure OpenAI API 키 (환경 변수를 쓰려면 비워 두세요): ",
+  "provider.azure.keySaved": "Azure OpenAI API 키를 저장했습니다",
+  "provider.azure.configured": "Azure OpenAI를 설정했습니다: {endpoint}의 {deployment}",
+  "provider.bedrock.region": "AWS 리전 [{region}]: ",
+  "provider.bedrock.badRegion": "AWS 리전이 아닌 것 같습니다 (예: us-east-1, eu-central-1).",
+  "provider.bedrock.intro": "AWS Bedrock은 AWS 계정을 사용합니다. 먼저 Bedrock 콘솔에서 모델 액세스를 활성화하세요.",
+  "provider.bedrock.model": "모델 ID [{model}]: ",
+  "provider.bedrock.credentials": "AWS 자격 증명:",
+  "provider.bedrock.option.env": "환경 변수 (AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY 또는 AWS_PROFILE)",
+  "provider.bedrock.option.instance": "인스턴스 프로필 / 태스크 역할 (EC2, ECS, EKS)",
+  "provider.bedrock.option.keys": "액세스 키 (secrets.yaml에 저장)",
+  "provider.bedrock.accessKeyId": "AWS 액세스 키 ID: ",
+  "provider.bedrock.secretAccessKey": "AWS 시크릿 액세스 키: ",
+  "provider.bedrock.keysSaved": "AWS 액세스 키를 저장했습니다",
+  "provider.bedrock.noKeys": "키를 입력하지 않았습니다. 대신 환경 변수 AWS_ACCESS_KEY_ID와 AWS_SECRET_ACCESS_KEY를 설정하세요.",
+  "provider.bedrock.instanceRole": "역할은 AWS SDK가 자동으로 가져옵니다. EC2의 Docker에서는 인스턴스 메타데이터 홉 제한이 2 이상이어야 합니다.",
+  "provider.bedrock.configured": "AWS Bedrock을 설정했습니다: {region}의 {model}",
+  "bindPath.relative": "설정 폴더 \"{dir}\"는 상대 경로입니다. Docker에서 바인드 마운트하려면 절대 경로가 필요합니다.",
+  "bindPath.useAbsolute": "대신 절대 경로를 사용하세요: {path}",
+  "bindPath.notShared": "{dir}는 Docker Desktop이 기본으로 공유하는 폴더({roots}) 밖에 있습니다.",
+  "bindPath.addSharing": "Docker Desktop → Settings → Resources → File sharing에서 추가하거나 설정을 /Users 아래에 두세요.",
+  "bindPath.networkFs": "{dir}는 네트워크 파일 시스템({fsType})에 있습니다. 컨테이너 안에서는 소유권과 파일 잠금이 자주 깨집니다.",
+  "bindPath.localDisk": "설정 폴더는 로컬 디스크에 두세요 (HOME을 로컬 경로로 지정하거나 ~/.owliabot을 로컬 저장소로 심볼릭 링크).",
+  "bindPath.wslDrive": "{dir}는 WSL에 마운트된 Windows 드라이브({fsType})에 있습니다. 느리고 Linux 권한이 적용되지 않습니다.",
+  "bindPath.wslHome": "설정 폴더는 WSL 파일 시스템 안에 두세요 (예: /home/<you>/.owliabot).",
+  "bindPath.warning": "Docker가 설정 폴더를 마운트하지 못할 수 있습니다:",
+  "bindPath.continue": "이 폴더로 계속할까요?",
+  "caBundle.saved": "CA 번들을 {path}에 저장했습니다",
+  "caBundle.start": "CA 번들을 지정해 OwliaBot을 시작하세요: {command}",
+  "clipboard.copyItem": "{label} 복사",
+  "clipboard.done": "완료",
+  "clipboard.ask": "클립보드에 복사할까요?",
+  "clipboard.unavailable": "여기서는 클립보드를 쓸 수 없습니다. 위에 표시된 {label}을(를) 복사하세요.",
+  "clipboard.osc52": "{label}을(를) 터미널 클립보드로 보냈습니다 (OSC 52 지원 필요. 예: iTerm2, kitty, WezTerm, Windows Terminal).",
+  "clipboard.copied": "{label}을(를) 클립보드에 복사했습니다.",
+  "clipboard.startCommand": "시작 명령",
+  "clipboard.gatewayUrl": "게이트웨이 URL",
+  "clipboard.gatewayToken": "게이트웨이 토큰",
+  "mcp.header": "MCP 서버",
+  "mcp.intro": "MCP(Model Context Protocol)를 사용하면 봇이 외부 도구 서버를 쓸 수 있습니다.",
+  "mcp.existing": "app.yaml의 사용자 지정 서버: {names}",
+  "mcp.keepExisting": "app.yaml의 사용자 지정 MCP 서버 {count}개를 유지할까요?",
+  "mcp.pickPresets": "어떤 프리셋을 활성화할까요?",
+  "mcp.customDetail": "직접 서버를 정의합니다: 이름, 명령, 인수, 환경 변수, 전송 방식",
+  "mcp.added": "MCP 서버 \"{name}\"을(를) 추가했습니다",
+  "mcp.addAnother": "사용자 지정 서버를 더 추가할까요?",
+  "mcp.githubTokenAt": "{url}에서 토큰을 만드세요 (저장소 접근 권한은 필요한 만큼).",
+  "mcp.githubToken": "GitHub 개인 액세스 토큰 (나중에 GITHUB_PERSONAL_ACCESS_TOKEN을 설정하려면 Enter): ",
+  "mcp.presets": "MCP 프리셋: {names}",
+  "mcp.servers": "사용자 지정 MCP 서버: {names}",
+  "discord.header": "Discord 설정",
+  "discord.permissions": "봇에 다음 권한이 있는지 확인하세요: View Channels, Send Messages, Send Messages in Threads, Read Message History",
+  "discord.see": "참고: {url}",
+  "telegram.header": "Telegram 설정",
+  "telegram.discover": "봇에 메시지를 보내 사용자 ID를 찾을까요?",
+  "telegram.otherIds": "대화를 허용할 다른 사용자 ID (쉼표로 구분, 없으면 Enter): ",
+  "telegram.ids": "사용자 허용 목록 - 대화를 허용할 사용자 ID (쉼표로 구분): ",
+  "telegram.allowList": "Telegram 사용자 허용 목록: {ids}",
+  "demo.echoes": "데모 모드: 봇이 모델에 묻지 않고 메시지를 그대로 돌려줍니다.",
+  "demo.noKey": "API 키가 필요 없습니다. 추가하려면 --demo 없이 온보딩을 다시 실행하세요.",
+  "demo.starting": "데모를 시작하는 중",
+  "demo.startFailed": "시작하지 못했습니다: {error}",
+  "demo.startYourself": "다음 명령으로 직접 시작하세요: {command}",
+  "demo.running": "데모 봇이 실행 중입니다",
+  "demo.isDemo": "데모입니다: 답장은 모델이 아니라 데모 공급자가 되돌려 주는 것입니다.",
+  "demo.startWith": "시작 명령: {command}",
+  "demo.tryIt": "채팅 채널에서 메시지를 보내거나 (/status 같은 명령도 됩니다) 게이트웨이의 /health를 확인해 보세요.",
+  "demo.addKeyLater": "API 키가 생기면 --demo 없이 온보딩을 다시 실행해 추가하세요.",
+  "existing.header": "기존 설정을 찾았습니다",
+  "existing.at": "기존 설정 위치: {dir}",
+  "existing.anthropicKey": "Anthropic API 키를 찾았습니다: {key}...",
+  "existing.anthropicToken": "Anthropic setup-token을 찾았습니다",
+  "existing.anthropicOAuth": "Anthropic OAuth 토큰을 찾았습니다",
+  "existing.openaiKey": "OpenAI API 키를 찾았습니다: {key}...",
+  "existing.codexOAuth": "OpenAI OAuth 토큰(openai-codex)을 찾았습니다",
+  "existing.discordToken": "Discord 토큰을 찾았습니다: {token}...",
+  "existing.telegramToken": "Telegram 토큰을 찾았습니다: {token}...",
+  "existing.gatewayToken": "게이트웨이 토큰을 찾았습니다: {token}...",
+  "existing.reuse": "기존 설정을 다시 사용할까요?",
+  "existing.reusing": "기존 설정을 다시 사용합니다",
+  "existing.new": "새 자격 증명을 설정합니다",
+  "validation.paused": "네트워크 오류가 계속되어 확인을 일시 중지했습니다",
+  "validation.requestFailed": "요청이 실패했습니다",
+  "validation.rateLimited": "공급자의 요청 한도에 걸렸습니다",
+  "validation.unavailable": "공급자를 사용할 수 없습니다 (HTTP {status})",
+  "validation.timedOut": "{seconds}초 후 시간이 초과되었습니다",
+  "validation.networkError": "네트워크 오류 ({error})",
+  "validation.skipped": "{label} 확인을 건너뛰었습니다: {reason}. 확인 없이 계속합니다.",
+  "devcontainer.saved": "{file}을(를) {path}에 저장했습니다",
+  "devcontainer.header": "devcontainer에서 실행",
+  "devcontainer.vscode": "VS Code: {dir}을(를) 열고 \"Reopen in Container\"를 선택하세요",
+  "devcontainer.cli": "CLI:     {command}",
+  "devcontainer.gateway": "게이트웨이: {url}",
+  "discordPicker.check": "Discord 채널 목록",
+  "discordPicker.noChannels": "봇이 아직 텍스트 채널이 있는 서버에 없습니다. 먼저 초대하거나 채널 ID를 입력하세요.",
+  "discordPicker.pick": "응답해도 되는 채널 (없음 = 멘션된 모든 채널):",
+  "discordCheck.rejected": "Discord가 토큰을 거부했습니다 (401 Unauthorized)",
+  "discordCheck.unexpected": "Discord에서 예상치 못한 HTTP {status} 응답",
+  "discordCheck.check": "Discord 토큰",
+  "discordCheck.bot": "Discord 봇: {name}",
+  "discordCheck.intentOff": "Message Content Intent가 꺼져 있습니다. Bot > Privileged Gateway Intents에서 켜지 않으면 메시지를 볼 수 없습니다.",
+  "discordCheck.intentUnknown": "인텐트 설정을 읽지 못했습니다. Message Content Intent가 켜져 있는지 확인하세요.",
+  "discordCheck.invalid": "{message}. 오타가 없는지 확인하거나 개발자 포털에서 토큰을 재설정하세요.",
+  "discordCheck.again": "Discord 봇 토큰을 다시 붙여 넣으세요 (Enter: 나중에 설정): ",
+  "docker.defaultPort": "기본 게이트웨이 포트를 사용합니다: {port}",
+  "docker.watchtower": "Watchtower는 하루에 한 번 새 OwliaBot 이미지를 확인하고 그 이미지로 봇을 다시 시작합니다.",
+  "docker.watchtowerSocket": "봇 컨테이너만 건드리지만 Docker 소켓에 접근해야 합니다.",
+  "docker.autoUpdate": "봇을 자동으로 업데이트할까요 (watchtower 서비스 추가)?",
+  "docker.saved": "{file}을(를) {path}에 저장했습니다",
+  "docker.lastGood": "마지막으로 정상 동작한 이미지: {image}",
+  "docker.rollback": "{image}의 상태 확인이 실패하면 다음 명령으로 되돌리세요: {command}",
+  "docker.summary.config": "설정",
+  "docker.summary.secrets": "시크릿",
+  "docker.summary.auth": "인증",
+  "docker.summary.workspace": "워크스페이스",
+  "docker.summary.compose": "Compose",
+  "docker.summary.gateway": "게이트웨이",
+  "docker.summary.url": "URL",
+  "docker.summary.token": "토큰",
+  "docker.summary.public": "공개 URL",
+  "docker.summary.title": "설정 완료",
+  "docker.summary.plainTitle": "설정이 완료되었습니다.",
+  "dryRun.header": "드라이 런: {path}",
+  "dryRun.newFile": "새 파일 (아직 없음):",
+  "dryRun.noChanges": "변경 사항이 없습니다.",
+  "dryRun.changes": "기존 파일과 비교한 변경 사항:",
+  "dryRun.changesTo": "{path}의 변경 사항",
+  "dryRun.overwriteOne": "위 변경 사항으로 이 파일을 덮어쓸까요?",
+  "dryRun.overwriteMany": "위 변경 사항으로 이 파일 {count}개를 덮어쓸까요?",
+  "envFile.header": ".env의 시크릿",
+  "envFile.none": "(없음)",
+  "envFile.orchestrator": "오케스트레이터에서 주입하려면 이 파일과 env_file: 항목을 지우세요.",
+  "envFile.stale": "{path}는 이전 설정에서 남은 파일이며 .env보다 우선합니다. 삭제하세요.",
+  "environments.positiveInt": "양의 정수를 입력하세요.",
+  "environments.header": "환경: {name}",
+  "environments.lastGood": "{name}에서 마지막으로 정상 동작한 이미지: {image}",
+  "environments.imageTag": "이미지 태그 [{tag}]: ",
+  "environments.port": "게이트웨이 호스트 포트",
+  "environments.logLevel": "로그 수준 (info/debug) [{level}]: ",
+  "environments.maxIterations": "메시지당 최대 에이전트 반복 횟수",
+  "environments.timeout": "에이전트 시간 제한 (초)",
+  "environments.summary": "{name}: 이미지 태그 {tag}, 포트 {port}, 로그 수준 {level}",
+  "environments.saving": "환경을 저장하는 중: {name}",
+  "environments.listHeader": "환경",
+  "environments.where": "게이트웨이: {url}  설정: {dir}",
+  "gatewayAuth.noHttp": "게이트웨이 HTTP가 꺼져 있어 보호할 대상이 없습니다. 게이트웨이 인증을 건너뜁니다.",
+  "gatewayAuth.basicEnabled": "게이트웨이 기본 인증을 켰습니다 (사용자: {user}, 비밀번호는 secrets.yaml에 저장)",
+  "gatewayAuth.tlsGenerated": "로컬 CA와 클라이언트 인증서를 {dir}에 생성했습니다",
+  "gatewayAuth.header": "게이트웨이 보호",
+  "gatewayAuth.basicRoutes": "이제 /health를 제외한 모든 게이트웨이 경로에 기본 인증이 필요합니다:",
+  "gatewayAuth.basicPassword": "비밀번호는 secrets.yaml의 gateway.basicAuthPassword입니다.",
+  "gatewayAuth.mtls": "이제 게이트웨이가 HTTPS로 응답하며 클라이언트 인증서가 필요합니다:",
+  "gatewayAuth.caKey": "ca.key는 비공개로 보관하세요. 클라이언트 인증서를 더 서명할 때 씁니다.",
+  "gateway.optionalHeader": "게이트웨이 HTTP (선택)",
+  "gateway.intro": "게이트웨이 HTTP는 상태 확인과 연동을 위한 REST API를 제공합니다.",
+  "gateway.enable": "게이트웨이 HTTP를 켤까요?",
+  "gateway.port": "포트 [{port}]: ",
+  "gateway.tokenGenerated": "게이트웨이 토큰을 생성했습니다: {token}...",
+  "gateway.enabled": "포트 {port}에서 게이트웨이 HTTP를 켰습니다",
+  "gateway.header": "게이트웨이 HTTP",
+  "gateway.dockerIntro": "게이트웨이 HTTP는 상태 확인과 REST API 접근에 쓰입니다.",
+  "gateway.hostPort": "게이트웨이를 노출할 호스트 포트 [8787]: ",
+  "gateway.randomToken": "임의의 게이트웨이 토큰을 생성했습니다.",
+  "gateway.reusingToken": "기존 게이트웨이 토큰을 다시 사용합니다",
+  "gateway.token": "게이트웨이 토큰 [{token}...]: ",
+  "gateway.tokenSet": "게이트웨이 토큰을 설정했습니다",
+  "gateway.otherHeader": "기타 설정",
+  "gateway.timezone": "시간대 [UTC]: ",
+  "gateway.timezoneSet": "시간대: {tz}",
+  "githubActions.header": "GitHub Actions 배포",
+  "githubActions.commit": "app.yaml과 docker-compose.yml을 저장소 루트에 커밋하세요. secrets.yaml은 호스트에만 두세요.",
+  "githubActions.copies": "배포할 때 app.yaml은 호스트의 {dir}(docker-compose.yml이 마운트하는 디렉터리)로 복사됩니다.",
+  "githubActions.branch": "배포할 브랜치 [main]: ",
+  "githubActions.composeDir": "호스트의 docker-compose.yml 디렉터리 [~/owliabot]: ",
+  "githubActions.nextHeader": "GitHub Actions로 GitOps",
+  "githubActions.step1": "1. {path}을(를) 저장소 루트의 docker-compose.yml 옆에 복사하세요",
+  "githubActions.step2": "2. 두 파일과 {path}을(를) 커밋하세요 (secrets.yaml 제외)",
+  "githubActions.step3": "3. 저장소 시크릿을 추가하세요: {names}",
+  "githubActions.knownHosts": "   (known hosts는 {command}로 얻을 수 있습니다)",
+  "devWorkspace.bootstrap": "첫 실행 설정용 BOOTSTRAP.md를 만들었습니다",
+  "devWorkspace.skills": "기본 제공 스킬을 복사했습니다: {dir}",
+  "keychain.header": "OS 키체인",
+  "keychain.nothing": "키체인에 저장할 항목이 없습니다 (입력된 키나 토큰이 없음).",
+  "keychain.stored": "{names}을(를) {backend}에 저장했습니다",
+  "keychain.manage": "관리 명령: {command}",
+  "kiosk.offer": "아이나 신뢰할 수 없는 사용자를 위해 봇을 잠글까요 (키오스크 프리셋)?",
+  "kiosk.userId": "{channel} 사용자 ID",
+  "kiosk.channelId": "{channel} 채널 ID",
+  "kiosk.whichOne": "봇이 응답할 {what}는 무엇인가요?",
+  "kiosk.theOne": "봇이 응답할 단 하나의 {what}: ",
+  "kiosk.notAnId": "\"{id}\"는 ID가 아닙니다.",
+  "kiosk.header": "키오스크 프리셋",
+  "kiosk.intro": "봇은 한 곳에서 한 사용자에게만 응답하고 다른 누구에게도 응답하지 않습니다.",
+  "kiosk.whichChannel": "어떤 채팅 채널을 남길까요?",
+  "kiosk.inChannel": "{channel} 채널 {id}에서",
+  "kiosk.inDirect": "{channel} 다이렉트 메시지에서",
+  "kiosk.answersOnly": "사용자 {user}에게만 응답합니다 ({where})",
+  "kiosk.tools": "도구: {tools}",
+  "kiosk.commands": "셸 명령: {commands}",
+  "kiosk.noAccess": "웹 접근, 파일 쓰기, MCP 서버, 지갑, 예약 작업 없음",
+  "kiosk.history": "기록은 {hours}시간 동안만 보관, 메모리 검색과 세션 요약 없음",
+  "kubernetes.saved": "Kubernetes 매니페스트를 {path}에 저장했습니다",
+  "kubernetes.header": "Kubernetes에 배포",
+  "kubernetes.fillIn": "먼저 Secret의 stringData에 {keys}를 채우세요.",
+  "kubernetes.portForward": "# 로컬에서 게이트웨이에 접속",
+  "localRun.saved": "Docker 없이 실행하기 위한 {script}와 {config}를 저장했습니다",
+  "localRun.header": "Docker 없이 실행",
+  "localRun.where": "컨테이너를 멈춘 상태에서 docker-compose.yml이 있는 디렉터리에서:",
+  "localRun.npmInstall": "(먼저 그곳에서 npm install 실행)",
+  "localRun.shared": "컨테이너와 같은 secrets.yaml과 워크스페이스를 사용합니다.",
+  "mcp.custom.name": "서버 이름 (예: notion): ",
+  "mcp.custom.badName": "영문자, 숫자, - 또는 _를 사용하세요 (도구는 <name>__<tool>로 표시됩니다).",
+  "mcp.custom.taken": "\"{name}\"라는 서버가 이미 있습니다.",
+  "mcp.custom.transport": "전송 방식:",
+  "mcp.custom.stdio": "stdio (게이트웨이가 로컬 명령을 실행)",
+  "mcp.custom.sse": "sse (실행 중인 서버에 URL로 연결)",
+  "mcp.custom.url": "서버 URL: ",
+  "mcp.custom.badUrl": "http(s) URL을 입력하세요.",
+  "mcp.custom.command": "명령 (예: npx): ",
+  "mcp.custom.badCommand": "실행 파일만 입력하세요. 인수는 다음에 묻습니다.",
+  "mcp.custom.args": "인수 (공백으로 구분, 따옴표 사용 가능, 없으면 Enter): ",
+  "mcp.custom.openQuote": "따옴표가 닫히지 않았습니다.",
+  "mcp.custom.envIntro": "환경 변수는 KEY=VALUE 형식입니다. 시크릿은 ${VAR}로 쓰고 VAR를 환경에서 설정하세요.",
+  "mcp.custom.env": "환경 변수 (예: API_KEY=${NOTION_TOKEN}, 없으면 Enter): ",
+  "mcp.custom.badEnv": "공백으로 구분한 KEY=VALUE 형식을 사용하세요.",
+  "mcpRuntime.install": "{tool}을(를) 설치하세요: {url}",
+  "mcpRuntime.ask": "MCP 서버 명령이 설치되어 있는지 확인할까요?",
+  "mcpRuntime.header": "MCP 런타임 확인",
+  "mcpRuntime.inImage": "서버는 owliabot 이미지 안에서 실행되므로 이미지에 포함된 것과 비교합니다.",
+  "mcpRuntime.included": "{command}: 이미지에 포함됨 ({servers})",
+  "mcpRuntime.notInImage": "{command}: owliabot 이미지에 없습니다. 이미지에 추가하지 않으면 {servers}는 시작되지 않습니다.",
+  "mcpRuntime.found": "찾음",
+  "mcpRuntime.missing": "{command}: 찾을 수 없습니다. {servers}는 시작되지 않습니다.",
+  "models.check": "모델 목록",
+  "models.notListed": "{model}은(는) 계정의 모델 목록에 없습니다. 대신 다음 중에서 고르세요.",
+  "models.available": "이 키로 쓸 수 있는 모델:",
+  "modelPresets.check": "모델 프리셋 카탈로그",
+  "modelPresets.httpError": "모델 프리셋 카탈로그를 불러오지 못했습니다 (HTTP {status}). 기본 제공 프리셋을 사용합니다.",
+  "modelPresets.remote": "원격 모델 프리셋 카탈로그",
+  "modelPresets.file": "모델 프리셋 카탈로그 {path}",
+  "modelPresets.notMapping": "{source}을(를) 무시합니다: 매핑이 아닙니다.",
+  "modelPresets.ignoring": "{source}을(를) 무시합니다: {error}",
+  "nix.header": "Nix로 실행",
+  "nix.run": "# 이 설정으로 OwliaBot 시작",
+  "nix.develop": "# owliabot 명령을 쓸 수 있는 셸",
+  "notify.failed": "{origin}에 알리지 못했습니다: {error}.",
+  "notify.rejected": "{origin}이(가) 설정 알림을 거부했습니다 (HTTP {status}).",
+  "notify.sent": "설정 요약을 {origin}에 보냈습니다",
+  "oidc.header": "OIDC 로그인 (oauth2-proxy)",
+  "oidc.register": "ID 공급자에 OAuth/OIDC 애플리케이션을 등록하고",
+  "oidc.copy": "(Google Workspace, Okta, Azure AD, Keycloak 등) 클라이언트 ID와 시크릿을 복사하세요.",
+  "oidc.issuer": "발급자(Issuer) URL (예: https://accounts.google.com): ",
+  "oidc.clientId": "클라이언트 ID: ",
+  "oidc.clientSecret": "클라이언트 시크릿: ",
+  "oidc.domains": "허용할 이메일 도메인 (쉼표로 구분) [*]: ",
+  "oidc.publicUrl": "게이트웨이의 공개 URL [{url}]: ",
+  "oidc.redirect": "ID 공급자의 리디렉션 URI를 다음으로 설정하세요: {url}",
+  "oidc.added": "oauth2-proxy를 docker-compose.yml에 추가합니다",
+  "oidc.saved": "oauth2-proxy 설정을 {path}에 저장했습니다",
+  "ollama.check": "Ollama 모델 목록",
+  "ollama.installed": "이 Ollama 서버에 설치된 모델:",
+  "credential.where.anthropic": "console.anthropic.com에서 키를 만들거나 `claude setup-token`을 실행하세요.",
+  "credential.where.openai": "https://platform.openai.com/api-keys에서 키를 만드세요.",
+  "credential.where.openaiCompatible": "서버를 시작할 때 지정한 키를 쓰세요. 필요 없으면 비워 두세요.",
+  "credential.where.azureOpenai": "Azure 포털에서 Azure OpenAI 리소스의 키 및 엔드포인트에 있는 KEY 1을 복사하세요.",
+  "credential.where.bedrock": "AWS 콘솔에서 Bedrock 접근 권한이 있는 IAM 사용자의 액세스 키를 만드세요.",
+  "credential.where.discord": "Discord 개발자 포털의 Bot > Reset Token에서 복사하세요.",
+  "credential.where.telegram": "BotFather(/mybots > API Token)에서 복사하세요.",
+  "credential.where.slack": "https://api.slack.com/apps의 앱(OAuth & Permissions 또는 Basic Information > App-Level Tokens)에서 복사하세요.",
+  "credential.where.github": "https://github.com/settings/personal-access-tokens에서 만드세요.",
+  "credential.docExample": "문서에 있는 예시 토큰입니다",
+  "credential.placeholderWord": "\"{value}\"는 자리 표시자입니다",
+  "credential.template": "값 자체가 아니라 템플릿 자리 표시자입니다",
+  "credential.shortened": "줄여 쓴 것 같습니다 (...가 포함됨). 전체 값을 붙여 넣으세요",
+  "credential.exampleText": "예시에 있는 자리 표시자 문자열 같습니다",
+  "credential.prefixOnly": "키 접두사만 붙여 넣었습니다",
+  "credential.masked": "가려진 값 같습니다 (접두사 뒤가 x나 *뿐)",
+  "credential.wontWork": "이 값은 쓸 수 없습니다: {problem}. {where}",
+  "policy.updateFailed": "policy.yml의 allowedUsers를 업데이트하지 못했습니다: {error}",
+  "port.inUse": "127.0.0.1의 포트 {port}는 이미 사용 중입니다.",
+  "port.publishedBy": "컨테이너 {containers}가 공개하고 있습니다.",
+  "port.stopOld": "이전 OwliaBot이라면 먼저 중지하세요: {command}",
+  "port.noneFree": "{from}부터 {to} 사이에 빈 포트가 없습니다. {port}를 유지합니다.",
+  "port.useNext": "대신 포트 {port}를 게이트웨이에 쓸까요?",
+  "port.chosen": "게이트웨이 포트: {port}",
+  "port.keeping": "포트 {port}를 유지합니다. 봇을 시작하기 전에 비워 두세요.",
+  "profile.notSetUp": "{name} ({dir}, 아직 설정 안 됨)",
+  "profile.header": "프로필",
+  "profile.intro": "프로필마다 설정, 컨테이너, 포트가 따로 있는 별도의 봇입니다.",
+  "profile.new": "새 프로필...",
+  "profile.which": "어느 것을 설정할까요?",
+  "profile.name": "새 프로필 이름 (예: work): ",
+  "profile.exists": "프로필 {name}이(가) 이미 있습니다. 다른 이름을 고르세요.",
+  "smokeTest.offer": "지금 제공자 연결을 테스트할까요?",
+  "smokeTest.header": "연결 테스트",
+  "smokeTest.testing": "{label} 테스트 중...",
+  "smokeTest.label": "{label} 연결",
+  "smokeTest.rejected": "{label}: 키가 거부되었습니다 (HTTP {status}). 봇을 시작하기 전에 확인하세요.",
+  "smokeTest.unexpected": "{label}: 예상치 못한 HTTP {status}입니다.",
+  "smokeTest.ok": "{label}: OK ({latency} ms), 모델 {model}을(를) 쓸 수 있습니다",
+  "smokeTest.noModel": "{label}: {latency} ms 만에 연결했지만 모델 {model}을(를) 찾지 못했습니다",
+  "failover.untested": "{label}: 여기서는 테스트할 수 없습니다 (OAuth 또는 키 없음)",
+  "failover.rejected": "{label}: 키 거부됨 (HTTP {status})",
+  "failover.noModel": "{label}: 모델을 찾지 못함",
+  "failover.offer": "페일오버를 테스트할까요 (첫 번째 제공자가 실패한 상황을 흉내 냅니다)?",
+  "failover.header": "페일오버 테스트",
+  "failover.accepted": "{primary}이(가) 잘못된 키를 받아들여서 실패를 흉내 낼 수 없었습니다.",
+  "failover.works": "페일오버가 작동합니다: {primary}이(가) 실패해도 {servedBy}이(가) {latency} ms 만에 응답했습니다",
+  "failover.none": "{primary}이(가) 실패했을 때 응답한 폴백이 없습니다. 다른 제공자의 키와 모델을 확인하세요.",
+  "proxy.offer": "게이트웨이를 HTTPS로 공개할까요 (Caddy 또는 Traefik과 Let's Encrypt)?",
+  "proxy.which": "어떤 리버스 프록시를 쓸까요?",
+  "proxy.caddy": "Caddy (작은 설정 파일 하나)",
+  "proxy.publicWarning": "프록시 뒤에서는 인터넷의 누구나 게이트웨이에 접근할 수 있습니다. 토큰은 /command와 /admin만 보호합니다.",
+  "proxy.addBasicAuth": "게이트웨이 앞에 Basic 인증을 추가할까요 (권장)?",
+  "proxy.exposeAnyway": "Basic 인증 없이 게이트웨이를 공개할까요?",
+  "proxy.header": "게이트웨이 공개 ({name})",
+  "proxy.dns": "도메인의 DNS A/AAAA 레코드가 이 호스트를 가리키게 하고, 포트 80과 443을 여세요.",
+  "proxy.port80": "Let's Encrypt는 인증서를 발급하기 전에 포트 80으로 도메인을 확인합니다.",
+  "proxy.domain": "도메인 (예: bot.example.com): ",
+  "proxy.notDomain": "\"{answer}\"은(는) 도메인 이름이 아닙니다.",
+  "proxy.email": "Let's Encrypt 만료 알림용 이메일 (선택): ",
+  "proxy.added": "{proxy}을(를) docker-compose.yml에 추가합니다. 게이트웨이 포트는 더 이상 호스트에 공개되지 않습니다",
+  "proxy.saved": "{proxy} 설정을 {path}에 저장했습니다",
+  "root.warning": "root로 온보딩을 실행하고 있습니다.",
+  "root.ownedByRoot": "{dir} 아래 파일의 소유자가 root가 되어, 나중에 편집하려면",
+  "root.ownedByRootWhy": "sudo가 필요합니다. 또한 root가 아닌 사용자로 실행되는 컨테이너가 쓰지 못할 수 있습니다.",
+  "root.handOver": "생성한 파일의 소유자를 {user}(으)로 바꿀까요?",
+  "root.willHandOver": "저장한 뒤 파일 소유자를 {user}(으)로 바꿉니다.",
+  "root.tip": "팁: 온보딩은 평소 사용자로 (sudo 없이) 실행하세요.",
+  "root.continue": "그래도 root로 계속할까요?",
+  "root.handedOver": "생성한 파일의 소유자를 {user}(으)로 바꿨습니다",
+  "screens.written": "화면 {count}개를 {dir}에 기록했습니다",
+  "secretsKey.reusing": "{path}의 시크릿 키를 재사용합니다",
+  "secretsKey.created": "시크릿 키를 {path}에 만들었습니다",
+  "secretsKey.dryRun": "secrets.yaml은 age로 암호화됩니다 (키: {path})",
+  "secretsKey.header": "암호화된 시크릿",
+  "secretsKey.encrypted": "secrets.yaml은 age로 암호화되어 있습니다. 키: {path}",
+  "secretsKey.view": "보거나 편집하려면: {command}",
+  "secretsKey.backup": "키 파일을 백업하세요. 키가 없으면 시크릿을 복구할 수 없습니다.",
+  "security.header": "쓰기 도구 보안",
+  "security.intro": "쓰기 도구 허용 목록에 있는 사용자는 파일 쓰기/편집 도구를 쓸 수 있습니다.",
+  "security.autoIncluded": "채널 허용 목록에서 자동 포함: {ids}",
+  "security.additional": "추가로 허용할 사용자 ID (쉼표로 구분, 채널 사용자만 쓰려면 비워 두세요): ",
+  "security.enabled": "파일 쓰기 도구를 켰습니다 (write_file/edit_file/apply_patch)",
+  "security.allowList": "쓰기 도구 허용 목록: {ids}",
+  "security.confirmIntro": "확인을 켜면 봇이 파일 변경마다 내용을 보여 주고 yes/no 답을 기다립니다.",
+  "security.confirmAsk": "쓸 때마다 확인을 받을까요?",
+  "security.approver": "쓰기를 승인할 사용자 ID (요청한 사람으로 하려면 비워 두세요): ",
+  "security.approverIgnored": "{id}은(는) 채널 허용 목록에 없습니다. 봇은 답장을 포함해 이 사용자의 메시지를 무시합니다.",
+  "security.where": "봇이 어디에서 확인할까요?",
+  "security.whereChat": "요청이 온 채팅에서",
+  "security.whereDm": "{who}에게 보내는 다이렉트 메시지로",
+  "security.requester": "요청한 사람",
+  "security.timeout": "거부하기 전까지 답을 기다릴 초 [60]: ",
+  "security.badTimeout": "\"{answer}\"은(는) 정수 초가 아닙니다. 60을 씁니다.",
+  "security.confirmOff": "쓰기 도구 확인이 꺼져 있습니다 (허용 목록의 사용자는 바로 쓸 수 있습니다)",
+  "security.requestingUser": "요청한 사용자",
+  "security.confirmChat": "쓰기 도구 확인: {who}이(가) 같은 채팅에서 답합니다. {seconds}초 후 자동 거부",
+  "security.confirmDm": "쓰기 도구 확인: {who}이(가) 다이렉트 메시지로 답합니다. {seconds}초 후 자동 거부",
+  "slack.wrongPrefix": "올바르지 않은 것 같습니다. 이 토큰은 {prefix}(으)로 시작합니다.",
+  "slack.createApp": "https://api.slack.com/apps에서 Slack 앱을 만들고 Socket Mode를 켜세요.",
+  "slack.scopes": "봇 스코프: {scopes}",
+  "slack.guide": "가이드: {url}",
+  "slack.botToken": "Slack 봇 토큰(xoxb-...)을 붙여 넣으세요 (나중에 하려면 Enter): ",
+  "slack.appToken": "Slack 앱 수준 토큰(xapp-...)을 붙여 넣으세요 (나중에 하려면 Enter): ",
+  "slack.saved": "알겠습니다. 그 Slack 토큰을 쓰겠습니다.",
+  "swarm.detected": "이 Docker 엔진은 swarm 모드입니다.",
+  "swarm.offer": "docker-compose.yml 대신 `docker stack deploy`용 {file}을(를) 만들까요?",
+  "swarm.saved": "{file}을(를) {path}에 저장했습니다",
+  "swarm.header": "swarm에 배포",
+  "swarm.gateway": "게이트웨이: {url}",
+  "telegramIds.busy": "다른 프로세스가 이 봇의 업데이트를 받고 있습니다 (OwliaBot을 중지하거나 웹훅을 삭제하세요)",
+  "telegramIds.waiting": "Telegram을 열고 봇에게 아무 메시지(예: \"hi\")나 보내세요. 최대 90초 기다립니다...",
+  "telegramIds.check": "Telegram ID 조회",
+  "telegramIds.none": "메시지가 오지 않았습니다. 대신 ID를 입력할 수 있습니다.",
+  "telegramIds.allow": "{name} (ID {id})을(를) 허용할까요?",
+  "telegramIds.found": "Telegram 사용자 ID를 찾았습니다: {ids}",
+  "telegramCheck.format": "봇 토큰이 아닌 것 같습니다 (123456:ABC... 형식이어야 합니다)",
+  "telegramCheck.rejected": "Telegram이 토큰을 거부했습니다 (HTTP {status})",
+  "telegramCheck.unexpected": "Telegram에서 예상치 못한 HTTP {status}가 왔습니다",
+  "telegramCheck.badResponse": "예상치 못한 getMe 응답",
+  "telegramCheck.check": "Telegram 토큰",
+  "telegramCheck.bot": "Telegram 봇: @{username}",
+  "telegramCheck.sayHi": "실행되면 인사해 보세요: {url}",
+  "telegramCheck.invalid": "{message}. BotFather에서 토큰을 다시 복사하세요 (/mybots > API Token).",
+  "telegramCheck.again": "Telegram 봇 토큰을 다시 붙여 넣으세요 (나중에 하려면 Enter): ",
+  "testMessage.channel": "{service} 채널 {id}",
+  "testMessage.group": "{service} 그룹 {id}",
+  "testMessage.user": "{service} 사용자 {id}에게 DM",
+  "testMessage.discordRejected": "봇 토큰이 거부되었습니다. Developer Portal에서 재설정하세요.",
+  "testMessage.discordDm": "Discord에서는 사용자와 같은 서버에 있는 봇만 DM을 보낼 수 있습니다.",
+  "testMessage.discordInvite": "이 채널의 서버에 봇을 초대하고, 그곳에서 메시지 보내기 권한을 주세요.",
+  "testMessage.discordNoChannel": "그런 채널이 없습니다. discord.channelAllowList의 ID를 확인하세요.",
+  "testMessage.telegramRejected": "봇 토큰이 거부되었습니다. BotFather에서 다시 복사하세요.",
+  "testMessage.telegramStart": "봇은 대화를 시작할 수 없습니다. Telegram에서 봇을 열고 Start를 누른 뒤 다시 시도하세요.",
+  "testMessage.telegramGroup": "먼저 봇을 그룹에 추가하세요.",
+  "testMessage.telegramChatId": "허용 목록의 채팅 ID를 확인하세요.",
+  "testMessage.notAllowed": "{to}은(는) Discord나 Telegram 허용 목록에 없습니다.",
+  "testMessage.noTargets": "테스트 메시지를 보낼 Discord 채널/사용자나 Telegram 사용자/그룹이 허용 목록에 없습니다.",
+  "testMessage.pick": "테스트 메시지를 보낼 곳:",
+  "testMessage.sent": "{target}에 테스트 메시지를 보냈습니다",
+  "testMessage.reply": "답장해 보세요. 봇이 답하면 메시지를 보내는 것뿐 아니라 받는 것도 된다는 뜻입니다.",
+  "testMessage.unreachable": "{service}에 연결할 수 없습니다: {reason}",
+  "testMessage.failed": "{target}(으)로 보내지 못했습니다: {message}",
+  "timezone.header": "시간대",
+  "timezone.ask": "시간대 [{detected}] (자동 감지됨. 그대로 두려면 Enter, 검색하려면 도시나 지역 이름 일부를 입력하세요): ",
+  "timezone.noMatch": "\"{answer}\"와(과) 일치하는 시간대가 없습니다. Seoul이나 New York 같은 도시 이름으로 시도해 보세요.",
+  "timezone.chosen": "시간대: {zone}",
+  "timezone.tooMany": "\"{answer}\"와(과) 일치하는 시간대가 {count}개입니다. 조금 더 입력하세요.",
+  "timezone.matching": "일치하는 시간대:",
+  "timezone.searchAgain": "다시 검색",
+  "tunnel.ngrokHeader": "ngrok 터널",
+  "tunnel.cloudflareCreate": "Cloudflare Zero Trust → Networks → Tunnels에서 터널을 만들고 토큰을 복사하세요.",
+  "tunnel.cloudflareHostname": "게이트웨이 서비스를 가리키는 공개 호스트 이름을 추가하세요 (http://owliabot:8787,",
+  "tunnel.cloudflareOidc": "OIDC 로그인을 켰다면 http://oauth2-proxy:4180).",
+  "tunnel.ngrokToken": "{url}에서 authtoken을 복사하세요",
+  "tunnel.token": "터널 토큰: ",
+  "tunnel.hostname": "공개 호스트 이름 (예: bot.example.com): ",
+  "tunnel.ngrokDomain": "예약한 ngrok 도메인 (무작위 URL을 쓰려면 비워 두세요): ",
+  "tunnel.noHostname": "호스트 이름을 입력하지 않았습니다. Cloudflare에서 터널의 Public Hostnames에서 확인할 수 있습니다.",
+  "tunnel.added": "{provider} 터널을 docker-compose.yml에 추가합니다",
+  "tunnel.randomUrl": "무작위 URL ({url}에 표시됨)",
+  "tunnel.seeCloudflare": "Cloudflare에서 터널의 Public Hostnames 참고",
+  "tunnel.saved": "터널 토큰을 {path}에 저장했습니다",
+  "webhook.path": "수신 경로 [{path}]: ",
+  "webhook.badPath": "경로는 /로 시작하고 공백이나 쿼리 문자열이 없어야 합니다.",
+  "webhook.replyUrl": "답장을 보낼 URL (건너뛰려면 Enter): ",
+  "webhook.badUrl": "http(s) URL이 아닌 것 같습니다.",
+  "webhook.header": "웹훅",
+  "webhook.intro": "다른 시스템은 Gateway HTTP의 이 경로로 {example} 같은 JSON을 POST합니다.",
+  "webhook.auth": "인증에는 X-Webhook-Secret 헤더의 공유 시크릿을 씁니다.",
+  "webhook.secret": "공유 시크릿 (생성하려면 Enter): ",
+  "webhook.generated": "웹훅 시크릿을 생성했습니다: {secret}...",
+  "webhook.noReplyUrl": "답장 URL이 없으면 웹훅 메시지는 읽지만 제 답은 버려집니다.",
+  "webhook.gatewayOn": "웹훅은 Gateway HTTP가 받으므로 이를 켰습니다 (포트 {port}).",
+  "webhook.ready": "웹훅 준비 완료: POST {url}",
+  "setup.devModeSubtitle": "(개발 모드)",
+  "setup.devMode": "개발 모드가 켜져 있습니다 (OWLIABOT_DEV=1). 설정을 ~/.owlia_dev/에 저장합니다.",
+  "setup.found": "기존 설정을 찾았습니다",
+  "setup.folder": "설정 폴더: {dir}",
+  "setup.apiKeySet": "{service}: API 키가 설정되어 있습니다 ({key}...)",
+  "setup.invalidFormat": "⚠️ 형식이 잘못됨",
+  "setup.setupTokenSet": "Anthropic: setup-token이 설정되어 있습니다 {status}",
+  "setup.anthropicOAuth": "Anthropic: OAuth 토큰이 있습니다",
+  "setup.azure": "Azure OpenAI: {endpoint}의 {deployment}",
+  "setup.codexValid": "OpenAI Codex: ✅ OAuth 토큰이 유효합니다",
+  "setup.codexValidUntil": "OpenAI Codex: ✅ OAuth 토큰이 유효합니다 (만료: {expires})",
+  "setup.tokenSet": "{service}: 토큰이 설정되어 있습니다 ({token}...)",
+  "setup.otherProfiles": "이 머신의 다른 프로필: {profiles} (바꾸려면 owliabot --profile <name> onboard)",
+  "setup.keep": "이 설정을 계속 쓸까요?",
+  "setup.keeping": "좋습니다. 기존 설정을 그대로 쓰겠습니다.",
+  "setup.fresh": "알겠습니다. 처음부터 설정하겠습니다.",
+  "workspace.header": "작업 공간",
+  "workspace.docker": "Docker 모드에서는 컨테이너 안의 기본 작업 공간 경로를 씁니다.",
+  "workspace.chosen": "작업 공간: {path}",
+  "workspace.ask": "작업 공간 경로 [{path}]: ",
+  "nextSteps.header": "다음 단계",
+  "nextSteps.intro": "거의 다 됐습니다:",
+  "nextSteps.discordToken": "Discord 토큰은 나중에 추가: {command}",
+  "nextSteps.telegramToken": "Telegram 토큰은 나중에 추가: {command}",
+  "nextSteps.envVars": "환경 변수를 쓴다면 ANTHROPIC_API_KEY 또는 OPENAI_API_KEY를 설정하세요",
+  "nextSteps.signIn": "로그인 마치기: {command}",
+  "nextSteps.gateway": "게이트웨이 엔드포인트: {url} (토큰: {token}...)",
+  "nextSteps.start": "OwliaBot 시작: {command}",
+  "systemd.header": "systemd 서비스로 실행",
+  "systemd.installs": "{unit}을(를) 설치하고 시작",
+  "systemd.afterEdit": "app.yaml을 편집한 뒤",
+  "timing.took": "설정에 걸린 시간: {duration}{breakdown}",
+};
@@ -9,13 +9,72 @@
  *
  * Without --docker:
  *   - Writes config + secrets under OWLIABOT_HOME (or ~/.owlia_dev when OWLIABOT_DEV=1)
+ *
+ * --dry-run prints the generated files (secrets masked) and a diff against the
+ * existing ones instead of writing anything. Without it, changes to existing
+ * files are shown the same way and confirmed before anything is written, and
+ * the files being replaced are copied to <configDir>/backups/<timestamp>/
+ * (`owliabot rollback` restores them).
+ *
+ * --gateway-auth basic|mtls adds basic auth or mutual TLS in front of the gateway.
+ * --tunnel cloudflared|ngrok (docker mode) adds a tunnel sidecar for a public HTTPS URL.
+ * --oidc (docker mode) publishes the gateway through oauth2-proxy (OIDC login).
+ * --reverse-proxy caddy|traefik (docker mode) exposes the gateway publicly on 80/443 with a
+ *   Let's Encrypt certificate for your domain (also offered interactively for docker-compose.yml).
+ * --environments dev,prod (docker mode) writes one config dir + compose file per environment.
+ * --output-format kubernetes (docker mode) writes Kubernetes manifests instead of docker-compose.yml.
+ * --output-format swarm (docker mode) writes docker-stack.yml for `docker stack deploy`
+ *   (also offered interactively when the engine reports swarm mode).
+ * --github-actions (docker mode) writes a workflow that validates app.yaml and deploys it over SSH.
+ * --output-format devcontainer (docker mode) writes .devcontainer.json for VS Code / devcontainer CLI.
+ * --nix (native mode) also writes a Nix flake that runs OwliaBot with the generated config.
+ * --systemd (native mode) also writes a systemd unit + install script next to app.yaml.
+ * --encrypt-secrets encrypts secrets.yaml with age (key in <configDir>/auth/secrets.agekey).
+ * --keychain (native mode) keeps provider keys and channel tokens in the OS keychain.
+ * --secrets-env (docker mode) writes keys and tokens to a .env file loaded via env_file:
+ *   instead of secrets.yaml.
+ * --compose-profiles (docker mode) puts optional services (ollama, watchtower, tunnel,
+ *   proxy) in compose profiles, toggled with `docker compose --profile`.
+ * --auto-update (docker mode) adds Watchtower, limited to the bot container, so new
+ *   images are picked up by themselves (also asked interactively for docker-compose.yml).
+ * --platform <os/arch> (docker mode) pins the bot image's platform in docker-compose.yml,
+ *   e.g. linux/amd64 under emulation on an ARM host whose tag has no ARM build.
+ * --kiosk applies the locked-down kiosk preset (one channel, one user, read-only tools,
+ *   no web, short retention) on top of the answers (also offered interactively).
+ * --lang en|ja|ko sets the wizard's language (default: OWLIABOT_LANG, then the locale);
+ *   --lang on its own asks for one first.
+ * --accessible (also OWLIABOT_ACCESSIBLE=1, or TERM=dumb) prints plain lines: no colors, banner
+ *   art or symbols, "Step: <title>" headers and numbered options, for screen readers.
+ * --theme dark|light|high-contrast|mono picks the colors (default: OWLIABOT_THEME; NO_COLOR or
+ *   CLICOLOR=0 mean mono).
+ * --demo configures the demo provider (echo replies, no API key) instead of asking for one,
+ *   labels app.yaml and docker-compose.yml as a demo, and starts docker-compose.yml.
+ * --notify-url <url> POSTs a setup summary (no secrets) to a webhook once the bot answers /health
+ *   (install.sh sends it after its start check); --notify-host names the host in it.
+ * --local-run (docker mode) also writes run-local.sh + app.local.yaml to run the same config from a checkout.
+ * --ca-bundle <file> trusts an extra CA bundle for outbound HTTPS and wires it into the generated files.
+ * --debug-flow [file] (hidden) logs stage transitions with redacted answers, and writes a DOT graph to file.
+ * --dump-screens <dir> walks every screen with default answers (a dry run) and writes
+ *   the plain text of each to <dir>, for accessibility review.
+ * --profile <name> (global option) sets up a separate bot in ~/.owliabot-<name>, with its
+ *   own container, compose file and gateway port. Without it, a machine that already has
+ *   a profile is asked which one to set up (or for a new name).
+ *
+ * Every run ends with how long setup took, and (except --dry-run) appends its
+ * per-stage timing to onboarding-history.jsonl next to app.yaml.
  */
 
 import { createInterface } from "node:readline";
-import { join } from "node:path";
+import { dirname, join, resolve } from "node:path";
+import { existsSync } from "node:fs";
 import { DEFAULT_APP_CONFIG_PATH } from "./storage.js";
-import { AbortError, COLORS, info, success, header } from "./shared.js";
-import { detectTimezone } from "./steps/helpers.js";
+import {
+  AbortError, COLORS, info, success, warn, header, selectOption, setAccessible, setSpeedrun, setStageHelp, setTheme,
+  wantsAccessible,
+} from "./shared.js";
+import { THEMES, parseTheme, resolveTheme } from "./theme.js";
+import { LANGUAGES, parseLang, resolveLang, setLang, t, type Lang } from "./i18n.js";
+import { chooseTimezone } from "./steps/timezone.js";
 import { getProvidersSetup } from "./steps/provider-setup.js";
 import { getChannelsSetup } from "./steps/channel-setup.js";
 import { buildAppConfigFromPrompts, deriveWriteToolAllowListFromConfig } from "./steps/config-building.js";
@@ -25,11 +84,95 @@ import {
   promptDockerComposeSetup,
   buildDockerEnvLines,
   writeDockerCompose,
+  dockerComposePath,
   printDockerNextSteps,
+  composeUpCommand,
+  printImageRollbackHint,
+  promptAutoUpdate,
 } from "./steps/docker.js";
 import { writeDockerConfigLocalStyle, writeDevConfig, prepareDockerWorkspace } from "./steps/writers.js";
 import { printDevNextSteps } from "./steps/workspace-setup.js";
 import { initDevWorkspace } from "./steps/init-dev-workspace.js";
+import {
+  renderDevFiles,
+  renderDockerFiles,
+  printDryRunPreview,
+  confirmOverwrites,
+  maskSecrets,
+  type RenderedFile,
+} from "./steps/dry-run.js";
+import { detectRootInvocation, confirmRootInvocation, applyOwnership } from "./steps/root-check.js";
+import { confirmDockerBindPath, hostBindPath } from "./steps/bind-path-check.js";
+import {
+  applyGatewayAuth,
+  gatewayAuthConflict,
+  writeGatewayTlsMaterial,
+  printGatewayAuthSummary,
+  type GatewayAuthMode,
+} from "./steps/gateway-auth.js";
+import {
+  buildOnboardingInventory,
+  notifyHost,
+  sendOnboardingNotification,
+  waitForGatewayHealth,
+  writePendingNotification,
+} from "./steps/notify.js";
+import { isWebhookUrl } from "./steps/webhook-setup.js";
+import { promptTunnelSetup, writeTunnelEnv, describeTunnelUrl, type TunnelProvider } from "./steps/tunnel.js";
+import {
+  describeReverseProxyUrl,
+  promptExposeGateway,
+  confirmReverseProxyAuth,
+  promptReverseProxySetup,
+  writeReverseProxyConfig,
+  type ReverseProxy,
+} from "./steps/reverse-proxy.js";
+import { promptKioskPreset, setUpKioskPreset } from "./steps/kiosk.js";
+import { demoProviderSetup, inOnboardingContainer, printDemoNextSteps, startDemoStack } from "./steps/demo.js";
+import { promptOidcProxySetup, writeOidcProxyEnv } from "./steps/oidc-proxy.js";
+import {
+  promptEnvironmentVariants,
+  prepareEnvironments,
+  renderEnvironmentFiles,
+  writeEnvironments,
+  printEnvironmentsNextSteps,
+  environmentDockerPaths,
+} from "./steps/environments.js";
+import {
+  buildKubernetesManifests,
+  writeKubernetesManifests,
+  printKubernetesNextSteps,
+  kubernetesEnvSecretKeys,
+  KUBERNETES_MANIFEST_FILE,
+  type OutputFormat,
+} from "./steps/kubernetes.js";
+import {
+  prepareSecretsEncryption,
+  printSecretsEncryptionSummary,
+  describeSecretsEncryption,
+  type SecretsEncryptionResult,
+} from "./steps/secrets-encryption.js";
+import { ensureAgeAvailable } from "../config/secrets-crypto.js";
+import { keychainSet, type KeychainAccount } from "../config/keychain.js";
+import { requireKeychainBackend, moveSecretsToKeychain, printKeychainSummary } from "./steps/keychain-storage.js";
+import { moveSecretsToEnv, withoutEnvFileKeys, writeEnvFile, printEnvFileSummary, ENV_FILE } from "./steps/env-file.js";
+import {
+  buildDockerStackYaml,
+  writeDockerStack,
+  printSwarmNextSteps,
+  promptSwarmOutput,
+  DOCKER_STACK_FILE,
+} from "./steps/swarm.js";
+import { buildDevcontainerJson, writeDevcontainer, printDevcontainerNextSteps, DEVCONTAINER_FILE } from "./steps/devcontainer.js";
+import {
+  promptGithubActionsSetup,
+  buildGithubActionsWorkflow,
+  writeGithubActionsWorkflow,
+  printGithubActionsNextSteps,
+  GITHUB_WORKFLOW_FILE,
+} from "./steps/github-actions.js";
+import { buildNixFlakeFile, writeNixFlake, printNixNextSteps } from "./steps/nix-flake.js";
+import { buildSystemdFiles, writeSystemdFiles, printSystemdNextSteps, defaultServiceUser } from "./steps/systemd.js";
 import {
   printOnboardingBanner,
   printExistingConfigSummary,
@@ -37,6 +180,29 @@ import {
   ensureGatewayToken,
 } from "./steps/ui.js";
 import type { SecretsConfig } from "./secrets.js";
+import { getSecretsPath } from "./secrets.js";
+import { recordGeneratedFiles } from "../config/integrity.js";
+import { backupFiles } from "../config/backups.js";
+import { imageHistoryPath, lastKnownGoodImage, readImageHistory } from "../gateway/image-history.js";
+import { collectSecretStrings, redactError } from "../utils/redact.js";
+import { createFlowTracer, type FlowOutcome } from "./steps/flow-trace.js";
+import { StageTimer, printSetupTiming, appendSetupHistory } from "./steps/stage-timing.js";
+import { startScreenDump } from "./steps/screen-dump.js";
+import { hasStageHelp, showStageHelp } from "./steps/stage-help.js";
+import { formatProviderChain } from "./steps/provider-priority.js";
+import { offerClipboardCopy } from "./steps/clipboard.js";
+import { renderLocalRunFiles, writeLocalRunFiles, printLocalRunNextSteps } from "./steps/local-run.js";
+import { assertCaBundle, installCaBundle, printCaBundleNextSteps, CA_BUNDLE_FILE } from "./steps/ca-bundle.js";
+import { pickProfile } from "./steps/profile-picker.js";
+import {
+  applyProfile,
+  listProfiles,
+  profileComposeFile,
+  profileContainerName,
+  profileGatewayPort,
+  profileHome,
+} from "../config/profiles.js";
+import { resolveHomeDir } from "../utils/paths.js";
 
 // Re-export all step functions so consumers can import from onboard.ts
 export * from "./steps/index.js";
@@ -52,6 +218,66 @@ export interface OnboardOptions {
   docker?: boolean;
   /** Output directory for docker-compose.yml (docker mode) */
   outputDir?: string;
+  /** Preview generated files (and a diff against existing ones) without writing */
+  dryRun?: boolean;
+  /** Extra protection in front of the gateway (default: token only) */
+  gatewayAuth?: GatewayAuthMode;
+  /** Tunnel sidecar exposing the gateway publicly (docker mode) */
+  tunnel?: TunnelProvider;
+  /** Caddy/Traefik in front of the gateway on 80/443 with Let's Encrypt (docker mode) */
+  reverseProxy?: ReverseProxy;
+  /** Put an oauth2-proxy (OIDC login) in front of the gateway (docker mode) */
+  oidc?: boolean;
+  /** Environment names to generate variants for, e.g. ["dev", "prod"] (docker mode) */
+  environments?: string[];
+  /** Deployment output in docker mode (default: compose) */
+  outputFormat?: OutputFormat;
+  /** Generate a Nix flake that runs OwliaBot with the generated config (dev mode) */
+  nix?: boolean;
+  /** Generate a systemd unit for running natively, without Docker (dev mode) */
+  systemd?: boolean;
+  /** Encrypt secrets.yaml at rest with age */
+  encryptSecrets?: boolean;
+  /** Store provider keys and channel tokens in the OS keychain (dev mode) */
+  keychain?: boolean;
+  /** Write keys and tokens to a .env file (env_file:) instead of secrets.yaml (docker mode) */
+  secretsEnv?: boolean;
+  /** Generate a GitHub Actions workflow that validates and deploys the config (docker mode) */
+  githubActions?: boolean;
+  /** Put optional services in docker-compose.yml profiles (docker mode) */
+  composeProfiles?: boolean;
+  /** Add Watchtower to docker-compose.yml without asking (docker mode) */
+  autoUpdate?: boolean;
+  /** `platform:` for the bot service, e.g. linux/amd64 (docker mode) */
+  platform?: string;
+  /** Apply the locked-down kiosk preset without asking */
+  kiosk?: boolean;
+  /** Use the demo provider (echo replies, no API keys) and start the stack */
+  demo?: boolean;
+  /** Wizard language code; `true` asks for one (--lang without a value) */
+  lang?: string | true;
+  /** Plain output for screen readers and dumb terminals (no colors, art or symbols) */
+  accessible?: boolean;
+  /** Color theme: dark, light, high-contrast or mono (default: OWLIABOT_THEME, NO_COLOR, dark) */
+  theme?: string;
+  /** POST a setup summary (version, host, providers, channels; no secrets) here when done */
+  notifyUrl?: string;
+  /** Host name for the setup summary (default: OWLIABOT_NOTIFY_HOST, else the hostname) */
+  notifyHost?: string;
+  /** Let one comma-separated line answer several prompts in a row */
+  speedrun?: boolean;
+  /** Also write run-local.sh + app.local.yaml to run the compose config with node (docker mode) */
+  localRun?: boolean;
+  /** Extra CA bundle (PEM, absolute path) to trust for outbound HTTPS, here and in the bot */
+  caBundle?: string;
+  /** Log stage transitions (redacted answers); a string also writes a DOT graph there */
+  debugFlow?: boolean | string;
+  /** Walk the wizard with default answers and write each screen's plain text to this dir (implies dryRun) */
+  dumpScreens?: string;
+  /** Named profile to set up (~/.owliabot-<name>); undefined for the default one */
+  profile?: string;
+  /** Without a profile, ask which one when this machine already has one */
+  pickProfile?: boolean;
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -67,41 +293,272 @@ function getConfigAnchorPath(
     if (!dockerPaths) throw new Error("Internal error: dockerPaths is required in docker mode");
     return join(dockerPaths.configDir, "app.yaml");
   }
+  // A profile picked in the wizard moves the default path, which was resolved at startup.
+  if (options.profile && (!options.appConfigPath || options.appConfigPath === DEFAULT_APP_CONFIG_PATH)) {
+    return join(profileHome(options.profile), "app.yaml");
+  }
   return options.appConfigPath ?? DEFAULT_APP_CONFIG_PATH;
 }
 
+/**
+ * Ask for the profile before anything is resolved from OWLIABOT_HOME.
+ * Not inside a container: only the mounted config dir is visible there.
+ */
+async function askProfile(): Promise<string | undefined> {
+  if (process.env.OWLIABOT_DOCKER === "1" || existsSync("/.dockerenv")) return undefined;
+  const rl = createInterface({ input: process.stdin, output: process.stdout });
+  try {
+    return await pickProfile(rl, listProfiles(), resolveHomeDir());
+  } finally {
+    rl.close();
+  }
+}
+
+function announceBackup(backupDir: string | null): void {
+  if (backupDir) info(t("wizard.backup", { dir: backupDir }));
+}
+
+/** app.yaml and secrets.yaml, for the integrity manifest */
+function configFiles(appConfigPath: string): string[] {
+  return [appConfigPath, getSecretsPath(appConfigPath)];
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Main onboarding flow
 // ─────────────────────────────────────────────────────────────────────────────
 
 export async function runOnboarding(options: OnboardOptions = {}): Promise<void> {
+  // Walking the screens must never write config.
+  if (options.dumpScreens) options = { ...options, dryRun: true };
+  if (options.profile === undefined && options.pickProfile && !options.dryRun) {
+    options = { ...options, profile: await askProfile() };
+  }
+  if (options.profile) applyProfile(options.profile);
   const dockerMode = options.docker === true;
-  const dockerPaths = dockerMode ? initDockerPaths(options.outputDir) : null;
+  const dockerPaths = dockerMode ? initDockerPaths(options.outputDir, options.profile) : null;
   const appConfigPath = getConfigAnchorPath(options, dockerMode, dockerPaths);
   const defaultImage = "ghcr.io/owliabot/owliabot:latest";
 
+  const gatewayAuthError = gatewayAuthConflict(options.gatewayAuth ?? "none", {
+    ...options,
+    interactive: Boolean(process.stdin.isTTY),
+  });
+  if (gatewayAuthError) throw new Error(gatewayAuthError);
+  const kubernetes = options.outputFormat === "kubernetes";
+  if (kubernetes) {
+    if (!dockerMode) throw new Error("--output-format kubernetes requires --docker");
+    if (options.tunnel || options.oidc || options.environments?.length) {
+      throw new Error("--output-format kubernetes cannot be combined with --tunnel, --oidc or --environments");
+    }
+  }
+  let swarm = options.outputFormat === "swarm";
+  if (swarm) {
+    if (!dockerMode) throw new Error("--output-format swarm requires --docker");
+    if (options.tunnel || options.oidc || options.environments?.length) {
+      throw new Error("--output-format swarm cannot be combined with --tunnel, --oidc or --environments");
+    }
+  }
+  const devcontainer = options.outputFormat === "devcontainer";
+  if (devcontainer) {
+    if (!dockerMode) throw new Error("--output-format devcontainer requires --docker");
+    if (options.tunnel || options.oidc || options.environments?.length) {
+      throw new Error("--output-format devcontainer cannot be combined with --tunnel, --oidc or --environments");
+    }
+  }
+  if (options.encryptSecrets) {
+    if (kubernetes || options.environments?.length) {
+      throw new Error("--encrypt-secrets cannot be combined with --output-format kubernetes or --environments");
+    }
+    // Fail before the prompts rather than after them.
+    if (!options.dryRun) ensureAgeAvailable();
+  }
+  if (options.keychain && dockerMode) {
+    throw new Error("--keychain cannot be combined with --docker (the container can't reach the host keychain)");
+  }
+  const keychainBackend = options.keychain ? requireKeychainBackend() : null;
+  if (options.secretsEnv) {
+    if (!dockerMode) throw new Error("--secrets-env requires --docker");
+    if (kubernetes || swarm || devcontainer || options.environments?.length || options.encryptSecrets) {
+      throw new Error(
+        "--secrets-env only applies to docker-compose.yml and cannot be combined with --output-format, --environments or --encrypt-secrets",
+      );
+    }
+  }
+  if (options.githubActions) {
+    if (!dockerMode) throw new Error("--github-actions requires --docker");
+    if (kubernetes || swarm || devcontainer || options.environments?.length) {
+      throw new Error("--github-actions deploys docker-compose.yml and cannot be combined with --output-format or --environments");
+    }
+  }
+  if (options.composeProfiles) {
+    if (!dockerMode) throw new Error("--compose-profiles requires --docker");
+    if (kubernetes || swarm || devcontainer || options.environments?.length) {
+      throw new Error("--compose-profiles only applies to docker-compose.yml and cannot be combined with --output-format or --environments");
+    }
+  }
+  if (options.autoUpdate) {
+    if (!dockerMode) throw new Error("--auto-update requires --docker");
+    if (kubernetes || swarm || devcontainer || options.environments?.length) {
+      throw new Error("--auto-update only applies to docker-compose.yml and cannot be combined with --output-format or --environments");
+    }
+  }
+  if (options.platform) {
+    if (!dockerMode) throw new Error("--platform requires --docker");
+    if (kubernetes || swarm || devcontainer || options.environments?.length) {
+      throw new Error("--platform only applies to docker-compose.yml and cannot be combined with --output-format or --environments");
+    }
+  }
+  if (options.reverseProxy) {
+    if (!dockerMode) throw new Error("--reverse-proxy requires --docker");
+    if (kubernetes || swarm || devcontainer || options.environments?.length) {
+      throw new Error("--reverse-proxy only applies to docker-compose.yml and cannot be combined with --output-format or --environments");
+    }
+    if (options.tunnel || options.oidc) {
+      throw new Error("--reverse-proxy cannot be combined with --tunnel or --oidc; each of them publishes the gateway already");
+    }
+  }
+  if (options.notifyUrl && !isWebhookUrl(options.notifyUrl)) {
+    throw new Error("--notify-url must be an http(s) URL");
+  }
+  if (options.nix && dockerMode) {
+    throw new Error("--nix is for native installs and cannot be combined with --docker");
+  }
+  if (options.systemd && dockerMode) {
+    throw new Error("--systemd is for native installs and cannot be combined with --docker");
+  }
+  if (options.localRun) {
+    if (!dockerMode) throw new Error("--local-run requires --docker (native mode already runs locally)");
+    if (kubernetes || swarm || devcontainer || options.environments?.length) {
+      throw new Error("--local-run goes with docker-compose.yml and cannot be combined with --output-format or --environments");
+    }
+  }
+  if (typeof options.lang === "string" && !parseLang(options.lang)) {
+    throw new Error(`--lang must be one of: ${Object.keys(LANGUAGES).join(", ")}`);
+  }
+  if (options.theme && !parseTheme(options.theme)) {
+    throw new Error(`--theme must be one of: ${Object.keys(THEMES).join(", ")}`);
+  }
+  if (options.caBundle) {
+    if (kubernetes || options.environments?.length) {
+      throw new Error("--ca-bundle cannot be combined with --output-format kubernetes or --environments");
+    }
+    assertCaBundle(options.caBundle);
+  }
+  if (options.profile) {
+    if (kubernetes || swarm || devcontainer || options.environments?.length || options.localRun || options.githubActions) {
+      throw new Error(
+        "--profile applies to docker-compose.yml and native installs and cannot be combined with --output-format, --environments, --local-run or --github-actions",
+      );
+    }
+  }
+  if (options.environments?.length) {
+    if (!dockerMode) throw new Error("--environments requires --docker");
+    if (options.tunnel || options.oidc) {
+      throw new Error("--environments cannot be combined with --tunnel or --oidc; run onboard once per environment instead");
+    }
+  }
+
   const rl = createInterface({ input: process.stdin, output: process.stdout });
+  // Secrets typed in so far, so errors carrying child process output
+  // (docker, openssl, ...) can be scrubbed before they are printed.
+  const answeredSecrets: unknown[] = [];
+  const flow = createFlowTracer(options.debugFlow, () => collectSecretStrings(answeredSecrets));
+  let flowOutcome: FlowOutcome = "done";
+  const timer = new StageTimer();
+  let helpHintShown = !process.stdout.isTTY;
+  const enterStage = (stage: string, answers?: Record<string, unknown>) => {
+    flow.enter(stage, answers);
+    timer.enter(stage);
+    setStageHelp(hasStageHelp(stage) ? () => showStageHelp(stage) : null);
+    if (hasStageHelp(stage) && !helpHintShown) {
+      helpHintShown = true;
+      console.log(`${COLORS.DIM}  ${t("wizard.helpHint")}${COLORS.NC}`);
+    }
+  };
+  // One line per real run in onboarding-history.jsonl, cancelled and failed ones too
+  const recordHistory = (outcome: FlowOutcome) => {
+    if (!options.dryRun) appendSetupHistory(dirname(appConfigPath), timer.summary(outcome));
+  };
+  setSpeedrun(Boolean(options.speedrun));
+  setTheme(resolveTheme(options.theme));
+  setAccessible(wantsAccessible(options.accessible));
+  const screenDump = options.dumpScreens ? startScreenDump(options.dumpScreens) : null;
 
   try {
+    setLang(resolveLang(typeof options.lang === "string" ? options.lang : undefined) ?? "en");
+    if (options.lang === true) {
+      const codes = Object.keys(LANGUAGES) as Lang[];
+      setLang(codes[await selectOption(rl, t("wizard.language"), codes.map((c) => LANGUAGES[c]), 0)]);
+    }
+    enterStage("preflight", { dockerMode, outputFormat: options.outputFormat ?? "compose" });
     printOnboardingBanner(dockerMode);
 
+    const ownershipTarget = await confirmRootInvocation(rl, detectRootInvocation(), dirname(appConfigPath));
+    if (dockerPaths) {
+      const bindPath = hostBindPath(dockerPaths.configDir);
+      await confirmDockerBindPath(rl, bindPath.configDir, bindPath.options);
+    }
+
+    enterStage("existing-config", { ownershipTarget: ownershipTarget?.user });
     const existing = await detectExistingConfig(dockerMode, appConfigPath);
     if (existing) printExistingConfigSummary(dockerMode, appConfigPath, existing);
     const reuseExisting = await promptReuseExistingConfig(rl, existing);
 
-    const providerResult = await getProvidersSetup(rl, dockerMode, existing, reuseExisting);
+    enterStage("providers", { existing: Boolean(existing), reuseExisting });
+    const providerResult = options.demo
+      ? demoProviderSetup()
+      : await getProvidersSetup(rl, dockerMode, existing, reuseExisting);
     const secrets: SecretsConfig = { ...providerResult.secrets };
+    answeredSecrets.push(secrets);
 
+    enterStage("channels", { providers: providerResult.providers });
     const channels = await getChannelsSetup(rl, dockerMode, secrets, existing, reuseExisting);
 
-    const tz = detectTimezone();
+    enterStage("timezone", { channels });
+    const tz = await chooseTimezone(rl);
     const gatewayToken = ensureGatewayToken(secrets, existing, reuseExisting);
 
     let dockerCompose: Awaited<ReturnType<typeof promptDockerComposeSetup>> | null = null;
     if (dockerMode) {
-      dockerCompose = await promptDockerComposeSetup(rl, gatewayToken);
+      enterStage("docker", { timezone: tz });
+      dockerCompose = await promptDockerComposeSetup(
+        rl,
+        gatewayToken,
+        undefined,
+        String(profileGatewayPort(options.profile, dockerPaths?.outputDir)),
+      );
+      const canOfferSwarm = !kubernetes && !swarm && !devcontainer && !options.tunnel && !options.oidc && !options.environments?.length && !options.secretsEnv && !options.composeProfiles && !options.localRun && !options.profile && !options.autoUpdate && !options.reverseProxy && !options.platform;
+      if (canOfferSwarm) swarm = await promptSwarmOutput(rl);
+    } else {
+      flow.skip("docker", "native mode");
+    }
+    if ((options.tunnel || options.oidc) && !dockerMode) {
+      warn(t("wizard.sidecarsIgnored"));
     }
+    const tunnel = options.tunnel && dockerCompose ? await promptTunnelSetup(rl, options.tunnel) : undefined;
+    const oidc = options.oidc && dockerCompose
+      ? await promptOidcProxySetup(rl, dockerCompose.gatewayPort, tunnel?.hostname ? `https://${tunnel.hostname}` : undefined)
+      : undefined;
+    const canOfferReverseProxy = Boolean(dockerCompose) && !kubernetes && !swarm && !devcontainer && !tunnel && !oidc
+      && !options.environments?.length && options.gatewayAuth !== "mtls";
+    let reverseProxyChoice = options.reverseProxy ?? (canOfferReverseProxy ? await promptExposeGateway(rl) : undefined);
+    if (reverseProxyChoice) {
+      const auth = await confirmReverseProxyAuth(rl, options.gatewayAuth ?? "none");
+      if (auth) {
+        options = { ...options, gatewayAuth: auth };
+      } else if (options.reverseProxy) {
+        // An explicit --reverse-proxy is not quietly turned into a local-only gateway
+        warn(t("wizard.reverseProxyDeclined"));
+        throw new AbortError("reverse proxy declined");
+      } else {
+        info(t("wizard.reverseProxyKept"));
+        reverseProxyChoice = undefined;
+      }
+    }
+    const reverseProxy = reverseProxyChoice ? await promptReverseProxySetup(rl, reverseProxyChoice) : undefined;
+    const githubActions = options.githubActions && dockerCompose ? await promptGithubActionsSetup(rl, dockerPaths?.dockerConfigPath) : undefined;
 
+    enterStage("config", { timezone: tz, gatewayPort: dockerCompose?.gatewayPort, swarm, tunnel, oidc, reverseProxy, githubActions });
     const { config, workspacePath, writeToolAllowList } = await buildAppConfigFromPrompts(
       rl,
       dockerMode,
@@ -113,23 +570,313 @@ export async function runOnboarding(options: OnboardOptions = {}): Promise<void>
       channels.reuseTelegramConfig ?? false,
       channels.telegramAllowList,
       channels.telegramGroups,
+      channels.slackEnabled ?? false,
+      channels.webhookEnabled ?? false,
+      existing?.mcpServers ?? [],
+      undefined,
+      profileGatewayPort(options.profile),
     );
+    if (options.kiosk || await promptKioskPreset(rl)) {
+      enterStage("kiosk", { workspacePath });
+      await setUpKioskPreset(rl, config);
+    }
     const resolvedWriteToolAllowList = deriveWriteToolAllowListFromConfig(config) ?? writeToolAllowList;
     config.timezone = tz;
 
-    header("Saving your settings");
+    // Set when onboarding started the bot itself (demo), to its /health URL
+    let startedHealthUrl: string | undefined;
+    const finish = async () => {
+      if (options.notifyUrl) {
+        const outputFormat = kubernetes ? "kubernetes" : swarm ? "swarm" : devcontainer ? "devcontainer" : "compose";
+        const inventory = buildOnboardingInventory(config, { dockerMode, outputFormat }, new Date(), undefined, notifyHost(options.notifyHost));
+        if (startedHealthUrl && await waitForGatewayHealth(startedHealthUrl)) {
+          await sendOnboardingNotification(options.notifyUrl, inventory);
+        } else {
+          const pendingPath = writePendingNotification(dockerPaths?.configDir ?? dirname(appConfigPath), inventory);
+          if (inOnboardingContainer()) {
+            info(t("wizard.notifyByInstaller"));
+          } else {
+            info(t("wizard.notifySaved", { path: pendingPath }));
+            info(`  curl -fsS -X POST -H "Content-Type: application/json" --data-binary @${pendingPath} <notify-url>`);
+          }
+        }
+      }
+      printSetupTiming(timer.summary("done"));
+      success(t("wizard.allSet"));
+    };
+
+    if (options.environments?.length) {
+      if (!dockerPaths || !dockerCompose) throw new Error("Internal error: missing docker paths/docker setup");
+      enterStage("environments", { workspacePath, environments: options.environments });
+      const variants = await promptEnvironmentVariants(
+        rl,
+        options.environments,
+        dockerCompose.gatewayPort,
+        (name) => lastKnownGoodImage(readImageHistory(imageHistoryPath(environmentDockerPaths(dockerPaths, name).configDir))),
+      );
+      const prepared = prepareEnvironments(
+        variants,
+        dockerPaths,
+        config,
+        secrets,
+        tz,
+        defaultImage,
+        options.gatewayAuth ?? "none",
+        { generate: false },
+      );
+
+      if (options.dryRun) {
+        printDryRunPreview(renderEnvironmentFiles(prepared));
+        console.log("");
+        info(t("wizard.dryRun"));
+        return;
+      }
+
+      await confirmOverwrites(rl, renderEnvironmentFiles(prepared));
+      announceBackup(backupFiles(dirname(appConfigPath), renderEnvironmentFiles(prepared).map((f) => f.path)));
+      for (const env of prepared) writeGatewayTlsMaterial(env.gatewayAuth);
+      await writeEnvironments(prepared, resolvedWriteToolAllowList);
+      applyOwnership(prepared.flatMap((env) => [env.paths.configDir, env.composePath]), ownershipTarget);
+      printEnvironmentsNextSteps(prepared);
+      await finish();
+      return;
+    }
+
+    // Certificates and keychain entries are only created once the overwrite
+    // confirmation below has passed.
+    const gatewayAuth = applyGatewayAuth(options.gatewayAuth ?? "none", config, secrets, dirname(appConfigPath), {
+      generate: false,
+    });
+    const keychainWrites: Array<[KeychainAccount, string]> = [];
+    const movedToKeychain = keychainBackend
+      ? moveSecretsToKeychain(config, secrets, {
+          store: !options.dryRun,
+          set: (account, value) => keychainWrites.push([account, value]),
+        })
+      : [];
+    const envVars = options.secretsEnv ? moveSecretsToEnv(config, secrets) : null;
+    answeredSecrets.push(envVars);
+    const systemdFiles = options.systemd
+      ? buildSystemdFiles({
+          configDir: resolve(dirname(appConfigPath)),
+          appConfigPath: resolve(appConfigPath),
+          user: defaultServiceUser(ownershipTarget?.user),
+          caBundle: options.caBundle ? resolve(dirname(appConfigPath), CA_BUNDLE_FILE) : undefined,
+        })
+      : null;
+    const nixFlake = options.nix
+      ? buildNixFlakeFile({ configDir: resolve(dirname(appConfigPath)), appConfigPath: resolve(appConfigPath) })
+      : null;
+    // Last question before the files are written: only docker-compose.yml gets Watchtower.
+    const composeOutput = dockerMode && !kubernetes && !swarm && !devcontainer;
+    const autoUpdate = composeOutput && (options.autoUpdate === true || await promptAutoUpdate(rl));
+    const composeOptions = {
+      gatewayTls: Boolean(config.gateway?.http?.tls),
+      tunnel,
+      oidcProxy: Boolean(oidc),
+      reverseProxy,
+      secretsKey: options.encryptSecrets === true,
+      envFile: envVars ? ENV_FILE : undefined,
+      profiles: options.composeProfiles === true,
+      watchtower: autoUpdate,
+      platform: options.platform,
+      demo: options.demo === true,
+      caBundle: Boolean(options.caBundle),
+      ...(options.profile && {
+        containerName: profileContainerName(options.profile),
+        projectName: profileContainerName(options.profile),
+      }),
+    };
+    const composeEnvLines = (lines: string[]) => (envVars ? withoutEnvFileKeys(lines, envVars) : lines);
+    enterStage(options.dryRun ? "dry-run" : "write", {
+      workspacePath,
+      autoUpdate,
+      gatewayAuth: gatewayAuth.mode,
+      keychain: movedToKeychain,
+      envVars: envVars ? Object.keys(envVars) : undefined,
+    });
+    if (config.providers.length > 1) {
+      console.log("");
+      info(t("provider.chain", { chain: formatProviderChain(config.providers) }));
+    }
+
+    // What will be written, for --dry-run and the overwrite confirmation
+    const plannedFiles = (): RenderedFile[] => {
+      if (dockerMode && kubernetes) {
+        if (!dockerPaths) throw new Error("Internal error: missing docker paths");
+        const dockerEnv = buildDockerEnvLines(config, secrets, tz);
+        return [
+          ...renderDevFiles(config, secrets, join(dockerPaths.configDir, "app.yaml")),
+          {
+            path: join(dockerPaths.outputDir, KUBERNETES_MANIFEST_FILE),
+            content: buildKubernetesManifests(config, maskSecrets(secrets), dockerEnv, { image: defaultImage }),
+            previewOnly: true,
+          },
+        ];
+      } else if (dockerMode && swarm) {
+        if (!dockerPaths || !dockerCompose) throw new Error("Internal error: missing docker paths/docker setup");
+        const dockerEnv = buildDockerEnvLines(config, secrets, tz);
+        return [
+          ...renderDevFiles(config, secrets, join(dockerPaths.configDir, "app.yaml")),
+          {
+            path: join(dockerPaths.outputDir, DOCKER_STACK_FILE),
+            content: buildDockerStackYaml(
+              dockerPaths.dockerConfigPath,
+              dockerEnv,
+              dockerCompose.gatewayPort,
+              defaultImage,
+              composeOptions,
+            ),
+          },
+        ];
+      } else if (dockerMode && devcontainer) {
+        if (!dockerPaths || !dockerCompose) throw new Error("Internal error: missing docker paths/docker setup");
+        const dockerEnv = buildDockerEnvLines(config, secrets, tz);
+        return [
+          ...renderDevFiles(config, secrets, join(dockerPaths.configDir, "app.yaml")),
+          {
+            path: join(dockerPaths.outputDir, DEVCONTAINER_FILE),
+            content: buildDevcontainerJson(
+              dockerPaths.dockerConfigPath,
+              dockerEnv,
+              dockerCompose.gatewayPort,
+              defaultImage,
+              composeOptions,
+            ),
+          },
+        ];
+      } else if (dockerMode) {
+        if (!dockerPaths || !dockerCompose) throw new Error("Internal error: missing docker paths/docker setup");
+        const dockerEnv = composeEnvLines(buildDockerEnvLines(config, secrets, tz));
+        return [
+          ...renderDockerFiles(dockerPaths, config, secrets, dockerEnv, dockerCompose.gatewayPort, defaultImage, composeOptions),
+          ...(options.localRun ? renderLocalRunFiles(dockerPaths, config, dockerEnv, dockerCompose.gatewayPort) : []),
+          ...(githubActions
+            ? [{ path: join(dockerPaths.outputDir, GITHUB_WORKFLOW_FILE), content: buildGithubActionsWorkflow(githubActions) }]
+            : []),
+        ];
+      } else {
+        return [
+          ...renderDevFiles(config, secrets, appConfigPath),
+          ...(systemdFiles
+            ? [
+                { path: systemdFiles.unitPath, content: systemdFiles.unit },
+                { path: systemdFiles.scriptPath, content: systemdFiles.script },
+              ]
+            : []),
+          ...(nixFlake ? [nixFlake] : []),
+        ];
+      }
+    };
+
+    if (options.dryRun) {
+      printDryRunPreview(plannedFiles());
+      console.log("");
+      if (options.encryptSecrets) info(describeSecretsEncryption(dirname(appConfigPath)));
+      if (envVars) info(t("wizard.envWouldSet", { file: ENV_FILE, vars: Object.keys(envVars).join(", ") || t("wizard.nothing") }));
+      info(t("wizard.dryRun"));
+      return;
+    }
+
+    await confirmOverwrites(rl, plannedFiles());
+    announceBackup(backupFiles(dirname(appConfigPath), [
+      ...plannedFiles().map((f) => f.path),
+      ...(envVars && dockerPaths ? [join(dockerPaths.outputDir, ENV_FILE)] : []),
+    ]));
+    writeGatewayTlsMaterial(gatewayAuth);
+    for (const [account, value] of keychainWrites) keychainSet(account, value);
+
+    header(t("wizard.saving"));
+    let secretsEncryption: SecretsEncryptionResult | null = null;
     if (dockerMode) {
       if (!dockerPaths || !dockerCompose) throw new Error("Internal error: missing docker paths/docker setup");
 
       prepareDockerWorkspace(dockerPaths);
+      // After the permission widening above, so the key keeps age-keygen's 0600.
+      if (options.encryptSecrets) secretsEncryption = prepareSecretsEncryption(dockerPaths.configDir);
       await writeDockerConfigLocalStyle(dockerPaths, config, secrets);
+      if (options.caBundle) installCaBundle(dockerPaths.configDir, options.caBundle);
 
       // Docker mode: initialize a host workspace directory that is bind-mounted into the container.
       await initDevWorkspace(workspacePath, resolvedWriteToolAllowList);
 
       const dockerEnv = buildDockerEnvLines(config, secrets, tz);
-      writeDockerCompose(dockerPaths, dockerPaths.dockerConfigPath, dockerEnv, dockerCompose.gatewayPort, defaultImage);
+      if (kubernetes) {
+        const manifestPath = writeKubernetesManifests(
+          dockerPaths.outputDir,
+          buildKubernetesManifests(config, secrets, dockerEnv, { image: defaultImage }),
+        );
+        recordGeneratedFiles(dockerPaths.configDir, [...configFiles(appConfigPath), manifestPath]);
+        applyOwnership([dockerPaths.configDir, manifestPath], ownershipTarget);
+        printKubernetesNextSteps(manifestPath, kubernetesEnvSecretKeys(dockerEnv));
+        printGatewayAuthSummary(gatewayAuth, 8787);
+        await finish();
+        return;
+      }
+      if (swarm) {
+        const stackPath = writeDockerStack(
+          dockerPaths,
+          buildDockerStackYaml(dockerPaths.dockerConfigPath, dockerEnv, dockerCompose.gatewayPort, defaultImage, composeOptions),
+        );
+        recordGeneratedFiles(dockerPaths.configDir, [...configFiles(appConfigPath), stackPath]);
+        applyOwnership([dockerPaths.configDir, stackPath], ownershipTarget);
+        printSwarmNextSteps(stackPath, dockerCompose.gatewayPort);
+        printGatewayAuthSummary(gatewayAuth, dockerCompose.gatewayPort);
+        if (secretsEncryption) printSecretsEncryptionSummary(secretsEncryption);
+        await finish();
+        return;
+      }
+      if (devcontainer) {
+        const devcontainerPath = writeDevcontainer(
+          dockerPaths.outputDir,
+          buildDevcontainerJson(dockerPaths.dockerConfigPath, dockerEnv, dockerCompose.gatewayPort, defaultImage, composeOptions),
+        );
+        recordGeneratedFiles(dockerPaths.configDir, [...configFiles(appConfigPath), devcontainerPath]);
+        applyOwnership([dockerPaths.configDir, devcontainerPath], ownershipTarget);
+        printDevcontainerNextSteps(devcontainerPath, dockerCompose.gatewayPort);
+        printGatewayAuthSummary(gatewayAuth, dockerCompose.gatewayPort);
+        if (secretsEncryption) printSecretsEncryptionSummary(secretsEncryption);
+        await finish();
+        return;
+      }
+
+      const envPath = envVars ? writeEnvFile(dockerPaths.outputDir, envVars) : null;
+      writeDockerCompose(
+        dockerPaths,
+        dockerPaths.dockerConfigPath,
+        composeEnvLines(dockerEnv),
+        dockerCompose.gatewayPort,
+        defaultImage,
+        composeOptions,
+      );
+      if (tunnel) writeTunnelEnv(dockerPaths.configDir, tunnel);
+      if (oidc) writeOidcProxyEnv(dockerPaths.configDir, oidc, composeOptions.gatewayTls);
+      if (reverseProxy) writeReverseProxyConfig(dockerPaths.configDir, reverseProxy, composeOptions.gatewayTls);
+      const workflowPath = githubActions
+        ? writeGithubActionsWorkflow(dockerPaths.outputDir, buildGithubActionsWorkflow(githubActions))
+        : null;
+      const localRunPaths = options.localRun
+        ? writeLocalRunFiles(renderLocalRunFiles(dockerPaths, config, composeEnvLines(dockerEnv), dockerCompose.gatewayPort))
+        : [];
+      recordGeneratedFiles(dockerPaths.configDir, [
+        ...configFiles(appConfigPath),
+        dockerComposePath(dockerPaths),
+        ...(envPath ? [envPath] : []),
+        ...(workflowPath ? [workflowPath] : []),
+        ...localRunPaths,
+      ]);
+      applyOwnership(
+        [
+          dockerPaths.configDir,
+          dockerComposePath(dockerPaths),
+          ...(envPath ? [envPath] : []),
+          ...(workflowPath ? [workflowPath] : []),
+          ...localRunPaths,
+        ],
+        ownershipTarget,
+      );
 
+      const publicUrl = reverseProxy ? describeReverseProxyUrl(reverseProxy) : tunnel ? describeTunnelUrl(tunnel) : undefined;
       printDockerNextSteps(
         dockerPaths,
         dockerCompose.gatewayPort,
@@ -140,9 +887,53 @@ export async function runOnboarding(options: OnboardOptions = {}): Promise<void>
         providerResult.useAnthropic,
         providerResult.useOpenaiCodex,
         secrets,
+        publicUrl,
       );
+      printGatewayAuthSummary(gatewayAuth, dockerCompose.gatewayPort);
+      if (reverseProxy) {
+        info(t("wizard.reverseProxyOnly", { url: describeReverseProxyUrl(reverseProxy), port: dockerCompose.gatewayPort }));
+        info(t("wizard.reverseProxyFirstStart", { proxy: reverseProxy.proxy }));
+      }
+      printImageRollbackHint(readImageHistory(imageHistoryPath(dockerPaths.configDir)), defaultImage);
+      if (options.profile) {
+        info(t("wizard.profileStart", { command: `docker compose -f ${profileComposeFile(options.profile)} up -d` }));
+        info(t("wizard.profileCommands", { command: `owliabot --profile ${options.profile} status` }));
+      }
+      if (composeOptions.profiles) {
+        info(t("wizard.composeProfiles", { command: composeUpCommand(composeOptions) }));
+        info(t("wizard.composeProfilesHint"));
+      }
+      if (envPath && envVars) printEnvFileSummary(envPath, envVars, dockerPaths.configDir);
+      if (workflowPath) printGithubActionsNextSteps(workflowPath, join(dockerPaths.configDir, "app.yaml"));
+      if (localRunPaths.length > 0) printLocalRunNextSteps();
+      if (options.demo) {
+        // install.sh starts the stack itself once this container exits.
+        const composePath = dockerComposePath(dockerPaths);
+        const started = inOnboardingContainer() || startDemoStack(composePath);
+        if (started && !inOnboardingContainer() && !composeOptions.gatewayTls) {
+          startedHealthUrl = `http://localhost:${dockerCompose.gatewayPort}/health`;
+        }
+        printDemoNextSteps(started, `docker compose -f ${composePath} up -d`);
+      }
+      await offerClipboardCopy(rl, [
+        {
+          label: t("clipboard.startCommand"),
+          text: options.profile ? `docker compose -f ${profileComposeFile(options.profile)} up -d` : composeUpCommand(composeOptions),
+        },
+        { label: t("clipboard.gatewayUrl"), text: publicUrl ?? `http://localhost:${dockerCompose.gatewayPort}` },
+        { label: t("clipboard.gatewayToken"), text: gatewayToken },
+      ]);
     } else {
+      if (options.encryptSecrets) secretsEncryption = prepareSecretsEncryption(dirname(appConfigPath));
       await writeDevConfig(config, secrets, appConfigPath);
+      const caBundlePath = options.caBundle ? installCaBundle(dirname(appConfigPath), options.caBundle) : null;
+      if (systemdFiles) writeSystemdFiles(systemdFiles);
+      if (nixFlake) writeNixFlake(nixFlake);
+      recordGeneratedFiles(dirname(appConfigPath), [
+        ...configFiles(appConfigPath),
+        ...(systemdFiles ? [systemdFiles.unitPath, systemdFiles.scriptPath] : []),
+        ...(nixFlake ? [nixFlake.path] : []),
+      ]);
       await printDevNextSteps(
         workspacePath,
         channels.discordEnabled,
@@ -151,21 +942,44 @@ export async function runOnboarding(options: OnboardOptions = {}): Promise<void>
         providerResult.providers,
         resolvedWriteToolAllowList,
       );
+      if (keychainBackend) printKeychainSummary(keychainBackend, movedToKeychain);
+      if (systemdFiles) printSystemdNextSteps(systemdFiles);
+      if (nixFlake) printNixNextSteps(nixFlake);
+      if (caBundlePath && !systemdFiles) printCaBundleNextSteps(caBundlePath);
+      printGatewayAuthSummary(gatewayAuth, config.gateway?.http?.port ?? 8787);
+      applyOwnership([dirname(appConfigPath), workspacePath], ownershipTarget);
+      if (options.demo) printDemoNextSteps(false, `owliabot start -c ${appConfigPath}`);
+      await offerClipboardCopy(rl, [
+        { label: t("clipboard.startCommand"), text: `owliabot start -c ${appConfigPath}` },
+        { label: t("clipboard.gatewayUrl"), text: `http://localhost:${config.gateway?.http?.port ?? 8787}` },
+        { label: t("clipboard.gatewayToken"), text: gatewayToken },
+      ]);
     }
+    if (secretsEncryption) printSecretsEncryptionSummary(secretsEncryption);
 
-    success("All set!");
+    await finish();
 
   } catch (err) {
     if (err instanceof AbortError) {
+      // process.exit() below skips the finally block.
+      flow.end("cancelled");
+      recordHistory("cancelled");
+      screenDump?.finish();
       const cmd = dockerMode ? "owliabot onboard --docker" : "owliabot onboard";
       console.log("");
-      info("Setup cancelled. No changes were made.");
-      console.log(`  You can run this again anytime with: ${COLORS.CYAN}${cmd}${COLORS.NC}`);
+      info(t("wizard.cancelled"));
+      console.log(`  ${t("wizard.runAgain", { command: `${COLORS.CYAN}${cmd}${COLORS.NC}` })}`);
       console.log("");
       process.exit(130);
     }
-    throw err;
+    flowOutcome = "failed";
+    throw redactError(err, collectSecretStrings(answeredSecrets));
   } finally {
+    flow.end(flowOutcome);
+    recordHistory(flowOutcome);
+    setSpeedrun(false);
+    setStageHelp(null);
+    screenDump?.finish();
     rl.close();
   }
 }
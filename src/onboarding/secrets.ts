@@ -1,23 +1,37 @@
 import { readFile, writeFile, mkdir, chmod } from "node:fs/promises";
 import { dirname, join } from "node:path";
 import { parse, stringify } from "yaml";
+import { decryptSecretsContent, encryptSecretsContent, readSecretsRecipient } from "../config/secrets-crypto.js";
+import { refreshTrackedFile } from "../config/integrity.js";
 
 export interface SecretsConfig {
   discord?: { token?: string };
   telegram?: { token?: string };
+  /** Slack bot token (xoxb-) and app-level token (xapp-, Socket Mode) */
+  slack?: { botToken?: string; appToken?: string };
+  /** GitHub personal access token for the "github" MCP preset */
+  github?: { token?: string };
+  /** Shared secret for the generic webhook channel */
+  webhook?: { secret?: string };
   /** OpenAI API key (for openai provider, not OAuth) */
   openai?: { apiKey?: string };
   /** OpenAI-compatible (Ollama/vLLM/LM Studio/etc.) API key (optional) */
   "openai-compatible"?: { apiKey?: string };
+  /** Azure OpenAI resource key (azure-openai provider) */
+  "azure-openai"?: { apiKey?: string };
+  /** AWS access key pair for the amazon-bedrock provider (credentials mode "keys") */
+  "amazon-bedrock"?: { accessKeyId?: string; secretAccessKey?: string };
   /** 
    * Anthropic credentials
    * - token: setup-token from `claude setup-token` (starts with sk-ant-oat01-)
    * - apiKey: standard Anthropic API key (starts with sk-ant-api...)
    * Either one can be used; token takes precedence if both are set.
+   * - tokenExpiresAt: when the setup-token runs out (ISO), recorded on entry
    */
   anthropic?: { 
     token?: string;
     apiKey?: string;
+    tokenExpiresAt?: string;
   };
   /** Clawlet wallet auth */
   clawlet?: {
@@ -26,6 +40,8 @@ export interface SecretsConfig {
   /** Gateway token (primarily used for docker deployments) */
   gateway?: {
     token?: string;
+    /** Password for gateway.http.basicAuth when set to "secrets" */
+    basicAuthPassword?: string;
   };
 }
 
@@ -37,20 +53,26 @@ export async function loadSecrets(appConfigPath: string): Promise<SecretsConfig
   const secretsPath = getSecretsPath(appConfigPath);
   try {
     const content = await readFile(secretsPath, "utf-8");
-    return (parse(content) as SecretsConfig) ?? null;
+    return (parse(decryptSecretsContent(content, dirname(secretsPath))) as SecretsConfig) ?? null;
   } catch (err) {
     if ((err as NodeJS.ErrnoException).code === "ENOENT") return null;
     throw err;
   }
 }
 
+/**
+ * Write secrets.yaml. When the config dir has an age key (auth/secrets.agekey),
+ * the file is written encrypted to that key.
+ */
 export async function saveSecrets(
   appConfigPath: string,
   secrets: SecretsConfig
 ): Promise<void> {
   const secretsPath = getSecretsPath(appConfigPath);
   await mkdir(dirname(secretsPath), { recursive: true });
-  const content = stringify(secrets, { indent: 2 });
+  const yaml = stringify(secrets, { indent: 2 });
+  const recipient = readSecretsRecipient(dirname(secretsPath));
+  const content = recipient ? encryptSecretsContent(yaml, recipient) : yaml;
   await writeFile(secretsPath, content, "utf-8");
   // Best-effort permissions hardening
   try {
@@ -58,4 +80,5 @@ export async function saveSecrets(
   } catch {
     // ignore
   }
+  refreshTrackedFile(dirname(secretsPath), secretsPath);
 }
@@ -3,9 +3,10 @@
  */
 
 import { createInterface } from "node:readline";
-import { existsSync, readFileSync } from "node:fs";
-import { join } from "node:path";
+import { spawn, spawnSync } from "node:child_process";
 import type { LLMProviderId } from "./types.js";
+import { t } from "./i18n.js";
+import { THEMES, type ThemeName } from "./theme.js";
 
 // ─────────────────────────────────────────────────────────────────────────────
 // Abort handling
@@ -25,42 +26,93 @@ export class AbortError extends Error {
 // Colors
 // ─────────────────────────────────────────────────────────────────────────────
 
-export const COLORS = {
-  RED: "\x1b[0;31m",
-  GREEN: "\x1b[0;32m",
-  YELLOW: "\x1b[1;33m",
-  BLUE: "\x1b[0;34m",
-  CYAN: "\x1b[0;36m",
-  NC: "\x1b[0m", // No Color
-};
+/** The current theme's escapes (see theme.ts); blank in accessible mode */
+export const COLORS = { ...THEMES.dark };
+
+let theme: ThemeName = "dark";
+
+/** Switch COLORS to `name` (the escapes stay blank while accessible mode is on) */
+export function setTheme(name: ThemeName): void {
+  theme = name;
+  Object.assign(COLORS, accessible ? THEMES.mono : THEMES[name]);
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Accessible mode
+// ─────────────────────────────────────────────────────────────────────────────
+
+// `onboard --accessible` (or TERM=dumb): plain lines for screen readers and dumb terminals.
+let accessible = false;
+
+/**
+ * Turn accessible mode on or off. While on, nothing is colored, messages
+ * start with a word instead of a symbol, headers have no box-drawing
+ * characters and the banner is a single line of text.
+ */
+export function setAccessible(enabled: boolean): void {
+  accessible = enabled;
+  setTheme(theme);
+}
+
+export function isAccessible(): boolean {
+  return accessible;
+}
+
+/** Whether to start in accessible mode: the flag, OWLIABOT_ACCESSIBLE, or a dumb terminal */
+export function wantsAccessible(
+  flag: boolean | undefined,
+  env: NodeJS.ProcessEnv = process.env,
+  tty: boolean = Boolean(process.stdout.isTTY),
+): boolean {
+  if (flag) return true;
+  if (["1", "true", "yes"].includes(env.OWLIABOT_ACCESSIBLE?.toLowerCase() ?? "")) return true;
+  return tty && env.TERM === "dumb";
+}
 
 // ─────────────────────────────────────────────────────────────────────────────
 // Console helpers
 // ─────────────────────────────────────────────────────────────────────────────
 
-export function info(msg: string) { console.log(`${COLORS.BLUE}ℹ${COLORS.NC} ${msg}`); }
-export function success(msg: string) { console.log(`${COLORS.GREEN}✓${COLORS.NC} ${msg}`); }
-export function warn(msg: string) { console.log(`${COLORS.YELLOW}!${COLORS.NC} ${msg}`); }
-export function error(msg: string) { console.log(`${COLORS.RED}✗${COLORS.NC} ${msg}`); }
+function line(symbol: string, word: string, color: string, msg: string): void {
+  if (accessible) console.log(msg.trim() ? `${word}: ${msg}` : "");
+  else console.log(`${color}${symbol}${COLORS.NC} ${msg}`);
+}
+
+export function info(msg: string) { line("ℹ", "Note", COLORS.BLUE, msg); }
+export function success(msg: string) { line("✓", "Done", COLORS.GREEN, msg); }
+export function warn(msg: string) { line("!", "Warning", COLORS.YELLOW, msg); }
+export function error(msg: string) { line("✗", "Error", COLORS.RED, msg); }
 
 export function header(title: string) {
   console.log("");
-  console.log(`${COLORS.CYAN}━━━ ${title} ━━━${COLORS.NC}`);
+  console.log(accessible ? `Step: ${title}` : `${COLORS.CYAN}━━━ ${title} ━━━${COLORS.NC}`);
   console.log("");
 }
 
-export function printBanner(subtitle = "") {
+/** The ASCII-art wordmark (44 columns) */
+export const BANNER_ART = [
+  "   ____          ___       ____        _   ",
+  "  / __ \\        / (_)     |  _ \\      | |  ",
+  " | |  | |_      _| |_  __ _| |_) | ___ | |_ ",
+  " | |  | \\ \\ /\\ / / | |/ _` |  _ < / _ \\| __|",
+  " | |__| |\\ V  V /| | | (_| | |_) | (_) | |_ ",
+  "  \\____/  \\_/\\_/ |_|_|\\__,_|____/ \\___/ \\__|",
+];
+
+/**
+ * Print the banner. `lines` replaces the wordmark (see steps/banner.ts for
+ * narrow terminals, non-Latin locales and distribution overrides); in
+ * accessible mode only the welcome line is printed.
+ */
+export function printBanner(subtitle = "", lines: string[] = BANNER_ART) {
   const { CYAN, NC } = COLORS;
   console.log("");
-  console.log(`${CYAN}   ____          ___       ____        _   ${NC}`);
-  console.log(`${CYAN}  / __ \\        / (_)     |  _ \\      | |  ${NC}`);
-  console.log(`${CYAN} | |  | |_      _| |_  __ _| |_) | ___ | |_ ${NC}`);
-  console.log(`${CYAN} | |  | \\ \\ /\\ / / | |/ _\` |  _ < / _ \\| __|${NC}`);
-  console.log(`${CYAN} | |__| |\\ V  V /| | | (_| | |_) | (_) | |_ ${NC}`);
-  console.log(`${CYAN}  \\____/  \\_/\\_/ |_|_|\\__,_|____/ \\___/ \\__|${NC}`);
-  console.log("");
+  if (!accessible) {
+    for (const line of lines) console.log(`${CYAN}${line}${NC}`);
+    console.log("");
+  }
   const sub = subtitle ? ` ${subtitle}` : "";
-  console.log(`  Let's set up OwliaBot${sub}`);
+  console.log(`  ${t("banner.setUp", { subtitle: sub })}`);
   console.log("");
 }
 
@@ -70,14 +122,274 @@ export function printBanner(subtitle = "") {
 
 type RL = ReturnType<typeof createInterface>;
 
+// Speedrun mode (`onboard --speedrun`): one line can answer several prompts.
+let speedrun = false;
+let queuedAnswers: string[] = [];
+
+/**
+ * Turn speedrun mode on or off. While on, an answer containing commas is
+ * split and the remaining parts answer the next prompts in order, e.g.
+ * `2,1,sk-ant-...,1`. An empty part means "press Enter". Lists that would
+ * normally be comma-separated (IDs) can be typed space-separated instead.
+ */
+export function setSpeedrun(enabled: boolean): void {
+  speedrun = enabled;
+  queuedAnswers = [];
+}
+
+// Scripted mode (`onboard --dump-screens`): answers come from a function, the terminal is never read.
+let scriptedAnswer: ((question: string) => string) | null = null;
+
+/**
+ * Answer every prompt with `answer(question)` instead of reading the
+ * terminal (null turns it off). Prompts and answers are still printed.
+ */
+export function setScriptedAnswers(answer: ((question: string) => string) | null): void {
+  scriptedAnswer = answer;
+}
+
+// Help for the current wizard stage: F1 at any prompt, or "h" at a numbered or yes/no question.
+let stageHelp: (() => void) | null = null;
+
+/**
+ * Set what F1 / "h" shows (null: no help for this stage). The wizard calls
+ * this as it moves between stages.
+ */
+export function setStageHelp(show: (() => void) | null): void {
+  stageHelp = show;
+}
+
 /**
  * Ask a question. If secret=true, hide input (for tokens/passwords).
- * 
+ * With docsUrl, answering "d" opens the docs and asks again.
+ */
+export async function ask(rl: RL, q: string, secret = false, docsUrl?: string): Promise<string> {
+  if (docsUrl) console.log(`${COLORS.DIM}  ${t("prompt.docsHint")}${COLORS.NC}`);
+  for (;;) {
+    const answer = await nextAnswer(rl, q, secret);
+    if (!docsUrl || answer.toLowerCase() !== "d") return answer;
+    openDocs(docsUrl);
+  }
+}
+
+/**
+ * Command that opens a URL in the browser, or null when there is no browser
+ * to open: not a terminal, or Linux without a display (SSH, containers).
+ */
+export function browserCommand(
+  url: string,
+  platform: NodeJS.Platform = process.platform,
+  env: NodeJS.ProcessEnv = process.env,
+  interactive: boolean = Boolean(process.stdout.isTTY),
+): { cmd: string; args: string[] } | null {
+  if (!interactive) return null;
+  if (platform === "darwin") return { cmd: "open", args: [url] };
+  if (platform === "win32") return { cmd: "cmd", args: ["/c", "start", "", url] };
+  if (env.DISPLAY || env.WAYLAND_DISPLAY) return { cmd: "xdg-open", args: [url] };
+  return null;
+}
+
+/**
+ * Open a docs page in the browser; print the URL when that isn't possible.
+ */
+export function openDocs(url: string, command = browserCommand(url)): void {
+  if (command) {
+    try {
+      const child = spawn(command.cmd, command.args, { stdio: "ignore", detached: true });
+      child.on("error", () => {});
+      child.unref();
+      info(t("prompt.opening", { url }));
+      return;
+    } catch {
+      // fall through to printing the URL
+    }
+  }
+  info(t("prompt.docs", { url }));
+}
+
+/**
+ * One answer: scripted, queued from a speedrun line, else read from the terminal.
+ */
+async function nextAnswer(rl: RL, q: string, secret: boolean): Promise<string> {
+  if (scriptedAnswer || queuedAnswers.length > 0) {
+    const next = scriptedAnswer ? scriptedAnswer(q) : queuedAnswers.shift()!;
+    console.log(`${q}${secret && next ? "********" : next}`);
+    return next;
+  }
+  const answer = await readAnswer(rl, q, secret);
+  if (!speedrun || !answer.includes(",")) return answer;
+  const [first, ...rest] = answer.split(",").map((part) => part.trim());
+  queuedAnswers = rest;
+  return first;
+}
+
+/** How long Ctrl+R shows a secret while it is typed */
+export const REVEAL_MS = 2000;
+
+// Bracketed paste: the terminal wraps pasted text in PASTE_START/PASTE_END
+// while it is on, so a pasted newline or escape isn't taken for a key.
+const PASTE_ON = "\x1b[?2004h";
+const PASTE_OFF = "\x1b[?2004l";
+const PASTE_START = "\x1b[200~";
+const PASTE_END = "\x1b[201~";
+// CSI (arrows, Delete, F5+) and SS3 (F1-F4) sequences, skipped as one key
+const ESCAPE_SEQUENCE = /^\x1b(?:\[[0-9;?]*[ -/]*[@-~]|O.)/;
+const PARTIAL_ESCAPE = /^\x1b(?:\[[0-9;?]*[ -/]*|O)?$/;
+
+/** Length of the end of `text` that could be the start of `marker` */
+function partialMarker(text: string, marker: string): number {
+  for (let n = Math.min(marker.length - 1, text.length); n > 0; n--) {
+    if (marker.startsWith(text.slice(-n))) return n;
+  }
+  return 0;
+}
+
+/** The parts of stdin a masked secret prompt uses */
+export type SecretInput = Pick<NodeJS.ReadStream, "on" | "removeListener" | "setRawMode" | "isRaw">;
+
+/**
+ * Read a secret with the terminal in raw mode, drawing a mask character
+ * for each one typed. Ctrl+R shows the text for `revealMs` (or until the
+ * next key). Where raw mode can't be turned on, the line is read with
+ * terminal echo off instead.
+ *
+ * Bracketed paste is on while it reads, so a pasted token arrives whole:
+ * newlines and control characters inside a paste are dropped rather than
+ * submitting the prompt early. Input is handled one key at a time even
+ * when a chunk holds several (a paste without bracketed paste support).
+ *
  * Note: Secret input only accepts printable ASCII (32-126) to filter out
  * arrow keys, escape sequences, and other control characters. API tokens
  * and passwords are typically ASCII-only, so this is safe for most cases.
  */
-export function ask(rl: RL, q: string, secret = false): Promise<string> {
+export function readMaskedSecret(
+  rl: RL,
+  q: string,
+  io: { stdin?: SecretInput; stdout?: { write(text: string): unknown }; revealMs?: number } = {},
+): Promise<string> {
+  const stdin = io.stdin ?? process.stdin;
+  const stdout = io.stdout ?? process.stdout;
+  const revealMs = io.revealMs ?? REVEAL_MS;
+  const oldRawMode = stdin.isRaw;
+  try {
+    stdin.setRawMode(true);
+  } catch {
+    return readHiddenLine(rl, q);
+  }
+
+  // Dumb terminals would print the escape, so not in accessible mode
+  const bracketedPaste = !accessible;
+  stdout.write(`${COLORS.DIM}  ${t("prompt.secretHint")}${COLORS.NC}\n${bracketedPaste ? PASTE_ON : ""}${q}`);
+  // Redraws rewrite only the prompt's last line
+  const promptLine = q.slice(q.lastIndexOf("\n") + 1);
+  const mask = accessible ? "*" : "•";
+
+  return new Promise((resolve, reject) => {
+    let input = "";
+    let revealed = false;
+    let timer: NodeJS.Timeout | undefined;
+    let pending = "";
+    let pasting = false;
+    let done = false;
+    const redraw = () => stdout.write(`\r\x1b[K${promptLine}${revealed ? input : mask.repeat(input.length)}`);
+    const hide = () => {
+      clearTimeout(timer);
+      if (!revealed) return;
+      revealed = false;
+      redraw();
+    };
+    const finish = (settle: () => void) => {
+      done = true;
+      clearTimeout(timer);
+      stdin.removeListener("data", onData);
+      try {
+        stdin.setRawMode(oldRawMode ?? false);
+      } catch {
+        // Ignore errors during cleanup
+      }
+      stdout.write(bracketedPaste ? `${PASTE_OFF}\n` : "\n");
+      settle();
+    };
+    const paste = (text: string) => {
+      // Keep the printable ASCII (a copied token often brings a trailing newline)
+      const chars = text.replace(/[^\x20-\x7e]/g, "");
+      if (!chars) return;
+      hide();
+      input += chars;
+      stdout.write(mask.repeat(chars.length));
+    };
+    const onKey = (c: string) => {
+      if (c === "\n" || c === "\r") {
+        finish(() => resolve(input.trim()));
+      } else if (c === "\x03") { // Ctrl+C
+        finish(() => reject(new AbortError()));
+      } else if (c === "\x12") { // Ctrl+R: show what was typed for a moment
+        clearTimeout(timer);
+        revealed = true;
+        redraw();
+        timer = setTimeout(hide, revealMs);
+      } else if (c === "\x7f" || c === "\b") { // Backspace
+        hide();
+        if (input) {
+          input = input.slice(0, -1);
+          stdout.write("\b \b");
+        }
+      } else if (c.charCodeAt(0) >= 32 && c.charCodeAt(0) < 127) {
+        // Only accept printable ASCII (filter out arrow keys, escape sequences, etc.)
+        hide();
+        input += c;
+        stdout.write(mask);
+      }
+      // Silently ignore non-printable characters
+    };
+    const onData = (chunk: Buffer) => {
+      pending += chunk.toString();
+      while (pending && !done) {
+        if (pasting) {
+          const end = pending.indexOf(PASTE_END);
+          // Without the end marker yet, keep what may be its first bytes for the next chunk
+          const upTo = end === -1 ? pending.length - partialMarker(pending, PASTE_END) : end;
+          paste(pending.slice(0, upTo));
+          if (end === -1) {
+            pending = pending.slice(upTo);
+            return;
+          }
+          pending = pending.slice(end + PASTE_END.length);
+          pasting = false;
+        } else if (pending.startsWith(PASTE_START)) {
+          pasting = true;
+          pending = pending.slice(PASTE_START.length);
+        } else if (pending.startsWith("\x1b")) {
+          const seq = ESCAPE_SEQUENCE.exec(pending);
+          if (!seq && PARTIAL_ESCAPE.test(pending)) return; // the rest comes in the next chunk
+          pending = pending.slice(seq ? seq[0].length : 1);
+        } else {
+          onKey(pending[0]);
+          pending = pending.slice(1);
+        }
+      }
+    };
+    stdin.on("data", onData);
+  });
+}
+
+/** Line-mode fallback for secrets: the terminal driver echoes, so turn its echo off */
+function readHiddenLine(rl: RL, q: string): Promise<string> {
+  const stty = (arg: string) => spawnSync("stty", [arg], { stdio: ["inherit", "ignore", "ignore"] });
+  stty("-echo");
+  return new Promise((resolve) => {
+    rl.question(q, (ans) => {
+      stty("echo");
+      console.log("");
+      resolve(ans.trim());
+    });
+  });
+}
+
+/**
+ * Read one line. If secret=true, mask the input (see readMaskedSecret).
+ */
+function readAnswer(rl: RL, q: string, secret: boolean): Promise<string> {
   return new Promise((resolve, reject) => {
     // When readline closes (Ctrl+C in line mode, or stdin EOF), reject with AbortError.
     const onClose = () => reject(new AbortError());
@@ -92,81 +404,117 @@ export function ask(rl: RL, q: string, secret = false): Promise<string> {
         rl.question(q, (ans) => { cleanup(); resolve(ans.trim()); });
         return;
       }
-
-      // Hide input for secrets with proper cleanup
-      process.stdout.write(q);
-      const stdin = process.stdin;
-      const oldRawMode = stdin.isRaw;
-      
-      const restoreMode = () => {
-        try {
-          if (stdin.isTTY) stdin.setRawMode(oldRawMode ?? false);
-        } catch {
-          // Ignore errors during cleanup
-        }
-      };
-      
-      if (stdin.isTTY) {
-        try {
-          stdin.setRawMode(true);
-        } catch {
-          // Fall back to non-secret mode if raw mode fails
-          rl.question("", (ans) => { cleanup(); resolve(ans.trim()); });
-          return;
-        }
-      }
-      
-      let input = "";
-      const onData = (char: Buffer) => {
-        const c = char.toString();
-        if (c === "\n" || c === "\r") {
-          stdin.removeListener("data", onData);
-          restoreMode();
-          console.log("");
-          cleanup();
-          resolve(input.trim());
-        } else if (c === "\x03") { // Ctrl+C
-          stdin.removeListener("data", onData);
-          restoreMode();
-          console.log("");
-          cleanup();
-          reject(new AbortError());
-        } else if (c === "\x7f" || c === "\b") { // Backspace
-          input = input.slice(0, -1);
-        } else if (c.charCodeAt(0) >= 32 && c.charCodeAt(0) < 127) {
-          // Only accept printable ASCII (filter out arrow keys, escape sequences, etc.)
-          input += c;
-        }
-        // Silently ignore non-printable characters
-      };
-      stdin.on("data", onData);
+      readMaskedSecret(rl, q).then(
+        (ans) => { cleanup(); resolve(ans); },
+        (err) => { cleanup(); reject(err); },
+      );
     } else {
-      rl.question(q, (ans) => { cleanup(); resolve(ans.trim()); });
+      // F1 shows the stage help, then puts the prompt (and what was typed) back.
+      const onKeypress = (_s: string, key?: { name?: string }) => {
+        if (key?.name !== "f1" || !stageHelp) return;
+        stageHelp();
+        rl.prompt(true);
+      };
+      const helpKey = Boolean(stageHelp && process.stdin.isTTY);
+      if (helpKey) process.stdin.on("keypress", onKeypress);
+      rl.question(q, (ans) => {
+        if (helpKey) process.stdin.removeListener("keypress", onKeypress);
+        cleanup();
+        resolve(ans.trim());
+      });
     }
   });
 }
 
+/**
+ * ask() for questions whose answers are numbers or y/n, where "h" can't be
+ * an answer and shows the stage help instead.
+ */
+async function askOrHelp(rl: RL, q: string): Promise<string> {
+  for (;;) {
+    const answer = await ask(rl, q);
+    if (!stageHelp || answer.toLowerCase() !== "h") return answer;
+    stageHelp();
+  }
+}
+
 /**
  * Yes/No prompt with default.
  */
 export async function askYN(rl: RL, q: string, defaultYes = false): Promise<boolean> {
   const suffix = defaultYes ? "[Y/n]" : "[y/N]";
-  const ans = await ask(rl, `${q} ${suffix}: `);
+  const ans = await askOrHelp(rl, `${q} ${suffix}: `);
   if (!ans) return defaultYes;
   return ans.toLowerCase().startsWith("y");
 }
 
 /**
- * Select from numbered options.
+ * Select from numbered options. With defaultIndex, Enter picks that option.
+ * With docsUrl, answering "d" opens the docs and asks again.
  */
-export async function selectOption(rl: RL, prompt: string, options: string[]): Promise<number> {
+export async function selectOption(
+  rl: RL,
+  prompt: string,
+  options: string[],
+  defaultIndex?: number,
+  docsUrl?: string,
+): Promise<number> {
   console.log(prompt);
   options.forEach((opt, i) => console.log(`  ${i + 1}) ${opt}`));
+  if (docsUrl) console.log(`${COLORS.DIM}  ${t("prompt.docsHint")}${COLORS.NC}`);
+  const onEnter = defaultIndex !== undefined ? t("prompt.enterFor", { n: defaultIndex + 1 }) : "";
   while (true) {
-    const ans = await ask(rl, `Pick a number [1-${options.length}]: `);
+    const ans = await askOrHelp(rl, t("prompt.pickNumber", { count: options.length, onEnter }));
+    if (!ans && defaultIndex !== undefined) return defaultIndex;
+    if (docsUrl && ans.toLowerCase() === "d") {
+      openDocs(docsUrl);
+      continue;
+    }
     const num = parseInt(ans, 10);
     if (num >= 1 && num <= options.length) return num - 1;
-    warn(`Please type a number between 1 and ${options.length}.`);
+    warn(t("prompt.numberRange", { count: options.length }));
+  }
+}
+
+/**
+ * Pick any number of numbered options, each with an optional detail line.
+ * Accepts "1,3", ranges ("2-5"), "all", "none", or Enter for `defaults`.
+ * Returns the chosen indices in list order.
+ */
+export async function askMultiSelectWithDetails(
+  rl: RL,
+  prompt: string,
+  options: { label: string; detail?: string }[],
+  defaults: number[] = [],
+): Promise<number[]> {
+  console.log(prompt);
+  options.forEach((opt, i) => {
+    console.log(`  ${i + 1}) ${opt.label}`);
+    if (opt.detail) console.log(`     ${opt.detail}`);
+  });
+  const onEnter = defaults.length > 0
+    ? t("prompt.enterForList", { list: defaults.map((i) => i + 1).join(",") })
+    : t("prompt.enterForNone");
+  while (true) {
+    const ans = (await ask(rl, t("prompt.pickNumbers", { onEnter }))).toLowerCase();
+    if (!ans) return [...defaults];
+    if (ans === "none") return [];
+    if (ans === "all") return options.map((_, i) => i);
+
+    const picked = new Set<number>();
+    let valid = true;
+    for (const part of ans.split(/[\s,]+/).filter(Boolean)) {
+      const range = /^(\d+)(?:-(\d+))?$/.exec(part);
+      const from = range ? parseInt(range[1], 10) : NaN;
+      const to = range?.[2] ? parseInt(range[2], 10) : from;
+      if (!(from >= 1 && to <= options.length && from <= to)) {
+        valid = false;
+        break;
+      }
+      for (let n = from; n <= to; n++) picked.add(n - 1);
+    }
+    if (valid) return [...picked].sort((a, b) => a - b);
+    warn(t("prompt.numbersRange", { count: options.length }));
   }
 }
 
@@ -179,92 +527,6 @@ export const DEFAULT_MODELS: Record<LLMProviderId, string> = {
   openai: "gpt-5.2",
   "openai-codex": "gpt-5.2",
   "openai-compatible": "llama3.2",
+  "amazon-bedrock": "global.anthropic.claude-sonnet-4-5-20250929-v1:0",
+  "azure-openai": "gpt-4o",
 };
-
-// ─────────────────────────────────────────────────────────────────────────────
-// Existing config detection
-// ─────────────────────────────────────────────────────────────────────────────
-
-export interface ExistingConfig {
-  anthropicKey?: string;
-  anthropicToken?: string;
-  openaiKey?: string;
-  discordToken?: string;
-  telegramToken?: string;
-  gatewayToken?: string;
-  hasOAuthAnthro?: boolean;
-  hasOAuthCodex?: boolean;
-  oauthCodexExpires?: number;
-  anthropicTokenValid?: boolean;
-}
-
-/**
- * Detect existing configuration from secrets.yaml and auth directory.
- * @param configDir Directory containing secrets.yaml and auth/
- */
-export function detectExistingConfig(configDir: string): ExistingConfig | null {
-  const secretsPath = join(configDir, "secrets.yaml");
-  const authDir = join(configDir, "auth");
-  
-  if (!existsSync(secretsPath) && !existsSync(authDir)) {
-    return null;
-  }
-  
-  const result: ExistingConfig = {};
-  
-  // Parse secrets.yaml
-  if (existsSync(secretsPath)) {
-    try {
-      const content = readFileSync(secretsPath, "utf-8");
-      
-      // Anthropic
-      const anthroKeyMatch = content.match(/^anthropic:\s*\n\s+apiKey:\s*"?([^"\n]+)"?/m);
-      if (anthroKeyMatch?.[1] && anthroKeyMatch[1] !== '""') {
-        result.anthropicKey = anthroKeyMatch[1];
-      }
-      const anthroTokenMatch = content.match(/^anthropic:\s*\n(?:\s+apiKey:[^\n]*\n)?\s+token:\s*"?([^"\n]+)"?/m);
-      if (anthroTokenMatch?.[1] && anthroTokenMatch[1] !== '""') {
-        result.anthropicToken = anthroTokenMatch[1];
-      }
-      
-      // OpenAI
-      const openaiMatch = content.match(/^openai:\s*\n\s+apiKey:\s*"?([^"\n]+)"?/m);
-      if (openaiMatch?.[1] && openaiMatch[1] !== '""') {
-        result.openaiKey = openaiMatch[1];
-      }
-      
-      // Discord
-      const discordMatch = content.match(/^discord:\s*\n\s+token:\s*"?([^"\n]+)"?/m);
-      if (discordMatch?.[1] && discordMatch[1] !== '""') {
-        result.discordToken = discordMatch[1];
-      }
-      
-      // Telegram
-      const telegramMatch = content.match(/^telegram:\s*\n\s+token:\s*"?([^"\n]+)"?/m);
-      if (telegramMatch?.[1] && telegramMatch[1] !== '""') {
-        result.telegramToken = telegramMatch[1];
-      }
-      
-      // Gateway
-      const gatewayMatch = content.match(/^gateway:\s*\n\s+token:\s*"?([^"\n]+)"?/m);
-      if (gatewayMatch?.[1] && gatewayMatch[1] !== '""') {
-        result.gatewayToken = gatewayMatch[1];
-      }
-    } catch {
-      // Ignore parse errors
-    }
-  }
-  
-  // Check OAuth tokens
-  if (existsSync(authDir)) {
-    result.hasOAuthAnthro = existsSync(join(authDir, "auth-anthropic.json"));
-    result.hasOAuthCodex = existsSync(join(authDir, "auth-openai-codex.json"));
-  }
-  
-  // Return null if nothing found
-  if (Object.keys(result).length === 0) {
-    return null;
-  }
-  
-  return result;
-}
@@ -0,0 +1,121 @@
+/**
+ * Step module: who may talk to the bot, and where.
+ *
+ * Discord and Slack channel and member allowlists, and Telegram user
+ * IDs, checked for format as they're typed so a pasted username or channel
+ * link doesn't end up in app.yaml. The member and user IDs also seed the
+ * write-tool allowlist in the security step.
+ */
+
+import { createInterface } from "node:readline";
+import type { AppConfig } from "../types.js";
+import { ask, header, info, success, warn, error } from "../shared.js";
+import type { UserAllowLists } from "./types.js";
+import { t, type MessageKey } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export type IdKind = "discord" | "telegram" | "slack-member" | "slack-channel";
+
+// Same rules as `owliabot validate` (config/validate.ts)
+const ID_PATTERNS: Record<IdKind, RegExp> = {
+  discord: /^\d{17,20}$/,
+  telegram: /^\d+$/,
+  "slack-member": /^[UW][A-Z0-9]{6,}$/,
+  "slack-channel": /^[CG][A-Z0-9]{6,}$/,
+};
+
+const ID_HINTS: Record<IdKind, MessageKey> = {
+  discord: "access.hint.discord",
+  telegram: "access.hint.telegram",
+  "slack-member": "access.hint.slackMember",
+  "slack-channel": "access.hint.slackChannel",
+};
+
+/** Docs offered on the ID questions (answer "d"): where to find each kind of ID */
+export const ID_DOCS: Record<IdKind, string> = {
+  discord: "https://support.discord.com/hc/en-us/articles/206346498",
+  telegram: "https://github.com/owliabot/owliabot/blob/main/docs/docker-install.md#step-2-run-interactive-onboard",
+  "slack-member": "https://github.com/owliabot/owliabot/blob/main/docs/slack-setup.md",
+  "slack-channel": "https://github.com/owliabot/owliabot/blob/main/docs/slack-setup.md",
+};
+
+/**
+ * Split a comma/space separated answer into IDs, reporting the ones that
+ * don't look like IDs of that kind.
+ */
+export function parseIdList(answer: string, kind: IdKind): { ids: string[]; invalid: string[] } {
+  const parts = answer.split(/[\s,]+/).map((s) => s.trim()).filter(Boolean);
+  const ids = [...new Set(parts.filter((p) => ID_PATTERNS[kind].test(p)))];
+  const invalid = parts.filter((p) => !ID_PATTERNS[kind].test(p));
+  return { ids, invalid };
+}
+
+/**
+ * Ask for a list of IDs, repeating the question until every entry is valid.
+ * An empty answer means "no restriction" and returns [].
+ */
+export async function askIdList(rl: RL, question: string, kind: IdKind): Promise<string[]> {
+  for (;;) {
+    const { ids, invalid } = parseIdList(await ask(rl, question, false, ID_DOCS[kind]), kind);
+    if (invalid.length === 0) return ids;
+    error(t("access.invalid", { ids: invalid.join(", "), hint: t(ID_HINTS[kind]) }));
+  }
+}
+
+/**
+ * Discord part of the Access stage: channels the bot answers in and members
+ * allowed to talk to it. Empty answers keep the defaults (any channel where
+ * it's mentioned, any member). `pickChannels` offers the channels by name
+ * (see discord-picker.ts); typing IDs is the fallback when it returns null.
+ */
+export async function configureDiscordAccess(
+  rl: RL,
+  config: AppConfig,
+  userAllowLists: UserAllowLists,
+  pickChannels: ((rl: RL) => Promise<string[] | null>) | null = null,
+): Promise<void> {
+  header(t("access.header"));
+  info(t("access.intro"));
+
+  const channels = (pickChannels && (await pickChannels(rl)))
+    ?? (await askIdList(rl, t("access.discord.channels"), "discord"));
+  const members = await askIdList(rl, t("access.discord.members"), "discord");
+
+  config.discord = {
+    ...config.discord,
+    channelAllowList: channels,
+    ...(members.length > 0 && { memberAllowList: members }),
+  };
+  userAllowLists.discord = members;
+
+  if (channels.length > 0 && !pickChannels) success(t("access.discord.channelsSet", { ids: channels.join(", ") }));
+  if (members.length > 0) success(t("access.discord.membersSet", { ids: members.join(", ") }));
+}
+
+/**
+ * Slack part of the Access stage. Without a channel allowlist the bot only
+ * answers mentions and DMs.
+ */
+export async function configureSlackAccess(
+  rl: RL,
+  config: AppConfig,
+  userAllowLists: UserAllowLists,
+): Promise<void> {
+  header(t("access.slack.header"));
+  info(t("access.slack.intro"));
+
+  const channels = await askIdList(rl, t("access.slack.channels"), "slack-channel");
+  const members = await askIdList(rl, t("access.slack.members"), "slack-member");
+
+  config.slack = {
+    ...config.slack,
+    ...(channels.length > 0 && { channelAllowList: channels }),
+    ...(members.length > 0 && { memberAllowList: members }),
+  };
+  userAllowLists.slack = members;
+
+  if (channels.length > 0) success(t("access.slack.channelsSet", { ids: channels.join(", ") }));
+  if (members.length > 0) success(t("access.slack.membersSet", { ids: members.join(", ") }));
+  else warn(t("access.slack.noMembers"));
+}
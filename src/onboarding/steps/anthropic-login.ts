@@ -0,0 +1,51 @@
+/**
+ * Step module: browser login for Anthropic, like "Start OAuth flow now?" for Codex.
+ *
+ * Anthropic credentials come from `claude setup-token` (Claude Code CLI):
+ * it opens the browser, signs in with the Claude subscription and prints a
+ * long-lived sk-ant-oat01- token. When the CLI is installed we run it in
+ * the terminal, and the user pastes the token at the usual prompt. The
+ * token lives in secrets.yaml like a pasted one; there is no separate auth
+ * file for Anthropic.
+ */
+
+import { execFileSync, spawnSync } from "node:child_process";
+
+/** Runs the login and reports whether it finished successfully */
+export type ClaudeLogin = () => boolean;
+
+/**
+ * Whether the `claude` CLI can be started.
+ */
+export function hasClaudeCli(
+  exec: (cmd: string, args: string[]) => string = (cmd, args) =>
+    execFileSync(cmd, args, { stdio: "pipe", encoding: "utf-8", timeout: 5_000 }),
+): boolean {
+  try {
+    exec("claude", ["--version"]);
+    return true;
+  } catch {
+    return false;
+  }
+}
+
+/**
+ * Run `claude setup-token` attached to this terminal.
+ */
+export function runClaudeSetupToken(
+  spawn: (cmd: string, args: string[]) => { status: number | null } = (cmd, args) =>
+    spawnSync(cmd, args, { stdio: "inherit" }),
+): boolean {
+  return spawn("claude", ["setup-token"]).status === 0;
+}
+
+/**
+ * The login to offer, or null when it can't run here (no terminal, or the
+ * Claude CLI isn't installed).
+ */
+export function defaultClaudeLogin(
+  interactive: boolean = Boolean(process.stdin.isTTY),
+  available: () => boolean = () => hasClaudeCli(),
+): ClaudeLogin | null {
+  return interactive && available() ? () => runClaudeSetupToken() : null;
+}
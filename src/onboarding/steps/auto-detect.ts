@@ -0,0 +1,47 @@
+/**
+ * Step module: preselect answers from what the host already has.
+ *
+ * API keys exported in the environment suggest the provider, bot tokens in
+ * the environment or an existing secrets.yaml suggest the chat platform.
+ * The guess is only a default: the option is tagged "(auto-detected)" and
+ * Enter accepts it.
+ */
+
+import type { DetectedConfig } from "./config-detection.js";
+
+export const AUTO_DETECTED_TAG = "(auto-detected)";
+
+/**
+ * Index into the provider menu of askProviders(), or undefined.
+ * Both Anthropic and OpenAI keys → "Multiple providers".
+ */
+export function detectProviderChoice(env: NodeJS.ProcessEnv = process.env): number | undefined {
+  const anthropic = Boolean(env.ANTHROPIC_API_KEY);
+  const openai = Boolean(env.OPENAI_API_KEY);
+  if (anthropic && openai) return 4;
+  if (anthropic) return 0;
+  if (openai) return 1;
+  return undefined;
+}
+
+/**
+ * Index into the chat menu of askChannels(), or undefined.
+ */
+export function detectChannelChoice(
+  existing: DetectedConfig | null,
+  env: NodeJS.ProcessEnv = process.env,
+): number | undefined {
+  const discord = Boolean(existing?.discordToken || env.DISCORD_BOT_TOKEN);
+  const telegram = Boolean(existing?.telegramToken || env.TELEGRAM_BOT_TOKEN);
+  const slack = Boolean(existing?.slackBotToken || env.SLACK_BOT_TOKEN);
+  if (discord && telegram) return 2;
+  if (discord) return 0;
+  if (telegram) return 1;
+  if (slack) return 3;
+  return undefined;
+}
+
+/** Append the auto-detected tag to the chosen option's label. */
+export function tagAutoDetected(options: string[], index: number | undefined): string[] {
+  return options.map((label, i) => (i === index ? `${label} ${AUTO_DETECTED_TAG}` : label));
+}
@@ -0,0 +1,117 @@
+/**
+ * Step module: Azure OpenAI provider.
+ *
+ * Azure serves OpenAI models per deployment: requests go to
+ * <endpoint>/openai/deployments/<deployment>/chat/completions?api-version=...
+ * with an `api-key` header, so the openai-compatible path (one base URL,
+ * bearer auth) doesn't fit. The provider block keeps the parts apart:
+ *
+ *   - id: azure-openai
+ *     baseUrl: https://my-resource.openai.azure.com   # resource endpoint
+ *     model: gpt-4o-prod                              # deployment name
+ *     apiVersion: "2024-10-21"
+ *     apiKey: secrets                                 # or env (AZURE_OPENAI_API_KEY)
+ */
+
+import { createInterface } from "node:readline";
+import type { ProviderConfig } from "../types.js";
+import { info, success, warn, ask } from "../shared.js";
+import type { ProviderSetupState } from "./types.js";
+import { askCredential } from "./placeholder-credentials.js";
+import { presetModel } from "./model-presets.js";
+import { t } from "../i18n.js";
+
+/** What detection found for an earlier Azure OpenAI setup */
+export interface AzureOpenAIDetected {
+  apiKey: string;
+  endpoint: string;
+  deployment: string;
+  apiVersion?: string;
+}
+
+/** Written into app.yaml; the runtime uses the same default when it's missing */
+export const AZURE_OPENAI_API_VERSION = "2024-10-21";
+
+const AZURE_HOST = /\.(openai\.azure\.com|cognitiveservices\.azure\.com|openai\.azure\.us)$/i;
+
+/**
+ * The resource endpoint without a trailing slash or /openai suffix, or null
+ * when it isn't an https URL.
+ */
+export function normalizeAzureEndpoint(value: string): string | null {
+  try {
+    const url = new URL(value.trim());
+    if (url.protocol !== "https:") return null;
+    return `${url.origin}${url.pathname.replace(/\/+$/, "").replace(/\/openai$/, "")}`;
+  } catch {
+    return null;
+  }
+}
+
+/** Whether the endpoint is on an Azure OpenAI host (proxies in front of it are allowed too) */
+export function isAzureOpenAIHost(endpoint: string): boolean {
+  try {
+    return AZURE_HOST.test(new URL(endpoint).hostname);
+  } catch {
+    return false;
+  }
+}
+
+/** API versions look like 2024-10-21 or 2025-01-01-preview */
+export function isAzureApiVersion(value: string): boolean {
+  return /^\d{4}-\d{2}-\d{2}(-preview)?$/.test(value);
+}
+
+async function askEndpoint(rl: ReturnType<typeof createInterface>): Promise<string | null> {
+  for (;;) {
+    const answer = await ask(rl, t("provider.azure.endpoint"));
+    if (!answer.trim()) return null;
+    const endpoint = normalizeAzureEndpoint(answer);
+    if (!endpoint) {
+      warn(t("provider.azure.httpsOnly"));
+      continue;
+    }
+    if (!isAzureOpenAIHost(endpoint)) info(t("provider.azure.notAzure"));
+    return endpoint;
+  }
+}
+
+export async function maybeConfigureAzureOpenAI(
+  rl: ReturnType<typeof createInterface>,
+  state: ProviderSetupState,
+  aiChoice: number,
+): Promise<void> {
+  if (aiChoice !== 6) return;
+
+  console.log("");
+  info(t("provider.azure.whereKeys"));
+  info(t("provider.azure.whereDeployment"));
+  const endpoint = await askEndpoint(rl);
+  if (!endpoint) return;
+
+  const defaultDeployment = presetModel(state.modelPresets, "azure-openai");
+  const deployment = (await ask(rl, t("provider.azure.deployment", { deployment: defaultDeployment }))).trim() || defaultDeployment;
+
+  let apiVersion = "";
+  while (!apiVersion) {
+    const answer = (await ask(rl, t("provider.azure.apiVersion", { version: AZURE_OPENAI_API_VERSION }))).trim() || AZURE_OPENAI_API_VERSION;
+    if (isAzureApiVersion(answer)) apiVersion = answer;
+    else warn(t("provider.azure.apiVersionFormat"));
+  }
+
+  const apiKey = await askCredential(rl, t("provider.azure.key"), "azure-openai");
+  if (apiKey) {
+    state.secrets["azure-openai"] = { apiKey };
+    success(t("provider.azure.keySaved"));
+  }
+
+  state.providers.push({
+    id: "azure-openai",
+    model: deployment,
+    baseUrl: endpoint,
+    apiVersion,
+    apiKey: apiKey ? "secrets" : "env",
+    priority: state.priority++,
+  } as ProviderConfig);
+  success(t("provider.azure.configured", { deployment, endpoint }));
+}
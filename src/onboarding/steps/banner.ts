@@ -0,0 +1,83 @@
+/**
+ * Step module: which banner the wizard opens with.
+ *
+ * The ASCII-art wordmark needs 44 columns and reads as noise in terminals
+ * set up for non-Latin scripts (CJK fonts often widen the backslashes and
+ * break the art). There, and in narrow terminals, a one-line text header is
+ * printed instead. Distributions can ship their own banner text: a
+ * `branding/banner.txt` in the package root replaces the wordmark, and
+ * OWLIABOT_BANNER_FILE points at one for a single install.
+ */
+
+import { existsSync, readFileSync } from "node:fs";
+import { dirname, join } from "node:path";
+import { fileURLToPath } from "node:url";
+import { BANNER_ART } from "../shared.js";
+import { detectLocale } from "./model-presets.js";
+
+/** Banner asset a distribution can add to the package (none ships by default) */
+export const BANNER_ASSET = join("branding", "banner.txt");
+
+export const TEXT_BANNER = ["━━━ OwliaBot ━━━"];
+
+/** Languages whose terminals get the text header instead of the wordmark */
+const NON_LATIN_LANGS = new Set([
+  "zh", "ja", "ko", "ru", "uk", "be", "bg", "sr", "mk", "kk", "el",
+  "ar", "fa", "ur", "he", "yi", "hi", "mr", "ne", "bn", "ta", "te", "th", "lo", "my", "km", "ka", "hy", "am",
+]);
+
+/** Longest banner a custom file may have, so a wrong path can't flood the screen */
+const MAX_CUSTOM_LINES = 20;
+
+function packageRoot(): string {
+  return join(dirname(fileURLToPath(import.meta.url)), "..", "..", "..");
+}
+
+/**
+ * Custom banner lines: OWLIABOT_BANNER_FILE, else the packaged asset.
+ * Null when neither exists or the file is empty.
+ */
+export function readCustomBanner(
+  env: NodeJS.ProcessEnv = process.env,
+  root: string = packageRoot(),
+): string[] | null {
+  const path = env.OWLIABOT_BANNER_FILE?.trim() || join(root, BANNER_ASSET);
+  if (!existsSync(path)) return null;
+  try {
+    const lines = readFileSync(path, "utf-8").replace(/\s+$/, "").split("\n").slice(0, MAX_CUSTOM_LINES);
+    return lines.some((l) => l.trim()) ? lines : null;
+  } catch {
+    return null;
+  }
+}
+
+export function usesNonLatinScript(locale: string | undefined): boolean {
+  const lang = locale?.split("-")[0]?.toLowerCase();
+  return Boolean(lang && NON_LATIN_LANGS.has(lang));
+}
+
+/**
+ * The lines to print: a custom banner, else the wordmark when it fits and
+ * the locale is Latin-script, else the text header.
+ */
+export function chooseBannerLines(opts: {
+  custom?: string[] | null;
+  /** Terminal width; undefined when not a TTY */
+  columns?: number;
+  locale?: string;
+} = {}): string[] {
+  if (opts.custom) return opts.custom;
+  const width = Math.max(...BANNER_ART.map((l) => l.length));
+  if (opts.columns !== undefined && opts.columns < width) return TEXT_BANNER;
+  if (usesNonLatinScript(opts.locale)) return TEXT_BANNER;
+  return BANNER_ART;
+}
+
+/** chooseBannerLines() for this process */
+export function bannerLines(): string[] {
+  return chooseBannerLines({
+    custom: readCustomBanner(),
+    columns: process.stdout.columns,
+    locale: detectLocale(),
+  });
+}
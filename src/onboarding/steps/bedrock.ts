@@ -0,0 +1,93 @@
+/**
+ * Step module: AWS Bedrock provider.
+ *
+ * Bedrock signs requests with AWS credentials instead of an API key. Three
+ * ways to get them:
+ *   - env: AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY (or AWS_PROFILE) from
+ *     the environment → apiKey: env
+ *   - instance: the instance profile, ECS task role or IRSA the SDK finds on
+ *     its own → apiKey: none
+ *   - keys: an access key pair stored in secrets.yaml → apiKey: secrets
+ * The region goes into the provider block; the loader exports it as
+ * AWS_REGION when the environment doesn't set one.
+ */
+
+import { createInterface } from "node:readline";
+import type { ProviderConfig } from "../types.js";
+import { info, success, ask, selectOption } from "../shared.js";
+import type { ProviderSetupState } from "./types.js";
+import { askCredential } from "./placeholder-credentials.js";
+import { presetModel } from "./model-presets.js";
+import { t } from "../i18n.js";
+
+export type BedrockCredentialsMode = "env" | "instance" | "keys";
+
+export const BEDROCK_DEFAULT_REGION = "us-east-1";
+
+const AWS_REGION = /^[a-z]{2}(-gov)?-[a-z]+-\d$/;
+
+/** Whether a string looks like an AWS region ("us-east-1", "us-gov-west-1") */
+export function isAwsRegion(value: string): boolean {
+  return AWS_REGION.test(value);
+}
+
+/** apiKey value in app.yaml for each credentials mode */
+export function bedrockApiKey(mode: BedrockCredentialsMode): string {
+  if (mode === "keys") return "secrets";
+  return mode === "env" ? "env" : "none";
+}
+
+async function askRegion(rl: ReturnType<typeof createInterface>, fallback: string): Promise<string> {
+  for (;;) {
+    const region = (await ask(rl, t("provider.bedrock.region", { region: fallback }))).trim() || fallback;
+    if (isAwsRegion(region)) return region;
+    info(t("provider.bedrock.badRegion"));
+  }
+}
+
+export async function maybeConfigureBedrock(
+  rl: ReturnType<typeof createInterface>,
+  state: ProviderSetupState,
+  aiChoice: number,
+  env: NodeJS.ProcessEnv = process.env,
+): Promise<void> {
+  if (aiChoice !== 5) return;
+
+  console.log("");
+  info(t("provider.bedrock.intro"));
+  const region = await askRegion(rl, env.AWS_REGION || env.AWS_DEFAULT_REGION || BEDROCK_DEFAULT_REGION);
+
+  const defaultModel = presetModel(state.modelPresets, "amazon-bedrock");
+  const model = (await ask(rl, t("provider.bedrock.model", { model: defaultModel }))).trim() || defaultModel;
+
+  const modes: BedrockCredentialsMode[] = ["env", "instance", "keys"];
+  const mode = modes[await selectOption(rl, t("provider.bedrock.credentials"), [
+    t("provider.bedrock.option.env"),
+    t("provider.bedrock.option.instance"),
+    t("provider.bedrock.option.keys"),
+  ], env.AWS_ACCESS_KEY_ID || env.AWS_PROFILE ? 0 : undefined)];
+
+  if (mode === "keys") {
+    const accessKeyId = await askCredential(rl, t("provider.bedrock.accessKeyId"), "amazon-bedrock");
+    const secretAccessKey = await askCredential(rl, t("provider.bedrock.secretAccessKey"), "amazon-bedrock", true);
+    if (accessKeyId && secretAccessKey) {
+      state.secrets["amazon-bedrock"] = { accessKeyId, secretAccessKey };
+      success(t("provider.bedrock.keysSaved"));
+    } else {
+      info(t("provider.bedrock.noKeys"));
+    }
+  } else if (mode === "instance") {
+    info(t("provider.bedrock.instanceRole"));
+  }
+
+  // Keys mode without keys entered falls back to the environment.
+  const apiKey = bedrockApiKey(mode === "keys" && !state.secrets["amazon-bedrock"] ? "env" : mode);
+  state.providers.push({
+    id: "amazon-bedrock",
+    model,
+    region,
+    apiKey,
+    priority: state.priority++,
+  } as ProviderConfig);
+  success(t("provider.bedrock.configured", { model, region }));
+}
@@ -0,0 +1,149 @@
+/**
+ * Step module: Docker bind-mount path checks.
+ *
+ * The config dir is bind-mounted into the container. Some locations look fine
+ * on the host but fail (or misbehave) only at `docker compose up`: relative
+ * paths, network filesystems, and folders Docker Desktop doesn't share.
+ * Catch those before writing anything and explain the alternatives.
+ *
+ * In install.sh's onboarding container the process sees the container's
+ * mounts and always runs on Linux, so install.sh passes in the host's view
+ * (see hostBindPath).
+ */
+
+import { createInterface } from "node:readline";
+import { readFileSync } from "node:fs";
+import { isAbsolute, resolve } from "node:path";
+import { warn, info, askYN, AbortError } from "../shared.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export interface BindPathCheckResult {
+  ok: boolean;
+  problems: string[];
+  suggestions: string[];
+}
+
+export interface BindPathCheckOptions {
+  platform?: NodeJS.Platform;
+  /** Contents of /proc/mounts (Linux); read from disk when omitted */
+  mounts?: string | null;
+  /** Filesystem type of the config dir when already known; /proc/mounts is not read then */
+  fsType?: string | null;
+}
+
+/** Filesystems that commonly break bind mounts (uid squashing, no xattrs, locking). */
+const NETWORK_FS_TYPES = new Set([
+  "nfs", "nfs4", "cifs", "smb3", "smbfs", "fuse.sshfs", "afs", "ceph", "fuse.rclone", "davfs",
+]);
+
+/** Windows drive mounts under WSL: slow, and permissions don't map to Linux uids. */
+const WSL_DRIVE_FS_TYPES = new Set(["9p", "drvfs"]);
+
+/** Docker Desktop for Mac shares these roots by default (Settings → Resources → File sharing). */
+const DOCKER_DESKTOP_MAC_SHARED = ["/Users", "/Volumes", "/private", "/tmp", "/var/folders"];
+
+function readProcMounts(): string | null {
+  try {
+    return readFileSync("/proc/mounts", "utf-8");
+  } catch {
+    return null;
+  }
+}
+
+/**
+ * Find the filesystem type of the mount containing `path` (longest mount-point prefix).
+ */
+export function findMountFsType(path: string, mounts: string): string | null {
+  let best: { mountPoint: string; fsType: string } | null = null;
+  for (const line of mounts.split("\n")) {
+    const [, rawMountPoint, fsType] = line.split(/\s+/);
+    if (!rawMountPoint || !fsType) continue;
+    // /proc/mounts escapes spaces as \040
+    const mountPoint = rawMountPoint.replace(/\\040/g, " ");
+    const within = mountPoint === "/" || path === mountPoint || path.startsWith(`${mountPoint}/`);
+    if (!within) continue;
+    if (!best || mountPoint.length >= best.mountPoint.length) best = { mountPoint, fsType };
+  }
+  return best?.fsType ?? null;
+}
+
+/**
+ * Check whether `configDir` can be bind-mounted into the OwliaBot container.
+ */
+export function checkDockerBindPath(configDir: string, opts: BindPathCheckOptions = {}): BindPathCheckResult {
+  const platform = opts.platform ?? process.platform;
+  const problems: string[] = [];
+  const suggestions: string[] = [];
+
+  if (!isAbsolute(configDir)) {
+    problems.push(t("bindPath.relative", { dir: configDir }));
+    suggestions.push(t("bindPath.useAbsolute", { path: resolve(configDir) }));
+    return { ok: false, problems, suggestions };
+  }
+
+  if (platform === "darwin") {
+    const shared = DOCKER_DESKTOP_MAC_SHARED.some((root) => configDir === root || configDir.startsWith(`${root}/`));
+    if (!shared) {
+      problems.push(t("bindPath.notShared", { dir: configDir, roots: DOCKER_DESKTOP_MAC_SHARED.join(", ") }));
+      suggestions.push(t("bindPath.addSharing"));
+    }
+  }
+
+  if (platform === "linux") {
+    const mounts = opts.fsType !== undefined ? null : opts.mounts === undefined ? readProcMounts() : opts.mounts;
+    const fsType = opts.fsType !== undefined ? opts.fsType : mounts ? findMountFsType(configDir, mounts) : null;
+    if (fsType && NETWORK_FS_TYPES.has(fsType)) {
+      problems.push(t("bindPath.networkFs", { dir: configDir, fsType }));
+      suggestions.push(t("bindPath.localDisk"));
+    } else if (fsType && WSL_DRIVE_FS_TYPES.has(fsType)) {
+      problems.push(t("bindPath.wslDrive", { dir: configDir, fsType }));
+      suggestions.push(t("bindPath.wslHome"));
+    }
+  }
+
+  return { ok: problems.length === 0, problems, suggestions };
+}
+
+/**
+ * The config dir to check and how: the host's, when install.sh passed it in
+ * (OWLIABOT_HOST_CONFIG_DIR, OWLIABOT_HOST_PLATFORM, OWLIABOT_HOST_CONFIG_FS),
+ * else `configDir` on this machine.
+ */
+export function hostBindPath(
+  configDir: string,
+  env: NodeJS.ProcessEnv = process.env,
+): { configDir: string; options: BindPathCheckOptions } {
+  const hostDir = env.OWLIABOT_HOST_CONFIG_DIR?.trim();
+  if (!hostDir) return { configDir, options: {} };
+  return {
+    configDir: hostDir,
+    options: {
+      // An unknown host platform skips the platform checks rather than passing as Linux
+      platform: (env.OWLIABOT_HOST_PLATFORM?.trim().toLowerCase() ?? "") as NodeJS.Platform,
+      fsType: env.OWLIABOT_HOST_CONFIG_FS?.trim() || null,
+    },
+  };
+}
+
+/**
+ * Explain bind-mount problems and ask whether to continue anyway.
+ * Throws AbortError when the user chooses to stop.
+ */
+export async function confirmDockerBindPath(
+  rl: RL,
+  configDir: string,
+  opts: BindPathCheckOptions = {},
+): Promise<void> {
+  const result = checkDockerBindPath(configDir, opts);
+  if (result.ok) return;
+
+  console.log("");
+  warn(t("bindPath.warning"));
+  for (const problem of result.problems) warn(`  ${problem}`);
+  for (const suggestion of result.suggestions) info(`  ${suggestion}`);
+
+  const proceed = await askYN(rl, t("bindPath.continue"), false);
+  if (!proceed) throw new AbortError("Config folder cannot be bind-mounted");
+}
@@ -0,0 +1,83 @@
+/**
+ * Step module: custom CA bundle for outbound HTTPS (`--ca-bundle`).
+ *
+ * Behind a corporate TLS-inspecting proxy every HTTPS call fails with
+ * "unable to get local issuer certificate" unless the proxy's CA is trusted.
+ * Node only reads extra CAs (NODE_EXTRA_CA_CERTS) at startup, so onboarding
+ * restarts itself with the bundle set; validation calls, model discovery and
+ * catalog fetches then trust it. The bundle is copied into the config dir and
+ * mounted read-only into the container, with NODE_EXTRA_CA_CERTS pointing at
+ * it, so the bot trusts the same certificates.
+ */
+
+import { copyFileSync, existsSync, readFileSync, realpathSync } from "node:fs";
+import { join, resolve } from "node:path";
+import { spawnSync } from "node:child_process";
+import { info, success } from "../shared.js";
+import { t } from "../i18n.js";
+
+export const CA_BUNDLE_FILE = "ca-bundle.pem";
+
+/** Where the bundle is mounted inside the container. */
+export const CONTAINER_CA_BUNDLE_PATH = "/etc/owliabot/ca-bundle.pem";
+
+/** --ca-bundle, else OWLIABOT_CA_BUNDLE, as an absolute path */
+export function resolveCaBundlePath(
+  flag: string | undefined,
+  env: NodeJS.ProcessEnv = process.env,
+): string | undefined {
+  const value = flag?.trim() || env.OWLIABOT_CA_BUNDLE?.trim();
+  return value ? resolve(value) : undefined;
+}
+
+/**
+ * Check the bundle is a readable PEM file with at least one certificate.
+ * Throws with a message naming the file otherwise.
+ */
+export function assertCaBundle(path: string): void {
+  if (!existsSync(path)) throw new Error(`CA bundle ${path} does not exist`);
+  const pem = readFileSync(path, "utf-8");
+  if (!pem.includes("-----BEGIN CERTIFICATE-----")) {
+    throw new Error(`CA bundle ${path} contains no PEM certificate (-----BEGIN CERTIFICATE-----)`);
+  }
+}
+
+/** True when this process already started with the bundle loaded */
+export function caBundleLoaded(path: string, env: NodeJS.ProcessEnv = process.env): boolean {
+  const loaded = env.NODE_EXTRA_CA_CERTS;
+  return Boolean(loaded) && resolve(loaded!) === path;
+}
+
+/**
+ * Run this command again with NODE_EXTRA_CA_CERTS set and exit with its
+ * status. Returns only when the bundle is already loaded.
+ */
+export function relaunchWithCaBundle(path: string, env: NodeJS.ProcessEnv = process.env): void {
+  if (caBundleLoaded(path, env)) return;
+  const child = spawnSync(process.execPath, [...process.execArgv, ...process.argv.slice(1)], {
+    stdio: "inherit",
+    env: { ...env, NODE_EXTRA_CA_CERTS: path, OWLIABOT_CA_BUNDLE: path },
+  });
+  if (child.error) throw child.error;
+  process.exit(child.status ?? 1);
+}
+
+/**
+ * Copy the bundle into the config dir (the container mounts it from there).
+ * Returns the copy's path; nothing is copied when it already is that file.
+ */
+export function installCaBundle(configDir: string, source: string): string {
+  const target = join(configDir, CA_BUNDLE_FILE);
+  const same = existsSync(target) && realpathSync(target) === realpathSync(source);
+  if (!same) copyFileSync(source, target);
+  success(t("caBundle.saved", { path: target }));
+  return target;
+}
+
+/**
+ * Native mode: the bot reads the bundle the same way, so it has to be in the
+ * environment of `owliabot start`.
+ */
+export function printCaBundleNextSteps(path: string): void {
+  info(t("caBundle.start", { command: `NODE_EXTRA_CA_CERTS=${path} owliabot start` }));
+}
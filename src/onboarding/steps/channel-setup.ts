@@ -1,5 +1,5 @@
 /**
- * Channel setup for onboarding (Discord, Telegram)
+ * Channel setup for onboarding (Discord, Telegram, Slack, webhook)
  */
 
 import { createInterface } from "node:readline";
@@ -7,26 +7,46 @@ import type { SecretsConfig } from "../secrets.js";
 import type { AppConfig } from "../types.js";
 import { ask, askYN, selectOption, info, success, warn, header } from "../shared.js";
 import type { DetectedConfig, ChannelResult, UserAllowLists } from "./types.js";
+import { promptValidDiscordToken } from "./discord-validation.js";
+import { promptValidTelegramToken } from "./telegram-validation.js";
+import { askCredential } from "./placeholder-credentials.js";
+import { askSlackTokens } from "./slack-setup.js";
+import { detectChannelChoice, tagAutoDetected } from "./auto-detect.js";
+import { ID_DOCS } from "./access-setup.js";
+import { t } from "../i18n.js";
+
+const DISCORD_GUIDE = "https://github.com/owliabot/owliabot/blob/main/docs/discord-setup.md";
+const BOTFATHER_URL = "https://t.me/BotFather";
+/** Docs offered on the chat platform choice (answer "d") */
+const CHANNEL_DOCS = "https://github.com/owliabot/owliabot/blob/main/docs/docker-install.md#step-2-run-interactive-onboard";
 
 type RL = ReturnType<typeof createInterface>;
 type TelegramGroups = NonNullable<NonNullable<AppConfig["telegram"]>["groups"]>;
 
 /**
  * Interactive prompt for chat channels.
+ * Entered tokens are checked live against the platform API on a terminal.
  */
 export async function askChannels(
   rl: RL,
   secrets: SecretsConfig,
   existing: DetectedConfig | null,
+  validateTokens: boolean = Boolean(process.stdin.isTTY),
+  env: NodeJS.ProcessEnv = process.env,
 ): Promise<ChannelResult> {
-  const chatChoice = await selectOption(rl, "Where should OwliaBot chat with you?", [
+  const detected = detectChannelChoice(existing, env);
+  const chatChoice = await selectOption(rl, t("channel.choose"), tagAutoDetected([
     "Discord",
     "Telegram",
-    "Both (Discord + Telegram)",
-  ]);
+    t("channel.option.both"),
+    "Slack",
+    t("channel.option.webhook"),
+  ], detected), detected, CHANNEL_DOCS);
 
   const discordEnabled = chatChoice === 0 || chatChoice === 2;
   const telegramEnabled = chatChoice === 1 || chatChoice === 2;
+  const slackEnabled = chatChoice === 3;
+  const webhookEnabled = chatChoice === 4;
   let discordToken = "";
   let telegramToken = "";
   let reuseTelegramConfig = false;
@@ -41,10 +61,10 @@ export async function askChannels(
 
     if (hasExistingTelegram) {
       console.log("");
-      info(`I found existing Telegram settings (allowed users: ${allowCount}, groups: ${groupCount}).`);
+      info(t("channel.telegram.found", { users: allowCount, groups: groupCount }));
       const reuse = await askYN(
         rl,
-        "Reuse your existing Telegram setup?",
+        t("channel.telegram.reuse"),
         true,
       );
       if (reuse) {
@@ -56,25 +76,27 @@ export async function askChannels(
           secrets.telegram = { token: existing.telegramToken };
           telegramToken = existing.telegramToken;
         }
-        success("Got it. I'll reuse your existing Telegram configuration.");
+        success(t("channel.telegram.reused"));
       }
     }
   }
 
   if (discordEnabled) {
     console.log("");
-    info("You'll find your bot token in the Discord developer portal: https://discord.com/developers/applications");
-    info("Guide: https://github.com/owliabot/owliabot/blob/main/docs/discord-setup.md");
-    info("Quick reminder: enable MESSAGE CONTENT INTENT, otherwise I won't receive messages.");
-    const token = await ask(
+    info(t("channel.discord.portal", { url: "https://discord.com/developers/applications" }));
+    info(t("channel.guide", { url: DISCORD_GUIDE }));
+    info(t("channel.discord.intent"));
+    const entered = await askCredential(
       rl,
-      "Paste your Discord bot token (or press Enter to do this later): ",
+      t("channel.discord.paste"),
+      "discord",
       true,
     );
+    const token = validateTokens ? await promptValidDiscordToken(rl, entered) : entered;
     if (token) {
       secrets.discord = { token };
       discordToken = token;
-      success("Got it. I'll use that Discord token.");
+      success(t("channel.discord.saved"));
     }
   }
 
@@ -82,25 +104,32 @@ export async function askChannels(
     // If we chose to reuse and a token exists, skip the token prompt.
     if (!(reuseTelegramConfig && telegramToken)) {
       console.log("");
-      info("Create a bot with BotFather: https://t.me/BotFather");
-      const token = await ask(
+      info(t("channel.telegram.botfather", { url: BOTFATHER_URL }));
+      const entered = await askCredential(
         rl,
-        "Paste your Telegram bot token (or press Enter to do this later): ",
+        t("channel.telegram.paste"),
+        "telegram",
         true,
       );
+      const token = validateTokens ? await promptValidTelegramToken(rl, entered) : entered;
       if (token) {
         secrets.telegram = { token };
         telegramToken = token;
-        success("Got it. I'll use that Telegram token.");
+        success(t("channel.telegram.saved"));
       }
     }
   }
 
+  const slackBotToken = slackEnabled ? await askSlackTokens(rl, secrets) : "";
+
   return {
     discordEnabled,
     telegramEnabled,
     discordToken,
     telegramToken,
+    slackEnabled,
+    slackBotToken,
+    webhookEnabled,
     reuseTelegramConfig,
     telegramAllowList,
     telegramGroups,
@@ -116,8 +145,8 @@ export async function configureDiscordConfig(
   userAllowLists: UserAllowLists,
 ): Promise<void> {
   header("Discord");
-  info("Quick checklist: View Channels, Send Messages, Send Messages in Threads, Read Message History");
-  info("Guide: https://github.com/owliabot/owliabot/blob/main/docs/discord-setup.md");
+  info(t("channel.discord.checklist"));
+  info(t("channel.guide", { url: DISCORD_GUIDE }));
   console.log("");
 
   // Default: allow all channels and all members
@@ -139,7 +168,7 @@ export async function configureTelegramConfig(
 ): Promise<void> {
   header("Telegram");
 
-  const telegramUserIds = await ask(rl, "Who can talk to me? (comma-separated Telegram user IDs; press Enter to skip): ");
+  const telegramUserIds = await ask(rl, t("channel.telegram.who"), false, ID_DOCS.telegram);
   const allowList = telegramUserIds.split(",").map((s) => s.trim()).filter(Boolean);
   userAllowLists.telegram = allowList;
 
@@ -148,7 +177,7 @@ export async function configureTelegramConfig(
   };
 
   if (allowList.length > 0) {
-    success(`I'll only respond to these Telegram user IDs: ${allowList.join(", ")}`);
+    success(t("channel.telegram.allowed", { ids: allowList.join(", ") }));
   }
 }
 
@@ -162,18 +191,20 @@ export async function getChannelsSetup(
   existing: DetectedConfig | null,
   reuseExisting: boolean,
 ): Promise<ChannelResult> {
-  header("Chat");
+  header(t("channel.header"));
 
-  if (reuseExisting && (existing?.discordToken || existing?.telegramToken)) {
+  if (reuseExisting && (existing?.discordToken || existing?.telegramToken || existing?.slackBotToken)) {
     let discordEnabled = false;
     let telegramEnabled = false;
+    let slackEnabled = false;
     let discordToken = "";
     let telegramToken = "";
+    let slackBotToken = "";
     let reuseTelegramConfig = false;
     let telegramAllowList: string[] | undefined;
     let telegramGroups: TelegramGroups | undefined;
 
-    success("Using your existing chat setup:");
+    success(t("channel.existing"));
     if (existing?.discordToken) {
       discordEnabled = true;
       discordToken = existing.discordToken;
@@ -188,8 +219,8 @@ export async function getChannelsSetup(
       const groupCount = existing.telegramGroups ? Object.keys(existing.telegramGroups).length : 0;
       if (allowCount > 0 || groupCount > 0) {
         console.log("");
-        info(`I found existing Telegram settings (allowed users: ${allowCount}, groups: ${groupCount}).`);
-        const reuse = await askYN(rl, "Reuse your existing Telegram setup?", true);
+        info(t("channel.telegram.found", { users: allowCount, groups: groupCount }));
+        const reuse = await askYN(rl, t("channel.telegram.reuse"), true);
         if (reuse) {
           reuseTelegramConfig = true;
           telegramAllowList = existing.telegramAllowList;
@@ -206,10 +237,11 @@ export async function getChannelsSetup(
         secrets.telegram = { token: telegramToken };
       } else {
         console.log("");
-        info("Create a bot with BotFather: https://t.me/BotFather");
-        const token = await ask(
+        info(t("channel.telegram.botfather", { url: BOTFATHER_URL }));
+        const token = await askCredential(
           rl,
-          "Paste your Telegram bot token (or press Enter to do this later): ",
+          t("channel.telegram.paste"),
+          "telegram",
           true,
         );
         if (token) {
@@ -219,8 +251,18 @@ export async function getChannelsSetup(
       }
     }
 
-    if (!discordToken && !telegramToken) {
-      warn("No chat token yet. You can add it later.");
+    if (existing?.slackBotToken) {
+      slackEnabled = true;
+      slackBotToken = existing.slackBotToken;
+      secrets.slack = {
+        botToken: slackBotToken,
+        ...(existing.slackAppToken && { appToken: existing.slackAppToken }),
+      };
+      info("  - Slack");
+    }
+
+    if (!discordToken && !telegramToken && !slackBotToken) {
+      warn(t("channel.noToken"));
     }
 
     // After our strict allowList policy, the bot rejects all messages when no
@@ -229,13 +271,13 @@ export async function getChannelsSetup(
     const hasDcAllow = !!(existing?.discordMemberAllowList && existing.discordMemberAllowList.length > 0);
     if (!hasTgAllow && !hasDcAllow) {
       console.log("");
-      warn("No allowList configured. The bot won't respond to anyone without one.");
+      warn(t("channel.noAllowList"));
       if (telegramEnabled) {
-        const ids = await ask(rl, "Enter your Telegram user IDs (comma-separated, or press Enter to skip): ", true);
+        const ids = await ask(rl, t("channel.telegram.ids"), true);
         const parsed = ids.split(",").map((s) => s.trim()).filter(Boolean);
         if (parsed.length > 0) {
           telegramAllowList = parsed;
-          success(`Telegram allowList: ${parsed.join(", ")}`);
+          success(t("channel.telegram.allowList", { ids: parsed.join(", ") }));
         }
       }
     }
@@ -245,6 +287,8 @@ export async function getChannelsSetup(
       telegramEnabled,
       discordToken,
       telegramToken,
+      slackEnabled,
+      slackBotToken,
       reuseTelegramConfig,
       telegramAllowList,
       telegramGroups,
@@ -252,8 +296,8 @@ export async function getChannelsSetup(
   }
 
   const ch = await askChannels(rl, secrets, existing);
-  if (!ch.discordToken && !ch.telegramToken) {
-    warn("No chat token yet. You can add it later.");
+  if (!ch.discordToken && !ch.telegramToken && !ch.slackBotToken && !ch.webhookEnabled) {
+    warn(t("channel.noToken"));
   }
   return ch;
 }
@@ -0,0 +1,101 @@
+/**
+ * Step module: copy the start command, gateway URL and gateway token to the
+ * clipboard at the end of onboarding.
+ *
+ * A local clipboard tool (pbcopy, wl-copy, xclip, xsel, clip) is used when
+ * there is one. Otherwise the text goes to the terminal as an OSC 52 escape,
+ * which most terminals put on the clipboard of the machine they run on;
+ * that also covers SSH sessions and install.sh's onboarding container.
+ */
+
+import { spawnSync } from "node:child_process";
+import type { createInterface } from "node:readline";
+import { selectOption, success, warn } from "../shared.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export interface ClipboardItem {
+  /** What is copied, e.g. "gateway token" */
+  label: string;
+  text: string;
+}
+
+export type ClipboardMethod = { kind: "command"; cmd: string } | { kind: "osc52" };
+
+/** Clipboard tools to try on this platform, in order */
+export function clipboardCommands(
+  platform: NodeJS.Platform = process.platform,
+  env: NodeJS.ProcessEnv = process.env,
+): { cmd: string; args: string[] }[] {
+  if (platform === "darwin") return [{ cmd: "pbcopy", args: [] }];
+  if (platform === "win32") return [{ cmd: "clip", args: [] }];
+  const commands: { cmd: string; args: string[] }[] = [];
+  if (env.WAYLAND_DISPLAY) commands.push({ cmd: "wl-copy", args: [] });
+  if (env.DISPLAY) {
+    commands.push({ cmd: "xclip", args: ["-selection", "clipboard"] });
+    commands.push({ cmd: "xsel", args: ["--clipboard", "--input"] });
+  }
+  return commands;
+}
+
+/** OSC 52 "set clipboard" escape for `text`, passed through tmux/screen when inside one */
+export function osc52Sequence(text: string, env: NodeJS.ProcessEnv = process.env): string {
+  const seq = `\x1b]52;c;${Buffer.from(text, "utf-8").toString("base64")}\x07`;
+  if (env.TMUX) return `\x1bPtmux;${seq.replace(/\x1b/g, "\x1b\x1b")}\x1b\\`;
+  if (env.TERM?.startsWith("screen")) return `\x1bP${seq}\x1b\\`;
+  return seq;
+}
+
+/**
+ * Put `text` on the clipboard. Returns how, or null when there is neither a
+ * clipboard tool nor a terminal to send OSC 52 to.
+ */
+export function copyToClipboard(
+  text: string,
+  options: {
+    commands?: { cmd: string; args: string[] }[];
+    run?: (cmd: string, args: string[], input: string) => boolean;
+    stdout?: Pick<NodeJS.WriteStream, "write" | "isTTY">;
+    env?: NodeJS.ProcessEnv;
+  } = {},
+): ClipboardMethod | null {
+  const run = options.run ?? ((cmd, args, input) => {
+    const result = spawnSync(cmd, args, { input, stdio: ["pipe", "ignore", "ignore"], timeout: 5_000 });
+    return !result.error && result.status === 0;
+  });
+  for (const { cmd, args } of options.commands ?? clipboardCommands()) {
+    if (run(cmd, args, text)) return { kind: "command", cmd };
+  }
+  const stdout = options.stdout ?? process.stdout;
+  if (!stdout.isTTY) return null;
+  stdout.write(osc52Sequence(text, options.env));
+  return { kind: "osc52" };
+}
+
+/**
+ * Offer to copy each item until the user picks "Done" (the default, so
+ * unattended runs pass straight through). Only on a terminal.
+ */
+export async function offerClipboardCopy(
+  rl: RL,
+  items: ClipboardItem[],
+  copy: (text: string) => ClipboardMethod | null = (text) => copyToClipboard(text),
+): Promise<void> {
+  if (items.length === 0 || !process.stdout.isTTY) return;
+  console.log("");
+  for (;;) {
+    const labels = [...items.map((item) => t("clipboard.copyItem", { label: item.label })), t("clipboard.done")];
+    const choice = await selectOption(rl, t("clipboard.ask"), labels, labels.length - 1);
+    if (choice === items.length) return;
+    const item = items[choice];
+    const method = copy(item.text);
+    if (!method) {
+      warn(t("clipboard.unavailable", { label: item.label }));
+    } else if (method.kind === "osc52") {
+      success(t("clipboard.osc52", { label: item.label }));
+    } else {
+      success(t("clipboard.copied", { label: item.label }));
+    }
+  }
+}
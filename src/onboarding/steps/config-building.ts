@@ -3,17 +3,25 @@
  */
 
 import { createInterface } from "node:readline";
-import { join } from "node:path";
+import { dirname, join } from "node:path";
 import type { AppConfig, ProviderConfig, MemorySearchConfig, SystemCapabilityConfig } from "../types.js";
 import type { SecretsConfig } from "../secrets.js";
 import { getWorkspacePath } from "./workspace-setup.js";
 import { getGatewayConfig } from "./gateway-setup.js";
 import { configureDiscordConfig } from "./configure-discord.js";
 import { configureTelegramConfig } from "./configure-telegram.js";
+import { configureDiscordAccess, configureSlackAccess } from "./access-setup.js";
+import { pickDiscordChannels } from "./discord-picker.js";
+import { discoverTelegramUserIds } from "./telegram-discovery.js";
 import { configureWriteToolsSecurity } from "./security-setup.js";
+import { configureWebhookConfig } from "./webhook-setup.js";
 import type { UserAllowLists } from "./types.js";
-import { info, header, askYN } from "../shared.js";
-import { playwrightServerConfig, playwrightSecurityOverrides } from "../../mcp/servers/playwright.js";
+import { info, success, header, askYN, askMultiSelectWithDetails } from "../shared.js";
+import { askCredential } from "./placeholder-credentials.js";
+import { askCustomMcpServer, type CustomMcpServer } from "./mcp-custom.js";
+import { checkMcpRuntimes } from "./mcp-runtime-check.js";
+import { getAvailablePresets, getPresetDescription } from "../../mcp/presets.js";
+import { t } from "../i18n.js";
 
 export function buildDefaultMemorySearchConfig(workspace: string): MemorySearchConfig {
   return {
@@ -72,47 +80,80 @@ export function deriveWriteToolAllowListFromConfig(config: AppConfig): string[]
   if (config.telegram?.allowList) {
     for (const id of config.telegram.allowList) ids.add(id);
   }
+  if (config.slack?.memberAllowList) {
+    for (const id of config.slack.memberAllowList) ids.add(id);
+  }
   return ids.size > 0 ? [...ids] : null;
 }
 
-/**
- * MCP server presets available during onboarding.
- */
-const MCP_PRESETS = [
-  { name: "Playwright", description: "Browser automation via @playwright/mcp", config: playwrightServerConfig },
-] as const;
+/** MCP presets enabled when the user just presses Enter */
+const DEFAULT_MCP_PRESETS = ["playwright"];
 
 /**
- * Prompt user to choose which MCP servers to enable.
+ * Prompt user to choose which MCP presets to enable. They are written as
+ * `mcp.presets`; the gateway expands them (with their security overrides)
+ * at startup. The GitHub token goes to secrets.yaml.
+ *
+ * The last option defines custom servers (`mcp.servers`). Custom servers
+ * already in app.yaml are kept unless the user drops them.
  */
 export async function configureMcpServers(
   rl: ReturnType<typeof createInterface>,
+  secrets: SecretsConfig,
+  existingServers: CustomMcpServer[] = [],
 ): Promise<AppConfig["mcp"] | undefined> {
-  header("MCP Servers");
-  info("MCP (Model Context Protocol) lets your bot use external tool servers.");
-  info("Available presets:\n");
-
-  const selected: (typeof MCP_PRESETS)[number]["config"][] = [];
-  const securityOverrides: Record<string, { level: string; confirmRequired?: boolean }> = {};
-
-  for (const preset of MCP_PRESETS) {
-    const enable = await askYN(rl, `Enable ${preset.name}? (${preset.description})`, true);
-    if (!enable) continue;
-
-    selected.push(preset.config);
-
-    // If user enables Playwright MCP, write the recommended security overrides
-    // directly into app.yaml so runtime doesn't have to guess.
-    if (preset.config.name === "playwright") {
-      Object.assign(securityOverrides, playwrightSecurityOverrides);
+  header(t("mcp.header"));
+  info(t("mcp.intro"));
+
+  const servers: CustomMcpServer[] = [];
+  if (existingServers.length > 0) {
+    info(t("mcp.existing", { names: existingServers.map((s) => s.name).join(", ") }));
+    if (await askYN(rl, t("mcp.keepExisting", { count: existingServers.length }), true)) {
+      servers.push(...existingServers);
     }
   }
 
-  if (selected.length === 0) return undefined;
+  const names = getAvailablePresets();
+  const customIndex = names.length;
+  const picked = await askMultiSelectWithDetails(
+    rl,
+    t("mcp.pickPresets"),
+    [
+      ...names.map((name) => ({ label: name, detail: getPresetDescription(name) })),
+      { label: "custom", detail: t("mcp.customDetail") },
+    ],
+    names.flatMap((name, i) => (DEFAULT_MCP_PRESETS.includes(name) ? [i] : [])),
+  );
+  const presets = picked.filter((i) => i !== customIndex).map((i) => names[i]);
+
+  if (picked.includes(customIndex)) {
+    const taken = new Set([...presets, ...servers.map((s) => s.name)]);
+    do {
+      const server = await askCustomMcpServer(rl, taken);
+      taken.add(server.name);
+      servers.push(server);
+      success(t("mcp.added", { name: server.name }));
+    } while (await askYN(rl, t("mcp.addAnother"), false));
+  }
+
+  if (presets.length === 0 && servers.length === 0) return undefined;
+
+  if (presets.includes("github")) {
+    info(t("mcp.githubTokenAt", { url: "https://github.com/settings/personal-access-tokens" }));
+    const token = await askCredential(
+      rl,
+      t("mcp.githubToken"),
+      "github",
+      true,
+    );
+    if (token) secrets.github = { token };
+  }
 
+  if (presets.length > 0) success(t("mcp.presets", { names: presets.join(", ") }));
+  if (servers.length > 0) success(t("mcp.servers", { names: servers.map((s) => s.name).join(", ") }));
   return {
-    servers: selected.map((s) => ({ ...s })),
-    ...(Object.keys(securityOverrides).length > 0 ? { securityOverrides } : {}),
+    ...(presets.length > 0 && { presets }),
+    ...(servers.length > 0 && { servers }),
   };
 }
 
@@ -121,15 +162,20 @@ export async function buildAppConfigFromPrompts(
   dockerMode: boolean,
   appConfigPath: string,
   providers: ProviderConfig[],
-  _secrets: SecretsConfig,
+  secrets: SecretsConfig,
   discordEnabled: boolean,
   telegramEnabled: boolean,
   reuseTelegramConfig?: boolean,
   telegramAllowList?: string[],
   telegramGroups?: NonNullable<NonNullable<AppConfig["telegram"]>["groups"]>,
+  slackEnabled: boolean = false,
+  webhookEnabled: boolean = false,
+  existingMcpServers: CustomMcpServer[] = [],
+  liveLookups: boolean = Boolean(process.stdin.isTTY),
+  gatewayPort: number = 8787,
 ): Promise<{ config: AppConfig; workspacePath: string; writeToolAllowList: string[] | null }> {
   const workspace = await getWorkspacePath(rl, dockerMode, appConfigPath);
-  const gateway = await getGatewayConfig(rl, dockerMode);
+  const gateway = await getGatewayConfig(rl, dockerMode, gatewayPort);
 
   const config: AppConfig = {
     workspace,
@@ -140,7 +186,14 @@ export async function buildAppConfigFromPrompts(
   };
 
   const userAllowLists: UserAllowLists = { discord: [], telegram: [] };
-  if (discordEnabled) await configureDiscordConfig(rl, config, userAllowLists);
+  if (discordEnabled) {
+    await configureDiscordConfig(rl, config, userAllowLists);
+    const token = secrets.discord?.token;
+    const pickChannels = liveLookups && token
+      ? (r: typeof rl) => pickDiscordChannels(r, token, dirname(appConfigPath))
+      : null;
+    await configureDiscordAccess(rl, config, userAllowLists, pickChannels);
+  }
 
   if (telegramEnabled) {
     if (reuseTelegramConfig) {
@@ -151,13 +204,21 @@ export async function buildAppConfigFromPrompts(
       };
       if (telegramAllowList) userAllowLists.telegram = telegramAllowList;
     } else {
-      await configureTelegramConfig(rl, config, userAllowLists);
+      const token = secrets.telegram?.token;
+      const discoverIds = liveLookups && token ? (r: typeof rl) => discoverTelegramUserIds(r, token) : null;
+      await configureTelegramConfig(rl, config, userAllowLists, discoverIds);
     }
   }
 
+  if (slackEnabled) await configureSlackAccess(rl, config, userAllowLists);
+  if (webhookEnabled) await configureWebhookConfig(rl, config, secrets);
+
   // MCP servers
-  const mcpConfig = await configureMcpServers(rl);
-  if (mcpConfig) config.mcp = mcpConfig;
+  const mcpConfig = await configureMcpServers(rl, secrets, existingMcpServers);
+  if (mcpConfig) {
+    config.mcp = mcpConfig;
+    if (liveLookups) await checkMcpRuntimes(rl, mcpConfig, dockerMode);
+  }
 
   const writeToolAllowList = await configureWriteToolsSecurity(rl, config, userAllowLists);
 
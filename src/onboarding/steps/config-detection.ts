@@ -2,32 +2,49 @@
  * Existing configuration detection
  */
 
-import { dirname, join } from "node:path";
+import { dirname, join, resolve } from "node:path";
 import { existsSync, readFileSync } from "node:fs";
 import { parse as yamlParse } from "yaml";
 import { loadSecrets } from "../secrets.js";
 import { ensureOwliabotHomeEnv } from "../../utils/paths.js";
+import { listProfiles } from "../../config/profiles.js";
 import { loadOAuthCredentials } from "../../auth/oauth.js";
 import { validateAnthropicSetupToken } from "../../auth/setup-token.js";
 import type { AppConfig } from "../types.js";
+import type { AzureOpenAIDetected } from "./azure-openai.js";
 
 type TelegramGroups = NonNullable<NonNullable<AppConfig["telegram"]>["groups"]>;
+type McpServers = NonNullable<NonNullable<AppConfig["mcp"]>["servers"]>;
 
 export interface DetectedConfig {
   anthropicKey?: string;
   anthropicToken?: string;
+  /** secrets.anthropic.tokenExpiresAt (ISO) */
+  anthropicTokenExpiresAt?: string;
   openaiKey?: string;
   openaiCompatKey?: string;
+  /** Key from secrets.yaml plus endpoint/deployment from the app.yaml provider block */
+  azureOpenai?: AzureOpenAIDetected;
   discordToken?: string;
   telegramToken?: string;
+  slackBotToken?: string;
+  slackAppToken?: string;
   gatewayToken?: string;
+  /** secrets.gateway.basicAuthPassword, kept so clients using it still get in */
+  gatewayBasicAuthPassword?: string;
   hasOAuthAnthro?: boolean;
   hasOAuthCodex?: boolean;
   oauthCodexExpires?: number;
+  /** The Codex session has a refresh token, so it renews itself */
+  oauthCodexRefreshable?: boolean;
   anthropicTokenValid?: boolean;
   discordMemberAllowList?: string[];
   telegramAllowList?: string[];
   telegramGroups?: TelegramGroups;
+  /** Custom servers from app.yaml mcp.servers (presets are not included) */
+  mcpServers?: McpServers;
+  /** Other set-up profiles on this machine (not counted as existing config) */
+  otherProfiles?: string[];
 }
 
 /**
@@ -50,11 +67,17 @@ export async function detectExistingConfig(
     if (secrets) {
       if (secrets.anthropic?.apiKey) { result.anthropicKey = secrets.anthropic.apiKey; hasAny = true; }
       if (secrets.anthropic?.token) { result.anthropicToken = secrets.anthropic.token; hasAny = true; }
+      if (secrets.anthropic?.token && secrets.anthropic.tokenExpiresAt) {
+        result.anthropicTokenExpiresAt = secrets.anthropic.tokenExpiresAt;
+      }
       if (secrets.openai?.apiKey) { result.openaiKey = secrets.openai.apiKey; hasAny = true; }
       if (secrets["openai-compatible"]?.apiKey) { result.openaiCompatKey = secrets["openai-compatible"].apiKey; hasAny = true; }
       if (secrets.discord?.token) { result.discordToken = secrets.discord.token; hasAny = true; }
       if (secrets.telegram?.token) { result.telegramToken = secrets.telegram.token; hasAny = true; }
+      if (secrets.slack?.botToken) { result.slackBotToken = secrets.slack.botToken; hasAny = true; }
+      if (secrets.slack?.appToken) { result.slackAppToken = secrets.slack.appToken; hasAny = true; }
       if (secrets.gateway?.token) { result.gatewayToken = secrets.gateway.token; hasAny = true; }
+      if (secrets.gateway?.basicAuthPassword) { result.gatewayBasicAuthPassword = secrets.gateway.basicAuthPassword; hasAny = true; }
 
       // Check OAuth tokens (same location for both modes).
       // Keep prior behavior: only check OAuth when secrets.yaml exists to avoid
@@ -71,6 +94,7 @@ export async function detectExistingConfig(
           if (creds) {
             result.hasOAuthCodex = true;
             result.oauthCodexExpires = creds.expires;
+            result.oauthCodexRefreshable = Boolean(creds.refresh);
             hasAny = true;
           }
         } catch {
@@ -85,7 +109,8 @@ export async function detectExistingConfig(
       }
     }
 
-    // Best-effort: detect channel allowLists/groups from app.yaml so we can offer reuse.
+    // Best-effort: detect channel allowLists/groups and custom MCP servers from
+    // app.yaml so we can offer reuse.
     try {
       if (existsSync(appConfigPath)) {
         const raw = yamlParse(readFileSync(appConfigPath, "utf-8")) as any;
@@ -132,13 +157,41 @@ export async function detectExistingConfig(
             }
           }
         }
+
+        // Azure OpenAI: the key alone can't rebuild the provider block.
+        const azure = Array.isArray(raw?.providers)
+          ? raw.providers.find((p: any) => p?.id === "azure-openai")
+          : undefined;
+        const azureKey = secrets?.["azure-openai"]?.apiKey;
+        if (azureKey && typeof azure?.baseUrl === "string" && typeof azure?.model === "string") {
+          result.azureOpenai = {
+            apiKey: azureKey,
+            endpoint: azure.baseUrl,
+            deployment: azure.model,
+            ...(typeof azure.apiVersion === "string" && { apiVersion: azure.apiVersion }),
+          };
+          hasAny = true;
+        }
+
+        const servers = Array.isArray(raw?.mcp?.servers)
+          ? (raw.mcp.servers as McpServers).filter((s) => s && typeof s === "object" && typeof s.name === "string")
+          : [];
+        if (servers.length > 0) {
+          result.mcpServers = servers;
+          hasAny = true;
+        }
       }
     } catch {
       // ignore
     }
 
     // Keep behavior parity: only return non-empty.
-    return hasAny ? result : null;
+    if (!hasAny) return null;
+    const otherProfiles = listProfiles()
+      .filter((p) => p.configured && p.dir !== resolve(dirname(appConfigPath)))
+      .map((p) => p.name);
+    if (otherProfiles.length > 0) result.otherProfiles = otherProfiles;
+    return result;
   } catch {
     return null;
   }
@@ -0,0 +1,86 @@
+/**
+ * Step module: merge a regenerated app.yaml into the existing one.
+ *
+ * Re-running onboarding used to rewrite app.yaml from scratch, dropping
+ * sections the wizard doesn't know about (hand-added settings, newer
+ * options) and every comment. Instead the generated config is merged into
+ * the existing YAML document: values the wizard produces replace the old
+ * ones in place, so comments around them survive, and keys outside the
+ * wizard's schema are left alone. A key the wizard owns but no longer
+ * generates (e.g. `telegram` after the channel was deselected) is removed.
+ */
+
+import { isMap, isNode, isScalar, parseDocument, type Document, type YAMLMap } from "yaml";
+import type { AppConfig } from "../types.js";
+
+/** Keys the wizard writes; `true` means it owns the whole value */
+type KeyTree = { [key: string]: true | KeyTree };
+
+const WIZARD_KEYS: KeyTree = {
+  workspace: true,
+  timezone: true,
+  discord: { token: true, requireMentionInGuild: true, channelAllowList: true, memberAllowList: true },
+  slack: { memberAllowList: true, channelAllowList: true },
+  webhook: { path: true, outboundUrl: true },
+  telegram: { token: true, allowList: true, groups: true },
+  providers: true,
+  notifications: { channel: true },
+  memorySearch: true,
+  system: true,
+  gateway: {
+    http: { host: true, port: true, token: true, allowlist: true, basicAuth: true, tls: true },
+  },
+  security: {
+    writeGateEnabled: true,
+    writeToolAllowList: true,
+    writeToolConfirmation: true,
+    writeToolConfirmationTimeoutMs: true,
+    writeToolConfirmationApprover: true,
+    writeToolConfirmationIn: true,
+  },
+  agents: { loop: { maxIterations: true, timeoutSeconds: true } },
+  tools: { allowWrite: true },
+  mcp: { presets: true, servers: true, securityOverrides: true, defaults: true },
+  wallet: { clawlet: true },
+};
+
+function isPlainObject(value: unknown): value is Record<string, unknown> {
+  return typeof value === "object" && value !== null && !Array.isArray(value);
+}
+
+function mergeMap(doc: Document, map: YAMLMap, value: Record<string, unknown>, known: KeyTree): void {
+  for (const [key, next] of Object.entries(value)) {
+    if (next === undefined) continue;
+    const current = map.get(key, true);
+    const subtree = known[key];
+    if (isPlainObject(next) && isMap(current) && subtree !== true) {
+      mergeMap(doc, current, next, subtree ?? {});
+    } else if (isScalar(current) && !isPlainObject(next) && !Array.isArray(next)) {
+      // Keeps the scalar's own comment
+      current.value = next;
+    } else {
+      const node = doc.createNode(next);
+      if (isNode(current)) {
+        node.comment = current.comment;
+        node.commentBefore = current.commentBefore;
+      }
+      map.set(key, node);
+    }
+  }
+
+  for (const pair of [...map.items]) {
+    const key = isScalar(pair.key) ? String(pair.key.value) : String(pair.key);
+    if (key in known && value[key] === undefined) map.delete(key);
+  }
+}
+
+/**
+ * The existing app.yaml text with `config` merged in, or null when the
+ * existing file isn't a YAML mapping (the caller then rewrites it).
+ */
+export function mergeAppConfigYaml(existing: string, config: AppConfig): string | null {
+  const doc = parseDocument(existing);
+  if (doc.errors.length > 0 || !isMap(doc.contents)) return null;
+  mergeMap(doc, doc.contents, config as unknown as Record<string, unknown>, WIZARD_KEYS);
+  return doc.toString({ indent: 2 });
+}
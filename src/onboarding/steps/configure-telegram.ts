@@ -4,18 +4,34 @@
 
 import { createInterface } from "node:readline";
 import type { AppConfig } from "../types.js";
-import { success, header, ask } from "../shared.js";
+import { success, header, askYN } from "../shared.js";
 import type { UserAllowLists } from "./types.js";
+import { askIdList } from "./access-setup.js";
+import { t } from "../i18n.js";
 
+/**
+ * `discoverIds` finds IDs by having the user message the bot (see
+ * telegram-discovery.ts); IDs can still be typed in addition.
+ */
 export async function configureTelegramConfig(
   rl: ReturnType<typeof createInterface>,
   config: AppConfig,
   userAllowLists: UserAllowLists,
+  discoverIds: ((rl: ReturnType<typeof createInterface>) => Promise<string[]>) | null = null,
 ): Promise<void> {
-  header("Telegram configuration");
+  header(t("telegram.header"));
 
-  const telegramUserIds = await ask(rl, "User allowlist - user IDs allowed to interact (comma-separated): ");
-  const allowList = telegramUserIds.split(",").map((s) => s.trim()).filter(Boolean);
+  const found = discoverIds && await askYN(rl, t("telegram.discover"), true)
+    ? await discoverIds(rl)
+    : [];
+  const typed = await askIdList(
+    rl,
+    found.length > 0
+      ? t("telegram.otherIds")
+      : t("telegram.ids"),
+    "telegram",
+  );
+  const allowList = [...new Set([...found, ...typed])];
   userAllowLists.telegram = allowList;
 
   config.telegram = {
@@ -23,6 +39,6 @@ export async function configureTelegramConfig(
   };
 
   if (allowList.length > 0) {
-    success(`Telegram user allowlist: ${allowList.join(", ")}`);
+    success(t("telegram.allowList", { ids: allowList.join(", ") }));
   }
 }
@@ -0,0 +1,69 @@
+/**
+ * Step module: demo mode (`onboard --demo`).
+ *
+ * Replaces the AI provider stage with the built-in demo provider, which
+ * echoes messages back without a model or an API key. Channels, the gateway
+ * and commands are set up as usual, so the bot can be tried end to end
+ * first; app.yaml and docker-compose.yml say at the top that it's a demo.
+ */
+
+import { execFileSync } from "node:child_process";
+import { existsSync } from "node:fs";
+import { dirname } from "node:path";
+import { DEMO_MODEL, DEMO_PROVIDER_ID } from "../../agent/demo-provider.js";
+import type { ProviderConfig } from "../types.js";
+import type { ProviderResult } from "./types.js";
+import { header, info, success, warn, COLORS } from "../shared.js";
+import { t } from "../i18n.js";
+import { containerCli, type ContainerCli } from "../../logs/docker.js";
+
+/**
+ * The provider stage's result in demo mode: the demo provider and no secrets.
+ */
+export function demoProviderSetup(): ProviderResult {
+  header(t("provider.header"));
+  info(t("demo.echoes"));
+  info(t("demo.noKey"));
+  const provider: ProviderConfig = { id: DEMO_PROVIDER_ID, model: DEMO_MODEL, priority: 1 };
+  return { providers: [provider], secrets: {}, useAnthropic: false, useOpenaiCodex: false };
+}
+
+/**
+ * Whether onboarding runs in install.sh's onboarding container, which has no
+ * docker CLI; install.sh starts the stack itself afterwards.
+ */
+export function inOnboardingContainer(env: Record<string, string | undefined> = process.env): boolean {
+  return env.OWLIABOT_DOCKER === "1" || existsSync("/.dockerenv");
+}
+
+/**
+ * `docker compose up -d` (or `podman compose`) for the demo. Returns false (with the command to
+ * run by hand) when it couldn't be started.
+ */
+export function startDemoStack(
+  composePath: string,
+  exec: (cmd: string, args: string[]) => void = (cmd, args) => {
+    execFileSync(cmd, args, { cwd: dirname(composePath), stdio: "inherit" });
+  },
+  cli: ContainerCli = containerCli(),
+): boolean {
+  header(t("demo.starting"));
+  try {
+    exec(cli, ["compose", "-f", composePath, "up", "-d"]);
+  } catch (err) {
+    warn(t("demo.startFailed", { error: (err as Error).message }));
+    info(t("demo.startYourself", { command: `${cli} compose -f ${composePath} up -d` }));
+    return false;
+  }
+  success(t("demo.running"));
+  return true;
+}
+
+/** Closing lines for a demo setup */
+export function printDemoNextSteps(started: boolean, startCommand: string): void {
+  console.log("");
+  warn(t("demo.isDemo"));
+  if (!started) info(t("demo.startWith", { command: `${COLORS.CYAN}${startCommand}${COLORS.NC}` }));
+  info(t("demo.tryIt"));
+  info(t("demo.addKeyLater"));
+}
@@ -9,6 +9,7 @@ import { loadSecrets } from "../secrets.js";
 import { ensureOwliabotHomeEnv } from "../../utils/paths.js";
 import { info, success, header, askYN } from "../shared.js";
 import type { DetectedConfig } from "./types.js";
+import { t } from "../i18n.js";
 
 export async function detectExistingConfig(
   _dockerMode: boolean,
@@ -44,20 +45,20 @@ export function printExistingConfigSummary(
   appConfigPath: string,
   existing: DetectedConfig,
 ): void {
-  header("Existing configuration found");
-  info(`Found existing config at: ${dirname(appConfigPath)}`);
+  header(t("existing.header"));
+  info(t("existing.at", { dir: dirname(appConfigPath) }));
 
   if (existing.anthropicKey) {
     const truncLen = dockerMode ? 10 : 15;
-    info(`Found Anthropic API key: ${existing.anthropicKey.slice(0, truncLen)}...`);
+    info(t("existing.anthropicKey", { key: existing.anthropicKey.slice(0, truncLen) }));
   }
-  if (existing.anthropicToken) info("Found Anthropic setup-token");
-  if (dockerMode && existing.hasOAuthAnthro) info("Found Anthropic OAuth token");
-  if (existing.openaiKey) info(`Found OpenAI API key: ${existing.openaiKey.slice(0, 10)}...`);
-  if (dockerMode && existing.hasOAuthCodex) info("Found OpenAI OAuth token (openai-codex)");
-  if (existing.discordToken) info(`Found Discord token: ${existing.discordToken.slice(0, 20)}...`);
-  if (existing.telegramToken) info(`Found Telegram token: ${existing.telegramToken.slice(0, 10)}...`);
-  if (dockerMode && existing.gatewayToken) info(`Found Gateway token: ${existing.gatewayToken.slice(0, 10)}...`);
+  if (existing.anthropicToken) info(t("existing.anthropicToken"));
+  if (dockerMode && existing.hasOAuthAnthro) info(t("existing.anthropicOAuth"));
+  if (existing.openaiKey) info(t("existing.openaiKey", { key: existing.openaiKey.slice(0, 10) }));
+  if (dockerMode && existing.hasOAuthCodex) info(t("existing.codexOAuth"));
+  if (existing.discordToken) info(t("existing.discordToken", { token: existing.discordToken.slice(0, 20) }));
+  if (existing.telegramToken) info(t("existing.telegramToken", { token: existing.telegramToken.slice(0, 10) }));
+  if (dockerMode && existing.gatewayToken) info(t("existing.gatewayToken", { token: existing.gatewayToken.slice(0, 10) }));
 }
 
 export async function promptReuseExistingConfig(
@@ -66,8 +67,8 @@ export async function promptReuseExistingConfig(
 ): Promise<boolean> {
   if (!existing) return false;
 
-  const reuse = await askYN(rl, "Do you want to reuse existing configuration?", true);
-  if (reuse) success("Will reuse existing configuration");
-  else info("Will configure new credentials");
+  const reuse = await askYN(rl, t("existing.reuse"), true);
+  if (reuse) success(t("existing.reusing"));
+  else info(t("existing.new"));
   return reuse;
 }
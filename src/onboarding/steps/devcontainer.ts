@@ -0,0 +1,90 @@
+/**
+ * Step module: .devcontainer.json output for VS Code / devcontainer CLI users.
+ *
+ * Runs the published image with the generated config dir bind-mounted, like
+ * docker-compose.yml does. overrideCommand is off so the image's own
+ * `start` command runs instead of the devcontainer keep-alive loop.
+ */
+
+import { writeFileSync } from "node:fs";
+import { join } from "node:path";
+import type { DockerComposeOptions } from "./docker.js";
+import { CONTAINER_SECRETS_KEY_PATH } from "./secrets-encryption.js";
+import { CA_BUNDLE_FILE, CONTAINER_CA_BUNDLE_PATH } from "./ca-bundle.js";
+import { header, success, COLORS } from "../shared.js";
+import { t } from "../i18n.js";
+
+export const DEVCONTAINER_FILE = ".devcontainer.json";
+
+const CONTAINER_HOME = "/home/owliabot/.owliabot";
+
+/** `~/x` -> `${localEnv:HOME}/x` (devcontainer.json has its own variable syntax). */
+function expandHomeForDevcontainer(path: string): string {
+  return path === "~" ? "${localEnv:HOME}" : path.replace(/^~\//, "${localEnv:HOME}/");
+}
+
+/**
+ * Build .devcontainer.json content. `KEY=${KEY}` env lines are read from the
+ * host environment when the container starts.
+ */
+export function buildDevcontainerJson(
+  dockerConfigPath: string,
+  envLines: string[],
+  gatewayPort: string,
+  image: string,
+  options: Pick<DockerComposeOptions, "secretsKey" | "caBundle"> = {},
+): string {
+  const configPath = expandHomeForDevcontainer(dockerConfigPath);
+  const containerEnv: Record<string, string> = {};
+  for (const line of envLines) {
+    const eq = line.indexOf("=");
+    if (eq < 0) continue;
+    const key = line.slice(0, eq);
+    const value = line.slice(eq + 1);
+    containerEnv[key] = value === `\${${key}}` ? `\${localEnv:${key}}` : value;
+  }
+  const mounts = [
+    `source=${configPath},target=${CONTAINER_HOME},type=bind`,
+    `source=${configPath}/workspace,target=/app/workspace,type=bind`,
+  ];
+  if (options.secretsKey) {
+    mounts.push(`source=${configPath}/auth/secrets.agekey,target=${CONTAINER_SECRETS_KEY_PATH},type=bind,readonly`);
+    containerEnv.OWLIABOT_SECRETS_KEY_FILE = CONTAINER_SECRETS_KEY_PATH;
+  }
+  if (options.caBundle) {
+    mounts.push(`source=${configPath}/${CA_BUNDLE_FILE},target=${CONTAINER_CA_BUNDLE_PATH},type=bind,readonly`);
+    containerEnv.NODE_EXTRA_CA_CERTS = CONTAINER_CA_BUNDLE_PATH;
+  }
+
+  const devcontainer = {
+    name: "OwliaBot",
+    image,
+    overrideCommand: false,
+    appPort: [`127.0.0.1:${gatewayPort}:8787`],
+    mounts,
+    containerEnv,
+  };
+  return `${JSON.stringify(devcontainer, null, 2)}\n`;
+}
+
+/**
+ * Write .devcontainer.json.
+ */
+export function writeDevcontainer(outputDir: string, content: string): string {
+  const path = join(outputDir, DEVCONTAINER_FILE);
+  writeFileSync(path, content);
+  success(t("devcontainer.saved", { file: DEVCONTAINER_FILE, path }));
+  return path;
+}
+
+/**
+ * How to open it.
+ */
+export function printDevcontainerNextSteps(path: string, gatewayPort: string): void {
+  const C = COLORS;
+  header(t("devcontainer.header"));
+  console.log(`  ${t("devcontainer.vscode", { dir: `${C.CYAN}${join(path, "..")}${C.NC}` })}`);
+  console.log(`  ${t("devcontainer.cli", { command: `${C.CYAN}devcontainer up --workspace-folder ${join(path, "..")}${C.NC}` })}`);
+  console.log(`  ${t("devcontainer.gateway", { url: `http://localhost:${gatewayPort}` })}`);
+  console.log("");
+}
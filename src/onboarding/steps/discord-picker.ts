@@ -0,0 +1,165 @@
+/**
+ * Step module: pick Discord channels by name instead of pasting IDs.
+ *
+ * Lists the servers the bot has joined and their text channels through the
+ * Discord API, then builds `channelAllowList` from a multi-select. Results
+ * are cached next to the config for a few minutes (keyed by a hash of the
+ * token, never the token itself) so re-running the wizard doesn't hit the
+ * rate limit again.
+ */
+
+import { createHash } from "node:crypto";
+import { mkdir, readFile, writeFile } from "node:fs/promises";
+import { dirname, join } from "node:path";
+import { createInterface } from "node:readline";
+import { askMultiSelectWithDetails, info, success } from "../shared.js";
+import { DISCORD_API_BASE } from "./discord-validation.js";
+import { validationClient, noteSkippedValidation, type ValidationClient } from "./validation-client.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export const DISCORD_CHANNEL_CACHE_FILE = join("cache", "discord-channels.json");
+export const DISCORD_CHANNEL_CACHE_TTL_MS = 10 * 60 * 1000;
+
+// Channel types a bot can be talked to in: text, announcement, forum
+const MESSAGE_CHANNEL_TYPES = new Set([0, 5, 15]);
+
+export interface DiscordChannel {
+  id: string;
+  name: string;
+}
+
+export interface DiscordGuild {
+  id: string;
+  name: string;
+  channels: DiscordChannel[];
+}
+
+export type DiscordGuildListing =
+  | { kind: "ok"; guilds: DiscordGuild[] }
+  | { kind: "skipped"; reason: string };
+
+interface CacheFile {
+  tokenHash: string;
+  fetchedAt: number;
+  guilds: DiscordGuild[];
+}
+
+function tokenHash(token: string): string {
+  return createHash("sha256").update(token).digest("hex");
+}
+
+/**
+ * The bot's guilds with their message channels, sorted by channel position.
+ */
+export async function fetchDiscordGuilds(
+  token: string,
+  client: ValidationClient = validationClient,
+): Promise<DiscordGuildListing> {
+  const headers = { Authorization: `Bot ${token}` };
+
+  const res = await client.fetch(`${DISCORD_API_BASE}/users/@me/guilds`, { headers });
+  if (res.kind === "skipped") return res;
+  if (!res.response.ok) return { kind: "skipped", reason: `HTTP ${res.response.status} listing servers` };
+  const guilds = (await res.response.json()) as { id: string; name: string }[];
+
+  const out: DiscordGuild[] = [];
+  for (const guild of guilds) {
+    const chRes = await client.fetch(`${DISCORD_API_BASE}/guilds/${guild.id}/channels`, { headers });
+    if (chRes.kind === "skipped") return chRes;
+    if (!chRes.response.ok) {
+      out.push({ id: guild.id, name: guild.name, channels: [] });
+      continue;
+    }
+    const channels = (await chRes.response.json()) as { id: string; name: string; type: number; position?: number }[];
+    out.push({
+      id: guild.id,
+      name: guild.name,
+      channels: channels
+        .filter((c) => MESSAGE_CHANNEL_TYPES.has(c.type))
+        .sort((a, b) => (a.position ?? 0) - (b.position ?? 0))
+        .map((c) => ({ id: c.id, name: c.name })),
+    });
+  }
+  return { kind: "ok", guilds: out };
+}
+
+export async function readCachedGuilds(
+  cachePath: string,
+  token: string,
+  now = Date.now(),
+): Promise<DiscordGuild[] | null> {
+  try {
+    const cache = JSON.parse(await readFile(cachePath, "utf-8")) as CacheFile;
+    if (cache.tokenHash !== tokenHash(token)) return null;
+    if (now - cache.fetchedAt > DISCORD_CHANNEL_CACHE_TTL_MS) return null;
+    return cache.guilds;
+  } catch {
+    return null;
+  }
+}
+
+export async function writeCachedGuilds(
+  cachePath: string,
+  token: string,
+  guilds: DiscordGuild[],
+  now = Date.now(),
+): Promise<void> {
+  const cache: CacheFile = { tokenHash: tokenHash(token), fetchedAt: now, guilds };
+  try {
+    await mkdir(dirname(cachePath), { recursive: true });
+    await writeFile(cachePath, `${JSON.stringify(cache, null, 2)}\n`, "utf-8");
+  } catch {
+    // A cache that can't be written only costs another API call next time
+  }
+}
+
+/**
+ * Cached listing when fresh, otherwise a live one (cached on success).
+ */
+export async function listDiscordGuilds(
+  token: string,
+  configDir: string,
+  fetchGuilds: (token: string) => Promise<DiscordGuildListing> = (value) => fetchDiscordGuilds(value),
+): Promise<DiscordGuildListing> {
+  const cachePath = join(configDir, DISCORD_CHANNEL_CACHE_FILE);
+  const cached = await readCachedGuilds(cachePath, token);
+  if (cached) return { kind: "ok", guilds: cached };
+
+  const listing = await fetchGuilds(token);
+  if (listing.kind === "ok") await writeCachedGuilds(cachePath, token, listing.guilds);
+  return listing;
+}
+
+/**
+ * Let the user tick channels by name. Returns the chosen channel IDs, or
+ * null when the listing isn't available (caller falls back to typing IDs).
+ */
+export async function pickDiscordChannels(
+  rl: RL,
+  token: string,
+  configDir: string,
+  list: (token: string, configDir: string) => Promise<DiscordGuildListing> = listDiscordGuilds,
+): Promise<string[] | null> {
+  const listing = await list(token, configDir);
+  if (listing.kind === "skipped") {
+    noteSkippedValidation(t("discordPicker.check"), listing.reason);
+    return null;
+  }
+
+  const channels = listing.guilds.flatMap((g) => g.channels.map((c) => ({ ...c, guild: g.name })));
+  if (channels.length === 0) {
+    info(t("discordPicker.noChannels"));
+    return null;
+  }
+
+  const picked = await askMultiSelectWithDetails(
+    rl,
+    t("discordPicker.pick"),
+    channels.map((c) => ({ label: `#${c.name}`, detail: `${c.guild} · ${c.id}` })),
+  );
+  const ids = picked.map((i) => channels[i].id);
+  if (ids.length > 0) success(t("access.discord.channelsSet", { ids: picked.map((i) => `#${channels[i].name}`).join(", ") }));
+  return ids;
+}
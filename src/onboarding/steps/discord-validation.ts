@@ -0,0 +1,87 @@
+/**
+ * Step module: live Discord token check during the Channels stage.
+ *
+ * Calls `/users/@me` for the bot's name (401 means the token is wrong) and
+ * `/applications/@me` for the privileged intent flags, so a missing
+ * Message Content Intent shows up now instead of after `docker compose up`.
+ */
+
+import { createInterface } from "node:readline";
+import { success, warn, error, info } from "../shared.js";
+import { askCredential } from "./placeholder-credentials.js";
+import { validationClient, noteSkippedValidation, type ValidationClient } from "./validation-client.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export const DISCORD_API_BASE = "https://discord.com/api/v10";
+
+// Application flags for the Message Content privileged intent
+// (LIMITED is what unverified bots under 100 servers get).
+const GATEWAY_MESSAGE_CONTENT = 1 << 18;
+const GATEWAY_MESSAGE_CONTENT_LIMITED = 1 << 19;
+
+export type DiscordTokenCheck =
+  | { kind: "valid"; username: string; messageContentIntent: boolean | null }
+  | { kind: "invalid"; message: string }
+  | { kind: "skipped"; reason: string };
+
+/**
+ * Check a bot token against the Discord API.
+ * `messageContentIntent` is null when the application lookup didn't answer.
+ */
+export async function checkDiscordToken(
+  token: string,
+  client: ValidationClient = validationClient,
+): Promise<DiscordTokenCheck> {
+  const headers = { Authorization: `Bot ${token}` };
+
+  const me = await client.fetch(`${DISCORD_API_BASE}/users/@me`, { headers });
+  if (me.kind === "skipped") return me;
+  if (me.response.status === 401) return { kind: "invalid", message: t("discordCheck.rejected") };
+  if (!me.response.ok) return { kind: "skipped", reason: t("discordCheck.unexpected", { status: me.response.status }) };
+  const user = (await me.response.json()) as { username?: string; discriminator?: string };
+  const username = user.discriminator && user.discriminator !== "0"
+    ? `${user.username}#${user.discriminator}`
+    : String(user.username ?? "unknown");
+
+  let messageContentIntent: boolean | null = null;
+  const app = await client.fetch(`${DISCORD_API_BASE}/applications/@me`, { headers });
+  if (app.kind === "response" && app.response.ok) {
+    const flags = Number(((await app.response.json()) as { flags?: number }).flags ?? 0);
+    messageContentIntent = (flags & (GATEWAY_MESSAGE_CONTENT | GATEWAY_MESSAGE_CONTENT_LIMITED)) !== 0;
+  }
+
+  return { kind: "valid", username, messageContentIntent };
+}
+
+/**
+ * Validate the entered token, re-prompting while Discord rejects it.
+ * Returns the token to keep ("" when the user chose to add it later).
+ */
+export async function promptValidDiscordToken(
+  rl: RL,
+  token: string,
+  check: (token: string) => Promise<DiscordTokenCheck> = (value) => checkDiscordToken(value),
+): Promise<string> {
+  let current = token;
+  while (current) {
+    const result = await check(current);
+    if (result.kind === "skipped") {
+      noteSkippedValidation(t("discordCheck.check"), result.reason);
+      return current;
+    }
+    if (result.kind === "valid") {
+      success(t("discordCheck.bot", { name: result.username }));
+      if (result.messageContentIntent === false) {
+        warn(t("discordCheck.intentOff"));
+      } else if (result.messageContentIntent === null) {
+        info(t("discordCheck.intentUnknown"));
+      }
+      return current;
+    }
+    error(t("discordCheck.invalid", { message: result.message }));
+    current = await askCredential(rl, t("discordCheck.again"), "discord", true);
+  }
+  return "";
+}
@@ -7,8 +7,17 @@ import { join } from "node:path";
 import { mkdirSync, writeFileSync, readdirSync, lstatSync, chmodSync } from "node:fs";
 import type { AppConfig } from "../types.js";
 import type { SecretsConfig } from "../secrets.js";
-import { header, info, success, COLORS } from "../shared.js";
+import { askYN, header, info, isAccessible, success, COLORS } from "../shared.js";
 import type { createInterface } from "node:readline";
+import { buildTunnelComposeService, type TunnelSetup } from "./tunnel.js";
+import { buildOidcProxyComposeService, OIDC_PROXY_UPSTREAM } from "./oidc-proxy.js";
+import { buildReverseProxyComposeService, type ReverseProxySetup } from "./reverse-proxy.js";
+import { CONTAINER_SECRETS_KEY_PATH } from "./secrets-encryption.js";
+import { CA_BUNDLE_FILE, CONTAINER_CA_BUNDLE_PATH } from "./ca-bundle.js";
+import { checkGatewayPort } from "./port-check.js";
+import { lastKnownGoodImage, type ImageHistoryEntry } from "../../gateway/image-history.js";
+import { profileComposeFile, profileDirName } from "../../config/profiles.js";
+import { t } from "../i18n.js";
 
 type RL = ReturnType<typeof createInterface>;
 
@@ -18,23 +27,74 @@ export interface DockerPaths {
   dockerConfigPath: string;
   shellConfigPath: string;
   outputDir: string;
+  /** Compose file name in outputDir (default docker-compose.yml; docker-compose.<profile>.yml) */
+  composeFile?: string;
 }
 
+export interface DockerComposeOptions {
+  /** Gateway serves HTTPS (gateway.http.tls); the healthcheck must follow */
+  gatewayTls?: boolean;
+  /** Add a cloudflared/ngrok sidecar that exposes the gateway publicly */
+  tunnel?: TunnelSetup;
+  /** Publish the gateway through an oauth2-proxy sidecar (OIDC login) */
+  oidcProxy?: boolean;
+  /** Expose the gateway publicly behind Caddy/Traefik (Let's Encrypt); the gateway port is not published */
+  reverseProxy?: ReverseProxySetup;
+  /** container_name of the bot service (per-environment variants need distinct names) */
+  containerName?: string;
+  /** Top-level compose `name:`, so profiles sharing a directory stay separate projects */
+  projectName?: string;
+  /** secrets.yaml is age-encrypted: mount the key read-only and point the bot at it */
+  secretsKey?: boolean;
+  /** Load secrets from this env file (relative to docker-compose.yml), e.g. ".env" */
+  envFile?: string;
+  /** The config dir holds ca-bundle.pem: mount it read-only and trust it for outbound HTTPS */
+  caBundle?: boolean;
+  /**
+   * Put optional services in compose profiles (toggled with --profile):
+   * ollama and watchtower are always included, tunnel and proxy when configured.
+   */
+  profiles?: boolean;
+  /** Add Watchtower, limited to the bot container, and start it with the bot */
+  watchtower?: boolean;
+  /** Bot image platform, e.g. linux/amd64 under emulation on an ARM host */
+  platform?: string;
+  /** The config uses the demo provider (onboard --demo); say so at the top */
+  demo?: boolean;
+}
+
+/**
+ * A service of the generated compose file. The file is put together from
+ * these, so each add-on (sidecars, Watchtower) is switched on by itself.
+ */
+export interface ComposeService {
+  name: string;
+  /** The service's YAML, indented under `services:` */
+  block: string;
+  /** Compose profile it goes in when profiles are on */
+  profile?: ComposeProfile;
+}
+
+/** Compose profile names for the optional services */
+export const COMPOSE_PROFILES = ["ollama", "watchtower", "tunnel", "proxy"] as const;
+export type ComposeProfile = (typeof COMPOSE_PROFILES)[number];
+
 export interface DockerComposeSetup {
   gatewayToken: string;
   gatewayPort: string;
 }
 
 /**
- * Initialize Docker paths structure.
+ * Initialize Docker paths structure (~/.owliabot, or ~/.owliabot-<profile>).
  */
-export function initDockerPaths(outputDir?: string): DockerPaths {
+export function initDockerPaths(outputDir?: string, profile?: string): DockerPaths {
   // Docker mode always uses the host user's config directory.
   // This keeps volume mounts stable across machines and avoids /app/... host paths
   // that Docker Desktop (macOS) cannot mount.
-  const hostConfigDirAbs = join(homedir(), ".owliabot");
-  const dockerConfigPath = "~/.owliabot";
-  const shellConfigPath = "~/.owliabot";
+  const dirName = profileDirName(profile);
+  const hostConfigDirAbs = join(homedir(), dirName);
+  const dockerConfigPath = `~/${dirName}`;
+  const shellConfigPath = `~/${dirName}`;
   const outDir = outputDir ?? ".";
 
   mkdirSync(hostConfigDirAbs, { recursive: true });
@@ -44,19 +104,28 @@ export function initDockerPaths(outputDir?: string): DockerPaths {
     dockerConfigPath,
     shellConfigPath,
     outputDir: outDir,
+    composeFile: profileComposeFile(profile),
   };
 }
 
+/** Where docker-compose.yml (or the profile's compose file) goes */
+export function dockerComposePath(paths: DockerPaths): string {
+  return join(paths.outputDir, paths.composeFile ?? "docker-compose.yml");
+}
+
 /**
  * Prompt for Docker Compose-specific settings.
  */
 export async function promptDockerComposeSetup(
-  _rl: RL,
+  rl: RL,
   gatewayToken: string,
+  checkPort: boolean = Boolean(process.stdin.isTTY),
+  defaultPort: string = "8787",
 ): Promise<DockerComposeSetup> {
   header("Docker");
-  info("Using default Gateway port: 8787");
-  return { gatewayToken, gatewayPort: "8787" };
+  info(t("docker.defaultPort", { port: defaultPort }));
+  const gatewayPort = checkPort ? await checkGatewayPort(rl, defaultPort) : defaultPort;
+  return { gatewayToken, gatewayPort };
 }
 
 /**
@@ -77,6 +146,15 @@ export function buildDockerEnvLines(
   if (config.telegram && !secrets.telegram?.token) {
     env.push("TELEGRAM_BOT_TOKEN=${TELEGRAM_BOT_TOKEN}");
   }
+  if (config.slack && !secrets.slack?.botToken) {
+    env.push("SLACK_BOT_TOKEN=${SLACK_BOT_TOKEN}");
+  }
+  if (config.slack && !secrets.slack?.appToken) {
+    env.push("SLACK_APP_TOKEN=${SLACK_APP_TOKEN}");
+  }
+  if (config.mcp?.presets?.includes("github") && !secrets.github?.token) {
+    env.push("GITHUB_PERSONAL_ACCESS_TOKEN=${GITHUB_PERSONAL_ACCESS_TOKEN}");
+  }
 
   // Provider keys: only needed when provider explicitly uses env auth
   if (config.providers.some((p) => p.id === "anthropic" && p.apiKey === "env")) {
@@ -85,10 +163,143 @@ export function buildDockerEnvLines(
   if (config.providers.some((p) => p.id === "openai" && p.apiKey === "env")) {
     env.push("OPENAI_API_KEY=${OPENAI_API_KEY}");
   }
+  if (config.providers.some((p) => p.id === "azure-openai" && p.apiKey === "env")) {
+    env.push("AZURE_OPENAI_API_KEY=${AZURE_OPENAI_API_KEY}");
+  }
+  if (config.providers.some((p) => p.id === "amazon-bedrock" && p.apiKey === "env")) {
+    env.push("AWS_ACCESS_KEY_ID=${AWS_ACCESS_KEY_ID}");
+    env.push("AWS_SECRET_ACCESS_KEY=${AWS_SECRET_ACCESS_KEY}");
+  }
 
   return env;
 }
 
+/**
+ * Add `profiles:` under the service name of a compose service block.
+ */
+export function withComposeProfile(block: string, profile: ComposeProfile): string {
+  return block.replace(/^( {2}[\w-]+:\n)/m, `$1    profiles: ["${profile}"]\n`);
+}
+
+/**
+ * Local Ollama server, reachable from the bot at http://ollama:11434/v1.
+ */
+export function buildOllamaComposeService(dockerConfigPath: string): string {
+  return `
+  ollama:
+    image: ollama/ollama:latest
+    container_name: owliabot-ollama
+    restart: unless-stopped
+    volumes:
+      - ${dockerConfigPath}/ollama:/root/.ollama
+`;
+}
+
+/**
+ * Watchtower, limited to the bot container: pulls new images and restarts it.
+ */
+export function buildWatchtowerComposeService(containerName: string): string {
+  return `
+  watchtower:
+    image: containrrr/watchtower:latest
+    container_name: owliabot-watchtower
+    restart: unless-stopped
+    command: ["--cleanup", "${containerName}"]
+    volumes:
+      - /var/run/docker.sock:/var/run/docker.sock
+`;
+}
+
+/**
+ * Ask whether to add Watchtower so the bot picks up new images by itself
+ * (interactive runs only).
+ */
+export async function promptAutoUpdate(
+  rl: RL,
+  interactive: boolean = Boolean(process.stdin.isTTY),
+): Promise<boolean> {
+  if (!interactive) return false;
+  info(t("docker.watchtower"));
+  info(t("docker.watchtowerSocket"));
+  return askYN(rl, t("docker.autoUpdate"), false);
+}
+
+/**
+ * Profiles to pass to `docker compose up` so the sidecars chosen during
+ * onboarding start (ollama and watchtower stay opt-in).
+ */
+export function activeComposeProfiles(options: DockerComposeOptions): ComposeProfile[] {
+  if (!options.profiles) return [];
+  return [
+    ...(options.tunnel ? ["tunnel" as const] : []),
+    ...(options.oidcProxy || options.reverseProxy ? ["proxy" as const] : []),
+    ...(options.watchtower ? ["watchtower" as const] : []),
+  ];
+}
+
+const IMAGE_PLATFORM = /^[a-z0-9]+\/[a-z0-9_]+(\/v[0-9]+)?$/;
+
+/** `--platform` value: "linux/amd64", "linux/arm/v7", ... */
+export function parseImagePlatform(value: string | undefined): string | undefined {
+  if (value === undefined || value.trim() === "") return undefined;
+  const platform = value.trim().toLowerCase();
+  if (IMAGE_PLATFORM.test(platform)) return platform;
+  throw new Error(`Invalid platform "${value}" (expected os/arch, e.g. linux/amd64 or linux/arm64)`);
+}
+
+/** `docker compose up -d` with the active profiles */
+export function composeUpCommand(options: DockerComposeOptions): string {
+  const flags = activeComposeProfiles(options).map((p) => ` --profile ${p}`).join("");
+  return `docker compose${flags} up -d`;
+}
+
+/**
+ * The add-on services for `options`, in file order. With profiles on, ollama
+ * and watchtower are always listed (opt-in at `up` time). Their container
+ * names follow the bot's, so two installs don't collide.
+ */
+export function composeAddOns(
+  dockerConfigPath: string,
+  gatewayPort: string,
+  options: DockerComposeOptions = {},
+): ComposeService[] {
+  const containerName = options.containerName ?? "owliabot";
+  const gatewayUpstream = options.oidcProxy
+    ? OIDC_PROXY_UPSTREAM
+    : `${options.gatewayTls ? "https" : "http"}://owliabot:8787`;
+  const services: ComposeService[] = [];
+  if (options.oidcProxy) {
+    services.push({ name: "oauth2-proxy", block: buildOidcProxyComposeService(dockerConfigPath, gatewayPort), profile: "proxy" });
+  }
+  if (options.reverseProxy) {
+    services.push({
+      name: options.reverseProxy.proxy,
+      block: buildReverseProxyComposeService(options.reverseProxy, dockerConfigPath),
+      profile: "proxy",
+    });
+  }
+  if (options.tunnel) {
+    services.push({ name: "tunnel", block: buildTunnelComposeService(options.tunnel, dockerConfigPath, gatewayUpstream), profile: "tunnel" });
+  }
+  if (options.profiles) {
+    services.push({ name: "ollama", block: buildOllamaComposeService(dockerConfigPath), profile: "ollama" });
+  }
+  if (options.profiles || options.watchtower) {
+    services.push({ name: "watchtower", block: buildWatchtowerComposeService(containerName), profile: "watchtower" });
+  }
+  return services.map((service) => ({
+    ...service,
+    block: service.block.replace(/container_name: owliabot-/g, `container_name: ${containerName}-`),
+  }));
+}
+
+/** Service blocks for `services:`, each in its profile when profiles are on */
+export function renderComposeServices(services: ComposeService[], options: DockerComposeOptions = {}): string {
+  return services
+    .map((service) => (options.profiles && service.profile ? withComposeProfile(service.block, service.profile) : service.block))
+    .join("");
+}
+
 /**
  * Build docker-compose.yml content.
  */
@@ -97,36 +308,91 @@ export function buildDockerComposeYaml(
   envLines: string[],
   gatewayPort: string,
   defaultImage: string,
+  options: DockerComposeOptions = {},
 ): string {
-  const envBlock = envLines.length > 0
-    ? envLines.map((v) => `      - ${v}`).join("\n")
-    : "      - TZ=UTC";
+  // /health stays reachable without credentials; only the scheme changes with TLS.
+  const healthcheck = options.gatewayTls
+    ? `["CMD", "wget", "-qO-", "--no-check-certificate", "https://localhost:8787/health"]`
+    : `["CMD", "wget", "-qO-", "http://localhost:8787/health"]`;
+  // With OIDC or a reverse proxy, the proxy owns the host port(s) and the gateway is only reachable inside compose.
+  const ports = options.oidcProxy || options.reverseProxy
+    ? ""
+    : `    ports:
+      - "127.0.0.1:${gatewayPort}:8787"
+`;
+  const containerName = options.containerName ?? "owliabot";
+  const platform = options.platform ? `    platform: ${options.platform}\n` : "";
+  const profilesNote = options.profiles
+    ? `#
+# Optional services are in compose profiles and only start when enabled:
+#   ollama      local models, reachable from the bot at http://ollama:11434/v1
+#   watchtower  pulls new images and restarts the bot
+#   tunnel, proxy  (only present when set up during onboarding)
+# Start with: ${composeUpCommand(options)}
+# and add --profile <name> (or set COMPOSE_PROFILES) for more.
+`
+    : options.watchtower
+      ? `#
+# watchtower pulls new images and restarts the bot; delete the service to stop that.
+`
+      : "";
+  const env = [
+    ...envLines,
+    ...(options.secretsKey ? [`OWLIABOT_SECRETS_KEY_FILE=${CONTAINER_SECRETS_KEY_PATH}`] : []),
+    ...(options.caBundle ? [`NODE_EXTRA_CA_CERTS=${CONTAINER_CA_BUNDLE_PATH}`] : []),
+  ];
+  const envBlock = [
+    ...(env.length > 0 ? env : ["TZ=UTC"]),
+    // Lets the gateway record which image reached ready (last known good for rollback).
+    `OWLIABOT_IMAGE_REF=\${OWLIABOT_IMAGE:-${defaultImage}}`,
+    // OWLIABOT_SAFE_MODE=1 docker compose up -d: no write tools, exec or MCP (see config/safe-mode.ts)
+    "OWLIABOT_SAFE_MODE=${OWLIABOT_SAFE_MODE:-}",
+  ].map((v) => `      - ${v}`).join("\n");
+  const keyMount = [
+    options.secretsKey ? `      - ${dockerConfigPath}/auth/secrets.agekey:${CONTAINER_SECRETS_KEY_PATH}:ro\n` : "",
+    options.caBundle ? `      - ${dockerConfigPath}/${CA_BUNDLE_FILE}:${CONTAINER_CA_BUNDLE_PATH}:ro\n` : "",
+  ].join("");
+  const envFile = options.envFile
+    ? `    env_file:
+      - ${options.envFile}
+`
+    : "";
   // Intentionally use `~` in docker-compose.yml so the file is portable and resolves
   // to the host user's home directory.
-  return `# docker-compose.yml for OwliaBot
-# Generated by onboard
-
-services:
+  const bot: ComposeService = {
+    name: "owliabot",
+    block: `
   owliabot:
     image: \${OWLIABOT_IMAGE:-${defaultImage}}
-    container_name: owliabot
+${platform}    container_name: ${containerName}
     restart: unless-stopped
-    ports:
-      - "127.0.0.1:${gatewayPort}:8787"
-    volumes:
+${ports}    volumes:
       - ${dockerConfigPath}:/home/owliabot/.owliabot
       # Legacy compatibility: older configs may use workspace: /app/workspace
       - ${dockerConfigPath}/workspace:/app/workspace
-    environment:
+${keyMount}${envFile}    environment:
 ${envBlock}
     command: ["start", "-c", "/home/owliabot/.owliabot/app.yaml"]
     healthcheck:
-      test: ["CMD", "wget", "-qO-", "http://localhost:8787/health"]
+      test: ${healthcheck}
       interval: 5s
       timeout: 3s
       retries: 3
       start_period: 10s
-`;
+`,
+  };
+  const services = [bot, ...composeAddOns(dockerConfigPath, gatewayPort, options)];
+  const projectName = options.projectName ? `name: ${options.projectName}\n` : "";
+  const demoNote = options.demo
+    ? `#
+# DEMO MODE: the bot answers with the demo provider (echo, no model, no API keys).
+# Run onboarding again to set up a real provider before relying on it.
+`
+    : "";
+  return `# docker-compose.yml for OwliaBot
+# Generated by onboard
+${demoNote}${profilesNote}
+${projectName}services:${renderComposeServices(services, options)}`;
 }
 
 /**
@@ -176,13 +442,25 @@ export function writeDockerCompose(
   envLines: string[],
   gatewayPort: string,
   defaultImage: string,
+  options: DockerComposeOptions = {},
 ): void {
-  const composePath = join(paths.outputDir, "docker-compose.yml");
+  const composePath = dockerComposePath(paths);
   writeFileSync(
     composePath,
-    buildDockerComposeYaml(dockerConfigPath, envLines, gatewayPort, defaultImage),
+    buildDockerComposeYaml(dockerConfigPath, envLines, gatewayPort, defaultImage, options),
   );
-  success(`Saved docker-compose.yml in ${composePath}`);
+  success(t("docker.saved", { file: paths.composeFile ?? "docker-compose.yml", path: composePath }));
+}
+
+/**
+ * Name the last image that reached "Gateway ready" under this config dir,
+ * with the command to go back to it if the new one doesn't come up.
+ */
+export function printImageRollbackHint(history: ImageHistoryEntry[], deploying: string): void {
+  const lastGood = lastKnownGoodImage(history, deploying);
+  if (!lastGood) return;
+  info(t("docker.lastGood", { image: lastGood }));
+  info(t("docker.rollback", { image: deploying, command: `OWLIABOT_IMAGE=${lastGood} docker compose up -d` }));
 }
 
 /**
@@ -198,6 +476,7 @@ export function printDockerNextSteps(
   useAnthropic: boolean,
   useOpenaiCodex: boolean,
   secrets: SecretsConfig,
+  publicUrl?: string,
 ): void {
   const C = COLORS;
 
@@ -210,27 +489,52 @@ export function printDockerNextSteps(
     for (const ch of plain) {
       // Emoji and wide CJK chars take 2 columns; basic check via code point
       const cp = ch.codePointAt(0)!;
-      w += cp > 0x1f600 || (cp >= 0x2600 && cp <= 0x27bf) || (cp >= 0x1f300 && cp <= 0x1faff) ? 2 : 1;
+      const emoji = cp > 0x1f600 || (cp >= 0x2600 && cp <= 0x27bf) || (cp >= 0x1f300 && cp <= 0x1faff);
+      const cjk = (cp >= 0x1100 && cp <= 0x115f) || (cp >= 0x2e80 && cp <= 0xa4cf) || (cp >= 0xac00 && cp <= 0xd7a3)
+        || (cp >= 0xf900 && cp <= 0xfaff) || (cp >= 0xfe30 && cp <= 0xfe4f) || (cp >= 0xff00 && cp <= 0xff60);
+      w += emoji || cjk ? 2 : 1;
     }
     return w;
   };
 
-  const composePath = join(paths.outputDir, "docker-compose.yml");
+  const composePath = dockerComposePath(paths);
   const tokenShort = gatewayToken.slice(0, 8) + "...";
 
+  // Labels are padded to the widest in their group; translations differ in width
+  const aligned = (fields: [string, string][], gap: number) => {
+    const width = Math.max(...fields.map(([label]) => visWidth(label)));
+    return fields.map(([label, value]) => `${label}${" ".repeat(width - visWidth(label) + gap)}${value}`);
+  };
+
   // Build content lines first, then compute box width
+  const files = aligned([
+    [t("docker.summary.config"), `${paths.shellConfigPath}/app.yaml`],
+    [t("docker.summary.secrets"), `${paths.shellConfigPath}/secrets.yaml`],
+    [t("docker.summary.auth"), `${paths.shellConfigPath}/auth/`],
+    [t("docker.summary.workspace"), `${paths.shellConfigPath}/workspace/`],
+    [t("docker.summary.compose"), composePath],
+  ], 2);
+  const gateway = aligned([
+    [`${t("docker.summary.url")}:`, `${C.GREEN}http://localhost:${gatewayPort}${C.NC}`],
+    [`${t("docker.summary.token")}:`, `${C.YELLOW}${tokenShort}${C.NC}`],
+    ...(publicUrl ? [[`${t("docker.summary.public")}:`, `${C.GREEN}${publicUrl}${C.NC}`] as [string, string]] : []),
+  ], 1);
   const rows: string[] = [
-    "📁 Config     ~/.owliabot/app.yaml",
-    "🔐 Secrets    ~/.owliabot/secrets.yaml",
-    "🔑 Auth       ~/.owliabot/auth/",
-    "📂 Workspace  ~/.owliabot/workspace/",
-    `🐳 Compose    ${composePath}`,
+    ...["📁", "🔐", "🔑", "📂", "🐳"].map((icon, i) => `${icon} ${files[i]}`),
     "",
-    `${C.CYAN}🌐 Gateway${C.NC}`,
-    `   URL:   ${C.GREEN}http://localhost:${gatewayPort}${C.NC}`,
-    `   Token: ${C.YELLOW}${tokenShort}${C.NC}`,
+    `${C.CYAN}🌐 ${t("docker.summary.gateway")}${C.NC}`,
+    ...gateway.map((row) => `   ${row}`),
   ];
-  const titleRow = `${C.GREEN}✅  Setup Complete${C.NC}`;
+  const titleRow = `${C.GREEN}✅  ${t("docker.summary.title")}${C.NC}`;
+
+  if (isAccessible()) {
+    // Plain lines: no box, and no emoji for a screen reader to spell out
+    console.log("");
+    console.log(t("docker.summary.plainTitle"));
+    for (const row of rows) console.log(row.replace(/^\S+\s+(?=\S)/u, "").replace(/^\s+/, ""));
+    console.log("");
+    return;
+  }
 
   // Box inner width = max visible width of any row + 4 (2 padding each side)
   const maxVis = Math.max(visWidth(titleRow), ...rows.map(visWidth));
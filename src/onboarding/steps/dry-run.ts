@@ -0,0 +1,261 @@
+/**
+ * Step module: dry-run preview.
+ *
+ * Renders the files onboarding would write and prints them (with a line diff
+ * against whatever is already on disk) instead of writing anything. A normal
+ * run shows the same diff for files it is about to overwrite and asks first.
+ */
+
+import { existsSync, readFileSync } from "node:fs";
+import { createInterface } from "node:readline";
+import { join } from "node:path";
+import { parse, stringify } from "yaml";
+import type { AppConfig } from "../types.js";
+import { getSecretsPath, type SecretsConfig } from "../secrets.js";
+import { isEncryptedSecrets } from "../../config/secrets-crypto.js";
+import { AbortError, askYN, header, info, COLORS } from "../shared.js";
+import { injectDemoComment, injectTimezoneComment, readMergedAppConfig } from "./helpers.js";
+import { buildDockerComposeYaml, dockerComposePath, type DockerComposeOptions, type DockerPaths } from "./docker.js";
+import { t } from "../i18n.js";
+
+export interface RenderedFile {
+  /** Absolute (or output-dir relative) path the file would be written to */
+  path: string;
+  /** File content exactly as it would be written */
+  content: string;
+  /** True when the file holds secrets and must be masked before printing */
+  secret?: boolean;
+  /** Printed content differs from what is written (masked inline secrets); not diffed before overwriting */
+  previewOnly?: boolean;
+}
+
+/**
+ * Mask a secret value, keeping a short prefix so users can still tell keys apart.
+ */
+export function maskSecretValue(value: string): string {
+  if (value.length <= 8) return "****";
+  return `${value.slice(0, 4)}****`;
+}
+
+/**
+ * Return a deep copy of a secrets object with every string value masked.
+ */
+export function maskSecrets<T>(value: T): T {
+  if (typeof value === "string") return maskSecretValue(value) as T;
+  if (Array.isArray(value)) return value.map((v) => maskSecrets(v)) as T;
+  if (value && typeof value === "object") {
+    const out: Record<string, unknown> = {};
+    for (const [k, v] of Object.entries(value)) out[k] = maskSecrets(v);
+    return out as T;
+  }
+  return value;
+}
+
+/**
+ * Render app.yaml the same way saveAppConfigWithComments() writes it,
+ * merged into the file already at `path` when there is one.
+ */
+export function renderAppConfigYaml(config: AppConfig, path?: string): string {
+  const merged = path ? readMergedAppConfig(config, path) : null;
+  return injectDemoComment(injectTimezoneComment(merged ?? stringify(config, { indent: 2 })));
+}
+
+/**
+ * Render secrets.yaml the same way saveSecrets() writes it.
+ */
+export function renderSecretsYaml(secrets: SecretsConfig): string {
+  return stringify(secrets, { indent: 2 });
+}
+
+/**
+ * Build the list of files a dev-mode run would write.
+ */
+export function renderDevFiles(
+  config: AppConfig,
+  secrets: SecretsConfig,
+  appConfigPath: string,
+): RenderedFile[] {
+  const files: RenderedFile[] = [{ path: appConfigPath, content: renderAppConfigYaml(config, appConfigPath) }];
+  if (Object.keys(secrets).length > 0) {
+    files.push({ path: getSecretsPath(appConfigPath), content: renderSecretsYaml(secrets), secret: true });
+  }
+  return files;
+}
+
+/**
+ * Build the list of files a docker-mode run would write.
+ */
+export function renderDockerFiles(
+  paths: DockerPaths,
+  config: AppConfig,
+  secrets: SecretsConfig,
+  envLines: string[],
+  gatewayPort: string,
+  defaultImage: string,
+  composeOptions: DockerComposeOptions = {},
+): RenderedFile[] {
+  const files = renderDevFiles(config, secrets, join(paths.configDir, "app.yaml"));
+  files.push({
+    path: dockerComposePath(paths),
+    content: buildDockerComposeYaml(paths.dockerConfigPath, envLines, gatewayPort, defaultImage, composeOptions),
+  });
+  return files;
+}
+
+/**
+ * Compute a line-based diff between two texts.
+ * Each output line is prefixed with "+ ", "- " or "  ".
+ * Runs of unchanged lines longer than 2 * context are collapsed to "  …".
+ */
+export function formatLineDiff(before: string, after: string, context = 3): string[] {
+  const a = before.split("\n");
+  const b = after.split("\n");
+
+  // Longest common subsequence table (configs are small, O(n*m) is fine)
+  const lcs: number[][] = Array.from({ length: a.length + 1 }, () => new Array<number>(b.length + 1).fill(0));
+  for (let i = a.length - 1; i >= 0; i--) {
+    for (let j = b.length - 1; j >= 0; j--) {
+      lcs[i][j] = a[i] === b[j] ? lcs[i + 1][j + 1] + 1 : Math.max(lcs[i + 1][j], lcs[i][j + 1]);
+    }
+  }
+
+  const ops: Array<{ op: " " | "+" | "-"; line: string }> = [];
+  let i = 0;
+  let j = 0;
+  while (i < a.length && j < b.length) {
+    if (a[i] === b[j]) {
+      ops.push({ op: " ", line: a[i] });
+      i++;
+      j++;
+    } else if (lcs[i + 1][j] >= lcs[i][j + 1]) {
+      ops.push({ op: "-", line: a[i++] });
+    } else {
+      ops.push({ op: "+", line: b[j++] });
+    }
+  }
+  while (i < a.length) ops.push({ op: "-", line: a[i++] });
+  while (j < b.length) ops.push({ op: "+", line: b[j++] });
+
+  // Collapse long unchanged runs, keeping `context` lines around each change
+  const out: string[] = [];
+  let k = 0;
+  while (k < ops.length) {
+    if (ops[k].op !== " ") {
+      out.push(`${ops[k].op} ${ops[k].line}`);
+      k++;
+      continue;
+    }
+    let end = k;
+    while (end < ops.length && ops[end].op === " ") end++;
+    const run = ops.slice(k, end);
+    const keepHead = k === 0 ? 0 : context;
+    const keepTail = end === ops.length ? 0 : context;
+    if (run.length > keepHead + keepTail) {
+      for (const r of run.slice(0, keepHead)) out.push(`  ${r.line}`);
+      out.push("  …");
+      for (const r of run.slice(run.length - keepTail)) out.push(`  ${r.line}`);
+    } else {
+      for (const r of run) out.push(`  ${r.line}`);
+    }
+    k = end;
+  }
+  return out;
+}
+
+function readExisting(file: RenderedFile): string | null {
+  if (!existsSync(file.path)) return null;
+  try {
+    const raw = readFileSync(file.path, "utf-8");
+    if (!file.secret) return raw;
+    return renderSecretsYaml(maskSecrets((parse(raw) ?? {}) as SecretsConfig));
+  } catch {
+    return null;
+  }
+}
+
+function colorizeDiffLine(line: string): string {
+  if (line.startsWith("+ ")) return `${COLORS.GREEN}${line}${COLORS.NC}`;
+  if (line.startsWith("- ")) return `${COLORS.RED}${line}${COLORS.NC}`;
+  return line;
+}
+
+function printableContent(file: RenderedFile): string {
+  return file.secret
+    ? renderSecretsYaml(maskSecrets((parse(file.content) ?? {}) as SecretsConfig))
+    : file.content;
+}
+
+/**
+ * Print each rendered file, or a diff against the existing file on disk.
+ * Secret files are always masked before printing.
+ */
+export function printDryRunPreview(files: RenderedFile[]): void {
+  for (const file of files) {
+    header(t("dryRun.header", { path: file.path }));
+    const content = printableContent(file);
+    const existing = readExisting(file);
+
+    if (existing === null) {
+      info(t("dryRun.newFile"));
+      console.log(content.trimEnd());
+      continue;
+    }
+    if (existing === content) {
+      info(t("dryRun.noChanges"));
+      continue;
+    }
+    info(t("dryRun.changes"));
+    for (const line of formatLineDiff(existing.trimEnd(), content.trimEnd())) {
+      console.log(colorizeDiffLine(line));
+    }
+  }
+}
+
+/** An age-encrypted secrets.yaml can't be compared without decrypting it */
+function isEncryptedOnDisk(file: RenderedFile): boolean {
+  if (!file.secret || !existsSync(file.path)) return false;
+  try {
+    return isEncryptedSecrets(readFileSync(file.path, "utf-8"));
+  } catch {
+    return false;
+  }
+}
+
+/**
+ * Existing files the write would change, each with its diff lines.
+ * New files and unchanged ones are left out.
+ */
+export function diffExistingFiles(files: RenderedFile[]): Array<{ path: string; lines: string[] }> {
+  const changed: Array<{ path: string; lines: string[] }> = [];
+  for (const file of files) {
+    if (file.previewOnly || isEncryptedOnDisk(file)) continue;
+    const existing = readExisting(file);
+    const content = printableContent(file);
+    if (existing === null || existing === content) continue;
+    changed.push({ path: file.path, lines: formatLineDiff(existing.trimEnd(), content.trimEnd()) });
+  }
+  return changed;
+}
+
+/**
+ * Before overwriting: show what changes in each existing file and ask to go
+ * on. Declining aborts the run with nothing written. Silent when no existing
+ * file changes.
+ */
+export async function confirmOverwrites(
+  rl: ReturnType<typeof createInterface>,
+  files: RenderedFile[],
+): Promise<void> {
+  const changed = diffExistingFiles(files);
+  if (changed.length === 0) return;
+
+  for (const { path, lines } of changed) {
+    header(t("dryRun.changesTo", { path }));
+    for (const line of lines) console.log(colorizeDiffLine(line));
+  }
+  console.log("");
+  const question = changed.length === 1 ? t("dryRun.overwriteOne") : t("dryRun.overwriteMany", { count: changed.length });
+  if (!(await askYN(rl, question, true))) {
+    throw new AbortError("Declined to overwrite existing files");
+  }
+}
@@ -0,0 +1,107 @@
+/**
+ * Step module: hand secrets to the container through a .env file.
+ *
+ * `--secrets-env` (docker mode) moves every key and token collected so far
+ * into a .env file next to docker-compose.yml, which the service loads with
+ * `env_file:`. app.yaml refers to them with `apiKey: env` (or leaves the
+ * token unset), so no secrets.yaml is written. The same variables can come
+ * from any orchestrator that injects env instead.
+ */
+
+import { existsSync, writeFileSync } from "node:fs";
+import { join } from "node:path";
+import type { AppConfig } from "../types.js";
+import type { SecretsConfig } from "../secrets.js";
+import { header, success, warn } from "../shared.js";
+import { t } from "../i18n.js";
+
+export const ENV_FILE = ".env";
+
+/**
+ * Move secrets into env variables and point config at them. Mutates config
+ * and secrets (which ends up empty). Returns the variables in file order.
+ */
+export function moveSecretsToEnv(config: AppConfig, secrets: SecretsConfig): Record<string, string> {
+  const vars: Record<string, string> = {};
+
+  for (const provider of config.providers) {
+    if (provider.apiKey !== "secrets") continue;
+    if (provider.id === "anthropic") {
+      const value = secrets.anthropic?.token ?? secrets.anthropic?.apiKey;
+      if (value) vars.ANTHROPIC_API_KEY = value;
+    } else if (provider.id === "openai") {
+      if (secrets.openai?.apiKey) vars.OPENAI_API_KEY = secrets.openai.apiKey;
+    } else if (provider.id === "openai-compatible") {
+      if (secrets["openai-compatible"]?.apiKey) vars.OPENAI_COMPATIBLE_API_KEY = secrets["openai-compatible"].apiKey;
+    } else if (provider.id === "azure-openai") {
+      if (secrets["azure-openai"]?.apiKey) vars.AZURE_OPENAI_API_KEY = secrets["azure-openai"].apiKey;
+    } else if (provider.id === "amazon-bedrock") {
+      const keys = secrets["amazon-bedrock"];
+      if (keys?.accessKeyId) vars.AWS_ACCESS_KEY_ID = keys.accessKeyId;
+      if (keys?.secretAccessKey) vars.AWS_SECRET_ACCESS_KEY = keys.secretAccessKey;
+    } else {
+      continue;
+    }
+    provider.apiKey = "env";
+  }
+
+  // Channel tokens and the gateway credentials already fall back to env in
+  // the loader when secrets.yaml doesn't have them.
+  if (config.discord && secrets.discord?.token) vars.DISCORD_BOT_TOKEN = secrets.discord.token;
+  if (config.telegram && secrets.telegram?.token) vars.TELEGRAM_BOT_TOKEN = secrets.telegram.token;
+  if (config.slack && secrets.slack?.botToken) vars.SLACK_BOT_TOKEN = secrets.slack.botToken;
+  if (config.slack && secrets.slack?.appToken) vars.SLACK_APP_TOKEN = secrets.slack.appToken;
+  if (config.webhook && secrets.webhook?.secret) vars.OWLIABOT_WEBHOOK_SECRET = secrets.webhook.secret;
+  if (config.mcp?.presets?.includes("github") && secrets.github?.token) {
+    vars.GITHUB_PERSONAL_ACCESS_TOKEN = secrets.github.token;
+  }
+  if (secrets.clawlet?.token) vars.CLAWLET_TOKEN = secrets.clawlet.token;
+  if (secrets.gateway?.token) vars.OWLIABOT_GATEWAY_TOKEN = secrets.gateway.token;
+  if (secrets.gateway?.basicAuthPassword) vars.OWLIABOT_GATEWAY_PASSWORD = secrets.gateway.basicAuthPassword;
+
+  for (const key of Object.keys(secrets) as Array<keyof SecretsConfig>) delete secrets[key];
+  return vars;
+}
+
+/**
+ * Drop `KEY=${KEY}` compose lines for variables the env file already sets.
+ */
+export function withoutEnvFileKeys(envLines: string[], vars: Record<string, string>): string[] {
+  return envLines.filter((line) => !(line.split("=", 1)[0] in vars));
+}
+
+/**
+ * Build .env content (compose env_file syntax: KEY=value, no quoting).
+ */
+export function buildEnvFile(vars: Record<string, string>): string {
+  const lines = Object.entries(vars).map(([key, value]) => `${key}=${value}`);
+  return `# OwliaBot secrets, loaded by docker-compose.yml (env_file)
+# Generated by onboard. Keep this file out of version control.
+${lines.join("\n")}
+`;
+}
+
+/**
+ * Write .env next to docker-compose.yml, readable by the owner only.
+ */
+export function writeEnvFile(outputDir: string, vars: Record<string, string>): string {
+  const envPath = join(outputDir, ENV_FILE);
+  writeFileSync(envPath, buildEnvFile(vars), { mode: 0o600 });
+  success(t("common.savedSecrets", { path: envPath }));
+  return envPath;
+}
+
+/**
+ * Where the secrets went, plus a warning when an older secrets.yaml would
+ * still take precedence over the env values.
+ */
+export function printEnvFileSummary(envPath: string, vars: Record<string, string>, configDir: string): void {
+  header(t("envFile.header"));
+  console.log(`  ${Object.keys(vars).join(", ") || t("envFile.none")} -> ${envPath}`);
+  console.log(`  ${t("envFile.orchestrator")}`);
+  const staleSecrets = join(configDir, "secrets.yaml");
+  if (existsSync(staleSecrets)) {
+    warn(t("envFile.stale", { path: staleSecrets }));
+  }
+  console.log("");
+}
@@ -0,0 +1,234 @@
+/**
+ * Step module: per-environment docker output (dev / staging / prod).
+ *
+ * `--environments dev,prod` renders one variant per name from the same
+ * answers. Each variant gets its own config dir (~/.owliabot-<name>) and
+ * compose file (docker-compose.<name>.yml), with overrides for image tag,
+ * host port, log level and agent loop budgets.
+ */
+
+import { createInterface } from "node:readline";
+import { mkdirSync, writeFileSync } from "node:fs";
+import { join } from "node:path";
+import type { AppConfig } from "../types.js";
+import type { SecretsConfig } from "../secrets.js";
+import { header, info, success, ask } from "../shared.js";
+import {
+  buildDockerComposeYaml,
+  buildDockerEnvLines,
+  tryMakeTreeWritableForDocker,
+  type DockerComposeOptions,
+  type DockerPaths,
+} from "./docker.js";
+import { writeDockerConfigLocalStyle } from "./writers.js";
+import { initDevWorkspace } from "./init-dev-workspace.js";
+import { renderDevFiles, type RenderedFile } from "./dry-run.js";
+import { applyGatewayAuth, type GatewayAuthMode, type GatewayAuthResult } from "./gateway-auth.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export type EnvironmentLogLevel = "info" | "debug";
+
+export interface EnvironmentVariant {
+  name: string;
+  imageTag: string;
+  gatewayPort: string;
+  logLevel: EnvironmentLogLevel;
+  /** agents.loop.maxIterations */
+  maxIterations: number;
+  /** agents.loop.timeoutSeconds */
+  timeoutSeconds: number;
+}
+
+/** Everything needed to write (or preview) one environment. */
+export interface PreparedEnvironment {
+  variant: EnvironmentVariant;
+  paths: DockerPaths;
+  config: AppConfig;
+  secrets: SecretsConfig;
+  composePath: string;
+  compose: string;
+  /** Gateway auth as applied to this environment's config */
+  gatewayAuth: GatewayAuthResult;
+}
+
+const ENV_NAME = /^[a-z][a-z0-9-]{0,30}$/;
+
+/**
+ * Parse a comma-separated environment list ("dev,prod").
+ */
+export function parseEnvironmentNames(value: string | undefined): string[] | undefined {
+  if (value === undefined) return undefined;
+  const names = value.split(",").map((n) => n.trim().toLowerCase()).filter(Boolean);
+  if (names.length === 0) throw new Error("--environments needs at least one name (e.g. dev,prod)");
+  for (const name of names) {
+    if (!ENV_NAME.test(name)) {
+      throw new Error(`Invalid environment name "${name}" (use lowercase letters, digits and dashes)`);
+    }
+  }
+  if (new Set(names).size !== names.length) throw new Error("--environments contains duplicate names");
+  return names;
+}
+
+export function defaultEnvironmentVariant(name: string, index: number, basePort: string): EnvironmentVariant {
+  const port = Number.parseInt(basePort, 10) || 8787;
+  const isDev = name === "dev" || name === "development";
+  return {
+    name,
+    imageTag: "latest",
+    gatewayPort: String(port + index),
+    logLevel: isDev ? "debug" : "info",
+    maxIterations: isDev ? 20 : 50,
+    timeoutSeconds: 600,
+  };
+}
+
+async function askPositiveInt(rl: RL, q: string, fallback: number): Promise<number> {
+  for (;;) {
+    const raw = (await ask(rl, `${q} [${fallback}]: `)).trim();
+    if (!raw) return fallback;
+    const n = Number(raw);
+    if (Number.isInteger(n) && n > 0) return n;
+    info(t("environments.positiveInt"));
+  }
+}
+
+/**
+ * Ask for the per-environment overrides, defaulting every answer.
+ */
+export async function promptEnvironmentVariants(
+  rl: RL,
+  names: string[],
+  basePort: string,
+  lastGoodImage: (name: string) => string | undefined = () => undefined,
+): Promise<EnvironmentVariant[]> {
+  const variants: EnvironmentVariant[] = [];
+  for (const [index, name] of names.entries()) {
+    const d = defaultEnvironmentVariant(name, index, basePort);
+    header(t("environments.header", { name }));
+
+    const lastGood = lastGoodImage(name);
+    if (lastGood) info(t("environments.lastGood", { name, image: lastGood }));
+    const imageTag = (await ask(rl, t("environments.imageTag", { tag: d.imageTag }))).trim() || d.imageTag;
+    const gatewayPort = String(await askPositiveInt(rl, t("environments.port"), Number(d.gatewayPort)));
+    const level = (await ask(rl, t("environments.logLevel", { level: d.logLevel }))).trim().toLowerCase();
+    const logLevel: EnvironmentLogLevel = level === "debug" || level === "info" ? level : d.logLevel;
+    const maxIterations = await askPositiveInt(rl, t("environments.maxIterations"), d.maxIterations);
+    const timeoutSeconds = await askPositiveInt(rl, t("environments.timeout"), d.timeoutSeconds);
+
+    variants.push({ name, imageTag, gatewayPort, logLevel, maxIterations, timeoutSeconds });
+    success(t("environments.summary", { name, tag: imageTag, port: gatewayPort, level: logLevel }));
+  }
+  return variants;
+}
+
+/**
+ * Config dir and compose paths for one environment (~/.owliabot-<name>).
+ */
+export function environmentDockerPaths(base: DockerPaths, name: string): DockerPaths {
+  return {
+    configDir: `${base.configDir}-${name}`,
+    dockerConfigPath: `${base.dockerConfigPath}-${name}`,
+    shellConfigPath: `${base.shellConfigPath}-${name}`,
+    outputDir: base.outputDir,
+  };
+}
+
+/** Replace the tag of an image reference ("repo/name:tag"). */
+export function withImageTag(image: string, tag: string): string {
+  return `${image.replace(/:[^:/]+$/, "")}:${tag}`;
+}
+
+/**
+ * Build the config, secrets and compose file for every environment.
+ * Configs and secrets are copies, so variants never leak into each other.
+ * With `generate: false` no TLS material is created yet (see writeGatewayTlsMaterial).
+ */
+export function prepareEnvironments(
+  variants: EnvironmentVariant[],
+  basePaths: DockerPaths,
+  config: AppConfig,
+  secrets: SecretsConfig,
+  tz: string,
+  defaultImage: string,
+  gatewayAuth: GatewayAuthMode,
+  opts: { generate?: boolean } = {},
+): PreparedEnvironment[] {
+  return variants.map((variant) => {
+    const paths = environmentDockerPaths(basePaths, variant.name);
+    const envConfig: AppConfig = structuredClone(config);
+    const envSecrets: SecretsConfig = structuredClone(secrets);
+
+    envConfig.agents = {
+      ...envConfig.agents,
+      loop: { maxIterations: variant.maxIterations, timeoutSeconds: variant.timeoutSeconds },
+    };
+    const envGatewayAuth = applyGatewayAuth(gatewayAuth, envConfig, envSecrets, paths.configDir, opts);
+
+    const envLines = [...buildDockerEnvLines(envConfig, envSecrets, tz), `LOG_LEVEL=${variant.logLevel}`];
+    const composeOptions: DockerComposeOptions = {
+      gatewayTls: Boolean(envConfig.gateway?.http?.tls),
+      containerName: `owliabot-${variant.name}`,
+    };
+    const compose = buildDockerComposeYaml(
+      paths.dockerConfigPath,
+      envLines,
+      variant.gatewayPort,
+      withImageTag(defaultImage, variant.imageTag),
+      composeOptions,
+    );
+
+    return {
+      variant,
+      paths,
+      config: envConfig,
+      secrets: envSecrets,
+      composePath: join(paths.outputDir, `docker-compose.${variant.name}.yml`),
+      compose,
+      gatewayAuth: envGatewayAuth,
+    };
+  });
+}
+
+/**
+ * Files a dry run would show for the prepared environments.
+ */
+export function renderEnvironmentFiles(prepared: PreparedEnvironment[]): RenderedFile[] {
+  return prepared.flatMap((env) => [
+    ...renderDevFiles(env.config, env.secrets, join(env.paths.configDir, "app.yaml")),
+    { path: env.composePath, content: env.compose },
+  ]);
+}
+
+/**
+ * Write config dir, workspace and compose file for each environment.
+ */
+export async function writeEnvironments(
+  prepared: PreparedEnvironment[],
+  writeToolAllowList: string[] | null,
+): Promise<void> {
+  for (const env of prepared) {
+    header(t("environments.saving", { name: env.variant.name }));
+    mkdirSync(join(env.paths.configDir, "auth"), { recursive: true });
+    tryMakeTreeWritableForDocker(env.paths.configDir);
+
+    await writeDockerConfigLocalStyle(env.paths, env.config, env.secrets);
+    await initDevWorkspace(join(env.paths.configDir, "workspace"), writeToolAllowList);
+
+    writeFileSync(env.composePath, env.compose);
+    success(t("common.saved", { path: env.composePath }));
+  }
+}
+
+/**
+ * How to start each environment.
+ */
+export function printEnvironmentsNextSteps(prepared: PreparedEnvironment[]): void {
+  header(t("environments.listHeader"));
+  for (const env of prepared) {
+    console.log(`  ${env.variant.name.padEnd(10)} docker compose -f ${env.composePath} up -d`);
+    console.log(`  ${"".padEnd(10)} ${t("environments.where", { url: `http://localhost:${env.variant.gatewayPort}`, dir: env.paths.configDir })}`);
+  }
+  console.log("");
+}
@@ -0,0 +1,141 @@
+/**
+ * Step module: stage tracing for debugging the wizard (`onboard --debug-flow`).
+ *
+ * Reports like "I picked a provider and ended up somewhere else" are hard to
+ * reproduce from the written config alone. With --debug-flow every stage the
+ * wizard enters or skips is logged to stderr together with the answers that
+ * changed since the previous stage (secrets redacted). `--debug-flow <file>`
+ * also writes the path taken as a DOT graph: `dot -Tsvg flow.dot > flow.svg`.
+ */
+
+import { writeFileSync } from "node:fs";
+import { COLORS } from "../shared.js";
+import { redactSecrets } from "../../utils/redact.js";
+
+export type FlowOutcome = "done" | "cancelled" | "failed";
+
+export type FlowEvent =
+  | { kind: "enter"; from: string; to: string; changes: FlowChange[] }
+  | { kind: "skip"; from: string; stage: string; reason: string }
+  | { kind: "end"; from: string; outcome: FlowOutcome };
+
+export interface FlowChange {
+  key: string;
+  before: unknown;
+  after: unknown;
+}
+
+const SECRET_KEY = /token|secret|password|apikey|api_key|credential/i;
+/** Where a key comes from (provider apiKey), not the key itself */
+const KEY_SOURCES = new Set(["secrets", "env", "none", "oauth"]);
+const REDACTED = "[REDACTED]";
+
+/** Copy of an answer with secret-named fields and known secret values masked */
+export function redactAnswer(value: unknown, known: string[] = [], key = ""): unknown {
+  if (value === undefined || value === null) return value;
+  if (key && SECRET_KEY.test(key) && typeof value !== "boolean") {
+    return typeof value === "string" && KEY_SOURCES.has(value) ? value : REDACTED;
+  }
+  if (typeof value === "string") return redactSecrets(value, known);
+  if (Array.isArray(value)) return value.map((v) => redactAnswer(v, known));
+  if (typeof value === "object") {
+    return Object.fromEntries(Object.entries(value).map(([k, v]) => [k, redactAnswer(v, known, k)]));
+  }
+  return value;
+}
+
+function quote(id: string): string {
+  return `"${id.replace(/"/g, '\\"')}"`;
+}
+
+export class FlowTracer {
+  readonly events: FlowEvent[] = [];
+  private current = "start";
+  /** Redacted answers as JSON, to tell which ones changed */
+  private answers: Record<string, string> = {};
+  private ended = false;
+
+  constructor(
+    private readonly enabled: boolean,
+    private readonly dotPath?: string,
+    private readonly knownSecrets: () => string[] = () => [],
+    private readonly log: (line: string) => void = (line) => console.error(line),
+  ) {}
+
+  /**
+   * Move to `stage`. `answers` are the ones collected so far; only those
+   * that differ from the previous stage are logged.
+   */
+  enter(stage: string, answers: Record<string, unknown> = {}): void {
+    if (!this.enabled || this.ended) return;
+    const known = this.knownSecrets();
+    const changes: FlowChange[] = [];
+    for (const [key, value] of Object.entries(answers)) {
+      const after = JSON.stringify(redactAnswer(value, known, key) ?? null);
+      const before = this.answers[key];
+      if (before === after) continue;
+      changes.push({ key, before: before === undefined ? undefined : JSON.parse(before), after: JSON.parse(after) });
+      this.answers[key] = after;
+    }
+    this.events.push({ kind: "enter", from: this.current, to: stage, changes });
+    this.log(`${COLORS.DIM}[flow] ${this.current} → ${stage}${COLORS.NC}`);
+    for (const change of changes) {
+      const before = change.before === undefined ? "(unset)" : JSON.stringify(change.before);
+      this.log(`${COLORS.DIM}[flow]   ${change.key}: ${before} → ${JSON.stringify(change.after)}${COLORS.NC}`);
+    }
+    this.current = stage;
+  }
+
+  /** Record a stage the wizard jumped over, and why (a flag, reused config) */
+  skip(stage: string, reason: string): void {
+    if (!this.enabled || this.ended) return;
+    this.events.push({ kind: "skip", from: this.current, stage, reason });
+    this.log(`${COLORS.DIM}[flow] ${this.current} ⤳ skip ${stage} (${reason})${COLORS.NC}`);
+  }
+
+  /** Close the trace and write the DOT graph, once; later calls are ignored */
+  end(outcome: FlowOutcome): void {
+    if (!this.enabled || this.ended) return;
+    this.events.push({ kind: "end", from: this.current, outcome });
+    this.log(`${COLORS.DIM}[flow] ${this.current} → ${outcome}${COLORS.NC}`);
+    this.ended = true;
+    if (!this.dotPath) return;
+    try {
+      writeFileSync(this.dotPath, this.toDot());
+      this.log(`${COLORS.DIM}[flow] Graph written to ${this.dotPath}${COLORS.NC}`);
+    } catch (err) {
+      this.log(`[flow] Couldn't write ${this.dotPath}: ${(err as Error).message}`);
+    }
+  }
+
+  /** The path taken as a DOT digraph; edges are numbered in visiting order */
+  toDot(): string {
+    const lines = ["digraph onboarding {", "  rankdir=LR;", '  node [shape=box, fontname="Helvetica"];'];
+    let step = 0;
+    for (const event of this.events) {
+      step++;
+      if (event.kind === "enter") {
+        const keys = event.changes.map((c) => c.key).join(", ");
+        const label = keys ? `${step}: ${keys}` : `${step}`;
+        lines.push(`  ${quote(event.from)} -> ${quote(event.to)} [label=${quote(label)}];`);
+      } else if (event.kind === "skip") {
+        lines.push(`  ${quote(event.stage)} [style=dashed];`);
+        lines.push(`  ${quote(event.from)} -> ${quote(event.stage)} [style=dashed, label=${quote(`${step}: ${event.reason}`)}];`);
+      } else {
+        const color = event.outcome === "done" ? "green" : "red";
+        lines.push(`  ${quote(event.outcome)} [shape=oval, color=${color}];`);
+        lines.push(`  ${quote(event.from)} -> ${quote(event.outcome)} [label=${quote(`${step}`)}];`);
+      }
+    }
+    lines.push("}");
+    return `${lines.join("\n")}\n`;
+  }
+}
+
+/**
+ * Tracer for the --debug-flow option: off when unset, logging only for a
+ * bare flag, logging plus a DOT file when given a path.
+ */
+export function createFlowTracer(option: boolean | string | undefined, knownSecrets?: () => string[]): FlowTracer {
+  return new FlowTracer(Boolean(option), typeof option === "string" ? option : undefined, knownSecrets);
+}
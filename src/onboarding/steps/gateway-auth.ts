@@ -0,0 +1,184 @@
+/**
+ * Step module: extra gateway protection (basic auth / mutual TLS).
+ *
+ * The gateway token only guards the /command and /admin routes. When the
+ * gateway is reachable beyond localhost, users can add an outer fence:
+ *   - basic: HTTP basic auth on every route except /health
+ *   - mtls:  HTTPS with a locally generated CA; clients must present a cert
+ *            signed by it
+ */
+
+import { execFileSync } from "node:child_process";
+import { randomBytes } from "node:crypto";
+import { mkdirSync, chmodSync, rmSync } from "node:fs";
+import { join } from "node:path";
+import type { AppConfig } from "../types.js";
+import type { SecretsConfig } from "../secrets.js";
+import { info, success, warn, header, COLORS } from "../shared.js";
+import { t } from "../i18n.js";
+
+export type GatewayAuthMode = "none" | "basic" | "mtls";
+
+export const GATEWAY_AUTH_MODES: GatewayAuthMode[] = ["none", "basic", "mtls"];
+
+/** Directory (relative to the config dir) that holds the generated TLS material */
+export const GATEWAY_TLS_DIR = "tls";
+
+export interface GatewayAuthResult {
+  mode: GatewayAuthMode;
+  /** Basic auth username (mode "basic") */
+  username?: string;
+  /** Absolute path of the TLS directory (mode "mtls") */
+  tlsDir?: string;
+}
+
+export function parseGatewayAuthMode(value: string | undefined): GatewayAuthMode {
+  const mode = (value ?? "none").trim().toLowerCase();
+  if ((GATEWAY_AUTH_MODES as string[]).includes(mode)) return mode as GatewayAuthMode;
+  throw new Error(`Unknown gateway auth mode "${value}" (expected one of: ${GATEWAY_AUTH_MODES.join(", ")})`);
+}
+
+/**
+ * Why `mode` can't be used with the other chosen options, or null when it can.
+ * mTLS needs every client to present a certificate from the local CA: a tunnel,
+ * oauth2-proxy or reverse proxy in front of the gateway has none, and the
+ * Kubernetes manifests don't carry the generated certificates. A reverse proxy
+ * without any mode needs a terminal to offer basic auth on.
+ */
+export function gatewayAuthConflict(
+  mode: GatewayAuthMode,
+  options: { tunnel?: string; oidc?: boolean; reverseProxy?: string; outputFormat?: string; interactive?: boolean },
+): string | null {
+  if (mode === "none" && options.reverseProxy && options.interactive === false) {
+    return "--reverse-proxy exposes the gateway publicly; add --gateway-auth basic";
+  }
+  if (mode !== "mtls") return null;
+  if (options.oidc) return "--oidc cannot be combined with --gateway-auth mtls; use one or the other";
+  if (options.tunnel) {
+    return "--tunnel cannot be combined with --gateway-auth mtls; the tunnel can't present a client certificate (use --gateway-auth basic)";
+  }
+  if (options.reverseProxy) return "--reverse-proxy cannot be combined with --gateway-auth mtls";
+  if (options.outputFormat === "kubernetes") {
+    return "--output-format kubernetes cannot be combined with --gateway-auth mtls; the manifests don't ship the certificates (use --gateway-auth basic)";
+  }
+  return null;
+}
+
+function openssl(args: string[], cwd: string, input?: string): void {
+  execFileSync("openssl", args, { cwd, input, stdio: "pipe" });
+}
+
+/**
+ * Generate a local CA, a server cert (localhost/127.0.0.1) and one client cert
+ * in `tlsDir`. Requires the openssl CLI.
+ */
+export function generateGatewayTlsMaterial(tlsDir: string, days = 825): void {
+  mkdirSync(tlsDir, { recursive: true });
+
+  const subj = (cn: string) => `/O=OwliaBot/CN=${cn}`;
+  const d = String(days);
+
+  // Local CA
+  openssl(["req", "-x509", "-newkey", "rsa:2048", "-nodes", "-keyout", "ca.key", "-out", "ca.crt",
+    "-days", d, "-subj", subj("OwliaBot Local CA")], tlsDir);
+
+  // Server cert, valid for the names the gateway is reached by locally
+  openssl(["req", "-newkey", "rsa:2048", "-nodes", "-keyout", "server.key", "-out", "server.csr",
+    "-subj", subj("localhost")], tlsDir);
+  openssl(["x509", "-req", "-in", "server.csr", "-CA", "ca.crt", "-CAkey", "ca.key", "-CAcreateserial",
+    "-out", "server.crt", "-days", d, "-extfile", "/dev/stdin"], tlsDir,
+    "subjectAltName=DNS:localhost,IP:127.0.0.1\nextendedKeyUsage=serverAuth\n");
+
+  // Client cert for curl / integrations
+  openssl(["req", "-newkey", "rsa:2048", "-nodes", "-keyout", "client.key", "-out", "client.csr",
+    "-subj", subj("owliabot-client")], tlsDir);
+  openssl(["x509", "-req", "-in", "client.csr", "-CA", "ca.crt", "-CAkey", "ca.key", "-CAcreateserial",
+    "-out", "client.crt", "-days", d], tlsDir);
+
+  for (const csr of ["server.csr", "client.csr"]) {
+    try { rmSync(join(tlsDir, csr)); } catch { /* best-effort */ }
+  }
+  for (const key of ["ca.key", "server.key", "client.key"]) {
+    try { chmodSync(join(tlsDir, key), 0o600); } catch { /* best-effort */ }
+  }
+}
+
+/**
+ * Apply the chosen gateway auth mode to the config (and secrets).
+ * `configDir` is the directory app.yaml lives in; TLS paths are written relative to it.
+ * With `generate: false` (dry run) the config is updated but no certificates are created.
+ */
+export function applyGatewayAuth(
+  mode: GatewayAuthMode,
+  config: AppConfig,
+  secrets: SecretsConfig,
+  configDir: string,
+  opts: { generate?: boolean } = {},
+): GatewayAuthResult {
+  if (mode === "none") return { mode };
+
+  const http = config.gateway?.http;
+  if (!http) {
+    warn(t("gatewayAuth.noHttp"));
+    return { mode: "none" };
+  }
+
+  if (mode === "basic") {
+    const username = "owliabot";
+    // Keep a password carried over from the previous run, so configured clients still get in
+    const basicAuthPassword = secrets.gateway?.basicAuthPassword ?? randomBytes(18).toString("base64url");
+    secrets.gateway = { ...secrets.gateway, basicAuthPassword };
+    http.basicAuth = { username, password: "secrets" };
+    success(t("gatewayAuth.basicEnabled", { user: username }));
+    return { mode, username };
+  }
+
+  const result: GatewayAuthResult = { mode, tlsDir: join(configDir, GATEWAY_TLS_DIR) };
+  if (opts.generate !== false) writeGatewayTlsMaterial(result);
+  http.tls = {
+    certPath: `${GATEWAY_TLS_DIR}/server.crt`,
+    keyPath: `${GATEWAY_TLS_DIR}/server.key`,
+    clientCaPath: `${GATEWAY_TLS_DIR}/ca.crt`,
+  };
+  return result;
+}
+
+/**
+ * Create the certificates an mTLS result points at (nothing for other modes).
+ * For callers that applied the mode with `generate: false` and write later.
+ */
+export function writeGatewayTlsMaterial(result: GatewayAuthResult): void {
+  if (!result.tlsDir) return;
+  try {
+    generateGatewayTlsMaterial(result.tlsDir);
+  } catch (err) {
+    throw new Error(
+      `Could not generate TLS certificates with openssl: ${err instanceof Error ? err.message : String(err)}`,
+    );
+  }
+  success(t("gatewayAuth.tlsGenerated", { dir: result.tlsDir }));
+}
+
+/**
+ * Explain how to call the protected gateway.
+ */
+export function printGatewayAuthSummary(result: GatewayAuthResult, gatewayPort: string | number): void {
+  if (result.mode === "none") return;
+  const C = COLORS;
+
+  header(t("gatewayAuth.header"));
+  if (result.mode === "basic") {
+    info(t("gatewayAuth.basicRoutes"));
+    console.log(`  ${C.CYAN}curl -u ${result.username}:<password> -H "x-gateway-token: <token>" http://localhost:${gatewayPort}/status${C.NC}`);
+    info(t("gatewayAuth.basicPassword"));
+    return;
+  }
+
+  info(t("gatewayAuth.mtls"));
+  const dir = result.tlsDir ?? GATEWAY_TLS_DIR;
+  console.log(
+    `  ${C.CYAN}curl --cacert ${dir}/ca.crt --cert ${dir}/client.crt --key ${dir}/client.key \\
+      -H "x-gateway-token: <token>" https://localhost:${gatewayPort}/status${C.NC}`,
+  );
+  info(t("gatewayAuth.caKey"));
+}
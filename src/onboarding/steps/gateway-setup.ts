@@ -8,10 +8,12 @@ import type { AppConfig } from "../types.js";
 import type { SecretsConfig } from "../secrets.js";
 import { info, success, header, ask, askYN } from "../shared.js";
 import type { DetectedConfig, DockerGatewaySetup } from "./types.js";
+import { t } from "../i18n.js";
 
 export async function getGatewayConfig(
   rl: ReturnType<typeof createInterface>,
   dockerMode: boolean,
+  defaultPort: number = 8787,
 ): Promise<AppConfig["gateway"] | undefined> {
   if (dockerMode) {
     return {
@@ -19,17 +21,17 @@ export async function getGatewayConfig(
     };
   }
 
-  header("Gateway HTTP (optional)");
-  info("Gateway HTTP provides a REST API for health checks and integrations.");
+  header(t("gateway.optionalHeader"));
+  info(t("gateway.intro"));
 
-  const enableGateway = await askYN(rl, "Enable Gateway HTTP?", true);
+  const enableGateway = await askYN(rl, t("gateway.enable"), true);
   if (!enableGateway) return undefined;
 
-  const port = parseInt(await ask(rl, "Port [8787]: ") || "8787", 10);
+  const port = parseInt(await ask(rl, t("gateway.port", { port: defaultPort })) || String(defaultPort), 10);
   const token = randomBytes(16).toString("hex");
-  info(`Generated gateway token: ${token.slice(0, 8)}...`);
+  info(t("gateway.tokenGenerated", { token: token.slice(0, 8) }));
 
-  success(`Gateway HTTP enabled on port ${port}`);
+  success(t("gateway.enabled", { port }));
   return { http: { host: "127.0.0.1", port, token } };
 }
 
@@ -39,28 +41,28 @@ export async function configureDockerGatewayAndTimezone(
   reuseExisting: boolean,
   secrets: SecretsConfig,
 ): Promise<DockerGatewaySetup> {
-  header("Gateway HTTP");
-  info("Gateway HTTP is used for health checks and REST API access.");
+  header(t("gateway.header"));
+  info(t("gateway.dockerIntro"));
 
-  const gatewayPort = await ask(rl, "Host port to expose the gateway [8787]: ") || "8787";
+  const gatewayPort = await ask(rl, t("gateway.hostPort")) || "8787";
 
   let gatewayToken = reuseExisting && existing?.gatewayToken ? existing.gatewayToken : "";
   if (!gatewayToken) {
     gatewayToken = randomBytes(16).toString("hex");
-    info("Generated a random gateway token.");
+    info(t("gateway.randomToken"));
   } else {
-    success("Reusing existing Gateway token");
+    success(t("gateway.reusingToken"));
   }
 
-  const confirmToken = await ask(rl, `Gateway token [${gatewayToken.slice(0, 8)}...]: `, true);
+  const confirmToken = await ask(rl, t("gateway.token", { token: gatewayToken.slice(0, 8) }), true);
   if (confirmToken) gatewayToken = confirmToken;
-  success("Gateway token set");
+  success(t("gateway.tokenSet"));
 
   secrets.gateway = { token: gatewayToken };
 
-  header("Other settings");
-  const tz = await ask(rl, "Timezone [UTC]: ") || "UTC";
-  success(`Timezone: ${tz}`);
+  header(t("gateway.otherHeader"));
+  const tz = await ask(rl, t("gateway.timezone")) || "UTC";
+  success(t("gateway.timezoneSet", { tz }));
 
   return { gatewayToken, gatewayPort, tz };
 }
@@ -0,0 +1,143 @@
+/**
+ * Step module: GitHub Actions workflow for config-as-code repos.
+ *
+ * `--github-actions` (docker mode) writes .github/workflows/owliabot-deploy.yml
+ * next to docker-compose.yml. The repo is expected to hold app.yaml and
+ * docker-compose.yml at its root (never secrets.yaml). Every push and pull
+ * request runs `owliabot validate`. Pushes to the deploy branch then copy
+ * both files to the target host over SSH and restart the stack there.
+ */
+
+import { createInterface } from "node:readline";
+import { mkdirSync, writeFileSync } from "node:fs";
+import { dirname, join } from "node:path";
+import { header, info, success, ask, COLORS } from "../shared.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export const GITHUB_WORKFLOW_FILE = join(".github", "workflows", "owliabot-deploy.yml");
+
+/** Repository secrets the workflow reads. */
+export const GITHUB_DEPLOY_SECRETS = [
+  "OWLIABOT_SSH_HOST",
+  "OWLIABOT_SSH_USER",
+  "OWLIABOT_SSH_KEY",
+  "OWLIABOT_SSH_KNOWN_HOSTS",
+] as const;
+
+export interface GithubActionsSetup {
+  /** Branch whose pushes deploy */
+  branch: string;
+  /** Directory on the host that holds docker-compose.yml */
+  remoteComposeDir: string;
+  /** Directory on the host that holds app.yaml (the bind-mounted config dir) */
+  remoteConfigDir: string;
+}
+
+/**
+ * Ask where things live on the target host. app.yaml goes to
+ * `remoteConfigDir`, the config dir docker-compose.yml mounts (e.g.
+ * `~/.owliabot-<profile>` for a profile).
+ */
+export async function promptGithubActionsSetup(rl: RL, remoteConfigDir = "~/.owliabot"): Promise<GithubActionsSetup> {
+  header(t("githubActions.header"));
+  info(t("githubActions.commit"));
+  info(t("githubActions.copies", { dir: remoteConfigDir }));
+  const branch = (await ask(rl, t("githubActions.branch"))).trim() || "main";
+  const remoteComposeDir = (await ask(rl, t("githubActions.composeDir"))).trim() || "~/owliabot";
+  return { branch, remoteComposeDir, remoteConfigDir };
+}
+
+/** `~/x` stays unquoted so the remote shell expands it; everything else is single-quoted. */
+function remotePath(path: string): string {
+  if (path === "~") return "~";
+  const quoted = (p: string) => `'${p.replace(/'/g, "'\\''")}'`;
+  return path.startsWith("~/") ? `~/${quoted(path.slice(2))}` : quoted(path);
+}
+
+/**
+ * Render the workflow.
+ */
+export function buildGithubActionsWorkflow(setup: GithubActionsSetup): string {
+  const composeDir = remotePath(setup.remoteComposeDir);
+  const configDir = remotePath(setup.remoteConfigDir);
+  // Written through the remote shell like every other path here: scp's SFTP
+  // mode would take the quotes literally.
+  const copyTo = (dir: string, name: string) => `ssh -i ~/.ssh/id_deploy "$SSH_TARGET" "cat > ${remotePath(`${dir}/${name}`)}" < ${name}`;
+  const paths = `["app.yaml", "docker-compose.yml", "${GITHUB_WORKFLOW_FILE}"]`;
+
+  return `# Validate and deploy OwliaBot config (generated by onboard)
+# Repository secrets: ${GITHUB_DEPLOY_SECRETS.join(", ")}
+name: Deploy OwliaBot
+
+on:
+  push:
+    branches: ["${setup.branch}"]
+    paths: ${paths}
+  pull_request:
+    paths: ${paths}
+  workflow_dispatch:
+
+jobs:
+  validate:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-node@v4
+        with:
+          node-version: 22
+      - name: Schema check
+        run: npx --yes owliabot@latest validate -c app.yaml
+
+  deploy:
+    needs: validate
+    if: github.event_name != 'pull_request' && github.ref == 'refs/heads/${setup.branch}'
+    runs-on: ubuntu-latest
+    concurrency: owliabot-deploy
+    env:
+      SSH_TARGET: \${{ secrets.OWLIABOT_SSH_USER }}@\${{ secrets.OWLIABOT_SSH_HOST }}
+    steps:
+      - uses: actions/checkout@v4
+      - name: Configure SSH
+        env:
+          SSH_KEY: \${{ secrets.OWLIABOT_SSH_KEY }}
+          SSH_KNOWN_HOSTS: \${{ secrets.OWLIABOT_SSH_KNOWN_HOSTS }}
+        run: |
+          install -m 700 -d ~/.ssh
+          printf '%s\\n' "$SSH_KEY" > ~/.ssh/id_deploy
+          chmod 600 ~/.ssh/id_deploy
+          printf '%s\\n' "$SSH_KNOWN_HOSTS" > ~/.ssh/known_hosts
+      - name: Copy config
+        run: |
+          ssh -i ~/.ssh/id_deploy "$SSH_TARGET" "mkdir -p ${composeDir} ${configDir}"
+          ${copyTo(setup.remoteConfigDir, "app.yaml")}
+          ${copyTo(setup.remoteComposeDir, "docker-compose.yml")}
+      - name: Restart
+        run: ssh -i ~/.ssh/id_deploy "$SSH_TARGET" "cd ${composeDir} && docker compose pull && docker compose up -d"
+`;
+}
+
+/**
+ * Write the workflow under outputDir (creating .github/workflows).
+ */
+export function writeGithubActionsWorkflow(outputDir: string, content: string): string {
+  const path = join(outputDir, GITHUB_WORKFLOW_FILE);
+  mkdirSync(dirname(path), { recursive: true });
+  writeFileSync(path, content);
+  success(t("common.saved", { path }));
+  return path;
+}
+
+/**
+ * What to commit and which secrets to add.
+ */
+export function printGithubActionsNextSteps(workflowPath: string, appConfigPath: string): void {
+  const C = COLORS;
+  header(t("githubActions.nextHeader"));
+  console.log(`  ${t("githubActions.step1", { path: `${C.CYAN}${appConfigPath}${C.NC}` })}`);
+  console.log(`  ${t("githubActions.step2", { path: `${C.CYAN}${workflowPath}${C.NC}` })}`);
+  console.log(`  ${t("githubActions.step3", { names: GITHUB_DEPLOY_SECRETS.join(", ") })}`);
+  console.log(`  ${t("githubActions.knownHosts", { command: `${C.CYAN}ssh-keyscan <host>${C.NC}` })}`);
+  console.log("");
+}
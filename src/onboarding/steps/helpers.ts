@@ -2,7 +2,9 @@
  * Helper utilities for onboarding
  */
 
-import { readFileSync, writeFileSync } from "node:fs";
+import { existsSync, readFileSync, writeFileSync } from "node:fs";
+import type { AppConfig } from "../types.js";
+import { mergeAppConfigYaml } from "./config-merge.js";
 
 /**
  * Detect the system timezone, falling back to UTC if unavailable.
@@ -18,31 +20,70 @@ export function detectTimezone(): string {
 }
 
 /**
- * Inject a comment above the timezone field in the YAML file.
+ * Inject a comment above the timezone field in the YAML file
+ * (once: a merged app.yaml may already carry it).
  */
 export function injectTimezoneComment(yaml: string): string {
   const comment =
     "# Timezone was auto-detected during setup. Edit this value to override.";
+  if (yaml.includes(comment)) return yaml;
   return yaml.replace(
     /^(timezone:\s*.*)$/m,
     `${comment}\n$1`,
   );
 }
 
+const DEMO_COMMENT = [
+  "# DEMO MODE: the bot answers with the built-in demo provider, which echoes",
+  "# messages back. No model is called and no API key is needed. Run",
+  "# `owliabot onboard` again to set up a real provider.",
+];
+
 /**
- * Save app config with timezone comment injection.
+ * Label an app.yaml that uses the demo provider (onboard --demo) at the top,
+ * and drop the label again once it no longer does.
+ */
+export function injectDemoComment(yaml: string): string {
+  const unlabelled = yaml.startsWith(DEMO_COMMENT[0])
+    ? yaml.split("\n").slice(DEMO_COMMENT.length).join("\n")
+    : yaml;
+  if (!/^\s*-\s+id:\s*["']?demo["']?\s*$/m.test(unlabelled)) return unlabelled;
+  return `${DEMO_COMMENT.join("\n")}\n${unlabelled}`;
+}
+
+/**
+ * Save app config with timezone (and demo) comment injection. An existing app.yaml is
+ * merged into rather than replaced, keeping its comments and unknown keys.
  */
 export async function saveAppConfigWithComments(
   config: any,
   path: string,
   saveAppConfig: (config: any, path: string) => Promise<void>
 ): Promise<void> {
+  const merged = readMergedAppConfig(config, path);
+  if (merged !== null) {
+    writeFileSync(path, injectDemoComment(injectTimezoneComment(merged)), "utf-8");
+    return;
+  }
   await saveAppConfig(config, path);
   try {
     const raw = readFileSync(path, "utf-8");
-    const next = injectTimezoneComment(raw);
+    const next = injectDemoComment(injectTimezoneComment(raw));
     if (next !== raw) writeFileSync(path, next, "utf-8");
   } catch {
     // best-effort
   }
 }
+
+/**
+ * The app.yaml at `path` with `config` merged in, or null when there is no
+ * file to merge into (or it isn't a YAML mapping).
+ */
+export function readMergedAppConfig(config: AppConfig, path: string): string | null {
+  if (!existsSync(path)) return null;
+  try {
+    return mergeAppConfigYaml(readFileSync(path, "utf-8"), config);
+  } catch {
+    return null;
+  }
+}
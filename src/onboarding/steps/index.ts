@@ -13,3 +13,49 @@ export * from "./docker.js";
 export * from "./init-dev-workspace.js";
 export * from "./policy-allowed-users.js";
 export * from "./helpers.js";
+export * from "./dry-run.js";
+export * from "./root-check.js";
+export * from "./bind-path-check.js";
+export * from "./gateway-auth.js";
+export * from "./tunnel.js";
+export * from "./oidc-proxy.js";
+export * from "./environments.js";
+export * from "./kubernetes.js";
+export * from "./systemd.js";
+export * from "./secrets-encryption.js";
+export * from "./keychain-storage.js";
+export * from "./swarm.js";
+export * from "./validation-client.js";
+export * from "./env-file.js";
+export * from "./discord-validation.js";
+export * from "./devcontainer.js";
+export * from "./nix-flake.js";
+export * from "./github-actions.js";
+export * from "./telegram-validation.js";
+export * from "./provider-smoke-test.js";
+export * from "./placeholder-credentials.js";
+export * from "./ollama-discovery.js";
+export * from "./port-check.js";
+export * from "./timezone.js";
+export * from "./access-setup.js";
+export * from "./discord-picker.js";
+export * from "./telegram-discovery.js";
+export * from "./slack-setup.js";
+export * from "./webhook-setup.js";
+export * from "./auto-detect.js";
+export * from "./mcp-custom.js";
+export * from "./mcp-runtime-check.js";
+export * from "./anthropic-login.js";
+export * from "./notify.js";
+export * from "./model-presets.js";
+export * from "./bedrock.js";
+export * from "./azure-openai.js";
+export * from "./flow-trace.js";
+export * from "./provider-priority.js";
+export * from "./ca-bundle.js";
+export * from "./local-run.js";
+export * from "./model-discovery.js";
+export * from "./stage-timing.js";
+export * from "./banner.js";
+export * from "./screen-dump.js";
+export * from "./profile-picker.js";
@@ -5,6 +5,7 @@
 import { success } from "../shared.js";
 import { ensureWorkspaceInitialized } from "../../workspace/init.js";
 import { maybeUpdateWorkspacePolicyAllowedUsers } from "./policy-allowed-users.js";
+import { t } from "../i18n.js";
 
 export async function initDevWorkspace(
   workspace: string,
@@ -13,9 +14,9 @@ export async function initDevWorkspace(
   const workspaceInit = await ensureWorkspaceInitialized({ workspacePath: workspace });
   maybeUpdateWorkspacePolicyAllowedUsers(workspace, writeToolAllowList);
   if (workspaceInit.wroteBootstrap) {
-    success("Created BOOTSTRAP.md for first-run setup");
+    success(t("devWorkspace.bootstrap"));
   }
   if (workspaceInit.copiedSkills && workspaceInit.skillsDir) {
-    success(`Copied bundled skills to: ${workspaceInit.skillsDir}`);
+    success(t("devWorkspace.skills", { dir: workspaceInit.skillsDir }));
   }
 }
@@ -0,0 +1,102 @@
+/**
+ * Step module: keep tokens and provider keys in the OS keychain.
+ *
+ * `--keychain` moves the Anthropic/OpenAI keys and Discord/Telegram tokens
+ * collected so far out of secrets.yaml into the OS keychain, and leaves
+ * "keychain" references in app.yaml (see config/keychain.ts).
+ */
+
+import type { AppConfig } from "../types.js";
+import type { SecretsConfig } from "../secrets.js";
+import {
+  KEYCHAIN_REF,
+  detectKeychainBackend,
+  keychainSet,
+  type KeychainAccount,
+  type KeychainBackend,
+} from "../../config/keychain.js";
+import { header, success, info } from "../shared.js";
+import { t } from "../i18n.js";
+
+const BACKEND_NAMES: Record<KeychainBackend, string> = {
+  macos: "macOS Keychain",
+  windows: "Windows Credential Manager",
+  libsecret: "Secret Service (libsecret)",
+};
+
+export function describeKeychainBackend(backend: KeychainBackend): string {
+  return BACKEND_NAMES[backend];
+}
+
+/**
+ * Fail early when the machine has no usable keychain.
+ */
+export function requireKeychainBackend(): KeychainBackend {
+  const backend = detectKeychainBackend();
+  if (!backend) {
+    throw new Error("--keychain needs an OS keychain (macOS, Windows, or Linux with secret-tool from libsecret-tools)");
+  }
+  return backend;
+}
+
+/**
+ * Move secrets into the keychain and point config at it. Mutates config and
+ * secrets. With `store: false` (dry run) only the references change.
+ * Returns the accounts that were moved.
+ */
+export function moveSecretsToKeychain(
+  config: AppConfig,
+  secrets: SecretsConfig,
+  opts: { store?: boolean; set?: (account: KeychainAccount, value: string) => void } = {},
+): KeychainAccount[] {
+  const set = opts.store === false ? () => {} : (opts.set ?? keychainSet);
+  const moved: KeychainAccount[] = [];
+
+  for (const provider of config.providers) {
+    if (provider.apiKey !== "secrets") continue;
+    if (provider.id === "anthropic") {
+      const value = secrets.anthropic?.token ?? secrets.anthropic?.apiKey;
+      if (!value) continue;
+      set("anthropic", value);
+      delete secrets.anthropic;
+      provider.apiKey = KEYCHAIN_REF;
+      moved.push("anthropic");
+    } else if (provider.id === "openai") {
+      const value = secrets.openai?.apiKey;
+      if (!value) continue;
+      set("openai", value);
+      delete secrets.openai;
+      provider.apiKey = KEYCHAIN_REF;
+      moved.push("openai");
+    }
+  }
+
+  if (config.discord && secrets.discord?.token) {
+    set("discord", secrets.discord.token);
+    delete secrets.discord;
+    config.discord.token = KEYCHAIN_REF;
+    moved.push("discord");
+  }
+  if (config.telegram && secrets.telegram?.token) {
+    set("telegram", secrets.telegram.token);
+    delete secrets.telegram;
+    config.telegram.token = KEYCHAIN_REF;
+    moved.push("telegram");
+  }
+
+  return moved;
+}
+
+/**
+ * Tell the user where the secrets went and how to manage them.
+ */
+export function printKeychainSummary(backend: KeychainBackend, moved: KeychainAccount[]): void {
+  header(t("keychain.header"));
+  if (moved.length === 0) {
+    info(t("keychain.nothing"));
+    return;
+  }
+  success(t("keychain.stored", { names: moved.join(", "), backend: describeKeychainBackend(backend) }));
+  console.log(`  ${t("keychain.manage", { command: "owliabot secrets list | get | set | rotate | delete <name>" })}`);
+  console.log("");
+}
@@ -0,0 +1,178 @@
+/**
+ * Step module: locked-down kiosk preset.
+ *
+ * One choice for bots that minors or an untrusted audience talk to: a
+ * handful of read-only tools, read-only shell commands, no web access, no
+ * write tools for anyone, one chat channel and one user, and history that
+ * is kept for an hour instead of a day. It is applied on top of the answers
+ * already given, so the rest of the wizard stays the same.
+ */
+
+import { createInterface } from "node:readline";
+import type { AppConfig } from "../types.js";
+import { header, info, success, warn, ask, askYN, selectOption, AbortError } from "../shared.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+/** The only tools the model is offered */
+export const KIOSK_TOOLS = ["help", "echo", "list_files", "read_text_file", "exec", "clear_session"];
+
+/** Shell commands that only read */
+export const KIOSK_COMMANDS = ["ls", "cat", "head", "tail", "grep", "wc", "date", "pwd"];
+
+/** Events and gateway history are dropped after this long */
+export const KIOSK_RETENTION_MS = 60 * 60 * 1000;
+
+export type KioskChannel = "discord" | "telegram" | "slack";
+
+export interface KioskAudience {
+  channel: KioskChannel;
+  /** The one user the bot answers */
+  userId: string;
+  /** The one Discord/Slack channel it answers in (Telegram: direct messages only) */
+  channelId?: string;
+}
+
+const CHANNEL_LABELS: Record<KioskChannel, string> = {
+  discord: "Discord",
+  telegram: "Telegram",
+  slack: "Slack",
+};
+
+/**
+ * Offer the preset (interactive runs only; `--kiosk` skips the question).
+ */
+export async function promptKioskPreset(
+  rl: RL,
+  interactive: boolean = Boolean(process.stdin.isTTY),
+): Promise<boolean> {
+  if (!interactive) return false;
+  return askYN(rl, t("kiosk.offer"), false);
+}
+
+/** Chat channels configured so far, in the order the wizard asks for them */
+export function kioskChannels(config: AppConfig): KioskChannel[] {
+  return (["discord", "telegram", "slack"] as KioskChannel[]).filter((c) => config[c] !== undefined);
+}
+
+function allowListed(config: AppConfig, channel: KioskChannel): { users: string[]; channels: string[] } {
+  if (channel === "discord") {
+    return { users: config.discord?.memberAllowList ?? [], channels: config.discord?.channelAllowList ?? [] };
+  }
+  if (channel === "slack") {
+    return { users: config.slack?.memberAllowList ?? [], channels: config.slack?.channelAllowList ?? [] };
+  }
+  return { users: config.telegram?.allowList ?? [], channels: [] };
+}
+
+/** Pick one of `ids`, or ask for one when there are none */
+async function pickOne(rl: RL, ids: string[], what: string): Promise<string> {
+  if (ids.length === 1) return ids[0];
+  if (ids.length > 1) return ids[await selectOption(rl, t("kiosk.whichOne", { what }), ids, 0)];
+  for (let attempt = 0; attempt < 3; attempt++) {
+    const id = (await ask(rl, t("kiosk.theOne", { what }))).trim();
+    if (/^[A-Za-z0-9_-]+$/.test(id)) return id;
+    warn(t("kiosk.notAnId", { id }));
+  }
+  throw new AbortError(`The kiosk preset needs exactly one ${what}`);
+}
+
+/**
+ * Narrow the configured channels down to one channel and one user.
+ */
+export async function promptKioskAudience(rl: RL, config: AppConfig): Promise<KioskAudience> {
+  header(t("kiosk.header"));
+  const channels = kioskChannels(config);
+  if (channels.length === 0) {
+    throw new Error("The kiosk preset needs a Discord, Telegram or Slack channel");
+  }
+  info(t("kiosk.intro"));
+
+  const channel = channels.length === 1
+    ? channels[0]
+    : channels[await selectOption(rl, t("kiosk.whichChannel"), channels.map((c) => CHANNEL_LABELS[c]), 0)];
+  const listed = allowListed(config, channel);
+  const userId = await pickOne(rl, listed.users, t("kiosk.userId", { channel: CHANNEL_LABELS[channel] }));
+  const channelId = channel === "telegram"
+    ? undefined
+    : await pickOne(rl, listed.channels, t("kiosk.channelId", { channel: CHANNEL_LABELS[channel] }));
+  return { channel, userId, ...(channelId && { channelId }) };
+}
+
+/**
+ * Replace the access, tool and retention settings in `config` with the
+ * kiosk ones. Channels other than `audience.channel` (and the webhook) are
+ * removed; their tokens stay in secrets.yaml but are no longer used.
+ */
+export function applyKioskPreset(config: AppConfig, audience: KioskAudience): void {
+  const { userId, channelId } = audience;
+  if (audience.channel === "discord") {
+    config.discord = {
+      ...config.discord,
+      memberAllowList: [userId],
+      channelAllowList: [channelId!],
+      requireMentionInGuild: true,
+    };
+  } else if (audience.channel === "slack") {
+    config.slack = { ...config.slack, memberAllowList: [userId], channelAllowList: [channelId!] };
+  } else {
+    config.telegram = {
+      ...(config.telegram?.token && { token: config.telegram.token }),
+      allowList: [userId],
+      groups: { "*": { enabled: false } },
+    };
+  }
+  for (const other of kioskChannels(config)) {
+    if (other !== audience.channel) delete config[other];
+  }
+  delete config.webhook;
+
+  config.tools = { allowWrite: false, policy: { allowList: KIOSK_TOOLS } };
+  config.security = {
+    writeGateEnabled: true,
+    writeToolAllowList: [],
+    writeToolConfirmation: true,
+  };
+  config.system = {
+    exec: {
+      commandAllowList: KIOSK_COMMANDS,
+      envAllowList: ["PATH", "LANG"],
+      timeoutMs: 10_000,
+      maxOutputBytes: 64 * 1024,
+    },
+  };
+  delete config.mcp;
+  delete config.wallet;
+  config.cron = { enabled: false };
+
+  if (config.memorySearch) config.memorySearch = { ...config.memorySearch, enabled: false };
+  config.session = { summarizeOnReset: false };
+  config.infra = { eventStore: { enabled: true, ttlMs: KIOSK_RETENTION_MS } };
+  if (config.gateway?.http) config.gateway.http.eventTtlMs = KIOSK_RETENTION_MS;
+}
+
+/** Summary lines printed once the preset is applied */
+export function describeKioskPreset(audience: KioskAudience): string[] {
+  const channel = CHANNEL_LABELS[audience.channel];
+  const where = audience.channelId
+    ? t("kiosk.inChannel", { channel, id: audience.channelId })
+    : t("kiosk.inDirect", { channel });
+  return [
+    t("kiosk.answersOnly", { user: audience.userId, where }),
+    t("kiosk.tools", { tools: KIOSK_TOOLS.join(", ") }),
+    t("kiosk.commands", { commands: KIOSK_COMMANDS.join(", ") }),
+    t("kiosk.noAccess"),
+    t("kiosk.history", { hours: KIOSK_RETENTION_MS / 3_600_000 }),
+  ];
+}
+
+/**
+ * Ask for the audience, apply the preset and print what it did.
+ */
+export async function setUpKioskPreset(rl: RL, config: AppConfig): Promise<KioskAudience> {
+  const audience = await promptKioskAudience(rl, config);
+  applyKioskPreset(config, audience);
+  for (const line of describeKioskPreset(audience)) success(line);
+  return audience;
+}
@@ -0,0 +1,209 @@
+/**
+ * Step module: Kubernetes manifests as an alternative to docker-compose.
+ *
+ * Renders the same answers as a multi-document YAML file with a ConfigMap
+ * (app.yaml), Secret (secrets.yaml + env tokens), PVC (auth/workspace state),
+ * Deployment and Service, ready for `kubectl apply -f`.
+ */
+
+import { writeFileSync, chmodSync } from "node:fs";
+import { join } from "node:path";
+import { stringify } from "yaml";
+import type { AppConfig } from "../types.js";
+import type { SecretsConfig } from "../secrets.js";
+import { header, success, COLORS } from "../shared.js";
+import { renderAppConfigYaml, renderSecretsYaml } from "./dry-run.js";
+import { t } from "../i18n.js";
+
+export type OutputFormat = "compose" | "kubernetes" | "swarm" | "devcontainer";
+
+export const OUTPUT_FORMATS: OutputFormat[] = ["compose", "kubernetes", "swarm", "devcontainer"];
+
+export const KUBERNETES_MANIFEST_FILE = "owliabot-k8s.yaml";
+
+/** Home of the container user; the PVC is mounted here. */
+const CONTAINER_HOME = "/home/owliabot/.owliabot";
+
+export interface KubernetesManifestOptions {
+  image: string;
+  /** Kubernetes namespace (omitted from metadata when undefined) */
+  namespace?: string;
+  /** Resource name prefix */
+  name?: string;
+  /** Requested PVC size */
+  storage?: string;
+}
+
+export function parseOutputFormat(value: string | undefined): OutputFormat {
+  const format = (value ?? "compose").trim().toLowerCase();
+  if (format === "k8s") return "kubernetes";
+  if (format === "stack") return "swarm";
+  if ((OUTPUT_FORMATS as string[]).includes(format)) return format as OutputFormat;
+  throw new Error(`Unknown output format "${value}" (expected one of: ${OUTPUT_FORMATS.join(", ")})`);
+}
+
+/**
+ * Split docker env lines into literal values and ${VAR} placeholders.
+ * Placeholders become keys of the Secret so they can be filled in once.
+ */
+function splitEnvLines(envLines: string[]): { literal: Array<[string, string]>; fromSecret: string[] } {
+  const literal: Array<[string, string]> = [];
+  const fromSecret: string[] = [];
+  for (const line of envLines) {
+    const eq = line.indexOf("=");
+    if (eq < 0) continue;
+    const key = line.slice(0, eq);
+    const value = line.slice(eq + 1);
+    if (value === `\${${key}}`) fromSecret.push(key);
+    else literal.push([key, value]);
+  }
+  return { literal, fromSecret };
+}
+
+/**
+ * Build the manifests as one multi-document YAML string.
+ */
+export function buildKubernetesManifests(
+  config: AppConfig,
+  secrets: SecretsConfig,
+  envLines: string[],
+  opts: KubernetesManifestOptions,
+): string {
+  const name = opts.name ?? "owliabot";
+  const metadata = (suffix = "") => ({
+    name: `${name}${suffix}`,
+    ...(opts.namespace ? { namespace: opts.namespace } : {}),
+    labels: { "app.kubernetes.io/name": name },
+  });
+  const selector = { "app.kubernetes.io/name": name };
+  const tls = Boolean(config.gateway?.http?.tls);
+  const { literal, fromSecret } = splitEnvLines(envLines);
+
+  const configMap = {
+    apiVersion: "v1",
+    kind: "ConfigMap",
+    metadata: metadata("-config"),
+    data: { "app.yaml": renderAppConfigYaml(config) },
+  };
+
+  const secretData: Record<string, string> = { "secrets.yaml": renderSecretsYaml(secrets) };
+  // Tokens the bot reads from env: fill these in before applying.
+  for (const key of fromSecret) secretData[key] = "";
+  const secret = {
+    apiVersion: "v1",
+    kind: "Secret",
+    metadata: metadata("-secrets"),
+    type: "Opaque",
+    stringData: secretData,
+  };
+
+  const pvc = {
+    apiVersion: "v1",
+    kind: "PersistentVolumeClaim",
+    metadata: metadata("-data"),
+    spec: {
+      accessModes: ["ReadWriteOnce"],
+      resources: { requests: { storage: opts.storage ?? "1Gi" } },
+    },
+  };
+
+  // Liveness: the process answers. Readiness: channels are connected (/ready).
+  const probe = (path: string) => ({
+    httpGet: { path, port: "gateway", scheme: tls ? "HTTPS" : "HTTP" },
+    periodSeconds: 10,
+    timeoutSeconds: 3,
+  });
+
+  const deployment = {
+    apiVersion: "apps/v1",
+    kind: "Deployment",
+    metadata: metadata(),
+    spec: {
+      replicas: 1,
+      // Single writer for the sqlite stores on the PVC.
+      strategy: { type: "Recreate" },
+      selector: { matchLabels: selector },
+      template: {
+        metadata: { labels: selector },
+        spec: {
+          securityContext: { fsGroup: 1000 },
+          containers: [
+            {
+              name: "owliabot",
+              image: opts.image,
+              args: ["start", "-c", `${CONTAINER_HOME}/app.yaml`],
+              ports: [{ name: "gateway", containerPort: 8787 }],
+              env: [
+                ...literal.map(([key, value]) => ({ name: key, value })),
+                ...fromSecret.map((key) => ({
+                  name: key,
+                  valueFrom: { secretKeyRef: { name: `${name}-secrets`, key } },
+                })),
+              ],
+              volumeMounts: [
+                { name: "data", mountPath: CONTAINER_HOME },
+                { name: "data", mountPath: "/app/workspace", subPath: "workspace" },
+                { name: "config", mountPath: `${CONTAINER_HOME}/app.yaml`, subPath: "app.yaml", readOnly: true },
+                { name: "secrets", mountPath: `${CONTAINER_HOME}/secrets.yaml`, subPath: "secrets.yaml", readOnly: true },
+              ],
+              readinessProbe: { ...probe("/ready"), initialDelaySeconds: 5 },
+              livenessProbe: { ...probe("/health"), initialDelaySeconds: 30 },
+            },
+          ],
+          volumes: [
+            { name: "data", persistentVolumeClaim: { claimName: `${name}-data` } },
+            { name: "config", configMap: { name: `${name}-config` } },
+            { name: "secrets", secret: { secretName: `${name}-secrets`, defaultMode: 0o400 } },
+          ],
+        },
+      },
+    },
+  };
+
+  const service = {
+    apiVersion: "v1",
+    kind: "Service",
+    metadata: metadata(),
+    spec: {
+      type: "ClusterIP",
+      selector,
+      ports: [{ name: "gateway", port: 8787, targetPort: "gateway" }],
+    },
+  };
+
+  const preamble = "# Kubernetes manifests for OwliaBot\n# Generated by onboard\n";
+  return preamble + [configMap, secret, pvc, deployment, service]
+    .map((doc) => `---\n${stringify(doc, { indent: 2 })}`)
+    .join("");
+}
+
+/**
+ * Write the manifests next to where docker-compose.yml would go (0600: they contain secrets).
+ */
+export function writeKubernetesManifests(outputDir: string, manifests: string): string {
+  const manifestPath = join(outputDir, KUBERNETES_MANIFEST_FILE);
+  writeFileSync(manifestPath, manifests);
+  try { chmodSync(manifestPath, 0o600); } catch { /* best-effort */ }
+  success(t("kubernetes.saved", { path: manifestPath }));
+  return manifestPath;
+}
+
+/**
+ * Print kubectl next steps.
+ */
+export function printKubernetesNextSteps(manifestPath: string, envSecretKeys: string[]): void {
+  const C = COLORS;
+  header(t("kubernetes.header"));
+  if (envSecretKeys.length > 0) {
+    console.log(`  ${t("kubernetes.fillIn", { keys: envSecretKeys.join(", ") })}`);
+  }
+  console.log(`  ${C.CYAN}kubectl apply -f ${manifestPath}${C.NC}`);
+  console.log(`  ${C.CYAN}kubectl port-forward svc/owliabot 8787:8787${C.NC}   ${t("kubernetes.portForward")}`);
+  console.log(`  ${C.CYAN}kubectl logs -f deploy/owliabot${C.NC}`);
+  console.log("");
+}
+
+/** Env keys that the manifests expect to be filled in the Secret. */
+export function kubernetesEnvSecretKeys(envLines: string[]): string[] {
+  return splitEnvLines(envLines).fromSecret;
+}
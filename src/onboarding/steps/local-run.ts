@@ -0,0 +1,147 @@
+/**
+ * Step module: run the Docker config from a source checkout (`--local-run`).
+ *
+ * Next to docker-compose.yml, onboarding writes run-local.sh and an
+ * app.local.yaml beside app.yaml, from the same answers. The local config
+ * differs only where the container and the host disagree: the workspace
+ * (relative to the config dir instead of /app/workspace) and the gateway
+ * bind address (127.0.0.1 on the host port). secrets.yaml is shared, so
+ * switching between `docker compose up` and `./run-local.sh` needs no
+ * second wizard run. Only one of them should run at a time.
+ */
+
+import { chmodSync, writeFileSync } from "node:fs";
+import { join } from "node:path";
+import { stringify } from "yaml";
+import type { AppConfig } from "../types.js";
+import { header, success, COLORS } from "../shared.js";
+import type { DockerPaths } from "./docker.js";
+import { t } from "../i18n.js";
+
+export const LOCAL_APP_CONFIG_FILE = "app.local.yaml";
+export const LOCAL_RUN_SCRIPT = "run-local.sh";
+
+export interface LocalRunFile {
+  path: string;
+  content: string;
+}
+
+/** The app.yaml for a host process: host-side workspace and gateway address */
+export function buildLocalRunConfig(config: AppConfig, gatewayPort: string): AppConfig {
+  const local = structuredClone(config);
+  local.workspace = "workspace";
+  if (local.memorySearch?.store) {
+    local.memorySearch.store.path = "{workspace}/memory/{agentId}.sqlite";
+  }
+  if (local.gateway?.http) {
+    local.gateway.http.host = "127.0.0.1";
+    local.gateway.http.port = Number(gatewayPort);
+  }
+  return local;
+}
+
+function quoteShell(value: string): string {
+  return `'${value.replace(/'/g, "'\\''")}'`;
+}
+
+/**
+ * run-local.sh: same environment as the container (literal values exported,
+ * `KEY=${KEY}` pass-throughs checked), then `owliabot start` from a checkout.
+ */
+export function buildLocalRunScript(opts: {
+  /** Config dir as the host shell sees it, e.g. "~/.owliabot" */
+  shellConfigPath: string;
+  /** Environment lines of the compose service */
+  envLines: string[];
+  containerName?: string;
+}): string {
+  const home = opts.shellConfigPath.replace(/^~(?=\/|$)/, "$HOME");
+  const exports: string[] = [];
+  const required: string[] = [];
+  for (const line of opts.envLines) {
+    const eq = line.indexOf("=");
+    if (eq < 0) continue;
+    const key = line.slice(0, eq);
+    const value = line.slice(eq + 1);
+    if (value === `\${${key}}`) required.push(key);
+    // Container-only paths (mounted key files, CA bundle) don't exist on the host.
+    else if (!value.startsWith("/run/") && !value.startsWith("/etc/owliabot/")) {
+      exports.push(`export ${key}=\${${key}:-${quoteShell(value)}}`);
+    }
+  }
+  const container = opts.containerName ?? "owliabot";
+
+  return `#!/usr/bin/env bash
+# Run OwliaBot from a source checkout with the config onboarding wrote for Docker
+# (generated by onboard). Usage: ./${LOCAL_RUN_SCRIPT} [path to owliabot checkout]
+# Stop the container first: both would use the same bot tokens and data files.
+set -euo pipefail
+
+HERE="$(cd "$(dirname "$0")" && pwd)"
+CHECKOUT="\${1:-\${OWLIABOT_CHECKOUT:-.}}"
+export OWLIABOT_HOME="\${OWLIABOT_HOME:-${home}}"
+${exports.join("\n")}${exports.length > 0 ? "\n" : ""}
+# Keys written by --secrets-env
+if [ -f "$HERE/.env" ]; then set -a; . "$HERE/.env"; set +a; fi
+${required.length > 0
+    ? `for var in ${required.join(" ")}; do
+  [ -n "\${!var:-}" ] || echo "warning: $var is not set" >&2
+done
+`
+    : ""}
+if command -v docker >/dev/null 2>&1 && [ -n "$(docker ps -q -f name=^${container}$ 2>/dev/null)" ]; then
+  echo "The ${container} container is running. Stop it first: docker compose down" >&2
+  exit 1
+fi
+
+cd "$CHECKOUT"
+CONFIG="$OWLIABOT_HOME/${LOCAL_APP_CONFIG_FILE}"
+if [ -f dist/entry.js ]; then
+  exec node dist/entry.js start -c "$CONFIG"
+elif [ -f src/entry.ts ]; then
+  exec npx tsx src/entry.ts start -c "$CONFIG"
+else
+  exec npx owliabot start -c "$CONFIG"
+fi
+`;
+}
+
+/** Both files with their destinations (nothing is written) */
+export function renderLocalRunFiles(
+  paths: DockerPaths,
+  config: AppConfig,
+  envLines: string[],
+  gatewayPort: string,
+): LocalRunFile[] {
+  return [
+    {
+      path: join(paths.configDir, LOCAL_APP_CONFIG_FILE),
+      content: stringify(buildLocalRunConfig(config, gatewayPort), { indent: 2 }),
+    },
+    {
+      path: join(paths.outputDir, LOCAL_RUN_SCRIPT),
+      content: buildLocalRunScript({ shellConfigPath: paths.shellConfigPath, envLines }),
+    },
+  ];
+}
+
+export function writeLocalRunFiles(files: LocalRunFile[]): string[] {
+  for (const file of files) {
+    writeFileSync(file.path, file.content);
+    if (file.path.endsWith(".sh")) chmodSync(file.path, 0o755);
+  }
+  success(t("localRun.saved", { script: LOCAL_RUN_SCRIPT, config: LOCAL_APP_CONFIG_FILE }));
+  return files.map((f) => f.path);
+}
+
+/**
+ * How to use it. The script sits next to docker-compose.yml; its path as
+ * seen by onboarding may be a container path, so it is shown relative.
+ */
+export function printLocalRunNextSteps(): void {
+  header(t("localRun.header"));
+  console.log(t("localRun.where"));
+  console.log(`  ${COLORS.CYAN}./${LOCAL_RUN_SCRIPT} /path/to/owliabot-checkout${COLORS.NC}  ${t("localRun.npmInstall")}`);
+  console.log(t("localRun.shared"));
+  console.log("");
+}
@@ -0,0 +1,130 @@
+/**
+ * Step module: custom MCP server definitions.
+ *
+ * Servers that aren't presets are written as-is to `mcp.servers` in app.yaml.
+ */
+
+import { createInterface } from "node:readline";
+import type { AppConfig } from "../types.js";
+import { info, error, ask, selectOption } from "../shared.js";
+import { isWebhookUrl } from "./webhook-setup.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export type CustomMcpServer = NonNullable<NonNullable<AppConfig["mcp"]>["servers"]>[number];
+
+/**
+ * Tool names are `<server>__<tool>`, so a server name can't contain "__";
+ * keep it to letters, digits and single hyphens/underscores.
+ */
+export function isValidMcpServerName(name: string): boolean {
+  return /^[a-z0-9]+(?:[-_][a-z0-9]+)*$/i.test(name);
+}
+
+/**
+ * Split an argument line on whitespace, keeping "double" or 'single' quoted
+ * parts together. Returns null when a quote is left open.
+ */
+export function splitArgs(line: string): string[] | null {
+  const args: string[] = [];
+  let current = "";
+  let inArg = false;
+  let quote: string | null = null;
+
+  for (const ch of line) {
+    if (quote) {
+      if (ch === quote) quote = null;
+      else current += ch;
+    } else if (ch === "\"" || ch === "'") {
+      quote = ch;
+      inArg = true;
+    } else if (/\s/.test(ch)) {
+      if (inArg) args.push(current);
+      current = "";
+      inArg = false;
+    } else {
+      current += ch;
+      inArg = true;
+    }
+  }
+  if (quote) return null;
+  if (inArg) args.push(current);
+  return args;
+}
+
+/** Parse `KEY=VALUE` pairs separated by spaces. Returns null on a malformed pair. */
+export function parseEnvPairs(line: string): Record<string, string> | null {
+  const parts = splitArgs(line);
+  if (!parts) return null;
+  const env: Record<string, string> = {};
+  for (const part of parts) {
+    const eq = part.indexOf("=");
+    const key = eq > 0 ? part.slice(0, eq) : "";
+    if (!/^[A-Za-z_][A-Za-z0-9_]*$/.test(key)) return null;
+    env[key] = part.slice(eq + 1);
+  }
+  return env;
+}
+
+async function askServerName(rl: RL, takenNames: Set<string>): Promise<string> {
+  for (;;) {
+    const name = await ask(rl, t("mcp.custom.name"));
+    if (!isValidMcpServerName(name)) {
+      error(t("mcp.custom.badName"));
+    } else if (takenNames.has(name)) {
+      error(t("mcp.custom.taken", { name }));
+    } else {
+      return name;
+    }
+  }
+}
+
+async function askRequired(rl: RL, q: string, check: (v: string) => boolean, hint: string): Promise<string> {
+  for (;;) {
+    const answer = await ask(rl, q);
+    if (answer && check(answer)) return answer;
+    error(hint);
+  }
+}
+
+/**
+ * Ask for one custom MCP server: name, transport, then command/args/env for
+ * stdio or the URL for sse.
+ */
+export async function askCustomMcpServer(rl: RL, takenNames: Set<string>): Promise<CustomMcpServer> {
+  const name = await askServerName(rl, takenNames);
+  const transport = await selectOption(rl, t("mcp.custom.transport"), [
+    t("mcp.custom.stdio"),
+    t("mcp.custom.sse"),
+  ], 0);
+
+  if (transport === 1) {
+    const url = await askRequired(rl, t("mcp.custom.url"), isWebhookUrl, t("mcp.custom.badUrl"));
+    return { name, transport: "sse", url };
+  }
+
+  const command = await askRequired(rl, t("mcp.custom.command"), (v) => !/\s/.test(v),
+    t("mcp.custom.badCommand"));
+
+  let args: string[] | null = null;
+  while (!args) {
+    args = splitArgs(await ask(rl, t("mcp.custom.args")));
+    if (!args) error(t("mcp.custom.openQuote"));
+  }
+
+  info(t("mcp.custom.envIntro"));
+  let env: Record<string, string> | null = null;
+  while (!env) {
+    env = parseEnvPairs(await ask(rl, t("mcp.custom.env")));
+    if (!env) error(t("mcp.custom.badEnv"));
+  }
+
+  return {
+    name,
+    transport: "stdio",
+    command,
+    ...(args.length > 0 && { args }),
+    ...(Object.keys(env).length > 0 && { env }),
+  };
+}
@@ -0,0 +1,110 @@
+/**
+ * Step module: check that MCP server commands can run.
+ *
+ * Each stdio server is started by a runtime (npx, uvx, ...). A missing one
+ * only shows up when the gateway boots, so after the MCP stage we run
+ * `<command> --version` for each and report what's missing.
+ *
+ * In Docker mode the servers run inside the image, not on this host, so
+ * commands are checked against what the image ships instead.
+ */
+
+import { execFileSync } from "node:child_process";
+import { createInterface } from "node:readline";
+import type { AppConfig } from "../types.js";
+import { header, info, success, warn, askYN } from "../shared.js";
+import { getPresetCommand } from "../../mcp/presets.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+/** Runtimes available in the owliabot image (node:22-slim) */
+export const DOCKER_IMAGE_COMMANDS = ["node", "npm", "npx"];
+
+/** Where to get a missing runtime */
+const INSTALL_HINTS: Record<string, { tool: string; url: string }> = {
+  npx: { tool: "Node.js", url: "https://nodejs.org/" },
+  node: { tool: "Node.js", url: "https://nodejs.org/" },
+  uvx: { tool: "uv", url: "https://docs.astral.sh/uv/getting-started/installation/" },
+};
+
+export type RuntimeProbe =
+  | { kind: "ok"; version: string }
+  | { kind: "missing" };
+
+/**
+ * Group the configured stdio servers by the command that starts them.
+ */
+export function mcpRuntimeCommands(mcp: NonNullable<AppConfig["mcp"]>): Map<string, string[]> {
+  const commands = new Map<string, string[]>();
+  const add = (command: string | undefined, server: string) => {
+    if (!command) return;
+    commands.set(command, [...(commands.get(command) ?? []), server]);
+  };
+  for (const name of mcp.presets ?? []) add(getPresetCommand(name), name);
+  for (const server of mcp.servers ?? []) {
+    if ((server.transport ?? "stdio") === "stdio") add(server.command, server.name);
+  }
+  return commands;
+}
+
+/**
+ * Run `<command> --version`. Anything that fails to start (or times out)
+ * counts as missing.
+ */
+export function probeRuntime(
+  command: string,
+  exec: (cmd: string, args: string[]) => string = (cmd, args) =>
+    execFileSync(cmd, args, { stdio: "pipe", encoding: "utf-8", timeout: 5_000 }),
+): RuntimeProbe {
+  try {
+    const version = exec(command, ["--version"]).trim().split("\n")[0] ?? "";
+    return { kind: "ok", version };
+  } catch {
+    return { kind: "missing" };
+  }
+}
+
+/**
+ * Offer the check (default yes) and report each runtime. Never blocks
+ * setup. Returns the commands that are missing.
+ */
+export async function checkMcpRuntimes(
+  rl: RL,
+  mcp: NonNullable<AppConfig["mcp"]>,
+  dockerMode: boolean,
+  probe: (command: string) => RuntimeProbe = (c) => probeRuntime(c),
+): Promise<string[]> {
+  const commands = mcpRuntimeCommands(mcp);
+  if (commands.size === 0) return [];
+
+  if (!(await askYN(rl, t("mcpRuntime.ask"), true))) return [];
+
+  header(t("mcpRuntime.header"));
+  if (dockerMode) info(t("mcpRuntime.inImage"));
+
+  const missing: string[] = [];
+  for (const [command, servers] of commands) {
+    const usedBy = servers.join(", ");
+    if (dockerMode) {
+      if (DOCKER_IMAGE_COMMANDS.includes(command)) {
+        success(t("mcpRuntime.included", { command, servers: usedBy }));
+      } else {
+        missing.push(command);
+        warn(t("mcpRuntime.notInImage", { command, servers: usedBy }));
+      }
+      continue;
+    }
+
+    const result = probe(command);
+    if (result.kind === "ok") {
+      success(`${command}: ${result.version || t("mcpRuntime.found")} (${usedBy})`);
+    } else {
+      missing.push(command);
+      warn(t("mcpRuntime.missing", { command, servers: usedBy }));
+      const hint = INSTALL_HINTS[command];
+      if (hint) info(`  ${t("mcpRuntime.install", hint)}`);
+    }
+  }
+  return missing;
+}
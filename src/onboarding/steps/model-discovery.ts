@@ -0,0 +1,173 @@
+/**
+ * Step module: offer the models the provider actually serves.
+ *
+ * Built-in model defaults go stale, and a typo in a model ID only shows up
+ * when the bot first calls the provider. When a key is at hand, the wizard
+ * asks Anthropic's or OpenAI's models endpoint for the account's models and
+ * offers them as a numbered list. Listings are cached under
+ * OWLIABOT_HOME/cache for an hour (keyed by a hash of the key, never the
+ * key itself). No key, no network, or an error: the usual "Model [default]"
+ * prompt, with the preset default.
+ */
+
+import { createHash } from "node:crypto";
+import { mkdir, readFile, writeFile } from "node:fs/promises";
+import { dirname, join } from "node:path";
+import { createInterface } from "node:readline";
+import { ask, selectOption, warn } from "../shared.js";
+import { isSetupToken } from "../../auth/setup-token.js";
+import { ensureOwliabotHomeEnv } from "../../utils/paths.js";
+import { validationClient, noteSkippedValidation, type ValidationClient } from "./validation-client.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export type ModelListProvider = "anthropic" | "openai";
+
+export const MODEL_LIST_CACHE_FILE = join("cache", "provider-models.json");
+export const MODEL_LIST_CACHE_TTL_MS = 60 * 60 * 1000;
+
+/** How many models the picker shows; the rest can still be typed */
+const MAX_LISTED = 12;
+
+interface CacheEntry {
+  keyHash: string;
+  fetchedAt: number;
+  models: string[];
+}
+
+type CacheFile = Partial<Record<ModelListProvider, CacheEntry>>;
+
+function keyHash(key: string): string {
+  return createHash("sha256").update(key).digest("hex");
+}
+
+/** OpenAI lists embeddings, audio, images, ... too; keep the chat models */
+export function isOpenAIChatModel(id: string): boolean {
+  if (/(embedding|whisper|tts|dall-e|audio|realtime|transcribe|moderation|image|search)/.test(id)) return false;
+  return /^(gpt-|o\d|chatgpt-)/.test(id);
+}
+
+/**
+ * Model IDs from the provider's models endpoint, newest first, or null when
+ * the listing isn't available.
+ */
+export async function fetchProviderModels(
+  provider: ModelListProvider,
+  key: string,
+  client: ValidationClient = validationClient,
+): Promise<string[] | null> {
+  const request = provider === "anthropic"
+    ? {
+        url: "https://api.anthropic.com/v1/models?limit=100",
+        headers: {
+          ...(isSetupToken(key)
+            ? { Authorization: `Bearer ${key}`, "anthropic-beta": "oauth-2025-04-20" }
+            : { "x-api-key": key }),
+          "anthropic-version": "2023-06-01",
+        } as Record<string, string>,
+      }
+    : { url: "https://api.openai.com/v1/models", headers: { Authorization: `Bearer ${key}` } };
+
+  const result = await client.fetch(request.url, { headers: request.headers });
+  if (result.kind === "skipped") {
+    noteSkippedValidation(t("models.check"), result.reason);
+    return null;
+  }
+  if (!result.response.ok) return null;
+  const body = (await result.response.json().catch(() => null)) as
+    | { data?: Array<{ id?: unknown; created?: number; created_at?: string }> }
+    | null;
+  if (!Array.isArray(body?.data)) return null;
+
+  const created = (m: { created?: number; created_at?: string }) =>
+    m.created ?? (m.created_at ? Date.parse(m.created_at) / 1000 : 0);
+  const models = body.data
+    .filter((m): m is { id: string; created?: number; created_at?: string } => typeof m.id === "string")
+    .filter((m) => provider !== "openai" || isOpenAIChatModel(m.id))
+    .sort((a, b) => created(b) - created(a))
+    .map((m) => m.id);
+  return models.length > 0 ? models : null;
+}
+
+export async function readCachedModels(
+  cachePath: string,
+  provider: ModelListProvider,
+  key: string,
+  now = Date.now(),
+): Promise<string[] | null> {
+  try {
+    const cache = JSON.parse(await readFile(cachePath, "utf-8")) as CacheFile;
+    const entry = cache[provider];
+    if (!entry || entry.keyHash !== keyHash(key)) return null;
+    if (now - entry.fetchedAt > MODEL_LIST_CACHE_TTL_MS) return null;
+    return entry.models;
+  } catch {
+    return null;
+  }
+}
+
+export async function writeCachedModels(
+  cachePath: string,
+  provider: ModelListProvider,
+  key: string,
+  models: string[],
+  now = Date.now(),
+): Promise<void> {
+  let cache: CacheFile = {};
+  try {
+    cache = JSON.parse(await readFile(cachePath, "utf-8")) as CacheFile;
+  } catch {
+    // first listing, or an unreadable cache we simply replace
+  }
+  cache[provider] = { keyHash: keyHash(key), fetchedAt: now, models };
+  try {
+    await mkdir(dirname(cachePath), { recursive: true });
+    await writeFile(cachePath, `${JSON.stringify(cache, null, 2)}\n`, "utf-8");
+  } catch {
+    // A cache that can't be written only costs another API call next time
+  }
+}
+
+/**
+ * Cached listing when fresh, otherwise a live one (cached on success).
+ */
+export async function listProviderModels(
+  provider: ModelListProvider,
+  key: string,
+  homeDir: string = ensureOwliabotHomeEnv(),
+  fetchModels: (provider: ModelListProvider, key: string) => Promise<string[] | null> = (p, k) => fetchProviderModels(p, k),
+): Promise<string[] | null> {
+  const cachePath = join(homeDir, MODEL_LIST_CACHE_FILE);
+  const cached = await readCachedModels(cachePath, provider, key);
+  if (cached) return cached;
+  const models = await fetchModels(provider, key);
+  if (models) await writeCachedModels(cachePath, provider, key, models);
+  return models;
+}
+
+/**
+ * Ask for the model: from the live list when there is one (Enter keeps the
+ * preset default when the account has it, else takes the newest model),
+ * otherwise as free text.
+ */
+export async function promptModel(rl: RL, models: string[] | null, defaultModel: string): Promise<string> {
+  if (!models || models.length === 0) {
+    return (await ask(rl, t("provider.modelDefault", { model: defaultModel }))) || defaultModel;
+  }
+
+  const listed = models.slice(0, MAX_LISTED);
+  // Aliases like claude-opus-4-5 aren't listed; their dated IDs are.
+  const known = models.some((m) => m === defaultModel || m.startsWith(`${defaultModel}-`));
+  if (known && !listed.includes(defaultModel)) listed.unshift(defaultModel);
+  if (!known) warn(t("models.notListed", { model: defaultModel }));
+
+  const choice = await selectOption(
+    rl,
+    t("models.available"),
+    [...listed, t("provider.otherModel")],
+    known ? listed.indexOf(defaultModel) : 0,
+  );
+  if (choice < listed.length) return listed[choice];
+  return (await ask(rl, t("provider.modelDefault", { model: defaultModel }))) || defaultModel;
+}
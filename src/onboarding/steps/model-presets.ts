@@ -0,0 +1,190 @@
+/**
+ * Step module: model preset catalog for the provider step.
+ *
+ * The default model per provider and the OpenAI-compatible endpoints the
+ * wizard suggests are built in, but can be extended or overridden by a
+ * catalog keyed by locale. This lets you add a provider that is only
+ * available in some countries without changing code. The catalog comes from
+ * OWLIABOT_MODEL_PRESETS (a file path or an http(s) URL) or from
+ * model-presets.yaml in OWLIABOT_HOME:
+ *
+ *   default:
+ *     models: { anthropic: claude-sonnet-4-5 }
+ *   locales:
+ *     zh-CN:
+ *       compatibleEndpoints:
+ *         - { name: DeepSeek, baseUrl: "https://api.deepseek.com/v1", model: deepseek-chat }
+ *
+ * Entries for "zh" apply to every zh-* locale; "zh-CN" is applied after them.
+ */
+
+import { existsSync, readFileSync } from "node:fs";
+import { join } from "node:path";
+import { parse as yamlParse } from "yaml";
+import type { LLMProviderId } from "../types.js";
+import { DEFAULT_MODELS, warn } from "../shared.js";
+import { ensureOwliabotHomeEnv } from "../../utils/paths.js";
+import { validationClient, noteSkippedValidation, type ValidationClient } from "./validation-client.js";
+import { t } from "../i18n.js";
+
+export const MODEL_PRESETS_FILE = "model-presets.yaml";
+
+export interface CompatibleEndpoint {
+  name: string;
+  baseUrl: string;
+  /** Model to suggest when this endpoint is picked */
+  model?: string;
+  /** Models to offer as a numbered list; Enter picks `model` */
+  models?: string[];
+  /** Where to get an API key, for hosted endpoints */
+  keyUrl?: string;
+}
+
+export interface ModelPresetCatalog {
+  models: Record<LLMProviderId, string>;
+  compatibleEndpoints: CompatibleEndpoint[];
+}
+
+interface CatalogLayer {
+  models?: Partial<Record<LLMProviderId, string>>;
+  compatibleEndpoints?: CompatibleEndpoint[];
+}
+
+interface CatalogFile {
+  default?: CatalogLayer;
+  locales?: Record<string, CatalogLayer>;
+}
+
+export const BUILTIN_MODEL_PRESETS: ModelPresetCatalog = {
+  models: { ...DEFAULT_MODELS },
+  compatibleEndpoints: [
+    { name: "Ollama", baseUrl: "http://localhost:11434/v1" },
+    { name: "vLLM", baseUrl: "http://localhost:8000/v1" },
+    { name: "LM Studio", baseUrl: "http://localhost:1234/v1" },
+    { name: "LocalAI", baseUrl: "http://localhost:8080/v1" },
+    {
+      name: "Groq",
+      baseUrl: "https://api.groq.com/openai/v1",
+      model: "llama-3.3-70b-versatile",
+      models: ["llama-3.3-70b-versatile", "llama-3.1-8b-instant"],
+      keyUrl: "https://console.groq.com/keys",
+    },
+    {
+      name: "Mistral",
+      baseUrl: "https://api.mistral.ai/v1",
+      model: "mistral-large-latest",
+      models: ["mistral-large-latest", "mistral-small-latest", "codestral-latest"],
+      keyUrl: "https://console.mistral.ai/api-keys",
+    },
+    {
+      name: "Together",
+      baseUrl: "https://api.together.xyz/v1",
+      model: "meta-llama/Llama-3.3-70B-Instruct-Turbo",
+      models: ["meta-llama/Llama-3.3-70B-Instruct-Turbo", "Qwen/Qwen2.5-72B-Instruct-Turbo"],
+      keyUrl: "https://api.together.ai/settings/api-keys",
+    },
+    {
+      name: "OpenRouter",
+      baseUrl: "https://openrouter.ai/api/v1",
+      model: "anthropic/claude-sonnet-4.5",
+      models: ["anthropic/claude-sonnet-4.5", "openai/gpt-4o", "meta-llama/llama-3.3-70b-instruct"],
+      keyUrl: "https://openrouter.ai/settings/keys",
+    },
+  ],
+};
+
+/**
+ * Locale from the environment as a BCP 47 tag ("zh_CN.UTF-8" → "zh-CN").
+ * Falls back to the Intl locale; "C" and "POSIX" count as none.
+ */
+export function detectLocale(env: NodeJS.ProcessEnv = process.env): string | undefined {
+  const raw = env.LC_ALL || env.LC_MESSAGES || env.LANG || Intl.DateTimeFormat().resolvedOptions().locale;
+  const tag = raw?.split(/[.@]/)[0]?.replace(/_/g, "-");
+  if (!tag || tag === "C" || tag === "POSIX") return undefined;
+  return tag;
+}
+
+function applyLayer(catalog: ModelPresetCatalog, layer: CatalogLayer | undefined): ModelPresetCatalog {
+  if (!layer) return catalog;
+  const endpoints = [...catalog.compatibleEndpoints];
+  for (const endpoint of layer.compatibleEndpoints ?? []) {
+    if (!endpoint?.name || !endpoint.baseUrl) continue;
+    // Same name replaces the earlier entry, so a locale can repoint a built-in one.
+    const at = endpoints.findIndex((e) => e.name === endpoint.name);
+    if (at >= 0) endpoints[at] = endpoint;
+    else endpoints.push(endpoint);
+  }
+  const models = { ...catalog.models };
+  for (const [id, model] of Object.entries(layer.models ?? {})) {
+    if (id in models && typeof model === "string" && model) models[id as LLMProviderId] = model;
+  }
+  return { models, compatibleEndpoints: endpoints };
+}
+
+/**
+ * Merge a catalog file over the built-ins: default, then the language
+ * ("zh"), then the full locale ("zh-CN"). Locale keys match case-insensitively.
+ */
+export function resolveModelPresets(
+  file: CatalogFile | null,
+  locale: string | undefined,
+  base: ModelPresetCatalog = BUILTIN_MODEL_PRESETS,
+): ModelPresetCatalog {
+  let catalog = applyLayer(base, file?.default);
+  if (!locale || !file?.locales) return catalog;
+
+  const byKey = new Map(Object.entries(file.locales).map(([k, v]) => [k.toLowerCase(), v]));
+  const language = locale.split("-")[0].toLowerCase();
+  catalog = applyLayer(catalog, byKey.get(language));
+  if (locale.toLowerCase() !== language) catalog = applyLayer(catalog, byKey.get(locale.toLowerCase()));
+  return catalog;
+}
+
+async function readCatalogSource(source: string, client: ValidationClient): Promise<string | null> {
+  if (/^https?:\/\//i.test(source)) {
+    const result = await client.fetch(source);
+    if (result.kind === "skipped") {
+      noteSkippedValidation(t("modelPresets.check"), result.reason);
+      return null;
+    }
+    if (!result.response.ok) {
+      warn(t("modelPresets.httpError", { status: result.response.status }));
+      return null;
+    }
+    return result.response.text();
+  }
+  return existsSync(source) ? readFileSync(source, "utf-8") : null;
+}
+
+/** Where the catalog came from, for messages (a URL may carry a token) */
+function describeSource(source: string): string {
+  return /^https?:\/\//i.test(source) ? t("modelPresets.remote") : t("modelPresets.file", { path: source });
+}
+
+/** Default model for a provider (built-in when no catalog was loaded) */
+export function presetModel(catalog: ModelPresetCatalog | undefined, id: LLMProviderId): string {
+  return (catalog ?? BUILTIN_MODEL_PRESETS).models[id];
+}
+
+/**
+ * Load the catalog for this locale. Missing or broken catalogs fall back to
+ * the built-ins; onboarding never fails over presets.
+ */
+export async function loadModelPresets(
+  env: NodeJS.ProcessEnv = process.env,
+  client: ValidationClient = validationClient,
+): Promise<ModelPresetCatalog> {
+  const source = env.OWLIABOT_MODEL_PRESETS || join(ensureOwliabotHomeEnv(), MODEL_PRESETS_FILE);
+  let file: CatalogFile | null = null;
+  try {
+    const text = await readCatalogSource(source, client);
+    if (text !== null) {
+      const parsed = yamlParse(text) as unknown;
+      if (parsed && typeof parsed === "object") file = parsed as CatalogFile;
+      else warn(t("modelPresets.notMapping", { source: describeSource(source) }));
+    }
+  } catch (err) {
+    warn(t("modelPresets.ignoring", { source: describeSource(source), error: (err as Error).message }));
+  }
+  return resolveModelPresets(file, detectLocale(env));
+}
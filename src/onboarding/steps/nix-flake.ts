@@ -0,0 +1,113 @@
+/**
+ * Step module: Nix flake for native installs.
+ *
+ * `--nix` keeps the regular dev-mode flow and also writes
+ * <configDir>/nix/flake.nix. `nix run` starts OwliaBot (from npm, on the
+ * flake's Node.js) with OWLIABOT_HOME pointed at the config dir, and
+ * `nix develop` gives a shell with the same `owliabot` wrapper. The flake
+ * sits in its own directory because Nix copies the whole flake directory
+ * into the world-readable store, and secrets.yaml must stay out of it.
+ */
+
+import { mkdirSync, writeFileSync } from "node:fs";
+import { dirname, join } from "node:path";
+import { header, success, COLORS } from "../shared.js";
+import { t } from "../i18n.js";
+
+export const NIX_FLAKE_DIR = "nix";
+
+export interface NixFlakeOptions {
+  /** Directory holding app.yaml / secrets.yaml (becomes OWLIABOT_HOME) */
+  configDir: string;
+  /** Absolute path of app.yaml */
+  appConfigPath: string;
+  /** npm version or dist-tag to run */
+  version?: string;
+}
+
+export interface NixFlakeFile {
+  path: string;
+  content: string;
+}
+
+/** Single-quote a value for the shell, escaped for a Nix '' string. */
+function quoteNixShell(value: string): string {
+  return `'${value.replace(/'/g, "'\\''")}'`.replace(/''/g, "'''").replace(/\$\{/g, "''${");
+}
+
+/**
+ * Render flake.nix.
+ */
+export function buildNixFlake(opts: NixFlakeOptions): string {
+  const version = opts.version ?? "latest";
+  const home = quoteNixShell(opts.configDir);
+  const appConfig = quoteNixShell(opts.appConfigPath);
+  const flakeRef = `path:${join(opts.configDir, NIX_FLAKE_DIR)}`;
+
+  return `# OwliaBot flake (generated by onboard)
+# Run:   nix run ${flakeRef}
+# Shell: nix develop ${flakeRef}
+{
+  description = "OwliaBot with the config generated by onboard";
+
+  inputs = {
+    nixpkgs.url = "github:NixOS/nixpkgs/nixos-unstable";
+    flake-utils.url = "github:numtide/flake-utils";
+  };
+
+  outputs = { self, nixpkgs, flake-utils }:
+    flake-utils.lib.eachDefaultSystem (system:
+      let
+        pkgs = nixpkgs.legacyPackages.\${system};
+        owliabot = pkgs.writeShellApplication {
+          name = "owliabot";
+          runtimeInputs = [ pkgs.nodejs_22 ];
+          text = ''
+            export OWLIABOT_HOME="''\${OWLIABOT_HOME:-}"
+            if [ -z "$OWLIABOT_HOME" ]; then OWLIABOT_HOME=${home}; fi
+            exec npx --yes owliabot@${version} "$@"
+          '';
+        };
+        start = pkgs.writeShellApplication {
+          name = "owliabot-start";
+          text = ''
+            exec \${owliabot}/bin/owliabot start -c ${appConfig}
+          '';
+        };
+      in
+      {
+        packages.default = owliabot;
+        apps.default = flake-utils.lib.mkApp { drv = start; };
+        devShells.default = pkgs.mkShell { packages = [ owliabot ]; };
+      });
+}
+`;
+}
+
+/**
+ * Build the flake file for a config dir (nothing is written).
+ */
+export function buildNixFlakeFile(opts: NixFlakeOptions): NixFlakeFile {
+  return { path: join(opts.configDir, NIX_FLAKE_DIR, "flake.nix"), content: buildNixFlake(opts) };
+}
+
+/**
+ * Write flake.nix into its own directory.
+ */
+export function writeNixFlake(file: NixFlakeFile): void {
+  mkdirSync(dirname(file.path), { recursive: true });
+  writeFileSync(file.path, file.content);
+  success(t("common.saved", { path: file.path }));
+}
+
+/**
+ * How to run it.
+ */
+export function printNixNextSteps(file: NixFlakeFile): void {
+  const C = COLORS;
+  const flakeRef = `path:${dirname(file.path)}`;
+  header(t("nix.header"));
+  console.log(`  ${C.CYAN}nix run ${flakeRef}${C.NC}       ${t("nix.run")}`);
+  console.log(`  ${C.CYAN}nix develop ${flakeRef}${C.NC}   ${t("nix.develop")}`);
+  console.log("");
+}
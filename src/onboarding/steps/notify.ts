@@ -0,0 +1,143 @@
+/**
+ * Step module: tell a webhook that an install was set up (--notify-url).
+ *
+ * Platform teams provisioning many installs get one JSON POST per
+ * successful onboarding: version, host, providers, channels and MCP
+ * presets. Never secrets: only ids, models and names from app.yaml.
+ *
+ * "Successful" means the bot answered /health, which onboarding itself only
+ * sees when it started the stack (--demo). Otherwise the payload is saved as
+ * PENDING_NOTIFICATION_FILE next to app.yaml; install.sh POSTs it once its
+ * own health check passes, and other setups can send it after starting.
+ */
+
+import { readFileSync, writeFileSync } from "node:fs";
+import { hostname } from "node:os";
+import { dirname, join } from "node:path";
+import { fileURLToPath } from "node:url";
+import type { AppConfig } from "../types.js";
+import { success, warn } from "../shared.js";
+import { t } from "../i18n.js";
+
+/** Saved payload for a notification that waits for the bot to start */
+export const PENDING_NOTIFICATION_FILE = "setup-notification.json";
+
+export interface OnboardingInventory {
+  event: "owliabot.onboarded";
+  version: string;
+  host: string;
+  platform: string;
+  mode: "docker" | "native";
+  /** compose, kubernetes, swarm or devcontainer (docker mode) */
+  outputFormat?: string;
+  providers: Array<{ id: string; model: string }>;
+  channels: string[];
+  mcpPresets: string[];
+  gatewayHttp: boolean;
+  timestamp: string;
+}
+
+/** owliabot version from package.json, or "unknown" */
+export function owliabotVersion(): string {
+  try {
+    const root = join(dirname(fileURLToPath(import.meta.url)), "..", "..", "..");
+    return (JSON.parse(readFileSync(join(root, "package.json"), "utf-8")) as { version?: string }).version ?? "unknown";
+  } catch {
+    return "unknown";
+  }
+}
+
+/**
+ * Host name reported in the payload: --notify-host, else OWLIABOT_NOTIFY_HOST
+ * (install.sh sets it, since the onboarding container's own hostname is a
+ * container id), else this machine's hostname.
+ */
+export function notifyHost(flag?: string, env: NodeJS.ProcessEnv = process.env): string {
+  return flag?.trim() || env.OWLIABOT_NOTIFY_HOST?.trim() || hostname();
+}
+
+/**
+ * Build the payload from the generated config.
+ */
+export function buildOnboardingInventory(
+  config: AppConfig,
+  context: { dockerMode: boolean; outputFormat?: string },
+  now: Date = new Date(),
+  version: string = owliabotVersion(),
+  host: string = hostname(),
+): OnboardingInventory {
+  const channels = (["discord", "telegram", "slack", "webhook"] as const).filter((c) => Boolean(config[c]));
+  return {
+    event: "owliabot.onboarded",
+    version,
+    host,
+    platform: process.platform,
+    mode: context.dockerMode ? "docker" : "native",
+    ...(context.dockerMode && { outputFormat: context.outputFormat ?? "compose" }),
+    providers: config.providers.map((p) => ({ id: p.id, model: p.model })),
+    channels,
+    mcpPresets: config.mcp?.presets ?? [],
+    gatewayHttp: Boolean(config.gateway?.http),
+    timestamp: now.toISOString(),
+  };
+}
+
+/**
+ * Poll `url` until it answers 2xx, for up to `timeoutMs`.
+ */
+export async function waitForGatewayHealth(
+  url: string,
+  options: { fetchImpl?: typeof fetch; timeoutMs?: number; intervalMs?: number } = {},
+): Promise<boolean> {
+  const fetchImpl = options.fetchImpl ?? fetch;
+  const intervalMs = options.intervalMs ?? 2_000;
+  const deadline = Date.now() + (options.timeoutMs ?? 60_000);
+  for (;;) {
+    try {
+      if ((await fetchImpl(url, { signal: AbortSignal.timeout(3_000) })).ok) return true;
+    } catch {
+      // not listening yet
+    }
+    if (Date.now() + intervalMs > deadline) return false;
+    await new Promise((resolve) => setTimeout(resolve, intervalMs));
+  }
+}
+
+/** Save the payload for a later POST; returns its path */
+export function writePendingNotification(configDir: string, inventory: OnboardingInventory): string {
+  const path = join(configDir, PENDING_NOTIFICATION_FILE);
+  writeFileSync(path, `${JSON.stringify(inventory, null, 2)}\n`, { mode: 0o600 });
+  return path;
+}
+
+/**
+ * POST the inventory, once: a retry could report the same install twice.
+ * A failure is reported but never fails onboarding, since the files are
+ * already written. Only the URL's origin is printed, in case the rest
+ * carries a token.
+ */
+export async function sendOnboardingNotification(
+  url: string,
+  inventory: OnboardingInventory,
+  fetchImpl: typeof fetch = fetch,
+): Promise<boolean> {
+  const { origin } = new URL(url);
+  let response: Response;
+  try {
+    response = await fetchImpl(url, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify(inventory),
+      signal: AbortSignal.timeout(10_000),
+    });
+  } catch (err) {
+    warn(t("notify.failed", { origin, error: err instanceof Error ? err.message : String(err) }));
+    return false;
+  }
+  if (!response.ok) {
+    warn(t("notify.rejected", { origin, status: response.status }));
+    return false;
+  }
+  success(t("notify.sent", { origin }));
+  return true;
+}
@@ -0,0 +1,127 @@
+/**
+ * Step module: OIDC login in front of the gateway (oauth2-proxy sidecar).
+ *
+ * For team deployments the gateway port is published through oauth2-proxy
+ * instead of directly, so only people who can sign in with the organisation's
+ * identity provider reach the bot's HTTP interface. Client and cookie secrets
+ * live in <configDir>/oidc.env (referenced via env_file).
+ */
+
+import { createInterface } from "node:readline";
+import { randomBytes } from "node:crypto";
+import { writeFileSync, chmodSync } from "node:fs";
+import { join } from "node:path";
+import { header, info, success, ask, AbortError } from "../shared.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export const OIDC_ENV_FILE = "oidc.env";
+
+/** Compose service name / port of the proxy; tunnels point here when OIDC is on. */
+export const OIDC_PROXY_UPSTREAM = "http://oauth2-proxy:4180";
+
+export interface OidcProxySetup {
+  issuerUrl: string;
+  clientId: string;
+  clientSecret: string;
+  /** Allowed email domains ("*" = any account the issuer accepts) */
+  emailDomains: string[];
+  /** Externally visible base URL, used for the OAuth redirect */
+  publicUrl: string;
+  /** 32-char random cookie secret */
+  cookieSecret: string;
+}
+
+/**
+ * Ask for the identity provider details.
+ */
+export async function promptOidcProxySetup(
+  rl: RL,
+  gatewayPort: string,
+  defaultPublicUrl?: string,
+): Promise<OidcProxySetup> {
+  header(t("oidc.header"));
+  info(t("oidc.register"));
+  info(t("oidc.copy"));
+
+  const issuerUrl = (await ask(rl, t("oidc.issuer"))).trim().replace(/\/+$/, "");
+  if (!/^https:\/\//i.test(issuerUrl)) throw new AbortError("Issuer URL must start with https://");
+
+  const clientId = (await ask(rl, t("oidc.clientId"))).trim();
+  if (!clientId) throw new AbortError("Client ID is required");
+
+  const clientSecret = (await ask(rl, t("oidc.clientSecret"), true)).trim();
+  if (!clientSecret) throw new AbortError("Client secret is required");
+
+  const domains = (await ask(rl, t("oidc.domains"))).trim() || "*";
+  const emailDomains = domains.split(",").map((d) => d.trim()).filter(Boolean);
+
+  const fallbackUrl = defaultPublicUrl ?? `http://localhost:${gatewayPort}`;
+  const publicUrl = ((await ask(rl, t("oidc.publicUrl", { url: fallbackUrl }))).trim() || fallbackUrl)
+    .replace(/\/+$/, "");
+
+  info(t("oidc.redirect", { url: `${publicUrl}/oauth2/callback` }));
+  success(t("oidc.added"));
+
+  return {
+    issuerUrl,
+    clientId,
+    clientSecret,
+    emailDomains,
+    publicUrl,
+    cookieSecret: randomBytes(16).toString("hex"),
+  };
+}
+
+/**
+ * Contents of oidc.env (oauth2-proxy reads OAUTH2_PROXY_* variables).
+ */
+export function buildOidcProxyEnv(setup: OidcProxySetup, gatewayTls = false): string {
+  const lines = [
+    "OAUTH2_PROXY_PROVIDER=oidc",
+    `OAUTH2_PROXY_OIDC_ISSUER_URL=${setup.issuerUrl}`,
+    `OAUTH2_PROXY_CLIENT_ID=${setup.clientId}`,
+    `OAUTH2_PROXY_CLIENT_SECRET=${setup.clientSecret}`,
+    `OAUTH2_PROXY_COOKIE_SECRET=${setup.cookieSecret}`,
+    `OAUTH2_PROXY_COOKIE_SECURE=${setup.publicUrl.startsWith("https://") ? "true" : "false"}`,
+    `OAUTH2_PROXY_EMAIL_DOMAINS=${setup.emailDomains.join(",")}`,
+    `OAUTH2_PROXY_REDIRECT_URL=${setup.publicUrl}/oauth2/callback`,
+    "OAUTH2_PROXY_HTTP_ADDRESS=0.0.0.0:4180",
+    `OAUTH2_PROXY_UPSTREAMS=${gatewayTls ? "https" : "http"}://owliabot:8787`,
+    // Keep the unauthenticated health endpoint reachable for monitoring.
+    "OAUTH2_PROXY_SKIP_AUTH_ROUTES=GET=^/health$",
+    "OAUTH2_PROXY_REVERSE_PROXY=true",
+  ];
+  if (gatewayTls) lines.push("OAUTH2_PROXY_SSL_UPSTREAM_INSECURE_SKIP_VERIFY=true");
+  return `${lines.join("\n")}\n`;
+}
+
+/**
+ * docker-compose service block for oauth2-proxy. It takes over the host port
+ * that would otherwise publish the gateway directly.
+ */
+export function buildOidcProxyComposeService(dockerConfigPath: string, gatewayPort: string): string {
+  return `
+  oauth2-proxy:
+    image: quay.io/oauth2-proxy/oauth2-proxy:latest
+    container_name: owliabot-oauth2-proxy
+    restart: unless-stopped
+    env_file:
+      - ${dockerConfigPath}/${OIDC_ENV_FILE}
+    ports:
+      - "127.0.0.1:${gatewayPort}:4180"
+    depends_on:
+      - owliabot
+`;
+}
+
+/**
+ * Write oidc.env into the config dir (0600).
+ */
+export function writeOidcProxyEnv(configDir: string, setup: OidcProxySetup, gatewayTls = false): void {
+  const envPath = join(configDir, OIDC_ENV_FILE);
+  writeFileSync(envPath, buildOidcProxyEnv(setup, gatewayTls));
+  try { chmodSync(envPath, 0o600); } catch { /* best-effort */ }
+  success(t("oidc.saved", { path: envPath }));
+}
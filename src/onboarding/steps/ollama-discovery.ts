@@ -0,0 +1,64 @@
+/**
+ * Step module: list the models an Ollama server actually has.
+ *
+ * For the OpenAI-compatible provider, the wizard asks Ollama's native
+ * `/api/tags` endpoint (next to `/v1`) and offers the installed models with
+ * their sizes instead of a free-text model name. Any other server, or an
+ * unreachable one, falls back to the usual prompt.
+ */
+
+import { createInterface } from "node:readline";
+import { ask, selectOption } from "../shared.js";
+import { validationClient, noteSkippedValidation, type ValidationClient } from "./validation-client.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export interface OllamaModel {
+  name: string;
+  /** Size on disk in bytes */
+  size: number;
+}
+
+/** `http://host:11434/v1/` -> `http://host:11434` */
+export function ollamaApiRoot(baseUrl: string): string {
+  return baseUrl.trim().replace(/\/+$/, "").replace(/\/v1$/, "");
+}
+
+export function formatModelSize(bytes: number): string {
+  if (!Number.isFinite(bytes) || bytes <= 0) return "size unknown";
+  const gb = bytes / 1e9;
+  return gb >= 1 ? `${gb.toFixed(1)} GB` : `${Math.round(bytes / 1e6)} MB`;
+}
+
+/**
+ * Installed models, or null when the server isn't Ollama (or can't be reached).
+ */
+export async function discoverOllamaModels(
+  baseUrl: string,
+  client: ValidationClient = validationClient,
+): Promise<OllamaModel[] | null> {
+  const result = await client.fetch(`${ollamaApiRoot(baseUrl)}/api/tags`);
+  if (result.kind === "skipped") {
+    noteSkippedValidation(t("ollama.check"), result.reason);
+    return null;
+  }
+  if (!result.response.ok) return null;
+  const body = (await result.response.json().catch(() => null)) as { models?: Array<{ name?: string; size?: number }> } | null;
+  if (!Array.isArray(body?.models)) return null;
+  return body.models
+    .filter((m): m is { name: string; size?: number } => typeof m.name === "string")
+    .map((m) => ({ name: m.name, size: Number(m.size ?? 0) }));
+}
+
+/**
+ * Pick one of the installed models, or type another name (e.g. one still to pull).
+ */
+export async function promptOllamaModel(rl: RL, models: OllamaModel[], defaultModel: string): Promise<string> {
+  const choice = await selectOption(rl, t("ollama.installed"), [
+    ...models.map((m) => `${m.name} (${formatModelSize(m.size)})`),
+    t("provider.otherModel"),
+  ]);
+  if (choice < models.length) return models[choice].name;
+  return (await ask(rl, t("provider.modelDefault", { model: defaultModel }))) || defaultModel;
+}
@@ -0,0 +1,96 @@
+/**
+ * Step module: refuse placeholder credentials at entry time.
+ *
+ * Catches values that can't be real: "changeme", "sk-xxxx", "<YOUR_TOKEN>",
+ * "sk-ant-...", the example tokens from the Discord/Telegram docs. Otherwise
+ * they end up in secrets.yaml, and the bot just never answers. Deliberately
+ * narrow: anything that could plausibly be a real key is accepted.
+ */
+
+import { createInterface } from "node:readline";
+import { ask, error } from "../shared.js";
+import { t, type MessageKey } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export type CredentialKind = "anthropic" | "openai" | "openai-compatible" | "amazon-bedrock" | "azure-openai" | "discord" | "telegram" | "slack" | "github";
+
+const PLACEHOLDER_WORDS = new Set([
+  "changeme", "change-me", "change_me", "placeholder", "example", "secret", "password",
+  "token", "apikey", "api-key", "api_key", "key", "todo", "tbd", "none", "null", "undefined",
+]);
+
+/** Example tokens copied from the BotFather / Telegram Bot API and discord.js docs. */
+const DOC_EXAMPLE_TOKENS = new Set([
+  "123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11",
+  "110201543:AAHdqTcvCH1vGWJxfSeofSAs0K5PALDsaw",
+  "4839574812:AAFD39kkdpWt3ywyRZergyOLMaJhac60qc",
+  "MTk4NjIyNDgzNDcxOTI1MjQ4.Cl2FMQ.ZnCjm1XVW7vRze4b7Cq4se7kKWs",
+]);
+
+const KEY_PREFIXES = [/^sk-ant-(api\d+|oat\d+)-/i, /^sk-proj-/i, /^sk-/i, /^xox[bp]-/i, /^xapp-/i, /^github_pat_/i, /^gh[pousr]_/i];
+
+const WHERE_TO_GET: Record<CredentialKind, MessageKey> = {
+  anthropic: "credential.where.anthropic",
+  openai: "credential.where.openai",
+  "openai-compatible": "credential.where.openaiCompatible",
+  "azure-openai": "credential.where.azureOpenai",
+  "amazon-bedrock": "credential.where.bedrock",
+  discord: "credential.where.discord",
+  telegram: "credential.where.telegram",
+  slack: "credential.where.slack",
+  github: "credential.where.github",
+};
+
+/** Docs offered on the credential prompt (answer "d"). */
+export const CREDENTIAL_DOCS: Partial<Record<CredentialKind, string>> = {
+  anthropic: "https://console.anthropic.com/settings/keys",
+  openai: "https://platform.openai.com/api-keys",
+  "azure-openai": "https://learn.microsoft.com/azure/ai-services/openai/quickstart",
+  "amazon-bedrock": "https://docs.aws.amazon.com/bedrock/latest/userguide/getting-started.html",
+  discord: "https://github.com/owliabot/owliabot/blob/main/docs/discord-setup.md",
+  telegram: "https://core.telegram.org/bots/tutorial#obtain-your-bot-token",
+  slack: "https://github.com/owliabot/owliabot/blob/main/docs/slack-setup.md",
+  github: "https://docs.github.com/en/authentication/keeping-your-account-and-data-secure/managing-your-personal-access-tokens",
+};
+
+/**
+ * Why the value is a placeholder, or null when it could be real.
+ */
+export function detectPlaceholderCredential(value: string): string | null {
+  const v = value.trim();
+  if (!v) return null;
+  const lower = v.toLowerCase();
+
+  if (DOC_EXAMPLE_TOKENS.has(v)) return t("credential.docExample");
+  if (PLACEHOLDER_WORDS.has(lower)) return t("credential.placeholderWord", { value: v });
+  if (/^<.*>$/.test(v) || /^\$\{.*\}$/.test(v)) return t("credential.template");
+  if (/\.\.\.|…/.test(v)) return t("credential.shortened");
+  if (/your[-_ ]?(api[-_ ]?)?(key|token|secret)|(goes|paste)[-_ ]?here|[-_]here$/i.test(v)) {
+    return t("credential.exampleText");
+  }
+
+  const body = KEY_PREFIXES.reduce((rest, prefix) => rest.replace(prefix, ""), v);
+  if (body.length === 0) return t("credential.prefixOnly");
+  if (/^[x*•.]+$/i.test(body)) return t("credential.masked");
+  return null;
+}
+
+/**
+ * Ask for a credential, repeating the question while the answer is a
+ * placeholder. An empty answer is returned as-is (callers treat it as "later").
+ * Answering "d" opens the docs for that credential.
+ */
+export async function askCredential(
+  rl: RL,
+  question: string,
+  kind: CredentialKind,
+  secret = false,
+): Promise<string> {
+  for (;;) {
+    const value = await ask(rl, question, secret, CREDENTIAL_DOCS[kind]);
+    const problem = detectPlaceholderCredential(value);
+    if (!problem) return value;
+    error(t("credential.wontWork", { problem, where: t(WHERE_TO_GET[kind]) }));
+  }
+}
@@ -7,6 +7,7 @@ import { existsSync, readFileSync, writeFileSync } from "node:fs";
 import { parse as yamlParse, stringify as yamlStringify } from "yaml";
 import { createLogger } from "../../utils/logger.js";
 import { warn } from "../shared.js";
+import { t } from "../i18n.js";
 
 const log = createLogger("onboard");
 
@@ -37,6 +38,6 @@ export function maybeUpdateWorkspacePolicyAllowedUsers(
     writeFileSync(policyPath, yamlStringify(doc, { indent: 2 }), "utf-8");
     log.info(`Updated policy allowedUsers in ${policyPath}`);
   } catch (err) {
-    warn(`Failed to update policy.yml allowedUsers: ${(err as Error).message}`);
+    warn(t("policy.updateFailed", { error: (err as Error).message }));
   }
 }
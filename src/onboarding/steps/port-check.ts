@@ -0,0 +1,137 @@
+/**
+ * Step module: catch a gateway port that is already taken.
+ *
+ * Probes `127.0.0.1:<port>` by binding it briefly. When something else holds
+ * the port (often an older owliabot container), names the container if
+ * `docker ps` can tell, and offers the next free port instead of letting
+ * the first `docker compose up` fail with "port is already allocated".
+ *
+ * Inside install.sh's onboarding container neither the probe nor `docker
+ * ps` can see the host, so the answers come from install.sh instead:
+ * OWLIABOT_HOST_PORTS_BUSY (taken ports in 8787-8836) and
+ * OWLIABOT_HOST_PORT_OWNERS ("port:container,..."). Ports outside that range
+ * count as free there. In a container without them (a remote engine, a
+ * hand-run `docker run`) the check is skipped.
+ */
+
+import { execFileSync } from "node:child_process";
+import { createServer } from "node:net";
+import { createInterface } from "node:readline";
+import { info, warn, askYN } from "../shared.js";
+import { containerCli, isInsideDocker, type ContainerCli } from "../../logs/docker.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+/** How far past the requested port to look for a free one */
+const MAX_PORT_SEARCH = 20;
+
+/**
+ * Whether the port can be bound on the host (false when already in use).
+ */
+export function isPortFree(port: number, host = "127.0.0.1"): Promise<boolean> {
+  return new Promise((resolve) => {
+    const server = createServer();
+    server.once("error", () => resolve(false));
+    server.once("listening", () => server.close(() => resolve(true)));
+    server.listen(port, host);
+  });
+}
+
+/**
+ * First free port after `port`, or null when none is free nearby.
+ */
+export async function findNextFreePort(
+  port: number,
+  isFree: (port: number) => Promise<boolean> = (p) => isPortFree(p),
+): Promise<number | null> {
+  for (let candidate = port + 1; candidate <= Math.min(port + MAX_PORT_SEARCH, 65535); candidate++) {
+    if (await isFree(candidate)) return candidate;
+  }
+  return null;
+}
+
+/**
+ * Names of running containers that publish the port (empty when docker or
+ * podman is unavailable or nothing matches).
+ */
+export function containersPublishingPort(
+  port: number,
+  exec: (cmd: string, args: string[]) => string = (cmd, args) =>
+    execFileSync(cmd, args, { stdio: "pipe", encoding: "utf-8", timeout: 5_000 }),
+  cli: ContainerCli = containerCli(),
+): string[] {
+  try {
+    return exec(cli, ["ps", "--filter", `publish=${port}`, "--format", "{{.Names}}"])
+      .split("\n")
+      .map((name) => name.trim())
+      .filter(Boolean);
+  } catch {
+    return [];
+  }
+}
+
+export interface PortCheckDeps {
+  isFree: (port: number) => Promise<boolean>;
+  containers: (port: number) => string[];
+}
+
+/** Port facts install.sh probed on the host, or null when it passed none */
+export function hostPortDeps(env: Record<string, string | undefined> = process.env): PortCheckDeps | null {
+  const busyList = env.OWLIABOT_HOST_PORTS_BUSY;
+  if (busyList === undefined) return null;
+  const busy = new Set(busyList.split(",").map(Number).filter(Number.isInteger));
+  const owners = new Map<number, string[]>();
+  for (const entry of (env.OWLIABOT_HOST_PORT_OWNERS ?? "").split(",")) {
+    const sep = entry.indexOf(":");
+    if (sep < 1) continue;
+    const port = Number(entry.slice(0, sep));
+    owners.set(port, [...(owners.get(port) ?? []), entry.slice(sep + 1)]);
+  }
+  return { isFree: async (p) => !busy.has(p), containers: (p) => owners.get(p) ?? [] };
+}
+
+/**
+ * How to check ports here: probe directly on the host; in a container, what
+ * install.sh passed in, or null (can't tell, skip the check).
+ */
+export function defaultPortCheckDeps(
+  env: Record<string, string | undefined> = process.env,
+  inContainer: boolean = isInsideDocker(),
+): PortCheckDeps | null {
+  if (inContainer) return hostPortDeps(env);
+  return { isFree: (p) => isPortFree(p), containers: (p) => containersPublishingPort(p) };
+}
+
+/**
+ * Check the gateway port and offer the next free one when it's taken.
+ * Returns the port to use (unchanged when `deps` is null: no way to check).
+ */
+export async function checkGatewayPort(
+  rl: RL,
+  gatewayPort: string,
+  deps: PortCheckDeps | null = defaultPortCheckDeps(),
+): Promise<string> {
+  if (!deps) return gatewayPort;
+  const port = Number(gatewayPort);
+  if (!Number.isInteger(port) || (await deps.isFree(port))) return gatewayPort;
+
+  warn(t("port.inUse", { port }));
+  const containers = deps.containers(port);
+  if (containers.length > 0) {
+    info(t("port.publishedBy", { containers: containers.join(", ") }));
+    info(t("port.stopOld", { command: `docker stop ${containers[0]}` }));
+  }
+
+  const next = await findNextFreePort(port, deps.isFree);
+  if (next === null) {
+    warn(t("port.noneFree", { from: port + 1, to: port + MAX_PORT_SEARCH, port }));
+    return gatewayPort;
+  }
+  if (await askYN(rl, t("port.useNext", { port: next }), true)) {
+    info(t("port.chosen", { port: next }));
+    return String(next);
+  }
+  info(t("port.keeping", { port }));
+  return gatewayPort;
+}
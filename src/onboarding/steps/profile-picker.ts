@@ -0,0 +1,52 @@
+/**
+ * Step module: pick (or name) the profile to set up, when this machine
+ * already has an OwliaBot.
+ *
+ * Each profile is a separate bot: its own config dir, container and gateway
+ * port (see config/profiles.ts). `onboard --profile <name>` skips the question.
+ */
+
+import { createInterface } from "node:readline";
+import { DEFAULT_PROFILE, parseProfileName, type ProfileInfo } from "../../config/profiles.js";
+import { ask, header, info, selectOption, warn } from "../shared.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export function describeProfile(profile: ProfileInfo, homeDir: string): string {
+  const dir = profile.dir.startsWith(homeDir) ? `~${profile.dir.slice(homeDir.length)}` : profile.dir;
+  return profile.configured
+    ? `${profile.name} (${dir})`
+    : t("profile.notSetUp", { name: profile.name, dir });
+}
+
+/**
+ * Ask which profile to configure. Returns the profile name, undefined for
+ * the default one. Not asked when no profile exists yet (a first install).
+ */
+export async function pickProfile(rl: RL, profiles: ProfileInfo[], homeDir: string): Promise<string | undefined> {
+  if (profiles.length === 0) return undefined;
+
+  header(t("profile.header"));
+  info(t("profile.intro"));
+  const options = [...profiles.map((p) => describeProfile(p, homeDir)), t("profile.new")];
+  const index = await selectOption(rl, t("profile.which"), options, 0);
+  if (index < profiles.length) {
+    const name = profiles[index].name;
+    return name === DEFAULT_PROFILE ? undefined : name;
+  }
+
+  for (;;) {
+    const answer = await ask(rl, t("profile.name"));
+    try {
+      const name = parseProfileName(answer);
+      if (profiles.some((p) => p.name === name)) {
+        warn(t("profile.exists", { name }));
+        continue;
+      }
+      return name;
+    } catch (err) {
+      warn((err as Error).message);
+    }
+  }
+}
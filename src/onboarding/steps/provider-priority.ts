@@ -0,0 +1,77 @@
+/**
+ * Step module: fallback order for the "Multiple providers" choice.
+ *
+ * The provider step configures providers in menu order, which used to be
+ * the fallback order too. Here the user can move them around before the
+ * config is written: "3 up", "1 down", or a whole order like "3,1,2".
+ */
+
+import { createInterface } from "node:readline";
+import type { ProviderConfig } from "../types.js";
+import { ask, info, success, warn } from "../shared.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+/** Providers in fallback order (lowest priority number first) */
+export function sortByPriority(providers: ProviderConfig[]): ProviderConfig[] {
+  return [...providers].sort((a, b) => a.priority - b.priority);
+}
+
+/** "anthropic (claude-opus-4-5) → openai (gpt-5.2)" */
+export function formatProviderChain(providers: ProviderConfig[]): string {
+  return sortByPriority(providers).map((p) => `${p.id} (${p.model})`).join(" → ");
+}
+
+/** The same providers with priorities 1..n in the given order */
+export function withPriorities(providers: ProviderConfig[]): ProviderConfig[] {
+  return providers.map((p, i) => ({ ...p, priority: i + 1 }) as ProviderConfig);
+}
+
+/**
+ * Apply one reorder answer to `providers` (in fallback order). Returns the
+ * new order, or null when the answer can't be understood.
+ */
+export function applyReorder(providers: ProviderConfig[], answer: string): ProviderConfig[] | null {
+  const text = answer.trim().toLowerCase();
+  const n = providers.length;
+
+  const move = /^(\d+)\s*(up|down|u|d)$/.exec(text);
+  if (move) {
+    const from = parseInt(move[1], 10) - 1;
+    const to = move[2].startsWith("u") ? from - 1 : from + 1;
+    if (from < 0 || from >= n) return null;
+    if (to < 0 || to >= n) return [...providers];
+    const next = [...providers];
+    [next[from], next[to]] = [next[to], next[from]];
+    return next;
+  }
+
+  const order = text.split(/[\s,]+/).filter(Boolean).map((part) => parseInt(part, 10) - 1);
+  const complete = order.length === n && new Set(order).size === n && order.every((i) => i >= 0 && i < n);
+  return complete ? order.map((i) => providers[i]) : null;
+}
+
+/**
+ * Let the user change the fallback order. Enter keeps the current one.
+ * Returns the providers with their priorities renumbered.
+ */
+export async function askProviderPriority(rl: RL, providers: ProviderConfig[]): Promise<ProviderConfig[]> {
+  let order = sortByPriority(providers);
+  if (order.length < 2) return order;
+
+  console.log("");
+  info(t("provider.orderIntro"));
+  for (;;) {
+    order.forEach((p, i) => console.log(`  ${i + 1}) ${p.id} (${p.model})`));
+    const answer = await ask(rl, t("provider.orderAsk"));
+    if (!answer.trim()) break;
+    const next = applyReorder(order, answer);
+    if (next) order = next;
+    else warn(t("provider.orderInvalid", { count: order.length }));
+  }
+
+  const ordered = withPriorities(order);
+  success(t("provider.orderDone", { chain: formatProviderChain(ordered) }));
+  return ordered;
+}
@@ -8,45 +8,76 @@ import type { SecretsConfig } from "../secrets.js";
 import { info, success, warn, header, ask, askYN, selectOption, DEFAULT_MODELS } from "../shared.js";
 import { startOAuthFlow } from "../../auth/oauth.js";
 import { validateAnthropicSetupToken, isSetupToken } from "../../auth/setup-token.js";
+import { setupTokenExpiresAt } from "../../auth/credential-expiry.js";
 import type { DetectedConfig, ProviderResult, ProviderSetupState } from "./types.js";
+import { offerFailoverTest, offerProviderSmokeTest } from "./provider-smoke-test.js";
+import { askCredential } from "./placeholder-credentials.js";
+import { discoverOllamaModels, promptOllamaModel } from "./ollama-discovery.js";
+import { detectProviderChoice, tagAutoDetected } from "./auto-detect.js";
+import { defaultClaudeLogin, type ClaudeLogin } from "./anthropic-login.js";
+import { BUILTIN_MODEL_PRESETS, loadModelPresets, presetModel } from "./model-presets.js";
+import { maybeConfigureBedrock } from "./bedrock.js";
+import { maybeConfigureAzureOpenAI } from "./azure-openai.js";
+import { askProviderPriority } from "./provider-priority.js";
+import { listProviderModels, promptModel } from "./model-discovery.js";
+import { t } from "../i18n.js";
 
 export async function maybeConfigureAnthropic(
   rl: ReturnType<typeof createInterface>,
   state: ProviderSetupState,
   aiChoice: number,
+  claudeLogin?: ClaudeLogin | null,
+  discoverModels: boolean = Boolean(process.stdin.isTTY),
 ): Promise<void> {
   if (!(aiChoice === 0 || aiChoice === 4)) return;
 
   state.useAnthropic = true;
   console.log("");
 
-  header("Anthropic Authentication");
-  info("Supports two authentication methods:");
+  header(t("provider.anthropic.header"));
+  info(t("provider.anthropic.methods"));
   info("");
-  info("  • Setup-token (Claude Pro/Max subscription)");
-  info("    Run `claude setup-token` to generate one");
-  info("    Format: sk-ant-oat01-...");
+  info(t("provider.anthropic.setupToken"));
+  info(t("provider.anthropic.setupTokenHow"));
+  info(t("provider.anthropic.format", { format: "sk-ant-oat01-..." }));
   info("");
-  info("  • API Key (pay-as-you-go)");
-  info("    Get from console.anthropic.com");
-  info("    Format: sk-ant-api03-...");
+  info(t("provider.anthropic.apiKey"));
+  info(t("provider.anthropic.apiKeyWhere"));
+  info(t("provider.anthropic.format", { format: "sk-ant-api03-..." }));
   console.log("");
 
-  const tokenAns = await ask(rl, "Paste setup-token or API key (leave empty for env var): ");
+  // Only looked up here so other providers never probe for the Claude CLI.
+  const login = claudeLogin === undefined ? defaultClaudeLogin() : claudeLogin;
+  if (login && await askYN(rl, t("provider.anthropic.loginNow"), false)) {
+    info(t("provider.anthropic.loginStarting"));
+    rl.pause();
+    let ok: boolean;
+    try {
+      ok = login();
+    } finally {
+      rl.resume();
+    }
+    if (ok) info(t("provider.anthropic.loginCopy"));
+    else warn(t("provider.anthropic.loginFailed"));
+  }
+
+  const tokenAns = await askCredential(rl, t("provider.anthropic.paste"), "anthropic");
   if (tokenAns) {
     if (isSetupToken(tokenAns)) {
       const err = validateAnthropicSetupToken(tokenAns);
-      if (err) warn(`Setup-token validation warning: ${err}`);
-      state.secrets.anthropic = { token: tokenAns };
-      success("Setup-token saved (Claude Pro/Max)");
+      if (err) warn(t("provider.anthropic.tokenWarning", { error: err }));
+      state.secrets.anthropic = { token: tokenAns, tokenExpiresAt: setupTokenExpiresAt() };
+      success(t("provider.anthropic.tokenSaved"));
     } else {
       state.secrets.anthropic = { apiKey: tokenAns };
-      success("API key saved");
+      success(t("provider.anthropic.keySaved"));
     }
   }
 
-  const defaultModel = DEFAULT_MODELS.anthropic;
-  const model = (await ask(rl, `Model [${defaultModel}]: `)) || defaultModel;
+  const defaultModel = presetModel(state.modelPresets, "anthropic");
+  const anthropicKey = tokenAns || process.env.ANTHROPIC_API_KEY;
+  const models = discoverModels && anthropicKey ? await listProviderModels("anthropic", anthropicKey) : null;
+  const model = await promptModel(rl, models, defaultModel);
   const apiKeyValue = state.secrets.anthropic ? "secrets" : "env";
 
   state.providers.push({
@@ -61,19 +92,22 @@ export async function maybeConfigureOpenAI(
   rl: ReturnType<typeof createInterface>,
   state: ProviderSetupState,
   aiChoice: number,
+  discoverModels: boolean = Boolean(process.stdin.isTTY),
 ): Promise<void> {
   if (!(aiChoice === 1 || aiChoice === 4)) return;
 
   console.log("");
-  info("OpenAI API keys: https://platform.openai.com/api-keys");
-  const apiKey = await ask(rl, "OpenAI API key (leave empty for env var): ");
+  info(t("provider.openai.keys", { url: "https://platform.openai.com/api-keys" }));
+  const apiKey = await askCredential(rl, t("provider.openai.ask"), "openai");
   if (apiKey) {
     state.secrets.openai = { apiKey };
-    success("OpenAI API key saved");
+    success(t("provider.openai.saved"));
   }
 
-  const defaultModel = DEFAULT_MODELS.openai;
-  const model = (await ask(rl, `Model [${defaultModel}]: `)) || defaultModel;
+  const defaultModel = presetModel(state.modelPresets, "openai");
+  const openaiKey = apiKey || process.env.OPENAI_API_KEY;
+  const models = discoverModels && openaiKey ? await listProviderModels("openai", openaiKey) : null;
+  const model = await promptModel(rl, models, defaultModel);
   state.providers.push({
     id: "openai",
     model,
@@ -92,55 +126,79 @@ export async function maybeConfigureOpenAICodex(
 
   state.useOpenaiCodex = true;
   console.log("");
-  info("OpenAI Codex uses your ChatGPT Plus/Pro subscription via OAuth.");
+  info(t("provider.codex.intro"));
 
-  const runOAuth = await askYN(rl, "Start OAuth flow now?", false);
+  const runOAuth = await askYN(rl, t("provider.codex.startNow"), false);
   if (runOAuth) {
-    info("Starting OpenAI Codex OAuth flow...");
+    info(t("provider.codex.starting"));
     rl.pause();
     try {
       await startOAuthFlow("openai-codex", { headless: dockerMode });
-      success("OAuth completed");
+      success(t("provider.codex.done"));
     } finally {
       rl.resume();
     }
   } else {
     if (dockerMode) {
-      info("Run after container starts: docker exec -it owliabot owliabot auth setup openai-codex");
+      info(t("provider.codex.laterDocker", { command: "docker exec -it owliabot owliabot auth setup openai-codex" }));
     } else {
-      info("Run `owliabot auth setup openai-codex` later to authenticate.");
+      info(t("provider.codex.later", { command: "owliabot auth setup openai-codex" }));
     }
   }
 
   state.providers.push({
     id: "openai-codex",
-    model: DEFAULT_MODELS["openai-codex"],
+    model: presetModel(state.modelPresets, "openai-codex"),
     apiKey: "oauth",
     priority: state.priority++,
   } as ProviderConfig);
 }
 
+/** Pick one of a hosted endpoint's models; Enter takes the default */
+async function promptPresetModel(
+  rl: ReturnType<typeof createInterface>,
+  models: string[],
+  defaultModel: string,
+): Promise<string> {
+  const at = models.indexOf(defaultModel);
+  const choice = await selectOption(rl, t("provider.model"), [...models, t("provider.otherModel")], at >= 0 ? at : 0);
+  if (choice < models.length) return models[choice];
+  return (await ask(rl, t("provider.modelDefault", { model: defaultModel }))) || defaultModel;
+}
+
 export async function maybeConfigureOpenAICompatible(
   rl: ReturnType<typeof createInterface>,
   state: ProviderSetupState,
   aiChoice: number,
+  discoverModels: boolean = Boolean(process.stdin.isTTY),
 ): Promise<void> {
   if (!(aiChoice === 3 || aiChoice === 4)) return;
 
   console.log("");
-  info("OpenAI-compatible supports any server with the OpenAI v1 API:");
-  info("  - Ollama:    http://localhost:11434/v1");
-  info("  - vLLM:      http://localhost:8000/v1");
-  info("  - LM Studio: http://localhost:1234/v1");
-  info("  - LocalAI:   http://localhost:8080/v1");
+  const endpoints = (state.modelPresets ?? BUILTIN_MODEL_PRESETS).compatibleEndpoints;
+  info(t("provider.compatible.intro"));
+  endpoints.forEach((e, i) => info(`  ${i + 1}) ${`${e.name}:`.padEnd(11)} ${e.baseUrl}`));
   console.log("");
 
-  const baseUrl = await ask(rl, "API base URL: ");
+  const answer = await ask(rl, t("provider.compatible.baseUrl", { count: endpoints.length }));
+  const picked = /^\d+$/.test(answer) ? endpoints[Number(answer) - 1] : undefined;
+  const baseUrl = picked?.baseUrl ?? answer;
   if (!baseUrl) return;
 
-  const defaultModel = DEFAULT_MODELS["openai-compatible"];
-  const model = (await ask(rl, `Model [${defaultModel}]: `)) || defaultModel;
-  const apiKey = await ask(rl, "API key (optional, leave empty if not required): ");
+  const endpoint = picked ?? endpoints.find((e) => e.baseUrl.replace(/\/+$/, "") === baseUrl.replace(/\/+$/, ""));
+  const defaultModel = endpoint?.model ?? presetModel(state.modelPresets, "openai-compatible");
+  let model: string;
+  if (endpoint?.models?.length) {
+    model = await promptPresetModel(rl, endpoint.models, defaultModel);
+  } else {
+    const installed = discoverModels ? await discoverOllamaModels(baseUrl) : null;
+    model = installed && installed.length > 0
+      ? await promptOllamaModel(rl, installed, defaultModel)
+      : (await ask(rl, t("provider.modelDefault", { model: defaultModel }))) || defaultModel;
+  }
+  if (endpoint?.keyUrl) info(t("provider.compatible.keyAt", { name: endpoint.name, url: endpoint.keyUrl }));
+  const keyQuestion = endpoint?.keyUrl ? t("provider.compatible.key") : t("provider.compatible.keyOptional");
+  const apiKey = await askCredential(rl, keyQuestion, "openai-compatible");
 
   state.providers.push({
     id: "openai-compatible" as LLMProviderId,
@@ -153,12 +211,13 @@ export async function maybeConfigureOpenAICompatible(
   if (apiKey) {
     state.secrets["openai-compatible"] = { apiKey };
   }
-  success(`OpenAI-compatible configured: ${baseUrl}`);
+  success(t("provider.compatible.configured", { url: baseUrl }));
 }
 
 export async function askProviders(
   rl: ReturnType<typeof createInterface>,
   dockerMode: boolean,
+  env: NodeJS.ProcessEnv = process.env,
 ): Promise<ProviderResult> {
   const state: ProviderSetupState = {
     secrets: {},
@@ -166,20 +225,27 @@ export async function askProviders(
     priority: 1,
     useAnthropic: false,
     useOpenaiCodex: false,
+    modelPresets: await loadModelPresets(env),
   };
 
-  const aiChoice = await selectOption(rl, "Choose your AI provider(s):", [
-    "Anthropic (Claude) - API Key or setup-token",
-    "OpenAI (API key)",
-    "OpenAI Codex (ChatGPT Plus/Pro OAuth)",
-    "OpenAI-compatible (Ollama / vLLM / LM Studio / etc.)",
-    "Multiple providers (fallback chain)",
-  ]);
+  const detected = detectProviderChoice(env);
+  const aiChoice = await selectOption(rl, t("provider.choose"), tagAutoDetected([
+    t("provider.option.anthropic"),
+    t("provider.option.openai"),
+    t("provider.option.codex"),
+    t("provider.option.compatible"),
+    t("provider.option.multiple"),
+    t("provider.option.bedrock"),
+    t("provider.option.azure"),
+  ], detected), detected);
 
   await maybeConfigureAnthropic(rl, state, aiChoice);
   await maybeConfigureOpenAI(rl, state, aiChoice);
   await maybeConfigureOpenAICodex(rl, dockerMode, state, aiChoice);
   await maybeConfigureOpenAICompatible(rl, state, aiChoice);
+  await maybeConfigureBedrock(rl, state, aiChoice, env);
+  await maybeConfigureAzureOpenAI(rl, state, aiChoice);
+  if (aiChoice === 4) state.providers = await askProviderPriority(rl, state.providers);
 
   return {
     providers: state.providers,
@@ -200,7 +266,10 @@ export function reuseProvidersFromExisting(existing: DetectedConfig): ProviderRe
   if (existing.anthropicKey || existing.anthropicToken || existing.hasOAuthAnthro) {
     useAnthropic = true;
     if (existing.anthropicKey) secrets.anthropic = { apiKey: existing.anthropicKey };
-    if (existing.anthropicToken) secrets.anthropic = { ...secrets.anthropic, token: existing.anthropicToken };
+    if (existing.anthropicToken) {
+      secrets.anthropic = { ...secrets.anthropic, token: existing.anthropicToken };
+      if (existing.anthropicTokenExpiresAt) secrets.anthropic.tokenExpiresAt = existing.anthropicTokenExpiresAt;
+    }
     const apiKey = (existing.anthropicKey || existing.anthropicToken) ? "secrets" : "oauth";
     providers.push({
       id: "anthropic",
@@ -208,7 +277,7 @@ export function reuseProvidersFromExisting(existing: DetectedConfig): ProviderRe
       apiKey,
       priority: priority++,
     } as ProviderConfig);
-    success("Reusing Anthropic configuration");
+    success(t("provider.reusing", { name: "Anthropic" }));
   }
 
   // OpenAI
@@ -220,7 +289,7 @@ export function reuseProvidersFromExisting(existing: DetectedConfig): ProviderRe
       apiKey: "secrets",
       priority: priority++,
     } as ProviderConfig);
-    success("Reusing OpenAI configuration");
+    success(t("provider.reusing", { name: "OpenAI" }));
   }
 
   // OpenAI Codex (OAuth)
@@ -232,7 +301,22 @@ export function reuseProvidersFromExisting(existing: DetectedConfig): ProviderRe
       apiKey: "oauth",
       priority: priority++,
     } as ProviderConfig);
-    success("Reusing OpenAI Codex (OAuth) configuration");
+    success(t("provider.reusing", { name: "OpenAI Codex (OAuth)" }));
+  }
+
+  // Azure OpenAI (needs the endpoint and deployment from app.yaml too)
+  if (existing.azureOpenai) {
+    const { apiKey, endpoint, deployment, apiVersion } = existing.azureOpenai;
+    secrets["azure-openai"] = { apiKey };
+    providers.push({
+      id: "azure-openai",
+      model: deployment,
+      baseUrl: endpoint,
+      ...(apiVersion && { apiVersion }),
+      apiKey: "secrets",
+      priority: priority++,
+    } as ProviderConfig);
+    success(t("provider.reusing", { name: "Azure OpenAI" }));
   }
 
   return { providers, secrets, useAnthropic, useOpenaiCodex };
@@ -243,8 +327,9 @@ export async function getProvidersSetup(
   dockerMode: boolean,
   existing: DetectedConfig | null,
   reuseExisting: boolean,
+  interactive: boolean = Boolean(process.stdin.isTTY),
 ): Promise<ProviderResult> {
-  header("AI provider setup");
+  header(t("provider.header"));
 
   if (reuseExisting && existing) {
     const reused = reuseProvidersFromExisting(existing);
@@ -252,9 +337,16 @@ export async function getProvidersSetup(
   }
 
   const result = await askProviders(rl, dockerMode);
-  if (result.providers.length > 0) return result;
+  if (result.providers.length > 0) {
+    // The connection test is a live check, so only offer it on a terminal.
+    if (interactive) {
+      await offerProviderSmokeTest(rl, result.providers, result.secrets);
+      await offerFailoverTest(rl, result.providers, result.secrets);
+    }
+    return result;
+  }
 
-  warn("No provider configured. Add one later in the config file.");
+  warn(t("provider.none"));
   return {
     providers: [{
       id: "anthropic",
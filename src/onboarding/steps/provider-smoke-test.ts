@@ -0,0 +1,251 @@
+/**
+ * Step module: optional "test connection" for the chosen providers.
+ *
+ * One cheap authenticated request per provider: a model lookup, which
+ * exercises the key and confirms the model exists without spending tokens.
+ * The results are latency, model availability and auth errors. Runs through
+ * the shared validation client, so a flaky network just skips the check.
+ *
+ * With two or more providers a failover test is offered as well: the same
+ * lookups are walked in fallback order with the primary given an invalid
+ * key (or left out, when it has none), the way the runtime walks them after
+ * a failed call, to confirm that a fallback provider answers.
+ */
+
+import { createInterface } from "node:readline";
+import type { ProviderConfig } from "../types.js";
+import type { SecretsConfig } from "../secrets.js";
+import { header, info, success, warn, error, askYN } from "../shared.js";
+import { sortByPriority } from "./provider-priority.js";
+import { isSetupToken } from "../../auth/setup-token.js";
+import { validationClient, noteSkippedValidation, type ValidationClient } from "./validation-client.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export interface SmokeTestTarget {
+  label: string;
+  url: string;
+  headers: Record<string, string>;
+  model: string;
+  /** The response is a model list to search rather than a single model */
+  listModels?: boolean;
+}
+
+export type SmokeTestResult =
+  | { kind: "ok"; latencyMs: number; modelAvailable: boolean }
+  | { kind: "auth-error"; status: number }
+  | { kind: "error"; status: number }
+  | { kind: "skipped"; reason: string };
+
+/**
+ * Build the request for a provider, or null when there is nothing to test
+ * (OAuth providers, or no key in secrets/env).
+ */
+export function smokeTestTarget(
+  provider: ProviderConfig,
+  secrets: SecretsConfig,
+  env: Record<string, string | undefined> = process.env,
+): SmokeTestTarget | null {
+  const model = encodeURIComponent(provider.model);
+  if (provider.id === "anthropic") {
+    const key = secrets.anthropic?.token ?? secrets.anthropic?.apiKey ?? (provider.apiKey === "env" ? env.ANTHROPIC_API_KEY : undefined);
+    if (!key) return null;
+    const auth: Record<string, string> = isSetupToken(key)
+      ? { Authorization: `Bearer ${key}`, "anthropic-beta": "oauth-2025-04-20" }
+      : { "x-api-key": key };
+    return {
+      label: "Anthropic",
+      url: `https://api.anthropic.com/v1/models/${model}`,
+      headers: { ...auth, "anthropic-version": "2023-06-01" },
+      model: provider.model,
+    };
+  }
+  if (provider.id === "openai") {
+    const key = secrets.openai?.apiKey ?? (provider.apiKey === "env" ? env.OPENAI_API_KEY : undefined);
+    if (!key) return null;
+    return {
+      label: "OpenAI",
+      url: `https://api.openai.com/v1/models/${model}`,
+      headers: { Authorization: `Bearer ${key}` },
+      model: provider.model,
+    };
+  }
+  if (provider.id === "openai-compatible" && provider.baseUrl) {
+    const key = secrets["openai-compatible"]?.apiKey;
+    return {
+      label: `OpenAI-compatible (${provider.baseUrl})`,
+      url: `${provider.baseUrl.replace(/\/+$/, "")}/models`,
+      headers: key ? { Authorization: `Bearer ${key}` } : {},
+      model: provider.model,
+      listModels: true,
+    };
+  }
+  return null;
+}
+
+/**
+ * Run one smoke test.
+ */
+export async function runSmokeTest(
+  target: SmokeTestTarget,
+  client: ValidationClient = validationClient,
+  now: () => number = Date.now,
+): Promise<SmokeTestResult> {
+  const started = now();
+  const result = await client.fetch(target.url, { headers: target.headers });
+  if (result.kind === "skipped") return result;
+  const latencyMs = now() - started;
+  const { status } = result.response;
+
+  if (status === 401 || status === 403) return { kind: "auth-error", status };
+  if (status === 404 && !target.listModels) return { kind: "ok", latencyMs, modelAvailable: false };
+  if (!result.response.ok) return { kind: "error", status };
+
+  if (!target.listModels) return { kind: "ok", latencyMs, modelAvailable: true };
+  const body = (await result.response.json().catch(() => ({}))) as { data?: Array<{ id?: string }> };
+  const ids = (body.data ?? []).map((m) => m.id);
+  return { kind: "ok", latencyMs, modelAvailable: ids.includes(target.model) };
+}
+
+/**
+ * Offer the test (default yes) and report each provider's result.
+ * Never blocks setup: failures are reported, and the user carries on.
+ */
+export async function offerProviderSmokeTest(
+  rl: RL,
+  providers: ProviderConfig[],
+  secrets: SecretsConfig,
+  run: (target: SmokeTestTarget) => Promise<SmokeTestResult> = (target) => runSmokeTest(target),
+): Promise<void> {
+  const targets = providers
+    .map((p) => smokeTestTarget(p, secrets))
+    .filter((target): target is SmokeTestTarget => target !== null);
+  if (targets.length === 0) return;
+
+  if (!(await askYN(rl, t("smokeTest.offer"), true))) return;
+
+  header(t("smokeTest.header"));
+  for (const target of targets) {
+    info(t("smokeTest.testing", { label: target.label }));
+    const result = await run(target);
+    if (result.kind === "skipped") {
+      noteSkippedValidation(t("smokeTest.label", { label: target.label }), result.reason);
+    } else if (result.kind === "auth-error") {
+      error(t("smokeTest.rejected", { label: target.label, status: result.status }));
+    } else if (result.kind === "error") {
+      warn(t("smokeTest.unexpected", { label: target.label, status: result.status }));
+    } else if (result.modelAvailable) {
+      success(t("smokeTest.ok", { label: target.label, latency: result.latencyMs, model: target.model }));
+    } else {
+      warn(t("smokeTest.noModel", { label: target.label, latency: result.latencyMs, model: target.model }));
+    }
+  }
+}
+
+/** Key sent in place of the primary's during the failover test */
+export const FAILOVER_TEST_KEY = "owliabot-failover-test";
+
+export interface FailoverStep {
+  label: string;
+  /** "untested": the provider can't be checked from here (OAuth, no key) */
+  result: SmokeTestResult | { kind: "untested" };
+}
+
+export type FailoverTestResult =
+  | { kind: "ok"; primary: string; servedBy: string; latencyMs: number; tried: FailoverStep[] }
+  /** The primary accepted the invalid key, so the failure couldn't be simulated */
+  | { kind: "primary-accepted"; primary: string }
+  | { kind: "no-fallback"; primary: string; tried: FailoverStep[] }
+  | { kind: "skipped"; reason: string };
+
+/**
+ * The target with its credentials replaced by FAILOVER_TEST_KEY, or null
+ * when it sends none (a local server), so it can only be left out.
+ */
+export function withInvalidKey(target: SmokeTestTarget): SmokeTestTarget | null {
+  const headers = { ...target.headers };
+  if (headers.Authorization) headers.Authorization = `Bearer ${FAILOVER_TEST_KEY}`;
+  else if (headers["x-api-key"]) headers["x-api-key"] = FAILOVER_TEST_KEY;
+  else return null;
+  return { ...target, headers };
+}
+
+/**
+ * Make the primary fail, then try the other providers in fallback order
+ * until one answers with its model available.
+ */
+export async function runFailoverTest(
+  providers: ProviderConfig[],
+  secrets: SecretsConfig,
+  run: (target: SmokeTestTarget) => Promise<SmokeTestResult> = (target) => runSmokeTest(target),
+  env: Record<string, string | undefined> = process.env,
+): Promise<FailoverTestResult> {
+  const [first, ...rest] = sortByPriority(providers);
+  const primaryTarget = smokeTestTarget(first, secrets, env);
+  const primary = primaryTarget?.label ?? first.id;
+
+  const broken = primaryTarget ? withInvalidKey(primaryTarget) : null;
+  if (broken) {
+    const result = await run(broken);
+    if (result.kind === "skipped") return result;
+    if (result.kind === "ok") return { kind: "primary-accepted", primary };
+  }
+
+  const tried: FailoverStep[] = [];
+  for (const provider of rest) {
+    const target = smokeTestTarget(provider, secrets, env);
+    if (!target) {
+      tried.push({ label: provider.id, result: { kind: "untested" } });
+      continue;
+    }
+    const result = await run(target);
+    if (result.kind === "skipped") return result;
+    tried.push({ label: target.label, result });
+    if (result.kind === "ok" && result.modelAvailable) {
+      return { kind: "ok", primary, servedBy: target.label, latencyMs: result.latencyMs, tried };
+    }
+  }
+  return { kind: "no-fallback", primary, tried };
+}
+
+function describeStep(step: FailoverStep): string {
+  switch (step.result.kind) {
+    case "untested": return t("failover.untested", { label: step.label });
+    case "auth-error": return t("failover.rejected", { label: step.label, status: step.result.status });
+    case "error": return `${step.label}: HTTP ${step.result.status}`;
+    case "ok": return t("failover.noModel", { label: step.label });
+    default: return step.label;
+  }
+}
+
+/**
+ * Offer the failover test for multi-provider setups (default no) and
+ * report whether a fallback took over. Never blocks setup.
+ */
+export async function offerFailoverTest(
+  rl: RL,
+  providers: ProviderConfig[],
+  secrets: SecretsConfig,
+  run: (target: SmokeTestTarget) => Promise<SmokeTestResult> = (target) => runSmokeTest(target),
+): Promise<void> {
+  if (providers.length < 2) return;
+  if (!(await askYN(rl, t("failover.offer"), false))) return;
+
+  header(t("failover.header"));
+  const result = await runFailoverTest(providers, secrets, run);
+  if (result.kind === "skipped") {
+    noteSkippedValidation(t("failover.header"), result.reason);
+    return;
+  }
+  if (result.kind === "primary-accepted") {
+    warn(t("failover.accepted", { primary: result.primary }));
+    return;
+  }
+  for (const step of result.tried.slice(0, result.kind === "ok" ? -1 : undefined)) info(describeStep(step));
+  if (result.kind === "ok") {
+    success(t("failover.works", { primary: result.primary, servedBy: result.servedBy, latency: result.latencyMs }));
+  } else {
+    error(t("failover.none", { primary: result.primary }));
+  }
+}
@@ -0,0 +1,230 @@
+/**
+ * Step module: expose the gateway publicly behind Caddy or Traefik.
+ *
+ * Adds a reverse proxy container to docker-compose.yml that takes ports 80
+ * and 443, gets a Let's Encrypt certificate for the user's domain, and
+ * forwards to the gateway inside compose. The gateway port is then no longer
+ * published on the host. The proxy's config (Caddyfile, or Traefik's dynamic
+ * config) lives in the config dir; certificates are kept under it too, so
+ * they survive container rebuilds.
+ */
+
+import { createInterface } from "node:readline";
+import { mkdirSync, writeFileSync } from "node:fs";
+import { join } from "node:path";
+import { header, info, success, warn, ask, askYN, selectOption, AbortError } from "../shared.js";
+import type { GatewayAuthMode } from "./gateway-auth.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export type ReverseProxy = "caddy" | "traefik";
+
+export const REVERSE_PROXIES: ReverseProxy[] = ["caddy", "traefik"];
+
+export const CADDYFILE = "Caddyfile";
+export const TRAEFIK_DYNAMIC_FILE = "traefik-dynamic.yml";
+
+export interface ReverseProxySetup {
+  proxy: ReverseProxy;
+  /** Public hostname the certificate is issued for */
+  domain: string;
+  /** Let's Encrypt account email (expiry notices); optional */
+  email?: string;
+}
+
+export function parseReverseProxy(value: string | undefined): ReverseProxy | undefined {
+  if (value === undefined) return undefined;
+  const proxy = value.trim().toLowerCase();
+  if ((REVERSE_PROXIES as string[]).includes(proxy)) return proxy as ReverseProxy;
+  throw new Error(`Unknown reverse proxy "${value}" (expected one of: ${REVERSE_PROXIES.join(", ")})`);
+}
+
+const DOMAIN = /^(?=.{1,253}$)([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$/;
+
+/** `https://Bot.Example.com/` -> `bot.example.com`; undefined when it isn't a domain name */
+export function normalizeDomain(raw: string): string | undefined {
+  const domain = raw.trim().toLowerCase().replace(/^https?:\/\//, "").replace(/\/+$/, "");
+  return DOMAIN.test(domain) ? domain : undefined;
+}
+
+/**
+ * Offer to expose the gateway publicly (interactive runs only). Returns the
+ * chosen proxy, or undefined to keep it on 127.0.0.1.
+ */
+export async function promptExposeGateway(
+  rl: RL,
+  interactive: boolean = Boolean(process.stdin.isTTY),
+): Promise<ReverseProxy | undefined> {
+  if (!interactive) return undefined;
+  if (!(await askYN(rl, t("proxy.offer"), false))) {
+    return undefined;
+  }
+  const index = await selectOption(rl, t("proxy.which"), [
+    t("proxy.caddy"),
+    "Traefik",
+  ], 0);
+  return REVERSE_PROXIES[index];
+}
+
+/**
+ * A public gateway needs more than its token, which only guards /command
+ * and /admin. Returns the gateway auth mode to use, or undefined when the
+ * user would rather not expose it after all. Unattended runs must choose
+ * `--gateway-auth basic` themselves (mtls can't sit behind the proxy);
+ * runOnboarding rejects that through gatewayAuthConflict before any prompt.
+ */
+export async function confirmReverseProxyAuth(
+  rl: RL,
+  gatewayAuth: GatewayAuthMode,
+  interactive: boolean = Boolean(process.stdin.isTTY),
+): Promise<GatewayAuthMode | undefined> {
+  if (gatewayAuth !== "none") return gatewayAuth;
+  if (!interactive) {
+    throw new Error("--reverse-proxy exposes the gateway publicly; add --gateway-auth basic");
+  }
+  warn(t("proxy.publicWarning"));
+  if (await askYN(rl, t("proxy.addBasicAuth"), true)) return "basic";
+  if (await askYN(rl, t("proxy.exposeAnyway"), false)) return "none";
+  return undefined;
+}
+
+/**
+ * Ask for the domain and the Let's Encrypt email.
+ */
+export async function promptReverseProxySetup(rl: RL, proxy: ReverseProxy): Promise<ReverseProxySetup> {
+  header(t("proxy.header", { name: proxy === "caddy" ? "Caddy" : "Traefik" }));
+  info(t("proxy.dns"));
+  info(t("proxy.port80"));
+
+  let domain: string | undefined;
+  for (let attempt = 0; attempt < 3 && !domain; attempt++) {
+    const answer = await ask(rl, t("proxy.domain"));
+    domain = normalizeDomain(answer);
+    if (!domain) warn(t("proxy.notDomain", { answer }));
+  }
+  if (!domain) throw new AbortError("A domain is required to expose the gateway");
+
+  const email = (await ask(rl, t("proxy.email"))).trim() || undefined;
+
+  success(t("proxy.added", { proxy }));
+  return { proxy, domain, email };
+}
+
+/** In-compose URL of the gateway */
+function gatewayUpstream(gatewayTls: boolean): string {
+  return `${gatewayTls ? "https" : "http"}://owliabot:8787`;
+}
+
+/**
+ * Caddyfile: automatic HTTPS for the domain, proxied to the gateway. A
+ * gateway with its own (self-signed) certificate is reached over HTTPS
+ * without verification, inside the compose network only.
+ */
+export function buildCaddyfile(setup: ReverseProxySetup, gatewayTls = false): string {
+  const global = setup.email ? `{\n\temail ${setup.email}\n}\n\n` : "";
+  const transport = gatewayTls
+    ? ` {\n\t\ttransport http {\n\t\t\ttls_insecure_skip_verify\n\t\t}\n\t}`
+    : "";
+  return `${global}${setup.domain} {\n\treverse_proxy ${gatewayUpstream(gatewayTls)}${transport}\n}\n`;
+}
+
+/**
+ * Traefik dynamic config (file provider): one router for the domain with a
+ * Let's Encrypt certificate, and the gateway as its service.
+ */
+export function buildTraefikDynamicConfig(setup: ReverseProxySetup, gatewayTls = false): string {
+  const transport = gatewayTls
+    ? `        serversTransport: owliabot
+  serversTransports:
+    owliabot:
+      insecureSkipVerify: true
+`
+    : "";
+  return `http:
+  routers:
+    owliabot:
+      rule: "Host(\`${setup.domain}\`)"
+      entryPoints: [websecure]
+      service: owliabot
+      tls:
+        certResolver: letsencrypt
+  services:
+    owliabot:
+      loadBalancer:
+        servers:
+          - url: "${gatewayUpstream(gatewayTls)}"
+${transport}`;
+}
+
+/**
+ * docker-compose service block for the proxy. It publishes 80 and 443 on
+ * all interfaces; the gateway itself stays inside compose.
+ */
+export function buildReverseProxyComposeService(setup: ReverseProxySetup, dockerConfigPath: string): string {
+  if (setup.proxy === "caddy") {
+    return `
+  caddy:
+    image: caddy:2
+    container_name: owliabot-caddy
+    restart: unless-stopped
+    ports:
+      - "80:80"
+      - "443:443"
+      - "443:443/udp"
+    volumes:
+      - ${dockerConfigPath}/${CADDYFILE}:/etc/caddy/Caddyfile:ro
+      - ${dockerConfigPath}/caddy/data:/data
+      - ${dockerConfigPath}/caddy/config:/config
+    depends_on:
+      - owliabot
+`;
+  }
+
+  const command = [
+    "--entrypoints.web.address=:80",
+    "--entrypoints.web.http.redirections.entrypoint.to=websecure",
+    "--entrypoints.web.http.redirections.entrypoint.scheme=https",
+    "--entrypoints.websecure.address=:443",
+    "--certificatesresolvers.letsencrypt.acme.httpchallenge=true",
+    "--certificatesresolvers.letsencrypt.acme.httpchallenge.entrypoint=web",
+    "--certificatesresolvers.letsencrypt.acme.storage=/letsencrypt/acme.json",
+    ...(setup.email ? [`--certificatesresolvers.letsencrypt.acme.email=${setup.email}`] : []),
+    `--providers.file.filename=/etc/traefik/${TRAEFIK_DYNAMIC_FILE}`,
+  ];
+  return `
+  traefik:
+    image: traefik:v3
+    container_name: owliabot-traefik
+    restart: unless-stopped
+    command:
+${command.map((c) => `      - "${c}"`).join("\n")}
+    ports:
+      - "80:80"
+      - "443:443"
+    volumes:
+      - ${dockerConfigPath}/${TRAEFIK_DYNAMIC_FILE}:/etc/traefik/${TRAEFIK_DYNAMIC_FILE}:ro
+      - ${dockerConfigPath}/traefik:/letsencrypt
+    depends_on:
+      - owliabot
+`;
+}
+
+/**
+ * Write the proxy's config into the config dir, and the dir its
+ * certificates go to.
+ */
+export function writeReverseProxyConfig(configDir: string, setup: ReverseProxySetup, gatewayTls = false): void {
+  const [file, content, certDir] = setup.proxy === "caddy"
+    ? [CADDYFILE, buildCaddyfile(setup, gatewayTls), join(configDir, "caddy", "data")]
+    : [TRAEFIK_DYNAMIC_FILE, buildTraefikDynamicConfig(setup, gatewayTls), join(configDir, "traefik")];
+  const path = join(configDir, file);
+  writeFileSync(path, content);
+  mkdirSync(certDir, { recursive: true, mode: 0o700 });
+  success(t("proxy.saved", { proxy: setup.proxy, path }));
+}
+
+/** Public URL line for the completion summary */
+export function describeReverseProxyUrl(setup: ReverseProxySetup): string {
+  return `https://${setup.domain}`;
+}
@@ -0,0 +1,126 @@
+/**
+ * Step module: running-as-root detection.
+ *
+ * Onboarding under sudo silently produces a root-owned ~/.owliabot, which
+ * breaks later non-root edits and the container's uid mapping. Warn up front
+ * and offer to hand ownership of the generated files back to SUDO_USER.
+ */
+
+import { createInterface } from "node:readline";
+import { chownSync, lstatSync, readdirSync } from "node:fs";
+import { join } from "node:path";
+import { warn, info, success, askYN, AbortError } from "../shared.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export interface OwnershipTarget {
+  user: string;
+  uid: number;
+  gid: number;
+}
+
+export interface RootInvocation {
+  isRoot: boolean;
+  /** The invoking user when run via sudo (SUDO_USER/SUDO_UID/SUDO_GID) */
+  sudoTarget: OwnershipTarget | null;
+}
+
+/**
+ * Detect whether onboarding runs as root, and who invoked it via sudo.
+ */
+export function detectRootInvocation(
+  euid: number | undefined = process.geteuid?.(),
+  env: Record<string, string | undefined> = process.env,
+): RootInvocation {
+  if (euid !== 0) return { isRoot: false, sudoTarget: null };
+
+  const user = env.SUDO_USER?.trim();
+  const uid = Number.parseInt(env.SUDO_UID ?? "", 10);
+  const gid = Number.parseInt(env.SUDO_GID ?? "", 10);
+  const sudoTarget = user && user !== "root" && Number.isInteger(uid) && Number.isInteger(gid) && uid > 0
+    ? { user, uid, gid }
+    : null;
+
+  return { isRoot: true, sudoTarget };
+}
+
+/**
+ * Warn when running as root and ask how to proceed.
+ * Returns the user that should own the generated files, or null to keep root ownership.
+ * Throws AbortError when the user declines to continue as root.
+ *
+ * Without a TTY we can't ask, so we only warn and fall back to the prompt
+ * defaults (hand files to SUDO_USER when known, otherwise keep going as root).
+ */
+export async function confirmRootInvocation(
+  rl: RL,
+  invocation: RootInvocation,
+  configDir: string,
+  interactive: boolean = Boolean(process.stdin.isTTY),
+): Promise<OwnershipTarget | null> {
+  if (!invocation.isRoot) return null;
+
+  console.log("");
+  warn(t("root.warning"));
+  warn(t("root.ownedByRoot", { dir: configDir }));
+  warn(t("root.ownedByRootWhy"));
+
+  if (!interactive) return invocation.sudoTarget;
+
+  if (invocation.sudoTarget) {
+    const { user } = invocation.sudoTarget;
+    const handOver = await askYN(rl, t("root.handOver", { user }), true);
+    if (handOver) {
+      info(t("root.willHandOver", { user }));
+      return invocation.sudoTarget;
+    }
+  } else {
+    info(t("root.tip"));
+  }
+
+  const proceed = await askYN(rl, t("root.continue"), false);
+  if (!proceed) throw new AbortError("Declined to run as root");
+  return null;
+}
+
+/**
+ * Best-effort recursive chown. Symlinks are skipped so we never follow them
+ * out of the tree.
+ */
+export function chownTree(rootPath: string, target: OwnershipTarget): void {
+  if (process.platform === "win32") return;
+
+  const stack: string[] = [rootPath];
+  while (stack.length > 0) {
+    const p = stack.pop();
+    if (!p) break;
+
+    let st: ReturnType<typeof lstatSync>;
+    try {
+      st = lstatSync(p);
+    } catch {
+      continue;
+    }
+
+    if (st.isSymbolicLink()) continue;
+    try { chownSync(p, target.uid, target.gid); } catch { /* best-effort */ }
+
+    if (st.isDirectory()) {
+      let entries;
+      try { entries = readdirSync(p, { withFileTypes: true }); } catch { continue; }
+      for (const ent of entries) {
+        stack.push(join(p, ent.name));
+      }
+    }
+  }
+}
+
+/**
+ * Hand generated paths over to the sudo invoker.
+ */
+export function applyOwnership(paths: string[], target: OwnershipTarget | null): void {
+  if (!target) return;
+  for (const p of paths) chownTree(p, target);
+  success(t("root.handedOver", { user: target.user }));
+}
@@ -0,0 +1,124 @@
+/**
+ * Step module: plain-text dump of every wizard screen (`onboard --dump-screens <dir>`).
+ *
+ * For accessibility reviews and screen-reader testing the wizard is walked
+ * with default answers and the text of each screen is written to its own
+ * file (01-start.txt, 02-ai-providers.txt, ...), without colors. A screen
+ * starts at each `━━━ Title ━━━` header. The run is a dry run: nothing is
+ * written besides the dump, and stdin is treated as non-interactive so no
+ * live lookups are made.
+ */
+
+import { mkdirSync, writeFileSync } from "node:fs";
+import { join } from "node:path";
+import { format } from "node:util";
+import { info, setScriptedAnswers } from "../shared.js";
+import { t } from "../i18n.js";
+
+export interface Screen {
+  title: string;
+  lines: string[];
+}
+
+const ANSI = /\x1b\[[0-9;?]*[A-Za-z]/g;
+// `━━━ Title ━━━`, or `Step: Title` in accessible mode
+const HEADER = /^(?:━━━ (.+) ━━━|Step: (.+))$/;
+
+/** Attempts per prompt before the walk gives up on it */
+const DEFAULT_ATTEMPTS = ["", "1", "y"];
+
+export function stripAnsi(text: string): string {
+  return text.replace(ANSI, "");
+}
+
+/**
+ * Answers for walking the wizard unattended: Enter (the default) first. A
+ * prompt asked again right away (the default was rejected) gets "1", then
+ * "y", and then the walk stops with an error naming the prompt.
+ */
+export function defaultAnswers(): (question: string) => string {
+  let last = "";
+  let attempt = 0;
+  return (question) => {
+    attempt = question === last ? attempt + 1 : 0;
+    last = question;
+    if (attempt >= DEFAULT_ATTEMPTS.length) {
+      throw new Error(`--dump-screens: no default answer gets past "${stripAnsi(question).trim()}"`);
+    }
+    return DEFAULT_ATTEMPTS[attempt];
+  };
+}
+
+/** Split printed lines into screens at each header line */
+export function splitScreens(lines: string[]): Screen[] {
+  const screens: Screen[] = [{ title: "start", lines: [] }];
+  for (const line of lines) {
+    const match = HEADER.exec(line.trim());
+    if (match) screens.push({ title: match[1] ?? match[2], lines: [] });
+    screens.at(-1)!.lines.push(line);
+  }
+  return screens
+    .map((s) => ({ title: s.title, lines: trimBlankLines(s.lines) }))
+    .filter((s) => s.lines.length > 0);
+}
+
+function trimBlankLines(lines: string[]): string[] {
+  let start = 0;
+  let end = lines.length;
+  while (start < end && !lines[start].trim()) start++;
+  while (end > start && !lines[end - 1].trim()) end--;
+  return lines.slice(start, end);
+}
+
+function slug(title: string): string {
+  return title.toLowerCase().replace(/[^a-z0-9]+/g, "-").replace(/^-|-$/g, "") || "screen";
+}
+
+/** Write one file per screen; returns the paths written */
+export function writeScreens(dir: string, screens: Screen[]): string[] {
+  mkdirSync(dir, { recursive: true });
+  const width = Math.max(2, String(screens.length).length);
+  return screens.map((screen, i) => {
+    const path = join(dir, `${String(i + 1).padStart(width, "0")}-${slug(screen.title)}.txt`);
+    writeFileSync(path, `${screen.lines.join("\n")}\n`);
+    return path;
+  });
+}
+
+export interface ScreenDump {
+  /** Stop recording and write the screens. Safe to call more than once. */
+  finish(): void;
+}
+
+/**
+ * Start recording: console output is captured (and still shown), prompts are
+ * answered with defaultAnswers() and stdin reports itself as not a TTY.
+ */
+export function startScreenDump(dir: string): ScreenDump {
+  const lines: string[] = [];
+  const original = { log: console.log, error: console.error, warn: console.warn };
+  const wasTTY = process.stdin.isTTY;
+  const capture = (print: (...args: unknown[]) => void) => (...args: unknown[]) => {
+    lines.push(...stripAnsi(format(...args)).split("\n"));
+    print(...args);
+  };
+
+  console.log = capture(original.log);
+  console.error = capture(original.error);
+  console.warn = capture(original.warn);
+  process.stdin.isTTY = false as true;
+  setScriptedAnswers(defaultAnswers());
+
+  let finished = false;
+  return {
+    finish() {
+      if (finished) return;
+      finished = true;
+      setScriptedAnswers(null);
+      process.stdin.isTTY = wasTTY;
+      Object.assign(console, original);
+      const written = writeScreens(dir, splitScreens(lines));
+      info(t("screens.written", { count: written.length, dir }));
+    },
+  };
+}
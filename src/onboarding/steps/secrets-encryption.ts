@@ -0,0 +1,68 @@
+/**
+ * Step module: encrypt secrets.yaml at rest with age.
+ *
+ * `--encrypt-secrets` creates (or reuses) an age identity in
+ * <configDir>/auth/secrets.agekey. saveSecrets() encrypts to it whenever it
+ * exists, and every reader decrypts with it (config/secrets-crypto.ts).
+ * In docker mode the key is also mounted read-only into the container.
+ */
+
+import { existsSync, mkdirSync } from "node:fs";
+import { join } from "node:path";
+import { generateSecretsKey, SECRETS_KEY_FILE } from "../../config/secrets-crypto.js";
+import { header, info, success, warn } from "../shared.js";
+import { t } from "../i18n.js";
+
+/** Where the key is mounted inside the container. */
+export const CONTAINER_SECRETS_KEY_PATH = "/run/secrets/owliabot-secrets.agekey";
+
+export interface SecretsEncryptionResult {
+  keyPath: string;
+  /** False when an existing key was reused */
+  generated: boolean;
+}
+
+export function secretsEncryptionKeyPath(configDir: string): string {
+  return join(configDir, "auth", SECRETS_KEY_FILE);
+}
+
+/**
+ * Make sure the config dir has an age key. With `generate: false` (dry run)
+ * nothing is created.
+ */
+export function prepareSecretsEncryption(
+  configDir: string,
+  opts: { generate?: boolean } = {},
+): SecretsEncryptionResult {
+  const keyPath = secretsEncryptionKeyPath(configDir);
+  if (existsSync(keyPath)) {
+    info(t("secretsKey.reusing", { path: keyPath }));
+    return { keyPath, generated: false };
+  }
+  if (opts.generate === false) return { keyPath, generated: true };
+
+  mkdirSync(join(configDir, "auth"), { recursive: true });
+  generateSecretsKey(keyPath);
+  success(t("secretsKey.created", { path: keyPath }));
+  return { keyPath, generated: true };
+}
+
+/**
+ * Dry-run note: the preview shows plaintext, the real file would not be.
+ */
+export function describeSecretsEncryption(configDir: string): string {
+  return t("secretsKey.dryRun", { path: secretsEncryptionKeyPath(configDir) });
+}
+
+/**
+ * Remind the user that the key is now required to read their secrets.
+ */
+export function printSecretsEncryptionSummary(result: SecretsEncryptionResult): void {
+  header(t("secretsKey.header"));
+  console.log(`  ${t("secretsKey.encrypted", { path: result.keyPath })}`);
+  console.log(`  ${t("secretsKey.view", { command: `age -d -i ${result.keyPath} secrets.yaml` })}`);
+  if (result.generated) {
+    warn(t("secretsKey.backup"));
+  }
+  console.log("");
+}
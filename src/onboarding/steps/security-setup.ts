@@ -1,28 +1,30 @@
 /**
  * Step module: write tools security configuration.
+ *
+ * The allow-list decides who may trigger file writes; the confirmation
+ * questions decide whether each write waits for a yes/no reply, who gives
+ * it, where the bot asks, and how long before it gives up and denies.
  */
 
 import { createInterface } from "node:readline";
 import type { AppConfig } from "../types.js";
-import { info, success, header, ask } from "../shared.js";
+import { info, success, header, ask, askYN, selectOption, warn } from "../shared.js";
 import type { UserAllowLists } from "./types.js";
+import { t } from "../i18n.js";
 
 export async function configureWriteToolsSecurity(
   rl: ReturnType<typeof createInterface>,
   config: AppConfig,
   userAllowLists: UserAllowLists,
 ): Promise<string[] | null> {
-  const allUserIds = [...userAllowLists.discord, ...userAllowLists.telegram];
+  const allUserIds = [...userAllowLists.discord, ...userAllowLists.telegram, ...(userAllowLists.slack ?? [])];
   if (allUserIds.length === 0) return null;
 
-  header("Write tools security");
-  info("Users in the write-tool allowlist can use file write/edit tools.");
-  info(`Auto-included from channel allowlists: ${allUserIds.join(", ")}`);
+  header(t("security.header"));
+  info(t("security.intro"));
+  info(t("security.autoIncluded", { ids: allUserIds.join(", ") }));
 
-  const writeAllowListAns = await ask(
-    rl,
-    "Additional user IDs to allow (comma-separated, leave empty to use only channel users): ",
-  );
+  const writeAllowListAns = await ask(rl, t("security.additional"));
   const additionalIds = writeAllowListAns.split(",").map((s) => s.trim()).filter(Boolean);
   const writeToolAllowList = [...new Set([...allUserIds, ...additionalIds])];
   if (writeToolAllowList.length === 0) return null;
@@ -32,18 +34,69 @@ export async function configureWriteToolsSecurity(
     allowWrite: true,
   };
   config.security = {
-    writeGateEnabled: false,
+    writeGateEnabled: true,
     writeToolAllowList,
-    writeToolConfirmation: false,
+    ...(await askWriteConfirmation(rl, allUserIds)),
   };
 
-  success("Filesystem write tools enabled (write_file/edit_file/apply_patch)");
-  success(`Write-tool allowlist: ${writeToolAllowList.join(", ")}`);
-  success("Write-gate globally disabled");
-  success("Write-tool confirmation disabled (allowlisted users can write directly)");
+  success(t("security.enabled"));
+  success(t("security.allowList", { ids: writeToolAllowList.join(", ") }));
+  success(describeWriteConfirmation(config.security));
   return writeToolAllowList;
 }
 
+type ConfirmationSettings = Pick<
+  NonNullable<AppConfig["security"]>,
+  | "writeToolConfirmation"
+  | "writeToolConfirmationApprover"
+  | "writeToolConfirmationIn"
+  | "writeToolConfirmationTimeoutMs"
+>;
+
+async function askWriteConfirmation(
+  rl: ReturnType<typeof createInterface>,
+  channelUserIds: string[],
+): Promise<ConfirmationSettings> {
+  info(t("security.confirmIntro"));
+  const confirm = await askYN(rl, t("security.confirmAsk"), true);
+  if (!confirm) return { writeToolConfirmation: false };
+
+  const approver = (await ask(rl, t("security.approver"))).trim();
+  if (approver && !channelUserIds.includes(approver)) {
+    warn(t("security.approverIgnored", { id: approver }));
+  }
+  const where = await selectOption(
+    rl,
+    t("security.where"),
+    [t("security.whereChat"), t("security.whereDm", { who: approver || t("security.requester") })],
+    0,
+  );
+
+  const secondsAns = (await ask(rl, t("security.timeout"))).trim();
+  let seconds = 60;
+  if (secondsAns) {
+    const parsed = Number(secondsAns);
+    if (Number.isInteger(parsed) && parsed > 0) seconds = parsed;
+    else warn(t("security.badTimeout", { answer: secondsAns }));
+  }
+
+  return {
+    writeToolConfirmation: true,
+    ...(approver ? { writeToolConfirmationApprover: approver } : {}),
+    writeToolConfirmationIn: where === 1 ? "dm" : "chat",
+    writeToolConfirmationTimeoutMs: seconds * 1000,
+  };
+}
+
+function describeWriteConfirmation(security: ConfirmationSettings): string {
+  if (security.writeToolConfirmation === false) {
+    return t("security.confirmOff");
+  }
+  const who = security.writeToolConfirmationApprover ?? t("security.requestingUser");
+  const seconds = Math.round((security.writeToolConfirmationTimeoutMs ?? 60_000) / 1000);
+  return t(security.writeToolConfirmationIn === "dm" ? "security.confirmDm" : "security.confirmChat", { who, seconds });
+}
+
 export function deriveWriteToolAllowListFromConfig(config: AppConfig): string[] | null {
   const sec = (config as any).security as { writeToolAllowList?: unknown } | undefined;
   const fromSecurity = sec?.writeToolAllowList;
@@ -54,6 +107,7 @@ export function deriveWriteToolAllowListFromConfig(config: AppConfig): string[]
   const ids = new Set<string>();
   const discord = (config as any).discord as { memberAllowList?: unknown } | undefined;
   const telegram = (config as any).telegram as { allowList?: unknown } | undefined;
+  const slack = (config as any).slack as { memberAllowList?: unknown } | undefined;
 
   if (Array.isArray(discord?.memberAllowList)) {
     for (const v of discord.memberAllowList) {
@@ -65,6 +119,11 @@ export function deriveWriteToolAllowListFromConfig(config: AppConfig): string[]
       if (typeof v === "string" && v.trim()) ids.add(v.trim());
     }
   }
+  if (Array.isArray(slack?.memberAllowList)) {
+    for (const v of slack.memberAllowList) {
+      if (typeof v === "string" && v.trim()) ids.add(v.trim());
+    }
+  }
 
   return ids.size > 0 ? [...ids] : null;
 }
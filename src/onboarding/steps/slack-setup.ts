@@ -0,0 +1,49 @@
+/**
+ * Step module: Slack tokens during the Chat stage.
+ *
+ * The bot connects over Socket Mode, so it needs two tokens: the bot token
+ * (xoxb-, OAuth & Permissions) for the Web API and an app-level token
+ * (xapp-, connections:write) for the socket. No public URL is involved.
+ */
+
+import { createInterface } from "node:readline";
+import type { SecretsConfig } from "../secrets.js";
+import { info, success, error } from "../shared.js";
+import { askCredential } from "./placeholder-credentials.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+const SLACK_TOKEN_PREFIXES = { botToken: "xoxb-", appToken: "xapp-" } as const;
+
+async function askSlackToken(rl: RL, question: string, kind: keyof typeof SLACK_TOKEN_PREFIXES): Promise<string> {
+  const prefix = SLACK_TOKEN_PREFIXES[kind];
+  for (;;) {
+    const token = await askCredential(rl, question, "slack", true);
+    if (!token || token.startsWith(prefix)) return token;
+    error(t("slack.wrongPrefix", { prefix }));
+  }
+}
+
+/**
+ * Ask for both Slack tokens and store them in `secrets.slack`.
+ * Returns the bot token ("" when the user will add it later).
+ */
+export async function askSlackTokens(rl: RL, secrets: SecretsConfig): Promise<string> {
+  console.log("");
+  info(t("slack.createApp"));
+  info(t("slack.scopes", { scopes: "app_mentions:read, chat:write, im:history, channels:history, reactions:write, users:read" }));
+  info(t("slack.guide", { url: "https://github.com/owliabot/owliabot/blob/main/docs/slack-setup.md" }));
+
+  const botToken = await askSlackToken(rl, t("slack.botToken"), "botToken");
+  const appToken = await askSlackToken(rl, t("slack.appToken"), "appToken");
+
+  if (botToken || appToken) {
+    secrets.slack = {
+      ...(botToken && { botToken }),
+      ...(appToken && { appToken }),
+    };
+  }
+  if (botToken && appToken) success(t("slack.saved"));
+  return botToken;
+}
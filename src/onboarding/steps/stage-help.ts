@@ -0,0 +1,207 @@
+/**
+ * Step module: help articles for the wizard stages, shown full screen.
+ *
+ * F1 at any prompt (or "h" at a numbered or yes/no question) opens the
+ * article for the current stage in a pager, so the concepts behind a
+ * question (providers, intents, MCP, write gates, ...) can be read without
+ * leaving the terminal. Articles are markdown kept in this file (the build
+ * only ships compiled TypeScript) and rendered to ANSI for the pager.
+ */
+
+import { spawnSync } from "node:child_process";
+import { COLORS, isAccessible } from "../shared.js";
+
+export const STAGE_HELP: Record<string, string> = {
+  "existing-config": `# Existing setup
+
+OwliaBot found an \`app.yaml\` (and maybe \`secrets.yaml\`) from an earlier run.
+
+- **Reuse** keeps the providers, channel tokens and gateway token you already have, so you only answer what is missing.
+- **Start over** asks everything again. The files about to be replaced are copied to \`backups/\` first; \`owliabot rollback\` puts them back.
+
+Several bots on one host each get their own profile (\`--profile <name>\`), so reusing one never touches another.
+`,
+  providers: `# AI providers
+
+A provider is the service that runs the model: Anthropic, OpenAI, OpenAI Codex (ChatGPT sign-in), Azure OpenAI, AWS Bedrock, or any OpenAI-compatible server (Ollama, vLLM, LM Studio, ...).
+
+- **API key or OAuth.** Keys go to \`secrets.yaml\` (or the OS keychain / \`.env\` when you asked for that). OAuth providers store their tokens under \`auth/\`; the installer runs \`owliabot auth setup\` for them.
+- **Fallback order.** With more than one provider, the first one answers and the next ones take over when it fails (rate limits, outages). Put the cheapest reliable one first.
+- **Model.** Each provider gets a default model; change it later in \`app.yaml\` under \`providers\`.
+
+A quick test call checks each key before anything is written.
+`,
+  channels: `# Chat channels
+
+Channels are where people talk to the bot: Discord, Telegram, Slack, or a plain webhook.
+
+## Discord
+
+- Create an application and a bot at https://discord.com/developers/applications and copy its token.
+- **Intents** are the kinds of events Discord sends to the bot. OwliaBot needs the privileged **Message Content Intent** (Bot tab, Privileged Gateway Intents); without it the bot sees messages but not their text.
+- Invite the bot with the \`bot\` scope and permission to read and send messages.
+
+## Telegram
+
+- Talk to @BotFather, send \`/newbot\`, and copy the token.
+- Press **Start** in a chat with your bot before it can message you.
+
+## Who may talk to it
+
+Allow lists (user IDs, channel IDs, chat IDs) limit who the bot answers. Leave them empty only for a private server.
+`,
+  timezone: `# Timezone
+
+The bot uses it for dates in its answers, for scheduled jobs and for log timestamps. Pick the IANA name (for example \`Europe/Berlin\`); the detected one is usually right.
+`,
+  docker: `# Docker
+
+Onboarding writes \`docker-compose.yml\` next to where you ran it. The config lives in \`~/.owliabot\` on the host, mounted into the container.
+
+- **Gateway port.** The bot's HTTP gateway (health checks, webhooks) is published on \`127.0.0.1:<port>\` only. Pick another port if something already uses 8787.
+- **Swarm.** On a swarm manager you can get \`docker-stack.yml\` for \`docker stack deploy\` instead.
+- **Auto-update.** Watchtower can pull new OwliaBot images and restart the bot by itself. It only touches the bot container but needs the Docker socket.
+
+Start it with \`docker compose up -d\` and check it with \`owliabot status\`.
+`,
+  config: `# Bot settings
+
+## Workspace
+
+The folder the bot reads and writes: its memory, notes and files you give it. In Docker it is \`~/.owliabot/workspace\` on the host.
+
+## MCP servers
+
+MCP (Model Context Protocol) servers give the bot extra tools: a browser (Playwright), GitHub, a filesystem, or your own server. Each preset comes with its own limits; a custom server runs with the command and environment you give it. Enable only what you need: every tool is something the model may call.
+
+## Write gates
+
+Write tools (\`edit_file\`, \`write_file\`, \`apply_patch\`) change files in the workspace. The write gate decides who may trigger them and whether someone must confirm each write:
+
+- **Allowed users** may use write tools at all; everyone else gets read-only tools.
+- **Confirmation** asks the approver (the requester, or a user you name) in the chat or by direct message, and denies the write after the timeout.
+
+\`owliabot permissions\` shows the result in plain words.
+
+## Gateway
+
+The HTTP gateway serves \`/health\` and webhooks. Protect it with a token, mTLS or an OIDC login when it is reachable from outside.
+`,
+  kiosk: `# Kiosk preset
+
+For a bot that children or strangers talk to. It answers one person in one place, and can only read.
+
+- **One user, one channel.** Everyone else is ignored, and the other chat channels are removed.
+- **Read-only tools.** No web, no file writes, no MCP servers, no wallet, no scheduled jobs. Shell commands are limited to ones that only read, such as \`ls\` and \`cat\`.
+- **Short memory.** Events are kept for an hour; chats are not summarized or indexed.
+
+Edit \`app.yaml\` afterwards to loosen any of it.
+`,
+  environments: `# Environments
+
+With \`--environments dev,prod\` each environment gets its own config dir, compose file, container and port, built from the answers you gave once. You pick the image tag and port per environment; the last image that reached ready is offered, so rolling back is one answer.
+`,
+};
+
+/** Help is there for this stage */
+export function hasStageHelp(stage: string): boolean {
+  return stage in STAGE_HELP;
+}
+
+const BOLD = "\x1b[1m";
+const BOLD_OFF = "\x1b[22m";
+
+/** Bold on/off, blank in accessible mode and the mono theme */
+function bold(): [string, string] {
+  return COLORS.NC ? [BOLD, BOLD_OFF] : ["", ""];
+}
+
+function inline(text: string): string {
+  const [BOLD, BOLD_OFF] = bold();
+  return text
+    .replace(/`([^`]+)`/g, `${COLORS.YELLOW}$1${COLORS.NC}`)
+    .replace(/\*\*([^*]+)\*\*/g, `${BOLD}$1${BOLD_OFF}`);
+}
+
+const ANSI = /\x1b\[[0-9;]*m/g;
+
+/** Word-wrap to `width` visible columns; the first line starts with `first`, the others with `rest` */
+function wrap(text: string, width: number, first: string, rest: string): string[] {
+  const lines: string[] = [];
+  let line = first;
+  let empty = true;
+  for (const word of inline(text).split(/\s+/).filter(Boolean)) {
+    const candidate = line + (empty ? "" : " ") + word;
+    if (!empty && candidate.replace(ANSI, "").length > width) {
+      lines.push(line);
+      line = rest + word;
+    } else {
+      line = candidate;
+    }
+    empty = false;
+  }
+  lines.push(line);
+  return lines;
+}
+
+/**
+ * Render the markdown the articles use (headings, paragraphs, `-` lists,
+ * `code` and **bold**) to ANSI text wrapped at `width` columns. In
+ * accessible mode the text is plain: no colors, bold or rules.
+ */
+export function renderMarkdown(markdown: string, width: number = 80): string {
+  const [BOLD, BOLD_OFF] = bold();
+  const out: string[] = [];
+  const blocks = markdown.trim().split(/\n{2,}/);
+  for (const block of blocks) {
+    const lines = block.split("\n");
+    const heading = /^(#{1,3})\s+(.*)$/.exec(lines[0]);
+    if (heading && lines.length === 1) {
+      const title = heading[2];
+      out.push(heading[1].length === 1 && !isAccessible()
+        ? `${COLORS.CYAN}${BOLD}${title}${BOLD_OFF}\n${"━".repeat(Math.min(title.length, width))}${COLORS.NC}`
+        : `${COLORS.CYAN}${BOLD}${title}${BOLD_OFF}${COLORS.NC}`);
+    } else if (lines.every((l) => l.startsWith("- "))) {
+      const bullet = isAccessible() ? "  - " : "  • ";
+      out.push(lines.flatMap((l) => wrap(l.slice(2), width, bullet, "    ")).join("\n"));
+    } else {
+      out.push(wrap(lines.join(" "), width, "", "").join("\n"));
+    }
+  }
+  return `${out.join("\n\n")}\n`;
+}
+
+/**
+ * Pager command: $PAGER, else `less -R` (keeps the colors), or null when
+ * output is not a terminal.
+ */
+export function pagerCommand(
+  env: NodeJS.ProcessEnv = process.env,
+  interactive: boolean = Boolean(process.stdout.isTTY),
+): { cmd: string; args: string[] } | null {
+  if (!interactive) return null;
+  if (env.PAGER?.trim()) return { cmd: "sh", args: ["-c", env.PAGER] };
+  return { cmd: "less", args: ["-R"] };
+}
+
+/**
+ * Show the article for `stage` in the pager (printed when there is none, or
+ * it fails to start). Returns false when the stage has no article.
+ */
+export function showStageHelp(
+  stage: string,
+  pager = pagerCommand(),
+  width: number = Math.min(process.stdout.columns || 80, 100),
+): boolean {
+  const article = STAGE_HELP[stage];
+  if (!article) return false;
+  const text = renderMarkdown(article, width - 2);
+  if (pager) {
+    // The pager reads keys from the terminal; the article comes in on stdin.
+    const result = spawnSync(pager.cmd, pager.args, { input: text, stdio: ["pipe", "inherit", "inherit"] });
+    if (!result.error) return true;
+  }
+  console.log("");
+  console.log(text);
+  return true;
+}
@@ -0,0 +1,112 @@
+/**
+ * Step module: how long each onboarding stage took.
+ *
+ * The closing message says how long setup took, with the slowest stages, and
+ * every real run (not --dry-run) appends one JSON line to
+ * onboarding-history.jsonl next to app.yaml: outcome, last stage reached and
+ * time per stage. Cancelled and failed runs are recorded too, so a support
+ * request can show where setup stalled. Only stage names and durations are
+ * stored, never answers.
+ */
+
+import { appendFileSync, existsSync } from "node:fs";
+import { join } from "node:path";
+import { info } from "../shared.js";
+import type { FlowOutcome } from "./flow-trace.js";
+import { t } from "../i18n.js";
+
+export const ONBOARDING_HISTORY_FILE = "onboarding-history.jsonl";
+
+export interface StageDuration {
+  stage: string;
+  ms: number;
+}
+
+export interface SetupTiming {
+  outcome: FlowOutcome;
+  /** Last stage entered before the run ended */
+  lastStage: string;
+  totalMs: number;
+  stages: StageDuration[];
+}
+
+export class StageTimer {
+  private readonly startedAt: number;
+  private readonly stages: StageDuration[] = [];
+  private current: { stage: string; since: number } | null = null;
+
+  constructor(private readonly now: () => number = () => Date.now()) {
+    this.startedAt = now();
+  }
+
+  /** Close the running stage and start `stage`. Re-entering adds to it. */
+  enter(stage: string): void {
+    this.close();
+    this.current = { stage, since: this.now() };
+  }
+
+  /** Timing so far; the running stage counts up to now */
+  summary(outcome: FlowOutcome): SetupTiming {
+    const now = this.now();
+    const stages = this.stages.map((s) => ({ ...s }));
+    if (this.current) addTo(stages, this.current.stage, now - this.current.since);
+    return {
+      outcome,
+      lastStage: this.current?.stage ?? "start",
+      totalMs: now - this.startedAt,
+      stages,
+    };
+  }
+
+  private close(): void {
+    if (!this.current) return;
+    addTo(this.stages, this.current.stage, this.now() - this.current.since);
+  }
+}
+
+function addTo(stages: StageDuration[], stage: string, ms: number): void {
+  const existing = stages.find((s) => s.stage === stage);
+  if (existing) existing.ms += ms;
+  else stages.push({ stage, ms });
+}
+
+/** "4m12s", "38s", "0s" */
+export function formatDuration(ms: number): string {
+  const seconds = Math.round(ms / 1000);
+  const m = Math.floor(seconds / 60);
+  const s = seconds % 60;
+  return m > 0 ? `${m}m${String(s).padStart(2, "0")}s` : `${s}s`;
+}
+
+/**
+ * "Setup took 4m12s (providers 2m05s, channels 1m10s, config 40s)": the
+ * three slowest stages, skipping those under a second.
+ */
+export function describeSetupTiming(timing: SetupTiming): string {
+  const slowest = [...timing.stages]
+    .filter((s) => s.ms >= 1000)
+    .sort((a, b) => b.ms - a.ms)
+    .slice(0, 3)
+    .map((s) => `${s.stage} ${formatDuration(s.ms)}`);
+  const breakdown = slowest.length > 0 ? ` (${slowest.join(", ")})` : "";
+  return t("timing.took", { duration: formatDuration(timing.totalMs), breakdown });
+}
+
+export function printSetupTiming(timing: SetupTiming): void {
+  info(describeSetupTiming(timing));
+}
+
+/**
+ * Append the run to onboarding-history.jsonl in `dir`. Best-effort, and
+ * never creates `dir`: a run cancelled before anything was written leaves
+ * no trace on a fresh machine.
+ */
+export function appendSetupHistory(dir: string, timing: SetupTiming, now: Date = new Date()): void {
+  if (!existsSync(dir)) return;
+  const line = JSON.stringify({ timestamp: now.toISOString(), ...timing });
+  try {
+    appendFileSync(join(dir, ONBOARDING_HISTORY_FILE), `${line}\n`, { mode: 0o600 });
+  } catch {
+    // Timing history is a convenience; never fail onboarding over it
+  }
+}
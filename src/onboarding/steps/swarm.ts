@@ -0,0 +1,153 @@
+/**
+ * Step module: `docker stack deploy` output for swarm-mode engines.
+ *
+ * Same service as docker-compose.yml, reshaped for swarm: no container_name
+ * or restart (deploy.restart_policy instead), a single replica pinned to a
+ * manager node (the config dir is a bind mount), host-mode port publishing
+ * and ${HOME} instead of `~`, which `docker stack deploy` does not expand.
+ */
+
+import { execFileSync } from "node:child_process";
+import { createInterface } from "node:readline";
+import { writeFileSync } from "node:fs";
+import { join } from "node:path";
+import { header, success, info, askYN, COLORS } from "../shared.js";
+import type { DockerComposeOptions, DockerPaths } from "./docker.js";
+import { CONTAINER_SECRETS_KEY_PATH } from "./secrets-encryption.js";
+import { CA_BUNDLE_FILE, CONTAINER_CA_BUNDLE_PATH } from "./ca-bundle.js";
+import { containerCliCandidates } from "../../logs/docker.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export const DOCKER_STACK_FILE = "docker-stack.yml";
+
+export const DEFAULT_STACK_NAME = "owliabot";
+
+/**
+ * Whether the local engine runs in swarm mode. install.sh checks on the host
+ * and passes OWLIABOT_SWARM_ACTIVE into the onboarding container, which has
+ * no docker CLI of its own.
+ */
+export function detectSwarmActive(
+  env: Record<string, string | undefined> = process.env,
+  exec: (cmd: string, args: string[]) => string = (cmd, args) =>
+    execFileSync(cmd, args, { stdio: "pipe", encoding: "utf-8", timeout: 5_000 }),
+): boolean {
+  if (env.OWLIABOT_SWARM_ACTIVE !== undefined) return env.OWLIABOT_SWARM_ACTIVE === "1";
+  // Podman has no swarm mode
+  if (!containerCliCandidates(env).includes("docker")) return false;
+  try {
+    return exec("docker", ["info", "--format", "{{.Swarm.LocalNodeState}}"]).trim() === "active";
+  } catch {
+    return false;
+  }
+}
+
+/**
+ * Offer the stack variant when swarm is active (interactive runs only;
+ * the engine is not probed otherwise).
+ */
+export async function promptSwarmOutput(
+  rl: RL,
+  detect: () => boolean = () => detectSwarmActive(),
+  interactive: boolean = Boolean(process.stdin.isTTY),
+): Promise<boolean> {
+  if (!interactive || !detect()) return false;
+  info(t("swarm.detected"));
+  return askYN(rl, t("swarm.offer", { file: DOCKER_STACK_FILE }), true);
+}
+
+/** `~/x` -> `${HOME}/x` (stack deploy interpolates variables but not `~`). */
+function expandHomeForStack(path: string): string {
+  return path === "~" ? "${HOME}" : path.replace(/^~\//, "${HOME}/");
+}
+
+/**
+ * Build docker-stack.yml content.
+ */
+export function buildDockerStackYaml(
+  dockerConfigPath: string,
+  envLines: string[],
+  gatewayPort: string,
+  defaultImage: string,
+  options: Pick<DockerComposeOptions, "gatewayTls" | "secretsKey" | "caBundle"> = {},
+): string {
+  const configPath = expandHomeForStack(dockerConfigPath);
+  const healthcheck = options.gatewayTls
+    ? `["CMD", "wget", "-qO-", "--no-check-certificate", "https://localhost:8787/health"]`
+    : `["CMD", "wget", "-qO-", "http://localhost:8787/health"]`;
+  const env = [
+    ...envLines,
+    ...(options.secretsKey ? [`OWLIABOT_SECRETS_KEY_FILE=${CONTAINER_SECRETS_KEY_PATH}`] : []),
+    ...(options.caBundle ? [`NODE_EXTRA_CA_CERTS=${CONTAINER_CA_BUNDLE_PATH}`] : []),
+  ];
+  const envBlock = env.length > 0 ? env.map((v) => `      - ${v}`).join("\n") : "      - TZ=UTC";
+  const keyMount = [
+    options.secretsKey ? `      - ${configPath}/auth/secrets.agekey:${CONTAINER_SECRETS_KEY_PATH}:ro\n` : "",
+    options.caBundle ? `      - ${configPath}/${CA_BUNDLE_FILE}:${CONTAINER_CA_BUNDLE_PATH}:ro\n` : "",
+  ].join("");
+
+  return `# docker-stack.yml for OwliaBot (swarm mode)
+# Generated by onboard
+# Deploy with: docker stack deploy -c ${DOCKER_STACK_FILE} ${DEFAULT_STACK_NAME}
+
+services:
+  owliabot:
+    image: \${OWLIABOT_IMAGE:-${defaultImage}}
+    # Host-mode publishing binds on all interfaces of the node running the task;
+    # firewall the port if the node is reachable from outside.
+    ports:
+      - target: 8787
+        published: ${gatewayPort}
+        protocol: tcp
+        mode: host
+    volumes:
+      - ${configPath}:/home/owliabot/.owliabot
+      - ${configPath}/workspace:/app/workspace
+${keyMount}    environment:
+${envBlock}
+    command: ["start", "-c", "/home/owliabot/.owliabot/app.yaml"]
+    healthcheck:
+      test: ${healthcheck}
+      interval: 5s
+      timeout: 3s
+      retries: 3
+      start_period: 10s
+    deploy:
+      # One writer for the sqlite stores in the bind-mounted config dir.
+      replicas: 1
+      update_config:
+        order: stop-first
+      restart_policy:
+        condition: on-failure
+        delay: 5s
+      placement:
+        # Bind mounts need the config dir on the node running the task. With
+        # several managers, pin it with node.hostname == <host> instead.
+        constraints:
+          - node.role == manager
+`;
+}
+
+/**
+ * Write docker-stack.yml.
+ */
+export function writeDockerStack(paths: DockerPaths, content: string): string {
+  const stackPath = join(paths.outputDir, DOCKER_STACK_FILE);
+  writeFileSync(stackPath, content);
+  success(t("swarm.saved", { file: DOCKER_STACK_FILE, path: stackPath }));
+  return stackPath;
+}
+
+/**
+ * How to deploy and manage the stack.
+ */
+export function printSwarmNextSteps(stackPath: string, gatewayPort: string): void {
+  const C = COLORS;
+  header(t("swarm.header"));
+  console.log(`  ${C.CYAN}docker stack deploy -c ${stackPath} ${DEFAULT_STACK_NAME}${C.NC}`);
+  console.log(`  ${C.CYAN}docker service logs -f ${DEFAULT_STACK_NAME}_owliabot${C.NC}`);
+  console.log(`  ${t("swarm.gateway", { url: `http://<manager-node>:${gatewayPort}` })}`);
+  console.log("");
+}
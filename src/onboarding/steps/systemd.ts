@@ -0,0 +1,144 @@
+/**
+ * Step module: systemd unit for native (non-Docker) installs.
+ *
+ * `--systemd` keeps the regular dev-mode flow (no Docker involved) and also
+ * writes owliabot.service plus install-systemd.sh next to app.yaml. The unit
+ * runs the current Node binary and entrypoint with OWLIABOT_HOME pointed at
+ * the config dir, so the service sees exactly what onboarding wrote.
+ */
+
+import { writeFileSync, chmodSync } from "node:fs";
+import { userInfo } from "node:os";
+import { join, resolve } from "node:path";
+import { header, success, COLORS } from "../shared.js";
+import { t } from "../i18n.js";
+
+export const SYSTEMD_UNIT_FILE = "owliabot.service";
+export const SYSTEMD_INSTALL_SCRIPT = "install-systemd.sh";
+
+export interface SystemdUnitOptions {
+  /** Directory holding app.yaml / secrets.yaml (becomes OWLIABOT_HOME) */
+  configDir: string;
+  /** Absolute path of app.yaml */
+  appConfigPath: string;
+  /** Account the service runs as */
+  user: string;
+  /** Node binary (default: the one running onboarding) */
+  nodePath?: string;
+  /** OwliaBot entrypoint script (default: the one running onboarding) */
+  entryPath?: string;
+  /** Extra CA bundle for outbound HTTPS (NODE_EXTRA_CA_CERTS) */
+  caBundle?: string;
+}
+
+export interface SystemdFiles {
+  unitPath: string;
+  unit: string;
+  scriptPath: string;
+  script: string;
+}
+
+/** Quote a value for systemd's Environment=/ExecStart= parsing. */
+function quoteSystemd(value: string): string {
+  return /[\s"\\]/.test(value) ? `"${value.replace(/\\/g, "\\\\").replace(/"/g, '\\"')}"` : value;
+}
+
+function quoteShell(value: string): string {
+  return `'${value.replace(/'/g, "'\\''")}'`;
+}
+
+/**
+ * Account that should own the service: the sudo invoker when known, else the current user.
+ */
+export function defaultServiceUser(ownerOverride?: string | null): string {
+  if (ownerOverride) return ownerOverride;
+  try {
+    return userInfo().username;
+  } catch {
+    return process.env.USER ?? "root";
+  }
+}
+
+/**
+ * Render the systemd unit.
+ */
+export function buildSystemdUnit(opts: SystemdUnitOptions): string {
+  const node = opts.nodePath ?? process.execPath;
+  const entry = resolve(opts.entryPath ?? process.argv[1] ?? "dist/entry.js");
+  const exec = [node, entry, "start", "-c", opts.appConfigPath].map(quoteSystemd).join(" ");
+
+  return `# OwliaBot systemd unit (generated by onboard)
+[Unit]
+Description=OwliaBot
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+Type=simple
+User=${opts.user}
+WorkingDirectory=${quoteSystemd(opts.configDir)}
+Environment=${quoteSystemd(`OWLIABOT_HOME=${opts.configDir}`)}
+Environment=NODE_ENV=production
+${opts.caBundle ? `Environment=${quoteSystemd(`NODE_EXTRA_CA_CERTS=${opts.caBundle}`)}\n` : ""}ExecStart=${exec}
+Restart=on-failure
+RestartSec=5
+NoNewPrivileges=true
+
+[Install]
+WantedBy=multi-user.target
+`;
+}
+
+/**
+ * Render the install script (copies the unit and enables it).
+ */
+export function buildSystemdInstallScript(unitPath: string): string {
+  return `#!/usr/bin/env bash
+# Install and start the OwliaBot systemd service (generated by onboard)
+set -euo pipefail
+
+SUDO=""
+if [ "$(id -u)" -ne 0 ]; then SUDO="sudo"; fi
+
+$SUDO install -m 0644 ${quoteShell(unitPath)} /etc/systemd/system/${SYSTEMD_UNIT_FILE}
+$SUDO systemctl daemon-reload
+$SUDO systemctl enable --now ${SYSTEMD_UNIT_FILE}
+$SUDO systemctl --no-pager status ${SYSTEMD_UNIT_FILE} || true
+`;
+}
+
+/**
+ * Build both files for a config dir (nothing is written).
+ */
+export function buildSystemdFiles(opts: SystemdUnitOptions): SystemdFiles {
+  const unitPath = join(opts.configDir, SYSTEMD_UNIT_FILE);
+  return {
+    unitPath,
+    unit: buildSystemdUnit(opts),
+    scriptPath: join(opts.configDir, SYSTEMD_INSTALL_SCRIPT),
+    script: buildSystemdInstallScript(unitPath),
+  };
+}
+
+/**
+ * Write the unit (0644) and install script (0755).
+ */
+export function writeSystemdFiles(files: SystemdFiles): void {
+  writeFileSync(files.unitPath, files.unit);
+  writeFileSync(files.scriptPath, files.script);
+  try { chmodSync(files.scriptPath, 0o755); } catch { /* best-effort */ }
+  success(t("common.saved", { path: files.unitPath }));
+  success(t("common.saved", { path: files.scriptPath }));
+}
+
+/**
+ * How to install and manage the service.
+ */
+export function printSystemdNextSteps(files: SystemdFiles): void {
+  const C = COLORS;
+  header(t("systemd.header"));
+  console.log(`  ${C.CYAN}${files.scriptPath}${C.NC}   # ${t("systemd.installs", { unit: SYSTEMD_UNIT_FILE })}`);
+  console.log(`  ${C.CYAN}journalctl -u owliabot -f${C.NC}`);
+  console.log(`  ${C.CYAN}sudo systemctl restart owliabot${C.NC}   # ${t("systemd.afterEdit")}`);
+  console.log("");
+}
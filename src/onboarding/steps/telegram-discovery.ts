@@ -0,0 +1,123 @@
+/**
+ * Step module: find the user's numeric Telegram ID by having them message
+ * the bot.
+ *
+ * Polls the Bot API `getUpdates` while the user sends the bot any message,
+ * then offers each sender for the allowlist. Nobody knows their numeric ID
+ * offhand, and @userinfobot is one more detour. This only works while no
+ * other process is polling the same bot (a running OwliaBot, a webhook).
+ * Telegram answers 409 then, and the user types IDs instead.
+ */
+
+import { createInterface } from "node:readline";
+import { askYN, info, success, warn } from "../shared.js";
+import { TELEGRAM_API_BASE } from "./telegram-validation.js";
+import { validationClient, noteSkippedValidation, type ValidationClient } from "./validation-client.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export const TELEGRAM_DISCOVERY_TIMEOUT_MS = 90_000;
+// Long-poll seconds per getUpdates call; stays under the validation client timeout
+const LONG_POLL_SECONDS = 5;
+
+export interface TelegramSender {
+  id: string;
+  name: string;
+}
+
+export type TelegramSenderPoll =
+  | { kind: "ok"; senders: TelegramSender[] }
+  | { kind: "skipped"; reason: string };
+
+interface TelegramUser {
+  id: number;
+  is_bot?: boolean;
+  username?: string;
+  first_name?: string;
+  last_name?: string;
+}
+
+interface TelegramUpdate {
+  update_id: number;
+  message?: { from?: TelegramUser };
+}
+
+function senderName(from: TelegramUser): string {
+  if (from.username) return `@${from.username}`;
+  return [from.first_name, from.last_name].filter(Boolean).join(" ") || String(from.id);
+}
+
+/**
+ * Poll getUpdates until at least one person has messaged the bot or the
+ * deadline passes. Only updates newer than the first poll count, so old
+ * messages from strangers don't show up.
+ */
+export async function pollTelegramSenders(
+  token: string,
+  opts: { client?: ValidationClient; timeoutMs?: number; now?: () => number } = {},
+): Promise<TelegramSenderPoll> {
+  const client = opts.client ?? validationClient;
+  const now = opts.now ?? Date.now;
+  const deadline = now() + (opts.timeoutMs ?? TELEGRAM_DISCOVERY_TIMEOUT_MS);
+
+  // offset=-1 returns only the latest update; everything after it is new.
+  let offset = -1;
+  let skipBacklog = true;
+  do {
+    const wait = skipBacklog ? 0 : LONG_POLL_SECONDS;
+    const res = await client.fetch(
+      `${TELEGRAM_API_BASE}/bot${token}/getUpdates?offset=${offset}&timeout=${wait}&allowed_updates=%5B%22message%22%5D`,
+    );
+    if (res.kind === "skipped") return res;
+    if (res.response.status === 409) {
+      return { kind: "skipped", reason: t("telegramIds.busy") };
+    }
+    if (!res.response.ok) return { kind: "skipped", reason: t("telegramCheck.unexpected", { status: res.response.status }) };
+
+    const body = (await res.response.json()) as { ok?: boolean; result?: TelegramUpdate[] };
+    const updates = body.result ?? [];
+    if (updates.length > 0) offset = updates[updates.length - 1].update_id + 1;
+    else if (skipBacklog) offset = 0;
+
+    if (!skipBacklog) {
+      const senders = new Map<string, TelegramSender>();
+      for (const u of updates) {
+        const from = u.message?.from;
+        if (from && !from.is_bot) senders.set(String(from.id), { id: String(from.id), name: senderName(from) });
+      }
+      if (senders.size > 0) return { kind: "ok", senders: [...senders.values()] };
+    }
+    skipBacklog = false;
+  } while (now() < deadline);
+
+  return { kind: "ok", senders: [] };
+}
+
+/**
+ * Ask the user to message the bot and offer each sender for the allowlist.
+ * Returns the IDs the user accepted ([] when nobody wrote or polling failed).
+ */
+export async function discoverTelegramUserIds(
+  rl: RL,
+  token: string,
+  poll: (token: string) => Promise<TelegramSenderPoll> = (value) => pollTelegramSenders(value),
+): Promise<string[]> {
+  info(t("telegramIds.waiting"));
+  const result = await poll(token);
+  if (result.kind === "skipped") {
+    noteSkippedValidation(t("telegramIds.check"), result.reason);
+    return [];
+  }
+  if (result.senders.length === 0) {
+    warn(t("telegramIds.none"));
+    return [];
+  }
+
+  const ids: string[] = [];
+  for (const sender of result.senders) {
+    if (await askYN(rl, t("telegramIds.allow", { name: sender.name, id: sender.id }), true)) ids.push(sender.id);
+  }
+  if (ids.length > 0) success(t("telegramIds.found", { ids: ids.join(", ") }));
+  return ids;
+}
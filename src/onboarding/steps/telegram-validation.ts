@@ -0,0 +1,72 @@
+/**
+ * Step module: live Telegram token check during the Channels stage.
+ *
+ * Calls the Bot API `getMe` so a mistyped token is caught before it lands in
+ * secrets.yaml. 401 (and 404, which Telegram returns for a malformed token)
+ * mean the token is wrong.
+ */
+
+import { createInterface } from "node:readline";
+import { success, error, info } from "../shared.js";
+import { askCredential } from "./placeholder-credentials.js";
+import { validationClient, noteSkippedValidation, type ValidationClient } from "./validation-client.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export const TELEGRAM_API_BASE = "https://api.telegram.org";
+
+export type TelegramTokenCheck =
+  | { kind: "valid"; username: string }
+  | { kind: "invalid"; message: string }
+  | { kind: "skipped"; reason: string };
+
+/**
+ * Check a bot token with getMe.
+ */
+export async function checkTelegramToken(
+  token: string,
+  client: ValidationClient = validationClient,
+): Promise<TelegramTokenCheck> {
+  // Also keeps anything odd out of the URL path.
+  if (!/^\d+:[\w-]+$/.test(token)) {
+    return { kind: "invalid", message: t("telegramCheck.format") };
+  }
+  const result = await client.fetch(`${TELEGRAM_API_BASE}/bot${token}/getMe`);
+  if (result.kind === "skipped") return result;
+  const { status } = result.response;
+  if (status === 401 || status === 404) {
+    return { kind: "invalid", message: t("telegramCheck.rejected", { status }) };
+  }
+  if (!result.response.ok) return { kind: "skipped", reason: t("telegramCheck.unexpected", { status }) };
+  const body = (await result.response.json()) as { ok?: boolean; result?: { username?: string } };
+  if (!body.ok || !body.result?.username) return { kind: "skipped", reason: t("telegramCheck.badResponse") };
+  return { kind: "valid", username: body.result.username };
+}
+
+/**
+ * Validate the entered token, re-prompting while Telegram rejects it.
+ * Returns the token to keep ("" when the user chose to add it later).
+ */
+export async function promptValidTelegramToken(
+  rl: RL,
+  token: string,
+  check: (token: string) => Promise<TelegramTokenCheck> = (value) => checkTelegramToken(value),
+): Promise<string> {
+  let current = token;
+  while (current) {
+    const result = await check(current);
+    if (result.kind === "skipped") {
+      noteSkippedValidation(t("telegramCheck.check"), result.reason);
+      return current;
+    }
+    if (result.kind === "valid") {
+      success(t("telegramCheck.bot", { username: result.username }));
+      info(t("telegramCheck.sayHi", { url: `https://t.me/${result.username}` }));
+      return current;
+    }
+    error(t("telegramCheck.invalid", { message: result.message }));
+    current = await askCredential(rl, t("telegramCheck.again"), "telegram", true);
+  }
+  return "";
+}
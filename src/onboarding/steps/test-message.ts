@@ -0,0 +1,192 @@
+/**
+ * Step module: post an "OwliaBot is online" message to a Discord channel
+ * or Telegram chat once the bot runs (`owliabot test-message`, offered by
+ * install.sh after start).
+ *
+ * The token checks during onboarding only prove the token is valid. Sending
+ * to a target from the allow-lists also proves the bot was invited to that
+ * channel (or that the Telegram user has started the bot), and the reply
+ * the message asks for exercises the intents and the allow-lists on the
+ * way back in.
+ */
+
+import { createInterface } from "node:readline";
+import type { Config } from "../../config/schema.js";
+import { info, selectOption, success, warn } from "../shared.js";
+import { DISCORD_API_BASE } from "./discord-validation.js";
+import { TELEGRAM_API_BASE } from "./telegram-validation.js";
+import { validationClient, type ValidationClient } from "./validation-client.js";
+import { t, type MessageKey } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export const TEST_MESSAGE_TEXT =
+  "🦉 OwliaBot is online. Reply here to check it can read your messages too.";
+
+export interface TestMessageTarget {
+  channel: "discord" | "telegram";
+  kind: "channel" | "user" | "group";
+  id: string;
+}
+
+export type TestMessageResult =
+  | { kind: "sent" }
+  | { kind: "failed"; message: string; hint?: string }
+  | { kind: "skipped"; reason: string };
+
+/** Where a test message can go: the allow-listed channels, users and groups */
+export function testMessageTargets(config: Pick<Config, "discord" | "telegram">): TestMessageTarget[] {
+  const targets: TestMessageTarget[] = [];
+  if (config.discord?.token) {
+    for (const id of config.discord.channelAllowList ?? []) targets.push({ channel: "discord", kind: "channel", id });
+    for (const id of config.discord.memberAllowList ?? []) targets.push({ channel: "discord", kind: "user", id });
+  }
+  if (config.telegram?.token) {
+    for (const id of Object.keys(config.telegram.groups ?? {})) {
+      if (id !== "*") targets.push({ channel: "telegram", kind: "group", id });
+    }
+    for (const id of config.telegram.allowList ?? []) targets.push({ channel: "telegram", kind: "user", id });
+  }
+  return targets;
+}
+
+const TARGET_KEYS: Record<TestMessageTarget["kind"], MessageKey> = {
+  channel: "testMessage.channel",
+  group: "testMessage.group",
+  user: "testMessage.user",
+};
+
+function serviceName(target: TestMessageTarget): string {
+  return target.channel === "discord" ? "Discord" : "Telegram";
+}
+
+export function describeTarget(target: TestMessageTarget): string {
+  return t(TARGET_KEYS[target.kind], { service: serviceName(target), id: target.id });
+}
+
+async function errorText(response: Response): Promise<string> {
+  const body = (await response.json().catch(() => ({}))) as { message?: string; description?: string };
+  return body.message ?? body.description ?? `HTTP ${response.status}`;
+}
+
+export async function sendDiscordTestMessage(
+  token: string,
+  target: TestMessageTarget,
+  text: string = TEST_MESSAGE_TEXT,
+  client: ValidationClient = validationClient,
+): Promise<TestMessageResult> {
+  const headers = { Authorization: `Bot ${token}`, "Content-Type": "application/json" };
+  let channelId = target.id;
+  if (target.kind === "user") {
+    // A DM goes to the DM channel, which has to be opened first.
+    const dm = await client.fetch(`${DISCORD_API_BASE}/users/@me/channels`, {
+      method: "POST",
+      headers,
+      body: JSON.stringify({ recipient_id: target.id }),
+    });
+    if (dm.kind === "skipped") return dm;
+    if (!dm.response.ok) return { kind: "failed", message: await errorText(dm.response) };
+    channelId = ((await dm.response.json()) as { id: string }).id;
+  }
+
+  const result = await client.fetch(`${DISCORD_API_BASE}/channels/${encodeURIComponent(channelId)}/messages`, {
+    method: "POST",
+    headers,
+    body: JSON.stringify({ content: text }),
+  });
+  if (result.kind === "skipped") return result;
+  if (result.response.ok) return { kind: "sent" };
+  const { status } = result.response;
+  const message = await errorText(result.response);
+  if (status === 401) return { kind: "failed", message, hint: t("testMessage.discordRejected") };
+  if (status === 403) {
+    return {
+      kind: "failed",
+      message,
+      hint: target.kind === "user"
+        ? t("testMessage.discordDm")
+        : t("testMessage.discordInvite"),
+    };
+  }
+  if (status === 404) return { kind: "failed", message, hint: t("testMessage.discordNoChannel") };
+  return { kind: "failed", message };
+}
+
+export async function sendTelegramTestMessage(
+  token: string,
+  target: TestMessageTarget,
+  text: string = TEST_MESSAGE_TEXT,
+  client: ValidationClient = validationClient,
+): Promise<TestMessageResult> {
+  const result = await client.fetch(`${TELEGRAM_API_BASE}/bot${token}/sendMessage`, {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify({ chat_id: target.id, text }),
+  });
+  if (result.kind === "skipped") return result;
+  if (result.response.ok) return { kind: "sent" };
+  const { status } = result.response;
+  const message = await errorText(result.response);
+  if (status === 401 || status === 404) {
+    return { kind: "failed", message, hint: t("testMessage.telegramRejected") };
+  }
+  if (status === 403) {
+    return {
+      kind: "failed",
+      message,
+      hint: target.kind === "user"
+        ? t("testMessage.telegramStart")
+        : t("testMessage.telegramGroup"),
+    };
+  }
+  if (status === 400) return { kind: "failed", message, hint: t("testMessage.telegramChatId") };
+  return { kind: "failed", message };
+}
+
+export async function sendTestMessage(
+  config: Pick<Config, "discord" | "telegram">,
+  target: TestMessageTarget,
+  client: ValidationClient = validationClient,
+): Promise<TestMessageResult> {
+  return target.channel === "discord"
+    ? sendDiscordTestMessage(config.discord!.token!, target, TEST_MESSAGE_TEXT, client)
+    : sendTelegramTestMessage(config.telegram!.token!, target, TEST_MESSAGE_TEXT, client);
+}
+
+/**
+ * Pick a target (`to`, else ask when there is more than one) and send.
+ * Returns false when nothing could be sent.
+ */
+export async function runTestMessage(
+  rl: RL | null,
+  config: Pick<Config, "discord" | "telegram">,
+  to?: string,
+  send: (target: TestMessageTarget) => Promise<TestMessageResult> = (target) => sendTestMessage(config, target),
+): Promise<boolean> {
+  const targets = testMessageTargets(config).filter((target) => !to || target.id === to);
+  if (targets.length === 0) {
+    warn(to
+      ? t("testMessage.notAllowed", { to })
+      : t("testMessage.noTargets"));
+    return false;
+  }
+  let target = targets[0];
+  if (targets.length > 1 && rl) {
+    const index = await selectOption(rl, t("testMessage.pick"), targets.map(describeTarget), 0);
+    target = targets[index];
+  }
+
+  const result = await send(target);
+  if (result.kind === "sent") {
+    success(t("testMessage.sent", { target: describeTarget(target) }));
+    info(t("testMessage.reply"));
+    return true;
+  }
+  if (result.kind === "skipped") {
+    warn(t("testMessage.unreachable", { service: serviceName(target), reason: result.reason }));
+    return false;
+  }
+  warn(t("testMessage.failed", { target: describeTarget(target), message: result.message }));
+  if (result.hint) info(result.hint);
+  return false;
+}
@@ -0,0 +1,119 @@
+/**
+ * Step module: pick the timezone from the IANA list instead of trusting a
+ * free-text value.
+ *
+ * Defaults to the host's zone (TZ, then `/etc/localtime`, then the runtime's
+ * own setting). In install.sh's onboarding container /etc/localtime is the
+ * image's, so the installer passes the host's zone in as TZ. Typing part of a name searches the list that ships with the
+ * runtime's ICU data. A zone that doesn't exist would otherwise only
+ * surface as confusing date errors in the container log.
+ */
+
+import { readlinkSync } from "node:fs";
+import { createInterface } from "node:readline";
+import { ask, info, warn, header, selectOption } from "../shared.js";
+import { detectTimezone } from "./helpers.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+/** Docs offered on the timezone question (answer "d") */
+export const TIMEZONE_DOCS = "https://en.wikipedia.org/wiki/List_of_tz_database_time_zones";
+
+/** Above this many matches, ask for a narrower search instead of listing them */
+const MAX_LISTED_MATCHES = 15;
+
+export function isValidTimezone(tz: string): boolean {
+  try {
+    new Intl.DateTimeFormat("en-US", { timeZone: tz });
+    return true;
+  } catch {
+    return false;
+  }
+}
+
+/**
+ * All IANA zones known to the runtime (UTC included).
+ */
+export function listTimezones(): string[] {
+  const zones = typeof Intl.supportedValuesOf === "function" ? Intl.supportedValuesOf("timeZone") : [];
+  return zones.includes("UTC") ? zones : [...zones, "UTC"];
+}
+
+/**
+ * The host's zone: TZ when it names a known zone, else the /etc/localtime
+ * symlink target, else the runtime's resolved zone, else UTC.
+ */
+export function detectHostTimezone(
+  readLink: (path: string) => string = (p) => readlinkSync(p),
+  env: NodeJS.ProcessEnv = process.env,
+): string {
+  const fromEnv = env.TZ?.trim().replace(/^:/, "");
+  if (fromEnv && isValidTimezone(fromEnv)) return fromEnv;
+  try {
+    const match = /zoneinfo\/(.+)$/.exec(readLink("/etc/localtime"));
+    if (match && isValidTimezone(match[1])) return match[1];
+  } catch {
+    // Not a symlink (or no such file): fall through
+  }
+  return detectTimezone();
+}
+
+/**
+ * Case-insensitive search; spaces match underscores ("new york").
+ */
+export function searchTimezones(query: string, zones: string[] = listTimezones()): string[] {
+  const q = query.trim().toLowerCase().replace(/\s+/g, "_");
+  if (!q) return [];
+  return zones.filter((z) => z.toLowerCase().includes(q));
+}
+
+/**
+ * Ask for the timezone, defaulting to `detected`. Returns a valid IANA zone.
+ */
+export async function promptTimezone(
+  rl: RL,
+  detected: string,
+  zones: string[] = listTimezones(),
+): Promise<string> {
+  header(t("timezone.header"));
+  for (;;) {
+    const answer = await ask(
+      rl,
+      t("timezone.ask", { detected }),
+      false,
+      TIMEZONE_DOCS,
+    );
+    if (!answer) return detected;
+
+    const exact = zones.find((z) => z.toLowerCase() === answer.toLowerCase());
+    if (exact) return exact;
+
+    const matches = searchTimezones(answer, zones);
+    if (matches.length === 0) {
+      warn(t("timezone.noMatch", { answer }));
+      continue;
+    }
+    if (matches.length === 1) {
+      info(t("timezone.chosen", { zone: matches[0] }));
+      return matches[0];
+    }
+    if (matches.length > MAX_LISTED_MATCHES) {
+      warn(t("timezone.tooMany", { count: matches.length, answer }));
+      continue;
+    }
+    const choice = await selectOption(rl, t("timezone.matching"), [...matches, t("timezone.searchAgain")]);
+    if (choice < matches.length) return matches[choice];
+  }
+}
+
+/**
+ * Interactive runs get the picker; others keep the silent auto-detection.
+ */
+export async function chooseTimezone(
+  rl: RL,
+  interactive: boolean = Boolean(process.stdin.isTTY),
+): Promise<string> {
+  if (!interactive) return detectTimezone();
+  return promptTimezone(rl, detectHostTimezone());
+}
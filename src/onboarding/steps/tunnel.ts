@@ -0,0 +1,144 @@
+/**
+ * Step module: public tunnel sidecar (cloudflared / ngrok).
+ *
+ * Exposes the gateway over HTTPS without router or firewall changes by adding
+ * a tunnel container next to owliabot in docker-compose.yml. The tunnel token
+ * lives in <configDir>/tunnel.env (referenced via env_file), never in the
+ * compose file itself.
+ */
+
+import { createInterface } from "node:readline";
+import { writeFileSync, chmodSync } from "node:fs";
+import { join } from "node:path";
+import { header, info, success, warn, ask, AbortError } from "../shared.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+export type TunnelProvider = "cloudflared" | "ngrok";
+
+export const TUNNEL_PROVIDERS: TunnelProvider[] = ["cloudflared", "ngrok"];
+
+export const TUNNEL_ENV_FILE = "tunnel.env";
+
+export interface TunnelSetup {
+  provider: TunnelProvider;
+  token: string;
+  /** Public hostname (cloudflared: configured in the dashboard; ngrok: reserved domain). */
+  hostname?: string;
+}
+
+export function parseTunnelProvider(value: string | undefined): TunnelProvider | undefined {
+  if (value === undefined) return undefined;
+  const provider = value.trim().toLowerCase();
+  if ((TUNNEL_PROVIDERS as string[]).includes(provider)) return provider as TunnelProvider;
+  throw new Error(`Unknown tunnel provider "${value}" (expected one of: ${TUNNEL_PROVIDERS.join(", ")})`);
+}
+
+function normalizeHostname(raw: string): string | undefined {
+  const host = raw.trim().replace(/^https?:\/\//i, "").replace(/\/+$/, "");
+  return host || undefined;
+}
+
+/**
+ * Ask for the tunnel token (and public hostname) for the chosen provider.
+ */
+export async function promptTunnelSetup(rl: RL, provider: TunnelProvider): Promise<TunnelSetup> {
+  header(provider === "cloudflared" ? "Cloudflare Tunnel" : t("tunnel.ngrokHeader"));
+
+  if (provider === "cloudflared") {
+    info(t("tunnel.cloudflareCreate"));
+    info(t("tunnel.cloudflareHostname"));
+    info(t("tunnel.cloudflareOidc"));
+  } else {
+    info(t("tunnel.ngrokToken", { url: "https://dashboard.ngrok.com/get-started/your-authtoken" }));
+  }
+
+  const token = (await ask(rl, provider === "cloudflared" ? t("tunnel.token") : "ngrok authtoken: ", true)).trim();
+  if (!token) throw new AbortError("Tunnel token is required");
+
+  const hostname = normalizeHostname(
+    await ask(
+      rl,
+      provider === "cloudflared"
+        ? t("tunnel.hostname")
+        : t("tunnel.ngrokDomain"),
+    ),
+  );
+  if (provider === "cloudflared" && !hostname) {
+    warn(t("tunnel.noHostname"));
+  }
+
+  success(t("tunnel.added", { provider }));
+  return { provider, token, hostname };
+}
+
+/**
+ * Public URL line for the completion summary.
+ */
+export function describeTunnelUrl(setup: TunnelSetup): string {
+  if (setup.hostname) return `https://${setup.hostname}`;
+  return setup.provider === "ngrok"
+    ? t("tunnel.randomUrl", { url: "http://localhost:4040" })
+    : t("tunnel.seeCloudflare");
+}
+
+/**
+ * Contents of tunnel.env (read by the sidecar through env_file).
+ */
+export function buildTunnelEnv(setup: TunnelSetup): string {
+  const key = setup.provider === "cloudflared" ? "TUNNEL_TOKEN" : "NGROK_AUTHTOKEN";
+  return `${key}=${setup.token}\n`;
+}
+
+/**
+ * docker-compose service block for the tunnel sidecar.
+ * `upstream` is the in-compose URL traffic is forwarded to (gateway or auth proxy).
+ */
+export function buildTunnelComposeService(
+  setup: TunnelSetup,
+  dockerConfigPath: string,
+  upstream = "http://owliabot:8787",
+): string {
+  const envFile = `${dockerConfigPath}/${TUNNEL_ENV_FILE}`;
+
+  if (setup.provider === "cloudflared") {
+    return `
+  tunnel:
+    image: cloudflare/cloudflared:latest
+    container_name: owliabot-tunnel
+    restart: unless-stopped
+    command: ["tunnel", "--no-autoupdate", "run"]
+    env_file:
+      - ${envFile}
+    depends_on:
+      - owliabot
+`;
+  }
+
+  const args = ["http", upstream];
+  if (setup.hostname) args.push(`--url=${setup.hostname}`);
+  return `
+  tunnel:
+    image: ngrok/ngrok:latest
+    container_name: owliabot-tunnel
+    restart: unless-stopped
+    command: [${args.map((a) => `"${a}"`).join(", ")}]
+    env_file:
+      - ${envFile}
+    ports:
+      - "127.0.0.1:4040:4040"
+    depends_on:
+      - owliabot
+`;
+}
+
+/**
+ * Write tunnel.env into the config dir (0600).
+ */
+export function writeTunnelEnv(configDir: string, setup: TunnelSetup): void {
+  const envPath = join(configDir, TUNNEL_ENV_FILE);
+  writeFileSync(envPath, buildTunnelEnv(setup));
+  try { chmodSync(envPath, 0o600); } catch { /* best-effort */ }
+  success(t("tunnel.saved", { path: envPath }));
+}
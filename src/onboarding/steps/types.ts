@@ -4,19 +4,11 @@
 
 import type { ProviderConfig } from "../types.js";
 import type { SecretsConfig } from "../secrets.js";
-import type { ExistingConfig } from "../shared.js";
 import type { AppConfig } from "../types.js";
+import type { ModelPresetCatalog } from "./model-presets.js";
 
-export interface DetectedConfig extends ExistingConfig {
-  openaiCompatKey?: string;
-  hasOAuthAnthro?: boolean;
-  hasOAuthCodex?: boolean;
-  oauthCodexExpires?: number;
-  anthropicTokenValid?: boolean;
-  discordMemberAllowList?: string[];
-  telegramAllowList?: string[];
-  telegramGroups?: NonNullable<NonNullable<AppConfig["telegram"]>["groups"]>;
-}
+/** What a previous setup left behind; detected by config-detection.ts */
+export type { DetectedConfig } from "./config-detection.js";
 
 export interface OnboardOptions {
   /** Path for app.yaml in dev mode */
@@ -42,6 +34,8 @@ export interface ProviderSetupState {
   priority: number;
   useAnthropic: boolean;
   useOpenaiCodex: boolean;
+  /** Default models and suggested endpoints (built-ins when unset) */
+  modelPresets?: ModelPresetCatalog;
 }
 
 export interface ChannelResult {
@@ -49,9 +43,15 @@ export interface ChannelResult {
   telegramEnabled: boolean;
   discordToken: string;
   telegramToken: string;
+  slackEnabled?: boolean;
+  /** Slack bot token (xoxb-); the app token only goes to secrets */
+  slackBotToken?: string;
+  /** Generic HTTP webhook; configured in buildAppConfigFromPrompts */
+  webhookEnabled?: boolean;
   reuseTelegramConfig?: boolean;
   telegramAllowList?: string[];
   telegramGroups?: NonNullable<NonNullable<AppConfig["telegram"]>["groups"]>;
+  mcpServers?: NonNullable<NonNullable<AppConfig["mcp"]>["servers"]>;
 }
 
 /** @deprecated Use ChannelResult instead */
@@ -65,4 +65,4 @@ export interface DockerGatewaySetup {
   tz: string;
 }
 
-export type UserAllowLists = { discord: string[]; telegram: string[] };
+export type UserAllowLists = { discord: string[]; telegram: string[]; slack?: string[] };
@@ -6,9 +6,12 @@ import { dirname } from "node:path";
 import { randomBytes } from "node:crypto";
 import { IS_DEV_MODE } from "../storage.js";
 import type { SecretsConfig } from "../secrets.js";
-import { printBanner, info, success, header, askYN } from "../shared.js";
+import { printBanner, info, success, warn, header, askYN } from "../shared.js";
+import { setupTokenReminder, oauthSessionReminder } from "../../auth/credential-expiry.js";
 import type { DetectedConfig } from "./config-detection.js";
+import { bannerLines } from "./banner.js";
 import type { createInterface } from "node:readline";
+import { t } from "../i18n.js";
 
 type RL = ReturnType<typeof createInterface>;
 
@@ -17,13 +20,13 @@ type RL = ReturnType<typeof createInterface>;
  */
 export function printOnboardingBanner(dockerMode: boolean): void {
   if (dockerMode) {
-    printBanner("(Docker)");
+    printBanner("(Docker)", bannerLines());
     return;
   }
 
-  printBanner(IS_DEV_MODE ? "(dev mode)" : "");
+  printBanner(IS_DEV_MODE ? t("setup.devModeSubtitle") : "", bannerLines());
   if (IS_DEV_MODE) {
-    info("Dev mode is on (OWLIABOT_DEV=1). I'll save settings to ~/.owlia_dev/.");
+    info(t("setup.devMode"));
   }
 }
 
@@ -35,28 +38,43 @@ export function printExistingConfigSummary(
   appConfigPath: string,
   existing: DetectedConfig,
 ): void {
-  header("I found an existing setup");
-  info(`Settings folder: ${dirname(appConfigPath)}`);
+  header(t("setup.found"));
+  info(t("setup.folder", { dir: dirname(appConfigPath) }));
 
   if (existing.anthropicKey) {
     const truncLen = dockerMode ? 10 : 15;
-    info(`Anthropic: API key is set (${existing.anthropicKey.slice(0, truncLen)}...)`);
+    info(t("setup.apiKeySet", { service: "Anthropic", key: existing.anthropicKey.slice(0, truncLen) }));
   }
   if (existing.anthropicToken) {
-    const validLabel = existing.anthropicTokenValid === false ? " ⚠️ invalid format" : " ✅";
-    info(`Anthropic: setup-token is set${validLabel}`);
+    const status = existing.anthropicTokenValid === false ? t("setup.invalidFormat") : "✅";
+    info(t("setup.setupTokenSet", { status }));
+    const reminder = setupTokenReminder(existing.anthropicTokenExpiresAt);
+    if (reminder) warn(reminder);
+  }
+  if (dockerMode && existing.hasOAuthAnthro) info(t("setup.anthropicOAuth"));
+  if (existing.openaiKey) info(t("setup.apiKeySet", { service: "OpenAI", key: existing.openaiKey.slice(0, 10) }));
+  if (existing.azureOpenai) {
+    const { deployment, endpoint } = existing.azureOpenai;
+    info(t("setup.azure", { deployment, endpoint }));
   }
-  if (dockerMode && existing.hasOAuthAnthro) info("Anthropic: OAuth token is present");
-  if (existing.openaiKey) info(`OpenAI: API key is set (${existing.openaiKey.slice(0, 10)}...)`);
   if (existing.hasOAuthCodex) {
-    const expiryStr = existing.oauthCodexExpires
-      ? ` (expires: ${new Date(existing.oauthCodexExpires).toISOString().slice(0, 16)})`
-      : "";
-    info(`OpenAI Codex: ✅ OAuth token is valid${expiryStr}`);
+    info(existing.oauthCodexExpires
+      ? t("setup.codexValidUntil", { expires: new Date(existing.oauthCodexExpires).toISOString().slice(0, 16) })
+      : t("setup.codexValid"));
+    const reminder = oauthSessionReminder(
+      "OpenAI Codex",
+      existing.oauthCodexExpires,
+      existing.oauthCodexRefreshable !== false,
+    );
+    if (reminder) warn(reminder);
+  }
+  if (existing.discordToken) info(t("setup.tokenSet", { service: "Discord", token: existing.discordToken.slice(0, 20) }));
+  if (existing.telegramToken) info(t("setup.tokenSet", { service: "Telegram", token: existing.telegramToken.slice(0, 10) }));
+  if (existing.slackBotToken) info(t("setup.tokenSet", { service: "Slack", token: existing.slackBotToken.slice(0, 10) }));
+  if (dockerMode && existing.gatewayToken) info(t("setup.tokenSet", { service: "Gateway", token: existing.gatewayToken.slice(0, 10) }));
+  if (existing.otherProfiles?.length) {
+    info(t("setup.otherProfiles", { profiles: existing.otherProfiles.join(", ") }));
   }
-  if (existing.discordToken) info(`Discord: token is set (${existing.discordToken.slice(0, 20)}...)`);
-  if (existing.telegramToken) info(`Telegram: token is set (${existing.telegramToken.slice(0, 10)}...)`);
-  if (dockerMode && existing.gatewayToken) info(`Gateway: token is set (${existing.gatewayToken.slice(0, 10)}...)`);
 }
 
 /**
@@ -68,14 +86,15 @@ export async function promptReuseExistingConfig(
 ): Promise<boolean> {
   if (!existing) return false;
 
-  const reuse = await askYN(rl, "Want to keep using these settings?", true);
-  if (reuse) success("Great. I'll keep your existing settings.");
-  else info("Okay. We'll set things up fresh.");
+  const reuse = await askYN(rl, t("setup.keep"), true);
+  if (reuse) success(t("setup.keeping"));
+  else info(t("setup.fresh"));
   return reuse;
 }
 
 /**
- * Ensure gateway token exists (create or reuse).
+ * Ensure gateway token exists (create or reuse). A reused config also keeps
+ * its basic auth password.
  */
 export function ensureGatewayToken(
   secrets: SecretsConfig,
@@ -83,9 +102,12 @@ export function ensureGatewayToken(
   reuseExisting: boolean,
 ): string {
   // Always provision a gateway token when generating config.
-  // If a token already exists and the user opted to reuse config, keep it stable.
+  // If a token already exists and the user opted to reuse config, keep it
+  // stable, and the basic auth password with it.
   const reused = reuseExisting && existing?.gatewayToken ? existing.gatewayToken : "";
   const token = secrets.gateway?.token || reused || randomBytes(16).toString("hex");
-  secrets.gateway = { token };
+  const basicAuthPassword = secrets.gateway?.basicAuthPassword
+    ?? (reuseExisting ? existing?.gatewayBasicAuthPassword : undefined);
+  secrets.gateway = basicAuthPassword ? { token, basicAuthPassword } : { token };
   return token;
 }
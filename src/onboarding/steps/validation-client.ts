@@ -0,0 +1,139 @@
+/**
+ * Shared HTTP client for onboarding validation calls (token checks, model
+ * discovery, provider smoke tests).
+ *
+ * Validation is a convenience, never a requirement: every call gets a timeout,
+ * a couple of retries with backoff (honouring Retry-After on 429), and a
+ * process-wide circuit breaker. Once providers keep failing or throttling,
+ * further checks are skipped with a note instead of stalling the wizard.
+ */
+
+import { warn } from "../shared.js";
+import { t } from "../i18n.js";
+
+export type ValidationFetchResult =
+  | { kind: "response"; response: Response }
+  | { kind: "skipped"; reason: string };
+
+export interface ValidationClientOptions {
+  /** Per-attempt timeout */
+  timeoutMs?: number;
+  /** Extra attempts after the first one */
+  retries?: number;
+  /** First backoff delay; doubles on every retry */
+  baseDelayMs?: number;
+  /** Upper bound for any single wait (backoff or Retry-After) */
+  maxDelayMs?: number;
+  /** Consecutive failed calls before the breaker opens */
+  breakerThreshold?: number;
+  fetchImpl?: typeof fetch;
+  sleep?: (ms: number) => Promise<void>;
+}
+
+const DEFAULTS = {
+  timeoutMs: 8_000,
+  retries: 2,
+  baseDelayMs: 500,
+  maxDelayMs: 5_000,
+  breakerThreshold: 3,
+};
+
+/**
+ * Parse a Retry-After header (delta-seconds or HTTP-date) into milliseconds.
+ */
+export function parseRetryAfter(value: string | null, now = Date.now()): number | null {
+  if (!value) return null;
+  const trimmed = value.trim();
+  if (/^\d+$/.test(trimmed)) return Number(trimmed) * 1000;
+  const at = Date.parse(trimmed);
+  if (Number.isNaN(at)) return null;
+  return Math.max(0, at - now);
+}
+
+function isRetryableStatus(status: number): boolean {
+  return status === 429 || status === 502 || status === 503 || status === 504;
+}
+
+export class ValidationClient {
+  private readonly opts: Required<Omit<ValidationClientOptions, "fetchImpl" | "sleep">>;
+  private readonly fetchImpl: typeof fetch;
+  private readonly sleep: (ms: number) => Promise<void>;
+  private consecutiveFailures = 0;
+
+  constructor(options: ValidationClientOptions = {}) {
+    this.opts = {
+      timeoutMs: options.timeoutMs ?? DEFAULTS.timeoutMs,
+      retries: options.retries ?? DEFAULTS.retries,
+      baseDelayMs: options.baseDelayMs ?? DEFAULTS.baseDelayMs,
+      maxDelayMs: options.maxDelayMs ?? DEFAULTS.maxDelayMs,
+      breakerThreshold: options.breakerThreshold ?? DEFAULTS.breakerThreshold,
+    };
+    this.fetchImpl = options.fetchImpl ?? ((...args) => fetch(...args));
+    this.sleep = options.sleep ?? ((ms) => new Promise((resolve) => setTimeout(resolve, ms)));
+  }
+
+  /** True once repeated failures have tripped the breaker. */
+  get isOpen(): boolean {
+    return this.consecutiveFailures >= this.opts.breakerThreshold;
+  }
+
+  reset(): void {
+    this.consecutiveFailures = 0;
+  }
+
+  /**
+   * Fetch with timeout, retries and the breaker applied.
+   * Any definitive HTTP answer (2xx, 401, 404, ...) comes back as a response;
+   * timeouts, network errors and persistent throttling come back as "skipped".
+   */
+  async fetch(url: string, init: RequestInit = {}): Promise<ValidationFetchResult> {
+    if (this.isOpen) {
+      return { kind: "skipped", reason: t("validation.paused") };
+    }
+
+    let reason = t("validation.requestFailed");
+    for (let attempt = 0; attempt <= this.opts.retries; attempt++) {
+      let delay = Math.min(this.opts.baseDelayMs * 2 ** attempt, this.opts.maxDelayMs);
+
+      try {
+        const response = await this.fetchImpl(url, {
+          ...init,
+          signal: AbortSignal.timeout(this.opts.timeoutMs),
+        });
+
+        if (!isRetryableStatus(response.status)) {
+          this.consecutiveFailures = 0;
+          return { kind: "response", response };
+        }
+
+        reason = response.status === 429 ? t("validation.rateLimited") : t("validation.unavailable", { status: response.status });
+        const retryAfter = parseRetryAfter(response.headers.get("retry-after"));
+        if (retryAfter !== null) {
+          // Don't make the user wait minutes for an optional check.
+          if (retryAfter > this.opts.maxDelayMs) break;
+          delay = retryAfter;
+        }
+      } catch (err) {
+        const name = (err as Error)?.name;
+        reason = name === "TimeoutError" || name === "AbortError"
+          ? t("validation.timedOut", { seconds: this.opts.timeoutMs / 1000 })
+          : t("validation.networkError", { error: (err as Error)?.message ?? String(err) });
+      }
+
+      if (attempt < this.opts.retries) await this.sleep(delay);
+    }
+
+    this.consecutiveFailures++;
+    return { kind: "skipped", reason };
+  }
+}
+
+/** Process-wide client so the breaker spans every validation step. */
+export const validationClient = new ValidationClient();
+
+/**
+ * Tell the user a check was skipped (the wizard carries on regardless).
+ */
+export function noteSkippedValidation(label: string, reason: string): void {
+  warn(t("validation.skipped", { label, reason }));
+}
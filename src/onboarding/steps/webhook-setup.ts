@@ -0,0 +1,84 @@
+/**
+ * Step module: generic webhook channel.
+ *
+ * Systems the onboarder doesn't know natively POST JSON to a path on Gateway
+ * HTTP with a shared secret; replies can go to an outbound URL.
+ */
+
+import { createInterface } from "node:readline";
+import { randomBytes } from "node:crypto";
+import type { AppConfig } from "../types.js";
+import type { SecretsConfig } from "../secrets.js";
+import { info, success, warn, error, header, ask } from "../shared.js";
+import { t } from "../i18n.js";
+
+type RL = ReturnType<typeof createInterface>;
+
+const DEFAULT_WEBHOOK_PATH = "/webhook";
+
+/** True for absolute http(s) URLs. */
+export function isWebhookUrl(value: string): boolean {
+  try {
+    const url = new URL(value);
+    return url.protocol === "http:" || url.protocol === "https:";
+  } catch {
+    return false;
+  }
+}
+
+async function askWebhookPath(rl: RL): Promise<string> {
+  for (;;) {
+    const answer = (await ask(rl, t("webhook.path", { path: DEFAULT_WEBHOOK_PATH }))) || DEFAULT_WEBHOOK_PATH;
+    if (/^\/[\w\-./]*$/.test(answer)) return answer;
+    error(t("webhook.badPath"));
+  }
+}
+
+async function askOutboundUrl(rl: RL): Promise<string> {
+  for (;;) {
+    const answer = await ask(rl, t("webhook.replyUrl"));
+    if (!answer || isWebhookUrl(answer)) return answer;
+    error(t("webhook.badUrl"));
+  }
+}
+
+/**
+ * Configure the webhook channel: `webhook` in app.yaml, the shared secret in
+ * secrets.yaml. Turns on Gateway HTTP when it was declined, since it serves
+ * the inbound path.
+ */
+export async function configureWebhookConfig(
+  rl: RL,
+  config: AppConfig,
+  secrets: SecretsConfig,
+): Promise<void> {
+  header(t("webhook.header"));
+  info(t("webhook.intro", { example: '{"text": "...", "from": "ci"}' }));
+  info(t("webhook.auth"));
+
+  const path = await askWebhookPath(rl);
+
+  let secret = await ask(rl, t("webhook.secret"), true);
+  if (!secret) {
+    secret = randomBytes(24).toString("hex");
+    info(t("webhook.generated", { secret: secret.slice(0, 8) }));
+  }
+
+  const outboundUrl = await askOutboundUrl(rl);
+  if (!outboundUrl) warn(t("webhook.noReplyUrl"));
+
+  config.webhook = {
+    path,
+    ...(outboundUrl && { outboundUrl }),
+  };
+  secrets.webhook = { secret };
+
+  if (!config.gateway?.http) {
+    const token = randomBytes(16).toString("hex");
+    config.gateway = { http: { host: "127.0.0.1", port: 8787, token } };
+    info(t("webhook.gatewayOn", { port: 8787 }));
+  }
+
+  const { host, port } = config.gateway!.http!;
+  success(t("webhook.ready", { url: `http://${host}:${port}${path}` }));
+}
@@ -8,6 +8,7 @@ import type { SecretsConfig } from "../secrets.js";
 import type { ProviderConfig } from "../types.js";
 import { header, info, success, ask } from "../shared.js";
 import { initDevWorkspace } from "./init-dev-workspace.js";
+import { t } from "../i18n.js";
 
 /**
  * Get workspace path from user or defaults.
@@ -17,18 +18,18 @@ export async function getWorkspacePath(
   dockerMode: boolean,
   appConfigPath: string,
 ): Promise<string> {
-  header("Workspace");
+  header(t("workspace.header"));
 
   if (dockerMode) {
     const workspace = "/app/workspace";
-    info("Docker mode uses the default workspace path inside the container.");
-    success(`Workspace: ${workspace}`);
+    info(t("workspace.docker"));
+    success(t("workspace.chosen", { path: workspace }));
     return workspace;
   }
 
   const defaultWorkspace = join(dirname(appConfigPath), "workspace");
-  const workspace = (await ask(rl, `Workspace path [${defaultWorkspace}]: `)) || defaultWorkspace;
-  success(`Workspace: ${workspace}`);
+  const workspace = (await ask(rl, t("workspace.ask", { path: defaultWorkspace }))) || defaultWorkspace;
+  success(t("workspace.chosen", { path: workspace }));
   return workspace;
 }
 
@@ -41,27 +42,27 @@ export function printDevNextStepsText(
   secrets: SecretsConfig,
   providers: ProviderConfig[],
 ): void {
-  header("Next steps");
-  console.log("You're almost there:");
+  header(t("nextSteps.header"));
+  console.log(t("nextSteps.intro"));
 
   if (discordEnabled && !secrets.discord?.token) {
-    console.log("  • Add your Discord token later: owliabot token set discord");
+    console.log(`  • ${t("nextSteps.discordToken", { command: "owliabot token set discord" })}`);
   }
   if (telegramEnabled && !secrets.telegram?.token) {
-    console.log("  • Add your Telegram token later: owliabot token set telegram");
+    console.log(`  • ${t("nextSteps.telegramToken", { command: "owliabot token set telegram" })}`);
   }
   if (providers.some((p) => p.apiKey === "env")) {
-    console.log("  • If you're using environment variables, set ANTHROPIC_API_KEY or OPENAI_API_KEY");
+    console.log(`  • ${t("nextSteps.envVars")}`);
   }
   if (providers.some((p) => p.apiKey === "oauth" && p.id === "openai-codex")) {
-    console.log("  • Finish sign-in: owliabot auth setup openai-codex");
+    console.log(`  • ${t("nextSteps.signIn", { command: "owliabot auth setup openai-codex" })}`);
   }
 
   if (secrets.gateway?.token) {
-    console.log(`  • Gateway endpoint: http://localhost:8787 (token: ${secrets.gateway.token.slice(0, 8)}...)`);
+    console.log(`  • ${t("nextSteps.gateway", { url: "http://localhost:8787", token: secrets.gateway.token.slice(0, 8) })}`);
   }
 
-  console.log("  • Start OwliaBot: owliabot start");
+  console.log(`  • ${t("nextSteps.start", { command: "owliabot start" })}`);
   console.log("");
 }
 
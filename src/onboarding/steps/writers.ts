@@ -10,6 +10,7 @@ import { saveAppConfig } from "../storage.js";
 import { success } from "../shared.js";
 import { saveAppConfigWithComments } from "./helpers.js";
 import { tryMakeTreeWritableForDocker, type DockerPaths } from "./docker.js";
+import { t } from "../i18n.js";
 
 /**
  * Write Docker config files in local style.
@@ -21,13 +22,13 @@ export async function writeDockerConfigLocalStyle(
 ): Promise<void> {
   const dockerAppConfigPath = join(paths.configDir, "app.yaml");
   await saveAppConfigWithComments(config, dockerAppConfigPath, saveAppConfig);
-  success(`Saved your settings in ${dockerAppConfigPath}`);
+  success(t("common.savedSettings", { path: dockerAppConfigPath }));
 
   const hasSecrets = Object.keys(secrets).length > 0;
   if (!hasSecrets) return;
 
   await saveSecrets(dockerAppConfigPath, secrets);
-  success(`Saved your tokens and keys in ${join(paths.configDir, "secrets.yaml")}`);
+  success(t("common.savedSecrets", { path: join(paths.configDir, "secrets.yaml") }));
 }
 
 /**
@@ -39,13 +40,13 @@ export async function writeDevConfig(
   appConfigPath: string,
 ): Promise<void> {
   await saveAppConfigWithComments(config, appConfigPath, saveAppConfig);
-  success(`Saved your settings in ${appConfigPath}`);
+  success(t("common.savedSettings", { path: appConfigPath }));
 
   const hasSecrets = Object.keys(secrets).length > 0;
   if (!hasSecrets) return;
 
   await saveSecrets(appConfigPath, secrets);
-  success(`Saved your tokens and keys in ${dirname(appConfigPath)}/secrets.yaml`);
+  success(t("common.savedSecrets", { path: `${dirname(appConfigPath)}/secrets.yaml` }));
 }
 
 /**
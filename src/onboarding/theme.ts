@@ -0,0 +1,70 @@
+/**
+ * Color themes for the onboarding wizard.
+ *
+ * `dark` is the original palette. `light` avoids the bright yellow, cyan
+ * and dim text that wash out on a white background; `high-contrast` uses
+ * bold bright colors and never dims; `mono` prints no escapes at all. The
+ * theme comes from `onboard --theme`, else OWLIABOT_THEME; NO_COLOR (any
+ * value) or CLICOLOR=0 selects `mono` when neither is set.
+ */
+
+export interface Theme {
+  RED: string;
+  GREEN: string;
+  YELLOW: string;
+  BLUE: string;
+  CYAN: string;
+  /** Secondary text (hints, docs links) */
+  DIM: string;
+  /** Reset */
+  NC: string;
+}
+
+export const THEMES = {
+  dark: {
+    RED: "\x1b[0;31m",
+    GREEN: "\x1b[0;32m",
+    YELLOW: "\x1b[1;33m",
+    BLUE: "\x1b[0;34m",
+    CYAN: "\x1b[0;36m",
+    DIM: "\x1b[2m",
+    NC: "\x1b[0m",
+  },
+  light: {
+    RED: "\x1b[0;31m",
+    GREEN: "\x1b[0;32m",
+    YELLOW: "\x1b[0;33m",
+    BLUE: "\x1b[0;34m",
+    CYAN: "\x1b[1;34m",
+    DIM: "\x1b[0;35m",
+    NC: "\x1b[0m",
+  },
+  "high-contrast": {
+    RED: "\x1b[1;91m",
+    GREEN: "\x1b[1;92m",
+    YELLOW: "\x1b[1;93m",
+    BLUE: "\x1b[1;94m",
+    CYAN: "\x1b[1;96m",
+    DIM: "",
+    NC: "\x1b[0m",
+  },
+  mono: { RED: "", GREEN: "", YELLOW: "", BLUE: "", CYAN: "", DIM: "", NC: "" },
+} satisfies Record<string, Theme>;
+
+export type ThemeName = keyof typeof THEMES;
+
+export function parseTheme(value: string | undefined): ThemeName | undefined {
+  const name = value?.trim().toLowerCase();
+  return name && name in THEMES ? (name as ThemeName) : undefined;
+}
+
+/**
+ * The theme to use: the flag, else OWLIABOT_THEME, else `mono` under
+ * NO_COLOR / CLICOLOR=0, else `dark`. An explicit theme wins over NO_COLOR.
+ */
+export function resolveTheme(flag: string | undefined, env: NodeJS.ProcessEnv = process.env): ThemeName {
+  const chosen = parseTheme(flag) ?? parseTheme(env.OWLIABOT_THEME);
+  if (chosen) return chosen;
+  if (env.NO_COLOR || env.CLICOLOR === "0") return "mono";
+  return "dark";
+}
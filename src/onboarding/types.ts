@@ -1,5 +1,5 @@
 /** Supported LLM provider identifiers */
-export type LLMProviderId = "anthropic" | "openai" | "openai-codex" | "openai-compatible";
+export type LLMProviderId = "anthropic" | "openai" | "openai-codex" | "openai-compatible" | "amazon-bedrock" | "azure-openai";
 
 /** Provider configuration with OAuth or API key auth */
 export type ProviderConfig =
@@ -27,6 +27,12 @@ export type ProviderConfig =
       apiKey: string; // Anthropic API key (from secrets or env)
       priority: number;
     }
+  | {
+      id: "demo";
+      model: string; // Echo replies (onboard --demo)
+      apiKey?: undefined; // No API key
+      priority: number;
+    }
   | {
       id: string;
       model: string;
@@ -58,7 +64,8 @@ export interface SystemCapabilityConfig {
     timeoutMs: number;
     maxOutputBytes: number;
   };
-  web: {
+  /** Omitted to turn web_fetch off */
+  web?: {
     domainAllowList: string[];
     domainDenyList: string[];
     allowPrivateNetworks: boolean;
@@ -66,7 +73,8 @@ export interface SystemCapabilityConfig {
     maxResponseBytes: number;
     blockOnSecret: boolean;
   };
-  webSearch: {
+  /** Omitted to turn web_search off */
+  webSearch?: {
     defaultProvider: "brave" | "duckduckgo";
     timeoutMs: number;
     maxResults: number;
@@ -83,14 +91,33 @@ export interface AppConfig {
   // Channels
   discord?: {
     /** Discord bot token is expected via onboarding secrets.yaml or env */
+    /** "keychain" reads it from the OS keychain instead */
+    token?: "keychain";
     requireMentionInGuild?: boolean;
     channelAllowList?: string[];
     /** Optional allowlist for user ids (DMs / guild) */
     memberAllowList?: string[];
   };
 
+  slack?: {
+    /** Slack tokens are expected via onboarding secrets.yaml or env */
+    /** Optional allowlist for member ids (U...) */
+    memberAllowList?: string[];
+    /** Channels (C...) where the bot answers without a mention */
+    channelAllowList?: string[];
+  };
+
+  webhook?: {
+    /** Path served on Gateway HTTP; the shared secret lives in secrets.yaml */
+    path: string;
+    /** Where replies are POSTed */
+    outboundUrl?: string;
+  };
+
   telegram?: {
     /** Telegram bot token is expected via env (TELEGRAM_BOT_TOKEN) */
+    /** "keychain" reads it from the OS keychain instead */
+    token?: "keychain";
     /** Optional allowlist for direct messages */
     allowList?: string[];
     /**
@@ -128,6 +155,10 @@ export interface AppConfig {
       port: number;
       token?: string;
       allowlist?: string[];
+      basicAuth?: { username: string; password: string };
+      tls?: { certPath: string; keyPath: string; clientCaPath?: string };
+      /** How long gateway events are kept */
+      eventTtlMs?: number;
     };
   };
 
@@ -141,16 +172,54 @@ export interface AppConfig {
     writeToolConfirmation?: boolean;
     /** Timeout in ms for write tool confirmation */
     writeToolConfirmationTimeoutMs?: number;
+    /** User ID who confirms writes (default: the user who asked) */
+    writeToolConfirmationApprover?: string;
+    /** Where confirmations are asked: "chat" (default) or "dm" to the approver */
+    writeToolConfirmationIn?: "chat" | "dm";
+  };
+
+  // Agent loop limits
+  agents?: {
+    loop?: {
+      maxIterations?: number;
+      timeoutSeconds?: number;
+    };
   };
 
   // Tool configuration
   tools?: {
     /** Enable filesystem write tools (write_file / edit_file / apply_patch) */
     allowWrite?: boolean;
+    /** Only the tools in allowList (or all but denyList) are offered to the model */
+    policy?: {
+      allowList?: string[];
+      denyList?: string[];
+    };
+  };
+
+  // Session handling
+  session?: {
+    /** Write a summary to memory when a session is reset */
+    summarizeOnReset?: boolean;
+  };
+
+  // Cron scheduler
+  cron?: {
+    enabled?: boolean;
+  };
+
+  // Infrastructure (event store retention)
+  infra?: {
+    eventStore?: {
+      enabled?: boolean;
+      ttlMs?: number;
+    };
   };
 
   // MCP (Model Context Protocol) servers
   mcp?: {
+    /** Named presets expanded by the gateway (e.g. ["playwright", "github"]) */
+    presets?: string[];
     servers?: Array<{
       name: string;
       command?: string;
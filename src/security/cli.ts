@@ -84,6 +84,12 @@ export async function securityShow(): Promise<void> {
   if (timeoutMs != null) {
     log.info(`  confirmationTimeout:   ${timeoutMs}ms`);
   }
+  if (security.writeToolConfirmationApprover != null) {
+    log.info(`  confirmationApprover:  ${security.writeToolConfirmationApprover}`);
+  }
+  if (security.writeToolConfirmationIn != null) {
+    log.info(`  confirmationIn:        ${security.writeToolConfirmationIn}`);
+  }
 }
 
 /**
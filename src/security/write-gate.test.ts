@@ -186,6 +186,28 @@ describe("WriteGate", () => {
       expect(result.reason).toBe("timeout");
       expect(ch.sentMessages.some((m) => m.text.includes("timed out"))).toBe(true);
     });
+
+    it("waits for the configured approver instead of the requester", async () => {
+      const ch = mockChannel("yes");
+      const gate = new WriteGate(makeConfig({ approver: OTHER_USER }), ch);
+
+      const result = await gate.check(makeCall(), makeCtx());
+
+      expect(result.allowed).toBe(true);
+      expect(ch.waitForReply).toHaveBeenCalledWith(TARGET, OTHER_USER, 60_000);
+    });
+
+    it("asks in the approver's DM and tells the chat it is waiting", async () => {
+      const ch = mockChannel("no");
+      const gate = new WriteGate(makeConfig({ approver: OTHER_USER, confirmIn: "dm" }), ch);
+
+      const result = await gate.check(makeCall(), makeCtx());
+
+      expect(result.reason).toBe("denied");
+      expect(ch.waitForReply).toHaveBeenCalledWith(OTHER_USER, OTHER_USER, 60_000);
+      expect(ch.sentMessages.map((m) => m.target)).toEqual([OTHER_USER, TARGET, OTHER_USER]);
+      expect(ch.sentMessages[1].text).toContain("direct message");
+    });
   });
 
   // ── Confirmation disabled ──────────────────────────────────────────────
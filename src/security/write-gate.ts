@@ -40,6 +40,10 @@ export interface WriteGateConfig {
   confirmationEnabled: boolean;
   /** Milliseconds to wait for user reply before auto-deny */
   timeoutMs: number;
+  /** User who must answer; unset = the user who triggered the write */
+  approver?: string;
+  /** Ask in the requesting chat, or in a DM to the approver (default "chat") */
+  confirmIn?: "chat" | "dm";
   /** Path to audit JSONL file */
   auditPath: string;
 }
@@ -216,18 +220,26 @@ export class WriteGate {
     ctx: WriteGateCallContext,
   ): Promise<WriteGateVerdict> {
     const summary = this.buildConfirmationMessage(call);
+    const approver = this.config.approver ?? ctx.userId;
+    // A DM conversation is keyed by the user's ID on every channel
+    const where = this.config.confirmIn === "dm" ? approver : ctx.target;
 
-    await this.channel.sendMessage(ctx.target, { text: summary });
+    await this.channel.sendMessage(where, { text: summary });
+    if (where !== ctx.target) {
+      await this.channel.sendMessage(ctx.target, {
+        text: "⏳ Waiting for the write to be approved in a direct message.",
+      });
+    }
 
     const reply = await this.channel.waitForReply(
-      ctx.target,
-      ctx.userId,
+      where,
+      approver,
       this.config.timeoutMs,
     );
 
     if (reply === null) {
       log.info(`Confirmation timed out for ${call.name} (${ctx.sessionKey})`);
-      await this.channel.sendMessage(ctx.target, {
+      await this.channel.sendMessage(where, {
         text: "⏰ Write operation timed out — denied.",
       });
       return "timeout";
@@ -238,7 +250,7 @@ export class WriteGate {
       return "approved";
     }
 
-    await this.channel.sendMessage(ctx.target, {
+    await this.channel.sendMessage(where, {
       text: "❌ Write operation denied.",
     });
     return "denied";
@@ -307,6 +319,8 @@ export function createWriteGate(
     writeToolAllowList?: string[];
     writeToolConfirmation?: boolean;
     writeToolConfirmationTimeoutMs?: number;
+    writeToolConfirmationApprover?: string;
+    writeToolConfirmationIn?: "chat" | "dm";
   } | undefined,
   channel: WriteGateChannel,
   // Kept for backwards-compat in call sites; audit is now stored under OWLIABOT_HOME/gateway.
@@ -317,6 +331,8 @@ export function createWriteGate(
     allowList: security?.writeToolAllowList ?? [],
     confirmationEnabled: security?.writeToolConfirmation ?? true,
     timeoutMs: security?.writeToolConfirmationTimeoutMs ?? 60_000,
+    approver: security?.writeToolConfirmationApprover,
+    confirmIn: security?.writeToolConfirmationIn ?? "chat",
     auditPath: defaultAuditLogPath(),
   };
   return new WriteGate(cfg, channel);
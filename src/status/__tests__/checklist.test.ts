@@ -0,0 +1,54 @@
+import { describe, it, expect } from "vitest";
+import { checklistSettled, formatChecklist, latestStartLines, setupChecklist } from "../checklist.js";
+
+const line = (time: string, message: string) => `2026-03-01 10:${time} INFO [owliabot] ${message}`;
+
+const HEALTHY = [
+  line("00:00", "Loading config from /home/owliabot/.owliabot/app.yaml"),
+  line("00:01", "Config loaded successfully"),
+  line("00:02", "Gateway HTTP server listening on http://0.0.0.0:8787"),
+  line("00:03", "Starting Discord bot..."),
+  line("00:04", "Discord bot started"),
+  line("00:04", "Gateway started"),
+  line("00:05", "Gateway ready"),
+].join("\n");
+
+describe("setup checklist", () => {
+  it("passes each subsystem with its marker", () => {
+    const items = setupChecklist(HEALTHY, ["discord"]);
+    expect(items.map((i) => `${i.name}:${i.state}`)).toEqual([
+      "Config loaded:pass",
+      "Provider credentials:pass",
+      "Gateway HTTP:pass",
+      "Discord connected:pass",
+      "Ready:pass",
+    ]);
+    expect(checklistSettled(items)).toBe(true);
+  });
+
+  it("pinpoints the failing part and keeps configured channels that never logged", () => {
+    const logs = [
+      line("00:00", "Loading config from /app.yaml"),
+      line("00:01", "Config loaded successfully"),
+      line("00:02", "Telegram configured but token missing; skipping Telegram channel startup"),
+      line("00:03", "\u001b[33m  ⚠ No valid provider credentials found.\u001b[39m"),
+    ].join("\n");
+    const items = setupChecklist(logs, ["telegram", "discord"]);
+    expect(items.find((i) => i.name === "Telegram polling")).toMatchObject({ state: "fail" });
+    expect(items.find((i) => i.name === "Provider credentials")?.detail).toContain("No valid provider credentials");
+    expect(items.find((i) => i.name === "Discord connected")?.state).toBe("pending");
+    expect(formatChecklist(items)).toContain("  … Discord connected (not seen yet)");
+  });
+
+  it("reads only the latest start, within its first seconds", () => {
+    const logs = [
+      line("00:00", "Loading config from /app.yaml"),
+      line("00:01", "Gateway ready"),
+      line("05:00", "Loading config from /app.yaml"),
+      line("05:01", "Config loaded successfully"),
+      line("07:00", "Gateway ready"),
+    ].join("\n");
+    expect(latestStartLines(logs, 60)).toHaveLength(2);
+    expect(setupChecklist(logs, [], 60).find((i) => i.name === "Ready")?.state).toBe("pending");
+  });
+});
@@ -0,0 +1,155 @@
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import { execFileSync } from "node:child_process";
+import { mkdtempSync, readFileSync, rmSync, writeFileSync } from "node:fs";
+import { createServer } from "node:https";
+import type { AddressInfo } from "node:net";
+import { join } from "node:path";
+import { tmpdir } from "node:os";
+import { generateGatewayTlsMaterial } from "../../onboarding/steps/gateway-auth.js";
+import {
+  authLines,
+  collectStatus,
+  formatStatus,
+  healthUrl,
+  parseComposePs,
+  summarizeConfig,
+} from "../index.js";
+
+const DAY = 24 * 60 * 60 * 1000;
+
+const hasOpenssl = (() => {
+  try {
+    execFileSync("openssl", ["version"], { stdio: "ignore" });
+    return true;
+  } catch {
+    return false;
+  }
+})();
+
+describe("status", () => {
+  let dir: string;
+
+  beforeEach(() => {
+    dir = mkdtempSync(join(tmpdir(), "owliabot-status-"));
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+  });
+
+  it("parses both compose ps JSON formats", () => {
+    const row = { Service: "owliabot", State: "running", Health: "healthy", Status: "Up 2 hours (healthy)" };
+    const expected = [{ service: "owliabot", state: "running", health: "healthy", status: "Up 2 hours (healthy)" }];
+    expect(parseComposePs(JSON.stringify([row]))).toEqual(expected);
+    expect(parseComposePs(`${JSON.stringify(row)}\n`)).toEqual(expected);
+    expect(parseComposePs("")).toEqual([]);
+  });
+
+  it("finds the health URL from the published port, else app.yaml", () => {
+    const compose = 'services:\n  owliabot:\n    ports:\n      - "127.0.0.1:9000:8787"\n';
+    expect(healthUrl({}, compose)).toBe("http://127.0.0.1:9000/health");
+    expect(healthUrl({ gateway: { http: { port: 8800, tls: {} } } })).toBe("https://127.0.0.1:8800/health");
+    expect(healthUrl(null)).toBe("http://127.0.0.1:8787/health");
+  });
+
+  it("lists providers in fallback order and the configured channels", () => {
+    expect(
+      summarizeConfig({
+        providers: [
+          { id: "openai", model: "gpt-4o", priority: 2 },
+          { id: "anthropic", model: "claude-opus-4-5", priority: 1 },
+        ],
+        telegram: { allowList: [] },
+        discord: {},
+      }),
+    ).toEqual({ providers: ["anthropic (claude-opus-4-5)", "openai (gpt-4o)"], channels: ["discord", "telegram"] });
+  });
+
+  it("reports sign-in expiry with reminders when renewal is due", () => {
+    const now = Date.parse("2026-02-10T00:00:00Z");
+    const lines = authLines(
+      { "openai-codex": { authenticated: true, expiresAt: now + 3 * DAY, refreshable: false } },
+      { anthropic: { token: "sk-ant-oat01-x", tokenExpiresAt: "2027-01-01T00:00:00.000Z" } },
+      now,
+    );
+    expect(lines[0].label).toBe("openai-codex");
+    expect(lines[0].warning).toMatch(/expires in 3 days/);
+    expect(lines[1]).toEqual({ label: "anthropic", detail: "setup-token, expires 2027-01-01T00:00:00.000Z", warning: undefined });
+  });
+
+  it("collects everything into one screen", async () => {
+    const configPath = join(dir, "app.yaml");
+    const composePath = join(dir, "docker-compose.yml");
+    writeFileSync(configPath, "providers:\n  - id: anthropic\n    model: claude-opus-4-5\n    priority: 1\ndiscord: {}\n");
+    writeFileSync(composePath, 'services:\n  owliabot:\n    ports:\n      - "127.0.0.1:8787:8787"\n');
+
+    const report = await collectStatus({
+      configPath,
+      composeFile: composePath,
+      exec: () => JSON.stringify({ Service: "owliabot", State: "running", Health: "healthy" }),
+      fetchImpl: (async () => new Response(JSON.stringify({ ok: true, version: "0.2.0" }))) as unknown as typeof fetch,
+      oauth: async () => ({ "openai-codex": { authenticated: false } }),
+    });
+    const out = formatStatus(report).join("\n");
+
+    expect(out).toContain("owliabot     running (healthy)");
+    expect(out).toMatch(/http:\/\/127\.0\.0\.1:8787\/health: OK in \d+ ms, version 0\.2\.0/);
+    expect(out).toContain("providers: anthropic (claude-opus-4-5)");
+    expect(out).toContain("channels:  discord");
+    expect(out).toContain("openai-codex: not signed in");
+  });
+
+  it.skipIf(!hasOpenssl)("probes an mTLS gateway over HTTPS, trusting its local CA and sending the token", async () => {
+    const tlsDir = join(dir, "tls");
+    generateGatewayTlsMaterial(tlsDir, 1);
+    const tokens: Array<string | undefined> = [];
+    const server = createServer(
+      {
+        cert: readFileSync(join(tlsDir, "server.crt")),
+        key: readFileSync(join(tlsDir, "server.key")),
+        ca: readFileSync(join(tlsDir, "ca.crt")),
+        requestCert: true,
+        rejectUnauthorized: false,
+      },
+      (req, res) => {
+        tokens.push(req.headers["x-gateway-token"] as string | undefined);
+        res.end(JSON.stringify({ ok: true, version: "0.2.0" }));
+      },
+    );
+    await new Promise<void>((r) => server.listen(0, "127.0.0.1", r));
+    try {
+      const port = (server.address() as AddressInfo).port;
+      const configPath = join(dir, "app.yaml");
+      writeFileSync(
+        configPath,
+        `gateway:\n  http:\n    port: ${port}\n    token: secrets\n    tls:\n      certPath: tls/server.crt\n      keyPath: tls/server.key\n      clientCaPath: tls/ca.crt\n`,
+      );
+      writeFileSync(join(dir, "secrets.yaml"), "gateway:\n  token: gw-secret\n");
+
+      const report = await collectStatus({
+        configPath,
+        composeFile: join(dir, "docker-compose.yml"),
+        oauth: async () => ({}),
+        env: {},
+      });
+      expect(report.health).toMatchObject({ kind: "ok", url: `https://127.0.0.1:${port}/health`, version: "0.2.0" });
+      expect(tokens).toEqual(["gw-secret"]);
+    } finally {
+      server.close();
+    }
+  });
+
+  it("skips the container section without a compose file", async () => {
+    const report = await collectStatus({
+      configPath: join(dir, "app.yaml"),
+      composeFile: join(dir, "docker-compose.yml"),
+      fetchImpl: (async () => {
+        throw new Error("connect ECONNREFUSED");
+      }) as unknown as typeof fetch,
+      oauth: async () => ({ "openai-codex": { authenticated: false } }),
+    });
+    expect(report.container).toBeNull();
+    expect(report.config).toBeNull();
+    expect(formatStatus(report).join("\n")).toContain("connect ECONNREFUSED");
+  });
+});
@@ -0,0 +1,70 @@
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import { mkdirSync, mkdtempSync, rmSync, writeFileSync } from "node:fs";
+import { join } from "node:path";
+import { tmpdir } from "node:os";
+import { containerUsage, diskUsage, formatBytes, hostWorkspacePath, parseDockerStats } from "../usage.js";
+
+describe("status usage", () => {
+  let dir: string;
+
+  beforeEach(() => {
+    dir = mkdtempSync(join(tmpdir(), "owliabot-usage-"));
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+  });
+
+  it("parses docker stats output", () => {
+    const line = JSON.stringify({ Name: "owliabot", CPUPerc: "1.25%", MemUsage: "120MiB / 1.9GiB", MemPerc: "6.17%" });
+    expect(parseDockerStats(`${line}\n`)).toEqual([
+      { name: "owliabot", cpu: "1.25%", memory: "120MiB / 1.9GiB", memoryPercent: "6.17%" },
+    ]);
+  });
+
+  it("runs one docker stats for the running containers", () => {
+    const calls: string[][] = [];
+    const exec = (args: string[]) => {
+      calls.push(args);
+      return "";
+    };
+    expect(containerUsage([], exec)).toEqual({ kind: "ok", containers: [] });
+    expect(calls).toEqual([]);
+    containerUsage(["owliabot"], exec);
+    expect(calls[0]).toEqual(["stats", "--no-stream", "--format", "{{json .}}", "owliabot"]);
+    expect(containerUsage(["x"], () => { throw new Error("Cannot connect to the Docker daemon\nmore"); })).toEqual({
+      kind: "unavailable",
+      reason: "Cannot connect to the Docker daemon",
+    });
+  });
+
+  it("falls back to <configDir>/workspace for container paths", () => {
+    mkdirSync(join(dir, "workspace"));
+    expect(hostWorkspacePath(dir, "/app/workspace-that-does-not-exist")).toBe(join(dir, "workspace"));
+    expect(hostWorkspacePath(dir, "workspace")).toBe(join(dir, "workspace"));
+    expect(hostWorkspacePath(join(dir, "nothing"), undefined)).toBeNull();
+  });
+
+  it("sizes the workspace and each database with its journal files", () => {
+    const memory = join(dir, "workspace", "memory");
+    mkdirSync(memory, { recursive: true });
+    mkdirSync(join(dir, "gateway"));
+    writeFileSync(join(memory, "main.sqlite"), Buffer.alloc(3000));
+    writeFileSync(join(memory, "main.sqlite-wal"), Buffer.alloc(1000));
+    writeFileSync(join(dir, "workspace", "notes.md"), Buffer.alloc(500));
+    writeFileSync(join(dir, "gateway", "infra.db"), Buffer.alloc(200));
+
+    const report = diskUsage(dir, "workspace", "{workspace}/memory/{agentId}.sqlite");
+    expect(report.workspace).toEqual({ path: join(dir, "workspace"), bytes: 4500 });
+    expect(report.stores).toEqual([
+      { path: join(memory, "main.sqlite"), bytes: 4000 },
+      { path: join(dir, "gateway", "infra.db"), bytes: 200 },
+    ]);
+  });
+
+  it("formats sizes", () => {
+    expect(formatBytes(512)).toBe("512 B");
+    expect(formatBytes(1536)).toBe("1.5 KB");
+    expect(formatBytes(3 * 1024 * 1024)).toBe("3.0 MB");
+  });
+});
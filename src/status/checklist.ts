@@ -0,0 +1,161 @@
+/**
+ * Setup checklist from the bot's startup logs: which part came up and which
+ * didn't (config, providers, each channel, MCP servers), so "it's running
+ * but doesn't answer" points at the broken piece.
+ *
+ * Only the latest start is read: the logs from the last "Loading config
+ * from" line on, limited to the first `seconds` after it.
+ */
+
+import { BOT_SERVICE, type DockerExec } from "../upgrade/index.js";
+import { redactSecrets } from "../utils/redact.js";
+
+export type CheckState = "pass" | "fail" | "pending";
+
+export interface ChecklistItem {
+  name: string;
+  state: CheckState;
+  /** The log line that decided a failure */
+  detail?: string;
+}
+
+interface Marker {
+  name: string;
+  pass: RegExp;
+  fail?: RegExp;
+  /** Shown only when `configKey` is set in app.yaml or the logs mention it */
+  optional?: boolean;
+  configKey?: string;
+  mention?: RegExp;
+}
+
+const START_MARKER = "Loading config from";
+const TIMESTAMP = /^(\d{4}-\d{2}-\d{2})[ T](\d{2}:\d{2}:\d{2})/;
+// tslog colors its pretty output even without a TTY
+const ANSI = /\x1b\[[0-9;]*m/g;
+
+const MARKERS: Marker[] = [
+  {
+    name: "Config loaded",
+    pass: /Config loaded successfully/,
+    fail: /Invalid config|Failed to (load|parse)|YAMLParseError|ZodError/,
+  },
+  {
+    name: "Provider credentials",
+    // Checked before the channels start; silence until then means they're fine
+    pass: /Gateway started/,
+    fail: /No valid provider credentials found/,
+  },
+  {
+    name: "Gateway HTTP",
+    optional: true,
+    pass: /Gateway HTTP server listening/,
+    fail: /EADDRINUSE|address already in use/,
+  },
+  {
+    name: "Discord connected",
+    configKey: "discord",
+    optional: true,
+    mention: /Discord/,
+    pass: /Discord bot started|Logged in as /,
+    fail: /Discord configured but token missing|disallowed intents|invalid token was provided|TokenInvalid/,
+  },
+  {
+    name: "Telegram polling",
+    configKey: "telegram",
+    optional: true,
+    mention: /Telegram/,
+    pass: /Telegram bot started/,
+    fail: /Telegram configured but token missing|401: Unauthorized|409: Conflict/,
+  },
+  {
+    name: "Slack connected",
+    configKey: "slack",
+    optional: true,
+    mention: /Slack/,
+    pass: /Slack bot started/,
+    fail: /Slack configured but bot or app token missing|invalid_auth/,
+  },
+  {
+    name: "Webhook accepting",
+    configKey: "webhook",
+    optional: true,
+    mention: /Webhook/,
+    pass: /Webhook channel accepting POST/,
+    fail: /Webhook configured but/,
+  },
+  {
+    name: "MCP servers",
+    configKey: "mcp",
+    optional: true,
+    mention: /MCP/,
+    pass: /MCP: \d+ tools registered/,
+    fail: /MCP server "[^"]*" failed to start/,
+  },
+  { name: "Ready", pass: /Gateway ready/ },
+];
+
+function timestampMs(line: string): number | null {
+  const m = TIMESTAMP.exec(line);
+  return m ? Date.parse(`${m[1]}T${m[2]}`) : null;
+}
+
+/** Lines of the latest start, within `seconds` of it */
+export function latestStartLines(logs: string, seconds = 60): string[] {
+  const lines = logs.replace(ANSI, "").split("\n");
+  let start = -1;
+  for (let i = lines.length - 1; i >= 0; i--) {
+    if (lines[i].includes(START_MARKER)) {
+      start = i;
+      break;
+    }
+  }
+  const run = start >= 0 ? lines.slice(start) : lines;
+  const t0 = run.map(timestampMs).find((t) => t !== null);
+  if (t0 === undefined || t0 === null) return run;
+  return run.filter((line) => {
+    const t = timestampMs(line);
+    return t === null || t - t0 <= seconds * 1000;
+  });
+}
+
+/**
+ * Pass/fail per subsystem. `configured` lists the app.yaml top-level keys
+ * that are set; optional items not configured and not in the logs are left out.
+ */
+export function setupChecklist(logs: string, configured: string[] = [], seconds = 60): ChecklistItem[] {
+  const lines = latestStartLines(logs, seconds);
+  const text = lines.join("\n");
+  const items: ChecklistItem[] = [];
+  for (const marker of MARKERS) {
+    const failLine = marker.fail ? lines.find((l) => marker.fail!.test(l)) : undefined;
+    const passed = marker.pass.test(text);
+    const mentioned = Boolean(failLine) || passed || (marker.mention?.test(text) ?? false);
+    if (marker.optional && !mentioned && !(marker.configKey && configured.includes(marker.configKey))) continue;
+    if (failLine) items.push({ name: marker.name, state: "fail", detail: redactSecrets(failLine.replace(TIMESTAMP, "").trim()) });
+    else items.push({ name: marker.name, state: passed ? "pass" : "pending" });
+  }
+  return items;
+}
+
+/** Whether the startup has settled: ready, or something failed */
+export function checklistSettled(items: ChecklistItem[]): boolean {
+  return items.some((i) => i.state === "fail") || items.find((i) => i.name === "Ready")?.state === "pass";
+}
+
+/** `docker compose logs` of the bot, or null when they can't be read */
+export function botLogs(composePath: string, exec: DockerExec): string | null {
+  try {
+    return exec(["compose", "-f", composePath, "logs", "--no-color", "--no-log-prefix", BOT_SERVICE]);
+  } catch {
+    return null;
+  }
+}
+
+export function formatChecklist(items: ChecklistItem[]): string[] {
+  const mark: Record<CheckState, string> = { pass: "✓", fail: "✗", pending: "…" };
+  return items.flatMap((item) => [
+    `  ${mark[item.state]} ${item.name}${item.state === "pending" ? " (not seen yet)" : ""}`,
+    ...(item.detail ? [`      ${item.detail}`] : []),
+  ]);
+}
@@ -0,0 +1,357 @@
+/**
+ * `owliabot status`: one screen with what usually takes three commands
+ * after a restart.
+ *
+ * - Container state from `docker compose ps` (skipped for native installs,
+ *   i.e. when there is no compose file)
+ * - The gateway's /health endpoint
+ * - Providers (in fallback order) and channels from app.yaml
+ * - OAuth sessions and the Anthropic setup-token, with expiry reminders
+ * - CPU / memory of the running containers and disk used by the workspace
+ *   and SQLite stores (see usage.ts)
+ * - A pass/fail checklist of the latest startup from the bot's logs
+ *   (see checklist.ts)
+ */
+
+import { existsSync, readFileSync } from "node:fs";
+import { request as httpsRequest } from "node:https";
+import { dirname, join, resolve } from "node:path";
+import { parse } from "yaml";
+import { getAllOAuthStatus } from "../auth/oauth.js";
+import { oauthSessionReminder, setupTokenReminder } from "../auth/credential-expiry.js";
+import { loadSecrets, type SecretsConfig } from "../onboarding/secrets.js";
+import { GATEWAY_TLS_DIR } from "../onboarding/steps/gateway-auth.js";
+import { BOT_SERVICE, dockerExec, type DockerExec } from "../upgrade/index.js";
+import { botLogs, formatChecklist, setupChecklist, type ChecklistItem } from "./checklist.js";
+import { containerUsage, diskUsage, formatBytes, type DiskReport, type UsageReport } from "./usage.js";
+
+export interface ContainerState {
+  service: string;
+  /** Container name, for `docker stats` */
+  name?: string;
+  state: string;
+  /** "healthy", "starting", ... when the service has a healthcheck */
+  health?: string;
+  /** Human-readable status ("Up 2 hours (healthy)") */
+  status?: string;
+}
+
+export type ContainerReport =
+  | { kind: "ok"; containers: ContainerState[] }
+  | { kind: "unavailable"; reason: string };
+
+export type HealthReport =
+  | { kind: "ok"; url: string; status: number; latencyMs: number; version?: string }
+  | { kind: "error"; url: string; message: string };
+
+export interface ConfigSummary {
+  /** "anthropic (claude-opus-4-5)", in fallback order */
+  providers: string[];
+  channels: string[];
+}
+
+export interface AuthLine {
+  label: string;
+  detail: string;
+  warning?: string;
+}
+
+export interface StatusReport {
+  configPath: string;
+  /** null when app.yaml is missing or unreadable */
+  config: ConfigSummary | null;
+  /** null for native installs (no compose file) */
+  container: ContainerReport | null;
+  health: HealthReport;
+  auth: AuthLine[];
+  /** null without a compose file */
+  usage: UsageReport | null;
+  disk: DiskReport;
+  /** null without a compose file, or when the logs can't be read */
+  startup: ChecklistItem[] | null;
+}
+
+/** Minimal view of app.yaml; the file is not validated here */
+export interface RawAppConfig {
+  workspace?: unknown;
+  memorySearch?: { store?: { path?: unknown } };
+  providers?: Array<{ id?: string; model?: string; priority?: number }>;
+  gateway?: { http?: { port?: number; tls?: { clientCaPath?: unknown } | null; token?: unknown } };
+  [key: string]: unknown;
+}
+
+const CHANNEL_KEYS = ["discord", "telegram", "slack", "webhook"];
+
+/**
+ * `docker compose ps --format json` prints a JSON array (older Compose v2)
+ * or one object per line (newer).
+ */
+export function parseComposePs(out: string): ContainerState[] {
+  const text = out.trim();
+  if (!text) return [];
+  const rows = (text.startsWith("[") ? JSON.parse(text) : text.split("\n").map((l) => JSON.parse(l))) as Array<
+    Record<string, unknown>
+  >;
+  return rows.map((row) => ({
+    service: String(row.Service ?? row.Name ?? "?"),
+    name: row.Name ? String(row.Name) : undefined,
+    state: String(row.State ?? "unknown"),
+    health: row.Health ? String(row.Health) : undefined,
+    status: row.Status ? String(row.Status) : undefined,
+  }));
+}
+
+export function composeContainers(composePath: string, exec: DockerExec): ContainerReport {
+  try {
+    return { kind: "ok", containers: parseComposePs(exec(["compose", "-f", composePath, "ps", "--all", "--format", "json"])) };
+  } catch (err) {
+    return { kind: "unavailable", reason: (err as Error).message.split("\n")[0] };
+  }
+}
+
+/**
+ * Where /health answers on this host: the bot's published port from the
+ * compose file, else gateway.http.port from app.yaml.
+ */
+export function healthUrl(config: RawAppConfig | null, composeYaml?: string): string {
+  const scheme = config?.gateway?.http?.tls ? "https" : "http";
+  let port = config?.gateway?.http?.port ?? 8787;
+  if (composeYaml) {
+    const compose = parse(composeYaml) as { services?: Record<string, { ports?: unknown[] }> } | null;
+    const published = compose?.services?.[BOT_SERVICE]?.ports?.[0];
+    // "127.0.0.1:8787:8787" / "8787:8787": the host port is second to last
+    const parts = typeof published === "string" ? published.split(":") : [];
+    if (parts.length >= 2) port = Number.parseInt(parts[parts.length - 2], 10) || port;
+  }
+  return `${scheme}://127.0.0.1:${port}/health`;
+}
+
+/**
+ * The gateway token: app.yaml's (unless it is a ${VAR} reference or
+ * "secrets"), else secrets.yaml's, else OWLIABOT_GATEWAY_TOKEN.
+ */
+export function gatewayToken(
+  config: RawAppConfig | null,
+  env: Record<string, string | undefined>,
+  secrets?: SecretsConfig | null,
+): string | undefined {
+  const token = config?.gateway?.http?.token;
+  if (typeof token === "string" && token && token !== "secrets" && !token.includes("${")) return token;
+  return secrets?.gateway?.token || env.OWLIABOT_GATEWAY_TOKEN || undefined;
+}
+
+/**
+ * The CA behind the gateway's certificate when it serves HTTPS: the local CA
+ * onboarding generates (the mTLS clientCaPath, else tls/ca.crt next to
+ * app.yaml). undefined without TLS, or when neither file is on this host.
+ */
+export function gatewayCa(config: RawAppConfig | null, configDir: string): Buffer | undefined {
+  const tls = config?.gateway?.http?.tls;
+  if (!tls) return undefined;
+  const candidates = [
+    typeof tls.clientCaPath === "string" ? resolve(configDir, tls.clientCaPath) : undefined,
+    join(configDir, GATEWAY_TLS_DIR, "ca.crt"),
+  ];
+  const path = candidates.find((p): p is string => !!p && existsSync(p));
+  return path ? readFileSync(path) : undefined;
+}
+
+/**
+ * A `fetch` for HTTPS GETs that trusts `ca` instead of the system CAs,
+ * which are all the built-in fetch checks.
+ */
+export function fetchWithCa(ca: Buffer): typeof fetch {
+  return ((input: string | URL, init?: RequestInit) =>
+    new Promise<Response>((resolveResponse, reject) => {
+      const req = httpsRequest(
+        input,
+        { ca, headers: init?.headers as Record<string, string> | undefined, signal: init?.signal ?? undefined },
+        (res) => {
+          const chunks: Buffer[] = [];
+          res.on("data", (chunk: Buffer) => chunks.push(chunk));
+          res.on("error", reject);
+          res.on("end", () =>
+            resolveResponse(new Response(chunks.length ? Buffer.concat(chunks) : null, { status: res.statusCode ?? 502 })),
+          );
+        },
+      );
+      req.on("error", reject);
+      req.end();
+    })) as typeof fetch;
+}
+
+/** GET /health; `token` is sent as X-Gateway-Token when set */
+export async function checkHealth(
+  url: string,
+  fetchImpl: typeof fetch = fetch,
+  now: () => number = Date.now,
+  token?: string,
+): Promise<HealthReport> {
+  const started = now();
+  try {
+    const res = await fetchImpl(url, {
+      signal: AbortSignal.timeout(3_000),
+      ...(token ? { headers: { "X-Gateway-Token": token } } : {}),
+    });
+    const latencyMs = now() - started;
+    if (!res.ok) return { kind: "error", url, message: `HTTP ${res.status}` };
+    const body = (await res.json().catch(() => ({}))) as { version?: unknown };
+    return {
+      kind: "ok",
+      url,
+      status: res.status,
+      latencyMs,
+      version: typeof body.version === "string" ? body.version : undefined,
+    };
+  } catch (err) {
+    return { kind: "error", url, message: (err as Error).message };
+  }
+}
+
+export function summarizeConfig(config: RawAppConfig): ConfigSummary {
+  const providers = [...(config.providers ?? [])]
+    .sort((a, b) => (a.priority ?? 0) - (b.priority ?? 0))
+    .map((p) => `${p.id ?? "?"} (${p.model ?? "default model"})`);
+  return { providers, channels: CHANNEL_KEYS.filter((key) => config[key]) };
+}
+
+type OAuthStatuses = Awaited<ReturnType<typeof getAllOAuthStatus>>;
+
+export function authLines(oauth: OAuthStatuses, secrets: SecretsConfig | null, now: number = Date.now()): AuthLine[] {
+  const lines: AuthLine[] = [];
+  for (const [provider, status] of Object.entries(oauth)) {
+    if (!status.authenticated) {
+      lines.push({ label: provider, detail: "not signed in" });
+      continue;
+    }
+    const parts = ["signed in (OAuth)"];
+    if (status.email) parts.push(status.email);
+    if (status.expiresAt) parts.push(`expires ${new Date(status.expiresAt).toISOString()}`);
+    if (status.refreshable !== false) parts.push("renews itself");
+    const warning = oauthSessionReminder(provider, status.expiresAt, status.refreshable !== false, now) ?? undefined;
+    lines.push({ label: provider, detail: parts.join(", "), warning });
+  }
+  if (secrets?.anthropic?.token) {
+    const expires = secrets.anthropic.tokenExpiresAt;
+    lines.push({
+      label: "anthropic",
+      detail: expires ? `setup-token, expires ${expires}` : "setup-token",
+      warning: setupTokenReminder(expires, now) ?? undefined,
+    });
+  }
+  return lines;
+}
+
+export interface StatusOptions {
+  configPath: string;
+  composeFile: string;
+  exec?: DockerExec;
+  /** Defaults to fetch, trusting the gateway's local CA under TLS */
+  fetchImpl?: typeof fetch;
+  oauth?: () => Promise<OAuthStatuses>;
+  env?: Record<string, string | undefined>;
+}
+
+export async function collectStatus(options: StatusOptions): Promise<StatusReport> {
+  const configPath = resolve(options.configPath);
+  const composePath = resolve(options.composeFile);
+
+  let config: RawAppConfig | null = null;
+  try {
+    config = (parse(readFileSync(configPath, "utf-8")) as RawAppConfig | null) ?? null;
+  } catch {
+    config = null;
+  }
+
+  const composeYaml = existsSync(composePath) ? readFileSync(composePath, "utf-8") : undefined;
+  const container = composeYaml
+    ? composeContainers(composePath, options.exec ?? dockerExec(dirname(composePath)))
+    : null;
+  const secrets = await loadSecrets(configPath).catch(() => null);
+  const ca = gatewayCa(config, dirname(configPath));
+  const health = await checkHealth(
+    healthUrl(config, composeYaml),
+    options.fetchImpl ?? (ca ? fetchWithCa(ca) : fetch),
+    Date.now,
+    gatewayToken(config, options.env ?? process.env, secrets),
+  );
+  const auth = authLines(await (options.oauth ?? getAllOAuthStatus)(), secrets);
+
+  const running = container?.kind === "ok"
+    ? container.containers.filter((c) => c.state === "running" && c.name).map((c) => c.name!)
+    : [];
+  const usage = composeYaml ? containerUsage(running, options.exec ?? dockerExec(dirname(composePath))) : null;
+  const disk = diskUsage(dirname(configPath), config?.workspace, config?.memorySearch?.store?.path);
+  const logs = container?.kind === "ok" && container.containers.length > 0
+    ? botLogs(composePath, options.exec ?? dockerExec(dirname(composePath)))
+    : null;
+  const startup = logs === null ? null : setupChecklist(logs, Object.keys(config ?? {}));
+
+  return { configPath, config: config ? summarizeConfig(config) : null, container, health, auth, usage, disk, startup };
+}
+
+/** The report as plain lines, ready to print */
+export function formatStatus(report: StatusReport): string[] {
+  const lines: string[] = [];
+
+  lines.push("Container");
+  if (!report.container) {
+    lines.push("  no docker-compose.yml here (native install, or pass -f)");
+  } else if (report.container.kind === "unavailable") {
+    lines.push(`  docker compose ps failed: ${report.container.reason}`);
+  } else if (report.container.containers.length === 0) {
+    lines.push("  not created (run: docker compose up -d)");
+  } else {
+    for (const c of report.container.containers) {
+      lines.push(`  ${c.service.padEnd(12)} ${c.state}${c.health ? ` (${c.health})` : ""}${c.status ? `  ${c.status}` : ""}`);
+    }
+  }
+
+  if (report.usage?.kind === "unavailable") {
+    lines.push(`  docker stats failed: ${report.usage.reason}`);
+  } else if (report.usage && report.usage.containers.length > 0) {
+    lines.push("", "Resources");
+    for (const u of report.usage.containers) {
+      const memory = u.memoryPercent ? `${u.memory} (${u.memoryPercent})` : u.memory;
+      lines.push(`  ${u.name.padEnd(12)} cpu ${u.cpu}  memory ${memory}`);
+    }
+  }
+
+  lines.push("", "Gateway");
+  if (report.health.kind === "ok") {
+    const version = report.health.version ? `, version ${report.health.version}` : "";
+    lines.push(`  ${report.health.url}: OK in ${report.health.latencyMs} ms${version}`);
+  } else {
+    lines.push(`  ${report.health.url}: ${report.health.message}`);
+  }
+
+  if (report.startup) {
+    lines.push("", "Startup (latest start, from the logs)");
+    lines.push(...formatChecklist(report.startup));
+  }
+
+  lines.push("", `Config (${report.configPath})`);
+  if (!report.config) {
+    lines.push("  app.yaml not found or unreadable (run: owliabot onboard)");
+  } else {
+    lines.push(`  providers: ${report.config.providers.join(" → ") || "none"}`);
+    lines.push(`  channels:  ${report.config.channels.join(", ") || "none"}`);
+  }
+
+  lines.push("", "Disk");
+  lines.push(
+    report.disk.workspace
+      ? `  workspace  ${formatBytes(report.disk.workspace.bytes).padStart(9)}  ${report.disk.workspace.path}`
+      : "  workspace  not found on this host",
+  );
+  for (const store of report.disk.stores) {
+    lines.push(`  database   ${formatBytes(store.bytes).padStart(9)}  ${store.path}`);
+  }
+
+  lines.push("", "Auth");
+  for (const a of report.auth) {
+    lines.push(`  ${a.label}: ${a.detail}`);
+    if (a.warning) lines.push(`    ! ${a.warning}`);
+  }
+  return lines;
+}
@@ -0,0 +1,150 @@
+/**
+ * Resource usage for `owliabot status`: a one-shot `docker stats` of the
+ * running containers, and how much disk the workspace and the SQLite
+ * stores (memory index, gateway databases) take, so a ballooning memory
+ * store shows up before the disk fills.
+ */
+
+import { existsSync, lstatSync, readdirSync } from "node:fs";
+import { dirname, isAbsolute, join, resolve } from "node:path";
+import type { DockerExec } from "../upgrade/index.js";
+
+export interface ContainerUsage {
+  name: string;
+  /** "1.25%" */
+  cpu: string;
+  /** "120MiB / 1.9GiB" */
+  memory: string;
+  /** "6.17%" */
+  memoryPercent?: string;
+}
+
+export type UsageReport =
+  | { kind: "ok"; containers: ContainerUsage[] }
+  | { kind: "unavailable"; reason: string };
+
+export interface DiskUsage {
+  path: string;
+  bytes: number;
+}
+
+export interface DiskReport {
+  /** null when the workspace dir can't be found on this host */
+  workspace: DiskUsage | null;
+  /** One entry per database, its -wal / -shm files included */
+  stores: DiskUsage[];
+}
+
+const STORE_FILE = /\.(sqlite|db)(-wal|-shm|-journal)?$/;
+
+/** `docker stats --format '{{json .}}'` prints one object per line */
+export function parseDockerStats(out: string): ContainerUsage[] {
+  return out
+    .split("\n")
+    .map((line) => line.trim())
+    .filter(Boolean)
+    .map((line) => JSON.parse(line) as Record<string, unknown>)
+    .map((row) => ({
+      name: String(row.Name ?? row.Container ?? "?"),
+      cpu: String(row.CPUPerc ?? "?"),
+      memory: String(row.MemUsage ?? "?"),
+      memoryPercent: row.MemPerc ? String(row.MemPerc) : undefined,
+    }));
+}
+
+export function containerUsage(names: string[], exec: DockerExec): UsageReport {
+  if (names.length === 0) return { kind: "ok", containers: [] };
+  try {
+    return { kind: "ok", containers: parseDockerStats(exec(["stats", "--no-stream", "--format", "{{json .}}", ...names])) };
+  } catch (err) {
+    return { kind: "unavailable", reason: (err as Error).message.split("\n")[0] };
+  }
+}
+
+/** Total size of the files under `path` (symlinks are not followed) */
+export function directorySize(path: string): number {
+  let total = 0;
+  const stack = [path];
+  while (stack.length > 0) {
+    const p = stack.pop()!;
+    let st;
+    try {
+      st = lstatSync(p);
+    } catch {
+      continue;
+    }
+    if (st.isDirectory()) {
+      try {
+        for (const entry of readdirSync(p)) stack.push(join(p, entry));
+      } catch {
+        // unreadable dir: count what we can
+      }
+    } else if (st.isFile()) {
+      total += st.size;
+    }
+  }
+  return total;
+}
+
+/** SQLite files directly in `dir`, with journal files added to their database */
+function storesIn(dir: string): DiskUsage[] {
+  if (!existsSync(dir)) return [];
+  const sizes = new Map<string, number>();
+  for (const name of readdirSync(dir)) {
+    if (!STORE_FILE.test(name)) continue;
+    const db = join(dir, name.replace(/-(wal|shm|journal)$/, ""));
+    let size = 0;
+    try {
+      size = lstatSync(join(dir, name)).size;
+    } catch {
+      continue;
+    }
+    sizes.set(db, (sizes.get(db) ?? 0) + size);
+  }
+  return [...sizes].map(([path, bytes]) => ({ path, bytes }));
+}
+
+/**
+ * The workspace dir on this host. app.yaml may hold a container path
+ * (/app/workspace); the bind-mounted copy is then <configDir>/workspace.
+ */
+export function hostWorkspacePath(configDir: string, workspace: unknown): string | null {
+  const candidates = [
+    typeof workspace === "string" && workspace.trim()
+      ? (isAbsolute(workspace) ? workspace : resolve(configDir, workspace))
+      : null,
+    join(configDir, "workspace"),
+  ];
+  return candidates.find((p): p is string => p !== null && existsSync(p)) ?? null;
+}
+
+/**
+ * Disk used by the workspace, the memory index (memorySearch.store.path,
+ * default <workspace>/memory) and the gateway databases (<configDir>/gateway).
+ */
+export function diskUsage(configDir: string, workspace: unknown, memoryStorePath?: unknown): DiskReport {
+  const workspacePath = hostWorkspacePath(configDir, workspace);
+  const memoryDirs = new Set<string>();
+  if (workspacePath) memoryDirs.add(join(workspacePath, "memory"));
+  if (typeof memoryStorePath === "string") {
+    const storePath = workspacePath ? memoryStorePath.replace("{workspace}", workspacePath) : memoryStorePath;
+    if (isAbsolute(storePath) && !storePath.includes("{workspace}")) memoryDirs.add(dirname(storePath));
+  }
+  const stores = [...memoryDirs, join(configDir, "gateway")].flatMap(storesIn);
+  return {
+    workspace: workspacePath ? { path: workspacePath, bytes: directorySize(workspacePath) } : null,
+    stores: stores.sort((a, b) => b.bytes - a.bytes),
+  };
+}
+
+/** 1536 -> "1.5 KB" */
+export function formatBytes(bytes: number): string {
+  const units = ["B", "KB", "MB", "GB", "TB"];
+  let value = bytes;
+  let unit = 0;
+  while (value >= 1024 && unit < units.length - 1) {
+    value /= 1024;
+    unit++;
+  }
+  return unit === 0 ? `${value} B` : `${value.toFixed(1)} ${units[unit]}`;
+}
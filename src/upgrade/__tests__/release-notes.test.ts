@@ -0,0 +1,63 @@
+import { describe, it, expect } from "vitest";
+import {
+  compareVersions,
+  fetchReleases,
+  formatReleaseNotes,
+  parseVersion,
+  releaseNotesFor,
+  selectReleaseNotes,
+  type ReleaseNote,
+} from "../release-notes.js";
+import type { DockerExec } from "../index.js";
+
+function release(tag: string, prerelease = false): ReleaseNote {
+  return { tag, name: tag, body: `Changes in ${tag}`, url: `https://github.com/owliabot/owliabot/releases/tag/${tag}`, prerelease };
+}
+
+const RELEASES = [release("v1.4.0-rc.1", true), release("v1.3.0"), release("v1.2.1"), release("v1.2.0"), release("v1.1.0")];
+
+describe("release notes", () => {
+  it("orders versions, prereleases before their release", () => {
+    expect(parseVersion("latest")).toBeNull();
+    expect(compareVersions(parseVersion("v1.2.0")!, parseVersion("1.10.0")!)).toBeLessThan(0);
+    expect(compareVersions(parseVersion("1.4.0-rc.1")!, parseVersion("1.4.0")!)).toBeLessThan(0);
+  });
+
+  it("picks the releases between the running version and the new one", () => {
+    const picked = selectReleaseNotes(RELEASES, { image: "ghcr.io/owliabot/owliabot:latest", current: "1.2.0", target: "1.3.0" });
+    expect(picked.map((r) => r.tag)).toEqual(["v1.3.0", "v1.2.1"]);
+  });
+
+  it("falls back to the tag, or the newest full release for latest", () => {
+    expect(selectReleaseNotes(RELEASES, { image: "ghcr.io/owliabot/owliabot:1.2.1" }).map((r) => r.tag)).toEqual(["v1.2.1"]);
+    expect(selectReleaseNotes(RELEASES, { image: "ghcr.io/owliabot/owliabot:latest", current: "1.2.1" }).map((r) => r.tag))
+      .toEqual(["v1.3.0"]);
+    expect(selectReleaseNotes(RELEASES, { image: "ghcr.io/owliabot/owliabot:develop" })).toEqual([]);
+  });
+
+  it("reads versions from image labels and fetches GitHub releases", async () => {
+    const exec: DockerExec = (args) => {
+      if (args[0] === "image") return JSON.stringify({ "org.opencontainers.image.version": "1.1.0" });
+      return JSON.stringify({ "linux/amd64": { config: { Labels: { "org.opencontainers.image.version": "1.2.1" } } } });
+    };
+    const fetchImpl = (async () => new Response(JSON.stringify([
+      ...RELEASES.map((r) => ({ tag_name: r.tag, name: r.name, body: r.body, html_url: r.url, prerelease: r.prerelease })),
+      { tag_name: "v9.0.0", draft: true },
+    ]))) as typeof fetch;
+    expect((await fetchReleases(fetchImpl)).map((r) => r.tag)).not.toContain("v9.0.0");
+    const notes = await releaseNotesFor("ghcr.io/owliabot/owliabot:latest", "sha256:" + "c".repeat(64), { exec, fetchImpl });
+    expect(notes.map((r) => r.tag)).toEqual(["v1.2.1", "v1.2.0"]);
+    expect(await releaseNotesFor("registry.example.com/bot:latest", "sha256:x", { exec, fetchImpl })).toEqual([]);
+  });
+
+  it("renders a section per release", () => {
+    const text = formatReleaseNotes([{ ...release("v1.3.0"), name: "Spring", publishedAt: "2026-09-01T10:00:00Z" }]);
+    expect(text).toBe([
+      "── Spring [v1.3.0] (2026-09-01) ──",
+      "",
+      "Changes in v1.3.0",
+      "",
+      "https://github.com/owliabot/owliabot/releases/tag/v1.3.0",
+    ].join("\n"));
+  });
+});
@@ -0,0 +1,100 @@
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import { createHash } from "node:crypto";
+import { mkdirSync, mkdtempSync, rmSync } from "node:fs";
+import { join } from "node:path";
+import { tmpdir } from "node:os";
+import { detectCliInstall, isNewerVersion, runSelfUpdate, verifyTarball, type NpmExec } from "../self-update.js";
+
+const TARBALL = Buffer.from("fake tarball");
+const INTEGRITY = `sha512-${createHash("sha512").update(TARBALL).digest("base64")}`;
+
+/** npm registry serving `version` with the given integrity */
+function fakeRegistry(version: string, integrity = INTEGRITY): typeof fetch {
+  return (async (url: string | URL | Request) => {
+    const href = String(url);
+    if (href.endsWith("/latest")) {
+      return new Response(JSON.stringify({ version, dist: { tarball: "https://registry.example/owliabot.tgz", integrity } }));
+    }
+    return new Response(TARBALL);
+  }) as typeof fetch;
+}
+
+describe("self-update", () => {
+  let dir: string;
+
+  beforeEach(() => {
+    dir = mkdtempSync(join(tmpdir(), "owliabot-self-update-test-"));
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+  });
+
+  it("compares versions and checks the tarball's checksum", () => {
+    expect(isNewerVersion("0.3.0", "0.2.0")).toBe(true);
+    expect(isNewerVersion("0.2.0", "0.2.0")).toBe(false);
+    expect(() => verifyTarball(TARBALL, { integrity: INTEGRITY })).not.toThrow();
+    expect(() => verifyTarball(Buffer.from("tampered"), { integrity: INTEGRITY })).toThrow(/checksum mismatch/);
+    expect(() => verifyTarball(TARBALL, {})).toThrow(/no checksum/);
+  });
+
+  it("tells installs apart", () => {
+    const npmRoot = join(dir, "lib", "node_modules");
+    const globalDir = join(npmRoot, "owliabot");
+    mkdirSync(globalDir, { recursive: true });
+    const npm: NpmExec = () => `${npmRoot}\n`;
+    expect(detectCliInstall(globalDir, { npm, inDocker: false }).kind).toBe("global");
+    expect(detectCliInstall(join(dir, "_npx", "abc", "node_modules", "owliabot"), { npm, inDocker: false }).kind).toBe("npx");
+    const checkout = join(dir, "checkout");
+    mkdirSync(join(checkout, ".git"), { recursive: true });
+    expect(detectCliInstall(checkout, { npm, inDocker: false }).kind).toBe("source");
+    expect(detectCliInstall(globalDir, { npm, inDocker: true }).kind).toBe("docker");
+  });
+
+  it("installs the verified tarball over a global install", async () => {
+    const calls: string[][] = [];
+    const result = await runSelfUpdate({
+      name: "owliabot",
+      current: "0.2.0",
+      packageDir: dir,
+      install: { kind: "global", packageDir: dir },
+      fetchImpl: fakeRegistry("0.3.0"),
+      npm: (args) => {
+        calls.push(args);
+        return "";
+      },
+      log: () => {},
+    });
+    expect(result).toMatchObject({ latest: "0.3.0", updated: true });
+    expect(calls[0].slice(0, 2)).toEqual(["install", "-g"]);
+    expect(calls[0][2]).toMatch(/owliabot-0\.3\.0\.tgz$/);
+  });
+
+  it("refuses a tarball that doesn't match, and leaves other installs alone", async () => {
+    const npm: NpmExec = () => {
+      throw new Error("npm should not run");
+    };
+    await expect(runSelfUpdate({
+      name: "owliabot",
+      current: "0.2.0",
+      packageDir: dir,
+      install: { kind: "global", packageDir: dir },
+      fetchImpl: fakeRegistry("0.3.0", "sha512-AAAA"),
+      npm,
+      log: () => {},
+    })).rejects.toThrow(/checksum mismatch/);
+
+    const logs: string[] = [];
+    const result = await runSelfUpdate({
+      name: "owliabot",
+      current: "0.2.0",
+      packageDir: dir,
+      install: { kind: "npx", packageDir: dir },
+      fetchImpl: fakeRegistry("0.3.0"),
+      npm,
+      log: (m) => logs.push(m),
+    });
+    expect(result.updated).toBe(false);
+    expect(logs.at(-1)).toContain("npx owliabot@latest");
+  });
+});
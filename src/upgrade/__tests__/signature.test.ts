@@ -0,0 +1,66 @@
+import { describe, it, expect } from "vitest";
+import {
+  PUBLISHER_IDENTITY,
+  cosignVerifyArgs,
+  formatSignatureWarning,
+  verifyImageSignature,
+  type CosignExec,
+} from "../signature.js";
+
+const REF = "ghcr.io/owliabot/owliabot@sha256:" + "b".repeat(64);
+
+describe("verifyImageSignature", () => {
+  it("checks the release workflow's identity, or a key when given", () => {
+    expect(cosignVerifyArgs(REF)).toEqual([
+      "verify",
+      "--certificate-identity-regexp", PUBLISHER_IDENTITY,
+      "--certificate-oidc-issuer", "https://token.actions.githubusercontent.com",
+      "--output", "json",
+      REF,
+    ]);
+    expect(cosignVerifyArgs(REF, "cosign.pub")).toEqual(["verify", "--key", "cosign.pub", "--output", "json", REF]);
+    expect(new RegExp(PUBLISHER_IDENTITY).test(
+      "https://github.com/owliabot/owliabot/.github/workflows/docker.yml@refs/heads/main",
+    )).toBe(true);
+    expect(new RegExp(PUBLISHER_IDENTITY).test(
+      "https://github.com/someone/owliabot/.github/workflows/docker.yml@refs/heads/main",
+    )).toBe(false);
+  });
+
+  it("reports the signer of a verified image", () => {
+    const subject = "https://github.com/owliabot/owliabot/.github/workflows/docker.yml@refs/tags/v1.2.0";
+    const exec: CosignExec = () => JSON.stringify([{ critical: {}, optional: { Subject: subject } }]);
+    expect(verifyImageSignature(REF, { exec })).toEqual({ kind: "verified", ref: REF, signer: subject });
+
+    const digest = "sha256:" + "c".repeat(64);
+    const withDigest: CosignExec = () =>
+      JSON.stringify([{ critical: { image: { "docker-manifest-digest": digest } }, optional: { Subject: subject } }]);
+    expect(verifyImageSignature(REF, { exec: withDigest })).toMatchObject({ kind: "verified", digest });
+  });
+
+  it("keeps cosign's last error line when verification fails", () => {
+    const exec: CosignExec = () => {
+      throw Object.assign(new Error("Command failed: cosign verify"), {
+        stderr: "Error: no matching signatures:\nnone of the expected identities matched what was in the certificate\n",
+      });
+    };
+    const check = verifyImageSignature(REF, { exec });
+    expect(check).toEqual({
+      kind: "failed",
+      ref: REF,
+      reason: "none of the expected identities matched what was in the certificate",
+    });
+    if (check.kind === "verified") throw new Error("unreachable");
+    const warning = formatSignatureWarning(check).join("\n");
+    expect(warning).toContain("SIGNATURE CHECK FAILED");
+    expect(warning).toContain("not signed by the OwliaBot release workflow");
+    expect(warning).toContain("OWLIABOT_COSIGN_KEY");
+  });
+
+  it("says so when cosign is missing", () => {
+    const exec: CosignExec = () => {
+      throw Object.assign(new Error("spawnSync cosign ENOENT"), { code: "ENOENT" });
+    };
+    expect(verifyImageSignature(REF, { exec })).toMatchObject({ kind: "unavailable" });
+  });
+});
@@ -0,0 +1,239 @@
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import { mkdtempSync, rmSync, writeFileSync } from "node:fs";
+import { join } from "node:path";
+import { tmpdir } from "node:os";
+import {
+  composeImage,
+  composePlatform,
+  detectImageUpdate,
+  imageRegistry,
+  imageRepository,
+  manifestPlatforms,
+  platformAvailable,
+  runUpgrade,
+  type DockerExec,
+} from "../index.js";
+
+const IMAGE = "ghcr.io/owliabot/owliabot:latest";
+const OLD = "sha256:" + "a".repeat(64);
+const NEW = "sha256:" + "b".repeat(64);
+
+/** Fake docker: local digest changes to `remote` once `compose pull` ran */
+function fakeDocker(state: { local?: string; remote?: string; platforms?: string[]; server?: string }) {
+  const calls: string[] = [];
+  const exec: DockerExec = (args) => {
+    calls.push(args.join(" "));
+    if (args[0] === "image") {
+      if (!state.local) throw new Error("No such image");
+      return JSON.stringify([`ghcr.io/owliabot/owliabot@${state.local}`]);
+    }
+    if (args[0] === "buildx") {
+      if (!state.remote) throw new Error("docker: 'buildx' is not a docker command.");
+      const manifests = (state.platforms ?? []).map((p) => {
+        const [os, architecture, variant] = p.split("/");
+        return { platform: { os, architecture, ...(variant && { variant }) } };
+      });
+      return JSON.stringify({ digest: state.remote, ...(manifests.length > 0 && { manifests }) });
+    }
+    if (args[0] === "version") return `${state.server ?? ""}\n`;
+    if (args.join(" ").includes("pull")) state.local = state.remote ?? state.local;
+    return "";
+  };
+  return { exec, calls };
+}
+
+describe("upgrade", () => {
+  let dir: string;
+  let composePath: string;
+
+  beforeEach(() => {
+    dir = mkdtempSync(join(tmpdir(), "owliabot-upgrade-"));
+    composePath = join(dir, "docker-compose.yml");
+    writeFileSync(composePath, `services:\n  owliabot:\n    image: \${OWLIABOT_IMAGE:-${IMAGE}}\n`);
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+  });
+
+  it("reads the bot image from compose, honouring OWLIABOT_IMAGE", () => {
+    const yaml = `services:\n  owliabot:\n    image: \${OWLIABOT_IMAGE:-${IMAGE}}\n`;
+    expect(composeImage(yaml, {})).toBe(IMAGE);
+    expect(composeImage(yaml, { OWLIABOT_IMAGE: "ghcr.io/owliabot/owliabot:v1.2.0" })).toBe("ghcr.io/owliabot/owliabot:v1.2.0");
+    expect(composeImage("services:\n  other:\n    image: x\n", {})).toBeNull();
+    expect(imageRepository(IMAGE)).toBe("ghcr.io/owliabot/owliabot");
+    expect(imageRepository(`ghcr.io/owliabot/owliabot@${OLD}`)).toBe("ghcr.io/owliabot/owliabot");
+  });
+
+  it("compares local and registry digests", () => {
+    expect(detectImageUpdate(IMAGE, fakeDocker({ local: OLD, remote: OLD }).exec)).toEqual({
+      kind: "up-to-date",
+      digest: OLD,
+      platforms: [],
+    });
+    expect(detectImageUpdate(IMAGE, fakeDocker({ local: OLD, remote: NEW, platforms: ["linux/amd64"] }).exec)).toEqual({
+      kind: "available",
+      current: OLD,
+      latest: NEW,
+      platforms: ["linux/amd64"],
+    });
+    expect(detectImageUpdate(IMAGE, fakeDocker({ local: OLD }).exec)).toMatchObject({ kind: "unknown", current: OLD });
+  });
+
+  it("says which registry to log in to when a private image needs auth", () => {
+    const exec: DockerExec = (args) => {
+      if (args[0] === "buildx") throw new Error("ERROR: unexpected status: 401 Unauthorized\nmore");
+      throw new Error("No such image");
+    };
+    expect(imageRegistry("registry.example.com:5000/team/bot:1")).toBe("registry.example.com:5000");
+    expect(imageRegistry("team/bot:1")).toBe("docker.io");
+    expect(detectImageUpdate("registry.example.com:5000/team/bot:1", exec)).toEqual({
+      kind: "unknown",
+      current: undefined,
+      reason: "not logged in to registry.example.com:5000; run: docker login registry.example.com:5000",
+    });
+  });
+
+  it("pulls nothing when the new digest's signature doesn't verify", async () => {
+    const { exec, calls } = fakeDocker({ local: OLD, remote: NEW });
+    const verified: string[][] = [];
+    const warnings: string[] = [];
+    const result = await runUpgrade(composePath, {
+      exec,
+      env: {},
+      verifySignature: true,
+      cosign: (args) => {
+        verified.push(args);
+        throw Object.assign(new Error("cosign failed"), { stderr: "Error: no signatures found\n" });
+      },
+      log: () => {},
+      warn: (m) => warnings.push(m),
+    });
+    expect(verified[0].at(-1)).toBe(`ghcr.io/owliabot/owliabot@${NEW}`);
+    expect(result).toMatchObject({ upgraded: false, signature: { kind: "failed", reason: "Error: no signatures found" } });
+    expect(warnings[0]).toContain("SIGNATURE CHECK FAILED");
+    expect(calls.some((c) => c.startsWith("compose"))).toBe(false);
+
+    const signed = fakeDocker({ local: OLD, remote: NEW });
+    const ok = await runUpgrade(composePath, { exec: signed.exec, env: {}, verifySignature: true, cosign: () => "[]", log: () => {} });
+    expect(ok).toMatchObject({ upgraded: true, signature: { kind: "verified" } });
+  });
+
+  it("pulls the verified digest and tags it, instead of pulling the tag again", async () => {
+    const { exec, calls } = fakeDocker({ local: OLD, remote: NEW });
+    const result = await runUpgrade(composePath, { exec, env: {}, verifySignature: true, cosign: () => "[]", log: () => {} });
+    expect(result).toMatchObject({ upgraded: true, current: NEW });
+    expect(calls).toContain(`pull ghcr.io/owliabot/owliabot@${NEW}`);
+    expect(calls).toContain(`tag ghcr.io/owliabot/owliabot@${NEW} ${IMAGE}`);
+    expect(calls).not.toContain(`compose -f ${composePath} pull`);
+  });
+
+  it("pins an unknown registry digest to the one cosign verified, and pulls nothing without one", async () => {
+    const cosignOut = JSON.stringify([{ critical: { image: { "docker-manifest-digest": NEW } }, optional: {} }]);
+    const { exec, calls } = fakeDocker({ local: OLD });
+    const verified: string[] = [];
+    const result = await runUpgrade(composePath, {
+      exec,
+      env: {},
+      verifySignature: true,
+      cosign: (args) => {
+        verified.push(args.at(-1)!);
+        return cosignOut;
+      },
+      log: () => {},
+    });
+    expect(verified).toEqual([IMAGE]);
+    expect(result).toMatchObject({ signature: { kind: "verified", digest: NEW } });
+    expect(calls).toContain(`pull ghcr.io/owliabot/owliabot@${NEW}`);
+
+    const bare = fakeDocker({ local: OLD });
+    const warnings: string[] = [];
+    const refused = await runUpgrade(composePath, {
+      exec: bare.exec,
+      env: {},
+      verifySignature: true,
+      cosign: () => "[]",
+      log: () => {},
+      warn: (m) => warnings.push(m),
+    });
+    expect(refused).toMatchObject({ upgraded: false, signature: { kind: "failed" } });
+    expect(warnings.join("\n")).toContain("did not report which digest");
+    expect(bare.calls.some((c) => c.includes("pull"))).toBe(false);
+  });
+
+  it("pulls and restarts when a newer image is available", async () => {
+    const { exec, calls } = fakeDocker({ local: OLD, remote: NEW });
+    const result = await runUpgrade(composePath, { exec, env: {}, log: () => {} });
+    expect(result).toMatchObject({ upgraded: true, previous: OLD, current: NEW });
+    expect(calls).toContain(`compose -f ${composePath} pull`);
+    expect(calls.at(-1)).toBe(`compose -f ${composePath} up -d`);
+  });
+
+  it("asks before pulling a newer image, and leaves it alone when declined", async () => {
+    const { exec, calls } = fakeDocker({ local: OLD, remote: NEW });
+    const asked: string[] = [];
+    const result = await runUpgrade(composePath, {
+      exec,
+      env: {},
+      log: () => {},
+      confirm: async (image, update) => {
+        asked.push(`${image} ${update.latest}`);
+        return false;
+      },
+    });
+    expect(asked).toEqual([`${IMAGE} ${NEW}`]);
+    expect(result).toMatchObject({ upgraded: false, declined: true, previous: OLD });
+    expect(calls.some((c) => c.startsWith("compose"))).toBe(false);
+  });
+
+  it("leaves an up-to-date install alone, and only reports with --check", async () => {
+    const upToDate = fakeDocker({ local: OLD, remote: OLD });
+    expect((await runUpgrade(composePath, { exec: upToDate.exec, env: {}, log: () => {} })).upgraded).toBe(false);
+    expect(upToDate.calls.some((c) => c.startsWith("compose"))).toBe(false);
+
+    const check = fakeDocker({ local: OLD, remote: NEW });
+    expect((await runUpgrade(composePath, { check: true, exec: check.exec, env: {}, log: () => {} })).upgraded).toBe(false);
+    expect(check.calls.some((c) => c.startsWith("compose"))).toBe(false);
+  });
+
+  it("pulls when the registry can't be checked, restarting only if the image changed", async () => {
+    const { exec, calls } = fakeDocker({ local: OLD });
+    const result = await runUpgrade(composePath, { exec, env: {}, log: () => {} });
+    expect(result.upgraded).toBe(false);
+    expect(calls).toContain(`compose -f ${composePath} pull`);
+    expect(calls).not.toContain(`compose -f ${composePath} up -d`);
+  });
+
+  it("lists manifest platforms without the attestation entries", () => {
+    expect(manifestPlatforms({
+      manifests: [
+        { platform: { os: "linux", architecture: "amd64" } },
+        { platform: { os: "linux", architecture: "arm", variant: "v7" } },
+        { platform: { os: "unknown", architecture: "unknown" } },
+      ],
+    })).toEqual(["linux/amd64", "linux/arm/v7"]);
+    expect(platformAvailable(["linux/arm/v7"], "linux/arm")).toBe(true);
+    expect(platformAvailable(["linux/amd64"], "linux/arm64")).toBe(false);
+    expect(composePlatform("services:\n  owliabot:\n    platform: linux/amd64\n", {})).toBe("linux/amd64");
+  });
+
+  it("doesn't pull a tag that has no build for the engine's platform", async () => {
+    const logs: string[] = [];
+    const { exec, calls } = fakeDocker({ local: OLD, remote: NEW, platforms: ["linux/amd64"], server: "linux/arm64" });
+    const result = await runUpgrade(composePath, { exec, env: {}, log: (m) => logs.push(m) });
+
+    expect(result).toMatchObject({ upgraded: false, missingPlatform: "linux/arm64" });
+    expect(calls.some((c) => c.startsWith("compose"))).toBe(false);
+    expect(logs.at(-1)).toContain('add "platform: linux/amd64"');
+  });
+
+  it("follows the compose platform, and pulls anyway with --force", async () => {
+    writeFileSync(composePath, `services:\n  owliabot:\n    image: ${IMAGE}\n    platform: linux/amd64\n`);
+    const emulated = fakeDocker({ local: OLD, remote: NEW, platforms: ["linux/amd64"], server: "linux/arm64" });
+    expect((await runUpgrade(composePath, { exec: emulated.exec, env: {}, log: () => {} })).upgraded).toBe(true);
+
+    writeFileSync(composePath, `services:\n  owliabot:\n    image: ${IMAGE}\n`);
+    const forced = fakeDocker({ local: OLD, remote: NEW, platforms: ["linux/amd64"], server: "linux/arm64" });
+    expect((await runUpgrade(composePath, { force: true, exec: forced.exec, env: {}, log: () => {} })).upgraded).toBe(true);
+  });
+});
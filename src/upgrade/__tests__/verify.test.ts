@@ -0,0 +1,67 @@
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import { mkdtempSync, rmSync, writeFileSync } from "node:fs";
+import { join } from "node:path";
+import { tmpdir } from "node:os";
+import { gatewayToken } from "../../status/index.js";
+import { verifyStarted } from "../verify.js";
+
+describe("verifyStarted", () => {
+  let dir: string;
+  let composePath: string;
+
+  beforeEach(() => {
+    dir = mkdtempSync(join(tmpdir(), "owliabot-verify-"));
+    composePath = join(dir, "docker-compose.yml");
+    writeFileSync(composePath, 'services:\n  owliabot:\n    ports:\n      - "127.0.0.1:9000:8787"\n');
+  });
+
+  afterEach(() => {
+    rmSync(dir, { recursive: true, force: true });
+  });
+
+  /** A clock that advances by each sleep */
+  function fakeClock() {
+    let t = 0;
+    return { now: () => t, sleep: async (ms: number) => void (t += ms) };
+  }
+
+  it("polls /health until it answers, sending the gateway token", async () => {
+    const configPath = join(dir, "app.yaml");
+    writeFileSync(configPath, "gateway:\n  http:\n    token: gw-secret\n");
+    const seen: Array<{ url: string; token?: string }> = [];
+    let calls = 0;
+    const fetchImpl = (async (url: string, init?: RequestInit) => {
+      seen.push({ url, token: (init?.headers as Record<string, string> | undefined)?.["X-Gateway-Token"] });
+      if (++calls < 3) throw new Error("connect ECONNREFUSED");
+      return new Response(JSON.stringify({ ok: true }));
+    }) as unknown as typeof fetch;
+
+    const result = await verifyStarted(composePath, { configPath, fetchImpl, exec: () => "", ...fakeClock() });
+    expect(result).toMatchObject({ healthy: true, waitedMs: 4_000 });
+    expect(seen[0]).toEqual({ url: "http://127.0.0.1:9000/health", token: "gw-secret" });
+  });
+
+  it("gives up after the timeout and returns the bot's logs", async () => {
+    const calls: string[] = [];
+    const result = await verifyStarted(composePath, {
+      timeoutMs: 10_000,
+      fetchImpl: (async () => new Response("", { status: 503 })) as unknown as typeof fetch,
+      exec: (args) => {
+        calls.push(args.join(" "));
+        return "Error: Invalid config\n";
+      },
+      env: {},
+      ...fakeClock(),
+    });
+    expect(result).toMatchObject({ healthy: false, logs: "Error: Invalid config\n" });
+    expect(result.health).toMatchObject({ kind: "error", message: "HTTP 503" });
+    expect(calls).toEqual([`compose -f ${composePath} logs --no-color --tail 50 owliabot`]);
+  });
+
+  it("ignores ${VAR} and secrets tokens in app.yaml in favour of secrets.yaml, then the environment", () => {
+    expect(gatewayToken({ gateway: { http: { token: "${GW}" } } }, { OWLIABOT_GATEWAY_TOKEN: "env" })).toBe("env");
+    expect(gatewayToken({ gateway: { http: { token: "secrets" } } }, { OWLIABOT_GATEWAY_TOKEN: "env" }, { gateway: { token: "gw" } }))
+      .toBe("gw");
+    expect(gatewayToken(null, {})).toBeUndefined();
+  });
+});
@@ -0,0 +1,308 @@
+/**
+ * `owliabot upgrade`: update a Docker install without re-running onboarding.
+ *
+ * Reads the bot's image from docker-compose.yml (honouring OWLIABOT_IMAGE),
+ * compares the local digest with the registry's, and when they differ pulls
+ * the new image and runs `docker compose up -d`. The digest of the image
+ * that was running is reported so the previous version can be pinned again
+ * with OWLIABOT_IMAGE=<repo>@<digest> if the new one misbehaves.
+ *
+ * The registry's manifest also lists the platforms the tag was built for.
+ * When none matches the engine (an ARM host, e.g. a Raspberry Pi, and an
+ * amd64-only tag), nothing is pulled unless forced.
+ *
+ * With `verifySignature`, the digest about to be pulled must carry a valid
+ * cosign signature first (see signature.ts); otherwise nothing is pulled.
+ * A `confirm` callback gets the last word on a newer image, after e.g.
+ * showing its release notes (see release-notes.ts).
+ */
+
+import { execFileSync } from "node:child_process";
+import { readFileSync } from "node:fs";
+import { dirname, resolve } from "node:path";
+import { parse } from "yaml";
+import { containerCli, type ContainerCli } from "../logs/docker.js";
+import { formatSignatureWarning, verifyImageSignature, type CosignExec, type SignatureCheck } from "./signature.js";
+
+/** Service name onboarding gives the bot in docker-compose.yml */
+export const BOT_SERVICE = "owliabot";
+
+/**
+ * Run `docker <args>`. Captures and returns stdout, or with `inherit` shows
+ * the output (pull progress) and returns "". Throws when the command fails.
+ */
+export type DockerExec = (args: string[], opts?: { inherit?: boolean }) => string;
+
+/** DockerExec for the detected runtime (podman where there is no docker; see containerCli) */
+export function dockerExec(cwd: string, cli: ContainerCli = containerCli()): DockerExec {
+  return (args, opts = {}) => {
+    if (opts.inherit) {
+      execFileSync(cli, args, { cwd, stdio: "inherit" });
+      return "";
+    }
+    return execFileSync(cli, args, { cwd, stdio: "pipe", encoding: "utf-8", timeout: 30_000 });
+  };
+}
+
+/** `platforms` ("linux/arm64", ...) is empty for a single-platform manifest */
+export type ImageUpdate =
+  | { kind: "up-to-date"; digest: string; platforms: string[] }
+  /** `current` is undefined when the image was never pulled */
+  | { kind: "available"; current?: string; latest: string; platforms: string[] }
+  /** The registry couldn't be asked (no buildx, offline, private registry) */
+  | { kind: "unknown"; current?: string; reason: string };
+
+/** `${VAR:-default}` / `${VAR}` / plain references, resolved against env */
+function expandImageRef(ref: string, env: Record<string, string | undefined>): string {
+  return ref.replace(/\$\{(\w+)(?::?-([^}]*))?\}/g, (_, name: string, fallback?: string) => env[name] || fallback || "");
+}
+
+function botService(composeYaml: string): { image?: unknown; platform?: unknown } | undefined {
+  const doc = parse(composeYaml) as { services?: Record<string, { image?: unknown; platform?: unknown }> } | null;
+  return doc?.services?.[BOT_SERVICE];
+}
+
+/**
+ * The bot's image in a compose file, or null when the file has no
+ * `owliabot` service with an image.
+ */
+export function composeImage(
+  composeYaml: string,
+  env: Record<string, string | undefined> = process.env,
+): string | null {
+  const image = botService(composeYaml)?.image;
+  if (typeof image !== "string") return null;
+  return expandImageRef(image, env).trim() || null;
+}
+
+/** The bot service's `platform:` (e.g. linux/amd64 under emulation), or null */
+export function composePlatform(
+  composeYaml: string,
+  env: Record<string, string | undefined> = process.env,
+): string | null {
+  const platform = botService(composeYaml)?.platform;
+  if (typeof platform !== "string") return null;
+  return expandImageRef(platform, env).trim() || null;
+}
+
+interface ManifestDoc {
+  digest?: unknown;
+  manifests?: Array<{ platform?: { os?: string; architecture?: string; variant?: string } }>;
+}
+
+/**
+ * Platforms of a manifest list ("linux/amd64", "linux/arm/v7", ...). The
+ * "unknown/unknown" entries are build attestations, not images.
+ */
+export function manifestPlatforms(manifest: ManifestDoc): string[] {
+  const platforms = (manifest.manifests ?? [])
+    .map((m) => m.platform)
+    .filter((p): p is { os: string; architecture: string; variant?: string } => Boolean(p?.os && p.architecture))
+    .filter((p) => p.os !== "unknown")
+    .map((p) => [p.os, p.architecture, p.variant].filter(Boolean).join("/"));
+  return [...new Set(platforms)];
+}
+
+/** The engine's platform ("linux/arm64"), or undefined when docker can't say */
+export function dockerServerPlatform(exec: DockerExec): string | undefined {
+  try {
+    const platform = exec(["version", "--format", "{{.Server.Os}}/{{.Server.Arch}}"]).trim();
+    return /^[a-z0-9]+\/[a-z0-9_]+$/.test(platform) ? platform : undefined;
+  } catch {
+    return undefined;
+  }
+}
+
+/**
+ * Whether `platforms` has a build for `wanted`. "linux/arm" matches any arm
+ * variant, since the engine doesn't report one.
+ */
+export function platformAvailable(platforms: string[], wanted: string): boolean {
+  return platforms.some((p) => p === wanted || p.startsWith(`${wanted}/`));
+}
+
+/** Warning for a tag without a build for `wanted`, with the emulation way out when there is one */
+export function describeMissingPlatform(image: string, platforms: string[], wanted: string): string {
+  const message = `${image} has no ${wanted} build (only ${platforms.join(", ")})`;
+  if (!wanted.startsWith("linux/arm") || !platforms.includes("linux/amd64")) return message;
+  return `${message}. On an ARM host (e.g. a Raspberry Pi) it can run under emulation, slowly: `
+    + `add "platform: linux/amd64" to the ${BOT_SERVICE} service and install qemu binfmt support`;
+}
+
+/** "ghcr.io/owliabot/owliabot:latest" -> "ghcr.io/owliabot/owliabot" */
+export function imageRepository(image: string): string {
+  return image.replace(/@sha256:[0-9a-f]+$/, "").replace(/:[^:/]+$/, "");
+}
+
+/** Registry host of `image`: "registry.example.com:5000/bot:1" -> "registry.example.com:5000", "redis" -> "docker.io" */
+export function imageRegistry(image: string): string {
+  const first = image.split("/")[0];
+  const named = image.includes("/") && (first.includes(".") || first.includes(":") || first === "localhost");
+  return named ? first : "docker.io";
+}
+
+/** Digest of the local copy of `image` ("sha256:..."), or undefined */
+export function localImageDigest(image: string, exec: DockerExec): string | undefined {
+  try {
+    const out = exec(["image", "inspect", "--format", "{{json .RepoDigests}}", image]);
+    const digests = JSON.parse(out) as string[] | null;
+    const repo = imageRepository(image);
+    const match = digests?.find((d) => d.startsWith(`${repo}@`)) ?? digests?.[0];
+    return match?.split("@")[1];
+  } catch {
+    return undefined;
+  }
+}
+
+/**
+ * Compare the local digest of `image` with the registry's, and list the
+ * platforms the registry has it for.
+ */
+export function detectImageUpdate(image: string, exec: DockerExec): ImageUpdate {
+  const current = localImageDigest(image, exec);
+  let manifest: ManifestDoc;
+  try {
+    manifest = JSON.parse(exec(["buildx", "imagetools", "inspect", image, "--format", "{{json .Manifest}}"])) as ManifestDoc;
+  } catch (err) {
+    const message = (err as Error).message;
+    // Private registries answer 401/denied until `docker login` has been run
+    if (/unauthorized|denied|authentication required|\b401\b/i.test(message)) {
+      const registry = imageRegistry(image);
+      return { kind: "unknown", current, reason: `not logged in to ${registry}; run: docker login ${registry}` };
+    }
+    return { kind: "unknown", current, reason: message.split("\n")[0] };
+  }
+  const latest = typeof manifest.digest === "string" ? manifest.digest : "";
+  if (!latest.startsWith("sha256:")) return { kind: "unknown", current, reason: `unexpected digest "${latest}"` };
+  const platforms = manifestPlatforms(manifest);
+  return current === latest
+    ? { kind: "up-to-date", digest: latest, platforms }
+    : { kind: "available", current, latest, platforms };
+}
+
+/** `docker compose pull`, with docker's own progress output */
+export function pullDockerImageWithProgress(composePath: string, exec: DockerExec): void {
+  exec(["compose", "-f", composePath, "pull"], { inherit: true });
+}
+
+/**
+ * `docker pull repo@digest`, then point `image`'s tag at it. Used after a
+ * signature check, so a tag that moved since then can't get pulled instead.
+ */
+export function pullImageDigest(image: string, digest: string, platform: string | null, exec: DockerExec): void {
+  const ref = `${imageRepository(image)}@${digest}`;
+  exec(["pull", ...(platform ? ["--platform", platform] : []), ref], { inherit: true });
+  if (!image.includes("@")) exec(["tag", ref, image]);
+}
+
+/** `docker compose up -d`: recreates the containers whose image changed */
+export function startDockerCompose(composePath: string, exec: DockerExec): void {
+  exec(["compose", "-f", composePath, "up", "-d"], { inherit: true });
+}
+
+export interface UpgradeOptions {
+  /** Only report whether an update is available */
+  check?: boolean;
+  /** Pull and restart even when the digest matches (or can't be checked) */
+  force?: boolean;
+  /** Check the image's cosign signature before pulling, and pull nothing if it fails */
+  verifySignature?: boolean;
+  /** Public key to verify against instead of the release workflow's identity */
+  cosignKey?: string;
+  cosign?: CosignExec;
+  env?: Record<string, string | undefined>;
+  exec?: DockerExec;
+  log?: (message: string) => void;
+  /** For the signature warning (default: `log`) */
+  warn?: (message: string) => void;
+  /** Asked before pulling a newer image; false leaves everything as it is */
+  confirm?: (image: string, update: ImageUpdate & { kind: "available" }) => Promise<boolean>;
+}
+
+export interface UpgradeResult {
+  image: string;
+  update: ImageUpdate;
+  /** Whether the image was pulled and compose restarted */
+  upgraded: boolean;
+  /** Digest running before the upgrade, for pinning it again */
+  previous?: string;
+  /** Digest after the pull */
+  current?: string;
+  /** The engine's (or compose's) platform, when the tag has no build for it */
+  missingPlatform?: string;
+  /** Outcome of the signature check, when one was asked for */
+  signature?: SignatureCheck;
+  /** `confirm` said no */
+  declined?: boolean;
+}
+
+/**
+ * Check for a newer image and, unless `check` is set, pull it and restart
+ * compose. An unknown registry digest still pulls: the pull itself finds out.
+ * A tag without a build for this platform is only pulled with `force`.
+ * With `verifySignature`, the digest whose signature passed is pulled and
+ * tagged, so the tag can't be swapped in between.
+ */
+export async function runUpgrade(composeFile: string, options: UpgradeOptions = {}): Promise<UpgradeResult> {
+  const composePath = resolve(composeFile);
+  const exec = options.exec ?? dockerExec(dirname(composePath));
+  const log = options.log ?? ((message: string) => console.log(message));
+  const warn = options.warn ?? log;
+
+  const composeYaml = readFileSync(composePath, "utf-8");
+  const image = composeImage(composeYaml, options.env);
+  if (!image) throw new Error(`${composePath} has no "${BOT_SERVICE}" service with an image`);
+
+  const update = detectImageUpdate(image, exec);
+  if (update.kind === "up-to-date") log(`${image} is up to date (${update.digest})`);
+  else if (update.kind === "available") log(`A newer ${image} is available (${update.latest})`);
+  else log(`Could not check the registry for ${image} (${update.reason}); pulling to find out`);
+
+  const platforms = update.kind === "unknown" ? [] : update.platforms;
+  const wanted = platforms.length > 0 ? composePlatform(composeYaml, options.env) ?? dockerServerPlatform(exec) : undefined;
+  if (wanted && !platformAvailable(platforms, wanted)) {
+    log(describeMissingPlatform(image, platforms, wanted));
+    if (!options.force) {
+      const previous = update.kind === "up-to-date" ? update.digest : update.current;
+      return { image, update, upgraded: false, previous, missingPlatform: wanted };
+    }
+  }
+
+  let signature: SignatureCheck | undefined;
+  // What gets pulled when the signature was checked: the verified digest, never the tag
+  let verifiedDigest: string | undefined;
+  if (options.verifySignature && (update.kind !== "up-to-date" || options.force)) {
+    const registryDigest = update.kind === "available" ? update.latest : update.kind === "up-to-date" ? update.digest : undefined;
+    const ref = registryDigest ? `${imageRepository(image)}@${registryDigest}` : image;
+    signature = verifyImageSignature(ref, { key: options.cosignKey, exec: options.cosign });
+    // Without a registry digest the tag was verified; cosign says which digest that was
+    verifiedDigest = registryDigest ?? (signature.kind === "verified" ? signature.digest : undefined);
+    if (signature.kind === "verified" && !verifiedDigest) {
+      signature = { kind: "failed", ref, reason: "cosign did not report which digest it verified" };
+    }
+    if (signature.kind !== "verified") {
+      for (const line of formatSignatureWarning(signature, options.cosignKey)) warn(line);
+      return { image, update, upgraded: false, previous: update.kind === "up-to-date" ? update.digest : update.current, signature };
+    }
+    log(`Signature verified for ${ref} (signed by ${signature.signer})`);
+  }
+
+  const skip = options.check || (update.kind === "up-to-date" && !options.force);
+  if (skip) return { image, update, upgraded: false, previous: update.kind === "up-to-date" ? update.digest : update.current, signature };
+  if (update.kind === "available" && options.confirm && !(await options.confirm(image, update))) {
+    log("Nothing was pulled");
+    return { image, update, upgraded: false, previous: update.current, signature, declined: true };
+  }
+
+  const previous = localImageDigest(image, exec);
+  if (verifiedDigest) pullImageDigest(image, verifiedDigest, composePlatform(composeYaml, options.env), exec);
+  else pullDockerImageWithProgress(composePath, exec);
+  const current = localImageDigest(image, exec);
+  if (current && current === previous && !options.force) {
+    log(`${image} did not change; the running containers were left alone`);
+    return { image, update, upgraded: false, previous, current, signature };
+  }
+
+  startDockerCompose(composePath, exec);
+  return { image, update, upgraded: true, previous, current, signature };
+}
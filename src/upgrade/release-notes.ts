@@ -0,0 +1,171 @@
+/**
+ * Release notes for `owliabot upgrade`: before pulling a newer image, show
+ * what changed. Versions come from the images' OCI version labels (or a
+ * semver tag); the notes from the GitHub releases between the running
+ * version and the new one. Only the official image has releases to show.
+ */
+
+import { spawnSync } from "node:child_process";
+import { imageRepository, type DockerExec } from "./index.js";
+
+/** Repository whose GitHub releases describe the official image */
+export const OFFICIAL_IMAGE = "ghcr.io/owliabot/owliabot";
+
+export const RELEASES_API = "https://api.github.com/repos/owliabot/owliabot/releases";
+
+const VERSION_LABEL = "org.opencontainers.image.version";
+
+export interface ReleaseNote {
+  tag: string;
+  name: string;
+  body: string;
+  url: string;
+  prerelease: boolean;
+  publishedAt?: string;
+}
+
+type Semver = [number, number, number, string];
+
+/** "v1.2.3" / "1.2.3-rc.abc" -> parts, or null for anything else ("latest", "develop") */
+export function parseVersion(version: string | undefined): Semver | null {
+  const m = version?.trim().match(/^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?$/);
+  return m ? [Number(m[1]), Number(m[2]), Number(m[3]), m[4] ?? ""] : null;
+}
+
+/** Negative when a < b; a prerelease sorts before its release */
+export function compareVersions(a: Semver, b: Semver): number {
+  for (let i = 0; i < 3; i++) {
+    if (a[i] !== b[i]) return (a[i] as number) - (b[i] as number);
+  }
+  if (a[3] === b[3]) return 0;
+  if (!a[3]) return 1;
+  if (!b[3]) return -1;
+  return a[3] < b[3] ? -1 : 1;
+}
+
+function versionLabel(labels: unknown): string | undefined {
+  const value = (labels as Record<string, unknown> | null | undefined)?.[VERSION_LABEL];
+  return typeof value === "string" && value ? value : undefined;
+}
+
+/** Version label of the local copy of `image`, or undefined */
+export function localImageVersion(image: string, exec: DockerExec): string | undefined {
+  try {
+    return versionLabel(JSON.parse(exec(["image", "inspect", "--format", "{{json .Config.Labels}}", image])));
+  } catch {
+    return undefined;
+  }
+}
+
+/** Version label of `ref` on the registry (the first platform's, for a multi-platform image) */
+export function remoteImageVersion(ref: string, exec: DockerExec): string | undefined {
+  try {
+    const doc = JSON.parse(exec(["buildx", "imagetools", "inspect", ref, "--format", "{{json .Image}}"])) as
+      | { config?: { Labels?: unknown } }
+      | Record<string, { config?: { Labels?: unknown } }>;
+    if ("config" in doc) return versionLabel((doc as { config?: { Labels?: unknown } }).config?.Labels);
+    const first = Object.values(doc as Record<string, { config?: { Labels?: unknown } }>)[0];
+    return versionLabel(first?.config?.Labels);
+  } catch {
+    return undefined;
+  }
+}
+
+/** GitHub releases, newest first. Empty when GitHub can't be reached. */
+export async function fetchReleases(fetchImpl: typeof fetch = fetch): Promise<ReleaseNote[]> {
+  try {
+    const res = await fetchImpl(`${RELEASES_API}?per_page=30`, {
+      headers: { Accept: "application/vnd.github+json" },
+      signal: AbortSignal.timeout(10_000),
+    });
+    if (!res.ok) return [];
+    const data = (await res.json()) as Array<{
+      tag_name?: string; name?: string | null; body?: string | null; html_url?: string;
+      prerelease?: boolean; draft?: boolean; published_at?: string | null;
+    }>;
+    return data
+      .filter((r) => r.tag_name && !r.draft)
+      .map((r) => ({
+        tag: r.tag_name!,
+        name: r.name || r.tag_name!,
+        body: r.body ?? "",
+        url: r.html_url ?? "",
+        prerelease: Boolean(r.prerelease),
+        publishedAt: r.published_at ?? undefined,
+      }));
+  } catch {
+    return [];
+  }
+}
+
+/**
+ * The releases between the running version (exclusive) and the new one
+ * (inclusive), newest first. The new version is its label, else the image
+ * tag, else for `latest` the newest full release. Without a running version
+ * only the new one is returned; without a new one (e.g. `develop`), nothing.
+ */
+export function selectReleaseNotes(
+  releases: ReleaseNote[],
+  versions: { image: string; current?: string; target?: string },
+): ReleaseNote[] {
+  const tag = versions.image.match(/:([^:/@]+)$/)?.[1];
+  let target = parseVersion(versions.target) ?? parseVersion(tag);
+  if (!target && tag === "latest") {
+    const newest = releases.find((r) => !r.prerelease && parseVersion(r.tag));
+    target = newest ? parseVersion(newest.tag) : null;
+  }
+  if (!target) return [];
+  const current = parseVersion(versions.current);
+  const picked = releases.filter((r) => {
+    const v = parseVersion(r.tag);
+    if (!v || compareVersions(v, target!) > 0) return false;
+    return current ? compareVersions(v, current) > 0 : compareVersions(v, target!) === 0;
+  });
+  return picked.sort((a, b) => compareVersions(parseVersion(b.tag)!, parseVersion(a.tag)!));
+}
+
+/**
+ * Release notes for an available update of `image`, or [] when there are
+ * none to show (a custom image, a branch tag, GitHub unreachable).
+ */
+export async function releaseNotesFor(
+  image: string,
+  latestDigest: string,
+  options: { exec: DockerExec; fetchImpl?: typeof fetch },
+): Promise<ReleaseNote[]> {
+  if (imageRepository(image) !== OFFICIAL_IMAGE) return [];
+  const releases = await fetchReleases(options.fetchImpl);
+  if (releases.length === 0) return [];
+  return selectReleaseNotes(releases, {
+    image,
+    current: localImageVersion(image, options.exec),
+    target: remoteImageVersion(`${OFFICIAL_IMAGE}@${latestDigest}`, options.exec),
+  });
+}
+
+/** Plain-text rendering of `notes`, one section per release */
+export function formatReleaseNotes(notes: ReleaseNote[]): string {
+  return notes
+    .map((note) => {
+      const date = note.publishedAt ? ` (${note.publishedAt.slice(0, 10)})` : "";
+      const title = `── ${note.name}${note.name === note.tag ? "" : ` [${note.tag}]`}${date} ──`;
+      const body = note.body.replace(/\r\n/g, "\n").trim() || "(no notes)";
+      return [title, "", body, ...(note.url ? ["", note.url] : [])].join("\n");
+    })
+    .join("\n\n");
+}
+
+/**
+ * Show `text` in a scrollable pager ($PAGER, else `less`) when it doesn't
+ * fit the terminal; print it otherwise, or when no pager runs.
+ */
+export function showInPager(text: string, stream: NodeJS.WriteStream = process.stdout): void {
+  const rows = stream.rows ?? 0;
+  const lines = text.split("\n").length;
+  if (stream.isTTY && rows > 0 && lines > rows - 4) {
+    const pager = process.env.PAGER || "less -R";
+    const result = spawnSync(pager, { input: text, stdio: ["pipe", "inherit", "inherit"], shell: true });
+    if (!result.error && result.status === 0) return;
+  }
+  stream.write(`${text}\n`);
+}
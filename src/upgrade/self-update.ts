@@ -0,0 +1,177 @@
+/**
+ * `owliabot self-update`: update the CLI itself (the onboarder and the
+ * bot, outside Docker) from npm.
+ *
+ * Looks up the latest published version, downloads its tarball, checks it
+ * against the registry's integrity hash and installs it over the global
+ * install with `npm install -g <tarball>`. Installs that npm -g doesn't own
+ * (npx, a git checkout, a project dependency, a container) get the command
+ * that updates them instead.
+ */
+
+import { execFileSync } from "node:child_process";
+import { createHash } from "node:crypto";
+import { existsSync, mkdtempSync, realpathSync, rmSync, writeFileSync } from "node:fs";
+import { tmpdir } from "node:os";
+import { join, resolve } from "node:path";
+import { compareVersions, parseVersion } from "./release-notes.js";
+
+export const NPM_REGISTRY = "https://registry.npmjs.org";
+
+export interface PublishedVersion {
+  version: string;
+  tarball: string;
+  /** Subresource integrity ("sha512-<base64>"), when the registry has one */
+  integrity?: string;
+  /** Hex sha1 of the tarball (older packages only have this) */
+  shasum?: string;
+}
+
+export type CliInstall =
+  | { kind: "global"; packageDir: string }
+  | { kind: "npx"; packageDir: string }
+  | { kind: "source"; packageDir: string }
+  | { kind: "dependency"; packageDir: string }
+  | { kind: "docker"; packageDir: string };
+
+/** Run `npm <args>` and return stdout; throws when it fails */
+export type NpmExec = (args: string[]) => string;
+
+export function npmExec(): NpmExec {
+  return (args) => execFileSync("npm", args, { stdio: ["ignore", "pipe", "inherit"], encoding: "utf-8", timeout: 300_000 });
+}
+
+/** The `latest` dist-tag of `name` on the npm registry */
+export async function fetchLatestVersion(name: string, fetchImpl: typeof fetch = fetch): Promise<PublishedVersion> {
+  const res = await fetchImpl(`${NPM_REGISTRY}/${name}/latest`, {
+    headers: { Accept: "application/json" },
+    signal: AbortSignal.timeout(15_000),
+  });
+  if (!res.ok) throw new Error(`${NPM_REGISTRY} answered ${res.status} for ${name}`);
+  const doc = (await res.json()) as { version?: string; dist?: { tarball?: string; integrity?: string; shasum?: string } };
+  if (!doc.version || !doc.dist?.tarball) throw new Error(`${NPM_REGISTRY} returned no version for ${name}`);
+  return { version: doc.version, tarball: doc.dist.tarball, integrity: doc.dist.integrity, shasum: doc.dist.shasum };
+}
+
+/** Whether `latest` is a newer version than `current` */
+export function isNewerVersion(latest: string, current: string): boolean {
+  const a = parseVersion(latest);
+  const b = parseVersion(current);
+  return Boolean(a && b && compareVersions(a, b) > 0);
+}
+
+/**
+ * Throw unless `data` matches the published integrity hash (or, lacking
+ * one, the sha1 shasum). A tarball with neither is refused.
+ */
+export function verifyTarball(data: Buffer, published: Pick<PublishedVersion, "integrity" | "shasum">): void {
+  const sri = published.integrity?.split(/\s+/).find((h) => /^sha(256|384|512)-/.test(h));
+  if (sri) {
+    const [algo, expected] = [sri.slice(0, sri.indexOf("-")), sri.slice(sri.indexOf("-") + 1)];
+    const actual = createHash(algo).update(data).digest("base64");
+    if (actual !== expected) throw new Error(`checksum mismatch: expected ${sri}, got ${algo}-${actual}`);
+    return;
+  }
+  if (published.shasum) {
+    const actual = createHash("sha1").update(data).digest("hex");
+    if (actual !== published.shasum) throw new Error(`checksum mismatch: expected sha1 ${published.shasum}, got ${actual}`);
+    return;
+  }
+  throw new Error("the registry published no checksum for this version");
+}
+
+/** How the running CLI was installed, from its package directory */
+export function detectCliInstall(
+  packageDir: string,
+  options: { env?: Record<string, string | undefined>; npm?: NpmExec; inDocker?: boolean } = {},
+): CliInstall {
+  const env = options.env ?? process.env;
+  const dir = resolve(packageDir);
+  if (options.inDocker ?? (env.OWLIABOT_DOCKER === "1" || existsSync("/.dockerenv"))) return { kind: "docker", packageDir: dir };
+  if (dir.split(/[\\/]/).includes("_npx")) return { kind: "npx", packageDir: dir };
+  if (existsSync(join(dir, ".git"))) return { kind: "source", packageDir: dir };
+  try {
+    const globalRoot = (options.npm ?? npmExec())(["root", "-g"]).trim();
+    const real = (p: string) => (existsSync(p) ? realpathSync(p) : p);
+    if (globalRoot && real(dir).startsWith(real(globalRoot))) return { kind: "global", packageDir: dir };
+  } catch {
+    // no npm on PATH: not something npm -g can update
+  }
+  return { kind: "dependency", packageDir: dir };
+}
+
+/** What to run for an install self-update can't replace */
+export function manualUpdateHint(install: CliInstall, name: string): string {
+  switch (install.kind) {
+    case "npx":
+      return `npx runs a cached copy; use npx ${name}@latest`;
+    case "source":
+      return `this is a git checkout; update it with: git -C ${install.packageDir} pull && npm install && npm run build`;
+    case "docker":
+      return "this runs in a container; update the image with `owliabot upgrade` on the host";
+    case "dependency":
+      return `installed as a project dependency; run: npm install ${name}@latest`;
+    case "global":
+      return `npm install -g ${name}@latest`;
+  }
+}
+
+export interface SelfUpdateOptions {
+  name: string;
+  current: string;
+  packageDir: string;
+  /** Only report whether a newer version exists */
+  check?: boolean;
+  fetchImpl?: typeof fetch;
+  npm?: NpmExec;
+  install?: CliInstall;
+  log?: (message: string) => void;
+}
+
+export interface SelfUpdateResult {
+  current: string;
+  latest: string;
+  install: CliInstall;
+  updated: boolean;
+}
+
+/**
+ * Update the global install to the latest published version. The tarball
+ * is verified before npm sees it; npm then replaces the installed files.
+ */
+export async function runSelfUpdate(options: SelfUpdateOptions): Promise<SelfUpdateResult> {
+  const log = options.log ?? ((message: string) => console.log(message));
+  const npm = options.npm ?? npmExec();
+  const fetchImpl = options.fetchImpl ?? fetch;
+  const published = await fetchLatestVersion(options.name, fetchImpl);
+  const install = options.install ?? detectCliInstall(options.packageDir, { npm });
+  const result: SelfUpdateResult = { current: options.current, latest: published.version, install, updated: false };
+
+  if (!isNewerVersion(published.version, options.current)) {
+    log(`${options.name} ${options.current} is up to date`);
+    return result;
+  }
+  log(`${options.name} ${published.version} is available (running ${options.current})`);
+  if (options.check) return result;
+  if (install.kind !== "global") {
+    log(`Not updating in place: ${manualUpdateHint(install, options.name)}`);
+    return result;
+  }
+
+  const res = await fetchImpl(published.tarball, { signal: AbortSignal.timeout(120_000) });
+  if (!res.ok) throw new Error(`downloading ${published.tarball} failed: ${res.status}`);
+  const data = Buffer.from(await res.arrayBuffer());
+  verifyTarball(data, published);
+  log(`Downloaded and verified ${options.name}-${published.version}.tgz`);
+
+  const dir = mkdtempSync(join(tmpdir(), "owliabot-self-update-"));
+  try {
+    const file = join(dir, `${options.name}-${published.version}.tgz`);
+    writeFileSync(file, data);
+    npm(["install", "-g", file]);
+  } finally {
+    rmSync(dir, { recursive: true, force: true });
+  }
+  log(`Updated ${options.name} ${options.current} → ${published.version}`);
+  return { ...result, updated: true };
+}
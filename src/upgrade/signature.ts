@@ -0,0 +1,97 @@
+/**
+ * Optional supply-chain check before pulling: verify the image's cosign
+ * signature. Release images are signed keyless by the Docker workflow, so
+ * by default the signing certificate must name that workflow in
+ * owliabot/owliabot. A public key (OWLIABOT_COSIGN_KEY) replaces it, e.g.
+ * for a custom image signed with `cosign sign --key`.
+ */
+
+import { execFileSync } from "node:child_process";
+
+/** Certificate identity of the workflow that signs release images */
+export const PUBLISHER_IDENTITY = "^https://github\\.com/owliabot/owliabot/\\.github/workflows/docker\\.yml@";
+
+/** OIDC issuer of that workflow's signing certificate */
+export const PUBLISHER_ISSUER = "https://token.actions.githubusercontent.com";
+
+export const COSIGN_INSTALL_URL = "https://docs.sigstore.dev/cosign/system_config/installation/";
+
+/** Run `cosign <args>` and return stdout. Throws when it fails (ENOENT when missing). */
+export type CosignExec = (args: string[]) => string;
+
+export function cosignExec(): CosignExec {
+  return (args) => execFileSync("cosign", args, { stdio: ["ignore", "pipe", "pipe"], encoding: "utf-8", timeout: 60_000 });
+}
+
+export type SignatureCheck =
+  /** `digest` is the manifest digest cosign verified, when it reported one */
+  | { kind: "verified"; ref: string; signer: string; digest?: string }
+  | { kind: "failed"; ref: string; reason: string }
+  /** cosign isn't installed, so nothing was checked */
+  | { kind: "unavailable"; ref: string; reason: string };
+
+/** `cosign verify` arguments for `ref`: against `key`, or the publisher's workflow identity */
+export function cosignVerifyArgs(ref: string, key?: string): string[] {
+  const trust = key
+    ? ["--key", key]
+    : ["--certificate-identity-regexp", PUBLISHER_IDENTITY, "--certificate-oidc-issuer", PUBLISHER_ISSUER];
+  return ["verify", ...trust, "--output", "json", ref];
+}
+
+/** The last non-empty line of cosign's stderr (or the error message) */
+function failureReason(err: unknown): string {
+  const e = err as Error & { stderr?: string | Buffer };
+  const text = String(e.stderr || e.message || err);
+  return text.split("\n").map((l) => l.trim()).filter(Boolean).at(-1) ?? "verification failed";
+}
+
+/**
+ * Verify the cosign signature of `ref` (a tag, or better `repo@sha256:...`,
+ * the exact digest about to be pulled).
+ */
+export function verifyImageSignature(
+  ref: string,
+  options: { key?: string; exec?: CosignExec } = {},
+): SignatureCheck {
+  const exec = options.exec ?? cosignExec();
+  let out: string;
+  try {
+    out = exec(cosignVerifyArgs(ref, options.key));
+  } catch (err) {
+    if ((err as NodeJS.ErrnoException).code === "ENOENT") {
+      return { kind: "unavailable", ref, reason: `cosign is not installed (${COSIGN_INSTALL_URL})` };
+    }
+    return { kind: "failed", ref, reason: failureReason(err) };
+  }
+  let subject: string | undefined;
+  let digest: string | undefined;
+  try {
+    const sigs = JSON.parse(out) as Array<{
+      critical?: { image?: { "docker-manifest-digest"?: string } } | null;
+      optional?: { Subject?: string } | null;
+    }>;
+    subject = sigs[0]?.optional?.Subject || undefined;
+    digest = sigs[0]?.critical?.image?.["docker-manifest-digest"] || undefined;
+  } catch {
+    // cosign printed no JSON; the zero exit code is what counts
+  }
+  const signer = subject ?? (options.key ? `key ${options.key}` : "the OwliaBot release workflow");
+  return digest ? { kind: "verified", ref, signer, digest } : { kind: "verified", ref, signer };
+}
+
+/** Warning shown when a signature check didn't pass; nothing was pulled */
+export function formatSignatureWarning(check: Exclude<SignatureCheck, { kind: "verified" }>, key?: string): string[] {
+  const expected = key ? `the key ${key}` : "the OwliaBot release workflow";
+  const lines = [
+    "━━━ SIGNATURE CHECK FAILED ━━━",
+    check.kind === "unavailable"
+      ? `Could not verify ${check.ref}: ${check.reason}`
+      : `${check.ref} is not signed by ${expected}: ${check.reason}`,
+  ];
+  if (check.kind === "failed") {
+    lines.push("It may not come from the OwliaBot publisher, or it was changed after it was published.");
+    if (!key) lines.push("For a custom image, point OWLIABOT_COSIGN_KEY at the public key it was signed with.");
+  }
+  lines.push("Nothing was pulled. Upgrade without --verify-signature only if you trust where the image came from.");
+  return lines;
+}
@@ -0,0 +1,124 @@
+/**
+ * After `docker compose up -d` returns, the container exists but the bot
+ * may still crash on its config or never bind its port. Poll the gateway's
+ * /health until it answers (or ~60s pass) before calling the start a
+ * success, and show the bot's recent logs when it doesn't. Then follow the
+ * startup logs until the channels are up, for the per-subsystem checklist.
+ */
+
+import { readFileSync } from "node:fs";
+import { dirname, resolve } from "node:path";
+import { parse } from "yaml";
+import { botLogs, checklistSettled, setupChecklist, type ChecklistItem } from "../status/checklist.js";
+import { loadSecrets } from "../onboarding/secrets.js";
+import {
+  checkHealth,
+  fetchWithCa,
+  gatewayCa,
+  gatewayToken,
+  healthUrl,
+  type HealthReport,
+  type RawAppConfig,
+} from "../status/index.js";
+import { BOT_SERVICE, dockerExec, type DockerExec } from "./index.js";
+
+export type StartVerification =
+  | { healthy: true; health: HealthReport & { kind: "ok" }; waitedMs: number }
+  | { healthy: false; health: HealthReport; waitedMs: number; logs: string };
+
+export interface VerifyStartOptions {
+  /** app.yaml, for the gateway port, token and TLS CA */
+  configPath?: string;
+  timeoutMs?: number;
+  intervalMs?: number;
+  /** Log lines to capture when the probe fails */
+  logLines?: number;
+  exec?: DockerExec;
+  fetchImpl?: typeof fetch;
+  sleep?: (ms: number) => Promise<void>;
+  now?: () => number;
+  env?: Record<string, string | undefined>;
+}
+
+/** Last `lines` lines of the bot's logs, or why they couldn't be read */
+export function recentBotLogs(composePath: string, exec: DockerExec, lines = 50): string {
+  try {
+    return exec(["compose", "-f", composePath, "logs", "--no-color", "--tail", String(lines), BOT_SERVICE]);
+  } catch (err) {
+    return `(could not read logs: ${(err as Error).message.split("\n")[0]})`;
+  }
+}
+
+/**
+ * Poll /health on the bot's published port until it answers 200 or
+ * `timeoutMs` (default 60s) passes.
+ */
+export async function verifyStarted(composeFile: string, options: VerifyStartOptions = {}): Promise<StartVerification> {
+  const composePath = resolve(composeFile);
+  const exec = options.exec ?? dockerExec(dirname(composePath));
+  const timeoutMs = options.timeoutMs ?? 60_000;
+  const intervalMs = options.intervalMs ?? 2_000;
+  const sleep = options.sleep ?? ((ms: number) => new Promise<void>((r) => setTimeout(r, ms)));
+  const now = options.now ?? Date.now;
+
+  let config: RawAppConfig | null = null;
+  if (options.configPath) {
+    try {
+      config = (parse(readFileSync(options.configPath, "utf-8")) as RawAppConfig | null) ?? null;
+    } catch {
+      config = null;
+    }
+  }
+  const url = healthUrl(config, readFileSync(composePath, "utf-8"));
+  const secrets = options.configPath ? await loadSecrets(options.configPath).catch(() => null) : null;
+  const token = gatewayToken(config, options.env ?? process.env, secrets);
+  const ca = options.configPath ? gatewayCa(config, dirname(resolve(options.configPath))) : undefined;
+  const fetchImpl = options.fetchImpl ?? (ca ? fetchWithCa(ca) : undefined);
+
+  const started = now();
+  for (;;) {
+    const health = await checkHealth(url, fetchImpl, now, token);
+    const waitedMs = now() - started;
+    if (health.kind === "ok") return { healthy: true, health, waitedMs };
+    if (waitedMs >= timeoutMs) {
+      return { healthy: false, health, waitedMs, logs: recentBotLogs(composePath, exec, options.logLines) };
+    }
+    await sleep(intervalMs);
+  }
+}
+
+export interface WatchStartupOptions {
+  /** app.yaml top-level keys, so configured channels show even before they log */
+  configured?: string[];
+  /** How long to follow the logs (default 30s) */
+  seconds?: number;
+  intervalMs?: number;
+  exec?: DockerExec;
+  sleep?: (ms: number) => Promise<void>;
+  now?: () => number;
+}
+
+/**
+ * Re-read the bot's logs until the startup settles (ready, or a subsystem
+ * failed) or `seconds` pass, and return the checklist. null when the logs
+ * can't be read.
+ */
+export async function watchStartup(
+  composeFile: string,
+  options: WatchStartupOptions = {},
+): Promise<ChecklistItem[] | null> {
+  const composePath = resolve(composeFile);
+  const exec = options.exec ?? dockerExec(dirname(composePath));
+  const sleep = options.sleep ?? ((ms: number) => new Promise<void>((r) => setTimeout(r, ms)));
+  const now = options.now ?? Date.now;
+  const seconds = options.seconds ?? 30;
+
+  const started = now();
+  for (;;) {
+    const logs = botLogs(composePath, exec);
+    if (logs === null) return null;
+    const items = setupChecklist(logs, options.configured);
+    if (checklistSettled(items) || now() - started >= seconds * 1000) return items;
+    await sleep(options.intervalMs ?? 2_000);
+  }
+}
@@ -0,0 +1,72 @@
+/**
+ * Scrub credentials out of text before it is shown or logged.
+ *
+ * Output of child processes (docker, compose, openssl, the bot itself) can
+ * echo tokens back: env dumps, failed commands with their arguments, auth
+ * errors quoting the key. Everything that renders such output goes through
+ * redactSecrets() with the values we know (secrets.yaml, wizard answers)
+ * plus the shapes of common credentials.
+ */
+
+const REDACTED = "[REDACTED]";
+
+/** Credential shapes that may show up even when the value isn't known */
+const SECRET_PATTERNS = [
+  /sk-ant-[A-Za-z0-9_-]{10,}/g,
+  /sk-[A-Za-z0-9_-]{16,}/g,
+  /\b\d{6,}:[A-Za-z0-9_-]{30,}\b/g, // Telegram bot token
+  /\b[A-Za-z0-9_-]{20,}\.[A-Za-z0-9_-]{6,}\.[A-Za-z0-9_-]{20,}\b/g, // Discord bot token / JWT
+  /\bxox[abprs]-[A-Za-z0-9-]{10,}/g, // Slack bot/user token
+  /\bxapp-[A-Za-z0-9-]{10,}/g, // Slack app-level token
+  /\bgithub_pat_[A-Za-z0-9_]{20,}/g,
+  /\bgh[pousr]_[A-Za-z0-9]{20,}/g,
+  /\b(AKIA|ASIA)[0-9A-Z]{16}\b/g, // AWS access key ID
+  /(Bearer|Basic)\s+[A-Za-z0-9._~+/=-]{8,}/gi,
+];
+
+/** Shorter values are too likely to match ordinary words */
+const MIN_KNOWN_LENGTH = 6;
+
+/**
+ * Every string of at least 6 characters in a secrets-like value (objects,
+ * arrays and strings, nested).
+ */
+export function collectSecretStrings(...nodes: unknown[]): string[] {
+  const values: string[] = [];
+  const collect = (node: unknown) => {
+    if (typeof node === "string" && node.length >= MIN_KNOWN_LENGTH) values.push(node);
+    else if (node && typeof node === "object") Object.values(node).forEach(collect);
+  };
+  nodes.forEach(collect);
+  return values;
+}
+
+export function redactSecrets(text: string, known: string[] = []): string {
+  let out = text;
+  // Longest first, so a value containing another is replaced whole.
+  for (const value of [...known].sort((a, b) => b.length - a.length)) {
+    if (value.length >= MIN_KNOWN_LENGTH) out = out.split(value).join(REDACTED);
+  }
+  for (const pattern of SECRET_PATTERNS) {
+    out = out.replace(pattern, (match) => (/^(Bearer|Basic)\s/i.test(match) ? `${match.split(/\s+/)[0]} ${REDACTED}` : REDACTED));
+  }
+  return out;
+}
+
+/**
+ * Redact an error's message and stack in place (they usually carry a failed
+ * command's stderr) and return it, for `throw redactError(err, known)`.
+ */
+export function redactError<T>(err: T, known: string[] = []): T {
+  if (err instanceof Error) {
+    err.message = redactSecrets(err.message, known);
+    if (err.stack) err.stack = redactSecrets(err.stack, known);
+    const output = err as Error & { stderr?: unknown; stdout?: unknown };
+    for (const key of ["stderr", "stdout"] as const) {
+      const value = output[key];
+      if (typeof value === "string") output[key] = redactSecrets(value, known);
+      else if (Buffer.isBuffer(value)) output[key] = redactSecrets(value.toString("utf-8"), known);
+    }
+  }
+  return err;
+}